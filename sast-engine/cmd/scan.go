@@ -16,6 +16,7 @@ import (
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/builder"
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/patterns"
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/registry"
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/resolution"
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/docker"
@@ -71,6 +72,11 @@ Examples:
 		diffAware, _ := cmd.Flags().GetBool("diff-aware")
 		baseRef, _ := cmd.Flags().GetString("base")
 		headRef, _ := cmd.Flags().GetString("head")
+		explain, _ := cmd.Flags().GetBool("explain")
+		statusStr, _ := cmd.Flags().GetString("status")
+		baselinePath, _ := cmd.Flags().GetString("baseline")
+		writeBaselinePath, _ := cmd.Flags().GetString("write-baseline")
+		sortBy, _ := cmd.Flags().GetString("sort")
 
 		// Track scan started event (no PII, just metadata)
 		analytics.ReportEventWithProperties(analytics.ScanStarted, map[string]any{
@@ -123,6 +129,26 @@ Examples:
 			}
 		}
 
+		// Parse and validate --status
+		status, err := patterns.ParseMatchStatus(statusStr)
+		if err != nil {
+			return fmt.Errorf("--status: %w", err)
+		}
+
+		// Validate --sort
+		if sortBy != "" && sortBy != "score" {
+			return fmt.Errorf("--sort: invalid value %q, must be score", sortBy)
+		}
+
+		// Load --baseline up front so a malformed file fails before scanning
+		var baseline *output.Baseline
+		if baselinePath != "" {
+			baseline, err = output.LoadBaseline(baselinePath)
+			if err != nil {
+				return fmt.Errorf("--baseline: %w", err)
+			}
+		}
+
 		// Handle remote ruleset downloads and merge with local rules
 		finalRulesPath, tempDir, err := prepareRules(rulesPath, rulesetSpecs, refreshRules, logger)
 		if err != nil {
@@ -343,6 +369,7 @@ Examples:
 
 		// Merge container detections with code analysis detections
 		allEnriched = append(allEnriched, containerDetections...)
+		scoreDetections(allEnriched, cg)
 
 		// Apply diff filter when diff-aware mode is active.
 		if diffAware && len(changedFiles) > 0 {
@@ -352,6 +379,33 @@ Examples:
 			logger.Progress("Diff filter: %d/%d findings in changed files", len(allEnriched), totalBefore)
 		}
 
+		// Keep only confirmed or potential flows when --status is set.
+		if status != "" {
+			totalBefore := len(allEnriched)
+			allEnriched = filterByStatus(allEnriched, status, cg)
+			logger.Progress("Status filter: %d/%d findings are %s", len(allEnriched), totalBefore, status)
+		}
+
+		// Snapshot every current finding before --baseline drops known ones.
+		if writeBaselinePath != "" {
+			if err := output.SaveBaseline(writeBaselinePath, output.DetectionBaseline(allEnriched)); err != nil {
+				return err
+			}
+			logger.Progress("Wrote baseline of %d findings to %s", len(allEnriched), writeBaselinePath)
+		}
+
+		// Report only findings introduced since the baseline.
+		var baselined int
+		if baseline != nil {
+			allEnriched, baselined = output.FilterBaselined(allEnriched, baseline)
+			logger.Progress("Baseline: %d known findings suppressed, %d new", baselined, len(allEnriched))
+		}
+
+		// Rank the riskiest findings first when --sort=score is set.
+		if sortBy == "score" {
+			output.SortByScore(allEnriched)
+		}
+
 		// Step 6: Format and display results
 		// Count unique rule IDs from all detections (includes both code and container rules)
 		uniqueRules := make(map[string]bool)
@@ -384,6 +438,7 @@ Examples:
 		case "text":
 			formatter := output.NewTextFormatter(&output.OutputOptions{
 				Verbosity: verbosity,
+				Explain:   explain,
 			}, logger)
 			if err := formatter.Format(allEnriched, summary); err != nil {
 				return fmt.Errorf("failed to format output: %w", err)
@@ -439,7 +494,15 @@ Examples:
 		}
 
 		// Determine exit code based on findings and --fail-on flag
-		exitCode := output.DetermineExitCode(allEnriched, failOn, scanErrors)
+		// Baselined findings were dropped above; they count as suppressed.
+		gateFindings := output.DetectionGateFindings(allEnriched)
+		for range baselined {
+			gateFindings = append(gateFindings, output.GateFinding{Suppressed: true})
+		}
+		exitCode := output.GateExitCode(gateFindings, failOn, scanErrors)
+		if len(failOn) > 0 {
+			logger.Progress("CI gate %s", output.ExitSummary(gateFindings, failOn))
+		}
 
 		// Track scan completion with results (no PII, just counts and metadata)
 		severityBreakdown := make(map[string]int)
@@ -470,6 +533,41 @@ Examples:
 	},
 }
 
+// filterByStatus returns the detections whose data flow has the given
+// status (see patterns.FlowStatus), in order.
+func filterByStatus(detections []*dsl.EnrichedDetection, status patterns.MatchStatus, cg *core.CallGraph) []*dsl.EnrichedDetection {
+	var filtered []*dsl.EnrichedDetection
+	for _, det := range detections {
+		if patterns.FlowStatus(detectionFlowPath(det.Detection), cg) == status {
+			filtered = append(filtered, det)
+		}
+	}
+	return filtered
+}
+
+// scoreDetections sets the Score of each detection (see output.RiskScore),
+// checking whether an HTTP entrypoint reaches the start of its data flow.
+func scoreDetections(detections []*dsl.EnrichedDetection, cg *core.CallGraph) {
+	for _, det := range detections {
+		source := detectionFlowPath(det.Detection)[0]
+		det.Score = output.RiskScore(det.Rule.Severity, det.Detection.Confidence,
+			patterns.ReachableFromEntrypoint(source, cg))
+	}
+}
+
+// detectionFlowPath returns the functions a detection's taint passes
+// through, from source to sink: its call path for global flows, otherwise
+// the source and sink functions.
+func detectionFlowPath(detection dsl.DataflowDetection) []string {
+	if len(detection.CallPath) > 0 {
+		return detection.CallPath
+	}
+	if detection.SourceFunctionFQN != "" && detection.SourceFunctionFQN != detection.FunctionFQN {
+		return []string{detection.SourceFunctionFQN, detection.FunctionFQN}
+	}
+	return []string{detection.FunctionFQN}
+}
+
 func countTotalCallSites(cg *core.CallGraph) int {
 	total := 0
 	for _, sites := range cg.CallSites {
@@ -589,6 +687,7 @@ func executeContainerRules(
 			},
 			DetectionType: dsl.DetectionTypePattern,
 		}
+		detection.Fingerprint = output.DetectionFingerprint(detection)
 
 		enriched = append(enriched, detection)
 	}
@@ -1067,6 +1166,11 @@ func init() {
 	scanCmd.Flags().Bool("diff-aware", false, "Enable diff-aware scanning (only report findings in changed files)")
 	scanCmd.Flags().String("base", "", "Base git ref for diff-aware scanning (required with --diff-aware)")
 	scanCmd.Flags().String("head", "HEAD", "Head git ref for diff-aware scanning")
+	scanCmd.Flags().Bool("explain", false, "Show each step of taint flows from source to sink (text output)")
+	scanCmd.Flags().String("baseline", "", "Suppress findings recorded in this baseline file and report only new ones")
+	scanCmd.Flags().String("write-baseline", "", "Write the current findings to this baseline file (see --baseline)")
+	scanCmd.Flags().String("status", "", "Only report flows with this status: confirmed (every call resolved with high confidence) or potential")
+	scanCmd.Flags().String("sort", "", "Order findings: score (riskiest first, see the score field of JSON and SARIF output)")
 	scanCmd.Flags().Bool("enable-db-cache", false, "Enable SQLite-backed incremental analysis cache (experimental)")
 	scanCmd.MarkFlagRequired("project")
 }
@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/shivasurya/code-pathfinder/sast-engine/dsl"
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/patterns"
 	"github.com/shivasurya/code-pathfinder/sast-engine/output"
 	"github.com/shivasurya/code-pathfinder/sast-engine/ruleset"
 	"github.com/stretchr/testify/assert"
@@ -57,6 +59,52 @@ func TestCountTotalCallSites(t *testing.T) {
 	})
 }
 
+func TestFilterByStatus(t *testing.T) {
+	cg := core.NewCallGraph()
+	cg.AddCallSite("app.view", core.CallSite{Target: "run", TargetFQN: "app.run", Resolved: true})
+	cg.AddCallSite("app.view", core.CallSite{
+		Target: "worker.guess", TargetFQN: "app.guess", Resolved: true,
+		ResolvedViaTypeInference: true, TypeConfidence: 0.5,
+	})
+
+	local := &dsl.EnrichedDetection{Detection: dsl.DataflowDetection{FunctionFQN: "app.view", Scope: "local"}}
+	resolved := &dsl.EnrichedDetection{Detection: dsl.DataflowDetection{
+		FunctionFQN: "app.run", SourceFunctionFQN: "app.view", Scope: "global",
+	}}
+	guessed := &dsl.EnrichedDetection{Detection: dsl.DataflowDetection{
+		FunctionFQN: "app.guess", SourceFunctionFQN: "app.view", Scope: "global",
+		CallPath: []string{"app.view", "app.guess"},
+	}}
+	detections := []*dsl.EnrichedDetection{local, resolved, guessed}
+
+	assert.Equal(t, []*dsl.EnrichedDetection{local, resolved}, filterByStatus(detections, patterns.MatchStatusConfirmed, cg))
+	assert.Equal(t, []*dsl.EnrichedDetection{guessed}, filterByStatus(detections, patterns.MatchStatusPotential, cg))
+}
+
+func TestScoreDetections(t *testing.T) {
+	cg := core.NewCallGraph()
+	cg.AddFunction("app.views.search", &graph.Node{Name: "search", Routes: []graph.Route{{Method: "GET", Path: "/search"}}})
+	cg.AddFunction("app.db.query", &graph.Node{Name: "query"})
+	cg.AddFunction("scripts.backfill.run", &graph.Node{Name: "run"})
+	cg.AddEdge("app.views.search", "app.db.query")
+
+	internal := &dsl.EnrichedDetection{
+		Detection: dsl.DataflowDetection{FunctionFQN: "scripts.backfill.run", Confidence: 1.0},
+		Rule:      dsl.RuleMetadata{Severity: "low"},
+	}
+	route := &dsl.EnrichedDetection{
+		Detection: dsl.DataflowDetection{FunctionFQN: "app.db.query", SourceFunctionFQN: "app.views.search", Confidence: 0.8},
+		Rule:      dsl.RuleMetadata{Severity: "critical"},
+	}
+	detections := []*dsl.EnrichedDetection{internal, route}
+	scoreDetections(detections, cg)
+
+	assert.InDelta(t, 0.125, internal.Score, 1e-9)
+	assert.InDelta(t, 0.8, route.Score, 1e-9)
+	output.SortByScore(detections)
+	assert.Equal(t, []*dsl.EnrichedDetection{route, internal}, detections)
+}
+
 func TestPrintDetections(t *testing.T) {
 	t.Run("prints detections with all fields", func(t *testing.T) {
 		// Capture stdout
@@ -315,6 +363,20 @@ func TestScanCommandOutputFormats(t *testing.T) {
 		require.NotNil(t, flag, "fail-on flag should be registered")
 	})
 
+	t.Run("scan command has explain flag", func(t *testing.T) {
+		flag := scanCmd.Flags().Lookup("explain")
+		require.NotNil(t, flag, "explain flag should be registered")
+		assert.Equal(t, "false", flag.DefValue)
+	})
+
+	for _, name := range []string{"status", "baseline", "write-baseline"} {
+		t.Run("scan command has "+name+" flag", func(t *testing.T) {
+			flag := scanCmd.Flags().Lookup(name)
+			require.NotNil(t, flag, "%s flag should be registered", name)
+			assert.Equal(t, "", flag.DefValue)
+		})
+	}
+
 	t.Run("output format validation", func(t *testing.T) {
 		// Valid formats
 		validFormats := []string{"text", "json", "sarif", "csv"}
@@ -617,6 +679,9 @@ func TestScanCmdValidation(t *testing.T) {
 		scanCmd.Flags().Set("diff-aware", "false")
 		scanCmd.Flags().Set("base", "")
 		scanCmd.Flags().Set("head", "HEAD")
+		scanCmd.Flags().Set("status", "")
+		scanCmd.Flags().Set("baseline", "")
+		scanCmd.Flags().Set("sort", "")
 	}
 
 	t.Run("missing rules and ruleset returns error", func(t *testing.T) {
@@ -646,6 +711,38 @@ func TestScanCmdValidation(t *testing.T) {
 		assert.Contains(t, err.Error(), "--output must be")
 	})
 
+	t.Run("invalid status returns error", func(t *testing.T) {
+		resetFlags()
+		scanCmd.Flags().Set("rules", "/tmp/test-rules.py")
+		scanCmd.Flags().Set("project", t.TempDir())
+		scanCmd.Flags().Set("status", "likely")
+		err := scanCmd.RunE(scanCmd, []string{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `--status: invalid status "likely"`)
+	})
+
+	t.Run("invalid sort returns error", func(t *testing.T) {
+		resetFlags()
+		scanCmd.Flags().Set("rules", "/tmp/test-rules.py")
+		scanCmd.Flags().Set("project", t.TempDir())
+		scanCmd.Flags().Set("sort", "severity")
+		err := scanCmd.RunE(scanCmd, []string{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `--sort: invalid value "severity"`)
+	})
+
+	t.Run("malformed baseline returns error", func(t *testing.T) {
+		resetFlags()
+		baselinePath := filepath.Join(t.TempDir(), "baseline.json")
+		require.NoError(t, os.WriteFile(baselinePath, []byte(`{"version": 1, "findings": [`), 0o644))
+		scanCmd.Flags().Set("rules", "/tmp/test-rules.py")
+		scanCmd.Flags().Set("project", t.TempDir())
+		scanCmd.Flags().Set("baseline", baselinePath)
+		err := scanCmd.RunE(scanCmd, []string{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--baseline: malformed baseline")
+	})
+
 	t.Run("diff-aware without base returns error", func(t *testing.T) {
 		resetFlags()
 		scanCmd.Flags().Set("rules", "/tmp/test-rules.py")
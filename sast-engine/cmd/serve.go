@@ -14,6 +14,7 @@ import (
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/registry"
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/resolution"
 	"github.com/shivasurya/code-pathfinder/sast-engine/mcp"
+	mcphttp "github.com/shivasurya/code-pathfinder/sast-engine/mcp/http"
 	"github.com/shivasurya/code-pathfinder/sast-engine/output"
 	"github.com/spf13/cobra"
 )
@@ -31,7 +32,8 @@ about symbols, call graphs, and code relationships.
 
 Transport modes:
   - stdio (default): Standard input/output for direct integration
-  - http: HTTP server for network access`,
+  - http: HTTP server for network access
+  - http --rest: the tools as a JSON REST API (POST /tool/{name})`,
 	RunE: runServe,
 }
 
@@ -41,6 +43,7 @@ func init() {
 	serveCmd.Flags().String("python-version", "", "Python version override (auto-detected from .python-version or pyproject.toml)")
 	serveCmd.Flags().Bool("http", false, "Use HTTP transport instead of stdio")
 	serveCmd.Flags().String("address", ":8080", "HTTP server address (only with --http)")
+	serveCmd.Flags().Bool("rest", false, "Serve the tools as a JSON REST API instead of JSON-RPC (only with --http)")
 }
 
 func runServe(cmd *cobra.Command, _ []string) error {
@@ -48,6 +51,7 @@ func runServe(cmd *cobra.Command, _ []string) error {
 	pythonVersionOverride, _ := cmd.Flags().GetString("python-version")
 	useHTTP, _ := cmd.Flags().GetBool("http")
 	address, _ := cmd.Flags().GetString("address")
+	useREST, _ := cmd.Flags().GetBool("rest")
 	disableAnalytics, _ := cmd.Flags().GetBool("disable-metrics")
 
 	// Auto-detect Python version
@@ -138,6 +142,9 @@ func runServe(cmd *cobra.Command, _ []string) error {
 	// Start serving immediately (before indexing completes)
 	fmt.Fprintln(os.Stderr, "MCP server ready (indexing in background)...")
 
+	if useHTTP && useREST {
+		return runRESTServer(server, address)
+	}
 	if useHTTP {
 		return runHTTPServer(server, address)
 	}
@@ -178,3 +185,32 @@ func runHTTPServer(mcpServer *mcp.Server, address string) error {
 		return httpServer.Shutdown(ctx)
 	}
 }
+
+func runRESTServer(mcpServer *mcp.Server, address string) error {
+	// Set transport type for analytics.
+	mcpServer.SetTransport("http")
+
+	config := mcp.DefaultHTTPConfig()
+	config.Address = address
+	httpServer := mcphttp.NewServer(mcpServer, config)
+
+	// Handle graceful shutdown.
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+	errChan := make(chan error, 1)
+	go func() {
+		fmt.Fprintf(os.Stderr, "REST API listening on %s\n", address)
+		errChan <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errChan:
+		return err
+	case sig := <-sigChan:
+		fmt.Fprintf(os.Stderr, "\nReceived %v, shutting down...\n", sig)
+		ctx, cancel := context.WithTimeout(context.Background(), config.ShutdownTimeout)
+		defer cancel()
+		return httpServer.Shutdown(ctx)
+	}
+}
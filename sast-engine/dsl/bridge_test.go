@@ -1,6 +1,7 @@
 package dsl
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/analysis/taint"
@@ -414,6 +415,9 @@ func TestExecuteGlobal_WithSummaries(t *testing.T) {
 			if d.MatchMethod != "interprocedural_vdg" {
 				t.Errorf("expected MatchMethod 'interprocedural_vdg', got %q", d.MatchMethod)
 			}
+			if !slices.Equal(d.CallPath, []string{funcA, funcB}) {
+				t.Errorf("expected CallPath [%s %s], got %v", funcA, funcB, d.CallPath)
+			}
 		}
 	}
 	if globalCount == 0 {
@@ -295,6 +295,7 @@ func (e *DataflowExecutor) executeGlobal() []DataflowDetection {
 				Sanitized:         false,
 				Scope:             "global",
 				MatchMethod:       "interprocedural_vdg",
+				CallPath:          path,
 			})
 		}
 	}
@@ -27,6 +27,13 @@ type EnrichedDetection struct {
 	// Detection classification
 	DetectionType DetectionType
 
+	// Fingerprint identifies the finding across scans, independent of line
+	// numbers (see output.DetectionFingerprint).
+	Fingerprint string
+
+	// Score ranks the finding for triage, 0.0-1.0 (see output.RiskScore).
+	Score float64
+
 	// Config for confidence level thresholds (nil → defaults).
 	Config *QueryTypeConfig
 }
@@ -151,6 +151,10 @@ type DataflowDetection struct {
 	MatchedCallSite *core.CallSite  // Internal: matched call site for DataflowExecutor use
 	MatchMethod     string          // How the match was made: "type_inference", "fqn_bridge", "fqn_prefix", "name_fallback"
 
+	// CallPath is the chain of functions taint passes through, from the
+	// source function to the sink function. Set for global detections only.
+	CallPath []string `json:"callPath,omitempty"`
+
 	// SinkParamIndex is the positional index of the tainted sink parameter.
 	// nil when parameter position could not be determined.
 	SinkParamIndex *int `json:"sinkParamIndex,omitempty"`
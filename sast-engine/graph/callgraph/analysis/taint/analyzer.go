@@ -60,6 +60,21 @@ func AnalyzeIntraProceduralTaint(
 	sources []string,
 	sinks []string,
 	sanitizers []string,
+) *core.TaintSummary {
+	return AnalyzeIntraProceduralTaintWithPropagators(functionFQN, statements, defUseChain, sources, sinks, sanitizers, nil)
+}
+
+// AnalyzeIntraProceduralTaintWithPropagators is AnalyzeIntraProceduralTaint
+// with custom propagators deciding which calls carry taint to their result.
+// With no propagators, any tainted argument taints a call's result.
+func AnalyzeIntraProceduralTaintWithPropagators(
+	functionFQN string,
+	statements []*core.Statement,
+	defUseChain *core.DefUseChain,
+	sources []string,
+	sinks []string,
+	sanitizers []string,
+	propagators []Propagator,
 ) *core.TaintSummary {
 	taintState := NewTaintState()
 	summary := core.NewTaintSummary(functionFQN)
@@ -88,14 +103,29 @@ func AnalyzeIntraProceduralTaint(
 			continue
 		}
 
-		// Handle ASSIGNMENT propagation
-		if stmt.Type == core.StatementTypeAssignment {
-			propagateAssignment(stmt, taintState, summary)
+		// A guarded conversion either raised, leaving the function, or
+		// narrowed the value to a number or UUID, which cannot carry an
+		// injection payload. The string it was converted from stays tainted.
+		if isGuardedConversion(stmt) {
+			taintState.SetUntainted(stmt.Def)
+			continue
 		}
 
-		// Handle CALL propagation
-		if stmt.Type == core.StatementTypeCall || stmt.CallTarget != "" {
-			propagateCall(stmt, taintState, summary)
+		if len(propagators) > 0 && stmt.CallTarget != "" && stmt.Def != "" {
+			// Configured propagators decide call results
+			applyPropagators(stmt, propagators, taintState, summary)
+		} else {
+			// Handle ASSIGNMENT propagation
+			if stmt.Type == core.StatementTypeAssignment {
+				propagateAssignment(stmt, taintState, summary)
+			}
+
+			// Handle CALL propagation. The RHS of a plain copy (b = a) is
+			// recorded as its CallTarget but is not a call, so it keeps the
+			// confidence propagateAssignment gave it.
+			if stmt.Type == core.StatementTypeCall || (stmt.CallTarget != "" && !isVariableCopy(stmt)) {
+				propagateCall(stmt, taintState, summary)
+			}
 		}
 
 		// Check if this is a SINK
@@ -121,6 +151,8 @@ func AnalyzeIntraProceduralTaint(
 }
 
 // propagateAssignment propagates taint through assignments: y = x.
+// An assignment from clean values kills any earlier taint on the LHS, so
+// x = source(); x = "const"; sink(x) is clean.
 func propagateAssignment(stmt *core.Statement, taintState *TaintState, summary *core.TaintSummary) {
 	if stmt.Def == "" {
 		return
@@ -141,6 +173,9 @@ func propagateAssignment(stmt *core.Statement, taintState *TaintState, summary *
 			return
 		}
 	}
+
+	// Reassignment to a clean value kills prior taint
+	taintState.SetUntainted(stmt.Def)
 }
 
 // propagateCall propagates taint through function calls: y = func(x).
@@ -182,6 +217,11 @@ func propagateCall(stmt *core.Statement, taintState *TaintState, summary *core.T
 	})
 }
 
+// isVariableCopy reports whether stmt assigns one variable to another: b = a.
+func isVariableCopy(stmt *core.Statement) bool {
+	return stmt.Type == core.StatementTypeAssignment && len(stmt.Uses) == 1 && stmt.CallTarget == stmt.Uses[0]
+}
+
 // handleSanitizer handles sanitizer calls (removes taint).
 func handleSanitizer(stmt *core.Statement, taintState *TaintState) {
 	if stmt.Def != "" {
@@ -190,6 +230,8 @@ func handleSanitizer(stmt *core.Statement, taintState *TaintState) {
 }
 
 // isSource checks if statement is a taint source.
+// Besides call targets, dotted sources (e.g., "request.FILES") also match the
+// statement's attribute access, so indexing like request.FILES["f"] is tainted.
 func isSource(stmt *core.Statement, sources []string) bool {
 	if stmt.CallTarget == "" {
 		return false
@@ -199,6 +241,10 @@ func isSource(stmt *core.Statement, sources []string) bool {
 		if matchesFunctionName(stmt.CallTarget, source) {
 			return true
 		}
+		if stmt.AttributeAccess != "" && strings.Contains(source, ".") &&
+			matchesFunctionName(stmt.AttributeAccess, source) {
+			return true
+		}
 	}
 
 	// Check hardcoded stdlib sources
@@ -215,6 +261,11 @@ func isSink(stmt *core.Statement, sinks []string) bool {
 		if matchesFunctionName(stmt.CallTarget, sink) {
 			return true
 		}
+		// Qualified sinks (subprocess.run) match the full call chain
+		if stmt.CallChain != "" && strings.Contains(sink, ".") &&
+			matchesFunctionName(stmt.CallChain, sink) {
+			return true
+		}
 	}
 
 	return false
@@ -236,9 +287,30 @@ func isSanitizer(stmt *core.Statement, sanitizers []string) bool {
 	return isStdlibSanitizer(stmt.CallTarget)
 }
 
-// Hardcoded stdlib sources (Tier 2).
+// GuardedConversions are conversions that either raise or return a number
+// or UUID. When guarded by a try whose handlers leave the function (see
+// core.Statement.Guarded), their result is not tainted.
+var GuardedConversions = []string{"int", "float", "uuid.UUID"}
+
+// isGuardedConversion checks if statement assigns the result of one of
+// GuardedConversions inside a guarded try body, e.g.
+// "try: n = int(value) except ValueError: return".
+func isGuardedConversion(stmt *core.Statement) bool {
+	if !stmt.Guarded || stmt.Def == "" || stmt.CallTarget == "" {
+		return false
+	}
+	for _, conversion := range GuardedConversions {
+		if stmt.CallChain == conversion || stmt.CallTarget == conversion ||
+			(strings.Contains(conversion, ".") && matchesFunctionName(conversion, stmt.CallTarget)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Hardcoded stdlib sources (Tier 2). Environment variables are trusted
+// unless a pattern lists them as sources.
 var stdlibSources = map[string][]string{
-	"os":     {"getenv", "environ"},
 	"sys":    {"argv"},
 	"socket": {"recv", "recvfrom", "recvmsg"},
 }
@@ -314,10 +386,11 @@ func splitModuleFunction(callTarget string) (module, function string) {
 // Supports exact matches, suffix matches (e.g., "builtins.eval" matches "eval"),
 // and handles parentheses (e.g., "input()" matches "input").
 func matchesFunctionName(callTarget, pattern string) bool {
-	// Strip parentheses from call target if present
+	// Strip parentheses, or the subscript of an index access
+	// (os.environ["X"]), from call target if present
 	cleanTarget := callTarget
-	if before, _, ok := strings.Cut(callTarget, "("); ok {
-		cleanTarget = before
+	if i := strings.IndexAny(callTarget, "(["); i >= 0 {
+		cleanTarget = callTarget[:i]
 	}
 
 	// Exact match: "eval" == "eval"
@@ -5,6 +5,7 @@ import (
 
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 //
@@ -342,8 +343,8 @@ func TestIsStdlibSource(t *testing.T) {
 		target   string
 		expected bool
 	}{
-		{"os.getenv", "os.getenv", true},
-		{"os.environ", "os.environ", true},
+		{"os.getenv", "os.getenv", false},
+		{"os.environ", "os.environ", false},
 		{"sys.argv", "sys.argv", true},
 		{"socket.recv", "socket.recv", true},
 		{"os.path.join", "os.path.join", false},
@@ -425,3 +426,124 @@ func TestSplitModuleFunction(t *testing.T) {
 		})
 	}
 }
+
+func TestIsSource_AttributeAccess(t *testing.T) {
+	// x = request.FILES["f"]
+	subscript := &core.Statement{
+		Type:            core.StatementTypeAssignment,
+		Def:             "x",
+		CallTarget:      `request.FILES["f"]`,
+		AttributeAccess: "request.FILES",
+	}
+	assert.True(t, isSource(subscript, []string{"request.FILES"}))
+	assert.False(t, isSource(subscript, []string{"request.GET"}))
+
+	// Undotted sources only match call targets, not attribute names.
+	attr := &core.Statement{
+		Type:            core.StatementTypeAssignment,
+		Def:             "y",
+		CallTarget:      "self.input",
+		AttributeAccess: "self.input",
+	}
+	assert.False(t, isSource(attr, []string{"request.input"}))
+}
+
+func TestAnalyzeIntraProceduralTaint_ReassignmentKillsAndRegenerates(t *testing.T) {
+	source := &core.Statement{LineNumber: 1, Type: core.StatementTypeAssignment, Def: "x", CallTarget: "input"}
+	constant := &core.Statement{LineNumber: 2, Type: core.StatementTypeAssignment, Def: "x", CallTarget: ""}
+	sink := &core.Statement{LineNumber: 3, Type: core.StatementTypeCall, Uses: []string{"x"}, CallTarget: "eval"}
+
+	analyze := func(statements ...*core.Statement) *core.TaintSummary {
+		return AnalyzeIntraProceduralTaint("test.func", statements, core.BuildDefUseChains(statements),
+			[]string{"input"}, []string{"eval"}, []string{})
+	}
+
+	// x = input(); x = "const"; eval(x)
+	assert.False(t, analyze(source, constant, sink).HasDetections())
+
+	// x = "const"; x = input(); eval(x)
+	reordered := analyze(constant, source, sink)
+	require.True(t, reordered.HasDetections())
+	assert.Equal(t, uint32(3), reordered.Detections[0].SinkLine)
+
+	// y = x propagates; y = "const" then kills only y
+	propagate := &core.Statement{LineNumber: 2, Type: core.StatementTypeAssignment, Def: "y", Uses: []string{"x"}}
+	killY := &core.Statement{LineNumber: 3, Type: core.StatementTypeAssignment, Def: "y"}
+	sinkY := &core.Statement{LineNumber: 4, Type: core.StatementTypeCall, Uses: []string{"y"}, CallTarget: "eval"}
+	sinkX := &core.Statement{LineNumber: 5, Type: core.StatementTypeCall, Uses: []string{"x"}, CallTarget: "eval"}
+	summary := analyze(source, propagate, killY, sinkY, sinkX)
+	require.Len(t, summary.Detections, 1)
+	assert.Equal(t, uint32(5), summary.Detections[0].SinkLine)
+}
+
+func TestAnalyzeIntraProceduralTaint_AliasSurvivesReassignment(t *testing.T) {
+	analyze := func(statements ...*core.Statement) *core.TaintSummary {
+		return AnalyzeIntraProceduralTaint("test.func", statements, core.BuildDefUseChains(statements),
+			[]string{"source"}, []string{"sink"}, []string{})
+	}
+	source := &core.Statement{LineNumber: 1, Type: core.StatementTypeAssignment, Def: "a", CallTarget: "source"}
+	clean := &core.Statement{LineNumber: 3, Type: core.StatementTypeAssignment, Def: "a", CallTarget: `"clean"`}
+	sink := &core.Statement{LineNumber: 4, Type: core.StatementTypeCall, Uses: []string{"b"}, CallTarget: "sink"}
+
+	// a = source(); b = a; a = "clean"; sink(b)
+	alias := &core.Statement{LineNumber: 2, Type: core.StatementTypeAssignment, Def: "b", Uses: []string{"a"}, CallTarget: "a"}
+	summary := analyze(source, alias, clean, sink)
+	require.Len(t, summary.Detections, 1)
+	assert.Equal(t, "b", summary.Detections[0].SourceVar)
+	assert.Equal(t, 1.0, summary.Detections[0].Confidence, "a plain copy does not decay like a call")
+
+	// a = source(); b += a; a = "clean"; sink(b)
+	augmented := &core.Statement{LineNumber: 2, Type: core.StatementTypeAssignment, Def: "b", Uses: []string{"b", "a"}}
+	summary = analyze(source, augmented, clean, sink)
+	require.Len(t, summary.Detections, 1)
+	assert.Equal(t, uint32(1), summary.Detections[0].SourceLine)
+
+	// b = a.strip() is a call, so it still decays
+	call := &core.Statement{LineNumber: 2, Type: core.StatementTypeAssignment, Def: "b", Uses: []string{"a"}, CallTarget: "a.strip"}
+	summary = analyze(source, call, clean, sink)
+	require.Len(t, summary.Detections, 1)
+	assert.InDelta(t, 0.7, summary.Detections[0].Confidence, 1e-9)
+}
+
+func TestAnalyzeIntraProceduralTaint_GuardedConversion(t *testing.T) {
+	// x = source(); try: n = int(x) except ValueError: return; sink(n); sink(x)
+	statements := func(guarded bool) []*core.Statement {
+		return []*core.Statement{
+			{LineNumber: 1, Type: core.StatementTypeAssignment, Def: "x", CallTarget: "source"},
+			{LineNumber: 3, Type: core.StatementTypeAssignment, Def: "n", Uses: []string{"x"}, CallTarget: "int", CallChain: "int", Guarded: guarded},
+			{LineNumber: 6, Type: core.StatementTypeCall, Uses: []string{"n"}, CallTarget: "sink"},
+			{LineNumber: 7, Type: core.StatementTypeCall, Uses: []string{"x"}, CallTarget: "sink"},
+		}
+	}
+	sinkLines := func(summary *core.TaintSummary) []uint32 {
+		var lines []uint32
+		for _, detection := range summary.Detections {
+			lines = append(lines, detection.SinkLine)
+		}
+		return lines
+	}
+
+	guarded := AnalyzeIntraProceduralTaint("test.func", statements(true), nil, []string{"source"}, []string{"sink"}, nil)
+	assert.Equal(t, []uint32{7}, sinkLines(guarded), "the converted value is clean, the input string is not")
+
+	unguarded := AnalyzeIntraProceduralTaint("test.func", statements(false), nil, []string{"source"}, []string{"sink"}, nil)
+	assert.Equal(t, []uint32{6, 7}, sinkLines(unguarded))
+}
+
+func TestIsGuardedConversion(t *testing.T) {
+	tests := []struct {
+		stmt core.Statement
+		want bool
+	}{
+		{core.Statement{Def: "n", CallTarget: "int", CallChain: "int", Guarded: true}, true},
+		{core.Statement{Def: "f", CallTarget: "float", CallChain: "float", Guarded: true}, true},
+		{core.Statement{Def: "k", CallTarget: "UUID", CallChain: "uuid.UUID", Guarded: true}, true},
+		{core.Statement{Def: "k", CallTarget: "UUID", CallChain: "UUID", Guarded: true}, true},
+		{core.Statement{Def: "n", CallTarget: "int", CallChain: "int"}, false},
+		{core.Statement{Def: "s", CallTarget: "str", CallChain: "str", Guarded: true}, false},
+		{core.Statement{CallTarget: "int", CallChain: "int", Guarded: true}, false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, isGuardedConversion(&tt.stmt), "%+v", tt.stmt)
+	}
+}
@@ -0,0 +1,89 @@
+package taint
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TrustedBoundary names the functions after which data is considered clean,
+// such as a project's validation layer. A value returned by a trusted
+// function is untainted whatever its arguments were, unlike a sanitizer,
+// which is matched by call name and clears only the value it is applied to.
+//
+// Trust covers return values only: a trusted function that passes a
+// parameter to a sink inside its body is still reported.
+type TrustedBoundary struct {
+	Functions []string `yaml:"functions"` // Function FQNs, e.g. "app.forms.clean_email"
+	Modules   []string `yaml:"modules"`   // Modules whose functions are all trusted, e.g. "app.validators"
+}
+
+// trustedBoundaryConfig is the YAML config for a trusted boundary:
+//
+//	trusted_boundary:
+//	  modules:
+//	    - app.validators
+//	  functions:
+//	    - app.forms.clean_email
+type trustedBoundaryConfig struct {
+	TrustedBoundary TrustedBoundary `yaml:"trusted_boundary"`
+}
+
+// LoadTrustedBoundary parses a trusted boundary from a YAML config.
+func LoadTrustedBoundary(data []byte) (*TrustedBoundary, error) {
+	var config trustedBoundaryConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse trusted boundary config: %w", err)
+	}
+	for _, name := range append(config.TrustedBoundary.Functions, config.TrustedBoundary.Modules...) {
+		if strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("trusted boundary has an empty entry")
+		}
+	}
+	return &config.TrustedBoundary, nil
+}
+
+// Trusts reports whether fqn is a trusted function: listed in Functions, or
+// defined in one of Modules or their submodules (methods included). A nil
+// boundary trusts nothing.
+func (b *TrustedBoundary) Trusts(fqn string) bool {
+	if b == nil || fqn == "" {
+		return false
+	}
+	for _, function := range b.Functions {
+		if fqn == function {
+			return true
+		}
+	}
+	for _, module := range b.Modules {
+		if strings.HasPrefix(fqn, module+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// trust rewrites a trusted function's summary so its return value carries
+// no taint, from its parameters or from sources it reads.
+func (b *TrustedBoundary) trust(ts *TaintTransferSummary) {
+	clear(ts.ParamToReturn)
+	ts.IsSource = false
+	ts.ReturnTaintedBySource = false
+	ts.IsSanitizer = true
+}
+
+// trustedSummary is the summary of a trusted function the call graph has
+// no body for, such as a library validator.
+func trustedSummary(fqn string) *TaintTransferSummary {
+	return &TaintTransferSummary{
+		FunctionFQN:       fqn,
+		ParamToReturn:     make(map[int]bool),
+		ParamToSink:       make(map[int]bool),
+		ParamToSinkLine:   make(map[int]uint32),
+		ParamToSinkCall:   make(map[int]string),
+		ParamToSinkCallee: make(map[int]string),
+		ParamToSinkArg:    make(map[int]int),
+		IsSanitizer:       true,
+	}
+}
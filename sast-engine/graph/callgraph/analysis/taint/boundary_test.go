@@ -0,0 +1,86 @@
+package taint
+
+import (
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadTrustedBoundary(t *testing.T) {
+	boundary, err := LoadTrustedBoundary([]byte(`
+trusted_boundary:
+  modules:
+    - app.validators
+  functions:
+    - app.forms.clean_email
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app.validators"}, boundary.Modules)
+	assert.Equal(t, []string{"app.forms.clean_email"}, boundary.Functions)
+
+	_, err = LoadTrustedBoundary([]byte("trusted_boundary: ["))
+	assert.Error(t, err)
+
+	_, err = LoadTrustedBoundary([]byte("trusted_boundary:\n  modules:\n    - \"\"\n"))
+	assert.Error(t, err)
+}
+
+func TestTrustedBoundary_Trusts(t *testing.T) {
+	boundary := &TrustedBoundary{
+		Functions: []string{"app.forms.clean_email"},
+		Modules:   []string{"app.validators"},
+	}
+
+	assert.True(t, boundary.Trusts("app.forms.clean_email"))
+	assert.True(t, boundary.Trusts("app.validators.clean_path"))
+	assert.True(t, boundary.Trusts("app.validators.net.Host.clean"))
+	assert.False(t, boundary.Trusts("app.forms.clean_name"))
+	assert.False(t, boundary.Trusts("app.validators_extra.clean"))
+	assert.False(t, boundary.Trusts(""))
+
+	var none *TrustedBoundary
+	assert.False(t, none.Trusts("app.validators.clean_path"))
+}
+
+// boundaryTestGraph models:
+//
+//	def view():
+//	    cmd = input()
+//	    safe = validate(cmd)   # library function, not in the call graph
+//	    os.system(safe)
+func boundaryTestGraph() *core.CallGraph {
+	cg := core.NewCallGraph()
+	cg.Functions["app.view"] = &graph.Node{Name: "app.view"}
+	cg.Statements["app.view"] = []*core.Statement{
+		{Type: core.StatementTypeAssignment, LineNumber: 2, Def: "cmd", CallTarget: "input"},
+		{Type: core.StatementTypeAssignment, LineNumber: 3, Def: "safe", CallTarget: "validate", Uses: []string{"cmd"}},
+		{Type: core.StatementTypeCall, LineNumber: 4, CallTarget: "os.system", Uses: []string{"safe"}},
+	}
+	cg.AddCallSite("app.view", core.CallSite{Target: "input", TargetFQN: "builtins.input", Resolved: true, Location: core.Location{Line: 2}})
+	cg.AddCallSite("app.view", core.CallSite{
+		Target: "validate", TargetFQN: "lib.checks.validate", Resolved: true, Location: core.Location{Line: 3},
+		Arguments: []core.Argument{{Value: "cmd", IsVariable: true}},
+	})
+	cg.AddCallSite("app.view", core.CallSite{
+		Target: "os.system", TargetFQN: "os.system", Resolved: true, Location: core.Location{Line: 4},
+		Arguments: []core.Argument{{Value: "safe", IsVariable: true}},
+	})
+	return cg
+}
+
+func TestAnalyzeReachableSinksWithBoundary_LibraryFunction(t *testing.T) {
+	cg := boundaryTestGraph()
+	sources, sinks := []string{"input"}, []string{"os.system"}
+
+	require.Len(t, AnalyzeReachableSinks(cg, sources, sinks), 1)
+
+	boundary := &TrustedBoundary{Functions: []string{"lib.checks.validate"}}
+	assert.Empty(t, AnalyzeReachableSinksWithBoundary(cg, sources, sinks, boundary))
+
+	summaries := BuildTransferSummariesWithBoundary(cg, sources, sinks, nil, boundary)
+	require.Contains(t, summaries, "lib.checks.validate")
+	assert.True(t, summaries["lib.checks.validate"].IsSanitizer)
+}
@@ -0,0 +1,137 @@
+package taint
+
+import (
+	"slices"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// CoveredSink is a sink call that taint reaches in an analyzed call graph.
+type CoveredSink struct {
+	FunctionFQN string   // Function calling the sink (e.g., "app.views.search")
+	SinkFQN     string   // Resolved sink call (e.g., "builtins.eval")
+	Sources     []string // Sorted sources reaching it (e.g., "request.GET")
+}
+
+// SourceChange is a sink reached in both graphs by different sources.
+type SourceChange struct {
+	FunctionFQN string   // Function calling the sink
+	SinkFQN     string   // Resolved sink call
+	Added       []string // Sources reaching the sink only in the second graph
+	Removed     []string // Sources reaching the sink only in the first graph
+}
+
+// CoverageDiff is the difference in taint coverage between two analyzed
+// call graphs. Each list is sorted by function, then sink.
+type CoverageDiff struct {
+	NewlyReachable   []CoveredSink  // Sinks reached only in the second graph
+	NewlyUnreachable []CoveredSink  // Sinks reached only in the first graph
+	ChangedSources   []SourceChange // Sinks reached in both, by different sources
+}
+
+// IsEmpty reports whether both graphs have the same taint coverage.
+func (d CoverageDiff) IsEmpty() bool {
+	return len(d.NewlyReachable) == 0 && len(d.NewlyUnreachable) == 0 && len(d.ChangedSources) == 0
+}
+
+// CompareCoverage compares the taint detections recorded in the summaries
+// of two analyzed call graphs, such as the same project analyzed before and
+// after a rule change. The graphs' Summaries must hold the detections of
+// the rules being compared, e.g. from AnalyzeIntraProceduralTaint. Where
+// patterns.DiffFindings compares individual findings, this compares which
+// sinks are reachable at all and from where.
+//
+// A sink is identified by the function calling it and its resolved FQN, not
+// its line, so edits elsewhere in a file do not register as coverage
+// changes. Several calls to the same sink in one function count as one.
+// Sanitized detections are not coverage.
+func CompareCoverage(a, b *core.CallGraph) CoverageDiff {
+	before, after := coveredSinks(a), coveredSinks(b)
+
+	var diff CoverageDiff
+	for _, key := range sortedKeys(after) {
+		sink, ok := before[key]
+		if !ok {
+			diff.NewlyReachable = append(diff.NewlyReachable, *after[key])
+			continue
+		}
+		added := sourcesMissingFrom(after[key].Sources, sink.Sources)
+		removed := sourcesMissingFrom(sink.Sources, after[key].Sources)
+		if len(added) > 0 || len(removed) > 0 {
+			diff.ChangedSources = append(diff.ChangedSources, SourceChange{
+				FunctionFQN: sink.FunctionFQN,
+				SinkFQN:     sink.SinkFQN,
+				Added:       added,
+				Removed:     removed,
+			})
+		}
+	}
+	for _, key := range sortedKeys(before) {
+		if _, ok := after[key]; !ok {
+			diff.NewlyUnreachable = append(diff.NewlyUnreachable, *before[key])
+		}
+	}
+	return diff
+}
+
+// coveredSinks returns the sinks reached by unsanitized detections in the
+// call graph's summaries, keyed by function and sink FQN.
+func coveredSinks(cg *core.CallGraph) map[string]*CoveredSink {
+	sinks := make(map[string]*CoveredSink)
+	if cg == nil {
+		return sinks
+	}
+	for funcFQN, summary := range cg.Summaries {
+		if summary == nil {
+			continue
+		}
+		for _, detection := range summary.Detections {
+			if !detection.IsTainted() {
+				continue
+			}
+			sinkFQN := resolvedCallAt(detection.SinkCall, detection.SinkLine, funcFQN, cg)
+			key := funcFQN + "\x00" + sinkFQN
+			sink, ok := sinks[key]
+			if !ok {
+				sink = &CoveredSink{FunctionFQN: funcFQN, SinkFQN: sinkFQN}
+				sinks[key] = sink
+			}
+			if source := detectionSource(detection, funcFQN, cg); !slices.Contains(sink.Sources, source) {
+				sink.Sources = append(sink.Sources, source)
+			}
+		}
+	}
+	for _, sink := range sinks {
+		slices.Sort(sink.Sources)
+	}
+	return sinks
+}
+
+// detectionSource returns the source of a detection: the resolved call or
+// attribute read assigned to its source variable, or the variable itself
+// (e.g., a parameter) when no statement on the source line defines it.
+func detectionSource(detection *core.TaintInfo, funcFQN string, cg *core.CallGraph) string {
+	for _, stmt := range functionStatements(cg, funcFQN) {
+		if stmt.LineNumber != detection.SourceLine || stmt.Def != detection.SourceVar {
+			continue
+		}
+		if stmt.CallTarget != "" {
+			return resolvedCallAt(stmt.CallTarget, stmt.LineNumber, funcFQN, cg)
+		}
+		if stmt.AttributeAccess != "" {
+			return stmt.AttributeAccess
+		}
+	}
+	return detection.SourceVar
+}
+
+// sourcesMissingFrom returns the sources in sources that others lacks.
+func sourcesMissingFrom(sources, others []string) []string {
+	var missing []string
+	for _, source := range sources {
+		if !slices.Contains(others, source) {
+			missing = append(missing, source)
+		}
+	}
+	return missing
+}
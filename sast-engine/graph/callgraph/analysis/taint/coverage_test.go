@@ -0,0 +1,81 @@
+package taint
+
+import (
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/stretchr/testify/assert"
+)
+
+// coverageTestGraph models the functions below, with search's input passed
+// through escape first when sanitized is true:
+//
+//	def search():                   def run():
+//	    q = input()                     cmd = os.getenv("CMD")
+//	    q = escape(q)  # sanitized      os.system(cmd)
+//	    eval(q)
+func coverageTestGraph(sanitized bool, runSource string) *core.CallGraph {
+	cg := core.NewCallGraph()
+	addFunction := func(fqn string, statements []*core.Statement, calls ...core.CallSite) {
+		cg.Functions[fqn] = &graph.Node{Name: fqn}
+		cg.Statements[fqn] = statements
+		for _, call := range calls {
+			cg.AddCallSite(fqn, call)
+		}
+		cg.Summaries[fqn] = AnalyzeIntraProceduralTaint(fqn, statements, core.BuildDefUseChains(statements),
+			[]string{"input", "getenv", "read"}, []string{"eval", "system"}, []string{"escape"})
+	}
+
+	search := []*core.Statement{
+		{Type: core.StatementTypeAssignment, LineNumber: 2, Def: "q", CallTarget: "input"},
+	}
+	if sanitized {
+		search = append(search, &core.Statement{Type: core.StatementTypeAssignment, LineNumber: 3, Def: "q", CallTarget: "escape", Uses: []string{"q"}})
+	}
+	search = append(search, &core.Statement{Type: core.StatementTypeCall, LineNumber: 4, CallTarget: "eval", Uses: []string{"q"}})
+	addFunction("app.search", search,
+		core.CallSite{Target: "input", TargetFQN: "builtins.input", Location: core.Location{Line: 2}},
+		core.CallSite{Target: "eval", TargetFQN: "builtins.eval", Location: core.Location{Line: 4}},
+	)
+
+	addFunction("app.run", []*core.Statement{
+		{Type: core.StatementTypeAssignment, LineNumber: 7, Def: "cmd", CallTarget: runSource},
+		{Type: core.StatementTypeCall, LineNumber: 8, CallTarget: "os.system", Uses: []string{"cmd"}},
+	},
+		core.CallSite{Target: "os.system", TargetFQN: "os.system", Location: core.Location{Line: 8}},
+	)
+	return cg
+}
+
+func TestCompareCoverage_SanitizerRemovesSink(t *testing.T) {
+	before := coverageTestGraph(false, "os.getenv")
+	after := coverageTestGraph(true, "os.getenv")
+
+	diff := CompareCoverage(before, after)
+	assert.Empty(t, diff.NewlyReachable)
+	assert.Equal(t, []CoveredSink{{FunctionFQN: "app.search", SinkFQN: "builtins.eval", Sources: []string{"builtins.input"}}}, diff.NewlyUnreachable)
+	assert.Empty(t, diff.ChangedSources)
+
+	// The other way round, the sink becomes reachable.
+	reverse := CompareCoverage(after, before)
+	assert.Equal(t, diff.NewlyUnreachable, reverse.NewlyReachable)
+	assert.Empty(t, reverse.NewlyUnreachable)
+}
+
+func TestCompareCoverage_ChangedSources(t *testing.T) {
+	diff := CompareCoverage(coverageTestGraph(false, "os.getenv"), coverageTestGraph(false, "sys.stdin.read"))
+	assert.Empty(t, diff.NewlyReachable)
+	assert.Empty(t, diff.NewlyUnreachable)
+	assert.Equal(t, []SourceChange{{
+		FunctionFQN: "app.run",
+		SinkFQN:     "os.system",
+		Added:       []string{"sys.stdin.read"},
+		Removed:     []string{"os.getenv"},
+	}}, diff.ChangedSources)
+}
+
+func TestCompareCoverage_Identical(t *testing.T) {
+	assert.True(t, CompareCoverage(coverageTestGraph(false, "os.getenv"), coverageTestGraph(false, "os.getenv")).IsEmpty())
+	assert.True(t, CompareCoverage(nil, core.NewCallGraph()).IsEmpty())
+}
@@ -19,4 +19,46 @@
 //	for _, detection := range summary.Detections {
 //	    fmt.Printf("Taint flow detected: %s\n", detection.Variable)
 //	}
+//
+// AnalyzeReachableSinks aggregates inter-procedural flows per sink, listing
+// every source that reaches it:
+//
+//	for _, sink := range taint.AnalyzeReachableSinks(callGraph, sources, sinks) {
+//	    fmt.Printf("%s <- %v via %v\n", sink.SinkFQN, sink.Sources, sink.Path)
+//	}
+//
+// AnalyzeFunction answers the question for a single function, summarizing
+// only it and its transitive callees. Its Rules cache those summaries, so
+// later queries about other functions reuse them:
+//
+//	rules := &taint.Rules{Sources: sources, Sinks: sinks}
+//	summary := taint.AnalyzeFunction(callGraph, "myapp.views.handler", rules)
+//
+// Taint written to a module-level variable under `global` reaches the other
+// functions of the module that read it.
+//
+// A TrustedBoundary models a project's validation layer: values returned by
+// its functions are clean whatever their inputs, so flows routed through it
+// are not reported:
+//
+//	boundary, err := taint.LoadTrustedBoundary([]byte(`
+//	trusted_boundary:
+//	  modules:
+//	    - app.validators
+//	`))
+//	sinks := taint.AnalyzeReachableSinksWithBoundary(callGraph, sources, sinks, boundary)
+//
+// CompareCoverage reports how taint coverage differs between two analyzed
+// call graphs, e.g. before and after adding a sanitizer to a rule: sinks
+// newly reachable or unreachable, and sinks whose sources changed:
+//
+//	diff := taint.CompareCoverage(before, after)
+//	for _, sink := range diff.NewlyUnreachable {
+//	    fmt.Printf("%s no longer reaches %s\n", sink.FunctionFQN, sink.SinkFQN)
+//	}
+//
+// A GuardedConversions call (int, float, uuid.UUID) in a try whose except
+// handlers all return or raise yields a clean value, since code after the
+// try only runs once the input parsed as a number or UUID. The input string
+// itself stays tainted.
 package taint
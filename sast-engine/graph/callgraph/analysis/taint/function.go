@@ -0,0 +1,106 @@
+package taint
+
+import (
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// Rules are the sources, sinks, and sanitizers of AnalyzeFunction queries.
+// A Rules value caches the transfer summaries and results it computed for a
+// call graph, so keep one per rule set and reuse it across queries. The
+// cache is dropped when a Rules value is used with another call graph.
+// Do not change the lists after the first query.
+type Rules struct {
+	Sources    []string
+	Sinks      []string
+	Sanitizers []string
+
+	mu        sync.Mutex
+	callGraph *core.CallGraph
+	summaries map[string]*TaintTransferSummary
+	results   map[string]*core.TaintSummary
+}
+
+// AnalyzeFunction runs inter-procedural taint analysis on one function, as
+// AnalyzeInterProcedural does with the summaries of BuildTransferSummaries,
+// without summarizing the whole program: transfer summaries are built only
+// for the function and its transitive callees, and cached in rules along
+// with the result. A later query reuses both, summarizing only callees not
+// seen before, which keeps interactive "is this function vulnerable?"
+// questions cheap.
+//
+// Returns nil if fqn is not a function of cg.
+func AnalyzeFunction(cg *core.CallGraph, fqn string, rules *Rules) *core.TaintSummary {
+	if _, ok := cg.Functions[fqn]; !ok {
+		return nil
+	}
+
+	rules.mu.Lock()
+	defer rules.mu.Unlock()
+	if rules.callGraph != cg {
+		rules.callGraph = cg
+		rules.summaries = make(map[string]*TaintTransferSummary)
+		rules.results = make(map[string]*core.TaintSummary)
+	}
+	if result, ok := rules.results[fqn]; ok {
+		return result
+	}
+
+	rules.summarize(cg, transitiveCallees(cg, fqn))
+	result := AnalyzeInterProcedural(fqn, functionStatements(cg, fqn), rules.Sources, rules.Sinks, rules.Sanitizers, cg, rules.summaries)
+	rules.results[fqn] = result
+	return result
+}
+
+// summarize adds to the cached summaries those of functions not summarized
+// yet, iterating to a fixpoint as BuildTransferSummaries does. functions
+// must be closed under callees, so that summaries already cached, built
+// for earlier closures, are final.
+func (r *Rules) summarize(cg *core.CallGraph, functions []string) {
+	var pending []string
+	for _, funcFQN := range functions {
+		if _, ok := r.summaries[funcFQN]; !ok && len(functionStatements(cg, funcFQN)) > 0 {
+			pending = append(pending, funcFQN)
+		}
+	}
+
+	for range maxSummaryIterations {
+		next := make(map[string]*TaintTransferSummary, len(pending))
+		changed := false
+		for _, funcFQN := range pending {
+			params := parameterNames(cg.Functions[funcFQN].MethodArgumentsValue)
+			ts := BuildTaintTransferSummary(funcFQN, functionStatements(cg, funcFQN), params, r.Sources, r.Sinks, r.Sanitizers, cg, r.summaries)
+			next[funcFQN] = ts
+			if !sameTransfer(r.summaries[funcFQN], ts) {
+				changed = true
+			}
+		}
+		for funcFQN, ts := range next {
+			r.summaries[funcFQN] = ts
+		}
+		if !changed {
+			break
+		}
+	}
+}
+
+// transitiveCallees returns fqn and the functions of cg it calls, directly
+// or indirectly, sorted.
+func transitiveCallees(cg *core.CallGraph, fqn string) []string {
+	seen := map[string]bool{fqn: true}
+	queue := []string{fqn}
+	for len(queue) > 0 {
+		caller := queue[0]
+		queue = queue[1:]
+		for _, callee := range cg.Edges[caller] {
+			if _, ok := cg.Functions[callee]; ok && !seen[callee] {
+				seen[callee] = true
+				queue = append(queue, callee)
+			}
+		}
+	}
+	return slices.Sorted(maps.Keys(seen))
+}
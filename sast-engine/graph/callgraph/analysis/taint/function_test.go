@@ -0,0 +1,67 @@
+package taint
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeFunction_MatchesGlobalPass(t *testing.T) {
+	cg := reachableTestGraph()
+	sources := []string{"input", "os.getenv"}
+	sinks := []string{"os.system", "eval"}
+	global := BuildTransferSummaries(cg, sources, sinks, nil)
+
+	rules := &Rules{Sources: sources, Sinks: sinks}
+	for _, fqn := range []string{"app.cli", "app.web", "app.direct", "app.safe", "app.wrapper", "app.run"} {
+		t.Run(fqn, func(t *testing.T) {
+			expected := AnalyzeInterProcedural(fqn, functionStatements(cg, fqn), sources, sinks, nil, cg, global)
+			result := AnalyzeFunction(cg, fqn, rules)
+			require.NotNil(t, result)
+			assert.Equal(t, expected.Detections, result.Detections)
+		})
+	}
+
+	// app.cli reaches os.system through wrapper and run.
+	cli := AnalyzeFunction(cg, "app.cli", rules)
+	require.Len(t, cli.Detections, 1)
+	assert.Equal(t, uint32(2), cli.Detections[0].SourceLine)
+	assert.Empty(t, AnalyzeFunction(cg, "app.safe", rules).Detections)
+}
+
+func TestAnalyzeFunction_SummarizesOnlyCallees(t *testing.T) {
+	cg := reachableTestGraph()
+	rules := &Rules{Sources: []string{"input"}, Sinks: []string{"os.system", "eval"}}
+
+	AnalyzeFunction(cg, "app.direct", rules)
+	assert.Equal(t, []string{"app.direct"}, slices.Sorted(maps.Keys(rules.summaries)))
+
+	AnalyzeFunction(cg, "app.cli", rules)
+	assert.Equal(t, []string{"app.cli", "app.direct", "app.run", "app.wrapper"}, slices.Sorted(maps.Keys(rules.summaries)),
+		"app.web and app.safe are not summarized")
+
+	// Repeated queries are answered from the cache.
+	first := AnalyzeFunction(cg, "app.cli", rules)
+	assert.Same(t, first, AnalyzeFunction(cg, "app.cli", rules))
+
+	// Another call graph starts over.
+	other := reachableTestGraph()
+	assert.NotSame(t, first, AnalyzeFunction(other, "app.cli", rules))
+	assert.NotContains(t, rules.summaries, "app.direct")
+}
+
+func TestAnalyzeFunction_UnknownFunction(t *testing.T) {
+	assert.Nil(t, AnalyzeFunction(reachableTestGraph(), "app.missing", &Rules{}))
+}
+
+func TestAnalyzeFunction_KeywordArguments(t *testing.T) {
+	cg := keywordTestGraph()
+	rules := &Rules{Sources: []string{"input"}, Sinks: []string{"os.system"}}
+
+	assert.True(t, AnalyzeFunction(cg, "app.by_keyword", rules).HasDetections())
+	assert.False(t, AnalyzeFunction(cg, "app.swapped", rules).HasDetections())
+	assert.False(t, AnalyzeFunction(cg, "app.mixed", rules).HasDetections())
+}
@@ -0,0 +1,104 @@
+package taint
+
+import (
+	"slices"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// globalKey identifies a module-level variable by its file and name.
+type globalKey struct {
+	file string
+	name string
+}
+
+// taintedGlobal is a module-level variable that a function declares global
+// and assigns data from a source.
+type taintedGlobal struct {
+	Source string // Source whose data reaches the global (first, sorted)
+	Writer string // FQN of the function assigning it
+}
+
+// findTaintedGlobals returns the module-level variables that functions
+// rebind via `global` (see core.CallGraph.GlobalWrites) to data from a
+// source. A global assigned from another tainted global is tainted too, so
+// the search repeats until no more are found.
+//
+// Globals are flow-insensitive across functions: a tainted write anywhere
+// taints every read of the global in its module, whatever the call order.
+func findTaintedGlobals(cg *core.CallGraph, sources, sanitizers []string, summaries map[string]*TaintTransferSummary) map[globalKey]taintedGlobal {
+	globals := make(map[globalKey]taintedGlobal)
+	for range maxSummaryIterations {
+		changed := false
+		for _, funcFQN := range sortedKeys(cg.GlobalWrites) {
+			funcNode, ok := cg.Functions[funcFQN]
+			if !ok {
+				continue
+			}
+			statements := functionStatements(cg, funcFQN)
+			vdg := NewVarDepGraph()
+			seedTaintedGlobals(vdg, funcFQN, cg, globals)
+			vdg.Build(statements, sources, nil, sanitizers)
+			EnhanceVDGWithCalleeSummaries(vdg, statements, funcFQN, cg, summaries)
+
+			for _, name := range cg.GlobalWrites[funcFQN] {
+				key := globalKey{file: funcNode.File, name: name}
+				if _, ok := globals[key]; ok {
+					continue
+				}
+				for _, stmt := range statements {
+					if stmt.Def != name {
+						continue
+					}
+					if found := vdg.reachingSources(nodeKey(name, stmt.LineNumber), funcFQN, cg); len(found) > 0 {
+						globals[key] = taintedGlobal{Source: found[0], Writer: funcFQN}
+						changed = true
+						break
+					}
+				}
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+	return globals
+}
+
+// seedTaintedGlobals defines each tainted global of the function's module
+// as a taint source at line 0, before the function's own statements, so
+// reads that no local assignment shadows see the taint. Parameters named
+// like a global shadow it and are not seeded.
+func seedTaintedGlobals(vdg *VarDepGraph, funcFQN string, cg *core.CallGraph, globals map[globalKey]taintedGlobal) {
+	funcNode, ok := cg.Functions[funcFQN]
+	if !ok || len(globals) == 0 {
+		return
+	}
+	params := parameterNames(funcNode.MethodArgumentsValue)
+	for key, global := range globals {
+		if key.file != funcNode.File || slices.Contains(params, key.name) {
+			continue
+		}
+		defKey := nodeKey(key.name, 0)
+		vdg.Nodes[defKey] = &VarDefSite{
+			VarName:    key.name,
+			Line:       0,
+			IsTaintSrc: true,
+			CallTarget: global.Source,
+		}
+		vdg.LatestDef[key.name] = defKey
+	}
+}
+
+// globalWriterPath prefixes path with the function that tainted the
+// global, when the flow starts at a seeded global rather than in funcFQN.
+func globalWriterPath(path []string, detection TaintDetection, funcFQN string, cg *core.CallGraph, globals map[globalKey]taintedGlobal) []string {
+	if detection.SourceLine != 0 {
+		return path
+	}
+	global, ok := globals[globalKey{file: cg.Functions[funcFQN].File, name: detection.SourceVar}]
+	if !ok || global.Writer == funcFQN {
+		return path
+	}
+	return append([]string{global.Writer}, path...)
+}
@@ -1,6 +1,10 @@
 package taint
 
 import (
+	"slices"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/cfg"
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
 )
@@ -34,6 +38,12 @@ type TaintTransferSummary struct {
 	// within the callee (e.g., "cursor.execute").
 	ParamToSinkCall map[int]string
 
+	// ParamToSinkCallee maps parameter index to the callee it is passed to
+	// when it reaches a sink transitively rather than within the function.
+	// ParamToSinkArg holds the callee's parameter index it is passed as.
+	ParamToSinkCallee map[int]string
+	ParamToSinkArg    map[int]int
+
 	// IsSource is true if the function returns tainted data (calls a source
 	// internally) regardless of parameters.
 	IsSource bool
@@ -72,6 +82,9 @@ func BuildTaintTransferSummary(
 			ParamToSink:     make(map[int]bool),
 			ParamToSinkLine: make(map[int]uint32),
 			ParamToSinkCall: make(map[int]string),
+
+			ParamToSinkCallee: make(map[int]string),
+			ParamToSinkArg:    make(map[int]int),
 		}
 		for _, idx := range params {
 			if idx == -1 {
@@ -93,6 +106,9 @@ func BuildTaintTransferSummary(
 		ParamToSink:     make(map[int]bool),
 		ParamToSinkLine: make(map[int]uint32),
 		ParamToSinkCall: make(map[int]string),
+
+		ParamToSinkCallee: make(map[int]string),
+		ParamToSinkArg:    make(map[int]int),
 	}
 
 	if len(statements) == 0 {
@@ -214,7 +230,7 @@ func BuildTaintTransferSummary(
 					continue
 				}
 
-				callSiteArgs := findCallSiteArgs(stmt, functionFQN, callGraph)
+				callSiteArgs := boundCallSiteArgs(stmt, functionFQN, calleeFQN, callGraph, ts)
 				for argIdx, arg := range callSiteArgs {
 					if !ts.ParamToSink[argIdx] || !arg.IsVariable {
 						continue
@@ -226,6 +242,8 @@ func BuildTaintTransferSummary(
 					path := vdg.findPath(paramKey, argDefKey)
 					if path != nil && !vdg.pathContainsSanitizer(path) {
 						summary.ParamToSink[i] = true
+						summary.ParamToSinkCallee[i] = calleeFQN
+						summary.ParamToSinkArg[i] = argIdx
 						break
 					}
 				}
@@ -445,7 +463,7 @@ func AnalyzeInterProcedural(
 		}
 
 		// Check if any argument to this indirect sink is tainted
-		callSiteArgs := findCallSiteArgs(stmt, callerFQN, callGraph)
+		callSiteArgs := boundCallSiteArgs(stmt, callerFQN, calleeFQN, callGraph, ts)
 		for paramIdx, arg := range callSiteArgs {
 			if !ts.ParamToSink[paramIdx] || !arg.IsVariable {
 				continue
@@ -549,7 +567,7 @@ func EnhanceVDGWithCalleeSummaries(
 		}
 
 		// Case 3: Callee propagates taint from param to return
-		callSiteArgs := findCallSiteArgs(stmt, callerFQN, callGraph)
+		callSiteArgs := boundCallSiteArgs(stmt, callerFQN, calleeFQN, callGraph, transferSummary)
 		for paramIdx, arg := range callSiteArgs {
 			if !arg.IsVariable {
 				continue
@@ -594,6 +612,58 @@ func findCallSiteArgs(stmt *core.Statement, callerFQN string, callGraph *core.Ca
 	return nil
 }
 
+// boundCallSiteArgs returns the arguments of the call made by stmt indexed
+// by the callee parameter they bind, as numbered in the callee's ParamNames;
+// the entries of parameters no argument binds are zero. Keyword arguments (password=cmd) bind the parameter of that
+// name, whatever their position, and are returned as their value. The
+// callee's signature decides the binding when it is in the call graph (see
+// core.BindArguments); otherwise positional arguments bind by position.
+// Arguments spread into or collected by *args and **kwargs are left out.
+func boundCallSiteArgs(stmt *core.Statement, callerFQN, calleeFQN string, callGraph *core.CallGraph, callee *TaintTransferSummary) []core.Argument {
+	args := findCallSiteArgs(stmt, callerFQN, callGraph)
+	if len(args) == 0 {
+		return nil
+	}
+
+	var parameters []string
+	if function, ok := callGraph.Functions[calleeFQN]; ok && function != nil {
+		for _, binding := range core.BindArguments(function, args) {
+			switch binding.ParameterKind {
+			case graph.ParameterVarPositional, graph.ParameterVarKeyword:
+				parameters = append(parameters, "")
+			default:
+				parameters = append(parameters, binding.Parameter)
+			}
+		}
+	} else {
+		for i, arg := range args {
+			keyword, _, ok := strings.Cut(arg.Value, "=")
+			switch {
+			case ok && identifier.MatchString(strings.TrimSpace(keyword)):
+				parameters = append(parameters, strings.TrimSpace(keyword))
+			case i < len(callee.ParamNames):
+				parameters = append(parameters, callee.ParamNames[i])
+			default:
+				parameters = append(parameters, "")
+			}
+		}
+	}
+
+	bound := make([]core.Argument, len(callee.ParamNames))
+	for i, arg := range args {
+		paramIdx := slices.Index(callee.ParamNames, parameters[i])
+		if parameters[i] == "" || paramIdx < 0 {
+			continue
+		}
+		if keyword, value, ok := strings.Cut(arg.Value, "="); ok && identifier.MatchString(strings.TrimSpace(keyword)) && !strings.HasPrefix(value, "=") {
+			value = strings.TrimSpace(value)
+			arg.Value, arg.IsVariable = value, identifier.MatchString(value)
+		}
+		bound[paramIdx] = arg
+	}
+	return bound
+}
+
 // resolveCallTarget resolves a call target string to a function FQN.
 // It checks the call graph's call sites for the caller function to find
 // matching resolved targets.
@@ -0,0 +1,152 @@
+package taint
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// ReceiverArg is the Propagator.FromArg value selecting the receiver of a
+// method call: s in s.strip().
+const ReceiverArg = -1
+
+// Propagator describes how a call passes taint to its result: from the
+// argument at FromArg (or the receiver, for ReceiverArg) to the value it
+// returns when ToReturn is set.
+//
+// Propagators replace the default assumption that every call result is
+// tainted when any argument is. Once a propagator is configured, calls
+// matching none of them return untainted values, and a matching call only
+// carries taint from the configured argument.
+type Propagator struct {
+	FQN      string `yaml:"fqn"`      // Function or method, e.g. "copy.deepcopy" or "str.strip"
+	FromArg  int    `yaml:"fromArg"`  // 0-based positional argument, or ReceiverArg
+	ToReturn bool   `yaml:"toReturn"` // Whether taint reaches the return value
+}
+
+// identifier matches a plain Python name.
+var identifier = regexp.MustCompile(`^[A-Za-z_]\w*$`)
+
+// variableName matches a name that is not an attribute (x in x.y, not y).
+var variableName = regexp.MustCompile(`(^|[^.\w])([A-Za-z_]\w*)`)
+
+// matchPropagator returns the propagator for the call in stmt, or nil.
+// Receiver propagators are matched by method name, since a statement does
+// not record its receiver's type: "str.strip" matches name.strip().
+func matchPropagator(stmt *core.Statement, propagators []Propagator) *Propagator {
+	chain := stmt.CallChain
+	if chain == "" {
+		chain = stmt.CallTarget
+	}
+	for i := range propagators {
+		propagator := &propagators[i]
+		if matchesFunctionName(chain, propagator.FQN) {
+			return propagator
+		}
+		if propagator.FromArg == ReceiverArg && strings.Contains(chain, ".") {
+			_, method := splitModuleFunction(propagator.FQN)
+			if _, called := splitModuleFunction(chain); called == method {
+				return propagator
+			}
+		}
+	}
+	return nil
+}
+
+// propagatedArgument returns the expression a propagator reads taint from:
+// the receiver chain for ReceiverArg, otherwise the positional argument at
+// FromArg. Returns "" if the call has no such argument.
+func propagatedArgument(stmt *core.Statement, propagator *Propagator) string {
+	if propagator.FromArg == ReceiverArg {
+		chain := stmt.CallChain
+		if chain == "" {
+			chain = stmt.CallTarget
+		}
+		receiver, _ := splitModuleFunction(chain)
+		return receiver
+	}
+
+	var args []string
+	if open := strings.IndexByte(stmt.CallTarget, '('); open >= 0 && strings.HasSuffix(stmt.CallTarget, ")") {
+		args = splitTopLevel(stmt.CallTarget[open+1 : len(stmt.CallTarget)-1])
+	} else {
+		args = stmt.CallArgs
+	}
+	positional := 0
+	for _, arg := range args {
+		if isKeywordArgument(arg) {
+			continue
+		}
+		if positional == propagator.FromArg {
+			return arg
+		}
+		positional++
+	}
+	return ""
+}
+
+// isKeywordArgument reports whether arg is a name=value argument.
+func isKeywordArgument(arg string) bool {
+	name, value, ok := strings.Cut(arg, "=")
+	return ok && identifier.MatchString(strings.TrimSpace(name)) && !strings.HasPrefix(value, "=")
+}
+
+// splitTopLevel splits an argument list at commas outside brackets and
+// string literals.
+func splitTopLevel(s string) []string {
+	var parts []string
+	depth, start := 0, 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(' || c == '[' || c == '{':
+			depth++
+		case c == ')' || c == ']' || c == '}':
+			depth--
+		case c == ',' && depth == 0:
+			parts = append(parts, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+	if last := strings.TrimSpace(s[start:]); last != "" {
+		parts = append(parts, last)
+	}
+	return parts
+}
+
+// applyPropagators sets the taint of the variable a call assigns according
+// to the configured propagators, replacing propagateAssignment and
+// propagateCall for calls.
+func applyPropagators(stmt *core.Statement, propagators []Propagator, taintState *TaintState, summary *core.TaintSummary) {
+	var taintInfo *variableTaintInfo
+	if propagator := matchPropagator(stmt, propagators); propagator != nil && propagator.ToReturn {
+		for _, match := range variableName.FindAllStringSubmatch(propagatedArgument(stmt, propagator), -1) {
+			if info := taintState.GetTaintInfo(match[2]); info != nil {
+				taintInfo = info
+				break
+			}
+		}
+	}
+	if taintInfo == nil {
+		taintState.SetUntainted(stmt.Def)
+		return
+	}
+
+	// A configured propagator is exact, so taint carries over without decay
+	taintState.SetTainted(stmt.Def, taintInfo.Source, taintInfo.Confidence, taintInfo.SourceLine)
+	summary.AddTaintedVar(stmt.Def, &core.TaintInfo{
+		SourceLine: taintInfo.SourceLine,
+		SourceVar:  stmt.Def,
+		Confidence: taintInfo.Confidence,
+	})
+}
@@ -0,0 +1,81 @@
+package taint
+
+import (
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/stretchr/testify/assert"
+)
+
+// propagatorStatements models:
+//
+//	data = request.GET.get("q")
+//	result = <call>
+//	os.system(result)
+func propagatorStatements(call, chain string) []*core.Statement {
+	return []*core.Statement{
+		{Type: core.StatementTypeAssignment, LineNumber: 1, Def: "data", CallTarget: "request.GET.get", Uses: []string{"request"}},
+		{Type: core.StatementTypeAssignment, LineNumber: 2, Def: "result", CallTarget: call, CallChain: chain, Uses: []string{"data"}},
+		{Type: core.StatementTypeCall, LineNumber: 3, CallTarget: "os.system", CallArgs: []string{"result"}, Uses: []string{"result"}},
+	}
+}
+
+func analyzeWithPropagators(statements []*core.Statement, propagators []Propagator) *core.TaintSummary {
+	return AnalyzeIntraProceduralTaintWithPropagators("app.view", statements, core.BuildDefUseChains(statements),
+		[]string{"request.GET.get"}, []string{"os.system"}, nil, propagators)
+}
+
+func TestPropagators_CarryTaint(t *testing.T) {
+	propagators := []Propagator{
+		{FQN: "copy.deepcopy", FromArg: 0, ToReturn: true},
+		{FQN: "str.strip", FromArg: ReceiverArg, ToReturn: true},
+	}
+
+	summary := analyzeWithPropagators(propagatorStatements("copy.deepcopy(data)", "copy.deepcopy"), propagators)
+	assert.True(t, summary.HasDetections(), "listed propagator carries taint")
+	assert.Equal(t, 1.0, summary.Detections[0].Confidence, "propagators do not decay confidence")
+
+	summary = analyzeWithPropagators(propagatorStatements("data.strip()", "data.strip"), propagators)
+	assert.True(t, summary.HasDetections(), "receiver propagator carries taint from the receiver")
+}
+
+func TestPropagators_UnlistedCallBreaksTaint(t *testing.T) {
+	propagators := []Propagator{{FQN: "copy.deepcopy", FromArg: 0, ToReturn: true}}
+
+	summary := analyzeWithPropagators(propagatorStatements("normalize(data)", "normalize"), propagators)
+	assert.False(t, summary.HasDetections())
+
+	// Without propagators, any tainted argument taints the result
+	summary = analyzeWithPropagators(propagatorStatements("normalize(data)", "normalize"), nil)
+	assert.True(t, summary.HasDetections())
+}
+
+func TestPropagators_FromArg(t *testing.T) {
+	propagators := []Propagator{
+		{FQN: "os.path.join", FromArg: 1, ToReturn: true},
+		{FQN: "hashlib.sha256", FromArg: 0, ToReturn: false},
+	}
+
+	summary := analyzeWithPropagators(propagatorStatements(`os.path.join(data, "static")`, "os.path.join"), propagators)
+	assert.False(t, summary.HasDetections(), "taint in an unlisted argument is dropped")
+
+	summary = analyzeWithPropagators(propagatorStatements(`os.path.join("/srv", data)`, "os.path.join"), propagators)
+	assert.True(t, summary.HasDetections())
+
+	summary = analyzeWithPropagators(propagatorStatements("hashlib.sha256(data)", "hashlib.sha256"), propagators)
+	assert.False(t, summary.HasDetections(), "toReturn false does not reach the result")
+}
+
+func TestPropagatedArgument_SkipsKeywords(t *testing.T) {
+	stmt := &core.Statement{CallTarget: `json.dumps(indent=2, obj=data)`}
+	assert.Empty(t, propagatedArgument(stmt, &Propagator{FQN: "json.dumps", FromArg: 0}))
+
+	stmt = &core.Statement{CallTarget: `copy.deepcopy(data, memo={"a": 1})`}
+	assert.Equal(t, "data", propagatedArgument(stmt, &Propagator{FQN: "copy.deepcopy", FromArg: 0}))
+	assert.Empty(t, propagatedArgument(stmt, &Propagator{FQN: "copy.deepcopy", FromArg: 1}))
+}
+
+func TestSplitTopLevel(t *testing.T) {
+	assert.Equal(t, []string{"a", "f(b, c)", `"d, e"`, "[1, 2]"}, splitTopLevel(`a, f(b, c), "d, e", [1, 2]`))
+	assert.Empty(t, splitTopLevel(""))
+}
@@ -0,0 +1,293 @@
+package taint
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/cfg"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// maxSummaryIterations bounds the fixpoint in BuildTransferSummaries.
+const maxSummaryIterations = 10
+
+// ReachableSink is a sink that data from at least one source can reach.
+type ReachableSink struct {
+	SinkFQN string   // Resolved sink call (e.g., "os.system")
+	Sources []string // Sorted sources that reach the sink (e.g., "builtins.input")
+	Path    []string // Representative function path from the source's function to the one calling the sink
+}
+
+// AnalyzeReachableSinks reports which sinks are reachable from the given
+// sources, using inter-procedural taint transfer summaries. Each sink
+// appears once, with every source whose data reaches it and the shortest
+// function path found to it. This is the aggregate view over individual
+// matches, suited to coverage reports. Results are sorted by sink FQN.
+//
+// Sources are reported as the resolved source call (e.g., "builtins.input"),
+// or the attribute read for attribute sources (e.g., "request.GET"). A
+// function whose summary says it returns tainted data is reported as the
+// source when its result is what reaches the sink.
+//
+// A module-level variable that a function assigns source data under
+// `global` is tainted for every function in its module that reads it; the
+// path then starts at the assigning function.
+func AnalyzeReachableSinks(cg *core.CallGraph, sources, sinks []string) []ReachableSink {
+	return AnalyzeReachableSinksWithBoundary(cg, sources, sinks, nil)
+}
+
+// AnalyzeReachableSinksWithBoundary is AnalyzeReachableSinks with values
+// returned by the functions of a trusted boundary treated as clean.
+func AnalyzeReachableSinksWithBoundary(cg *core.CallGraph, sources, sinks []string, boundary *TrustedBoundary) []ReachableSink {
+	summaries := BuildTransferSummariesWithBoundary(cg, sources, sinks, nil, boundary)
+	globals := findTaintedGlobals(cg, sources, nil, summaries)
+
+	reachable := make(map[string]*ReachableSink)
+	record := func(sinkFQN, source string, path []string) {
+		sink, ok := reachable[sinkFQN]
+		if !ok {
+			sink = &ReachableSink{SinkFQN: sinkFQN, Path: path}
+			reachable[sinkFQN] = sink
+		}
+		if !slices.Contains(sink.Sources, source) {
+			sink.Sources = append(sink.Sources, source)
+		}
+		if len(path) < len(sink.Path) {
+			sink.Path = path
+		}
+	}
+
+	for _, funcFQN := range sortedFunctions(cg) {
+		statements := functionStatements(cg, funcFQN)
+		if len(statements) == 0 {
+			continue
+		}
+
+		vdg := NewVarDepGraph()
+		seedTaintedGlobals(vdg, funcFQN, cg, globals)
+		vdg.Build(statements, sources, sinks, nil)
+		EnhanceVDGWithCalleeSummaries(vdg, statements, funcFQN, cg, summaries)
+
+		// Sinks called in this function
+		for _, detection := range vdg.FindTaintFlows(statements, sinks) {
+			source := sourceName(vdg.Nodes[nodeKey(detection.SourceVar, detection.SourceLine)], funcFQN, cg)
+			path := globalWriterPath([]string{funcFQN}, detection, funcFQN, cg, globals)
+			record(resolvedCallAt(detection.SinkCall, detection.SinkLine, funcFQN, cg), source, path)
+		}
+
+		// Sinks reached through callees whose parameters flow to a sink
+		for _, stmt := range statements {
+			if stmt.CallTarget == "" {
+				continue
+			}
+			calleeFQN := resolveCallTarget(stmt.CallTarget, funcFQN, cg)
+			ts, ok := summaries[calleeFQN]
+			if !ok {
+				continue
+			}
+			for paramIdx, arg := range boundCallSiteArgs(stmt, funcFQN, calleeFQN, cg, ts) {
+				if !ts.ParamToSink[paramIdx] || !arg.IsVariable {
+					continue
+				}
+				argDefKey, found := vdg.LatestDefAt(arg.Value, stmt.LineNumber)
+				if !found {
+					continue
+				}
+				tainted := vdg.reachingSources(argDefKey, funcFQN, cg)
+				if len(tainted) == 0 {
+					continue
+				}
+				chain, sinkFQN := sinkChain(calleeFQN, paramIdx, cg, summaries, map[string]bool{funcFQN: true})
+				for _, source := range tainted {
+					record(sinkFQN, source, append([]string{funcFQN}, chain...))
+				}
+			}
+		}
+	}
+
+	result := make([]ReachableSink, 0, len(reachable))
+	for _, sinkFQN := range sortedKeys(reachable) {
+		sink := reachable[sinkFQN]
+		slices.Sort(sink.Sources)
+		result = append(result, *sink)
+	}
+	return result
+}
+
+// BuildTransferSummaries builds a TaintTransferSummary for every function in
+// the call graph. Summaries are rebuilt with the previous round's summaries
+// as callee information until none change, so taint propagates through call
+// chains of any depth up to maxSummaryIterations.
+func BuildTransferSummaries(cg *core.CallGraph, sources, sinks, sanitizers []string) map[string]*TaintTransferSummary {
+	return BuildTransferSummariesWithBoundary(cg, sources, sinks, sanitizers, nil)
+}
+
+// BuildTransferSummariesWithBoundary is BuildTransferSummaries with the
+// functions of a trusted boundary summarized as returning clean values.
+// Trusted call targets without a body in the call graph get such a summary
+// too, so library validators can be trusted.
+func BuildTransferSummariesWithBoundary(cg *core.CallGraph, sources, sinks, sanitizers []string, boundary *TrustedBoundary) map[string]*TaintTransferSummary {
+	summaries := trustedCallTargets(cg, boundary)
+	for range maxSummaryIterations {
+		next := trustedCallTargets(cg, boundary)
+		changed := false
+		for _, funcFQN := range sortedFunctions(cg) {
+			statements := functionStatements(cg, funcFQN)
+			if len(statements) == 0 {
+				continue
+			}
+			params := parameterNames(cg.Functions[funcFQN].MethodArgumentsValue)
+			ts := BuildTaintTransferSummary(funcFQN, statements, params, sources, sinks, sanitizers, cg, summaries)
+			if boundary.Trusts(funcFQN) {
+				boundary.trust(ts)
+			}
+			next[funcFQN] = ts
+			if !sameTransfer(summaries[funcFQN], ts) {
+				changed = true
+			}
+		}
+		summaries = next
+		if !changed {
+			break
+		}
+	}
+	return summaries
+}
+
+// trustedCallTargets returns a summary for each trusted function called in
+// the call graph, keyed by FQN.
+func trustedCallTargets(cg *core.CallGraph, boundary *TrustedBoundary) map[string]*TaintTransferSummary {
+	summaries := make(map[string]*TaintTransferSummary)
+	if boundary == nil {
+		return summaries
+	}
+	for _, callSites := range cg.CallSites {
+		for _, callSite := range callSites {
+			if boundary.Trusts(callSite.TargetFQN) {
+				summaries[callSite.TargetFQN] = trustedSummary(callSite.TargetFQN)
+			}
+		}
+	}
+	return summaries
+}
+
+// sinkChain follows a callee parameter that reaches a sink through further
+// callees. It returns the functions passed through, starting with fqn, and
+// the resolved sink reached by the last of them.
+func sinkChain(fqn string, param int, cg *core.CallGraph, summaries map[string]*TaintTransferSummary, seen map[string]bool) ([]string, string) {
+	ts := summaries[fqn]
+	seen[fqn] = true
+	if callee, ok := ts.ParamToSinkCallee[param]; ok && !seen[callee] && summaries[callee] != nil {
+		chain, sinkFQN := sinkChain(callee, ts.ParamToSinkArg[param], cg, summaries, seen)
+		return append([]string{fqn}, chain...), sinkFQN
+	}
+	return []string{fqn}, resolvedCall(ts.ParamToSinkCall[param], fqn, cg)
+}
+
+// reachingSources returns the sorted sources of the taint-source nodes with
+// an unsanitized path to key.
+func (g *VarDepGraph) reachingSources(key, funcFQN string, cg *core.CallGraph) []string {
+	var found []string
+	for srcKey, srcNode := range g.Nodes {
+		if !srcNode.IsTaintSrc {
+			continue
+		}
+		path := g.findPath(srcKey, key)
+		if path == nil || g.pathContainsSanitizer(path) {
+			continue
+		}
+		if source := sourceName(srcNode, funcFQN, cg); !slices.Contains(found, source) {
+			found = append(found, source)
+		}
+	}
+	slices.Sort(found)
+	return found
+}
+
+// sourceName names the source read at a taint-source def site: the
+// attribute for attribute sources (x = request.GET), otherwise the resolved
+// call (x = input()).
+func sourceName(node *VarDefSite, funcFQN string, cg *core.CallGraph) string {
+	if node.CallTarget == "" && node.AttributeAccess != "" {
+		return node.AttributeAccess
+	}
+	return resolvedCall(node.CallTarget, funcFQN, cg)
+}
+
+// resolvedCall returns the FQN a call target in funcFQN resolves to, or the
+// target itself when it has no resolved call site.
+func resolvedCall(callTarget, funcFQN string, cg *core.CallGraph) string {
+	if fqn := resolveCallTarget(callTarget, funcFQN, cg); fqn != "" {
+		return fqn
+	}
+	return callTarget
+}
+
+// resolvedCallAt is resolvedCall for the call on a given line. Statements
+// record only the called name of a method or module call ("system" for
+// os.system(cmd)), so the call site on the line is matched by suffix.
+func resolvedCallAt(callTarget string, line uint32, funcFQN string, cg *core.CallGraph) string {
+	for _, callSite := range cg.CallSites[funcFQN] {
+		if callSite.Location.Line != int(line) || callSite.TargetFQN == "" {
+			continue
+		}
+		if callSite.Target == callTarget || strings.HasSuffix(callSite.Target, "."+callTarget) {
+			return callSite.TargetFQN
+		}
+	}
+	return resolvedCall(callTarget, funcFQN, cg)
+}
+
+// functionStatements returns a function's statements, flattened from its
+// CFG when one was built.
+func functionStatements(cg *core.CallGraph, funcFQN string) []*core.Statement {
+	if cfGraph, ok := cg.CFGs[funcFQN].(*cfg.ControlFlowGraph); ok {
+		if blockStmts, ok := cg.CFGBlockStatements[funcFQN].(cfg.BlockStatements); ok {
+			return FlattenBlockStatements(cfGraph, blockStmts)
+		}
+	}
+	return cg.Statements[funcFQN]
+}
+
+// parameterNames returns the names of a function's parameters without
+// annotations or defaults, skipping self and cls.
+func parameterNames(params []string) []string {
+	var names []string
+	for _, param := range params {
+		name, _, _ := strings.Cut(param, "=")
+		name, _, _ = strings.Cut(name, ":")
+		name = strings.TrimSpace(name)
+		if name != "" && name != "self" && name != "cls" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// sameTransfer reports whether two summaries propagate taint the same way.
+func sameTransfer(a, b *TaintTransferSummary) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.IsSource == b.IsSource &&
+		a.IsSanitizer == b.IsSanitizer &&
+		a.ReturnTaintedBySource == b.ReturnTaintedBySource &&
+		maps.Equal(a.ParamToReturn, b.ParamToReturn) &&
+		maps.Equal(a.ParamToSink, b.ParamToSink)
+}
+
+// sortedFunctions returns the FQNs of the call graph's functions, sorted.
+func sortedFunctions(cg *core.CallGraph) []string {
+	return sortedKeys(cg.Functions)
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
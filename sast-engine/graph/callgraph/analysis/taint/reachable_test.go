@@ -0,0 +1,220 @@
+package taint
+
+import (
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// reachableTestGraph models:
+//
+//	def cli():                      def run(command):
+//	    cmd = input()                   os.system(command)
+//	    wrapper(cmd)
+//	                                def direct():
+//	def web():                          code = input()
+//	    cmd = os.getenv("CMD")          eval(code)
+//	    run(cmd)
+//	                                def safe():
+//	def wrapper(arg):                   cmd = "ls"
+//	    run(arg)                        run(cmd)
+func reachableTestGraph() *core.CallGraph {
+	cg := core.NewCallGraph()
+	addFunction := func(fqn string, params ...string) {
+		cg.Functions[fqn] = &graph.Node{Name: fqn, MethodArgumentsValue: params}
+	}
+	addCall := func(caller, target, targetFQN string, line int, args ...string) {
+		var arguments []core.Argument
+		for i, arg := range args {
+			arguments = append(arguments, core.Argument{Value: arg, IsVariable: true, Position: i})
+		}
+		cg.AddCallSite(caller, core.CallSite{
+			Target:    target,
+			TargetFQN: targetFQN,
+			Resolved:  true,
+			Location:  core.Location{Line: line},
+			Arguments: arguments,
+		})
+		cg.AddEdge(caller, targetFQN)
+	}
+
+	addFunction("app.cli")
+	cg.Statements["app.cli"] = []*core.Statement{
+		{Type: core.StatementTypeAssignment, LineNumber: 2, Def: "cmd", CallTarget: "input"},
+		{Type: core.StatementTypeCall, LineNumber: 3, CallTarget: "wrapper", Uses: []string{"cmd"}},
+	}
+	addCall("app.cli", "input", "builtins.input", 2)
+	addCall("app.cli", "wrapper", "app.wrapper", 3, "cmd")
+
+	addFunction("app.web")
+	cg.Statements["app.web"] = []*core.Statement{
+		{Type: core.StatementTypeAssignment, LineNumber: 6, Def: "cmd", CallTarget: "os.getenv"},
+		{Type: core.StatementTypeCall, LineNumber: 7, CallTarget: "run", Uses: []string{"cmd"}},
+	}
+	addCall("app.web", "os.getenv", "os.getenv", 6)
+	addCall("app.web", "run", "app.run", 7, "cmd")
+
+	addFunction("app.wrapper", "arg")
+	cg.Statements["app.wrapper"] = []*core.Statement{
+		{Type: core.StatementTypeCall, LineNumber: 10, CallTarget: "run", Uses: []string{"arg"}},
+	}
+	addCall("app.wrapper", "run", "app.run", 10, "arg")
+
+	addFunction("app.run", "command: str")
+	cg.Statements["app.run"] = []*core.Statement{
+		{Type: core.StatementTypeCall, LineNumber: 13, CallTarget: "os.system", Uses: []string{"command"}},
+	}
+	addCall("app.run", "os.system", "os.system", 13, "command")
+
+	addFunction("app.direct")
+	cg.Statements["app.direct"] = []*core.Statement{
+		{Type: core.StatementTypeAssignment, LineNumber: 16, Def: "code", CallTarget: "input"},
+		{Type: core.StatementTypeCall, LineNumber: 17, CallTarget: "eval", Uses: []string{"code"}},
+	}
+	addCall("app.direct", "input", "builtins.input", 16)
+	addCall("app.direct", "eval", "builtins.eval", 17, "code")
+
+	addFunction("app.safe")
+	cg.Statements["app.safe"] = []*core.Statement{
+		{Type: core.StatementTypeAssignment, LineNumber: 20, Def: "cmd"},
+		{Type: core.StatementTypeCall, LineNumber: 21, CallTarget: "run", Uses: []string{"cmd"}},
+	}
+	addCall("app.safe", "run", "app.run", 21, "cmd")
+
+	return cg
+}
+
+func TestAnalyzeReachableSinks(t *testing.T) {
+	cg := reachableTestGraph()
+
+	sinks := AnalyzeReachableSinks(cg, []string{"input", "os.getenv"}, []string{"os.system", "eval"})
+	require.Len(t, sinks, 2)
+
+	// eval is called directly on input in app.direct.
+	assert.Equal(t, ReachableSink{
+		SinkFQN: "builtins.eval",
+		Sources: []string{"builtins.input"},
+		Path:    []string{"app.direct"},
+	}, sinks[0])
+
+	// os.system is reached from both sources; the path through app.web is
+	// shorter than the one through app.wrapper.
+	assert.Equal(t, ReachableSink{
+		SinkFQN: "os.system",
+		Sources: []string{"builtins.input", "os.getenv"},
+		Path:    []string{"app.web", "app.run"},
+	}, sinks[1])
+}
+
+func TestAnalyzeReachableSinks_TransitiveChain(t *testing.T) {
+	cg := reachableTestGraph()
+
+	sinks := AnalyzeReachableSinks(cg, []string{"input"}, []string{"os.system"})
+	require.Len(t, sinks, 1)
+	assert.Equal(t, "os.system", sinks[0].SinkFQN)
+	assert.Equal(t, []string{"builtins.input"}, sinks[0].Sources)
+	assert.Equal(t, []string{"app.cli", "app.wrapper", "app.run"}, sinks[0].Path)
+}
+
+func TestAnalyzeReachableSinks_NoSources(t *testing.T) {
+	cg := reachableTestGraph()
+	assert.Empty(t, AnalyzeReachableSinks(cg, []string{"sys.argv"}, []string{"os.system", "eval"}))
+}
+
+func TestBuildTransferSummaries(t *testing.T) {
+	cg := reachableTestGraph()
+
+	summaries := BuildTransferSummaries(cg, []string{"input"}, []string{"os.system"}, nil)
+	require.Contains(t, summaries, "app.run")
+	assert.Equal(t, []string{"command"}, summaries["app.run"].ParamNames)
+	assert.True(t, summaries["app.run"].ParamToSink[0])
+
+	// wrapper only reaches the sink through run, so it needs a second round.
+	require.Contains(t, summaries, "app.wrapper")
+	assert.True(t, summaries["app.wrapper"].ParamToSink[0])
+	assert.Equal(t, "app.run", summaries["app.wrapper"].ParamToSinkCallee[0])
+	assert.Equal(t, 0, summaries["app.wrapper"].ParamToSinkArg[0])
+}
+
+// keywordTestGraph models:
+//
+//	def store(user, password):      def by_keyword():
+//	    os.system(password)             cmd = input()
+//	                                    store("x", password=cmd)
+//	def swapped():
+//	    cmd = input()               def mixed():
+//	    store(password="ls", user=cmd)  cmd = input()
+//	                                    store(cmd, password="ls")
+func keywordTestGraph() *core.CallGraph {
+	cg := core.NewCallGraph()
+	cg.Functions["app.store"] = &graph.Node{Name: "store", MethodArgumentsValue: []string{"user", "password"}}
+	cg.Statements["app.store"] = []*core.Statement{
+		{Type: core.StatementTypeCall, LineNumber: 2, CallTarget: "os.system", Uses: []string{"password"}},
+	}
+	cg.AddCallSite("app.store", core.CallSite{
+		Target: "os.system", TargetFQN: "os.system", Resolved: true, Location: core.Location{Line: 2},
+		Arguments: []core.Argument{{Value: "password", IsVariable: true}},
+	})
+	cg.AddEdge("app.store", "os.system")
+
+	addCaller := func(caller string, line int, args ...core.Argument) {
+		cg.Functions[caller] = &graph.Node{Name: caller}
+		cg.Statements[caller] = []*core.Statement{
+			{Type: core.StatementTypeAssignment, LineNumber: uint32(line), Def: "cmd", CallTarget: "input"},
+			{Type: core.StatementTypeCall, LineNumber: uint32(line + 1), CallTarget: "store", Uses: []string{"cmd"}},
+		}
+		cg.AddCallSite(caller, core.CallSite{Target: "input", TargetFQN: "builtins.input", Resolved: true, Location: core.Location{Line: line}})
+		cg.AddCallSite(caller, core.CallSite{
+			Target: "store", TargetFQN: "app.store", Resolved: true, Location: core.Location{Line: line + 1}, Arguments: args,
+		})
+		cg.AddEdge(caller, "builtins.input")
+		cg.AddEdge(caller, "app.store")
+	}
+	addCaller("app.by_keyword", 5,
+		core.Argument{Value: `"x"`, Position: 0},
+		core.Argument{Value: "password=cmd", Position: 1})
+	addCaller("app.swapped", 9,
+		core.Argument{Value: `password="ls"`, Position: 0},
+		core.Argument{Value: "user=cmd", Position: 1})
+	addCaller("app.mixed", 13,
+		core.Argument{Value: "cmd", IsVariable: true, Position: 0},
+		core.Argument{Value: `password="ls"`, Position: 1})
+	return cg
+}
+
+func TestAnalyzeReachableSinks_KeywordArguments(t *testing.T) {
+	cg := keywordTestGraph()
+
+	sinks := AnalyzeReachableSinks(cg, []string{"input"}, []string{"os.system"})
+	require.Len(t, sinks, 1)
+	// Only the call passing cmd as password reaches os.system; the others
+	// bind it to user, by keyword or by position.
+	assert.Equal(t, ReachableSink{
+		SinkFQN: "os.system",
+		Sources: []string{"builtins.input"},
+		Path:    []string{"app.by_keyword", "app.store"},
+	}, sinks[0])
+}
+
+func TestBoundCallSiteArgs(t *testing.T) {
+	cg := keywordTestGraph()
+	store := &TaintTransferSummary{ParamNames: []string{"user", "password"}}
+	call := func(caller string) *core.Statement {
+		return cg.Statements[caller][1]
+	}
+
+	args := boundCallSiteArgs(call("app.swapped"), "app.swapped", "app.store", cg, store)
+	require.Len(t, args, 2)
+	assert.Equal(t, core.Argument{Value: "cmd", IsVariable: true, Position: 1}, args[0])
+	assert.Equal(t, core.Argument{Value: `"ls"`, Position: 0}, args[1])
+
+	// Without the callee's node, keywords still bind by name.
+	delete(cg.Functions, "app.store")
+	args = boundCallSiteArgs(call("app.by_keyword"), "app.by_keyword", "app.store", cg, store)
+	require.Len(t, args, 2)
+	assert.Equal(t, `"x"`, args[0].Value)
+	assert.Equal(t, core.Argument{Value: "cmd", IsVariable: true, Position: 1}, args[1])
+}
@@ -0,0 +1,119 @@
+package taint
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/cfg"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// defSet maps a variable name to the def-site keys (see defSiteKeys) that
+// may define its current value.
+type defSet map[string]map[string]bool
+
+// clone returns a deep copy of the set.
+func (d defSet) clone() defSet {
+	out := make(defSet, len(d))
+	for varName, keys := range d {
+		out[varName] = maps.Clone(keys)
+	}
+	return out
+}
+
+// union adds every def in other to d.
+func (d defSet) union(other defSet) {
+	for varName, keys := range other {
+		if d[varName] == nil {
+			d[varName] = make(map[string]bool, len(keys))
+		}
+		for key := range keys {
+			d[varName][key] = true
+		}
+	}
+}
+
+// equal reports whether d and other hold the same defs.
+func (d defSet) equal(other defSet) bool {
+	return maps.EqualFunc(d, other, func(a, b map[string]bool) bool {
+		return maps.Equal(a, b)
+	})
+}
+
+// reachingDefs records, for each statement, the defs reaching the point just
+// before it executes.
+type reachingDefs map[*core.Statement]defSet
+
+// defsAt returns the sorted def-site keys of varName reaching stmt.
+func (r reachingDefs) defsAt(stmt *core.Statement, varName string) []string {
+	return slices.Sorted(maps.Keys(r[stmt][varName]))
+}
+
+// computeReachingDefs runs the classic reaching-definitions dataflow over the
+// CFG. Each definition of a variable kills all other definitions of it and
+// generates its own; at merge points the incoming sets are unioned.
+// Iteration runs to a fixed point, so loops are handled. Definitions are
+// identified by keys.
+func computeReachingDefs(cfGraph *cfg.ControlFlowGraph, blockStmts cfg.BlockStatements, keys map[*core.Statement]string) reachingDefs {
+	order := blockOrder(cfGraph)
+
+	transfer := func(in defSet, stmts []*core.Statement, record reachingDefs) defSet {
+		state := in.clone()
+		for _, stmt := range stmts {
+			if record != nil {
+				record[stmt] = state.clone()
+			}
+			if stmt.Def != "" {
+				state[stmt.Def] = map[string]bool{keys[stmt]: true}
+			}
+		}
+		return state
+	}
+
+	inOf := func(blockID string, out map[string]defSet) defSet {
+		in := make(defSet)
+		if block, ok := cfGraph.GetBlock(blockID); ok {
+			for _, predID := range block.Predecessors {
+				in.union(out[predID])
+			}
+		}
+		return in
+	}
+
+	out := make(map[string]defSet, len(order))
+	for changed := true; changed; {
+		changed = false
+		for _, blockID := range order {
+			newOut := transfer(inOf(blockID, out), blockStmts[blockID], nil)
+			if !newOut.equal(out[blockID]) {
+				out[blockID] = newOut
+				changed = true
+			}
+		}
+	}
+
+	result := make(reachingDefs)
+	for _, blockID := range order {
+		transfer(inOf(blockID, out), blockStmts[blockID], result)
+	}
+	return result
+}
+
+// blockOrder returns the block IDs reachable from entry in BFS order.
+func blockOrder(cfGraph *cfg.ControlFlowGraph) []string {
+	order := []string{cfGraph.EntryBlockID}
+	visited := map[string]bool{cfGraph.EntryBlockID: true}
+	for i := 0; i < len(order); i++ {
+		block, ok := cfGraph.GetBlock(order[i])
+		if !ok {
+			continue
+		}
+		for _, succID := range block.Successors {
+			if !visited[succID] {
+				visited[succID] = true
+				order = append(order, succID)
+			}
+		}
+	}
+	return order
+}
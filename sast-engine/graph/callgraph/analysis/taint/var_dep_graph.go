@@ -28,6 +28,10 @@ type VarDefSite struct {
 	CallTarget      string
 	CallChain       string
 	AttributeAccess string
+
+	// seq orders defs of a variable on the same line, e.g.
+	// a = source(); a = "clean", so the later one is the latest.
+	seq int
 }
 
 // VarDepGraph is a directed graph of variable data dependencies within a function.
@@ -50,6 +54,28 @@ func nodeKey(varName string, line uint32) string {
 	return fmt.Sprintf("%s@%d", varName, line)
 }
 
+// defSiteKeys returns the node key of each definition in statements. The
+// first def of a variable on a line is keyed "var@line" (see nodeKey), later
+// ones on the same line "var@line#2", "var@line#3"... so that each keeps its
+// own node: in a = source(); b = a; a = "clean" the clean def must not
+// replace the one b copied.
+func defSiteKeys(statements []*core.Statement) map[*core.Statement]string {
+	keys := make(map[*core.Statement]string)
+	seen := make(map[string]int)
+	for _, stmt := range statements {
+		if stmt.Def == "" {
+			continue
+		}
+		key := nodeKey(stmt.Def, stmt.LineNumber)
+		seen[key]++
+		if n := seen[key]; n > 1 {
+			key = fmt.Sprintf("%s#%d", key, n)
+		}
+		keys[stmt] = key
+	}
+	return keys
+}
+
 // Build constructs the VDG from statements.
 // sources/sinks/sanitizers are function name patterns.
 func (g *VarDepGraph) Build(
@@ -58,15 +84,39 @@ func (g *VarDepGraph) Build(
 	sinks []string,
 	sanitizers []string,
 ) {
-	for _, stmt := range statements {
+	g.build(statements, defSiteKeys(statements), sources, sanitizers, func(_ *core.Statement, varName string) []string {
+		if key, ok := g.LatestDef[varName]; ok {
+			return []string{key}
+		}
+		return nil
+	})
+}
+
+// defsAtFunc returns the def-site keys of varName that reach stmt.
+type defsAtFunc func(stmt *core.Statement, varName string) []string
+
+// build adds a node per definition, keyed by keys, and an edge from every
+// def of a used variable that reaches the defining statement, as reported
+// by defsAt. A plain copy (b = a) or augmented assignment (b += a) is an
+// edge like any other use, so the copy keeps the taint of the def it read
+// even when the original is reassigned afterwards.
+func (g *VarDepGraph) build(
+	statements []*core.Statement,
+	keys map[*core.Statement]string,
+	sources []string,
+	sanitizers []string,
+	defsAt defsAtFunc,
+) {
+	for i, stmt := range statements {
 		if stmt.Def == "" {
 			continue
 		}
 
-		key := nodeKey(stmt.Def, stmt.LineNumber)
+		key := keys[stmt]
 		node := &VarDefSite{
 			VarName:         stmt.Def,
 			Line:            stmt.LineNumber,
+			seq:             i + 1,
 			CallTarget:      stmt.CallTarget,
 			CallChain:       stmt.CallChain,
 			AttributeAccess: stmt.AttributeAccess,
@@ -91,11 +141,14 @@ func (g *VarDepGraph) Build(
 		if stmt.AttributeAccess != "" && matchesAnyPattern(stmt.AttributeAccess, sanitizers) {
 			node.IsSanitized = true
 		}
+		if isGuardedConversion(stmt) {
+			node.IsSanitized = true
+		}
 
 		g.Nodes[key] = node
 
 		for _, usedVar := range stmt.Uses {
-			if srcKey, ok := g.LatestDef[usedVar]; ok {
+			for _, srcKey := range defsAt(stmt, usedVar) {
 				g.Edges[srcKey] = append(g.Edges[srcKey], key)
 			}
 		}
@@ -115,6 +168,18 @@ func matchesAnyPattern(callTarget string, patterns []string) bool {
 
 // FindTaintFlows discovers taint flows from sources to sinks using BFS reachability.
 func (g *VarDepGraph) FindTaintFlows(statements []*core.Statement, sinks []string) []TaintDetection {
+	return g.findTaintFlows(statements, sinks, func(stmt *core.Statement, varName string) []string {
+		if key, ok := g.LatestDefAt(varName, stmt.LineNumber); ok {
+			return []string{key}
+		}
+		return nil
+	})
+}
+
+// findTaintFlows reports a flow for each source that reaches a sink argument
+// through any def of that argument reported by defsAt, without passing
+// through a sanitizer.
+func (g *VarDepGraph) findTaintFlows(statements []*core.Statement, sinks []string, defsAt defsAtFunc) []TaintDetection {
 	// Collect all taint source node keys
 	var sourceKeys []string
 	for key, node := range g.Nodes {
@@ -137,30 +202,35 @@ func (g *VarDepGraph) FindTaintFlows(statements []*core.Statement, sinks []strin
 		}
 
 		for _, usedVar := range stmt.Uses {
-			defKey, found := g.LatestDefAt(usedVar, stmt.LineNumber)
-			if !found {
+			defKeys := defsAt(stmt, usedVar)
+			if len(defKeys) == 0 {
 				continue
 			}
 
 			for _, srcKey := range sourceKeys {
-				path := g.findPath(srcKey, defKey)
-				if path == nil {
-					continue
-				}
-				if g.pathContainsSanitizer(path) {
-					continue
+				// One detection per source, via the first reaching def
+				// with an unsanitized path.
+				for _, defKey := range defKeys {
+					path := g.findPath(srcKey, defKey)
+					if path == nil {
+						continue
+					}
+					if g.pathContainsSanitizer(path) {
+						continue
+					}
+
+					srcNode := g.Nodes[srcKey]
+					detections = append(detections, TaintDetection{
+						SourceLine:      srcNode.Line,
+						SourceVar:       srcNode.VarName,
+						SinkLine:        stmt.LineNumber,
+						SinkCall:        stmt.CallTarget,
+						SinkVar:         usedVar,
+						PropagationPath: g.pathToVarNames(path),
+						Confidence:      1.0,
+					})
+					break
 				}
-
-				srcNode := g.Nodes[srcKey]
-				detections = append(detections, TaintDetection{
-					SourceLine:      srcNode.Line,
-					SourceVar:       srcNode.VarName,
-					SinkLine:        stmt.LineNumber,
-					SinkCall:        stmt.CallTarget,
-					SinkVar:         usedVar,
-					PropagationPath: g.pathToVarNames(path),
-					Confidence:      1.0,
-				})
 			}
 		}
 	}
@@ -169,23 +239,22 @@ func (g *VarDepGraph) FindTaintFlows(statements []*core.Statement, sinks []strin
 }
 
 // LatestDefAt finds the node with matching VarName and Line <= beforeLine,
-// with the highest Line value. Returns the node key and true, or ("", false).
+// with the highest Line value, or the last of several defs on that line.
+// Returns the node key and true, or ("", false).
 func (g *VarDepGraph) LatestDefAt(varName string, beforeLine uint32) (string, bool) {
+	var best *VarDefSite
 	var bestKey string
-	var bestLine uint32
-	found := false
 
 	for key, node := range g.Nodes {
-		if node.VarName == varName && node.Line <= beforeLine {
-			if !found || node.Line > bestLine {
-				bestKey = key
-				bestLine = node.Line
-				found = true
-			}
+		if node.VarName != varName || node.Line > beforeLine {
+			continue
+		}
+		if best == nil || node.Line > best.Line || (node.Line == best.Line && node.seq > best.seq) {
+			best, bestKey = node, key
 		}
 	}
 
-	return bestKey, found
+	return bestKey, best != nil
 }
 
 // findPath performs BFS from src to dst and returns the path as node keys, or nil if unreachable.
@@ -249,12 +318,15 @@ func AnalyzeWithVDG(
 	sinks []string,
 	sanitizers []string,
 ) *core.TaintSummary {
-	summary := core.NewTaintSummary(functionFQN)
-
 	vdg := NewVarDepGraph()
 	vdg.Build(statements, sources, sinks, sanitizers)
 
-	detections := vdg.FindTaintFlows(statements, sinks)
+	return detectionsToSummary(functionFQN, vdg.FindTaintFlows(statements, sinks))
+}
+
+// detectionsToSummary converts VDG detections into a TaintSummary.
+func detectionsToSummary(functionFQN string, detections []TaintDetection) *core.TaintSummary {
+	summary := core.NewTaintSummary(functionFQN)
 
 	for _, det := range detections {
 		taintInfo := &core.TaintInfo{
@@ -282,6 +354,11 @@ func AnalyzeWithVDG(
 // then runs VDG analysis over the complete statement set.
 // This captures taint flows through control flow bodies (if/for/while/try/with)
 // that the flat ExtractStatements approach misses.
+//
+// Uses are linked to their reaching definitions on the CFG (kill/gen), so a
+// reassignment kills prior taint only when it happens on every path to the
+// use. For example, x = source(); if c: x = "const"; sink(x) is still
+// tainted through the path where the branch is not taken.
 func AnalyzeWithCFG(
 	functionFQN string,
 	cfGraph *cfg.ControlFlowGraph,
@@ -292,9 +369,13 @@ func AnalyzeWithCFG(
 ) *core.TaintSummary {
 	// Flatten block statements in topological order (BFS from entry)
 	allStatements := FlattenBlockStatements(cfGraph, blockStmts)
+	keys := defSiteKeys(allStatements)
+	reaching := computeReachingDefs(cfGraph, blockStmts, keys)
+
+	vdg := NewVarDepGraph()
+	vdg.build(allStatements, keys, sources, sanitizers, reaching.defsAt)
 
-	// Use existing VDG analysis on the flattened statements
-	return AnalyzeWithVDG(functionFQN, allStatements, sources, sinks, sanitizers)
+	return detectionsToSummary(functionFQN, vdg.findTaintFlows(allStatements, sinks, reaching.defsAt))
 }
 
 // FlattenBlockStatements collects statements from all blocks in BFS order from entry.
@@ -334,7 +415,7 @@ func FlattenBlockStatements(cfGraph *cfg.ControlFlowGraph, blockStmts cfg.BlockS
 func (g *VarDepGraph) pathToVarNames(path []string) []string {
 	names := make([]string, len(path))
 	for i, key := range path {
-		// Node key format is "varname@line"
+		// Node key format is "varname@line" or "varname@line#n"
 		if node, ok := g.Nodes[key]; ok {
 			names[i] = node.VarName
 		} else {
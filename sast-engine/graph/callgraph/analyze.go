@@ -0,0 +1,323 @@
+package callgraph
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/analysis/taint"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/builder"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/patterns"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/resolution"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+)
+
+// AnalyzeOptions configures Analyze. The zero value analyzes the whole
+// project with the default security patterns.
+type AnalyzeOptions struct {
+	// CodeGraph is an already-parsed code graph for the project.
+	// When nil, Analyze parses the project itself.
+	CodeGraph *graph.CodeGraph
+
+	// Logger receives diagnostics. Defaults to VerbosityDefault.
+	Logger *output.Logger
+
+	// SkipTests excludes test files from the module registry.
+	SkipTests bool
+
+	// IncludeNotebooks analyzes the Jupyter notebooks in the project as
+	// Python modules. Findings in them are reported by cell (see
+	// registry.BuildModuleRegistryWithNotebooks).
+	IncludeNotebooks bool
+
+	// PatternRegistry holds the patterns to check.
+	// When nil, the default patterns are loaded, with the request sources of
+	// the framework the project imports (see patterns.DetectRulesetFramework).
+	PatternRegistry *patterns.PatternRegistry
+
+	// GlobalSources are added to the request sources of the default patterns
+	// (see patterns.PatternRegistry.GlobalSources). Unused when
+	// PatternRegistry is set.
+	GlobalSources []string
+
+	// TargetFiles restricts analysis to these files and what they touch
+	// (see builder.BuildForFiles). When empty, the whole project is analyzed.
+	TargetFiles []string
+
+	// SourceMapper translates finding locations back to original sources,
+	// e.g. when the project contains Python extracted from notebooks.
+	SourceMapper core.SourceMapper
+
+	// Status, when set, keeps only the matches with that status, e.g.
+	// patterns.MatchStatusConfirmed to drop flows that rely on speculative
+	// call edges. When empty, all matches are reported.
+	Status patterns.MatchStatus
+
+	// Baseline, when set, is the path of a baseline saved by SaveBaseline.
+	// Matches in it are still reported, with Suppression set to
+	// patterns.SuppressedByBaseline, so only findings introduced since
+	// count. A malformed baseline fails the analysis.
+	Baseline string
+
+	// Progress, when set, receives the call graph build's progress (see
+	// builder.BuildOptions.Progress).
+	Progress func(builder.ProgressEvent)
+}
+
+// AnalysisResult bundles everything produced by Analyze.
+type AnalysisResult struct {
+	CallGraph       *core.CallGraph
+	ModuleRegistry  *core.ModuleRegistry
+	PatternRegistry *patterns.PatternRegistry
+
+	// Languages are the languages whose code is in CallGraph, sorted
+	// (core.LanguageGo, core.LanguagePython). Go FQNs are namespaced (see
+	// core.NamespacedFQN).
+	Languages []string
+
+	// Matches are pattern findings, one per matched pattern (see
+	// AnalyzePatterns), with duplicates merged (see DedupeMatches).
+	Matches []SecurityMatch
+
+	// Summary counts Matches by file, module, severity, and rule (see
+	// Summarize).
+	Summary Summary
+
+	// TaintFlows are all intra-procedural source-to-sink flows found by
+	// running each source-sink and missing-sanitizer pattern over every function.
+	TaintFlows []TaintFlow
+
+	Metrics AnalysisMetrics
+}
+
+// TaintFlow is a single intra-procedural taint detection for a pattern.
+type TaintFlow struct {
+	PatternID   string          // Pattern whose sources and sinks matched
+	FunctionFQN string          // Function containing the flow
+	Detection   *core.TaintInfo // Source line, sink line and sink call
+
+	// Suppression is set when the flow is reported but exempted, e.g. its
+	// sink is allowlisted in the function's module (see
+	// patterns.PatternRegistry.SinkAllowlist).
+	Suppression patterns.Suppression
+}
+
+// AnalysisMetrics summarizes an Analyze run.
+type AnalysisMetrics struct {
+	Modules           int
+	Functions         int
+	CallSites         int
+	ResolvedCallSites int
+	Patterns          int
+	Matches           int
+	TaintFlows        int
+
+	BuildDuration   time.Duration // Module registry and call graph construction
+	PatternDuration time.Duration // Pattern matching
+	TaintDuration   time.Duration // Pattern-driven taint analysis
+	TotalDuration   time.Duration
+}
+
+// Analyze runs the complete analysis pipeline on a Python project:
+//  1. Module registry building
+//  2. Call graph construction, combined with the Go call graph when the
+//     project root has a go.mod (whole-project runs only), and with the
+//     calls running a program of the other language recorded as external
+//     edges (see builder.LinkCrossLanguageCalls)
+//  3. Pattern detection
+//  4. Pattern-driven intra-procedural taint analysis
+//
+// This is the single entry point for embedding the analyzer. Use the
+// individual packages directly for finer control.
+//
+// Parameters:
+//   - projectPath: path to project root
+//   - opts: optional code graph, logger, patterns and target files
+//
+// Returns:
+//   - AnalysisResult: call graph, registries, findings and metrics
+//   - error: if the baseline cannot be read, or the module registry or call
+//     graph cannot be built
+func Analyze(projectPath string, opts AnalyzeOptions) (*AnalysisResult, error) {
+	start := time.Now()
+
+	var baseline *output.Baseline
+	if opts.Baseline != "" {
+		var err error
+		if baseline, err = output.LoadBaseline(opts.Baseline); err != nil {
+			return nil, err
+		}
+	}
+
+	logger := opts.Logger
+	if logger == nil {
+		logger = output.NewLogger(output.VerbosityDefault)
+	}
+
+	codeGraph := opts.CodeGraph
+	if codeGraph == nil {
+		codeGraph = graph.Initialize(projectPath, nil)
+	}
+
+	buildOptions := builder.BuildOptions{
+		CodeGraph:        codeGraph,
+		Logger:           logger,
+		SkipTests:        opts.SkipTests,
+		IncludeNotebooks: opts.IncludeNotebooks,
+		SourceMapper:     opts.SourceMapper,
+		Progress:         opts.Progress,
+	}
+	var callGraph *core.CallGraph
+	var moduleRegistry *core.ModuleRegistry
+	var err error
+	if len(opts.TargetFiles) > 0 {
+		callGraph, moduleRegistry, err = builder.BuildForFiles(projectPath, opts.TargetFiles, buildOptions)
+	} else {
+		callGraph, moduleRegistry, err = builder.BuildWithOptions(projectPath, buildOptions)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var languages []string
+	if len(moduleRegistry.Modules) > 0 {
+		languages = append(languages, core.LanguagePython)
+	}
+	if len(opts.TargetFiles) == 0 {
+		if goRegistry := buildGoCallGraph(projectPath, codeGraph, callGraph, logger); goRegistry != nil {
+			languages = append(languages, core.LanguageGo)
+			builder.LinkCrossLanguageCalls(callGraph, projectPath, moduleRegistry, goRegistry)
+		}
+	}
+	slices.Sort(languages)
+	buildDone := time.Now()
+
+	patternRegistry := opts.PatternRegistry
+	if patternRegistry == nil {
+		patternRegistry = patterns.NewPatternRegistry()
+		patternRegistry.Framework = patterns.DetectRulesetFramework(callGraph)
+		patternRegistry.GlobalSources = opts.GlobalSources
+		patternRegistry.LoadDefaultPatterns()
+	}
+
+	matches := DedupeMatches(AnalyzePatterns(callGraph, patternRegistry))
+	if opts.Status != "" {
+		matches = slices.DeleteFunc(matches, func(match SecurityMatch) bool {
+			return match.Status != opts.Status
+		})
+	}
+	if baseline != nil {
+		suppressBaselined(matches, baseline)
+	}
+	patternsDone := time.Now()
+
+	taintFlows := analyzeTaintFlows(callGraph, patternRegistry)
+	taintDone := time.Now()
+
+	result := &AnalysisResult{
+		CallGraph:       callGraph,
+		ModuleRegistry:  moduleRegistry,
+		PatternRegistry: patternRegistry,
+		Languages:       languages,
+		Matches:         matches,
+		Summary:         Summarize(matches, moduleRegistry),
+		TaintFlows:      taintFlows,
+		Metrics: AnalysisMetrics{
+			Modules:         len(moduleRegistry.Modules),
+			Functions:       len(callGraph.Functions),
+			Patterns:        len(patternRegistry.Patterns),
+			Matches:         len(matches),
+			TaintFlows:      len(taintFlows),
+			BuildDuration:   buildDone.Sub(start),
+			PatternDuration: patternsDone.Sub(buildDone),
+			TaintDuration:   taintDone.Sub(patternsDone),
+			TotalDuration:   taintDone.Sub(start),
+		},
+	}
+	for _, callSites := range callGraph.CallSites {
+		result.Metrics.CallSites += len(callSites)
+		for _, callSite := range callSites {
+			if callSite.Resolved {
+				result.Metrics.ResolvedCallSites++
+			}
+		}
+	}
+
+	logger.Debug("Analysis complete: %d functions, %d matches, %d taint flows in %v",
+		result.Metrics.Functions, result.Metrics.Matches, result.Metrics.TaintFlows, result.Metrics.TotalDuration)
+
+	return result, nil
+}
+
+// buildGoCallGraph builds the call graph of the Go module at projectPath,
+// if it has a go.mod, and merges it into callGraph with its FQNs namespaced
+// (see builder.NamespaceCallGraph). Returns the Go module registry, or nil
+// if there is no Go module or its graph cannot be built.
+func buildGoCallGraph(projectPath string, codeGraph *graph.CodeGraph, callGraph *core.CallGraph, logger *output.Logger) *core.GoModuleRegistry {
+	if _, err := os.Stat(filepath.Join(projectPath, "go.mod")); err != nil {
+		return nil
+	}
+	goRegistry, err := resolution.BuildGoModuleRegistry(projectPath)
+	if err != nil {
+		logger.Warning("Failed to build Go module registry: %v", err)
+		return nil
+	}
+	goCallGraph, err := builder.BuildGoCallGraph(codeGraph, goRegistry, resolution.NewGoTypeInferenceEngine(goRegistry), logger, nil)
+	if err != nil {
+		logger.Warning("Failed to build Go call graph: %v", err)
+		return nil
+	}
+	builder.NamespaceCallGraph(goCallGraph, core.LanguageGo)
+	builder.MergeCallGraphs(callGraph, goCallGraph)
+	return goRegistry
+}
+
+// analyzeTaintFlows runs every enabled source-sink and missing-sanitizer
+// pattern over the statements of each function, returning flows sorted by
+// pattern ID, function FQN, then sink line. Other pattern types match sinks in their own
+// way (e.g., only the URL argument of a framework redirect) and are skipped.
+// Flows into sinks allowlisted for their module are kept, marked suppressed.
+func analyzeTaintFlows(callGraph *core.CallGraph, patternRegistry *patterns.PatternRegistry) []TaintFlow {
+	var flows []TaintFlow
+	for _, pattern := range patternRegistry.Patterns {
+		if pattern.Type != patterns.PatternTypeSourceSink && pattern.Type != patterns.PatternTypeMissingSanitizer {
+			continue
+		}
+		if !patternRegistry.IsEnabled(pattern.ID) {
+			continue
+		}
+		for funcFQN, statements := range callGraph.Statements {
+			summary := taint.AnalyzeIntraProceduralTaint(
+				funcFQN,
+				statements,
+				core.BuildDefUseChains(statements),
+				pattern.Sources,
+				pattern.Sinks,
+				pattern.Sanitizers,
+			)
+			for _, detection := range patterns.DetectionsAtSinkArguments(pattern, funcFQN, summary.Detections, callGraph) {
+				flows = append(flows, TaintFlow{
+					PatternID:   pattern.ID,
+					FunctionFQN: funcFQN,
+					Detection:   detection,
+					Suppression: patternRegistry.SinkSuppression(funcFQN, int(detection.SinkLine), callGraph),
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(flows, func(i, j int) bool {
+		if flows[i].PatternID != flows[j].PatternID {
+			return flows[i].PatternID < flows[j].PatternID
+		}
+		if flows[i].FunctionFQN != flows[j].FunctionFQN {
+			return flows[i].FunctionFQN < flows[j].FunctionFQN
+		}
+		return flows[i].Detection.SinkLine < flows[j].Detection.SinkLine
+	})
+
+	return flows
+}
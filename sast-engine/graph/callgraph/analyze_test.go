@@ -0,0 +1,341 @@
+package callgraph
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/patterns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyze_DjangoFixture(t *testing.T) {
+	projectPath, err := filepath.Abs("../../test-fixtures/python/django_sources")
+	require.NoError(t, err)
+
+	result, err := Analyze(projectPath, AnalyzeOptions{})
+	require.NoError(t, err)
+
+	// Symbols
+	assert.Contains(t, result.CallGraph.Functions, "views.files_view")
+	assert.Contains(t, result.CallGraph.Functions, "views.safe_view")
+	assert.Contains(t, result.ModuleRegistry.Modules, "views")
+
+	// Pattern finding
+	require.NotEmpty(t, result.Matches)
+	assert.Equal(t, "Code injection via eval with user input", result.Matches[0].PatternName)
+	assert.Equal(t, "CWE-94", result.Matches[0].CWE)
+
+	// Every eval of request data is a taint flow; the constant one is not.
+	flowFunctions := make(map[string]bool)
+	for _, flow := range result.TaintFlows {
+		assert.Equal(t, "CODE-INJECTION-001", flow.PatternID)
+		assert.Contains(t, flow.Detection.SinkCall, "eval")
+		flowFunctions[flow.FunctionFQN] = true
+	}
+	for _, fn := range []string{"views.files_view", "views.cookies_view", "views.meta_view", "views.body_view", "views.session_view"} {
+		assert.True(t, flowFunctions[fn], "%s should have a taint flow", fn)
+	}
+	assert.False(t, flowFunctions["views.safe_view"])
+
+	// Metrics
+	assert.Equal(t, 1, result.Metrics.Modules)
+	assert.Equal(t, len(result.CallGraph.Functions), result.Metrics.Functions)
+	assert.Equal(t, len(result.Matches), result.Metrics.Matches)
+	assert.Equal(t, len(result.TaintFlows), result.Metrics.TaintFlows)
+	assert.Equal(t, len(result.PatternRegistry.Patterns), result.Metrics.Patterns)
+	assert.Positive(t, result.Metrics.CallSites)
+	assert.LessOrEqual(t, result.Metrics.ResolvedCallSites, result.Metrics.CallSites)
+	assert.GreaterOrEqual(t, result.Metrics.TotalDuration, result.Metrics.BuildDuration)
+}
+
+func TestAnalyze_CustomPatternsAndTargetFiles(t *testing.T) {
+	projectPath, err := filepath.Abs("../../test-fixtures/python/open_redirect")
+	require.NoError(t, err)
+
+	patternRegistry := patterns.NewPatternRegistry()
+	patternRegistry.LoadDefaultPatterns()
+	pattern, ok := patternRegistry.GetPattern("OPEN-REDIRECT-001")
+	require.True(t, ok)
+	custom := patterns.NewPatternRegistry()
+	custom.AddPattern(pattern)
+
+	result, err := Analyze(projectPath, AnalyzeOptions{
+		PatternRegistry: custom,
+		TargetFiles:     []string{"views.py"},
+	})
+	require.NoError(t, err)
+
+	assert.Same(t, custom, result.PatternRegistry)
+	require.Len(t, result.Matches, 2, "every redirect in views.py is reported")
+	assert.Equal(t, "CWE-601", result.Matches[0].CWE)
+	assert.Equal(t, "views.login_redirect", result.Matches[0].SinkFQN)
+	assert.Equal(t, "views.logout_redirect", result.Matches[1].SinkFQN)
+	assert.Empty(t, result.TaintFlows, "open redirect is not a generic taint pattern")
+	assert.Empty(t, result.CallGraph.CallSites["flask_app.flask_next"], "files outside the target scope are not analyzed")
+}
+
+func TestAnalyze_InvalidPath(t *testing.T) {
+	_, err := Analyze("/nonexistent/path", AnalyzeOptions{})
+	assert.Error(t, err)
+}
+
+func TestAnalyze_SourceMapper(t *testing.T) {
+	projectPath, err := filepath.Abs("../../test-fixtures/python/open_redirect")
+	require.NoError(t, err)
+
+	plain, err := Analyze(projectPath, AnalyzeOptions{})
+	require.NoError(t, err)
+
+	// Pretend every file was extracted from a notebook whose code starts at
+	// line 100 of the original.
+	offsetMapper := func(file string, line int) (string, int) {
+		return strings.TrimSuffix(file, ".py") + ".ipynb", line + 99
+	}
+	mapped, err := Analyze(projectPath, AnalyzeOptions{SourceMapper: offsetMapper})
+	require.NoError(t, err)
+
+	require.NotEmpty(t, plain.Matches)
+	require.Len(t, mapped.Matches, len(plain.Matches))
+	for i, match := range mapped.Matches {
+		original := plain.Matches[i]
+		require.NotEmpty(t, original.SinkFile)
+		assert.Equal(t, strings.TrimSuffix(original.SinkFile, ".py")+".ipynb", match.SinkFile)
+		assert.Equal(t, original.SinkLine+99, match.SinkLine)
+		assert.Equal(t, original.SinkCode, match.SinkCode, "snippets come from the analyzed file")
+	}
+
+	// Stored call sites stay in analyzed-file coordinates.
+	assert.Equal(t, plain.CallGraph.CallSites["views.login_redirect"], mapped.CallGraph.CallSites["views.login_redirect"])
+}
+
+func TestAnalyze_Notebook(t *testing.T) {
+	projectPath, err := filepath.Abs("../../test-fixtures/python/notebook_eval")
+	require.NoError(t, err)
+
+	plain, err := Analyze(projectPath, AnalyzeOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, plain.Matches, "notebooks are opt-in")
+
+	result, err := Analyze(projectPath, AnalyzeOptions{IncludeNotebooks: true})
+	require.NoError(t, err)
+	assert.Contains(t, result.CallGraph.Functions, "analysis.evaluate")
+	assert.Contains(t, result.CallGraph.Functions, "analysis.area")
+
+	require.Len(t, result.Matches, 1)
+	match := result.Matches[0]
+	assert.Equal(t, "CWE-94", match.CWE)
+	assert.Equal(t, filepath.Join(projectPath, "analysis.ipynb")+"#cell3", match.SinkFile)
+	assert.Equal(t, uint32(3), match.SinkLine)
+	assert.Equal(t, "return eval(expression)", match.SinkCode)
+
+	require.Len(t, result.TaintFlows, 1)
+	assert.Equal(t, "analysis.evaluate", result.TaintFlows[0].FunctionFQN)
+}
+
+func TestAnalyze_StatusFilter(t *testing.T) {
+	projectPath, err := filepath.Abs("../../test-fixtures/python/django_sources")
+	require.NoError(t, err)
+
+	all, err := Analyze(projectPath, AnalyzeOptions{})
+	require.NoError(t, err)
+	require.NotEmpty(t, all.Matches)
+	for _, match := range all.Matches {
+		assert.Equal(t, patterns.MatchStatusConfirmed, match.Status, match.PatternName)
+	}
+
+	confirmed, err := Analyze(projectPath, AnalyzeOptions{Status: patterns.MatchStatusConfirmed})
+	require.NoError(t, err)
+	assert.Len(t, confirmed.Matches, len(all.Matches))
+
+	potential, err := Analyze(projectPath, AnalyzeOptions{Status: patterns.MatchStatusPotential})
+	require.NoError(t, err)
+	assert.Empty(t, potential.Matches)
+	assert.Equal(t, 0, potential.Metrics.Matches)
+}
+
+func TestAnalyze_DisabledPatterns(t *testing.T) {
+	projectPath, err := filepath.Abs("../../test-fixtures/python/django_sources")
+	require.NoError(t, err)
+
+	patternRegistry := patterns.NewPatternRegistry()
+	patternRegistry.LoadDefaultPatterns()
+	patternRegistry.DisabledPatterns = []string{"CODE-INJECTION-001"}
+
+	result, err := Analyze(projectPath, AnalyzeOptions{PatternRegistry: patternRegistry})
+	require.NoError(t, err)
+
+	for _, match := range result.Matches {
+		assert.NotEqual(t, "CWE-94", match.CWE)
+	}
+	assert.Empty(t, result.TaintFlows)
+}
+
+func TestAnalyze_CodeInjectionFixture(t *testing.T) {
+	projectPath, err := filepath.Abs("../../test-fixtures/python/code_injection")
+	require.NoError(t, err)
+
+	result, err := Analyze(projectPath, AnalyzeOptions{})
+	require.NoError(t, err)
+
+	flows := make(map[string]string)
+	for _, flow := range result.TaintFlows {
+		flows[flow.FunctionFQN] = flow.PatternID
+	}
+	assert.Equal(t, "CODE-INJECTION-001", flows["app.eval_expression"])
+	assert.Equal(t, "CODE-INJECTION-001", flows["app.exec_code"])
+	assert.Equal(t, "CODE-INJECTION-001", flows["app.compile_source"])
+	assert.Equal(t, "CODE-INJECTION-002", flows["app.load_plugin"])
+	assert.Equal(t, "CODE-INJECTION-002", flows["app.load_builtin_import"])
+
+	// Tainted globals for constant code, and re.compile, are not code injection.
+	assert.NotContains(t, flows, "app.exec_constant")
+	assert.NotContains(t, flows, "app.search")
+
+	severities := make(map[string]string)
+	for _, match := range result.Matches {
+		severities[match.CWE] = match.Severity
+	}
+	assert.Equal(t, "critical", severities["CWE-94"])
+	assert.Equal(t, "high", severities["CWE-470"])
+}
+
+func TestAnalyze_SubprocessArgvFixture(t *testing.T) {
+	projectPath, err := filepath.Abs("../../test-fixtures/python/subprocess_argv")
+	require.NoError(t, err)
+
+	result, err := Analyze(projectPath, AnalyzeOptions{})
+	require.NoError(t, err)
+
+	flows := make(map[string][]string)
+	for _, flow := range result.TaintFlows {
+		flows[flow.FunctionFQN] = append(flows[flow.FunctionFQN], flow.PatternID)
+	}
+	// A tainted program is COMMAND-INJECTION-002, a tainted later argument
+	// COMMAND-INJECTION-003, and a shell command COMMAND-INJECTION-001.
+	assert.Equal(t, []string{"COMMAND-INJECTION-002"}, flows["app.run_tool"])
+	assert.Equal(t, []string{"COMMAND-INJECTION-002"}, flows["app.launch"])
+	assert.Equal(t, []string{"COMMAND-INJECTION-003"}, flows["app.convert"])
+	assert.Equal(t, []string{"COMMAND-INJECTION-003"}, flows["app.archive"])
+	assert.Equal(t, []string{"COMMAND-INJECTION-001"}, flows["app.ping"])
+	assert.NotContains(t, flows, "app.version")
+
+	severities := make(map[string]string)
+	for _, id := range []string{"COMMAND-INJECTION-001", "COMMAND-INJECTION-002", "COMMAND-INJECTION-003"} {
+		pattern, ok := result.PatternRegistry.GetPattern(id)
+		require.True(t, ok)
+		severities[id] = string(pattern.Severity)
+	}
+	assert.Equal(t, map[string]string{
+		"COMMAND-INJECTION-001": "critical",
+		"COMMAND-INJECTION-002": "high",
+		"COMMAND-INJECTION-003": "medium",
+	}, severities)
+}
+
+func TestAnalyze_SinkAllowlist(t *testing.T) {
+	projectPath, err := filepath.Abs("../../test-fixtures/python/sink_allowlist")
+	require.NoError(t, err)
+
+	suppressions := func(registry *patterns.PatternRegistry) map[string]patterns.Suppression {
+		result, err := Analyze(projectPath, AnalyzeOptions{PatternRegistry: registry})
+		require.NoError(t, err)
+		byFunction := make(map[string]patterns.Suppression)
+		for _, flow := range result.TaintFlows {
+			if flow.PatternID == "COMMAND-INJECTION-001" {
+				byFunction[flow.FunctionFQN] = flow.Suppression
+			}
+		}
+		return byFunction
+	}
+
+	registry := patterns.NewPatternRegistry()
+	registry.LoadDefaultPatterns()
+	assert.Equal(t, map[string]patterns.Suppression{
+		"ops.views.restart":                 "",
+		"ops.maintenance.tasks.rotate_logs": "",
+	}, suppressions(registry))
+
+	// Exempting os.system in the maintenance package keeps its flow,
+	// reported as suppressed; the handler is still flagged.
+	registry = patterns.NewPatternRegistry()
+	registry.LoadDefaultPatterns()
+	require.NoError(t, registry.LoadSinkAllowlistConfig([]byte(`
+sink_allowlist:
+  - sink: os.system
+    module: ops.maintenance
+`)))
+	assert.Equal(t, map[string]patterns.Suppression{
+		"ops.views.restart":                 "",
+		"ops.maintenance.tasks.rotate_logs": patterns.SuppressedByAllowlist,
+	}, suppressions(registry))
+}
+
+func TestAnalyze_FrameworkRuleset(t *testing.T) {
+	projectPath, err := filepath.Abs("../../test-fixtures/python/framework_rulesets")
+	require.NoError(t, err)
+
+	result, err := Analyze(projectPath, AnalyzeOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, patterns.FrameworkFlask, result.PatternRegistry.Framework)
+
+	flows := make(map[string]bool)
+	for _, flow := range result.TaintFlows {
+		flows[flow.FunctionFQN] = true
+	}
+	assert.True(t, flows["app.flask_view"])
+	assert.False(t, flows["app.django_view"], "Django sources are not loaded for a Flask project")
+
+	// Global sources are added to the detected framework's.
+	result, err = Analyze(projectPath, AnalyzeOptions{GlobalSources: []string{"request.GET"}})
+	require.NoError(t, err)
+	flows = make(map[string]bool)
+	for _, flow := range result.TaintFlows {
+		flows[flow.FunctionFQN] = true
+	}
+	assert.True(t, flows["app.flask_view"])
+	assert.True(t, flows["app.django_view"])
+}
+
+func TestAnalyze_MultiLanguage(t *testing.T) {
+	projectPath, err := filepath.Abs("../../test-fixtures/multi_language")
+	require.NoError(t, err)
+
+	result, err := Analyze(projectPath, AnalyzeOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{core.LanguageGo, core.LanguagePython}, result.Languages)
+
+	goMain := "go:example.com/polyglot/cmd/worker.main"
+	assert.Contains(t, result.CallGraph.Functions, "app.run_worker")
+	assert.Contains(t, result.CallGraph.Functions, "scripts.report.main")
+	assert.Contains(t, result.CallGraph.Functions, goMain)
+	assert.NotContains(t, result.CallGraph.Functions, "example.com/polyglot/cmd/worker.main", "Go FQNs are namespaced")
+
+	require.Len(t, result.CallGraph.ExternalEdges, 2)
+	assert.Equal(t, core.ExternalEdge{
+		Caller:   "app.run_worker",
+		Language: core.LanguageGo,
+		Command:  "go run ./cmd/worker",
+		Target:   goMain,
+		Location: result.CallGraph.ExternalEdges[0].Location,
+	}, result.CallGraph.ExternalEdges[0])
+	assert.Equal(t, goMain, result.CallGraph.ExternalEdges[1].Caller)
+	assert.Equal(t, core.LanguagePython, result.CallGraph.ExternalEdges[1].Language)
+	assert.Equal(t, "python3 scripts/report.py --daily", result.CallGraph.ExternalEdges[1].Command)
+	assert.Equal(t, "scripts.report.main", result.CallGraph.ExternalEdges[1].Target)
+
+	assert.Contains(t, result.CallGraph.Edges["app.run_worker"], goMain)
+	assert.Contains(t, result.CallGraph.Edges[goMain], "scripts.report.main")
+}
+
+func TestAnalyze_PythonOnlyLanguages(t *testing.T) {
+	projectPath, err := filepath.Abs("../../test-fixtures/python/framework_rulesets")
+	require.NoError(t, err)
+
+	result, err := Analyze(projectPath, AnalyzeOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{core.LanguagePython}, result.Languages)
+	assert.Empty(t, result.CallGraph.ExternalEdges)
+}
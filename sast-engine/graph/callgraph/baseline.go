@@ -0,0 +1,66 @@
+package callgraph
+
+import (
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/patterns"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+)
+
+// SaveBaseline writes the findings of a scan to path as a baseline, so later
+// runs of Analyze with AnalyzeOptions.Baseline suppress them and only new
+// findings count.
+func SaveBaseline(matches []SecurityMatch, path string) error {
+	entries := make([]output.BaselineEntry, 0, len(matches))
+	for _, match := range matches {
+		entries = append(entries, output.BaselineEntry{
+			Fingerprint: match.Fingerprint,
+			RuleID:      match.PatternID,
+			Function:    match.SinkFQN,
+			File:        match.SinkFile,
+			Line:        int(match.SinkLine),
+		})
+	}
+	return output.SaveBaseline(path, entries)
+}
+
+// suppressBaselined marks the matches in baseline SuppressedByBaseline,
+// pairing them by Fingerprint as output.Baseline.Baselined does. Matches
+// already suppressed keep their reason.
+func suppressBaselined(matches []SecurityMatch, baseline *output.Baseline) {
+	fingerprints := make([]string, len(matches))
+	for i, match := range matches {
+		fingerprints[i] = match.Fingerprint
+	}
+	for i, baselined := range baseline.Baselined(fingerprints) {
+		if baselined && matches[i].Suppression == "" {
+			matches[i].Suppression = patterns.SuppressedByBaseline
+		}
+	}
+}
+
+// DiffFindings compares the findings of a scan against a baseline by
+// Fingerprint. New holds current findings absent from the baseline and
+// fixed holds baseline findings no longer reported, each in input order.
+// Findings sharing a fingerprint (the same line twice in one function)
+// are matched by count.
+func DiffFindings(baseline, current []SecurityMatch) (newFindings, fixed []SecurityMatch) {
+	return unmatchedFindings(current, baseline), unmatchedFindings(baseline, current)
+}
+
+// unmatchedFindings returns the findings in matches left over after pairing
+// each finding in others with one of the same fingerprint.
+func unmatchedFindings(matches, others []SecurityMatch) []SecurityMatch {
+	counts := make(map[string]int, len(others))
+	for _, other := range others {
+		counts[other.Fingerprint]++
+	}
+
+	var unmatched []SecurityMatch
+	for _, match := range matches {
+		if counts[match.Fingerprint] > 0 {
+			counts[match.Fingerprint]--
+			continue
+		}
+		unmatched = append(unmatched, match)
+	}
+	return unmatched
+}
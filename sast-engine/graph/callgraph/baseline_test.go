@@ -0,0 +1,175 @@
+package callgraph
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/patterns"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// analyzeSource analyzes a project with a single views.py holding source.
+func analyzeSource(t *testing.T, source string) *AnalysisResult {
+	t.Helper()
+	projectPath := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(projectPath, "views.py"), []byte(source), 0o644))
+	result, err := Analyze(projectPath, AnalyzeOptions{})
+	require.NoError(t, err)
+	require.NotEmpty(t, result.Matches)
+	return result
+}
+
+func TestAnalyze_FingerprintStableAcrossUnrelatedEdits(t *testing.T) {
+	source, err := os.ReadFile("../../test-fixtures/python/django_sources/views.py")
+	require.NoError(t, err)
+	original := analyzeSource(t, string(source))
+
+	edited := strings.Replace(string(source), "\n\ndef files_view", `
+
+LOGIN_URL = "/login"
+
+
+def home(request):
+    return None
+
+
+def files_view`, 1)
+	shifted := analyzeSource(t, edited)
+
+	require.Len(t, shifted.Matches, len(original.Matches))
+	for i, match := range original.Matches {
+		assert.NotEmpty(t, match.Fingerprint)
+		assert.NotEqual(t, match.SinkLine, shifted.Matches[i].SinkLine, "unrelated lines shift the finding")
+		assert.Equal(t, match.Fingerprint, shifted.Matches[i].Fingerprint, match.PatternID)
+	}
+}
+
+func TestAnalyze_Baseline(t *testing.T) {
+	projectPath := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(projectPath, "views.py"), []byte(`def calculate(request):
+    expression = request.GET.get("expr")
+    return eval(expression)
+`), 0o644))
+
+	analyze := func(opts AnalyzeOptions) []SecurityMatch {
+		result, err := Analyze(projectPath, opts)
+		require.NoError(t, err)
+		return result.Matches
+	}
+	unsuppressed := func(matches []SecurityMatch) []string {
+		var ids []string
+		for _, match := range matches {
+			if match.Suppression == "" {
+				ids = append(ids, match.PatternID)
+			}
+		}
+		return ids
+	}
+
+	findings := analyze(AnalyzeOptions{})
+	require.Equal(t, []string{"CODE-INJECTION-001"}, unsuppressed(findings))
+	baselinePath := filepath.Join(t.TempDir(), "baseline.json")
+	require.NoError(t, SaveBaseline(findings, baselinePath))
+
+	// Re-running unchanged reports nothing new; the finding is kept, suppressed
+	matches := analyze(AnalyzeOptions{Baseline: baselinePath})
+	assert.Empty(t, unsuppressed(matches))
+	require.Len(t, matches, 1)
+	assert.Equal(t, patterns.SuppressedByBaseline, matches[0].Suppression)
+
+	// A new flow of the baselined rule is reported, and fails the gate
+	require.NoError(t, os.WriteFile(filepath.Join(projectPath, "admin.py"), []byte(`def evaluate(request):
+    formula = request.POST.get("formula")
+    return eval(formula)
+`), 0o644))
+	result, err := Analyze(projectPath, AnalyzeOptions{Baseline: baselinePath})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"CODE-INJECTION-001"}, unsuppressed(result.Matches))
+	require.Len(t, result.Matches, 2)
+	for _, match := range result.Matches {
+		if match.Suppression == "" {
+			assert.Equal(t, "admin.evaluate", match.SinkFQN)
+		}
+	}
+	assert.Equal(t, output.ExitCodeFindings, result.ExitCode([]string{"critical"}))
+	require.NoError(t, os.Remove(filepath.Join(projectPath, "admin.py")))
+
+	// A new flow of another rule is reported
+	require.NoError(t, os.WriteFile(filepath.Join(projectPath, "tasks.py"), []byte(`import os
+
+
+def ping(request):
+    host = request.GET.get("host")
+    os.system("ping " + host)
+`), 0o644))
+	assert.Equal(t, []string{"COMMAND-INJECTION-001"}, unsuppressed(analyze(AnalyzeOptions{Baseline: baselinePath})))
+
+	// A malformed baseline fails the analysis
+	malformedPath := filepath.Join(t.TempDir(), "malformed.json")
+	require.NoError(t, os.WriteFile(malformedPath, []byte(`{"version": 1, "findings": [`), 0o644))
+	_, err = Analyze(projectPath, AnalyzeOptions{Baseline: malformedPath})
+	assert.ErrorContains(t, err, "malformed baseline")
+}
+
+func TestAnalyzePatterns_FingerprintDeterministic(t *testing.T) {
+	// Two functions read input() and two call eval(), all reachable from
+	// each other, so several source-sink pairs have a path.
+	buildCallGraph := func() *core.CallGraph {
+		cg := core.NewCallGraph()
+		cg.AddCallSite("app.read_a", core.CallSite{Target: "input", TargetFQN: "builtins.input", Resolved: true})
+		cg.AddCallSite("app.read_b", core.CallSite{Target: "input", TargetFQN: "builtins.input", Resolved: true})
+		cg.AddCallSite("app.run_a", core.CallSite{Target: "eval", TargetFQN: "builtins.eval", Resolved: true})
+		cg.AddCallSite("app.run_b", core.CallSite{Target: "eval", TargetFQN: "builtins.eval", Resolved: true})
+		for _, caller := range []string{"app.read_a", "app.read_b"} {
+			cg.AddEdge(caller, "app.dispatch")
+		}
+		for _, callee := range []string{"app.run_a", "app.run_b"} {
+			cg.AddEdge("app.dispatch", callee)
+		}
+		return cg
+	}
+	registry := patterns.NewPatternRegistry()
+	registry.AddPattern(&patterns.Pattern{
+		ID: "TEST-SOURCE-SINK", Type: patterns.PatternTypeSourceSink,
+		Sources: []string{"input"}, Sinks: []string{"eval"},
+	})
+
+	first := AnalyzePatterns(buildCallGraph(), registry)
+	require.NotEmpty(t, first)
+	for range 50 {
+		again := AnalyzePatterns(buildCallGraph(), registry)
+		require.Len(t, again, len(first))
+		for i := range first {
+			assert.Equal(t, first[i].Fingerprint, again[i].Fingerprint)
+			assert.Equal(t, first[i].DataFlowPath, again[i].DataFlowPath)
+		}
+	}
+}
+
+func TestDiffFindings(t *testing.T) {
+	finding := func(function, code string) SecurityMatch {
+		match := SecurityMatch{PatternID: "OPEN-REDIRECT-001", SinkFQN: function, SinkCode: code}
+		match.Fingerprint = matchFingerprint(match)
+		return match
+	}
+	kept := finding("views.login", "return redirect(next_url)")
+	removed := finding("views.logout", "return redirect(request.GET['next'])")
+	added := finding("views.signup", "return redirect(target)")
+	duplicate := finding("views.login", "return redirect(next_url)")
+
+	newFindings, fixed := DiffFindings(
+		[]SecurityMatch{kept, removed},
+		[]SecurityMatch{added, kept, duplicate},
+	)
+	assert.Equal(t, []SecurityMatch{added, duplicate}, newFindings, "a second identical finding is new")
+	assert.Equal(t, []SecurityMatch{removed}, fixed)
+
+	newFindings, fixed = DiffFindings([]SecurityMatch{kept}, []SecurityMatch{kept})
+	assert.Empty(t, newFindings)
+	assert.Empty(t, fixed)
+}
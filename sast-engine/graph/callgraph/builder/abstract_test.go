@@ -0,0 +1,54 @@
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCallGraph_AbstractMethodDispatch(t *testing.T) {
+	projectPath, err := filepath.Abs("../../../test-fixtures/python/abstract_dispatch")
+	require.NoError(t, err)
+
+	logger := output.NewLogger(output.VerbosityDefault)
+	codeGraph := graph.Initialize(projectPath, nil)
+	callGraph, registry, err := BuildCallGraphFromPath(codeGraph, projectPath, logger)
+	require.NoError(t, err)
+
+	callSite := func(caller, target string) core.CallSite {
+		for _, cs := range callGraph.CallSites[caller] {
+			if cs.Target == target {
+				return cs
+			}
+		}
+		require.FailNow(t, "call site not found", "%s in %s", target, caller)
+		return core.CallSite{}
+	}
+	overrides := []string{"shapes.Circle.area", "squares.Square.area"}
+
+	// A parameter annotated with the ABC dispatches to both implementers
+	area := callSite("report.total", "shape.area")
+	assert.Equal(t, "shapes.Shape.area", area.TargetFQN)
+	assert.True(t, area.VirtualDispatch)
+	assert.Equal(t, overrides, area.DispatchTargets)
+	assert.Subset(t, callGraph.Edges["report.total"], overrides)
+
+	// So does a template method calling the abstract method on self
+	selfArea := callSite("shapes.Shape.describe", "self.area")
+	assert.True(t, selfArea.VirtualDispatch)
+	assert.Equal(t, overrides, selfArea.DispatchTargets)
+	assert.Subset(t, callGraph.Edges["shapes.Shape.describe"], overrides)
+
+	explanation := ExplainResolution(codeGraph, callGraph, registry, "report.total", "shape.area()", logger)
+	assert.Equal(t, "abstract_method", explanation.Strategy)
+
+	// Concrete methods are ordinary calls
+	for _, cs := range callGraph.CallSites["shapes.Circle.area"] {
+		assert.False(t, cs.VirtualDispatch, cs.Target)
+	}
+}
@@ -33,6 +33,7 @@ const (
 	fileCacheVersion    = "1"
 	functionIndexVersion = "1"
 	pass4Version        = "1"
+	checkpointVersion   = "1"
 )
 
 // CachedCallSite is the minimal data needed to reconstruct a CallSiteInternal.
@@ -120,6 +121,7 @@ type CachedPass4Result struct {
 //   - Pass 2b variable scopes and Pass 3 call sites (file_cache table)
 //   - Pass 4 resolved edges (pass4_results table)
 //   - Pass 1 function index snapshot (function_index table)
+//   - build pass checkpoints (build_checkpoints table, see BuildOptions.Checkpoint)
 //
 // Thread-safety: the DB connection serialises writes; parallel goroutines should
 // only call Get* (reads) and flush with Put* sequentially afterwards.
@@ -201,6 +203,13 @@ func initSchema(db *sql.DB, projectRoot string) error {
 			edges_json      TEXT    NOT NULL,
 			unresolved_json TEXT    NOT NULL
 		)`,
+		// State of each completed build pass — one row per pass.
+		`CREATE TABLE IF NOT EXISTS build_checkpoints (
+			pass        TEXT    PRIMARY KEY,
+			inputs_hash TEXT    NOT NULL,
+			updated_at  INTEGER NOT NULL,
+			state_json  TEXT    NOT NULL
+		)`,
 	}
 	for _, stmt := range createStmts {
 		if _, err := db.ExecContext(context.Background(), stmt); err != nil {
@@ -213,7 +222,7 @@ func initSchema(db *sql.DB, projectRoot string) error {
 	_ = db.QueryRowContext(context.Background(), `SELECT value FROM meta WHERE key='project_root'`).Scan(&storedRoot)
 	if storedRoot != "" && storedRoot != projectRoot {
 		// Project root changed — wipe everything; this is a different project.
-		for _, tbl := range []string{"file_cache", "function_index", "pass4_results"} {
+		for _, tbl := range []string{"file_cache", "function_index", "pass4_results", "build_checkpoints"} {
 			if _, err := db.ExecContext(context.Background(), `DELETE FROM `+tbl); err != nil {
 				return fmt.Errorf("analysis cache: wipe table %s on project root change: %w", tbl, err)
 			}
@@ -229,6 +238,7 @@ func initSchema(db *sql.DB, projectRoot string) error {
 		{"file_cache_version", fileCacheVersion, "file_cache"},
 		{"function_index_version", functionIndexVersion, "function_index"},
 		{"pass4_version", pass4Version, "pass4_results"},
+		{"checkpoint_version", checkpointVersion, "build_checkpoints"},
 	}
 	for _, tv := range tableVersions {
 		var stored string
@@ -248,6 +258,7 @@ func initSchema(db *sql.DB, projectRoot string) error {
 		{"file_cache_version", fileCacheVersion},
 		{"function_index_version", functionIndexVersion},
 		{"pass4_version", pass4Version},
+		{"checkpoint_version", checkpointVersion},
 	}
 	for _, kv := range upserts {
 		if _, err := db.ExecContext(context.Background(),
@@ -526,6 +537,36 @@ func NeedsPass4Rerun(cached *CachedPass4Result, currentHash string, addedFQNs, r
 	return false
 }
 
+// ---- Build checkpoints (build_checkpoints table) ----
+
+// LoadCheckpoint returns the state saved for a build pass, if it was saved
+// for the same inputs. Returns (nil, false) on any miss.
+func (c *AnalysisCache) LoadCheckpoint(pass, inputsHash string) ([]byte, bool) {
+	var storedHash, stateJSON string
+	err := c.db.QueryRowContext(context.Background(),
+		`SELECT inputs_hash, state_json FROM build_checkpoints WHERE pass=?`,
+		pass,
+	).Scan(&storedHash, &stateJSON)
+	if err != nil || storedHash != inputsHash {
+		return nil, false
+	}
+	return []byte(stateJSON), true
+}
+
+// SaveCheckpoint stores the state of a completed build pass, replacing the
+// state saved for it by an earlier build.
+func (c *AnalysisCache) SaveCheckpoint(pass, inputsHash string, state []byte) error {
+	_, err := c.db.ExecContext(context.Background(),
+		`INSERT OR REPLACE INTO build_checkpoints(pass, inputs_hash, updated_at, state_json)
+		 VALUES(?,?,?,?)`,
+		pass, inputsHash, time.Now().Unix(), string(state),
+	)
+	if err != nil {
+		return fmt.Errorf("analysis cache: save checkpoint for %s: %w", pass, err)
+	}
+	return nil
+}
+
 // hashFile returns the hex-encoded SHA-256 digest of a file's contents.
 func hashFile(path string) (string, error) {
 	f, err := os.Open(path)
@@ -812,3 +812,26 @@ func TestOpenAnalysisCache_VersionMismatch_OnlyWipesAffectedTable(t *testing.T)
 	_, hit := cache2.GetFileCached(goFile)
 	assert.True(t, hit, "file_cache should survive a pass4_version bump")
 }
+
+// ---- build_checkpoints ----
+
+func TestCheckpoint_SaveAndLoad(t *testing.T) {
+	cache := openTempCache(t)
+
+	_, ok := cache.LoadCheckpoint(PassReturnTypes, "hash-1")
+	assert.False(t, ok, "nothing saved yet")
+
+	require.NoError(t, cache.SaveCheckpoint(PassReturnTypes, "hash-1", []byte(`{"a":1}`)))
+	state, ok := cache.LoadCheckpoint(PassReturnTypes, "hash-1")
+	require.True(t, ok)
+	assert.JSONEq(t, `{"a":1}`, string(state))
+
+	_, ok = cache.LoadCheckpoint(PassReturnTypes, "hash-2")
+	assert.False(t, ok, "saved for other inputs")
+	_, ok = cache.LoadCheckpoint(PassCallSites, "hash-1")
+	assert.False(t, ok, "saved for another pass")
+
+	require.NoError(t, cache.SaveCheckpoint(PassReturnTypes, "hash-2", []byte(`{"a":2}`)))
+	_, ok = cache.LoadCheckpoint(PassReturnTypes, "hash-1")
+	assert.False(t, ok, "replaced by the later build")
+}
@@ -0,0 +1,217 @@
+package builder
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"sync"
+	"sync/atomic"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/python"
+)
+
+// ASTCache caches parsed Python tree-sitter trees across call graph passes.
+// Entries are keyed by file path and validated against a SHA256 hash of the
+// file content, so a tree is never reused after the file has been edited.
+//
+// A cache may be kept across builds (see BuildOptions.ASTCache) to speed up
+// incremental rebuilds: unchanged files hit, edited files are re-parsed.
+//
+// A cache created with NewASTCache keeps every tree, so its memory grows
+// with the number of files. One created with NewBoundedASTCache keeps at
+// most a fixed number, evicting the least recently used; an evicted file is
+// re-parsed by the next pass that reads it.
+//
+// Sources added with AddSource overlay the file system: the builder reads
+// them instead of the file at that path, so unsaved buffers can be analyzed
+// (see BuildCallGraphFromSources).
+//
+// Thread-safety:
+//   - All methods are safe for concurrent use
+//   - Returned trees are NOT safe for concurrent use; the builder only
+//     touches a given file's tree from one worker at a time
+type ASTCache struct {
+	entries    map[string]*list.Element // Maps file path to its element in order
+	order      *list.List               // *astCacheEntry values, most recently used first
+	maxEntries int                      // Maximum number of trees kept; 0 means no limit
+	sources    map[string][]byte        // Maps file path to in-memory contents
+	mu         sync.Mutex               // Protects entries, order and sources
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// astCacheEntry is a parsed tree and the content hash it was parsed from.
+type astCacheEntry struct {
+	filePath    string
+	contentHash string
+	tree        *sitter.Tree
+}
+
+// ASTCacheStats reports cache effectiveness.
+type ASTCacheStats struct {
+	Hits    int64
+	Misses  int64
+	Entries int
+}
+
+// DefaultASTCacheSize is the number of trees kept by the cache a build
+// creates when BuildOptions.ASTCache is nil.
+const DefaultASTCacheSize = 1024
+
+// NewASTCache creates a new empty AST cache that keeps every tree.
+func NewASTCache() *ASTCache {
+	return NewBoundedASTCache(0)
+}
+
+// NewBoundedASTCache creates a new empty AST cache that keeps at most
+// maxEntries trees, evicting the least recently used. A maxEntries of zero
+// or less means no limit.
+func NewBoundedASTCache(maxEntries int) *ASTCache {
+	return &ASTCache{
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+		maxEntries: max(maxEntries, 0),
+		sources:    make(map[string][]byte),
+	}
+}
+
+// AddSource registers in-memory contents for filePath. ReadSource returns
+// them in place of the file on disk.
+func (c *ASTCache) AddSource(filePath string, sourceCode []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.sources[filePath] = sourceCode
+}
+
+// Sources returns a copy of the in-memory contents added with AddSource,
+// keyed by file path, or nil if there are none.
+func (c *ASTCache) Sources() map[string][]byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.sources) == 0 {
+		return nil
+	}
+	return maps.Clone(c.sources)
+}
+
+// ReadSource returns the in-memory contents registered for filePath, or
+// reads the file from disk when there are none.
+func (c *ASTCache) ReadSource(filePath string) ([]byte, error) {
+	c.mu.Lock()
+	sourceCode, ok := c.sources[filePath]
+	c.mu.Unlock()
+	if ok {
+		return sourceCode, nil
+	}
+	return ReadFileBytes(filePath)
+}
+
+// GetOrParse returns the cached tree for filePath if it was parsed from the
+// same content, otherwise parses sourceCode and caches the result.
+//
+// Parameters:
+//   - filePath: absolute path to the Python file
+//   - sourceCode: current file contents
+//
+// Returns:
+//   - parsed tree (owned by the cache; callers must not Close it)
+//   - error if parsing fails
+func (c *ASTCache) GetOrParse(filePath string, sourceCode []byte) (*sitter.Tree, error) {
+	contentHash := hashContent(sourceCode)
+
+	c.mu.Lock()
+	if element, ok := c.entries[filePath]; ok {
+		if entry := element.Value.(*astCacheEntry); entry.contentHash == contentHash {
+			c.order.MoveToFront(element)
+			c.mu.Unlock()
+			c.hits.Add(1)
+			return entry.tree, nil
+		}
+	}
+	c.mu.Unlock()
+
+	c.misses.Add(1)
+	tree, err := parsePython(sourceCode)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stale and evicted trees are dropped rather than closed: a caller may
+	// still hold nodes from them, and the tree-sitter finalizer frees them
+	// once unreachable.
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.remove(filePath)
+	c.entries[filePath] = c.order.PushFront(&astCacheEntry{filePath: filePath, contentHash: contentHash, tree: tree})
+	for c.maxEntries > 0 && c.order.Len() > c.maxEntries {
+		c.remove(c.order.Back().Value.(*astCacheEntry).filePath)
+	}
+
+	return tree, nil
+}
+
+// remove drops the tree cached for filePath, if any. c.mu must be held.
+func (c *ASTCache) remove(filePath string) {
+	if element, ok := c.entries[filePath]; ok {
+		c.order.Remove(element)
+		delete(c.entries, filePath)
+	}
+}
+
+// Invalidate removes the cached tree for filePath.
+func (c *ASTCache) Invalidate(filePath string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.remove(filePath)
+}
+
+// Stats returns hit/miss counters and the number of cached trees.
+func (c *ASTCache) Stats() ASTCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return ASTCacheStats{
+		Hits:    c.hits.Load(),
+		Misses:  c.misses.Load(),
+		Entries: len(c.entries),
+	}
+}
+
+// Close releases all cached trees. The cache is empty but usable afterwards.
+func (c *ASTCache) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for element := c.order.Front(); element != nil; element = element.Next() {
+		element.Value.(*astCacheEntry).tree.Close()
+	}
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+}
+
+// parsePython parses Python source with a fresh tree-sitter parser.
+func parsePython(sourceCode []byte) (*sitter.Tree, error) {
+	parser := sitter.NewParser()
+	parser.SetLanguage(python.GetLanguage())
+	defer parser.Close()
+
+	tree, err := parser.ParseCtx(context.Background(), nil, sourceCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Python code: %w", err)
+	}
+	return tree, nil
+}
+
+// hashContent returns the hex-encoded SHA256 of sourceCode.
+func hashContent(sourceCode []byte) string {
+	sum := sha256.Sum256(sourceCode)
+	return hex.EncodeToString(sum[:])
+}
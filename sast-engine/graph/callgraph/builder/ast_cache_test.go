@@ -0,0 +1,267 @@
+package builder
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/registry"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestASTCache_GetOrParse(t *testing.T) {
+	cache := NewASTCache()
+	defer cache.Close()
+
+	source := []byte("def foo():\n    return 1\n")
+
+	first, err := cache.GetOrParse("/project/a.py", source)
+	require.NoError(t, err)
+	require.NotNil(t, first)
+
+	second, err := cache.GetOrParse("/project/a.py", source)
+	require.NoError(t, err)
+	assert.Same(t, first, second, "same content should reuse the cached tree")
+
+	stats := cache.Stats()
+	assert.Equal(t, int64(1), stats.Hits)
+	assert.Equal(t, int64(1), stats.Misses)
+	assert.Equal(t, 1, stats.Entries)
+}
+
+func TestASTCache_ContentChangeInvalidates(t *testing.T) {
+	cache := NewASTCache()
+	defer cache.Close()
+
+	original, err := cache.GetOrParse("/project/a.py", []byte("def foo():\n    pass\n"))
+	require.NoError(t, err)
+
+	edited, err := cache.GetOrParse("/project/a.py", []byte("def bar():\n    pass\n"))
+	require.NoError(t, err)
+	assert.NotSame(t, original, edited, "edited content must not reuse the stale tree")
+	assert.Contains(t, edited.RootNode().String(), "function_definition")
+
+	stats := cache.Stats()
+	assert.Equal(t, int64(0), stats.Hits)
+	assert.Equal(t, int64(2), stats.Misses)
+	assert.Equal(t, 1, stats.Entries)
+}
+
+func TestASTCache_InvalidateAndClose(t *testing.T) {
+	cache := NewASTCache()
+	source := []byte("x = 1\n")
+
+	_, err := cache.GetOrParse("/project/a.py", source)
+	require.NoError(t, err)
+	_, err = cache.GetOrParse("/project/b.py", source)
+	require.NoError(t, err)
+
+	cache.Invalidate("/project/a.py")
+	assert.Equal(t, 1, cache.Stats().Entries)
+
+	cache.Close()
+	assert.Equal(t, 0, cache.Stats().Entries)
+
+	// Cache remains usable after Close.
+	_, err = cache.GetOrParse("/project/a.py", source)
+	require.NoError(t, err)
+	assert.Equal(t, 1, cache.Stats().Entries)
+	cache.Close()
+}
+
+func TestASTCache_BoundedEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewBoundedASTCache(2)
+	defer cache.Close()
+	source := []byte("x = 1\n")
+
+	a, err := cache.GetOrParse("/project/a.py", source)
+	require.NoError(t, err)
+	_, err = cache.GetOrParse("/project/b.py", source)
+	require.NoError(t, err)
+
+	// Touching a.py makes b.py the least recently used
+	again, err := cache.GetOrParse("/project/a.py", source)
+	require.NoError(t, err)
+	assert.Same(t, a, again)
+
+	_, err = cache.GetOrParse("/project/c.py", source)
+	require.NoError(t, err)
+	assert.Equal(t, 2, cache.Stats().Entries)
+
+	again, err = cache.GetOrParse("/project/a.py", source)
+	require.NoError(t, err)
+	assert.Same(t, a, again, "a.py should survive the eviction")
+
+	_, err = cache.GetOrParse("/project/b.py", source)
+	require.NoError(t, err)
+	stats := cache.Stats()
+	assert.Equal(t, int64(4), stats.Misses, "b.py was evicted and is re-parsed")
+	assert.Equal(t, int64(2), stats.Hits)
+	assert.Equal(t, 2, stats.Entries)
+}
+
+func TestBuildCallGraph_BoundedASTCache(t *testing.T) {
+	tmpDir := writeScopeFixture(t)
+	codeGraph := graph.Initialize(tmpDir, nil)
+	moduleRegistry, err := registry.BuildModuleRegistry(tmpDir, false)
+	require.NoError(t, err)
+
+	unbounded, err := buildCallGraph(codeGraph, moduleRegistry, tmpDir, output.NewLogger(output.VerbosityDefault), nil, nil, defaultStrategies, nil, nil)
+	require.NoError(t, err)
+
+	cache := NewBoundedASTCache(1)
+	defer cache.Close()
+	bounded, err := buildCallGraph(codeGraph, moduleRegistry, tmpDir, output.NewLogger(output.VerbosityDefault), nil, cache, defaultStrategies, nil, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, cache.Stats().Entries)
+	assert.Greater(t, cache.Stats().Misses, int64(len(moduleRegistry.Modules)), "evicted files are re-parsed")
+	assert.Equal(t, unbounded.Edges, bounded.Edges)
+	assert.Equal(t, len(unbounded.Summaries), len(bounded.Summaries))
+}
+
+func TestBuildCallGraph_ASTCacheHitsAfterFirstPass(t *testing.T) {
+	tmpDir := writeScopeFixture(t)
+	codeGraph := graph.Initialize(tmpDir, nil)
+	moduleRegistry, err := registry.BuildModuleRegistry(tmpDir, false)
+	require.NoError(t, err)
+
+	cache := NewASTCache()
+	defer cache.Close()
+
+	_, err = buildCallGraph(codeGraph, moduleRegistry, tmpDir, output.NewLogger(output.VerbosityDefault), nil, cache, defaultStrategies, nil, nil)
+	require.NoError(t, err)
+
+	fileCount := int64(len(moduleRegistry.Modules))
+	stats := cache.Stats()
+	assert.Equal(t, fileCount, stats.Misses, "each file should be parsed exactly once")
+	// Passes 2-4 plus per-function taint summaries all hit the cache.
+	assert.GreaterOrEqual(t, stats.Hits, 3*fileCount)
+
+	// A rebuild with unchanged files parses nothing.
+	_, err = buildCallGraph(codeGraph, moduleRegistry, tmpDir, output.NewLogger(output.VerbosityDefault), nil, cache, defaultStrategies, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, fileCount, cache.Stats().Misses)
+}
+
+func TestBuildForFiles_ReusesASTCacheAcrossBuilds(t *testing.T) {
+	tmpDir := writeScopeFixture(t)
+	cache := NewASTCache()
+	defer cache.Close()
+
+	_, _, err := BuildForFiles(tmpDir, []string{"views.py"}, BuildOptions{ASTCache: cache})
+	require.NoError(t, err)
+	missesAfterFirst := cache.Stats().Misses
+
+	// Edit one file: only it is re-parsed on the next build.
+	viewsPath := filepath.Join(tmpDir, "views.py")
+	content, err := os.ReadFile(viewsPath)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(viewsPath, []byte(strings.ReplaceAll(string(content), "data", "payload")), 0644))
+
+	callGraph, _, err := BuildForFiles(tmpDir, []string{"views.py"}, BuildOptions{ASTCache: cache})
+	require.NoError(t, err)
+	assert.Equal(t, missesAfterFirst+1, cache.Stats().Misses)
+	assert.Contains(t, callGraph.Summaries, "views.handle")
+}
+
+// BenchmarkBuildCallGraph_ASTCache measures a full Python build on the fixture
+// project, where every pass shares one parsed tree per file.
+func BenchmarkBuildCallGraph_ASTCache(b *testing.B) {
+	projectPath, err := filepath.Abs("../../../test-fixtures/python/simple_project")
+	if err != nil {
+		b.Fatal(err)
+	}
+	codeGraph := graph.Initialize(projectPath, nil)
+	moduleRegistry, err := registry.BuildModuleRegistry(projectPath, false)
+	if err != nil {
+		b.Fatal(err)
+	}
+	logger := output.NewLogger(output.VerbosityDefault)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := BuildCallGraph(codeGraph, moduleRegistry, projectPath, logger); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkASTCache_Reparse compares re-parsing each file once per pass with
+// serving passes 2-5 from the cache, on the fixture project.
+func BenchmarkASTCache_Reparse(b *testing.B) {
+	projectPath, err := filepath.Abs("../../../test-fixtures/python/simple_project")
+	if err != nil {
+		b.Fatal(err)
+	}
+	moduleRegistry, err := registry.BuildModuleRegistry(projectPath, false)
+	if err != nil {
+		b.Fatal(err)
+	}
+	sources := make(map[string][]byte)
+	for _, filePath := range moduleRegistry.Modules {
+		content, err := ReadFileBytes(filePath)
+		if err != nil {
+			b.Fatal(err)
+		}
+		sources[filePath] = content
+	}
+	const passes = 5
+
+	b.Run("uncached", func(b *testing.B) {
+		for b.Loop() {
+			for range passes {
+				for _, source := range sources {
+					tree, err := parsePython(source)
+					if err != nil {
+						b.Fatal(err)
+					}
+					tree.Close()
+				}
+			}
+		}
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		for b.Loop() {
+			cache := NewASTCache()
+			for range passes {
+				for filePath, source := range sources {
+					if _, err := cache.GetOrParse(filePath, source); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+			cache.Close()
+		}
+	})
+}
+
+func TestASTCache_ReadSource(t *testing.T) {
+	cache := NewASTCache()
+	defer cache.Close()
+
+	onDisk := filepath.Join(t.TempDir(), "a.py")
+	require.NoError(t, os.WriteFile(onDisk, []byte("x = 1\n"), 0644))
+
+	source, err := cache.ReadSource(onDisk)
+	require.NoError(t, err)
+	assert.Equal(t, "x = 1\n", string(source))
+
+	cache.AddSource(onDisk, []byte("x = 2\n"))
+	source, err = cache.ReadSource(onDisk)
+	require.NoError(t, err)
+	assert.Equal(t, "x = 2\n", string(source), "in-memory source overlays the file")
+
+	cache.AddSource("virtual/b.py", []byte("y = 1\n"))
+	source, err = cache.ReadSource("virtual/b.py")
+	require.NoError(t, err)
+	assert.Equal(t, "y = 1\n", string(source))
+
+	_, err = cache.ReadSource("virtual/missing.py")
+	assert.Error(t, err)
+}
@@ -0,0 +1,45 @@
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/analysis/taint"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCallGraph_TrustedBoundary(t *testing.T) {
+	projectPath, err := filepath.Abs("../../../test-fixtures/python/trusted_boundary")
+	require.NoError(t, err)
+
+	codeGraph := graph.Initialize(projectPath, nil)
+	callGraph, _, err := BuildCallGraphFromPath(codeGraph, projectPath, output.NewLogger(output.VerbosityDefault))
+	require.NoError(t, err)
+
+	sources := []string{"input"}
+	sinks := []string{"os.system", "eval"}
+	sinkFQNs := func(reachable []taint.ReachableSink) []string {
+		var fqns []string
+		for _, sink := range reachable {
+			fqns = append(fqns, sink.SinkFQN)
+		}
+		return fqns
+	}
+
+	// Without a boundary, input reaches os.system through the validators
+	assert.Equal(t, []string{"builtins.eval", "os.system"},
+		sinkFQNs(taint.AnalyzeReachableSinks(callGraph, sources, sinks)))
+
+	// Trusting the validators module clears those flows; relay is not trusted
+	boundary, err := taint.LoadTrustedBoundary([]byte(`
+trusted_boundary:
+  modules:
+    - validators
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"builtins.eval"},
+		sinkFQNs(taint.AnalyzeReachableSinksWithBoundary(callGraph, sources, sinks, boundary)))
+}
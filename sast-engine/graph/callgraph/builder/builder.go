@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -13,6 +14,7 @@ import (
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/extraction"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/notebook"
 	cgregistry "github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/registry"
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/resolution"
 	"github.com/shivasurya/code-pathfinder/sast-engine/output"
@@ -98,11 +100,33 @@ func getOptimalWorkerCount() int {
 //	  reverseEdges: {"myapp.utils.sanitize": ["myapp.views.get_user"]}
 //	  callSites: {"myapp.views.get_user": [CallSite{Target: "sanitize", ...}]}
 func BuildCallGraph(codeGraph *graph.CodeGraph, registry *core.ModuleRegistry, projectRoot string, logger *output.Logger) (*core.CallGraph, error) {
+	return buildCallGraph(codeGraph, registry, projectRoot, logger, nil, nil, defaultStrategies, nil, nil)
+}
+
+// buildCallGraph is the internal implementation of BuildCallGraph.
+// When scope is non-nil, passes 2-5 only run for the files and functions
+// the scope includes (see BuildForFiles). A nil scope analyzes everything.
+// When astCache is nil, a cache private to this build is used and released
+// on return; a caller-provided cache is left populated for later rebuilds.
+// Call sites are resolved by the first of strategies deciding their target.
+// Progress of the file and function passes goes to progress, if non-nil.
+// File passes restored from checkpoint are skipped, and those that run are
+// saved to it.
+func buildCallGraph(codeGraph *graph.CodeGraph, registry *core.ModuleRegistry, projectRoot string, logger *output.Logger, scope *buildScope, astCache *ASTCache, strategies []ResolutionStrategy, progress *progressReporter, checkpoint *buildCheckpoint) (*core.CallGraph, error) {
 	callGraph := core.NewCallGraph()
 
 	// Initialize import map cache for performance
 	// This avoids re-parsing imports from the same file multiple times
-	importCache := NewImportMapCache()
+	importCache := scope.importMapCache()
+
+	// Parse each file once and share the tree across all passes
+	if astCache == nil {
+		astCache = NewBoundedASTCache(DefaultASTCacheSize)
+		defer astCache.Close()
+	}
+
+	// Notebooks in the registry are analyzed as the Python of their code cells
+	notebooks := loadNotebooks(codeGraph, registry, astCache, logger)
 
 	// Initialize type inference engine
 	typeEngine := resolution.NewTypeInferenceEngine(registry)
@@ -173,72 +197,94 @@ func BuildCallGraph(codeGraph *graph.CodeGraph, registry *core.ModuleRegistry, p
 		filePath   string
 	}
 
-	returnJobs := make(chan returnJob, 100)
 	var returnMutex sync.Mutex
 	allReturnStatements := make([]*resolution.ReturnStatement, 0)
 	allFunctionsWithReturnValues := make(map[string]bool)
-	var processedFiles atomic.Int64
 	numWorkers := getOptimalWorkerCount()
 	var wg sync.WaitGroup
 
 	logger.Debug("Using %d parallel workers for callgraph construction", numWorkers)
 
-	// Start workers for return type extraction
-	for range numWorkers {
-		wg.Go(func() {
-			for job := range returnJobs {
-				sourceCode, err := ReadFileBytes(job.filePath)
-				if err != nil {
-					continue
-				}
+	pythonFiles := countPythonFiles(registry, scope)
 
-				// Extract imports using cache (needed for class instantiation resolution)
-				importMap, err := importCache.GetOrExtract(job.filePath, sourceCode, registry)
-				if err != nil {
-					continue
-				}
+	var restoredReturns returnTypesCheckpoint
+	if checkpoint.restore(PassReturnTypes, &restoredReturns) {
+		allReturnStatements = restoredReturns.ReturnStatements
+		allFunctionsWithReturnValues = restoredReturns.FunctionsWithReturnValues
+		for filePath, importMap := range restoredReturns.ImportMaps {
+			typeEngine.AddImportMap(filePath, importMap)
+		}
+	} else {
+		returnJobs := make(chan returnJob, 100)
+		var processedFiles atomic.Int64
+		progress.start(PassReturnTypes, pythonFiles)
+
+		// Start workers for return type extraction
+		for range numWorkers {
+			wg.Go(func() {
+				for job := range returnJobs {
+					sourceCode, err := astCache.ReadSource(job.filePath)
+					if err != nil {
+						continue
+					}
+
+					tree, err := astCache.GetOrParse(job.filePath, sourceCode)
+					if err != nil {
+						continue
+					}
 
-				// Store ImportMap for later use in attribute placeholder resolution (P0 fix)
-				typeEngine.AddImportMap(job.filePath, importMap)
+					// Extract imports using cache (needed for class instantiation resolution)
+					importMap := importCache.GetOrExtractFromAST(job.filePath, sourceCode, tree.RootNode(), registry)
 
-				returns, functionsWithReturns, err := resolution.ExtractReturnTypes(job.filePath, sourceCode, job.modulePath, typeEngine.Builtins, importMap)
-				if err != nil {
-					continue
-				}
+					// Store ImportMap for later use in attribute placeholder resolution (P0 fix)
+					typeEngine.AddImportMap(job.filePath, importMap)
 
-				returnMutex.Lock()
-				if len(returns) > 0 {
-					allReturnStatements = append(allReturnStatements, returns...)
-				}
-				for fqn := range functionsWithReturns {
-					allFunctionsWithReturnValues[fqn] = true
-				}
-				returnMutex.Unlock()
+					returns, functionsWithReturns := resolution.ExtractReturnTypesFromAST(job.filePath, sourceCode, tree.RootNode(), job.modulePath, typeEngine.Builtins, importMap)
+
+					returnMutex.Lock()
+					if len(returns) > 0 {
+						allReturnStatements = append(allReturnStatements, returns...)
+					}
+					for fqn := range functionsWithReturns {
+						allFunctionsWithReturnValues[fqn] = true
+					}
+					returnMutex.Unlock()
 
-				// Progress tracking
-				count := processedFiles.Add(1)
-				if count%1000 == 0 {
-					logger.Debug("Processed %d/%d files for return types", count, len(registry.Modules))
+					// Progress tracking
+					progress.advance()
+					count := processedFiles.Add(1)
+					if count%1000 == 0 {
+						logger.Debug("Processed %d/%d files for return types", count, len(registry.Modules))
+					}
 				}
-			}
-		})
-	}
+			})
+		}
 
-	// Queue all Python files
-	for modulePath, filePath := range registry.Modules {
-		if !strings.HasSuffix(filePath, ".py") {
-			continue
+		// Queue all Python files
+		for modulePath, filePath := range registry.Modules {
+			if !strings.HasSuffix(filePath, ".py") || !scope.includesFile(filePath) {
+				continue
+			}
+			returnJobs <- returnJob{modulePath, filePath}
 		}
-		returnJobs <- returnJob{modulePath, filePath}
-	}
-	close(returnJobs)
-	wg.Wait()
+		close(returnJobs)
+		wg.Wait()
+		progress.finish()
 
-	logger.Debug("Completed return type extraction: %d files processed", processedFiles.Load())
+		logger.Debug("Completed return type extraction: %d files processed", processedFiles.Load())
+
+		checkpoint.save(PassReturnTypes, returnTypesCheckpoint{
+			ReturnStatements:          allReturnStatements,
+			FunctionsWithReturnValues: allFunctionsWithReturnValues,
+			ImportMaps:                typeEngine.ImportMaps,
+		})
+	}
 
 	// Merge return types and add to engine
 	mergedReturns := resolution.MergeReturnTypes(allReturnStatements)
 	typeEngine.AddReturnTypesToEngine(mergedReturns)
+	typeEngine.AddEnterTypesToEngine(resolution.MergeEnterTypes(allReturnStatements))
+	typeEngine.AddElementTypesToEngine(resolution.MergeElementTypes(allReturnStatements))
 
 	// Back-populate inferred return types to function nodes and detect void functions
 	populateInferredReturnTypes(callGraph, typeEngine, allFunctionsWithReturnValues, logger)
@@ -249,54 +295,86 @@ func BuildCallGraph(codeGraph *graph.CodeGraph, registry *core.ModuleRegistry, p
 	preloadThirdPartyModules(typeEngine, logger)
 
 	// Phase 2 Task 8: Extract ALL variable assignments BEFORE resolving calls (second pass - PARALLELIZED)
-	logger.Debug("Extracting variable assignments (parallel)...")
-
-	varJobs := make(chan string, 100)
-	var varProcessed atomic.Int64
-	wg = sync.WaitGroup{}
-
-	// Start workers for variable assignment extraction
-	for range numWorkers {
-		wg.Go(func() {
-			for filePath := range varJobs {
-				sourceCode, err := ReadFileBytes(filePath)
-				if err != nil {
-					continue
-				}
+	var restoredScopes variableAssignmentsCheckpoint
+	if checkpoint.restore(PassVariableAssignments, &restoredScopes) {
+		for _, functionScope := range restoredScopes.Scopes {
+			typeEngine.AddScope(functionScope)
+		}
+	} else {
+		logger.Debug("Extracting variable assignments (parallel)...")
+
+		varJobs := make(chan string, 100)
+		var varProcessed atomic.Int64
+		wg = sync.WaitGroup{}
+		progress.start(PassVariableAssignments, pythonFiles)
+
+		// Start workers for variable assignment extraction
+		for range numWorkers {
+			wg.Go(func() {
+				for filePath := range varJobs {
+					sourceCode, err := astCache.ReadSource(filePath)
+					if err != nil {
+						continue
+					}
 
-				// Extract imports using cache (needed for class instantiation resolution)
-				importMap, err := importCache.GetOrExtract(filePath, sourceCode, registry)
-				if err != nil {
-					continue
-				}
+					tree, err := astCache.GetOrParse(filePath, sourceCode)
+					if err != nil {
+						continue
+					}
 
-				// Store ImportMap for later use in attribute placeholder resolution (P0 fix)
-				typeEngine.AddImportMap(filePath, importMap)
+					// Extract imports using cache (needed for class instantiation resolution)
+					importMap := importCache.GetOrExtractFromAST(filePath, sourceCode, tree.RootNode(), registry)
 
-				// Extract variable assignments - typeEngine methods are mutex-protected internally
-				// Class context is tracked during AST traversal to build class-qualified FQNs (matching Pass 1)
-				_ = extraction.ExtractVariableAssignments(filePath, sourceCode, typeEngine, registry, typeEngine.Builtins, importMap)
+					// Store ImportMap for later use in attribute placeholder resolution (P0 fix)
+					typeEngine.AddImportMap(filePath, importMap)
 
-				// Progress tracking
-				count := varProcessed.Add(1)
-				if count%1000 == 0 {
-					logger.Debug("Processed %d files for variable assignments", count)
+					// Extract variable assignments - typeEngine methods are mutex-protected internally
+					// Class context is tracked during AST traversal to build class-qualified FQNs (matching Pass 1)
+					extraction.ExtractVariableAssignmentsFromAST(filePath, sourceCode, tree.RootNode(), typeEngine, registry, typeEngine.Builtins, importMap)
+
+					// Progress tracking
+					progress.advance()
+					count := varProcessed.Add(1)
+					if count%1000 == 0 {
+						logger.Debug("Processed %d files for variable assignments", count)
+					}
 				}
-			}
-		})
-	}
+			})
+		}
 
-	// Queue all Python files
-	for _, filePath := range registry.Modules {
-		if !strings.HasSuffix(filePath, ".py") {
-			continue
+		// Queue all Python files
+		for _, filePath := range registry.Modules {
+			if !strings.HasSuffix(filePath, ".py") || !scope.includesFile(filePath) {
+				continue
+			}
+			varJobs <- filePath
 		}
-		varJobs <- filePath
+		close(varJobs)
+		wg.Wait()
+		progress.finish()
+
+		logger.Debug("Completed variable assignment extraction: %d files processed", varProcessed.Load())
+
+		checkpoint.save(PassVariableAssignments, variableAssignmentsCheckpoint{Scopes: typeEngine.Scopes})
 	}
-	close(varJobs)
-	wg.Wait()
 
-	logger.Debug("Completed variable assignment extraction: %d files processed", varProcessed.Load())
+	// Type enum member reads (Color.RED, Color.RED.value) from the enum classes
+	// tagged during parsing. Must run BEFORE var: and call: resolution.
+	registerEnumMembers(codeGraph, registry, typeEngine)
+	registerProperties(callGraph, typeEngine)
+	typeEngine.ResolveEnumMemberBindings()
+
+	// Record Protocol/ABC classes and their implementers so calls on
+	// parameters annotated with them resolve to the declared methods.
+	registerProtocols(codeGraph, registry, callGraph, typeEngine)
+
+	// Record abstract methods and their concrete overrides, so calls to them
+	// reach the overrides through virtual dispatch.
+	registerAbstractMethods(codeGraph, registry, callGraph, typeEngine)
+
+	// Record @singledispatch functions and their registered implementations,
+	// so calls dispatch on the type of their first argument.
+	registerSingleDispatch(registry, callGraph, typeEngine)
 
 	// Resolve var: placeholders in return types using scope variable lookups.
 	// Must happen AFTER variable extraction (scopes populated) and BEFORE call: resolution.
@@ -314,45 +392,66 @@ func BuildCallGraph(codeGraph *graph.CodeGraph, registry *core.ModuleRegistry, p
 	// Must run AFTER resolveThirdPartyVariableBindings.
 	resolveStdlibVariableBindings(typeEngine, logger)
 
+	// Type property reads (report.title) with the getter's return type. Must
+	// run AFTER call: resolution so the receivers are typed.
+	typeEngine.ResolvePropertyBindings()
+
 	// Phase 3 Task 12: Extract class attributes (third pass - PARALLELIZED)
-	logger.Debug("Extracting class attributes (parallel)...")
-
-	attrJobs := make(chan returnJob, 100) // Reuse returnJob struct
-	var attrProcessed atomic.Int64
-	wg = sync.WaitGroup{}
-
-	// Start workers for class attribute extraction
-	for range numWorkers {
-		wg.Go(func() {
-			for job := range attrJobs {
-				sourceCode, err := ReadFileBytes(job.filePath)
-				if err != nil {
-					continue
-				}
+	var restoredAttributes classAttributesCheckpoint
+	if checkpoint.restore(PassClassAttributes, &restoredAttributes) {
+		for _, classAttrs := range restoredAttributes.Classes {
+			typeEngine.Attributes.AddClassAttributes(classAttrs)
+		}
+	} else {
+		logger.Debug("Extracting class attributes (parallel)...")
+
+		attrJobs := make(chan returnJob, 100) // Reuse returnJob struct
+		var attrProcessed atomic.Int64
+		wg = sync.WaitGroup{}
+		progress.start(PassClassAttributes, pythonFiles)
+
+		// Start workers for class attribute extraction
+		for range numWorkers {
+			wg.Go(func() {
+				for job := range attrJobs {
+					sourceCode, err := astCache.ReadSource(job.filePath)
+					if err != nil {
+						continue
+					}
 
-				// Extract class attributes - AttributeRegistry methods are mutex-protected
-				_ = extraction.ExtractClassAttributes(job.filePath, sourceCode, job.modulePath, typeEngine, typeEngine.Attributes)
+					tree, err := astCache.GetOrParse(job.filePath, sourceCode)
+					if err != nil {
+						continue
+					}
 
-				// Progress tracking
-				count := attrProcessed.Add(1)
-				if count%1000 == 0 {
-					logger.Debug("Processed %d files for class attributes", count)
+					// Extract class attributes - AttributeRegistry methods are mutex-protected
+					extraction.ExtractClassAttributesFromAST(job.filePath, sourceCode, tree.RootNode(), job.modulePath, typeEngine, typeEngine.Attributes)
+
+					// Progress tracking
+					progress.advance()
+					count := attrProcessed.Add(1)
+					if count%1000 == 0 {
+						logger.Debug("Processed %d files for class attributes", count)
+					}
 				}
-			}
-		})
-	}
+			})
+		}
 
-	// Queue all Python files
-	for modulePath, filePath := range registry.Modules {
-		if !strings.HasSuffix(filePath, ".py") {
-			continue
+		// Queue all Python files
+		for modulePath, filePath := range registry.Modules {
+			if !strings.HasSuffix(filePath, ".py") || !scope.includesFile(filePath) {
+				continue
+			}
+			attrJobs <- returnJob{modulePath, filePath}
 		}
-		attrJobs <- returnJob{modulePath, filePath}
-	}
-	close(attrJobs)
-	wg.Wait()
+		close(attrJobs)
+		wg.Wait()
+		progress.finish()
+
+		logger.Debug("Completed class attribute extraction: %d files processed", attrProcessed.Load())
 
-	logger.Debug("Completed class attribute extraction: %d files processed", attrProcessed.Load())
+		checkpoint.save(PassClassAttributes, classAttributesCheckpoint{Classes: typeEngine.Attributes.Classes})
+	}
 
 	// Phase 3 Task 12: Resolve placeholder types in attributes (Pass 3)
 	resolution.ResolveAttributePlaceholders(typeEngine.Attributes, typeEngine, registry, codeGraph)
@@ -361,110 +460,211 @@ func BuildCallGraph(codeGraph *graph.CodeGraph, registry *core.ModuleRegistry, p
 	resolveParentClassInheritance(codeGraph, callGraph, registry, typeEngine, logger)
 
 	// Process each Python file in the project (fourth pass for call site resolution - PARALLELIZED)
-	logger.Debug("Resolving call sites (parallel)...")
-
-	callSiteJobs := make(chan returnJob, 100)
-	var callGraphMutex sync.Mutex // Protect callGraph modifications
-	var callSiteProcessed atomic.Int64
-	wg = sync.WaitGroup{}
-
-	// Start workers for call site resolution
-	for range numWorkers {
-		wg.Go(func() {
-			for job := range callSiteJobs {
-				// Read source code for parsing
-				sourceCode, err := ReadFileBytes(job.filePath)
-				if err != nil {
-					continue
-				}
-
-				// Extract imports using cache (cache is thread-safe)
-				importMap, err := importCache.GetOrExtract(job.filePath, sourceCode, registry)
-				if err != nil {
-					continue
-				}
+	var restoredCalls callSitesCheckpoint
+	if checkpoint.restore(PassCallSites, &restoredCalls) {
+		for caller, callees := range restoredCalls.Edges {
+			for _, callee := range callees {
+				callGraph.AddEdge(caller, callee)
+			}
+		}
+		for caller, callSites := range restoredCalls.CallSites {
+			for _, callSite := range callSites {
+				callGraph.AddCallSite(caller, callSite)
+			}
+		}
+		for module, imported := range restoredCalls.ModuleImports {
+			for _, importedModule := range imported {
+				callGraph.AddModuleImport(module, importedModule)
+			}
+		}
+	} else {
+		logger.Debug("Resolving call sites (parallel)...")
+
+		callSiteJobs := make(chan returnJob, 100)
+		var callGraphMutex sync.Mutex // Protect callGraph modifications
+		var callSiteProcessed atomic.Int64
+		wg = sync.WaitGroup{}
+		progress.start(PassCallSites, pythonFiles)
+
+		// Start workers for call site resolution
+		for range numWorkers {
+			wg.Go(func() {
+				for job := range callSiteJobs {
+					// Read source code for parsing
+					sourceCode, err := astCache.ReadSource(job.filePath)
+					if err != nil {
+						continue
+					}
 
-				// Store ImportMap for later use in attribute placeholder resolution (P0 fix)
-				typeEngine.AddImportMap(job.filePath, importMap)
+					tree, err := astCache.GetOrParse(job.filePath, sourceCode)
+					if err != nil {
+						continue
+					}
 
-				// Extract all call sites from this file
-				callSites, err := resolution.ExtractCallSites(job.filePath, sourceCode, importMap)
-				if err != nil {
-					continue
-				}
+					// Extract imports using cache (cache is thread-safe)
+					importMap := importCache.GetOrExtractFromAST(job.filePath, sourceCode, tree.RootNode(), registry)
 
-				// Get all function definitions in this file
-				fileFunctions := getFunctionsInFile(codeGraph, job.filePath)
+					// Store ImportMap for later use in attribute placeholder resolution (P0 fix)
+					typeEngine.AddImportMap(job.filePath, importMap)
 
-				// Process each call site to resolve targets and build edges
-				for _, callSite := range callSites {
-					// Phase 1: Find the caller function containing this call site
-					// Now with class context for class-qualified FQNs
-					callerFQN := findContainingFunction(callSite.Location, fileFunctions, job.modulePath, classContext)
-					if callerFQN == "" {
-						callerFQN = job.modulePath
+					// Record the project modules this file imports
+					imported := importedModules(importMap, registry)
+					callGraphMutex.Lock()
+					for _, module := range imported {
+						callGraph.AddModuleImport(job.modulePath, module)
 					}
+					callGraphMutex.Unlock()
 
-					// Resolve the call target to a fully qualified name
-						targetFQN, resolved, typeInfo := resolveCallTarget(callSite.Target, importMap, registry, job.modulePath, codeGraph, typeEngine, callerFQN, callGraph, logger)
+					// Extract all call sites from this file
+					callSites := resolution.ExtractCallSitesFromAST(job.filePath, sourceCode, tree.RootNode(), importMap)
+
+					// Get all function definitions in this file
+					fileFunctions := getFunctionsInFile(codeGraph, job.filePath)
+					definitionTimeSpans := findDefinitionTimeSpans(tree.RootNode())
+
+					// Process each call site to resolve targets and build edges
+					for _, callSite := range callSites {
+						// Phase 1: Find the caller function containing this call site
+						// Now with class context for class-qualified FQNs
+						callerFQN := findContainingFunction(callSite.Location, fileFunctions, job.modulePath, classContext)
+						// Decorators and default arguments run where the function is
+						// defined: in the enclosing function, or at import time
+						if scope, ok := definitionTimeScope(callSite.Location, definitionTimeSpans); ok {
+							callerFQN = ""
+							if scope != nil {
+								callerFQN = findContainingFunction(*scope, fileFunctions, job.modulePath, classContext)
+							}
+						}
+						if callerFQN == "" {
+							callerFQN = job.modulePath
+						}
 
-					// Update call site with resolution information
-					callSite.TargetFQN = targetFQN
-					callSite.Resolved = resolved
+						// Resolve the call target to a fully qualified name
+						resolutionCtx := ResolutionContext{
+							Target:        callSite.Target,
+							CallerFQN:     callerFQN,
+							CurrentModule: job.modulePath,
+							ImportMap:     importMap,
+							Registry:      registry,
+							CodeGraph:     codeGraph,
+							CallGraph:     callGraph,
+							TypeEngine:    typeEngine,
+							Logger:        logger,
+						}
+						targetFQN, resolved, typeInfo := resolveWithStrategies(strategies, resolutionCtx)
+
+						// Update call site with resolution information
+						callSite.TargetFQN = targetFQN
+						callSite.Resolved = resolved
+
+						// Phase 2 Task 10: Populate type inference metadata
+						if typeInfo != nil {
+							callSite.ResolvedViaTypeInference = true
+							callSite.InferredType = typeInfo.TypeFQN
+							callSite.TypeConfidence = typeInfo.Confidence
+							callSite.TypeSource = typeInfo.Source
+						}
 
-					// Phase 2 Task 10: Populate type inference metadata
-					if typeInfo != nil {
-						callSite.ResolvedViaTypeInference = true
-						callSite.InferredType = typeInfo.TypeFQN
-						callSite.TypeConfidence = typeInfo.Confidence
-						callSite.TypeSource = typeInfo.Source
-					}
+						// Calls to an abstract method run one of its concrete overrides
+						if resolved && typeInfo != nil && typeInfo.Source == "abstract_method" {
+							if overrides, ok := typeEngine.AbstractOverrides(targetFQN); ok {
+								callSite.VirtualDispatch = true
+								callSite.DispatchTargets = overrides
+							}
+						}
+
+						// Calls through a dispatch table run any function it holds
+						if resolved && typeInfo != nil && typeInfo.Source == "dispatch_table" {
+							callSite.DispatchTargets, _ = dispatchTableTargets(resolutionCtx)
+						}
+
+						// Calls to a @singledispatch function run the implementation
+						// registered for their first argument's type
+						if resolved {
+							argType := dispatchArgumentType(callSite, callerFQN, typeEngine)
+							if implementation, ok := typeEngine.SingleDispatchImplementation(targetFQN, argType); ok {
+								callSite.DispatchFunction = targetFQN
+								callSite.DispatchType = argType
+								callSite.TargetFQN = implementation
+								targetFQN = implementation
+							}
+						}
 
-					// If resolution failed, categorize the failure reason
-					if !resolved {
-						callSite.FailureReason = categorizeResolutionFailure(callSite.Target, targetFQN, typeEngine)
+						// If resolution failed, categorize the failure reason
+						if !resolved {
+							callSite.FailureReason = categorizeResolutionFailure(callSite.Target, targetFQN, typeEngine)
+						}
+
+						// CRITICAL: Lock callGraph modifications (shared state)
+						callGraphMutex.Lock()
+						callGraph.AddCallSite(callerFQN, *callSite)
+						if resolved {
+							callGraph.AddEdge(callerFQN, targetFQN)
+							for _, override := range callSite.DispatchTargets {
+								callGraph.AddEdge(callerFQN, override)
+							}
+							if typeInfo != nil && typeInfo.Source == "protocol" {
+								// The argument may be any implementer; link each one's method too
+								method := strings.TrimPrefix(targetFQN, typeInfo.TypeFQN)
+								for _, implementer := range typeEngine.ProtocolImplementers(typeInfo.TypeFQN) {
+									if _, ok := callGraph.Functions[implementer+method]; ok {
+										callGraph.AddEdge(callerFQN, implementer+method)
+									}
+								}
+							}
+						}
+						callGraphMutex.Unlock()
 					}
 
-					// CRITICAL: Lock callGraph modifications (shared state)
-					callGraphMutex.Lock()
-					callGraph.AddCallSite(callerFQN, *callSite)
-					if resolved {
-						callGraph.AddEdge(callerFQN, targetFQN)
+					// Progress tracking
+					progress.advance()
+					count := callSiteProcessed.Add(1)
+					if count%1000 == 0 {
+						logger.Debug("Processed %d files for call sites", count)
 					}
-					callGraphMutex.Unlock()
 				}
+			})
+		}
 
-				// Progress tracking
-				count := callSiteProcessed.Add(1)
-				if count%1000 == 0 {
-					logger.Debug("Processed %d files for call sites", count)
-				}
+		// Queue all Python files
+		for modulePath, filePath := range registry.Modules {
+			if !strings.HasSuffix(filePath, ".py") || !scope.includesFile(filePath) {
+				continue
 			}
+			callSiteJobs <- returnJob{modulePath, filePath}
+		}
+		close(callSiteJobs)
+		wg.Wait()
+		progress.finish()
+
+		logger.Debug("Completed call site resolution: %d files processed", callSiteProcessed.Load())
+
+		checkpoint.save(PassCallSites, callSitesCheckpoint{
+			Edges:         callGraph.Edges,
+			CallSites:     callGraph.CallSites,
+			ModuleImports: callGraph.ModuleImports,
 		})
 	}
 
-	// Queue all Python files
-	for modulePath, filePath := range registry.Modules {
-		if !strings.HasSuffix(filePath, ".py") {
-			continue
-		}
-		callSiteJobs <- returnJob{modulePath, filePath}
-	}
-	close(callSiteJobs)
-	wg.Wait()
+	// Attach Django URLconf routes to their view functions
+	registerURLConfRoutes(callGraph, typeEngine)
 
-	logger.Debug("Completed call site resolution: %d files processed", callSiteProcessed.Load())
+	// Link middleware __call__ methods to the apps they wrap
+	linkMiddlewareChains(callGraph, registry, typeEngine)
 
 	// Phase 3 Task 12: Print attribute failure analysis (debug mode only)
 	resolution.PrintAttributeFailureStats(logger)
 
 	// Pass 5: Generate taint summaries for all functions
 	logger.Debug("Generating taint summaries...")
-	GenerateTaintSummaries(callGraph, codeGraph, registry)
+	generateTaintSummaries(callGraph, scope.functionFilter(callGraph), astCache, progress)
+	logger.Debug("AST cache: %d hits, %d misses", astCache.Stats().Hits, astCache.Stats().Misses)
 	logger.Statistic("Generated taint summaries for %d functions", len(callGraph.Summaries))
 
 	// Store attribute registry for symbol search and type inference
-	callGraph.Attributes = typeEngine.Attributes
+	if err := callGraph.SetAttributes(typeEngine.Attributes); err != nil {
+		logger.Warning("Skipping attribute registry: %v", err)
+	}
 
 	// Store type engine for module variable type lookups in MCP tools
 	callGraph.TypeEngine = typeEngine
@@ -473,9 +673,64 @@ func BuildCallGraph(codeGraph *graph.CodeGraph, registry *core.ModuleRegistry, p
 	callGraph.ThirdPartyRemote = typeEngine.ThirdPartyRemote
 	callGraph.StdlibRemote = typeEngine.StdlibRemote
 
+	// Keep in-memory sources readable, and report notebook findings by cell
+	callGraph.Sources = astCache.Sources()
+	if len(notebooks) > 0 {
+		callGraph.SourceMapper = notebook.SourceMapper(notebooks)
+	}
+
 	return callGraph, nil
 }
 
+// countPythonFiles returns the number of Python files the file passes of
+// a build process: those of registry the scope includes.
+func countPythonFiles(registry *core.ModuleRegistry, scope *buildScope) int {
+	count := 0
+	for _, filePath := range registry.Modules {
+		if strings.HasSuffix(filePath, ".py") && scope.includesFile(filePath) {
+			count++
+		}
+	}
+	return count
+}
+
+// loadNotebooks extracts the notebooks registered in the module registry
+// (see registry.BuildModuleRegistryWithNotebooks), adds their synthetic
+// modules to astCache, and parses them into codeGraph. Notebooks that
+// cannot be read are skipped with a warning.
+func loadNotebooks(codeGraph *graph.CodeGraph, registry *core.ModuleRegistry, astCache *ASTCache, logger *output.Logger) []*notebook.Notebook {
+	var notebooks []*notebook.Notebook
+	sources := make(map[string][]byte)
+	for _, filePath := range registry.Modules {
+		if !notebook.IsSourcePath(filePath) {
+			continue
+		}
+		nb, err := notebook.Load(notebook.NotebookPath(filePath))
+		if err != nil {
+			logger.Warning("Skipping notebook: %v", err)
+			continue
+		}
+		notebooks = append(notebooks, nb)
+		sources[filePath] = nb.Source
+		astCache.AddSource(filePath, nb.Source)
+	}
+	if len(notebooks) == 0 {
+		return nil
+	}
+
+	notebookGraph := graph.InitializeFromSources(sources)
+	for _, node := range notebookGraph.Nodes {
+		codeGraph.AddNode(node)
+	}
+	for _, edge := range notebookGraph.Edges {
+		codeGraph.AddEdge(edge.From, edge.To)
+	}
+	// Notebook classes may inherit from project classes
+	graph.ResolveTransitiveInheritance(codeGraph)
+	logger.Debug("Loaded %d notebooks", len(notebooks))
+	return notebooks
+}
+
 // preloadThirdPartyModules scans all collected ImportMaps and pre-fetches
 // third-party modules that appear in project imports. This avoids per-call-site
 // CDN downloads during call resolution (Pass 4).
@@ -558,7 +813,7 @@ func indexFunctions(codeGraph *graph.CodeGraph, callGraph *core.CallGraph, regis
 
 		// Build fully qualified name with class context if applicable
 		fqn := buildFQN(modulePath, node, classContext)
-		callGraph.Functions[fqn] = node
+		callGraph.AddFunction(fqn, node)
 	}
 }
 
@@ -923,469 +1178,32 @@ func ResolveCallTarget(target string, importMap *core.ImportMap, registry *core.
 	return resolveCallTarget(target, importMap, registry, currentModule, codeGraph, typeEngine, callerFQN, callGraph, logger)
 }
 
-// resolveCallTarget is the internal implementation of ResolveCallTarget.
+// resolveCallTarget is the internal implementation of ResolveCallTarget,
+// trying the built-in strategies in order (see strategies.go).
 func resolveCallTarget(target string, importMap *core.ImportMap, registry *core.ModuleRegistry, currentModule string, codeGraph *graph.CodeGraph, typeEngine *resolution.TypeInferenceEngine, callerFQN string, callGraph *core.CallGraph, logger *output.Logger) (string, bool, *core.TypeInfo) {
-	// Backward compatibility: if typeEngine or callerFQN not provided, skip type inference
-	if typeEngine == nil || callerFQN == "" {
-		fqn, resolved := resolveCallTargetLegacy(target, importMap, registry, currentModule, codeGraph)
-		return fqn, resolved, nil
-	}
-
-	// Phase 3 Task 11: Check for method chaining BEFORE other resolution
-	// Chains have pattern "()." indicating call followed by attribute access
-	if strings.Contains(target, ").") {
-		chainFQN, chainResolved, chainType := resolution.ResolveChainedCall(
-			target,
-			typeEngine,
-			typeEngine.Builtins,
-			registry,
-			codeGraph,
-			callerFQN,
-			currentModule,
-			callGraph,
-		)
-		if chainResolved {
-			return chainFQN, true, chainType
-		}
-		// Chain parsing attempted but failed - fall through to regular resolution
-	}
-
-	// Phase 3 Task 12: Check for self.attribute.method() patterns BEFORE self.method()
-	// Pattern: self.attr.method (2+ dots starting with self.)
-	if strings.HasPrefix(target, "self.") && strings.Count(target, ".") >= 2 {
-		attrFQN, attrResolved, attrType := resolution.ResolveSelfAttributeCall(
-			target,
-			callerFQN,
-			typeEngine,
-			typeEngine.Builtins,
-			callGraph,
-		)
-		if attrResolved {
-			return attrFQN, true, attrType
-		}
-
-		// PR #7: Fallback — check parent class attributes via third-party registry
-		// For self.attr.method where attr isn't in child class, try parent classes
-		if typeEngine != nil && typeEngine.ThirdPartyRemote != nil && codeGraph != nil {
-			attrParts := strings.Split(target, ".")
-			if len(attrParts) >= 3 {
-				attrName := attrParts[1]
-				methodOnAttr := attrParts[len(attrParts)-1]
-				callerParts := strings.Split(callerFQN, ".")
-				if len(callerParts) >= 3 {
-					callerClassName := callerParts[len(callerParts)-2]
-					callerClassFQN := currentModule + "." + callerClassName
-					fqn, resolved, typeInfo := resolveInheritedSelfAttrMethod(
-						callerClassFQN, attrName, methodOnAttr,
-						codeGraph, registry, typeEngine, logger,
-					)
-					if resolved {
-						return fqn, true, typeInfo
-					}
-				}
-			}
-		}
-		// Attribute resolution attempted but failed - fall through
-	}
-
-	// Phase 3: Handle super().method() calls - resolve to parent class method
-	if after, ok := strings.CutPrefix(target, "super()."); ok {
-		methodName := after
-
-		// Extract current class name from callerFQN
-		// callerFQN format: "module.ClassName.methodName"
-		parts := strings.Split(callerFQN, ".")
-
-		if len(parts) >= 3 {
-			// Current class info
-			className := parts[len(parts)-2]
-			currentClassFQN := currentModule + "." + className
-
-			// PR #7: Find the class node and resolve parent via imports + third-party
-			if codeGraph != nil {
-				for _, node := range codeGraph.Nodes {
-					if node.Type != "class_definition" && node.Type != "dataclass" {
-						continue
-					}
-					if node.File == "" || len(node.Interface) == 0 {
-						continue
-					}
-					modulePath, ok := registry.FileToModule[node.File]
-					if !ok {
-						continue
-					}
-					nodeFQN := modulePath + "." + node.Name
-					if nodeFQN != currentClassFQN {
-						continue
-					}
-
-					// Found the class — resolve each parent and check for the method
-					for _, superClassName := range node.Interface {
-						parentFQN := resolution.ResolveParentClassFQN(
-							currentClassFQN, superClassName, node.File,
-							typeEngine, registry,
-						)
-						if parentFQN == "" {
-							continue
-						}
-
-						// Check userland first
-						parentMethodFQN := parentFQN + "." + methodName
-						if callGraph != nil && callGraph.Functions[parentMethodFQN] != nil {
-							return parentMethodFQN, true, nil
-						}
-
-						// Check third-party registry
-						if typeEngine != nil && typeEngine.ThirdPartyRemote != nil {
-							if loader, ok := typeEngine.ThirdPartyRemote.(*cgregistry.ThirdPartyRegistryRemote); ok {
-								tpModule, tpClass := splitModuleAndName(parentFQN)
-								if tpClass != "" && loader.HasModule(tpModule) {
-									method := findThirdPartyClassMethod(loader, tpModule, tpClass, methodName, logger)
-									if method != nil {
-										return parentFQN + "." + methodName, true, nil
-									}
-								}
-							}
-						}
-					}
-					break
-				}
-			}
-
-			// Heuristic fallback: Try common parent class names (Base suffix)
-			if callGraph != nil {
-				parentMethodFQN := currentModule + "." + className + "Base." + methodName
-				if callGraph.Functions[parentMethodFQN] != nil {
-					return parentMethodFQN, true, nil
-				}
-			}
-
-			// Try module-level function as last resort
-			moduleFQN := currentModule + "." + methodName
-			if callGraph != nil && callGraph.Functions[moduleFQN] != nil {
-				return moduleFQN, true, nil
-			}
-
-			// Return unresolved with descriptive FQN
-			return currentModule + ".super()." + methodName, false, nil
-		}
-
-		// Can't extract class from callerFQN
-		return "super()." + methodName, false, nil
-	}
-
-	// Phase 2: Handle self.method() calls - resolve to current class method
-	if after, ok := strings.CutPrefix(target, "self."); ok {
-		methodName := after
-
-		// Phase 2: Extract class name from callerFQN for class-qualified lookup
-		// callerFQN format: "module.ClassName.methodName" for methods
-		//                   "module.functionName" for module-level functions
-		parts := strings.Split(callerFQN, ".")
-
-		// If callerFQN has 3+ parts, it's a class method
-		// parts = ["module", "ClassName", "methodName"] or more for nested modules
-		if len(parts) >= 3 {
-			// Extract class name (second-to-last part)
-			// For "module.ClassName.methodName" → className = "ClassName"
-			// For "app.models.User.save" → className = "User"
-			className := parts[len(parts)-2]
-
-			// Build class-qualified FQN: module.ClassName.methodName
-			classQualifiedFQN := currentModule + "." + className + "." + methodName
-
-			// Try class-qualified lookup first
-			if validateFQN(classQualifiedFQN, registry) {
-				return classQualifiedFQN, true, nil
-			}
-
-			// Check if target exists in Functions map (more reliable than validateFQN)
-			if callGraph != nil && callGraph.Functions[classQualifiedFQN] != nil {
-				return classQualifiedFQN, true, nil
-			}
-		}
-
-		// Fall back to module-level method (backward compatibility)
-		// This handles cases where method might be at module level or
-		// when class extraction fails
-		moduleFQN := currentModule + "." + methodName
-		if validateFQN(moduleFQN, registry) {
-			return moduleFQN, true, nil
-		}
-
-		// Check Functions map for module-level
-		if callGraph != nil && callGraph.Functions[moduleFQN] != nil {
-			return moduleFQN, true, nil
-		}
-
-		// Return unresolved but with module prefix
-		return moduleFQN, false, nil
-	}
-
-	// Handle simple names (no dots)
-	if !strings.Contains(target, ".") {
-		// Check if it's a Python built-in
-		if pythonBuiltins[target] {
-			// Return as builtins.function for pattern matching
-			return "builtins." + target, true, nil
-		}
-
-		// Try to resolve through imports
-		if fqn, ok := importMap.Resolve(target); ok {
-			// Validate if it exists in registry
-			if validateFQN(fqn, registry) {
-				return fqn, true, nil
-			}
-			// Check stdlib for imported names (e.g., from os import getcwd)
-			if typeEngine != nil && typeEngine.StdlibRemote != nil {
-				if remoteLoader, ok := typeEngine.StdlibRemote.(*cgregistry.StdlibRegistryRemote); ok {
-					if validateStdlibFQN(fqn, remoteLoader, logger) {
-						return fqn, true, nil
-					}
-				}
-			}
-			// Check third-party for imported names (e.g., from requests import Session)
-			if typeEngine != nil && typeEngine.ThirdPartyRemote != nil {
-				if loader, ok := typeEngine.ThirdPartyRemote.(*cgregistry.ThirdPartyRegistryRemote); ok {
-					if validateThirdPartyFQN(fqn, loader, logger) {
-						return fqn, true, nil
-					}
-				}
-			}
-			// Check callGraph.Functions directly (may differ from registry module keys)
-			if callGraph != nil && callGraph.Functions[fqn] != nil {
-				return fqn, true, nil
-			}
-			// Fix: strip leading package prefix and retry
-			// Import FQNs use full package path (e.g., label_studio.core.utils.params.get_env)
-			// but registry keys are relative to project root (e.g., core.utils.params.get_env)
-			if strippedFQN, ok := resolveWithPrefixStripping(fqn, registry, callGraph); ok {
-				return strippedFQN, true, nil
-			}
-			return fqn, false, nil
-		}
-
-		// Not in imports - might be in same module
-		sameLevelFQN := currentModule + "." + target
-		if validateFQN(sameLevelFQN, registry) {
-			return sameLevelFQN, true, nil
-		}
-
-		// Can't resolve - return as-is
-		return target, false, nil
-	}
-
-	// Handle qualified names (with dots)
-	parts := strings.SplitN(target, ".", 2)
-	base := parts[0]
-	rest := parts[1]
-
-	// Phase 2 Task 9: Try type inference for variable.method() calls
-	if typeEngine != nil && callerFQN != "" {
-		// Try function scope first, then fall back to module scope
-		var binding *resolution.VariableBinding
-
-		// Check function scope first
-		functionScope := typeEngine.GetScope(callerFQN)
-		if functionScope != nil {
-			if b := functionScope.GetVariable(base); b != nil {
-				binding = b
-			}
-		}
-
-		// If not found in function scope, try module scope
-		if binding == nil {
-			moduleScope := typeEngine.GetScope(currentModule)
-			if moduleScope != nil {
-				if b := moduleScope.GetVariable(base); b != nil {
-					binding = b
-				}
-			}
-		}
-
-		if binding != nil {
-			// Check if variable has type information
-			if binding.Type != nil {
-				typeFQN := binding.Type.TypeFQN
-
-				// Skip placeholders (call:, var:) - not yet resolved
-				if strings.HasPrefix(typeFQN, "call:") || strings.HasPrefix(typeFQN, "var:") {
-					// Continue to legacy resolution
-				} else {
-					// Check if it's a builtin type
-					if typeEngine.Builtins != nil && strings.HasPrefix(typeFQN, "builtins.") {
-						method := typeEngine.Builtins.GetMethod(typeFQN, rest)
-						if method != nil {
-							// Resolved to builtin method - return with type info
-							return typeFQN + "." + rest, true, binding.Type
-						}
-					}
-
-					// Phase 3: Enhanced instance.method() resolution
-					// Check if it's a project type (user-defined class/method)
-					methodFQN := typeFQN + "." + rest
-
-					// Phase 3: Try Functions map first with class-qualified FQN
-					// This is more reliable than codeGraph.Nodes for class methods
-					if callGraph != nil {
-						if node := callGraph.Functions[methodFQN]; node != nil {
-							// Found in Functions map with class-qualified FQN
-							if node.Type == "method" || node.Type == "function_definition" ||
-								node.Type == "constructor" || node.Type == "property" ||
-								node.Type == "special_method" {
-								return methodFQN, true, binding.Type
-							}
-						}
-					}
-
-					// Validate method exists in code graph (fallback)
-					if codeGraph != nil {
-						if node, ok := codeGraph.Nodes[methodFQN]; ok {
-							if node.Type == "method_declaration" || node.Type == "function_definition" {
-								// Resolved via code graph validation - return with type info
-								return methodFQN, true, binding.Type
-							}
-						}
-
-						// Legacy: Python class methods stored at module level
-						// Try stripping the class name and looking for module.method
-						// This is for backward compatibility with older indexing
-						lastDot := strings.LastIndex(typeFQN, ".")
-						if lastDot >= 0 {
-							modulePart := typeFQN[:lastDot]
-							className := typeFQN[lastDot+1:]
-
-							// Check if it looks like a Python class (PascalCase)
-							if len(className) > 0 && className[0] >= 'A' && className[0] <= 'Z' {
-								pythonMethodFQN := modulePart + "." + rest
-								if callGraph != nil {
-									if node, ok := callGraph.Functions[pythonMethodFQN]; ok {
-										if node.Type == "method_declaration" || node.Type == "function_definition" {
-											// Resolved via Python module-level method lookup
-											return pythonMethodFQN, true, binding.Type
-										}
-									}
-								}
-							}
-						}
-					}
-
-					// PR #6: Check third-party type registry for method on typed variable
-					if typeEngine.ThirdPartyRemote != nil {
-						if loader, ok := typeEngine.ThirdPartyRemote.(*cgregistry.ThirdPartyRegistryRemote); ok {
-							tpModule, tpClass := splitModuleAndName(typeFQN)
-							if tpClass != "" && loader.HasModule(tpModule) {
-								method := findThirdPartyClassMethod(loader, tpModule, tpClass, rest, logger)
-								if method != nil {
-									return methodFQN, true, &core.TypeInfo{
-										TypeFQN:    typeFQN,
-										Confidence: binding.Type.Confidence,
-										Source:     "typeshed",
-									}
-								}
-							}
-						}
-					}
-
-					// Check stdlib type registry for method on typed variable.
-					if typeEngine.StdlibRemote != nil {
-						if stdlibLoader, ok := typeEngine.StdlibRemote.(*cgregistry.StdlibRegistryRemote); ok {
-							stModule, stClass := splitModuleAndName(typeFQN)
-							if stClass != "" && stdlibLoader.HasModule(stModule) {
-								method := stdlibLoader.GetClassMethod(stModule, stClass, rest, logger)
-								if method != nil {
-									return methodFQN, true, &core.TypeInfo{
-										TypeFQN:    typeFQN,
-										Confidence: binding.Type.Confidence,
-										Source:     "stdlib",
-									}
-								}
-							}
-						}
-					}
-
-					// Heuristic: If type has good confidence (>= 0.7), assume method exists
-					if binding.Type.Confidence >= 0.7 {
-						// Resolved via confidence heuristic - return with type info
-						return methodFQN, true, binding.Type
-					}
-
-				}
-			}
-		}
-	}
-
-	// Try to resolve base through imports
-	if baseFQN, ok := importMap.Resolve(base); ok {
-		fullFQN := baseFQN + "." + rest
-		// Check if it's an ORM pattern (before validateFQN, since ORM methods don't exist in source)
-		if ormFQN, resolved := resolution.ResolveORMCall(target, currentModule, registry, codeGraph); resolved {
-			return ormFQN, true, nil
-		}
-		// PR #3: Check stdlib registry before user project registry
-		if typeEngine != nil && typeEngine.StdlibRemote != nil {
-			if remoteLoader, ok := typeEngine.StdlibRemote.(*cgregistry.StdlibRegistryRemote); ok {
-				if validateStdlibFQN(fullFQN, remoteLoader, logger) {
-					return fullFQN, true, nil
-				}
-			}
-		}
-		// PR #6: Check third-party registry before user project registry
-		if typeEngine != nil && typeEngine.ThirdPartyRemote != nil {
-			if loader, ok := typeEngine.ThirdPartyRemote.(*cgregistry.ThirdPartyRegistryRemote); ok {
-				if validateThirdPartyFQN(fullFQN, loader, logger) {
-					return fullFQN, true, nil
-				}
-			}
-		}
-		if validateFQN(fullFQN, registry) {
-			return fullFQN, true, nil
-		}
-		// Check callGraph.Functions directly
-		if callGraph != nil && callGraph.Functions[fullFQN] != nil {
-			return fullFQN, true, nil
-		}
-		// Fix: strip leading package prefix and retry
-		if strippedFQN, ok := resolveWithPrefixStripping(fullFQN, registry, callGraph); ok {
-			return strippedFQN, true, nil
-		}
-		return fullFQN, false, nil
-	}
-
-	// Base not in imports - might be module-level access
-	// Try current module
-	fullFQN := currentModule + "." + target
-	if validateFQN(fullFQN, registry) {
-		return fullFQN, true, nil
-	}
-
-	// Before giving up, check if it's an ORM pattern (Django, SQLAlchemy, etc.)
-	// ORM methods are dynamically generated at runtime and won't be in source
-	if ormFQN, resolved := resolution.ResolveORMCall(target, currentModule, registry, codeGraph); resolved {
-		return ormFQN, true, nil
-	}
+	return resolveWithStrategies(defaultStrategies, ResolutionContext{
+		Target:        target,
+		CallerFQN:     callerFQN,
+		CurrentModule: currentModule,
+		ImportMap:     importMap,
+		Registry:      registry,
+		CodeGraph:     codeGraph,
+		CallGraph:     callGraph,
+		TypeEngine:    typeEngine,
+		Logger:        logger,
+	})
+}
 
-	// PR #3: Last resort - check if target is a stdlib call (e.g., os.path.join)
-	// This handles cases where stdlib modules are imported directly (import os.path)
+// isStdlibSubmoduleCall reports whether fqn calls an attribute of a known
+// stdlib submodule, e.g. os.path.join after import os. It stands in for the
+// stdlib registry when that could not be loaded, so it only applies while
+// typeEngine has none.
+func isStdlibSubmoduleCall(fqn string, registry *core.ModuleRegistry, typeEngine *resolution.TypeInferenceEngine) bool {
 	if typeEngine != nil && typeEngine.StdlibRemote != nil {
-		if remoteLoader, ok := typeEngine.StdlibRemote.(*cgregistry.StdlibRegistryRemote); ok {
-			if validateStdlibFQN(target, remoteLoader, logger) {
-				return target, true, nil
-			}
-		}
-	}
-
-	// PR #6: Last resort - check third-party registry
-	if typeEngine != nil && typeEngine.ThirdPartyRemote != nil {
-		if loader, ok := typeEngine.ThirdPartyRemote.(*cgregistry.ThirdPartyRegistryRemote); ok {
-			if validateThirdPartyFQN(target, loader, logger) {
-				return target, true, nil
-			}
-		}
+		return false
 	}
-
-	// Can't resolve - return as-is
-	return target, false, nil
+	_, ok := registry.StdlibSubmoduleAttribute(fqn)
+	return ok
 }
 
 // stdlibModuleAliases maps platform-specific module aliases to their canonical names.
@@ -1663,6 +1481,354 @@ func resolveInheritedSelfAttrMethod(
 	return "", false, nil
 }
 
+// importedModules returns the sorted project modules an import map refers to.
+// Each import resolves to the longest module prefix of its FQN, so
+// "from app.models import User" imports app.models; imports outside the
+// project are dropped.
+func importedModules(importMap *core.ImportMap, registry *core.ModuleRegistry) []string {
+	var modules []string
+	for _, fqn := range importMap.Imports {
+		module := fqn
+		for {
+			if _, ok := registry.Modules[module]; ok {
+				if !slices.Contains(modules, module) {
+					modules = append(modules, module)
+				}
+				break
+			}
+			lastDot := strings.LastIndex(module, ".")
+			if lastDot < 0 {
+				break
+			}
+			module = module[:lastDot]
+		}
+	}
+	slices.Sort(modules)
+	return modules
+}
+
+// resolveCallableInstance resolves a call to a variable holding a class
+// instance, as in handler = Handler(); handler(event), to the class's
+// __call__ method. The variable is looked up in the caller's scope, then the
+// module's. The returned type info carries the instance type with Source
+// "dunder_call", recording why the call resolved.
+func resolveCallableInstance(name, callerFQN, currentModule string, typeEngine *resolution.TypeInferenceEngine, callGraph *core.CallGraph) (string, *core.TypeInfo, bool) {
+	if callGraph == nil {
+		return "", nil, false
+	}
+	binding := typeEngine.GetVariableInScope(callerFQN, name)
+	if binding == nil {
+		if moduleScope := typeEngine.GetScope(currentModule); moduleScope != nil {
+			binding = moduleScope.GetVariable(name)
+		}
+	}
+	if binding == nil || binding.Type == nil || binding.Type.TypeFQN == "" {
+		return "", nil, false
+	}
+	callFQN := binding.Type.TypeFQN + ".__call__"
+	if _, ok := callGraph.GetFunction(callFQN); !ok {
+		return "", nil, false
+	}
+	return callFQN, &core.TypeInfo{
+		TypeFQN:    binding.Type.TypeFQN,
+		Confidence: binding.Type.Confidence,
+		Source:     "dunder_call",
+	}, true
+}
+
+// registerEnumMembers records the members of every enum class in the code
+// graph, with the type of each member's value, so member reads can be typed.
+// Members are the class-level assignments inside the enum's body; names with
+// a leading underscore are not members. auto() values are typed as int.
+func registerEnumMembers(codeGraph *graph.CodeGraph, registry *core.ModuleRegistry, typeEngine *resolution.TypeInferenceEngine) {
+	var enums []*graph.Node
+	classFields := make(map[string][]*graph.Node) // file -> class-level assignments
+	for _, node := range codeGraph.Nodes {
+		if node.SourceLocation == nil {
+			continue
+		}
+		if node.Type == "enum" {
+			enums = append(enums, node)
+		} else if node.Scope == "class" && !strings.HasPrefix(node.Name, "_") {
+			classFields[node.File] = append(classFields[node.File], node)
+		}
+	}
+
+	for _, enumNode := range enums {
+		modulePath, ok := registry.FileToModule[enumNode.File]
+		if !ok {
+			continue
+		}
+		enumFQN := modulePath + "." + enumNode.Name
+
+		for _, field := range classFields[enumNode.File] {
+			if field.SourceLocation.StartByte < enumNode.SourceLocation.StartByte ||
+				field.SourceLocation.EndByte > enumNode.SourceLocation.EndByte {
+				continue
+			}
+			value := strings.TrimSpace(field.VariableValue)
+			var valueType *core.TypeInfo
+			if value == "auto()" || value == "enum.auto()" {
+				valueType = &core.TypeInfo{TypeFQN: "builtins.int", Confidence: 1.0, Source: "literal"}
+			} else {
+				valueType = typeEngine.Builtins.InferLiteralType(value)
+			}
+			typeEngine.AddEnumMember(enumFQN, field.Name, valueType)
+		}
+	}
+}
+
+// registerProperties records the @property and @cached_property getters, so
+// attribute reads on their classes type as the getter's return type. A plain
+// return annotation (-> str, -> Owner) is preferred over the inferred type.
+func registerProperties(callGraph *core.CallGraph, typeEngine *resolution.TypeInferenceEngine) {
+	for fqn, node := range callGraph.Functions {
+		if node.Type != "property" {
+			continue
+		}
+		modulePath := typeEngine.Registry.FileToModule[node.File]
+		typeEngine.AddProperty(fqn, annotationType(modulePath, node.ReturnType, typeEngine.GetImportMap(node.File), typeEngine))
+	}
+}
+
+// annotationType resolves a type annotation to a type: builtins to
+// "builtins.<name>", imported names through importMap, dotted names as
+// written, and other bare names to a class of modulePath. Generic and union
+// annotations are not resolved.
+func annotationType(modulePath, annotation string, importMap *core.ImportMap, typeEngine *resolution.TypeInferenceEngine) *core.TypeInfo {
+	annotation = strings.Trim(strings.TrimSpace(annotation), `"'`)
+	if annotation == "" || annotation == "None" || strings.ContainsAny(annotation, "[|, ") {
+		return nil
+	}
+	typeFQN := annotation
+	if imported, ok := resolveImported(importMap, annotation); ok {
+		typeFQN = imported
+	} else if typeEngine.Builtins != nil && typeEngine.Builtins.GetType("builtins."+annotation) != nil {
+		typeFQN = "builtins." + annotation
+	} else if !strings.Contains(annotation, ".") {
+		if modulePath == "" {
+			return nil
+		}
+		typeFQN = modulePath + "." + annotation
+	}
+	return &core.TypeInfo{TypeFQN: typeFQN, Confidence: 0.95, Source: "annotation"}
+}
+
+// resolveImported resolves a name through a file's import map, which may be
+// nil.
+func resolveImported(importMap *core.ImportMap, name string) (string, bool) {
+	if importMap == nil {
+		return "", false
+	}
+	return importMap.Resolve(name)
+}
+
+// registerProtocols records every interface class (a typing.Protocol or
+// abc.ABC subclass, tagged during parsing) that declares methods, with the
+// classes defining all of those methods. Implementers need not inherit from
+// the protocol: conformance is structural, as for a type checker.
+func registerProtocols(codeGraph *graph.CodeGraph, registry *core.ModuleRegistry, callGraph *core.CallGraph, typeEngine *resolution.TypeInferenceEngine) {
+	methods := make(map[string][]string) // class FQN -> method names
+	var protocols []string
+	for _, node := range codeGraph.Nodes {
+		switch node.Type {
+		case "class_definition", "interface", "dataclass":
+		default:
+			continue
+		}
+		modulePath, ok := registry.FileToModule[node.File]
+		if !ok {
+			continue
+		}
+		classFQN := modulePath + "." + node.Name
+		if _, seen := methods[classFQN]; seen {
+			continue
+		}
+		methods[classFQN] = nil
+		if node.Type == "interface" {
+			protocols = append(protocols, classFQN)
+		}
+	}
+	for fqn := range callGraph.Functions {
+		dot := strings.LastIndex(fqn, ".")
+		if dot < 0 {
+			continue
+		}
+		classFQN, method := fqn[:dot], fqn[dot+1:]
+		if names, isClass := methods[classFQN]; isClass {
+			methods[classFQN] = append(names, method)
+		}
+	}
+
+	slices.Sort(protocols)
+	for _, protocolFQN := range protocols {
+		declared := methods[protocolFQN]
+		if len(declared) == 0 {
+			continue
+		}
+		var implementers []string
+		for classFQN, defined := range methods {
+			if classFQN == protocolFQN {
+				continue
+			}
+			if !slices.ContainsFunc(declared, func(name string) bool { return !slices.Contains(defined, name) }) {
+				implementers = append(implementers, classFQN)
+			}
+		}
+		slices.Sort(implementers)
+		typeEngine.AddProtocol(protocolFQN, implementers)
+	}
+}
+
+// registerAbstractMethods records every @abstractmethod in the call graph
+// with the overrides defined by its class's subclasses, direct or indirect.
+// Subclasses are found through the bases of class nodes; overrides that are
+// themselves abstract are skipped.
+func registerAbstractMethods(codeGraph *graph.CodeGraph, registry *core.ModuleRegistry, callGraph *core.CallGraph, typeEngine *resolution.TypeInferenceEngine) {
+	subclasses := make(map[string][]string) // class FQN -> direct subclass FQNs
+	for _, node := range codeGraph.Nodes {
+		switch node.Type {
+		case "class_definition", "interface", "dataclass":
+		default:
+			continue
+		}
+		modulePath, ok := registry.FileToModule[node.File]
+		if !ok {
+			continue
+		}
+		classFQN := modulePath + "." + node.Name
+		for _, base := range node.Interface {
+			parentFQN := resolution.ResolveParentClassFQN(classFQN, base, node.File, typeEngine, registry)
+			if parentFQN != "" && !slices.Contains(subclasses[parentFQN], classFQN) {
+				subclasses[parentFQN] = append(subclasses[parentFQN], classFQN)
+			}
+		}
+	}
+
+	for fqn, node := range callGraph.Functions {
+		if !isAbstractMethod(node) {
+			continue
+		}
+		dot := strings.LastIndex(fqn, ".")
+		if dot < 0 {
+			continue
+		}
+		classFQN, method := fqn[:dot], fqn[dot+1:]
+
+		var overrides []string
+		visited := map[string]bool{classFQN: true}
+		queue := slices.Clone(subclasses[classFQN])
+		for len(queue) > 0 {
+			subclass := queue[0]
+			queue = queue[1:]
+			if visited[subclass] {
+				continue
+			}
+			visited[subclass] = true
+			if override, ok := callGraph.Functions[subclass+"."+method]; ok && !isAbstractMethod(override) {
+				overrides = append(overrides, subclass+"."+method)
+			}
+			queue = append(queue, subclasses[subclass]...)
+		}
+		slices.Sort(overrides)
+		typeEngine.AddAbstractMethod(fqn, overrides)
+	}
+}
+
+// registerSingleDispatch records every @singledispatch function with the
+// implementations registered on it by @<function>.register, in its module
+// or in modules importing it, keyed by the FQN of the type each handles.
+func registerSingleDispatch(registry *core.ModuleRegistry, callGraph *core.CallGraph, typeEngine *resolution.TypeInferenceEngine) {
+	implementations := make(map[string]map[string]string) // function FQN -> type FQN -> implementation FQN
+	for fqn, node := range callGraph.Functions {
+		if isSingleDispatch(node) && implementations[fqn] == nil {
+			implementations[fqn] = make(map[string]string)
+		}
+	}
+	if len(implementations) == 0 {
+		return
+	}
+
+	for fqn, node := range callGraph.Functions {
+		dispatchType, _ := node.Metadata[graph.MetadataDispatchType].(string)
+		if dispatchType == "" {
+			continue
+		}
+		modulePath, ok := registry.FileToModule[node.File]
+		if !ok {
+			continue
+		}
+		importMap := typeEngine.GetImportMap(node.File)
+		for _, decorator := range node.Annotation {
+			generic, isRegister := strings.CutSuffix(decorator, ".register")
+			if !isRegister {
+				continue
+			}
+			functionFQN, imported := resolveImported(importMap, generic)
+			if !imported {
+				functionFQN = modulePath + "." + generic
+			}
+			registered, ok := implementations[functionFQN]
+			if !ok {
+				continue
+			}
+			if typeInfo := annotationType(modulePath, dispatchType, importMap, typeEngine); typeInfo != nil {
+				registered[typeInfo.TypeFQN] = fqn
+			}
+		}
+	}
+
+	for fqn, registered := range implementations {
+		typeEngine.AddSingleDispatch(fqn, registered)
+	}
+}
+
+// isSingleDispatch reports whether a function is decorated with
+// @singledispatch or @functools.singledispatch.
+func isSingleDispatch(node *graph.Node) bool {
+	return slices.Contains(node.Annotation, "singledispatch") || slices.Contains(node.Annotation, "functools.singledispatch")
+}
+
+// dispatchArgumentType returns the type of the first positional argument of
+// a call: a literal's type, or the type bound to a variable of the caller
+// at or before the call. Returns "" when unknown.
+func dispatchArgumentType(callSite *core.CallSite, callerFQN string, typeEngine *resolution.TypeInferenceEngine) string {
+	if len(callSite.Arguments) == 0 {
+		return ""
+	}
+	argument := callSite.Arguments[0]
+	if argument.IsDictSpread || strings.HasPrefix(argument.Value, "*") {
+		return ""
+	}
+	if !argument.IsVariable {
+		if typeInfo := typeEngine.Builtins.InferLiteralType(argument.Value); typeInfo != nil {
+			return typeInfo.TypeFQN
+		}
+		return ""
+	}
+	scope := typeEngine.GetScope(callerFQN)
+	if scope == nil {
+		return ""
+	}
+	var latest *resolution.VariableBinding
+	for _, binding := range scope.Variables[argument.Value] {
+		if binding.Type != nil && binding.Location.Line <= uint32(callSite.Location.Line) && //nolint:gosec
+			(latest == nil || binding.Location.Line >= latest.Location.Line) {
+			latest = binding
+		}
+	}
+	if latest == nil {
+		return ""
+	}
+	return latest.Type.TypeFQN
+}
+
+// isAbstractMethod reports whether a function is decorated with
+// @abstractmethod or @abc.abstractmethod.
+func isAbstractMethod(node *graph.Node) bool {
+	return slices.Contains(node.Annotation, "abstractmethod") || slices.Contains(node.Annotation, "abc.abstractmethod")
+}
+
 // resolveParentClassInheritance iterates over class_definition nodes, resolves parent
 // class FQNs via imports, and propagates parameter types from parent methods to child overrides.
 // For example: class TestView(View) → resolves View to django.views.View → propagates
@@ -1765,6 +1931,8 @@ func resolveParentClassInheritance(
 //
 //	Phase A: Module-level function/constructor calls (e.g., call:requests.get)
 //	Phase B: Instance method calls on variables resolved in Phase A (e.g., call:session.get)
+//
+// Loop variables are skipped: `for x in f()` binds an element of f's result.
 func resolveThirdPartyVariableBindings(typeEngine *resolution.TypeInferenceEngine, logger *output.Logger) {
 	if typeEngine.ThirdPartyRemote == nil {
 		return
@@ -1778,7 +1946,7 @@ func resolveThirdPartyVariableBindings(typeEngine *resolution.TypeInferenceEngin
 	for _, scope := range typeEngine.Scopes {
 		for varName, bindings := range scope.Variables {
 			for i, binding := range bindings {
-				if binding == nil || binding.Type == nil || !strings.HasPrefix(binding.Type.TypeFQN, "call:") {
+				if binding == nil || binding.Type == nil || binding.ForTarget || !strings.HasPrefix(binding.Type.TypeFQN, "call:") {
 					continue
 				}
 				funcName := strings.TrimPrefix(binding.Type.TypeFQN, "call:")
@@ -1827,7 +1995,7 @@ func resolveThirdPartyVariableBindings(typeEngine *resolution.TypeInferenceEngin
 	for _, scope := range typeEngine.Scopes {
 		for varName, bindings := range scope.Variables {
 			for i, binding := range bindings {
-				if binding == nil || binding.Type == nil || !strings.HasPrefix(binding.Type.TypeFQN, "call:") {
+				if binding == nil || binding.Type == nil || binding.ForTarget || !strings.HasPrefix(binding.Type.TypeFQN, "call:") {
 					continue
 				}
 				funcName := strings.TrimPrefix(binding.Type.TypeFQN, "call:")
@@ -1877,6 +2045,8 @@ func resolveThirdPartyVariableBindings(typeEngine *resolution.TypeInferenceEngin
 //
 //	Phase A: Module-level function/constructor calls (e.g., call:sqlite3.connect)
 //	Phase B: Instance method calls on variables resolved in Phase A (e.g., call:conn.cursor)
+//
+// Loop variables are skipped, as in resolveThirdPartyVariableBindings.
 func resolveStdlibVariableBindings(typeEngine *resolution.TypeInferenceEngine, logger *output.Logger) {
 	var loader *cgregistry.StdlibRegistryRemote
 	if typeEngine.StdlibRemote != nil {
@@ -1892,7 +2062,7 @@ func resolveStdlibVariableBindings(typeEngine *resolution.TypeInferenceEngine, l
 	for _, scope := range typeEngine.Scopes {
 		for varName, bindings := range scope.Variables {
 			for i, binding := range bindings {
-				if binding == nil || binding.Type == nil || !strings.HasPrefix(binding.Type.TypeFQN, "call:") {
+				if binding == nil || binding.Type == nil || binding.ForTarget || !strings.HasPrefix(binding.Type.TypeFQN, "call:") {
 					continue
 				}
 				funcName := strings.TrimPrefix(binding.Type.TypeFQN, "call:")
@@ -1963,7 +2133,7 @@ func resolveStdlibVariableBindings(typeEngine *resolution.TypeInferenceEngine, l
 	for _, scope := range typeEngine.Scopes {
 		for varName, bindings := range scope.Variables {
 			for i, binding := range bindings {
-				if binding == nil || binding.Type == nil || !strings.HasPrefix(binding.Type.TypeFQN, "call:") {
+				if binding == nil || binding.Type == nil || binding.ForTarget || !strings.HasPrefix(binding.Type.TypeFQN, "call:") {
 					continue
 				}
 				funcName := strings.TrimPrefix(binding.Type.TypeFQN, "call:")
@@ -5,6 +5,7 @@ import (
 
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/resolution"
+	sitter "github.com/smacker/go-tree-sitter"
 )
 
 // ImportMapCache provides thread-safe caching of ImportMap instances.
@@ -86,3 +87,16 @@ func (c *ImportMapCache) GetOrExtract(filePath string, sourceCode []byte, regist
 
 	return importMap, nil
 }
+
+// GetOrExtractFromAST is like GetOrExtract but extracts from an already-parsed
+// tree on a cache miss instead of parsing sourceCode again.
+func (c *ImportMapCache) GetOrExtractFromAST(filePath string, sourceCode []byte, root *sitter.Node, registry *core.ModuleRegistry) *core.ImportMap {
+	if importMap, ok := c.Get(filePath); ok {
+		return importMap
+	}
+
+	importMap := resolution.ExtractImportsFromAST(filePath, sourceCode, root, registry)
+	c.Put(filePath, importMap)
+
+	return importMap
+}
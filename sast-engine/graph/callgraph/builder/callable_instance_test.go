@@ -0,0 +1,51 @@
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCallGraph_CallableInstance(t *testing.T) {
+	projectPath, err := filepath.Abs("../../../test-fixtures/python/callable_instance")
+	require.NoError(t, err)
+
+	codeGraph := graph.Initialize(projectPath, nil)
+	callGraph, _, err := BuildCallGraphFromPath(codeGraph, projectPath, output.NewLogger(output.VerbosityDefault))
+	require.NoError(t, err)
+
+	callSite := func(caller, target string) core.CallSite {
+		for _, site := range callGraph.CallSites[caller] {
+			if site.Target == target {
+				return site
+			}
+		}
+		t.Fatalf("no call to %s in %s", target, caller)
+		return core.CallSite{}
+	}
+
+	for _, tt := range []struct{ caller, target string }{
+		{"app.dispatch", "local"},
+		{"app.dispatch_global", "handler"},
+	} {
+		t.Run(tt.caller, func(t *testing.T) {
+			site := callSite(tt.caller, tt.target)
+			assert.True(t, site.Resolved)
+			assert.Equal(t, "app.Handler.__call__", site.TargetFQN)
+			assert.Equal(t, "app.Handler", site.InferredType)
+			assert.Equal(t, "dunder_call", site.TypeSource)
+			assert.Contains(t, callGraph.Callees(tt.caller), "app.Handler.__call__")
+		})
+	}
+
+	// Plain defines no __call__, so its instance call is not routed there
+	site := callSite("app.dispatch_plain", "plain")
+	assert.NotEqual(t, "app.Plain.__call__", site.TargetFQN)
+	assert.Empty(t, site.TypeSource)
+	assert.NotContains(t, callGraph.Callees("app.dispatch_plain"), "app.Plain.__call__")
+}
@@ -0,0 +1,127 @@
+package builder
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/resolution"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+)
+
+// returnTypesCheckpoint is the state PassReturnTypes leaves for later passes.
+type returnTypesCheckpoint struct {
+	ReturnStatements          []*resolution.ReturnStatement `json:"returnStatements"`
+	FunctionsWithReturnValues map[string]bool               `json:"functionsWithReturnValues"`
+	ImportMaps                map[string]*core.ImportMap    `json:"importMaps"`
+}
+
+// variableAssignmentsCheckpoint is the state PassVariableAssignments leaves
+// for later passes: the function scopes as extracted, before their
+// placeholder types are resolved.
+type variableAssignmentsCheckpoint struct {
+	Scopes map[string]*resolution.FunctionScope `json:"scopes"`
+}
+
+// classAttributesCheckpoint is the state PassClassAttributes leaves for
+// later passes: the class attributes as extracted, before their placeholder
+// types are resolved.
+type classAttributesCheckpoint struct {
+	Classes map[string]*core.ClassAttributes `json:"classes"`
+}
+
+// callSitesCheckpoint is the state PassCallSites leaves for later passes.
+// Reverse edges are rebuilt from Edges.
+type callSitesCheckpoint struct {
+	Edges         map[string][]string        `json:"edges"`
+	CallSites     map[string][]core.CallSite `json:"callSites"`
+	ModuleImports map[string][]string        `json:"moduleImports"`
+}
+
+// buildCheckpoint saves the state of each completed build pass to an
+// AnalysisCache, and restores it in a later build of the same inputs so
+// that the build resumes after the last pass saved. Passes are restored in
+// order, and only while each of them is: once one pass runs, the later
+// passes run too. Steps between passes are not saved; they run again on
+// the restored state.
+//
+// A nil *buildCheckpoint neither saves nor restores and all methods are
+// nil-safe.
+type buildCheckpoint struct {
+	cache      *AnalysisCache
+	inputsHash string
+	logger     *output.Logger
+	resuming   bool // every pass so far was restored
+}
+
+// newBuildCheckpoint returns a checkpoint of the build of the files of
+// registry that scope includes, or nil when cache is nil.
+func newBuildCheckpoint(cache *AnalysisCache, registry *core.ModuleRegistry, scope *buildScope, logger *output.Logger) *buildCheckpoint {
+	if cache == nil {
+		return nil
+	}
+	return &buildCheckpoint{
+		cache:      cache,
+		inputsHash: checkpointInputsHash(registry, scope),
+		logger:     logger,
+		resuming:   true,
+	}
+}
+
+// checkpointInputsHash returns a digest of the module paths, file paths and
+// contents of the files a build processes. Checkpoints saved for another
+// digest are not restored.
+func checkpointInputsHash(registry *core.ModuleRegistry, scope *buildScope) string {
+	modulePaths := make([]string, 0, len(registry.Modules))
+	for modulePath, filePath := range registry.Modules {
+		if scope.includesFile(filePath) {
+			modulePaths = append(modulePaths, modulePath)
+		}
+	}
+	slices.Sort(modulePaths)
+
+	h := sha256.New()
+	for _, modulePath := range modulePaths {
+		filePath := registry.Modules[modulePath]
+		contentHash, err := hashFile(filePath)
+		if err != nil {
+			contentHash = "unreadable"
+		}
+		fmt.Fprintf(h, "%s\x00%s\x00%s\n", modulePath, filePath, contentHash)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// restore decodes the state saved for pass into state. It reports false
+// when the pass must run instead: nothing was saved for it, or an earlier
+// pass ran.
+func (c *buildCheckpoint) restore(pass string, state any) bool {
+	if c == nil || !c.resuming {
+		return false
+	}
+	data, ok := c.cache.LoadCheckpoint(pass, c.inputsHash)
+	if ok && json.Unmarshal(data, state) == nil {
+		c.logger.Debug("Restored %s pass from checkpoint", pass)
+		return true
+	}
+	c.resuming = false
+	return false
+}
+
+// save stores the state pass leaves for later passes. Failing to save is
+// not fatal to the build.
+func (c *buildCheckpoint) save(pass string, state any) {
+	if c == nil {
+		return
+	}
+	data, err := json.Marshal(state)
+	if err == nil {
+		err = c.cache.SaveCheckpoint(pass, c.inputsHash, data)
+	}
+	if err != nil {
+		c.logger.Warning("Failed to save %s checkpoint: %v", pass, err)
+	}
+}
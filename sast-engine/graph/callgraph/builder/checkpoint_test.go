@@ -0,0 +1,198 @@
+package builder
+
+import (
+	"cmp"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/resolution"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// errInterrupted stops a build from its progress callback.
+type errInterrupted struct{}
+
+// writeCheckpointFixture writes a project whose call resolution depends on
+// the state of every file pass: return types, variable types, and class
+// attributes.
+func writeCheckpointFixture(t *testing.T) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+
+	files := map[string]string{
+		"models.py": `
+class Session:
+    def execute(self, query):
+        return query
+
+class User:
+    def __init__(self, name):
+        self.name = name
+        self.session = Session()
+
+    def save(self):
+        self.session.execute("UPDATE users SET name = " + self.name)
+`,
+		"views.py": `
+from models import User
+
+def load_user(name):
+    return User(name)
+
+def update():
+    user = load_user(input())
+    user.save()
+`,
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644))
+	}
+	return tmpDir
+}
+
+// buildInterrupted builds projectPath, stopping as the pass after the
+// checkpointed ones starts.
+func buildInterrupted(t *testing.T, projectPath string, cache *AnalysisCache, stopAt string) {
+	t.Helper()
+	assert.PanicsWithValue(t, errInterrupted{}, func() {
+		_, _, _ = BuildWithOptions(projectPath, BuildOptions{
+			Checkpoint: cache,
+			Progress: func(event ProgressEvent) {
+				if event.Pass == stopAt {
+					panic(errInterrupted{})
+				}
+			},
+		})
+	})
+}
+
+// graphSnapshot returns the parts of callGraph built by the checkpointed
+// passes in a form that does not depend on the order files were processed.
+func graphSnapshot(t *testing.T, callGraph *core.CallGraph) map[string]any {
+	t.Helper()
+	edges := map[string][]string{}
+	for caller, callees := range callGraph.Edges {
+		edges[caller] = slices.Sorted(slices.Values(callees))
+	}
+	callSites := map[string][]core.CallSite{}
+	for caller, sites := range callGraph.CallSites {
+		callSites[caller] = slices.SortedFunc(slices.Values(sites), func(a, b core.CallSite) int {
+			return cmp.Or(cmp.Compare(a.Location.Line, b.Location.Line), cmp.Compare(a.Location.Column, b.Location.Column))
+		})
+	}
+	typeEngine, ok := callGraph.TypeEngine.(*resolution.TypeInferenceEngine)
+	require.True(t, ok)
+	scopes, err := json.Marshal(typeEngine.Scopes)
+	require.NoError(t, err)
+	attributes, err := json.Marshal(typeEngine.Attributes.Classes)
+	require.NoError(t, err)
+	return map[string]any{
+		"edges":      edges,
+		"callSites":  callSites,
+		"summaries":  callGraph.Summaries,
+		"scopes":     string(scopes),
+		"attributes": string(attributes),
+	}
+}
+
+func TestBuildWithOptions_ResumesFromCheckpoint(t *testing.T) {
+	tmpDir := writeCheckpointFixture(t)
+	cache, err := OpenAnalysisCache(t.TempDir())
+	require.NoError(t, err)
+	defer cache.Close()
+
+	uninterrupted, _, err := BuildWithOptions(tmpDir, BuildOptions{})
+	require.NoError(t, err)
+	require.Contains(t, uninterrupted.Edges["views.update"], "models.User.save")
+	require.Contains(t, uninterrupted.Edges["models.User.save"], "models.Session.execute")
+
+	// Interrupted after Pass 3: passes 1-3 are checkpointed.
+	buildInterrupted(t, tmpDir, cache, PassCallSites)
+
+	var passes []string
+	resumed, _, err := BuildWithOptions(tmpDir, BuildOptions{
+		Checkpoint: cache,
+		Progress: func(event ProgressEvent) {
+			if event.Processed == 0 {
+				passes = append(passes, event.Pass)
+			}
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{PassCallSites, PassTaintSummaries}, passes, "passes 1-3 are restored")
+	assert.Equal(t, graphSnapshot(t, uninterrupted), graphSnapshot(t, resumed))
+}
+
+func TestBuildWithOptions_CheckpointOfCompletedBuild(t *testing.T) {
+	tmpDir := writeCheckpointFixture(t)
+	cache, err := OpenAnalysisCache(t.TempDir())
+	require.NoError(t, err)
+	defer cache.Close()
+
+	first, _, err := BuildWithOptions(tmpDir, BuildOptions{Checkpoint: cache})
+	require.NoError(t, err)
+
+	var passes []string
+	second, _, err := BuildWithOptions(tmpDir, BuildOptions{
+		Checkpoint: cache,
+		Progress: func(event ProgressEvent) {
+			if event.Processed == 0 {
+				passes = append(passes, event.Pass)
+			}
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{PassTaintSummaries}, passes, "all file passes are restored")
+	assert.Equal(t, graphSnapshot(t, first), graphSnapshot(t, second))
+}
+
+func TestBuildWithOptions_CheckpointInvalidatedByChangedFile(t *testing.T) {
+	tmpDir := writeCheckpointFixture(t)
+	cache, err := OpenAnalysisCache(t.TempDir())
+	require.NoError(t, err)
+	defer cache.Close()
+
+	buildInterrupted(t, tmpDir, cache, PassCallSites)
+
+	views := filepath.Join(tmpDir, "views.py")
+	require.NoError(t, os.WriteFile(views, []byte(`
+from models import Session
+
+def load_user(name):
+    return Session()
+
+def update():
+    user = load_user(input())
+    user.save()
+`), 0644))
+
+	var passes []string
+	callGraph, _, err := BuildWithOptions(tmpDir, BuildOptions{
+		Checkpoint: cache,
+		Progress: func(event ProgressEvent) {
+			if event.Processed == 0 {
+				passes = append(passes, event.Pass)
+			}
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{PassReturnTypes, PassVariableAssignments, PassClassAttributes, PassCallSites, PassTaintSummaries}, passes)
+	assert.NotContains(t, callGraph.Edges["views.update"], "models.User.save")
+}
+
+func TestBuildCheckpoint_Nil(t *testing.T) {
+	var checkpoint *buildCheckpoint
+	assert.Nil(t, newBuildCheckpoint(nil, core.NewModuleRegistry(), nil, nil))
+	assert.NotPanics(t, func() {
+		assert.False(t, checkpoint.restore(PassReturnTypes, &returnTypesCheckpoint{}))
+		checkpoint.save(PassReturnTypes, returnTypesCheckpoint{})
+	})
+}
@@ -0,0 +1,26 @@
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCallGraph_CircularImports(t *testing.T) {
+	projectPath, err := filepath.Abs("../../../test-fixtures/python/circular_imports")
+	require.NoError(t, err)
+
+	codeGraph := graph.Initialize(projectPath, nil)
+	callGraph, _, err := BuildCallGraphFromPath(codeGraph, projectPath, output.NewLogger(output.VerbosityDefault))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"pkg.b"}, callGraph.ModuleImports["pkg.a"])
+	assert.Equal(t, []string{"pkg.a"}, callGraph.ModuleImports["pkg.b"], "stdlib imports are not recorded")
+	assert.Equal(t, []string{"pkg.a"}, callGraph.ModuleImports["pkg.c"])
+
+	assert.Equal(t, [][]string{{"pkg.a", "pkg.b"}}, callGraph.CircularImports())
+}
@@ -0,0 +1,60 @@
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCallGraph_ClassmethodFactories(t *testing.T) {
+	projectPath, err := filepath.Abs("../../../test-fixtures/python/classmethod_factory")
+	require.NoError(t, err)
+
+	codeGraph := graph.Initialize(projectPath, nil)
+	callGraph, _, err := BuildCallGraphFromPath(codeGraph, projectPath, output.NewLogger(output.VerbosityDefault))
+	require.NoError(t, err)
+
+	findCallSite := func(caller, target string) core.CallSite {
+		t.Helper()
+		for _, cs := range callGraph.CallSites[caller] {
+			if cs.Target == target {
+				return cs
+			}
+		}
+		require.Failf(t, "call site not found", "%s in %s", target, caller)
+		return core.CallSite{}
+	}
+
+	tests := []struct {
+		caller string
+		target string
+		want   string
+	}{
+		// Calling a classmethod on the class resolves to the method.
+		{"app.register", "User.from_dict", "models.User.from_dict"},
+		// The factory returns cls(...), so its result is a User.
+		{"app.register", "user.save", "models.User.save"},
+		{"app.welcome", "visitor.display_name", "models.User.display_name"},
+		// Constructor calls type their result as the class.
+		{"app.create", "User", "models.User"},
+		{"app.create", "user.save", "models.User.save"},
+		// cls(...) inside the factory is the class itself.
+		{"models.User.from_dict", "cls", "models.User"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.caller+"/"+tt.target, func(t *testing.T) {
+			cs := findCallSite(tt.caller, tt.target)
+			assert.True(t, cs.Resolved)
+			assert.Equal(t, tt.want, cs.TargetFQN)
+		})
+	}
+
+	saveCall := findCallSite("app.register", "user.save")
+	assert.True(t, saveCall.ResolvedViaTypeInference)
+	assert.Equal(t, "models.User", saveCall.InferredType)
+}
@@ -0,0 +1,148 @@
+package builder
+
+import (
+	"maps"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// pythonProcessCalls are the Python functions running a command, and
+// goProcessCalls the Go ones, with the index of the argument naming the
+// program (exec.CommandContext takes a context first).
+var (
+	pythonProcessCalls = map[string]int{
+		"subprocess.run":          0,
+		"subprocess.call":         0,
+		"subprocess.check_call":   0,
+		"subprocess.check_output": 0,
+		"subprocess.Popen":        0,
+		"os.system":               0,
+		"os.popen":                0,
+	}
+	goProcessCalls = map[string]int{
+		"os/exec.Command":        0,
+		"os/exec.CommandContext": 1,
+	}
+)
+
+// stringLiteral matches a quoted string literal, capturing its contents.
+var stringLiteral = regexp.MustCompile(`"([^"\\]*)"|'([^'\\]*)'|` + "`([^`]*)`")
+
+// LinkCrossLanguageCalls records the calls of callGraph that run a program
+// of another language as external edges (see core.ExternalEdge): a Python
+// subprocess running "go run ./cmd/worker" or a .go file, and a Go
+// exec.Command running a .py script. The target is the entry point of the
+// program when it is in the graph: main of the Go package in that directory,
+// or main of the script's module. Such edges are also added to Edges, so
+// reachability follows them across languages.
+//
+// Parameters:
+//   - callGraph: combined graph, with Go FQNs namespaced (see NamespaceCallGraph)
+//   - projectPath: project root, which relative program paths are resolved against
+//   - pythonRegistry: Python module registry, nil if the project has no Python
+//   - goRegistry: Go module registry, nil if the project has no go.mod
+func LinkCrossLanguageCalls(callGraph *core.CallGraph, projectPath string, pythonRegistry *core.ModuleRegistry, goRegistry *core.GoModuleRegistry) {
+	root, err := filepath.Abs(projectPath)
+	if err != nil {
+		root = projectPath
+	}
+
+	for _, caller := range slices.Sorted(maps.Keys(callGraph.CallSites)) {
+		callerLanguage, _ := core.SplitLanguage(caller)
+		for _, site := range callGraph.CallSites[caller] {
+			_, target := core.SplitLanguage(site.TargetFQN)
+			calls := pythonProcessCalls
+			if callerLanguage == core.LanguageGo {
+				calls = goProcessCalls
+			}
+			program, ok := calls[target]
+			if !ok {
+				continue
+			}
+
+			words := commandWords(site.Arguments, program)
+			language, path := externalProgram(words)
+			if language == "" || language == callerLanguage {
+				continue
+			}
+
+			edge := core.ExternalEdge{
+				Caller:   caller,
+				Language: language,
+				Command:  strings.Join(words, " "),
+				Target:   externalEntryPoint(callGraph, language, filepath.Join(root, path), pythonRegistry, goRegistry),
+				Location: site.Location,
+			}
+			callGraph.ExternalEdges = append(callGraph.ExternalEdges, edge)
+			if edge.Target != "" {
+				callGraph.AddEdge(caller, edge.Target)
+			}
+		}
+	}
+}
+
+// commandWords returns the words of the command run by a call, given as a
+// list of string literals from the program argument on (["go", "run", "."]
+// or "go", "run", ".") or as one string to split ("go run ."). Keyword
+// arguments are skipped.
+func commandWords(arguments []core.Argument, program int) []string {
+	var words []string
+	for _, argument := range arguments[min(program, len(arguments)):] {
+		if keyword, value, ok := strings.Cut(argument.Value, "="); ok && nameExpression.MatchString(strings.TrimSpace(keyword)) && !strings.HasPrefix(value, "=") {
+			continue
+		}
+		for _, match := range stringLiteral.FindAllStringSubmatch(argument.Value, -1) {
+			words = append(words, match[1]+match[2]+match[3])
+		}
+	}
+	if len(words) == 1 {
+		return strings.Fields(words[0])
+	}
+	return words
+}
+
+// externalProgram returns the language and path of the program a command
+// runs: the package directory of "go run <path>" or of the first .go file
+// named, or the first .py file named.
+// Returns "" for commands running neither.
+func externalProgram(words []string) (language, path string) {
+	if len(words) >= 3 && words[0] == "go" && words[1] == "run" {
+		if filepath.Ext(words[2]) == ".go" {
+			return core.LanguageGo, filepath.Dir(words[2])
+		}
+		return core.LanguageGo, words[2]
+	}
+	for _, word := range words {
+		switch filepath.Ext(word) {
+		case ".go":
+			return core.LanguageGo, filepath.Dir(word)
+		case ".py":
+			return core.LanguagePython, word
+		}
+	}
+	return "", ""
+}
+
+// externalEntryPoint returns the FQN of main in the Go package at dir path
+// or in the Python script at path, "" if it is not in the graph.
+func externalEntryPoint(callGraph *core.CallGraph, language, path string, pythonRegistry *core.ModuleRegistry, goRegistry *core.GoModuleRegistry) string {
+	var fqn string
+	switch {
+	case language == core.LanguageGo && goRegistry != nil:
+		if importPath, ok := goRegistry.DirToImport[path]; ok {
+			fqn = core.NamespacedFQN(core.LanguageGo, importPath+".main")
+		}
+	case language == core.LanguagePython && pythonRegistry != nil:
+		if module, ok := pythonRegistry.FileToModule[path]; ok {
+			fqn = module + ".main"
+		}
+	}
+	if _, ok := callGraph.Functions[fqn]; !ok {
+		return ""
+	}
+	return fqn
+}
@@ -0,0 +1,103 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespaceCallGraph(t *testing.T) {
+	callGraph := core.NewCallGraph()
+	callGraph.Functions["app.main"] = &graph.Node{Name: "main", Language: "go"}
+	callGraph.Functions["app.helper"] = &graph.Node{Name: "helper", Language: "go"}
+	callGraph.AddEdge("app.main", "app.helper")
+	callGraph.CallSites["app.main"] = []core.CallSite{
+		{Target: "helper", Resolved: true, TargetFQN: "app.helper"},
+		{Target: "Println", Resolved: true, TargetFQN: "fmt.Println"},
+		{Target: "unknown", TargetFQN: "unknown"},
+	}
+	callGraph.Summaries["app.main"] = &core.TaintSummary{FunctionFQN: "app.main"}
+
+	NamespaceCallGraph(callGraph, core.LanguageGo)
+
+	assert.Contains(t, callGraph.Functions, "go:app.main")
+	assert.Contains(t, callGraph.Functions, "go:app.helper")
+	assert.NotContains(t, callGraph.Functions, "app.main")
+	assert.Equal(t, []string{"go:app.helper"}, callGraph.Edges["go:app.main"])
+	assert.Equal(t, []string{"go:app.main"}, callGraph.ReverseEdges["go:app.helper"])
+	assert.Equal(t, "go:app.main", callGraph.Summaries["go:app.main"].FunctionFQN)
+
+	sites := callGraph.CallSites["go:app.main"]
+	require.Len(t, sites, 3)
+	assert.Equal(t, "go:app.helper", sites[0].TargetFQN)
+	assert.Equal(t, "go:fmt.Println", sites[1].TargetFQN)
+	assert.Equal(t, "unknown", sites[2].TargetFQN, "unresolved targets are kept as written")
+
+	// Python graphs keep their FQNs, so they merge without collision.
+	python := core.NewCallGraph()
+	python.Functions["app.main"] = &graph.Node{Name: "main", Language: "python"}
+	NamespaceCallGraph(python, core.LanguagePython)
+	MergeCallGraphs(python, callGraph)
+	assert.Len(t, python.Functions, 3)
+}
+
+func TestCommandWords(t *testing.T) {
+	tests := []struct {
+		name      string
+		arguments []string
+		program   int
+		want      []string
+	}{
+		{"list", []string{`["go", "run", "./cmd/worker"]`, "check=True"}, 0, []string{"go", "run", "./cmd/worker"}},
+		{"string", []string{`"python3 scripts/job.py --fast"`, "shell=True"}, 0, []string{"python3", "scripts/job.py", "--fast"}},
+		{"go arguments", []string{`"python3"`, `"scripts/job.py"`}, 0, []string{"python3", "scripts/job.py"}},
+		{"context first", []string{"ctx", `"python3"`, "`job.py`"}, 1, []string{"python3", "job.py"}},
+		{"variable", []string{"command"}, 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var arguments []core.Argument
+			for i, value := range tt.arguments {
+				arguments = append(arguments, core.Argument{Value: value, Position: i})
+			}
+			assert.Equal(t, tt.want, commandWords(arguments, tt.program))
+		})
+	}
+}
+
+func TestExternalProgram(t *testing.T) {
+	tests := []struct {
+		words    []string
+		language string
+		path     string
+	}{
+		{[]string{"go", "run", "./cmd/worker"}, core.LanguageGo, "./cmd/worker"},
+		{[]string{"go", "run", "tools/gen.go"}, core.LanguageGo, "tools"},
+		{[]string{"gofmt", "-w", "tools/gen.go"}, core.LanguageGo, "tools"},
+		{[]string{"python3", "scripts/job.py"}, core.LanguagePython, "scripts/job.py"},
+		{[]string{"rm", "-rf", "build"}, "", ""},
+	}
+	for _, tt := range tests {
+		language, path := externalProgram(tt.words)
+		assert.Equal(t, tt.language, language, tt.words)
+		assert.Equal(t, tt.path, path, tt.words)
+	}
+}
+
+func TestLinkCrossLanguageCalls_UnknownEntryPoint(t *testing.T) {
+	callGraph := core.NewCallGraph()
+	callGraph.CallSites["app.build"] = []core.CallSite{
+		{Target: "subprocess.run", TargetFQN: "subprocess.run", Arguments: []core.Argument{{Value: `["go", "run", "./cmd/missing"]`}}},
+		{Target: "subprocess.run", TargetFQN: "subprocess.run", Arguments: []core.Argument{{Value: `["python3", "other.py"]`}}},
+	}
+
+	LinkCrossLanguageCalls(callGraph, "/project", core.NewModuleRegistry(), nil)
+
+	require.Len(t, callGraph.ExternalEdges, 1, "Python running Python is not external")
+	assert.Equal(t, core.LanguageGo, callGraph.ExternalEdges[0].Language)
+	assert.Empty(t, callGraph.ExternalEdges[0].Target)
+	assert.Empty(t, callGraph.Edges["app.build"])
+}
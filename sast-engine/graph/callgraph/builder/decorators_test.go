@@ -0,0 +1,47 @@
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/resolution"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCallGraph_ContextManagerDecorator(t *testing.T) {
+	projectPath, err := filepath.Abs("../../../test-fixtures/python/decorators")
+	require.NoError(t, err)
+
+	codeGraph := graph.Initialize(projectPath, nil)
+	callGraph, _, err := BuildCallGraphFromPath(codeGraph, projectPath, output.NewLogger(output.VerbosityDefault))
+	require.NoError(t, err)
+
+	engine, ok := callGraph.TypeEngine.(*resolution.TypeInferenceEngine)
+	require.True(t, ok)
+
+	// Calling a @contextmanager function returns a context manager...
+	returnType, ok := engine.GetReturnType("db.open_session")
+	require.True(t, ok)
+	assert.Equal(t, resolution.ContextManagerTypeFQN, returnType.TypeFQN)
+
+	manager := engine.GetScope("db.session_factory").GetVariable("manager")
+	require.NotNil(t, manager)
+	assert.Equal(t, resolution.ContextManagerTypeFQN, manager.Type.TypeFQN)
+
+	// ...and `with open_session() as session` binds the yielded Session.
+	session := engine.GetScope("db.list_users").GetVariable("session")
+	require.NotNil(t, session)
+	assert.Equal(t, "db.Session", session.Type.TypeFQN)
+	assert.Equal(t, "context_manager_yield", session.Type.Source)
+
+	var queryFQN string
+	for _, callSite := range callGraph.CallSites["db.list_users"] {
+		if callSite.Target == "session.query" {
+			queryFQN = callSite.TargetFQN
+		}
+	}
+	assert.Equal(t, "db.Session.query", queryFQN)
+}
@@ -0,0 +1,91 @@
+package builder
+
+import (
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// definitionTimeSpan is source that runs when a function is defined rather
+// than when it is called: a decorator, or a parameter's default value.
+type definitionTimeSpan struct {
+	start, end sitter.Point
+	// scope is the name of the function whose body defines the function, or
+	// nil when it is defined at module level (directly or in a class body),
+	// where the span runs at import time.
+	scope *sitter.Node
+}
+
+// findDefinitionTimeSpans returns the decorators and parameter defaults of
+// every function defined in a file.
+func findDefinitionTimeSpans(root *sitter.Node) []definitionTimeSpan {
+	var spans []definitionTimeSpan
+	var walk func(node *sitter.Node)
+	walk = func(node *sitter.Node) {
+		switch node.Type() {
+		case "decorator":
+			spans = append(spans, definitionTimeSpan{node.StartPoint(), node.EndPoint(), definingScope(node)})
+		case "default_parameter", "typed_default_parameter":
+			// Lambda defaults run where the lambda is, with its other code
+			if value := node.ChildByFieldName("value"); value != nil && node.Parent().Type() == "parameters" {
+				spans = append(spans, definitionTimeSpan{value.StartPoint(), value.EndPoint(), definingScope(node)})
+			}
+		}
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			walk(node.NamedChild(i))
+		}
+	}
+	walk(root)
+	return spans
+}
+
+// definingScope returns the name of the function whose body runs a function
+// definition's decorators and defaults, or nil for module level.
+// node is a decorator or parameter of the definition. Class bodies run in
+// their enclosing scope, so they are passed through.
+func definingScope(node *sitter.Node) *sitter.Node {
+	// Skip past the definition the decorator or parameter belongs to
+	definition := node.Parent()
+	for definition != nil && definition.Type() != "function_definition" && definition.Type() != "decorated_definition" {
+		definition = definition.Parent()
+	}
+	if definition == nil {
+		return nil
+	}
+	if definition.Type() == "decorated_definition" {
+		definition = definition.ChildByFieldName("definition")
+	}
+	for parent := definition.Parent(); parent != nil; parent = parent.Parent() {
+		if parent.Type() != "function_definition" {
+			continue
+		}
+		return parent.ChildByFieldName("name")
+	}
+	return nil
+}
+
+// definitionTimeScope reports whether a call site runs at definition time,
+// and if so returns the location of the name of the function it runs in, or
+// nil for module level.
+func definitionTimeScope(location core.Location, spans []definitionTimeSpan) (*core.Location, bool) {
+	point := sitter.Point{Row: uint32(location.Line - 1), Column: uint32(location.Column - 1)} //nolint:gosec
+	for _, span := range spans {
+		if pointBefore(point, span.start) || !pointBefore(point, span.end) {
+			continue
+		}
+		if span.scope == nil {
+			return nil, true
+		}
+		return &core.Location{
+			File:   location.File,
+			Line:   int(span.scope.StartPoint().Row) + 1,
+			Column: int(span.scope.StartPoint().Column) + 1,
+		}, true
+	}
+	return nil, false
+}
+
+// pointBefore reports whether a comes before b in the source.
+func pointBefore(a, b sitter.Point) bool {
+	return a.Row < b.Row || (a.Row == b.Row && a.Column < b.Column)
+}
@@ -0,0 +1,67 @@
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCallGraph_DefinitionTimeCalls(t *testing.T) {
+	projectPath, err := filepath.Abs("../../../test-fixtures/python/definition_time_calls")
+	require.NoError(t, err)
+
+	codeGraph := graph.Initialize(projectPath, nil)
+	callGraph, _, err := BuildCallGraphFromPath(codeGraph, projectPath, output.NewLogger(output.VerbosityDefault))
+	require.NoError(t, err)
+
+	callLines := func(caller, targetFQN string) []int {
+		var lines []int
+		for _, site := range callGraph.CallSites[caller] {
+			if site.TargetFQN == targetFQN {
+				lines = append(lines, site.Location.Line)
+			}
+		}
+		return lines
+	}
+
+	// Module-level defaults and decorators, including those of methods, run at import time
+	assert.Contains(t, callGraph.Callees("app"), "app.make_default")
+	assert.Contains(t, callGraph.Callees("app"), "app.retry")
+	assert.Equal(t, []int{23}, callLines("app", "app.make_default"))
+	assert.Equal(t, []int{27, 33, 36}, callLines("app", "app.compute_retries"))
+	assert.Equal(t, []int{27, 36}, callLines("app", "app.retry"))
+
+	// They are not calls made by the function being defined, or the one before it
+	assert.NotContains(t, callGraph.Callees("app.handle"), "app.make_default")
+	assert.NotContains(t, callGraph.Callees("app.handle"), "app.retry")
+	assert.NotContains(t, callGraph.Callees("app.Client.send"), "app.compute_retries")
+
+	// A nested definition's defaults run in the enclosing function
+	assert.Equal(t, []int{42}, callLines("app.configure", "app.make_default"))
+	assert.Equal(t, []int{16}, callLines("app.retry.decorate", "functools.wraps"))
+
+	// A lambda's defaults run where the lambda is
+	assert.Equal(t, []int{48}, callLines("app.make_fallback", "app.make_default"))
+}
+
+func TestDefinitionTimeScope(t *testing.T) {
+	spans := []definitionTimeSpan{
+		{start: sitter.Point{Row: 2, Column: 10}, end: sitter.Point{Row: 2, Column: 24}},
+	}
+
+	scope, ok := definitionTimeScope(core.Location{Line: 3, Column: 11}, spans)
+	assert.True(t, ok)
+	assert.Nil(t, scope)
+
+	_, ok = definitionTimeScope(core.Location{Line: 3, Column: 25}, spans)
+	assert.False(t, ok, "the span end is exclusive")
+
+	_, ok = definitionTimeScope(core.Location{Line: 4, Column: 11}, spans)
+	assert.False(t, ok)
+}
@@ -0,0 +1,47 @@
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCallGraph_LookupDefaults(t *testing.T) {
+	projectPath, err := filepath.Abs("../../../test-fixtures/python/dict_get_default")
+	require.NoError(t, err)
+
+	codeGraph := graph.Initialize(projectPath, nil)
+	callGraph, _, err := BuildCallGraphFromPath(codeGraph, projectPath, output.NewLogger(output.VerbosityDefault))
+	require.NoError(t, err)
+
+	callSite := func(caller, target string) core.CallSite {
+		t.Helper()
+		for _, cs := range callGraph.CallSites[caller] {
+			if cs.Target == target {
+				return cs
+			}
+		}
+		require.Failf(t, "call site not found", "%s in %s", target, caller)
+		return core.CallSite{}
+	}
+
+	// backend = config.get("backend", LocalBackend())
+	store := callSite("app.save", "backend.store")
+	assert.True(t, store.Resolved)
+	assert.Equal(t, "app.LocalBackend.store", store.TargetFQN)
+	assert.Equal(t, "app.LocalBackend", store.InferredType)
+	assert.Contains(t, callGraph.Edges["app.save"], "app.LocalBackend.store")
+
+	// cache = getattr(settings, "cache", MemoryCache())
+	lookup := callSite("app.cached", "cache.lookup")
+	assert.True(t, lookup.Resolved)
+	assert.Equal(t, "app.MemoryCache.lookup", lookup.TargetFQN)
+
+	// A None default says nothing about the value
+	assert.NotEqual(t, "app.LocalBackend.store", callSite("app.optional", "backend.store").TargetFQN)
+}
@@ -0,0 +1,63 @@
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCallGraph_DispatchTable(t *testing.T) {
+	projectPath, err := filepath.Abs("../../../test-fixtures/python/dispatch_tables")
+	require.NoError(t, err)
+
+	codeGraph := graph.Initialize(projectPath, nil)
+	logger := output.NewLogger(output.VerbosityDefault)
+	callGraph, moduleRegistry, err := BuildCallGraphFromPath(codeGraph, projectPath, logger)
+	require.NoError(t, err)
+
+	callSite := func(caller, target string) core.CallSite {
+		for _, site := range callGraph.CallSites[caller] {
+			if site.Target == target {
+				return site
+			}
+		}
+		t.Fatalf("no call to %s in %s", target, caller)
+		return core.CallSite{}
+	}
+
+	tests := []struct {
+		caller   string
+		target   string
+		handlers []string
+	}{
+		{"app.dispatch", "HANDLERS[action]", []string{"app.handle_create", "app.handle_update", "handlers.handle_delete"}},
+		{"app.dispatch_local", "handlers[action]", []string{"app.handle_create", "app.handle_update"}},
+		{"app.run_pipeline", "steps[step]", []string{"app.handle_create", "app.handle_update"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.caller, func(t *testing.T) {
+			site := callSite(tt.caller, tt.target)
+			assert.True(t, site.Resolved)
+			assert.Equal(t, tt.handlers[0], site.TargetFQN)
+			assert.Equal(t, "dispatch_table", site.TypeSource)
+			assert.Equal(t, tt.handlers, site.DispatchTargets)
+			for _, handler := range tt.handlers {
+				assert.Contains(t, callGraph.Callees(tt.caller), handler)
+			}
+		})
+	}
+
+	// A table of builtins holds no project functions to dispatch to
+	site := callSite("app.convert", "CONVERTERS[kind]")
+	assert.Empty(t, site.TypeSource)
+	assert.Empty(t, site.DispatchTargets)
+
+	explanation := ExplainResolution(codeGraph, callGraph, moduleRegistry, "app.dispatch", "HANDLERS[action](payload)", logger)
+	assert.Equal(t, "dispatch_table", explanation.Strategy)
+	assert.Equal(t, "app.handle_create", explanation.TargetFQN)
+}
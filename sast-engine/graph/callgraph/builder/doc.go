@@ -20,12 +20,45 @@
 //  1. Direct import resolution
 //  2. Method chaining with type inference
 //  3. Self-attribute resolution (self.attr.method)
-//  4. Type inference for variable.method() calls
+//  4. Type inference for variable.method() calls, and for calls to
+//     instances of classes defining __call__ (handler(x) → Handler.__call__)
+//     Parameters annotated with a typing.Protocol or abc.ABC class resolve
+//     to the declared method (shape.draw() → Drawable.draw), with edges to
+//     the same method on every class defining all the protocol's methods.
+//     Calls to an @abstractmethod, on a receiver typed as its ABC or on self
+//     in the ABC, also get edges to every concrete subclass's override and
+//     are flagged as virtual dispatch (CallSite.DispatchTargets)
+//     Calls to a @functools.singledispatch function resolve to the
+//     implementation registered for their first argument's type, or to the
+//     function itself when the type is unknown (CallSite.DispatchType)
+//     Calls through an indexed container of functions, a dispatch table
+//     (handlers[key]() with handlers = {"a": handle_a, ...}), get edges to
+//     every function the container holds (CallSite.DispatchTargets)
+//     Loop variables iterating a generator function's result take the type
+//     it yields (for user in active_users(): user.greet() → User.greet)
+//     PEP 484 type comments declare types in legacy code: on assignments
+//     (user = load(row)  # type: User) and signatures
+//     (def find(name):  # type: (str) -> User), typing parameters and returns
+//     WSGI/ASGI middleware calling the app they wrap (self.app(environ, ...)
+//     with self.app = app in __init__) get an edge to the __call__ of each
+//     app constructed around: LoggingMiddleware(AuthMiddleware(app))
 //  5. ORM pattern detection (Django, SQLAlchemy)
 //  6. Framework detection (known external frameworks)
-//  7. Standard library resolution via remote CDN
+//  7. Standard library resolution via remote CDN, falling back offline to
+//     attributes of common stdlib submodules (os.path.join after import os)
 //
-// Each strategy is tried in order until one succeeds.
+// Each strategy is tried in order until one succeeds. ExplainResolution runs
+// a hypothetical call through the same strategies from inside a function and
+// reports which one matched, or why all failed:
+//
+//	explanation := builder.ExplainResolution(codeGraph, callGraph, moduleRegistry, "myapp.views.index", "client.fetch(url)", logger)
+//
+// Each strategy is a ResolutionStrategy registered with a priority. A Builder
+// runs custom strategies alongside the built-in ones, ordered by priority:
+//
+//	b := builder.NewBuilder()
+//	b.RegisterStrategy(rpcStrategy, builder.PrioritySimpleName+1)
+//	callGraph, err := b.BuildCallGraph(codeGraph, moduleRegistry, projectRoot, logger)
 //
 // # Multi-Pass Architecture
 //
@@ -41,10 +74,77 @@
 // This multi-pass approach ensures that all necessary type information
 // is collected before attempting to resolve call sites.
 //
+// Calls in decorators and default arguments run when a function is defined,
+// not when it is called. Pass 5 attributes them to the scope defining the
+// function: the enclosing function, or the module (its FQN is the caller)
+// for module-level functions and methods, as for other import-time calls:
+//
+//	@retry(times=compute())  # app -> app.compute
+//	def fetch(url, session=make_session()):  # app -> app.make_session
+//
+// # Changed-Files Mode
+//
+// For pull request analysis, BuildForFiles restricts the expensive passes
+// to a set of target files and their one-hop neighbours:
+//
+//	callGraph, registry, err := builder.BuildForFiles(projectRoot, changedFiles, builder.BuildOptions{})
+//
+// # Progress
+//
+// BuildOptions.Progress receives a ProgressEvent as each file pass (and the
+// taint summary pass, per function) advances, for front-ends rendering a
+// progress bar. Callbacks are never concurrent; a nil Progress costs nothing:
+//
+//	callGraph, registry, err := builder.BuildWithOptions(projectRoot, builder.BuildOptions{
+//	    Progress: func(e builder.ProgressEvent) {
+//	        fmt.Printf("\r%s %d/%d", e.Pass, e.Processed, e.Total)
+//	    },
+//	})
+//
+// # Notebooks
+//
+// Jupyter notebooks registered by registry.BuildModuleRegistryWithNotebooks
+// (or BuildOptions.IncludeNotebooks) are extracted into synthetic Python
+// modules held in the ASTCache and analyzed like any other file. The call
+// graph keeps their sources in Sources, and its SourceMapper reports their
+// locations by cell:
+//
+//	loc := callGraph.OriginalLocation(site.Location)
+//	// loc.File == "reports/analysis.ipynb#cell3", loc.Line == 2
+//
+// # Multi-Language Projects
+//
+// A Python graph and a Go graph (BuildGoCallGraph) are combined by moving
+// the Go FQNs into their namespace, so that equal FQNs cannot collide, and
+// merging. LinkCrossLanguageCalls then records calls running a program of
+// the other language, like subprocess.run(["go", "run", "./cmd/worker"]), as
+// external edges to its main function:
+//
+//	NamespaceCallGraph(goCallGraph, core.LanguageGo) // "go:example.com/app/cmd/worker.main"
+//	MergeCallGraphs(callGraph, goCallGraph)
+//	LinkCrossLanguageCalls(callGraph, projectRoot, moduleRegistry, goRegistry)
+//
 // # Caching
 //
 // The builder uses ImportMapCache to avoid re-parsing imports from
 // the same file multiple times, significantly improving performance.
+// ASTCache goes further: each file is parsed once per build and the tree
+// is shared by every pass. Entries are keyed by content hash, so a cache
+// passed via BuildOptions.ASTCache can be reused across incremental rebuilds.
+// The per-build cache keeps at most DefaultASTCacheSize trees, so larger
+// projects re-parse some files rather than hold every tree in memory.
+//
+// # Checkpoints
+//
+// With BuildOptions.Checkpoint set to an AnalysisCache, the state left by
+// each completed file pass is saved to it. When a build is interrupted, the
+// next build of the same files (validated by content hashes) restores the
+// saved passes and resumes with the first one that did not complete:
+//
+//	cache, err := builder.OpenAnalysisCache(projectRoot)
+//	callGraph, registry, err := builder.BuildWithOptions(projectRoot, builder.BuildOptions{
+//	    Checkpoint: cache,
+//	})
 //
 // # Thread Safety
 //
@@ -0,0 +1,53 @@
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/resolution"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCallGraph_EnumMemberTypes(t *testing.T) {
+	projectPath, err := filepath.Abs("../../../test-fixtures/python/enum_members")
+	require.NoError(t, err)
+
+	codeGraph := graph.Initialize(projectPath, nil)
+	callGraph, _, err := BuildCallGraphFromPath(codeGraph, projectPath, output.NewLogger(output.VerbosityDefault))
+	require.NoError(t, err)
+
+	engine, ok := callGraph.TypeEngine.(*resolution.TypeInferenceEngine)
+	require.True(t, ok)
+
+	typeOf := func(scope, name string) string {
+		binding := engine.GetVariableInScope(scope, name)
+		if binding == nil || binding.Type == nil {
+			return ""
+		}
+		return binding.Type.TypeFQN
+	}
+
+	assert.Equal(t, "app.Color", typeOf("app.paint", "color"))
+	assert.Equal(t, "builtins.int", typeOf("app.paint", "code"))
+	assert.Equal(t, "builtins.str", typeOf("app.paint", "label"))
+	assert.Equal(t, "builtins.str", typeOf("app.paint", "status"))
+
+	// Settings is not an enum, so Settings.MODE is left untyped
+	assert.Empty(t, typeOf("app.paint", "mode"))
+
+	// Color(1) looks a member up by value; every Color value is an int
+	assert.Equal(t, "app.Color", typeOf("app.lookup", "color"))
+	assert.Equal(t, "builtins.int", typeOf("app.lookup", "code"))
+	assert.Equal(t, "builtins.str", typeOf("app.lookup", "label"))
+
+	var describe string
+	for _, callSite := range callGraph.CallSites["app.paint"] {
+		if callSite.Target == "color.describe" {
+			describe = callSite.TargetFQN
+		}
+	}
+	assert.Equal(t, "app.Color.describe", describe)
+}
@@ -0,0 +1,187 @@
+package builder
+
+import (
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/extraction"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/resolution"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+)
+
+// ResolutionExplanation describes how a call would resolve if it were made
+// inside a function. See ExplainResolution.
+type ResolutionExplanation struct {
+	Function      string          // FQN of the function the call is placed in
+	Expression    string          // Expression as given (e.g., "client.fetch()")
+	Target        string          // Call target parsed from the expression (e.g., "client.fetch")
+	Resolved      bool            // Whether the call resolved
+	TargetFQN     string          // Resolved FQN, or the best guess when unresolved
+	Strategy      string          // Strategy that resolved the call (e.g., "type_inference"); empty if unresolved
+	TypeInfo      *core.TypeInfo  // Type inference the resolution relied on, if any
+	Inferences    []TypeInference // Types inferred for the names the target starts with
+	FailureReason string          // Why resolution failed (empty if Resolved)
+}
+
+// TypeInference is the type inferred for a name in scope while explaining a
+// resolution, e.g., the receiver client in client.fetch().
+type TypeInference struct {
+	Name       string  // Variable name (e.g., "client", "self")
+	Scope      string  // FQN of the function or module scope the binding was found in
+	TypeFQN    string  // Inferred type (e.g., "app.api.Client")
+	Source     string  // How the type was inferred (e.g., "class_instantiation_local")
+	Confidence float32 // Confidence of the inference
+}
+
+// ExplainResolution runs a hypothetical call through the call resolution
+// strategies as if it appeared in the body of funcFQN, without the call
+// being in the source. expr is a Python call expression such as
+// "client.fetch(url)"; the parentheses may be omitted. Uses the imports of
+// the function's file and the type engine of a built call graph.
+//
+// When the function is unknown or expr is not a call, the explanation is
+// unresolved with FailureReason "unknown_function" or "not_a_call".
+func ExplainResolution(codeGraph *graph.CodeGraph, callGraph *core.CallGraph, registry *core.ModuleRegistry, funcFQN, expr string, logger *output.Logger) ResolutionExplanation {
+	explanation := ResolutionExplanation{Function: funcFQN, Expression: expr}
+
+	function, ok := callGraph.GetFunction(funcFQN)
+	if !ok {
+		explanation.FailureReason = "unknown_function"
+		return explanation
+	}
+	module := registry.FileToModule[function.File]
+	typeEngine, _ := callGraph.TypeEngine.(*resolution.TypeInferenceEngine)
+	importMap := core.NewImportMap(function.File)
+	if typeEngine != nil {
+		if fileImports := typeEngine.GetImportMap(function.File); fileImports != nil {
+			importMap = fileImports
+		}
+	}
+
+	target := parseCallTarget(expr, function.File, importMap)
+	if target == "" {
+		explanation.FailureReason = "not_a_call"
+		return explanation
+	}
+	explanation.Target = target
+	explanation.Inferences = scopeInferences(target, funcFQN, function, module, typeEngine)
+
+	targetFQN, resolved, typeInfo := resolveCallTarget(target, importMap, registry, module, codeGraph, typeEngine, funcFQN, callGraph, logger)
+	explanation.TargetFQN = targetFQN
+	explanation.Resolved = resolved
+	explanation.TypeInfo = typeInfo
+	if resolved {
+		explanation.Strategy = resolutionStrategy(target, importMap, typeInfo)
+	} else {
+		explanation.FailureReason = categorizeResolutionFailure(target, targetFQN, typeEngine)
+	}
+	return explanation
+}
+
+// parseCallTarget returns the target of the outermost call in expr, as the
+// call site extractor records it, or "" if expr does not parse to a call.
+func parseCallTarget(expr, filePath string, importMap *core.ImportMap) string {
+	expr = strings.TrimSpace(expr)
+	if expr != "" && !strings.HasSuffix(expr, ")") {
+		expr += "()"
+	}
+	sourceCode := []byte(expr)
+	tree, err := extraction.ParsePythonFile(sourceCode)
+	if err != nil {
+		return ""
+	}
+	defer tree.Close()
+
+	// Calls in arguments and chain receivers are extracted too. The outermost
+	// call starts first; of a chain's calls, it has the longest target.
+	var outermost *core.CallSite
+	for _, callSite := range resolution.ExtractCallSitesFromAST(filePath, sourceCode, tree.RootNode(), importMap) {
+		if outermost == nil || callSite.Location.Column < outermost.Location.Column ||
+			(callSite.Location.Column == outermost.Location.Column && len(callSite.Target) > len(outermost.Target)) {
+			outermost = callSite
+		}
+	}
+	if outermost == nil {
+		return ""
+	}
+	return outermost.Target
+}
+
+// scopeInferences returns the types bound to the name a target starts with:
+// the class of self or cls in a method, or the variable in the function's
+// scope, falling back to the module's.
+func scopeInferences(target, funcFQN string, function *graph.Node, module string, typeEngine *resolution.TypeInferenceEngine) []TypeInference {
+	name, _, _ := strings.Cut(target, ".")
+	name, _, _ = strings.Cut(name, "(")
+
+	if name == "self" || name == "cls" {
+		switch function.Type {
+		case "method", "constructor", "special_method", "property":
+			classFQN := funcFQN[:strings.LastIndex(funcFQN, ".")]
+			return []TypeInference{{Name: name, Scope: funcFQN, TypeFQN: classFQN, Source: "enclosing_class", Confidence: 1.0}}
+		}
+		return nil
+	}
+	if typeEngine == nil || name == "" {
+		return nil
+	}
+
+	scope, binding := funcFQN, typeEngine.GetVariableInScope(funcFQN, name)
+	if binding == nil {
+		if moduleScope := typeEngine.GetScope(module); moduleScope != nil {
+			scope, binding = module, moduleScope.GetVariable(name)
+		}
+	}
+	if binding == nil || binding.Type == nil {
+		return nil
+	}
+	return []TypeInference{{
+		Name:       name,
+		Scope:      scope,
+		TypeFQN:    binding.Type.TypeFQN,
+		Source:     binding.Type.Source,
+		Confidence: binding.Type.Confidence,
+	}}
+}
+
+// resolutionStrategy names the resolveCallTarget strategy that resolves a
+// target of this shape, following its order: method chains, self
+// attributes, super(), abstract methods, cls and self methods, dispatch
+// tables, then simple names (builtins, callable instances, imports, the
+// current module) and dotted names (protocol methods, type inference,
+// imports, module attributes).
+func resolutionStrategy(target string, importMap *core.ImportMap, typeInfo *core.TypeInfo) string {
+	base, _, dotted := strings.Cut(target, ".")
+	switch {
+	case strings.Contains(target, ")."):
+		return "method_chain"
+	case strings.HasPrefix(target, "self.") && strings.Count(target, ".") >= 2:
+		return "self_attribute"
+	case strings.HasPrefix(target, "super()."):
+		return "super_call"
+	case target == "cls":
+		return "cls_instantiation"
+	case typeInfo != nil && typeInfo.Source == "abstract_method":
+		return "abstract_method"
+	case base == "self" || base == "cls":
+		return "self_method"
+	case typeInfo != nil && typeInfo.Source == "dispatch_table":
+		return "dispatch_table"
+	case typeInfo != nil && typeInfo.Source == "dunder_call":
+		return "callable_instance"
+	case !dotted && pythonBuiltins[target]:
+		return "builtin"
+	case typeInfo != nil && typeInfo.Source == "protocol":
+		return "protocol_method"
+	case typeInfo != nil:
+		return "type_inference"
+	}
+	if _, ok := importMap.Resolve(base); ok {
+		return "import"
+	}
+	if !dotted {
+		return "same_module"
+	}
+	return "module_attribute"
+}
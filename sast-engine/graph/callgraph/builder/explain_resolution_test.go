@@ -0,0 +1,79 @@
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainResolution(t *testing.T) {
+	projectPath, err := filepath.Abs("../../../test-fixtures/python/explain_resolution")
+	require.NoError(t, err)
+
+	logger := output.NewLogger(output.VerbosityDefault)
+	codeGraph := graph.Initialize(projectPath, nil)
+	callGraph, moduleRegistry, err := BuildCallGraphFromPath(codeGraph, projectPath, logger)
+	require.NoError(t, err)
+
+	explain := func(funcFQN, expr string) ResolutionExplanation {
+		return ExplainResolution(codeGraph, callGraph, moduleRegistry, funcFQN, expr, logger)
+	}
+
+	t.Run("resolves through the receiver's inferred type", func(t *testing.T) {
+		explanation := explain("app.handler", "client.fetch(url)")
+		assert.Equal(t, "client.fetch", explanation.Target)
+		assert.True(t, explanation.Resolved)
+		assert.Equal(t, "app.Client.fetch", explanation.TargetFQN)
+		assert.Equal(t, "type_inference", explanation.Strategy)
+		require.Len(t, explanation.Inferences, 1)
+		assert.Equal(t, "client", explanation.Inferences[0].Name)
+		assert.Equal(t, "app.handler", explanation.Inferences[0].Scope)
+		assert.Equal(t, "app.Client", explanation.Inferences[0].TypeFQN)
+		assert.Empty(t, explanation.FailureReason)
+	})
+
+	t.Run("resolves self methods", func(t *testing.T) {
+		explanation := explain("app.Service.run", "self.helper")
+		assert.True(t, explanation.Resolved)
+		assert.Equal(t, "app.Service.helper", explanation.TargetFQN)
+		assert.Equal(t, "self_method", explanation.Strategy)
+		assert.Equal(t, []TypeInference{{Name: "self", Scope: "app.Service.run", TypeFQN: "app.Service", Source: "enclosing_class", Confidence: 1.0}}, explanation.Inferences)
+	})
+
+	t.Run("explains why an unknown receiver fails", func(t *testing.T) {
+		explanation := explain("app.handler", "session.pool.conn.fetch(url)")
+		assert.Equal(t, "session.pool.conn.fetch", explanation.Target)
+		assert.False(t, explanation.Resolved)
+		assert.Empty(t, explanation.Strategy)
+		assert.Empty(t, explanation.Inferences)
+		assert.Equal(t, "attribute_chain", explanation.FailureReason)
+	})
+
+	t.Run("does not add the call to the graph", func(t *testing.T) {
+		assert.NotContains(t, callGraph.Callees("app.handler"), "app.Client.fetch")
+	})
+
+	t.Run("unknown function", func(t *testing.T) {
+		explanation := explain("app.missing", "client.fetch()")
+		assert.False(t, explanation.Resolved)
+		assert.Equal(t, "unknown_function", explanation.FailureReason)
+	})
+
+	t.Run("not a call", func(t *testing.T) {
+		explanation := explain("app.handler", "")
+		assert.False(t, explanation.Resolved)
+		assert.Equal(t, "not_a_call", explanation.FailureReason)
+	})
+}
+
+func TestParseCallTarget(t *testing.T) {
+	importMap := core.NewImportMap("app.py")
+	assert.Equal(t, "foo", parseCallTarget("foo(bar.baz.qux())", "app.py", importMap))
+	assert.Equal(t, "a.b", parseCallTarget("a().b()", "app.py", importMap))
+	assert.Equal(t, "obj.method", parseCallTarget("  obj.method ", "app.py", importMap))
+}
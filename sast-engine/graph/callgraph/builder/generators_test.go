@@ -0,0 +1,57 @@
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/resolution"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCallGraph_GeneratorElements(t *testing.T) {
+	projectPath, err := filepath.Abs("../../../test-fixtures/python/generators")
+	require.NoError(t, err)
+
+	codeGraph := graph.Initialize(projectPath, nil)
+	callGraph, _, err := BuildCallGraphFromPath(codeGraph, projectPath, output.NewLogger(output.VerbosityDefault))
+	require.NoError(t, err)
+
+	engine, ok := callGraph.TypeEngine.(*resolution.TypeInferenceEngine)
+	require.True(t, ok)
+
+	// Calling a generator function returns a generator...
+	returnType, ok := engine.GetReturnType("users.active_users")
+	require.True(t, ok)
+	assert.Equal(t, resolution.GeneratorTypeFQN, returnType.TypeFQN)
+
+	// ...and iterating it binds the yielded value, directly or through a
+	// variable holding the generator.
+	user := engine.GetScope("users.greet_active").GetVariable("user")
+	require.NotNil(t, user)
+	assert.Equal(t, "users.User", user.Type.TypeFQN)
+	assert.Equal(t, "generator_yield", user.Type.Source)
+
+	admin := engine.GetScope("users.greet_admins").GetVariable("admin")
+	require.NotNil(t, admin)
+	assert.Equal(t, "users.User", admin.Type.TypeFQN)
+
+	targetOf := func(caller, target string) string {
+		for _, callSite := range callGraph.CallSites[caller] {
+			if callSite.Target == target {
+				return callSite.TargetFQN
+			}
+		}
+		return ""
+	}
+	assert.Equal(t, "users.User.greet", targetOf("users.greet_active", "user.greet"))
+	assert.Equal(t, "users.User.greet", targetOf("users.greet_admins", "admin.greet"))
+
+	// Iterating a list-returning function does not bind the list itself
+	name := engine.GetScope("users.shout_names").GetVariable("name")
+	require.NotNil(t, name)
+	assert.NotEqual(t, "builtins.list", name.Type.TypeFQN)
+	assert.NotEqual(t, "builtins.list.upper", targetOf("users.shout_names", "name.upper"))
+}
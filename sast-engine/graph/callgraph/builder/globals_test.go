@@ -0,0 +1,57 @@
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/analysis/taint"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func buildGlobalScopeCallGraph(t *testing.T) *core.CallGraph {
+	t.Helper()
+	projectPath, err := filepath.Abs("../../../test-fixtures/python/global_scope")
+	require.NoError(t, err)
+
+	codeGraph := graph.Initialize(projectPath, nil)
+	callGraph, _, err := BuildCallGraphFromPath(codeGraph, projectPath, output.NewLogger(output.VerbosityDefault))
+	require.NoError(t, err)
+	return callGraph
+}
+
+func TestBuildCallGraph_GlobalTaint(t *testing.T) {
+	callGraph := buildGlobalScopeCallGraph(t)
+
+	// configure() taints the global command; run() reads it into os.system.
+	// run_local() assigns its own command, so eval is not reached.
+	sinks := taint.AnalyzeReachableSinks(callGraph, []string{"input"}, []string{"os.system", "eval"})
+	require.Len(t, sinks, 1)
+	assert.Equal(t, taint.ReachableSink{
+		SinkFQN: "os.system",
+		Sources: []string{"builtins.input"},
+		Path:    []string{"app.configure", "app.run"},
+	}, sinks[0])
+}
+
+func TestBuildCallGraph_GlobalAndNonlocalTypes(t *testing.T) {
+	callGraph := buildGlobalScopeCallGraph(t)
+
+	calleeFQN := func(caller, target string) string {
+		for _, callSite := range callGraph.CallSites[caller] {
+			if callSite.Target == target {
+				return callSite.TargetFQN
+			}
+		}
+		return ""
+	}
+
+	// client is assigned a Client in connect() under `global client`
+	assert.Equal(t, "app.Client.send", calleeFQN("app.notify", "client.send"))
+
+	// close() reads conn through `nonlocal conn` from session()
+	assert.Equal(t, "app.Client.send", calleeFQN("app.session.close", "conn.send"))
+}
@@ -672,7 +672,7 @@ func indexGoFunctions(codeGraph *graph.CodeGraph, callGraph *core.CallGraph, reg
 		fqn := buildGoFQN(node, parentMap, registry)
 
 		// Add to CallGraph.Functions
-		callGraph.Functions[fqn] = node
+		callGraph.AddFunction(fqn, node)
 
 		// Eagerly create scope so Pattern 1b Source 2 always finds one.
 		// Guard with GetScope == nil so Pass 2b bindings are not overwritten.
@@ -0,0 +1,47 @@
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/analysis/taint"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/cfg"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCallGraph_GuardedConversionNarrowsTaint(t *testing.T) {
+	projectPath, err := filepath.Abs("../../../test-fixtures/python/guarded_conversion")
+	require.NoError(t, err)
+
+	codeGraph := graph.Initialize(projectPath, nil)
+	callGraph, _, err := BuildCallGraphFromPath(codeGraph, projectPath, output.NewLogger(output.VerbosityDefault))
+	require.NoError(t, err)
+
+	sinkLines := func(funcFQN string) []uint32 {
+		t.Helper()
+		cfGraph, ok := callGraph.CFGs[funcFQN].(*cfg.ControlFlowGraph)
+		require.True(t, ok, funcFQN)
+		blockStmts, ok := callGraph.CFGBlockStatements[funcFQN].(cfg.BlockStatements)
+		require.True(t, ok, funcFQN)
+
+		summary := taint.AnalyzeWithCFG(funcFQN, cfGraph, blockStmts, []string{"request.GET.get"}, []string{"execute"}, nil)
+		var lines []uint32
+		for _, detection := range summary.Detections {
+			lines = append(lines, detection.SinkLine)
+		}
+		return lines
+	}
+
+	// int() in a try whose handler returns: the integer query is clean, the
+	// raw string is still tainted
+	assert.Equal(t, []uint32{13}, sinkLines("app.paginate"))
+
+	// uuid.UUID() in a try whose handler raises
+	assert.Empty(t, sinkLines("app.lookup"))
+
+	// The handler falls through with the raw string
+	assert.Equal(t, []uint32{31}, sinkLines("app.lenient"))
+}
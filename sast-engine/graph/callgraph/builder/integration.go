@@ -1,6 +1,9 @@
 package builder
 
 import (
+	"errors"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
@@ -49,3 +52,100 @@ func BuildCallGraphFromPath(codeGraph *graph.CodeGraph, projectPath string, logg
 
 	return callGraph, moduleRegistry, nil
 }
+
+// BuildCallGraphFromSources builds a call graph from in-memory Python sources
+// keyed by virtual path, without touching the file system. This serves
+// buffers that are not on disk yet, such as editor plugins and playground
+// requests.
+//
+// Virtual paths are relative to an imaginary project root and determine
+// module paths the same way real paths do ("app/views.py" → "app.views");
+// a leading "/" is ignored. Functions, call sites, and other locations
+// report the virtual paths unchanged. Non-Python entries are ignored.
+//
+// Parameters:
+//   - sources: file contents keyed by virtual path
+//
+// Returns:
+//   - CallGraph: complete call graph with edges and call sites
+//   - ModuleRegistry: module path mappings for the virtual files
+//   - error: if the call graph cannot be built
+func BuildCallGraphFromSources(sources map[string][]byte) (*core.CallGraph, *core.ModuleRegistry, error) {
+	pythonSources := make(map[string][]byte, len(sources))
+	moduleRegistry := core.NewModuleRegistry()
+	astCache := NewBoundedASTCache(DefaultASTCacheSize)
+	defer astCache.Close()
+
+	for path, sourceCode := range sources {
+		if !strings.HasSuffix(path, ".py") {
+			continue
+		}
+		pythonSources[path] = sourceCode
+		moduleRegistry.AddModule(registry.RelativeModulePath(strings.TrimPrefix(filepath.ToSlash(path), "/")), path)
+		astCache.AddSource(path, sourceCode)
+	}
+
+	codeGraph := graph.InitializeFromSources(pythonSources)
+	logger := output.NewLogger(output.VerbosityDefault)
+	callGraph, err := buildCallGraph(codeGraph, moduleRegistry, "", logger, nil, astCache, defaultStrategies, nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return callGraph, moduleRegistry, nil
+}
+
+// BuildCallGraphFromPaths builds one call graph over several project roots,
+// such as the services of a monorepo, so calls between roots resolve.
+// Module paths are relative to each file's own root (see
+// registry.BuildModuleRegistryFromRoots), and the Python version is
+// detected from the first root.
+//
+// Parameters:
+//   - roots: project root directories
+//   - logger: structured logger for diagnostics
+//
+// Returns:
+//   - CallGraph: unified call graph with edges and call sites
+//   - ModuleRegistry: module path mappings across all roots
+//   - error: a *registry.ModuleConflictError if two roots define the same
+//     top-level package, or if any step fails
+func BuildCallGraphFromPaths(roots []string, logger *output.Logger) (*core.CallGraph, *core.ModuleRegistry, error) {
+	if len(roots) == 0 {
+		return nil, nil, errors.New("no project roots given")
+	}
+
+	// Pass 1: Build module registry
+	startRegistry := time.Now()
+	moduleRegistry, err := registry.BuildModuleRegistryFromRoots(roots, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	elapsedRegistry := time.Since(startRegistry)
+
+	// Pass 2: Parse every root into one code graph
+	codeGraph := graph.NewCodeGraph()
+	for _, root := range roots {
+		rootGraph := graph.Initialize(root, nil)
+		for _, node := range rootGraph.Nodes {
+			codeGraph.AddNode(node)
+		}
+		for _, edge := range rootGraph.Edges {
+			codeGraph.AddEdge(edge.From, edge.To)
+		}
+	}
+	// Classes may inherit from bases in another root
+	graph.ResolveTransitiveInheritance(codeGraph)
+
+	// Pass 3: Build call graph
+	startCallGraph := time.Now()
+	callGraph, err := BuildCallGraph(codeGraph, moduleRegistry, roots[0], logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	elapsedCallGraph := time.Since(startCallGraph)
+
+	graph.Log("Module registry built in:", elapsedRegistry)
+	graph.Log("Call graph built in:", elapsedCallGraph)
+
+	return callGraph, moduleRegistry, nil
+}
@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/registry"
 	"github.com/shivasurya/code-pathfinder/sast-engine/output"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -100,3 +102,73 @@ def main():
 	// Verify functions from both files are indexed
 	assert.NotEmpty(t, callGraph.Functions)
 }
+
+func TestBuildCallGraphFromSources(t *testing.T) {
+	sources := map[string][]byte{
+		"app/views.py": []byte(`from app.utils import sanitize
+
+def index(request):
+    name = request.args.get("name")
+    return sanitize(name)
+`),
+		"app/utils.py": []byte(`
+def sanitize(value):
+    return value.strip()
+`),
+		"README.md": []byte("# not python"),
+	}
+
+	callGraph, moduleRegistry, err := BuildCallGraphFromSources(sources)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"app.views": "app/views.py", "app.utils": "app/utils.py"}, moduleRegistry.Modules)
+
+	require.Contains(t, callGraph.Functions, "app.views.index")
+	require.Contains(t, callGraph.Functions, "app.utils.sanitize")
+	assert.Equal(t, "app/views.py", callGraph.Functions["app.views.index"].File)
+
+	assert.Contains(t, callGraph.GetCallees("app.views.index"), "app.utils.sanitize")
+	var sanitizeCall *core.CallSite
+	for i, callSite := range callGraph.CallSites["app.views.index"] {
+		if callSite.TargetFQN == "app.utils.sanitize" {
+			sanitizeCall = &callGraph.CallSites["app.views.index"][i]
+		}
+	}
+	require.NotNil(t, sanitizeCall)
+	assert.True(t, sanitizeCall.Resolved)
+	assert.Equal(t, "app/views.py", sanitizeCall.Location.File)
+	assert.Equal(t, 5, sanitizeCall.Location.Line)
+	assert.NotEmpty(t, callGraph.Statements["app.views.index"], "taint summaries read the in-memory source")
+}
+
+func TestBuildCallGraphFromPaths(t *testing.T) {
+	services, err := filepath.Abs("../../../test-fixtures/python/multi_root/services")
+	require.NoError(t, err)
+	roots := []string{filepath.Join(services, "a"), filepath.Join(services, "b")}
+
+	callGraph, moduleRegistry, err := BuildCallGraphFromPaths(roots, output.NewLogger(output.VerbosityDefault))
+	require.NoError(t, err)
+
+	assert.Contains(t, moduleRegistry.Modules, "billing.invoice")
+	assert.Contains(t, moduleRegistry.Modules, "shop.checkout")
+	assert.Contains(t, callGraph.Functions, "billing.invoice.create_invoice")
+	assert.Contains(t, callGraph.Functions, "shop.checkout.checkout")
+
+	// The call from root b into root a resolves
+	assert.Contains(t, callGraph.GetCallees("shop.checkout.checkout"), "billing.invoice.create_invoice")
+	assert.Contains(t, callGraph.GetCallers("billing.invoice.create_invoice"), "shop.checkout.checkout")
+}
+
+func TestBuildCallGraphFromPaths_Conflict(t *testing.T) {
+	services, err := filepath.Abs("../../../test-fixtures/python/multi_root/services")
+	require.NoError(t, err)
+	roots := []string{filepath.Join(services, "a"), filepath.Join(services, "legacy")}
+
+	_, _, err = BuildCallGraphFromPaths(roots, output.NewLogger(output.VerbosityDefault))
+	var conflictErr *registry.ModuleConflictError
+	require.ErrorAs(t, err, &conflictErr)
+	assert.Equal(t, []registry.ModuleConflict{{Package: "billing", Roots: roots}}, conflictErr.Conflicts)
+
+	_, _, err = BuildCallGraphFromPaths(nil, output.NewLogger(output.VerbosityDefault))
+	assert.Error(t, err)
+}
@@ -0,0 +1,69 @@
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/resolution"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCallGraph_Memoization(t *testing.T) {
+	projectPath, err := filepath.Abs("../../../test-fixtures/python/memoization")
+	require.NoError(t, err)
+
+	codeGraph := graph.Initialize(projectPath, nil)
+	callGraph, _, err := BuildCallGraphFromPath(codeGraph, projectPath, output.NewLogger(output.VerbosityDefault))
+	require.NoError(t, err)
+
+	memoized := map[string]string{
+		"reports.Report.title":   "cached_property",
+		"reports.Report.owner":   "cached_property",
+		"reports.load_report":    "lru_cache",
+		"reports.default_report": "cache",
+	}
+	for fqn, kind := range memoized {
+		function, ok := callGraph.GetFunction(fqn)
+		require.True(t, ok, fqn)
+		assert.Equal(t, kind, function.Metadata[graph.MetadataMemoization], fqn)
+	}
+	title, _ := callGraph.GetFunction("reports.Report.title")
+	assert.Equal(t, "property", title.Type)
+	render, _ := callGraph.GetFunction("reports.Report.render")
+	assert.Nil(t, render.Metadata)
+
+	engine, ok := callGraph.TypeEngine.(*resolution.TypeInferenceEngine)
+	require.True(t, ok)
+	typeOf := func(scope, name string) string {
+		binding := engine.GetScope(scope).GetVariable(name)
+		if binding == nil || binding.Type == nil {
+			return ""
+		}
+		return binding.Type.TypeFQN
+	}
+
+	// Memoized functions resolve to themselves and keep their return types
+	assert.Equal(t, "reports.Report", typeOf("reports.publish", "report"))
+	assert.Equal(t, "reports.Report", typeOf("reports.publish", "fallback"))
+
+	// Reading a cached_property types as the getter's return
+	assert.Equal(t, "builtins.str", typeOf("reports.publish", "title"))
+	assert.Equal(t, "reports.Owner", typeOf("reports.publish", "owner"))
+	assert.Equal(t, "builtins.str", typeOf("reports.Report.render", "heading"))
+
+	targets := make(map[string]string)
+	for _, caller := range []string{"reports.publish", "reports.Report.render"} {
+		for _, callSite := range callGraph.CallSites[caller] {
+			targets[callSite.Target] = callSite.TargetFQN
+		}
+	}
+	assert.Equal(t, "reports.load_report", targets["load_report"])
+	assert.Equal(t, "reports.default_report", targets["default_report"])
+	assert.Equal(t, "builtins.str.upper", targets["title.upper"])
+	assert.Equal(t, "builtins.str.upper", targets["heading.upper"])
+	assert.Equal(t, "reports.Owner.notify", targets["owner.notify"])
+	assert.Equal(t, "reports.Report.render", targets["fallback.render"])
+}
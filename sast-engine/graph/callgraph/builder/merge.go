@@ -20,6 +20,7 @@ import (
 //   - CallSites: Append sites from src to dst
 //   - Edges: Append callees from src to dst
 //   - ReverseEdges: Append callers from src to dst
+//   - ExternalEdges: Append edges from src to dst
 //
 // Parameters:
 //   - dst: destination call graph (e.g., Python call graph)
@@ -45,11 +46,87 @@ func MergeCallGraphs(dst, src *core.CallGraph) {
 		dst.ReverseEdges[callee] = append(dst.ReverseEdges[callee], callers...)
 	}
 
-	// Merge dataflow analysis data (Statements, CFGs, CFGBlockStatements, Summaries).
+	// Merge dataflow analysis data (Statements, CFGs, CFGBlockStatements, Summaries, GlobalWrites)
+	// and the module import graph.
 	// These maps are keyed by FQN — Go and Python FQN namespaces are disjoint,
 	// so maps.Copy is safe (no key collisions).
 	maps.Copy(dst.Statements, src.Statements)
 	maps.Copy(dst.CFGs, src.CFGs)
 	maps.Copy(dst.CFGBlockStatements, src.CFGBlockStatements)
 	maps.Copy(dst.Summaries, src.Summaries)
+	maps.Copy(dst.GlobalWrites, src.GlobalWrites)
+	maps.Copy(dst.ModuleImports, src.ModuleImports)
+
+	dst.ExternalEdges = append(dst.ExternalEdges, src.ExternalEdges...)
+}
+
+// NamespaceCallGraph moves the FQNs of callGraph, built for one language,
+// into that language's namespace (see core.NamespacedFQN), so that merging
+// it with graphs of other languages cannot collide: a Go module named
+// "app" and a Python package "app" both define "app.main". Resolved call
+// targets are moved too; unresolved targets are names as written.
+//
+// Note: This function modifies callGraph in-place.
+func NamespaceCallGraph(callGraph *core.CallGraph, language string) {
+	if language == core.LanguagePython {
+		return
+	}
+	namespaced := func(fqn string) string {
+		return core.NamespacedFQN(language, fqn)
+	}
+	namespacedAll := func(fqns []string) []string {
+		out := make([]string, len(fqns))
+		for i, fqn := range fqns {
+			out[i] = namespaced(fqn)
+		}
+		return out
+	}
+
+	callGraph.Functions = namespaceKeys(callGraph.Functions, namespaced)
+	callGraph.Parameters = namespaceKeys(callGraph.Parameters, namespaced)
+	callGraph.Statements = namespaceKeys(callGraph.Statements, namespaced)
+	callGraph.CFGs = namespaceKeys(callGraph.CFGs, namespaced)
+	callGraph.CFGBlockStatements = namespaceKeys(callGraph.CFGBlockStatements, namespaced)
+	callGraph.GlobalWrites = namespaceKeys(callGraph.GlobalWrites, namespaced)
+
+	callGraph.Summaries = namespaceKeys(callGraph.Summaries, namespaced)
+	for _, summary := range callGraph.Summaries {
+		if summary != nil {
+			summary.FunctionFQN = namespaced(summary.FunctionFQN)
+		}
+	}
+
+	callGraph.Edges = namespaceKeys(callGraph.Edges, namespaced)
+	for caller, callees := range callGraph.Edges {
+		callGraph.Edges[caller] = namespacedAll(callees)
+	}
+	callGraph.ReverseEdges = namespaceKeys(callGraph.ReverseEdges, namespaced)
+	for callee, callers := range callGraph.ReverseEdges {
+		callGraph.ReverseEdges[callee] = namespacedAll(callers)
+	}
+	callGraph.ModuleImports = namespaceKeys(callGraph.ModuleImports, namespaced)
+	for module, imported := range callGraph.ModuleImports {
+		callGraph.ModuleImports[module] = namespacedAll(imported)
+	}
+
+	callGraph.CallSites = namespaceKeys(callGraph.CallSites, namespaced)
+	for _, sites := range callGraph.CallSites {
+		for i := range sites {
+			if sites[i].Resolved {
+				sites[i].TargetFQN = namespaced(sites[i].TargetFQN)
+			}
+			if sites[i].DispatchTargets != nil {
+				sites[i].DispatchTargets = namespacedAll(sites[i].DispatchTargets)
+			}
+		}
+	}
+}
+
+// namespaceKeys returns m with its keys passed through namespaced.
+func namespaceKeys[V any](m map[string]V, namespaced func(string) string) map[string]V {
+	out := make(map[string]V, len(m))
+	for key, value := range m {
+		out[namespaced(key)] = value
+	}
+	return out
 }
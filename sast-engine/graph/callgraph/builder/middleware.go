@@ -0,0 +1,137 @@
+package builder
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/resolution"
+)
+
+// constructorExpression matches an argument that constructs an instance,
+// capturing the (dotted) class name: "AuthMiddleware(app)" → "AuthMiddleware".
+var constructorExpression = regexp.MustCompile(`^([A-Za-z_][\w.]*)\(.*\)$`)
+
+// nameExpression matches an argument that is a plain name.
+var nameExpression = regexp.MustCompile(`^[A-Za-z_]\w*$`)
+
+// linkMiddlewareChains reconstructs WSGI/ASGI middleware pipelines. A
+// middleware stores the app it wraps in __init__ (self.app = app) and calls
+// it from __call__ (self.app(environ, start_response)). For each construction
+// of such a class, as in LoggingMiddleware(AuthMiddleware(app)), the wrapped
+// app's __call__, or the app itself if it is a function, becomes a callee of
+// the middleware's __call__, and the self.app call site resolves to it with
+// TypeSource "middleware_chain". The wrapped app may be constructed in the
+// argument or held by a variable assigned before the call.
+func linkMiddlewareChains(callGraph *core.CallGraph, registry *core.ModuleRegistry, typeEngine *resolution.TypeInferenceEngine) {
+	for _, caller := range slices.Sorted(maps.Keys(callGraph.CallSites)) {
+		for _, site := range callGraph.CallSites[caller] {
+			if !site.Resolved {
+				continue
+			}
+			classAttrs := typeEngine.Attributes.GetClassAttributes(site.TargetFQN)
+			if classAttrs == nil || len(classAttrs.StoredParameters) == 0 {
+				continue
+			}
+			init, ok := callGraph.GetFunction(site.TargetFQN + ".__init__")
+			if !ok {
+				continue
+			}
+			callFQN := site.TargetFQN + ".__call__"
+			if _, ok := callGraph.GetFunction(callFQN); !ok {
+				continue
+			}
+			for _, binding := range core.BindArguments(init, site.Arguments) {
+				attribute, ok := classAttrs.StoredParameters[binding.Parameter]
+				if !ok {
+					continue
+				}
+				expr := binding.Argument.Value
+				if keyword, value, ok := strings.Cut(expr, "="); ok && strings.TrimSpace(keyword) == binding.Parameter {
+					expr = strings.TrimSpace(value)
+				}
+				app := middlewareAppTarget(expr, caller, site, registry, typeEngine, callGraph)
+				if app == "" {
+					continue
+				}
+				linkWrappedApp(callGraph, callFQN, "self."+attribute, app)
+			}
+		}
+	}
+}
+
+// middlewareAppTarget returns the function run by calling the app expr,
+// an argument of a call site of caller: the __call__ method of the class expr
+// constructs or of the instance a variable holds, or the function expr
+// names. Returns "" if expr is none of these.
+func middlewareAppTarget(expr, caller string, site core.CallSite, registry *core.ModuleRegistry, typeEngine *resolution.TypeInferenceEngine, callGraph *core.CallGraph) string {
+	module := registry.FileToModule[site.Location.File]
+	importMap := typeEngine.GetImportMap(site.Location.File)
+
+	if match := constructorExpression.FindStringSubmatch(expr); match != nil {
+		callFQN := resolveViewReference(match[1], module, importMap) + ".__call__"
+		if _, ok := callGraph.GetFunction(callFQN); ok {
+			return callFQN
+		}
+		return ""
+	}
+	if !nameExpression.MatchString(expr) {
+		return ""
+	}
+
+	binding := bindingBefore(typeEngine.GetScope(caller), expr, uint32(site.Location.Line))
+	if binding == nil {
+		binding = bindingBefore(typeEngine.GetScope(module), expr, uint32(site.Location.Line))
+	}
+	if binding != nil {
+		if binding.Type == nil || binding.Type.TypeFQN == "" {
+			return ""
+		}
+		callFQN := binding.Type.TypeFQN + ".__call__"
+		if _, ok := callGraph.GetFunction(callFQN); ok {
+			return callFQN
+		}
+		return ""
+	}
+	if fqn := resolveViewReference(expr, module, importMap); callGraph.Functions[fqn] != nil {
+		return fqn
+	}
+	return ""
+}
+
+// bindingBefore returns the last binding of name in scope assigned before
+// line, so that in app = Middleware(app) the argument is the earlier app.
+func bindingBefore(scope *resolution.FunctionScope, name string, line uint32) *resolution.VariableBinding {
+	if scope == nil {
+		return nil
+	}
+	var latest *resolution.VariableBinding
+	for _, binding := range scope.Variables[name] {
+		if binding != nil && binding.Location.Line < line && (latest == nil || binding.Location.Line >= latest.Location.Line) {
+			latest = binding
+		}
+	}
+	return latest
+}
+
+// linkWrappedApp adds app as a callee of a middleware's __call__ (callFQN),
+// and resolves its calls to the attribute holding the app (target) to app
+// unless they already resolve to a function.
+func linkWrappedApp(callGraph *core.CallGraph, callFQN, target, app string) {
+	sites := callGraph.CallSites[callFQN]
+	for i := range sites {
+		if sites[i].Target != target {
+			continue
+		}
+		callGraph.AddEdge(callFQN, app)
+		if callGraph.Functions[sites[i].TargetFQN] != nil {
+			continue
+		}
+		sites[i].Resolved = true
+		sites[i].TargetFQN = app
+		sites[i].FailureReason = ""
+		sites[i].TypeSource = "middleware_chain"
+	}
+}
@@ -0,0 +1,49 @@
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCallGraph_MiddlewareChain(t *testing.T) {
+	projectPath, err := filepath.Abs("../../../test-fixtures/python/middleware_chain")
+	require.NoError(t, err)
+
+	codeGraph := graph.Initialize(projectPath, nil)
+	callGraph, _, err := BuildCallGraphFromPath(codeGraph, projectPath, output.NewLogger(output.VerbosityDefault))
+	require.NoError(t, err)
+
+	// application = LoggingMiddleware(AuthMiddleware(Application()))
+	assert.Contains(t, callGraph.Edges["middleware.LoggingMiddleware.__call__"], "middleware.AuthMiddleware.__call__")
+	assert.Contains(t, callGraph.Edges["middleware.AuthMiddleware.__call__"], "middleware.Application.__call__")
+
+	// handler = Application(); handler = LoggingMiddleware(wrapped=handler)
+	assert.Contains(t, callGraph.Edges["middleware.LoggingMiddleware.__call__"], "middleware.Application.__call__")
+	assert.NotContains(t, callGraph.Edges["middleware.LoggingMiddleware.__call__"], "middleware.LoggingMiddleware.__call__")
+
+	// health_app = AuthMiddleware(health)
+	assert.Contains(t, callGraph.Edges["middleware.AuthMiddleware.__call__"], "wsgi.health")
+
+	// The pipeline reaches the app from the outermost middleware
+	assert.Contains(t, callGraph.GetCallers("middleware.Application.__call__"), "middleware.AuthMiddleware.__call__")
+
+	for caller, target := range map[string]string{
+		"middleware.AuthMiddleware.__call__":    "self.app",
+		"middleware.LoggingMiddleware.__call__": "self.wrapped",
+	} {
+		var found bool
+		for _, site := range callGraph.CallSites[caller] {
+			if site.Target == target {
+				found = true
+				assert.True(t, site.Resolved)
+				assert.Equal(t, "middleware_chain", site.TypeSource)
+			}
+		}
+		assert.True(t, found, "no call to %s in %s", target, caller)
+	}
+}
@@ -0,0 +1,85 @@
+package builder
+
+import "sync"
+
+// Build passes reported in ProgressEvent.Pass, in the order they run.
+const (
+	PassReturnTypes         = "return_types"
+	PassVariableAssignments = "variable_assignments"
+	PassClassAttributes     = "class_attributes"
+	PassCallSites           = "call_sites"
+	PassTaintSummaries      = "taint_summaries"
+)
+
+// ProgressEvent reports how far a build pass has got. Every pass starts
+// with an event where Processed is 0 and ends with one where Processed
+// equals Total.
+type ProgressEvent struct {
+	Pass      string // Build pass (e.g., PassCallSites)
+	Processed int    // Files done so far (functions for PassTaintSummaries)
+	Total     int    // Files the pass processes (functions for PassTaintSummaries)
+}
+
+// progressReporter sends progress events to a callback, one at a time.
+// A nil *progressReporter reports nothing and all methods are nil-safe.
+type progressReporter struct {
+	mu        sync.Mutex
+	callback  func(ProgressEvent)
+	pass      string
+	processed int
+	total     int
+}
+
+// newProgressReporter returns a reporter for callback, or nil when callback
+// is nil so that builds without one pay nothing.
+func newProgressReporter(callback func(ProgressEvent)) *progressReporter {
+	if callback == nil {
+		return nil
+	}
+	return &progressReporter{callback: callback}
+}
+
+// start begins a pass over total items.
+func (p *progressReporter) start(pass string, total int) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pass, p.processed, p.total = pass, 0, total
+	p.emit()
+}
+
+// advance records one more item of the current pass as done. Safe to call
+// from the pass's worker goroutines.
+func (p *progressReporter) advance() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.processed < p.total {
+		p.processed++
+		p.emit()
+	}
+}
+
+// finish ends the current pass, reporting it complete even if some items
+// were skipped (e.g., unreadable files).
+func (p *progressReporter) finish() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.processed < p.total {
+		p.processed = p.total
+		p.emit()
+	}
+}
+
+// emit calls the callback with the current state. Callers hold p.mu, which
+// keeps callbacks from running concurrently.
+func (p *progressReporter) emit() {
+	p.callback(ProgressEvent{Pass: p.pass, Processed: p.processed, Total: p.total})
+}
@@ -0,0 +1,85 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildWithOptions_Progress(t *testing.T) {
+	tmpDir := writeScopeFixture(t)
+
+	var events []ProgressEvent
+	callGraph, _, err := BuildWithOptions(tmpDir, BuildOptions{
+		Progress: func(event ProgressEvent) {
+			events = append(events, event)
+		},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, events)
+
+	var passes []string
+	last := map[string]ProgressEvent{}
+	for _, event := range events {
+		previous, seen := last[event.Pass]
+		if !seen {
+			passes = append(passes, event.Pass)
+			assert.Equal(t, 0, event.Processed, "%s starts at 0", event.Pass)
+		} else {
+			assert.Equal(t, previous.Total, event.Total, event.Pass)
+			assert.Greater(t, event.Processed, previous.Processed, "%s only advances", event.Pass)
+		}
+		last[event.Pass] = event
+	}
+
+	assert.Equal(t, []string{PassReturnTypes, PassVariableAssignments, PassClassAttributes, PassCallSites, PassTaintSummaries}, passes)
+	for _, pass := range []string{PassReturnTypes, PassVariableAssignments, PassClassAttributes, PassCallSites} {
+		assert.Equal(t, ProgressEvent{Pass: pass, Processed: 3, Total: 3}, last[pass])
+	}
+	assert.Equal(t, ProgressEvent{Pass: PassTaintSummaries, Processed: len(callGraph.Functions), Total: len(callGraph.Functions)}, last[PassTaintSummaries])
+}
+
+func TestBuildForFiles_ProgressCoversScope(t *testing.T) {
+	tmpDir := writeScopeFixture(t)
+
+	last := map[string]ProgressEvent{}
+	_, _, err := BuildForFiles(tmpDir, []string{"utils.py"}, BuildOptions{
+		Progress: func(event ProgressEvent) {
+			last[event.Pass] = event
+		},
+	})
+	require.NoError(t, err)
+
+	// utils.py and views.py, which imports it
+	assert.Equal(t, ProgressEvent{Pass: PassCallSites, Processed: 2, Total: 2}, last[PassCallSites])
+	assert.Equal(t, last[PassTaintSummaries].Total, last[PassTaintSummaries].Processed)
+}
+
+func TestProgressReporter_Nil(t *testing.T) {
+	var progress *progressReporter
+	assert.Nil(t, newProgressReporter(nil))
+	assert.NotPanics(t, func() {
+		progress.start(PassCallSites, 1)
+		progress.advance()
+		progress.finish()
+	})
+}
+
+func TestProgressReporter_FinishCompletesSkippedItems(t *testing.T) {
+	var events []ProgressEvent
+	progress := newProgressReporter(func(event ProgressEvent) {
+		events = append(events, event)
+	})
+
+	progress.start(PassReturnTypes, 3)
+	progress.advance()
+	progress.finish()
+	progress.finish()
+
+	assert.Equal(t, []ProgressEvent{
+		{Pass: PassReturnTypes, Processed: 0, Total: 3},
+		{Pass: PassReturnTypes, Processed: 1, Total: 3},
+		{Pass: PassReturnTypes, Processed: 3, Total: 3},
+	}, events)
+}
@@ -0,0 +1,52 @@
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/resolution"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCallGraph_ProtocolParameters(t *testing.T) {
+	projectPath, err := filepath.Abs("../../../test-fixtures/python/protocol_params")
+	require.NoError(t, err)
+
+	codeGraph := graph.Initialize(projectPath, nil)
+	logger := output.NewLogger(output.VerbosityDefault)
+	callGraph, registry, err := BuildCallGraphFromPath(codeGraph, projectPath, logger)
+	require.NoError(t, err)
+
+	engine, ok := callGraph.TypeEngine.(*resolution.TypeInferenceEngine)
+	require.True(t, ok)
+	// Square has no area(), so it does not conform
+	assert.Equal(t, []string{"shapes.Circle"}, engine.ProtocolImplementers("shapes.Drawable"))
+
+	targetOf := func(caller, target string) (string, string) {
+		for _, callSite := range callGraph.CallSites[caller] {
+			if callSite.Target == target {
+				return callSite.TargetFQN, callSite.TypeSource
+			}
+		}
+		return "", ""
+	}
+
+	draw, source := targetOf("shapes.render", "shape.draw")
+	assert.Equal(t, "shapes.Drawable.draw", draw)
+	assert.Equal(t, "protocol", source)
+	assert.Contains(t, callGraph.Edges["shapes.render"], "shapes.Drawable.draw")
+	assert.Contains(t, callGraph.Edges["shapes.render"], "shapes.Circle.draw")
+	assert.NotContains(t, callGraph.Edges["shapes.render"], "shapes.Square.draw")
+
+	// A string (forward reference) annotation names the protocol too
+	area, _ := targetOf("shapes.measure", "shape.area")
+	assert.Equal(t, "shapes.Drawable.area", area)
+	assert.Contains(t, callGraph.Edges["shapes.measure"], "shapes.Circle.area")
+
+	explanation := ExplainResolution(codeGraph, callGraph, registry, "shapes.render", "shape.draw()", logger)
+	assert.Equal(t, "shapes.Drawable.draw", explanation.TargetFQN)
+	assert.Equal(t, "protocol_method", explanation.Strategy)
+}
@@ -0,0 +1,47 @@
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCallGraph_Purity(t *testing.T) {
+	projectPath, err := filepath.Abs("../../../test-fixtures/python/purity")
+	require.NoError(t, err)
+
+	codeGraph := graph.Initialize(projectPath, nil)
+	callGraph, _, err := BuildCallGraphFromPath(codeGraph, projectPath, output.NewLogger(output.VerbosityDefault))
+	require.NoError(t, err)
+
+	tests := []struct {
+		fqn  string
+		want core.PurityClass
+	}{
+		{"app.add", core.PurityPure},
+		{"app.area", core.PurityPure},
+		{"app.factorial", core.PurityPure},
+		{"app.uses_global_read_only", core.PurityPure},
+		{"app.read_config", core.PurityImpure},
+		{"app.load_settings", core.PurityImpure},
+		{"app.remove_file", core.PurityImpure},
+		{"app.increment", core.PurityImpure},
+		{"app.reset", core.PurityImpure},
+		{"app.ping", core.PurityImpure},
+		{"app.pong", core.PurityImpure},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fqn, func(t *testing.T) {
+			assert.Equal(t, tt.want, callGraph.Purity(tt.fqn))
+		})
+	}
+
+	assert.Equal(t, []string{"counter"}, callGraph.GlobalWrites["app.increment"])
+	assert.Equal(t, []string{"counter"}, callGraph.GlobalWrites["app.reset"], "write inside an if body")
+	assert.NotContains(t, callGraph.GlobalWrites, "app.uses_global_read_only")
+}
@@ -0,0 +1,59 @@
+package builder
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/resolution"
+)
+
+// URLConfFunctions are the Django functions that map a URL pattern to a view
+// in a URLconf's urlpatterns.
+var URLConfFunctions = []string{"django.urls.path", "django.urls.re_path", "django.conf.urls.url"}
+
+// registerURLConfRoutes records the routes of Django URLconf entries, such as
+// path("users/<int:id>/", views.user_detail), on the view functions they
+// name. URLconf routes accept any method. Class-based views (as_view()) and
+// include() prefixes are not followed.
+func registerURLConfRoutes(callGraph *core.CallGraph, typeEngine *resolution.TypeInferenceEngine) {
+	for module, callSites := range callGraph.CallSites {
+		for _, callSite := range callSites {
+			if !slices.Contains(URLConfFunctions, callSite.TargetFQN) || len(callSite.Arguments) < 2 {
+				continue
+			}
+			pattern, view := callSite.Arguments[0], callSite.Arguments[1]
+			// The pattern must be a string literal and the view a plain name
+			if pattern.IsVariable || !strings.ContainsAny(pattern.Value, `"'`) ||
+				strings.ContainsAny(view.Value, "()[]=\"' ") {
+				continue
+			}
+			viewFQN := resolveViewReference(view.Value, module, typeEngine.GetImportMap(callSite.Location.File))
+			function, ok := callGraph.GetFunction(viewFQN)
+			if !ok {
+				continue
+			}
+			route := graph.Route{Method: "ANY", Path: strings.Trim(strings.TrimLeft(pattern.Value, "rRbBuU"), `"'`)}
+			if !slices.Contains(function.Routes, route) {
+				function.Routes = append(function.Routes, route)
+			}
+		}
+	}
+}
+
+// resolveViewReference resolves a view named in a URLconf ("views.index" or
+// "index") to an FQN through the URLconf module's imports, falling back to
+// the URLconf module itself.
+func resolveViewReference(view, module string, importMap *core.ImportMap) string {
+	base, rest, dotted := strings.Cut(view, ".")
+	if importMap != nil {
+		if fqn, ok := importMap.Resolve(base); ok {
+			if dotted {
+				return fqn + "." + rest
+			}
+			return fqn
+		}
+	}
+	return module + "." + view
+}
@@ -0,0 +1,40 @@
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCallGraph_Routes(t *testing.T) {
+	projectPath, err := filepath.Abs("../../../test-fixtures/python/routes")
+	require.NoError(t, err)
+
+	codeGraph := graph.Initialize(projectPath, nil)
+	callGraph, _, err := BuildCallGraphFromPath(codeGraph, projectPath, output.NewLogger(output.VerbosityDefault))
+	require.NoError(t, err)
+
+	tests := []struct {
+		function string
+		routes   []graph.Route
+	}{
+		{"flask_app.create_item", []graph.Route{{Method: "POST", Path: "/x"}}},
+		{"flask_app.health", []graph.Route{{Method: "GET", Path: "/health"}}},
+		{"fastapi_app.read_items", []graph.Route{{Method: "GET", Path: "/y"}}},
+		{"fastapi_app.items", []graph.Route{{Method: "GET", Path: "/z"}, {Method: "PUT", Path: "/z"}}},
+		{"shop.views.order_detail", []graph.Route{{Method: "ANY", Path: "orders/<int:order_id>/"}}},
+		{"shop.views.index", []graph.Route{{Method: "ANY", Path: "^$"}}},
+		{"flask_app.helper", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.function, func(t *testing.T) {
+			function, ok := callGraph.GetFunction(tt.function)
+			require.True(t, ok)
+			assert.Equal(t, tt.routes, function.Routes)
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package builder
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/registry"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+)
+
+// BuildOptions configures a call graph build (see BuildWithOptions and
+// BuildForFiles).
+type BuildOptions struct {
+	// CodeGraph is an already-parsed code graph for the project.
+	// When nil, BuildForFiles parses the project itself.
+	CodeGraph *graph.CodeGraph
+
+	// Logger receives build diagnostics. Defaults to VerbosityDefault.
+	Logger *output.Logger
+
+	// SkipTests excludes test files from the module registry.
+	SkipTests bool
+
+	// IncludeNotebooks registers the project's Jupyter notebooks as Python
+	// modules (see registry.BuildModuleRegistryWithNotebooks).
+	IncludeNotebooks bool
+
+	// ASTCache reuses parsed trees across builds. Files whose content is
+	// unchanged are not re-parsed. When nil, a per-build cache holding at
+	// most DefaultASTCacheSize trees is used and released after the build.
+	//
+	// The trade-off is memory against parse time. Every pass reads every
+	// file's tree, so a cache that keeps all trees (NewASTCache) parses
+	// each file once but holds the whole project's trees until the build
+	// ends, and for as long as the cache is kept. A bounded cache
+	// (NewBoundedASTCache) caps peak memory at that many trees; files
+	// beyond the cap are re-parsed by each pass that reads them.
+	ASTCache *ASTCache
+
+	// SourceMapper translates reported locations back to original sources
+	// (e.g., generated code). It is stored on the returned call graph,
+	// applied after the notebook cell mapping.
+	SourceMapper core.SourceMapper
+
+	// Progress, when set, is called as each build pass advances, e.g. to
+	// render a progress bar. It may be called from the build's worker
+	// goroutines, but never concurrently, and should return quickly.
+	// Passes restored from Checkpoint are not reported.
+	Progress func(ProgressEvent)
+
+	// Checkpoint, when set, saves the state left by each completed file
+	// pass (PassReturnTypes to PassCallSites). A later build of the same
+	// files, by path and content, restores it and resumes after the last
+	// pass saved, so an interrupted build does not start over.
+	Checkpoint *AnalysisCache
+}
+
+// buildScope restricts the expensive call graph passes to a subset of files.
+// A nil *buildScope means "analyze everything" and all methods are nil-safe.
+type buildScope struct {
+	// targets holds the absolute paths of the files under analysis.
+	targets map[string]bool
+
+	// files holds targets plus their one-hop import neighbours
+	// (modules they import and modules importing them).
+	files map[string]bool
+
+	// importCache carries import maps extracted while computing the scope
+	// so the build does not parse them a second time.
+	importCache *ImportMapCache
+}
+
+// includesFile reports whether passes 2-4 should process filePath.
+func (s *buildScope) includesFile(filePath string) bool {
+	return s == nil || s.files[filePath]
+}
+
+// importMapCache returns the scope's import cache, or a fresh one.
+func (s *buildScope) importMapCache() *ImportMapCache {
+	if s == nil || s.importCache == nil {
+		return NewImportMapCache()
+	}
+	return s.importCache
+}
+
+// functionFilter returns the predicate used to select functions for taint
+// summary generation: functions defined in target files, plus their direct
+// callers and callees. Returns nil (no filtering) for an unscoped build.
+func (s *buildScope) functionFilter(callGraph *core.CallGraph) func(string) bool {
+	if s == nil {
+		return nil
+	}
+
+	selected := make(map[string]bool)
+	for fqn, node := range callGraph.Functions {
+		if !s.targets[node.File] {
+			continue
+		}
+		selected[fqn] = true
+		for _, callee := range callGraph.GetCallees(fqn) {
+			selected[callee] = true
+		}
+		for _, caller := range callGraph.GetCallers(fqn) {
+			selected[caller] = true
+		}
+	}
+
+	return func(fqn string) bool {
+		return selected[fqn]
+	}
+}
+
+// BuildForFiles builds a call graph for projectPath that is only fully
+// analyzed for targetFiles. This is intended for pull request analysis,
+// where only changed files (and whatever they touch) need findings.
+//
+// The module registry and function index are still built for the whole
+// project since they are cheap. The expensive passes (return types,
+// variable assignments, class attributes, call sites) run for the target
+// files and their one-hop import neighbours, and taint summaries are only
+// generated for functions in target files plus their direct callers and
+// callees. Results for the target files are comparable to a full build.
+//
+// Parameters:
+//   - projectPath: path to project root
+//   - targetFiles: files to analyze, absolute or relative to projectPath
+//   - opts: optional pre-parsed code graph and logger
+//
+// Returns:
+//   - CallGraph: call graph scoped to the target files
+//   - ModuleRegistry: module path mappings for the whole project
+//   - error: if the registry cannot be built
+func BuildForFiles(projectPath string, targetFiles []string, opts BuildOptions) (*core.CallGraph, *core.ModuleRegistry, error) {
+	return buildWithOptions(projectPath, targetFiles, true, opts)
+}
+
+// BuildWithOptions builds the call graph of a whole project, like
+// BuildCallGraphFromPath, with the registry, parsing, and reporting
+// configured by opts.
+//
+// Parameters:
+//   - projectPath: path to project root
+//   - opts: optional pre-parsed code graph, logger, and progress callback
+//
+// Returns:
+//   - CallGraph: complete call graph with edges and call sites
+//   - ModuleRegistry: module path mappings
+//   - error: if the registry cannot be built
+func BuildWithOptions(projectPath string, opts BuildOptions) (*core.CallGraph, *core.ModuleRegistry, error) {
+	return buildWithOptions(projectPath, nil, false, opts)
+}
+
+// buildWithOptions implements BuildForFiles and, when scoped is false,
+// BuildWithOptions.
+func buildWithOptions(projectPath string, targetFiles []string, scoped bool, opts BuildOptions) (*core.CallGraph, *core.ModuleRegistry, error) {
+	logger := opts.Logger
+	if logger == nil {
+		logger = output.NewLogger(output.VerbosityDefault)
+	}
+
+	buildRegistry := registry.BuildModuleRegistry
+	if opts.IncludeNotebooks {
+		buildRegistry = registry.BuildModuleRegistryWithNotebooks
+	}
+	moduleRegistry, err := buildRegistry(projectPath, opts.SkipTests)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	codeGraph := opts.CodeGraph
+	if codeGraph == nil {
+		codeGraph = graph.Initialize(projectPath, nil)
+	}
+
+	astCache := opts.ASTCache
+	if astCache == nil {
+		astCache = NewBoundedASTCache(DefaultASTCacheSize)
+		defer astCache.Close()
+	}
+
+	var scope *buildScope
+	if scoped {
+		scope, err = newBuildScope(projectPath, targetFiles, moduleRegistry, astCache)
+		if err != nil {
+			return nil, nil, err
+		}
+		logger.Debug("Changed-files mode: %d target files, %d files in scope", len(scope.targets), len(scope.files))
+	}
+
+	callGraph, err := buildCallGraph(codeGraph, moduleRegistry, projectPath, logger, scope, astCache, defaultStrategies, newProgressReporter(opts.Progress), newBuildCheckpoint(opts.Checkpoint, moduleRegistry, scope, logger))
+	if err != nil {
+		return nil, nil, err
+	}
+	callGraph.SourceMapper = core.ChainSourceMappers(callGraph.SourceMapper, opts.SourceMapper)
+
+	return callGraph, moduleRegistry, nil
+}
+
+// newBuildScope resolves targetFiles against the module registry and expands
+// them with one hop of import dependencies and dependents.
+func newBuildScope(projectPath string, targetFiles []string, moduleRegistry *core.ModuleRegistry, astCache *ASTCache) (*buildScope, error) {
+	absRoot, err := filepath.Abs(projectPath)
+	if err != nil {
+		return nil, fmt.Errorf("resolving project path: %w", err)
+	}
+
+	scope := &buildScope{
+		targets:     make(map[string]bool),
+		files:       make(map[string]bool),
+		importCache: NewImportMapCache(),
+	}
+
+	targetModules := make(map[string]bool)
+	for _, file := range targetFiles {
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(absRoot, file)
+		}
+		file = filepath.Clean(file)
+		modulePath, ok := moduleRegistry.FileToModule[file]
+		if !ok {
+			continue
+		}
+		scope.targets[file] = true
+		scope.files[file] = true
+		targetModules[modulePath] = true
+	}
+
+	for filePath := range moduleRegistry.FileToModule {
+		sourceCode, err := astCache.ReadSource(filePath)
+		if err != nil {
+			continue
+		}
+		tree, err := astCache.GetOrParse(filePath, sourceCode)
+		if err != nil {
+			continue
+		}
+		importMap := scope.importCache.GetOrExtractFromAST(filePath, sourceCode, tree.RootNode(), moduleRegistry)
+
+		for _, fqn := range importMap.Imports {
+			importedModule, ok := findImportedModule(fqn, moduleRegistry)
+			if !ok {
+				continue
+			}
+			// Dependency: a target file imports this module.
+			if scope.targets[filePath] {
+				scope.files[moduleRegistry.Modules[importedModule]] = true
+			}
+			// Dependent: this file imports a target module.
+			if targetModules[importedModule] {
+				scope.files[filePath] = true
+			}
+		}
+	}
+
+	return scope, nil
+}
+
+// findImportedModule finds the project module an imported FQN refers to by
+// stripping trailing components until a registered module is found.
+// For example, "myapp.utils.sanitize" resolves to module "myapp.utils".
+func findImportedModule(fqn string, moduleRegistry *core.ModuleRegistry) (string, bool) {
+	for candidate := fqn; candidate != ""; {
+		if _, ok := moduleRegistry.Modules[candidate]; ok {
+			return candidate, true
+		}
+		idx := strings.LastIndex(candidate, ".")
+		if idx < 0 {
+			break
+		}
+		candidate = candidate[:idx]
+	}
+	return "", false
+}
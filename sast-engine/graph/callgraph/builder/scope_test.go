@@ -0,0 +1,142 @@
+package builder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/patterns"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/registry"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeScopeFixture(t *testing.T) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+
+	files := map[string]string{
+		"views.py": `
+from utils import run_code
+
+def handle():
+    data = input()
+    run_code(data)
+
+def local_eval():
+    cmd = input()
+    eval(cmd)
+`,
+		"utils.py": `
+def run_code(code):
+    eval(code)
+`,
+		"unrelated.py": `
+def helper():
+    return len("abc")
+
+def other():
+    helper()
+`,
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644))
+	}
+	return tmpDir
+}
+
+func TestBuildForFiles_MatchesFullBuildForTargets(t *testing.T) {
+	tmpDir := writeScopeFixture(t)
+	logger := output.NewLogger(output.VerbosityDefault)
+
+	codeGraph := graph.Initialize(tmpDir, nil)
+	moduleRegistry, err := registry.BuildModuleRegistry(tmpDir, false)
+	require.NoError(t, err)
+	fullGraph, err := BuildCallGraph(codeGraph, moduleRegistry, tmpDir, logger)
+	require.NoError(t, err)
+
+	scopedGraph, _, err := BuildForFiles(tmpDir, []string{"views.py"}, BuildOptions{Logger: logger})
+	require.NoError(t, err)
+
+	for _, fqn := range []string{"views.handle", "views.local_eval"} {
+		assert.ElementsMatch(t, fullGraph.Edges[fqn], scopedGraph.Edges[fqn], "edges for %s", fqn)
+		assert.Len(t, scopedGraph.CallSites[fqn], len(fullGraph.CallSites[fqn]), "call sites for %s", fqn)
+		assert.Contains(t, scopedGraph.Summaries, fqn)
+	}
+
+	// Direct callee in a dependency keeps its summary.
+	assert.Contains(t, scopedGraph.Summaries, "utils.run_code")
+
+	// Files outside the scope are not analyzed.
+	assert.Empty(t, scopedGraph.CallSites["unrelated.other"])
+	assert.NotContains(t, scopedGraph.Summaries, "unrelated.other")
+
+	// Pattern findings match the full build.
+	patternRegistry := patterns.NewPatternRegistry()
+	patternRegistry.LoadDefaultPatterns()
+	pattern, ok := patternRegistry.GetPattern("CODE-INJECTION-001")
+	require.True(t, ok)
+
+	fullMatch := patternRegistry.MatchPattern(pattern, fullGraph)
+	scopedMatch := patternRegistry.MatchPattern(pattern, scopedGraph)
+	require.True(t, fullMatch.Matched)
+	assert.Equal(t, fullMatch.Matched, scopedMatch.Matched)
+	assert.Equal(t, fullMatch.SourceFQN, scopedMatch.SourceFQN)
+	assert.Equal(t, fullMatch.SinkFQN, scopedMatch.SinkFQN)
+}
+
+func TestBuildForFiles_IncludesDependents(t *testing.T) {
+	tmpDir := writeScopeFixture(t)
+
+	scopedGraph, _, err := BuildForFiles(tmpDir, []string{filepath.Join(tmpDir, "utils.py")}, BuildOptions{})
+	require.NoError(t, err)
+
+	// views.py imports utils, so its call sites are resolved too.
+	assert.Contains(t, scopedGraph.GetCallers("utils.run_code"), "views.handle")
+	assert.Contains(t, scopedGraph.Summaries, "views.handle")
+	assert.Empty(t, scopedGraph.CallSites["unrelated.other"])
+}
+
+func TestBuildForFiles_UnknownTargets(t *testing.T) {
+	tmpDir := writeScopeFixture(t)
+
+	scopedGraph, moduleRegistry, err := BuildForFiles(tmpDir, []string{"missing.py", "README.md"}, BuildOptions{})
+	require.NoError(t, err)
+
+	// Registry and function index still cover the whole project.
+	assert.Len(t, moduleRegistry.Modules, 3)
+	assert.Contains(t, scopedGraph.Functions, "unrelated.helper")
+	assert.Empty(t, scopedGraph.CallSites)
+	assert.Empty(t, scopedGraph.Summaries)
+}
+
+func TestBuildForFiles_InvalidProject(t *testing.T) {
+	_, _, err := BuildForFiles("/nonexistent/project/path", []string{"a.py"}, BuildOptions{})
+	assert.Error(t, err)
+}
+
+func TestBuildScope_NilIsUnscoped(t *testing.T) {
+	var scope *buildScope
+	assert.True(t, scope.includesFile("/any/file.py"))
+	assert.Nil(t, scope.functionFilter(core.NewCallGraph()))
+	assert.NotNil(t, scope.importMapCache())
+}
+
+func TestFindImportedModule(t *testing.T) {
+	moduleRegistry := core.NewModuleRegistry()
+	moduleRegistry.AddModule("myapp.utils", "/project/myapp/utils.py")
+
+	module, ok := findImportedModule("myapp.utils.sanitize", moduleRegistry)
+	assert.True(t, ok)
+	assert.Equal(t, "myapp.utils", module)
+
+	module, ok = findImportedModule("myapp.utils", moduleRegistry)
+	assert.True(t, ok)
+	assert.Equal(t, "myapp.utils", module)
+
+	_, ok = findImportedModule("os.path.join", moduleRegistry)
+	assert.False(t, ok)
+}
@@ -0,0 +1,35 @@
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCallGraph_FindBySignature(t *testing.T) {
+	projectPath, err := filepath.Abs("../../../test-fixtures/python/typed_handlers")
+	require.NoError(t, err)
+
+	codeGraph := graph.Initialize(projectPath, nil)
+	callGraph, _, err := BuildCallGraphFromPath(codeGraph, projectPath, output.NewLogger(output.VerbosityDefault))
+	require.NoError(t, err)
+
+	names := func(nodes []*graph.Node) []string {
+		result := make([]string, 0, len(nodes))
+		for _, node := range nodes {
+			result = append(result, node.Name)
+		}
+		return result
+	}
+
+	// views.ReportView.get sorts first; the unannotated health never matches
+	assert.Equal(t, []string{"get", "profile", "search"}, names(callGraph.FindBySignature("HttpRequest", "")))
+	assert.Equal(t, []string{"get", "health", "profile"}, names(callGraph.FindBySignature("", "HttpResponse")))
+	assert.Equal(t, []string{"get", "health", "profile", "search"}, names(callGraph.FindBySignature("", "*Response")))
+	assert.Equal(t, []string{"search"}, names(callGraph.FindBySignature("*Request", "Json*")))
+	assert.Equal(t, []string{"parse_limit"}, names(callGraph.FindBySignature("int", "int")))
+}
@@ -0,0 +1,52 @@
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCallGraph_SingleDispatch(t *testing.T) {
+	projectPath, err := filepath.Abs("../../../test-fixtures/python/singledispatch")
+	require.NoError(t, err)
+
+	codeGraph := graph.Initialize(projectPath, nil)
+	callGraph, _, err := BuildCallGraphFromPath(codeGraph, projectPath, output.NewLogger(output.VerbosityDefault))
+	require.NoError(t, err)
+
+	renders := make(map[string]core.CallSite) // first argument -> call site
+	for _, cs := range callGraph.CallSites["report.summary"] {
+		if cs.Target == "render" {
+			renders[cs.Arguments[0].Value] = cs
+		}
+	}
+	require.Len(t, renders, 4)
+
+	tests := []struct {
+		argument       string
+		implementation string
+		dispatchType   string
+	}{
+		{"total", "formatting._render_int", "builtins.int"},
+		{"[1, 2]", "formatting._render_list", "builtins.list"},
+		// Registered from another module importing render
+		{"price", "currency._render_money", "currency.Money"},
+		// Unknown type falls back to the generic function
+		{"name", "formatting.render", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.argument, func(t *testing.T) {
+			cs := renders[tt.argument]
+			assert.True(t, cs.Resolved)
+			assert.Equal(t, tt.implementation, cs.TargetFQN)
+			assert.Equal(t, "formatting.render", cs.DispatchFunction)
+			assert.Equal(t, tt.dispatchType, cs.DispatchType)
+			assert.Contains(t, callGraph.Edges["report.summary"], tt.implementation)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCallGraph_StdlibSubmoduleAttributes(t *testing.T) {
+	projectPath, err := filepath.Abs("../../../test-fixtures/python/stdlib_submodules")
+	require.NoError(t, err)
+
+	codeGraph := graph.Initialize(projectPath, nil)
+	callGraph, _, err := BuildCallGraphFromPath(codeGraph, projectPath, output.NewLogger(output.VerbosityDefault))
+	require.NoError(t, err)
+
+	callSite := func(caller string) core.CallSite {
+		t.Helper()
+		require.Len(t, callGraph.CallSites[caller], 1, caller)
+		return callGraph.CallSites[caller][0]
+	}
+
+	// os.path is an attribute of the imported os module, not a class
+	joinCall := callSite("app.build_path")
+	assert.True(t, joinCall.Resolved)
+	assert.Equal(t, "os.path.join", joinCall.TargetFQN)
+	assert.Contains(t, callGraph.Edges["app.build_path"], "os.path.join")
+
+	existsCall := callSite("app.exists")
+	assert.True(t, existsCall.Resolved)
+	assert.Equal(t, "os.path.exists", existsCall.TargetFQN)
+
+	quoteCall := callSite("app.quote")
+	assert.True(t, quoteCall.Resolved)
+	assert.Equal(t, "urllib.parse.quote", quoteCall.TargetFQN)
+
+	// os.missing is not a stdlib submodule
+	assert.False(t, callSite("app.unknown").Resolved)
+}
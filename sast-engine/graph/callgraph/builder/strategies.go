@@ -0,0 +1,810 @@
+package builder
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	cgregistry "github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/registry"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/resolution"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+)
+
+// ResolutionContext is a call site to resolve and what is known about its
+// surroundings: the imports of its file, the module and function containing
+// it, and the registries and graphs built so far.
+type ResolutionContext struct {
+	Target        string // Call target as written (e.g., "utils.sanitize", "self.save")
+	CallerFQN     string // Function containing the call
+	CurrentModule string // Module containing the call
+	ImportMap     *core.ImportMap
+	Registry      *core.ModuleRegistry
+	CodeGraph     *graph.CodeGraph
+	CallGraph     *core.CallGraph
+	TypeEngine    *resolution.TypeInferenceEngine
+	Logger        *output.Logger
+}
+
+// ResolutionStrategy resolves call targets of some shape. Resolve returns
+// false to pass the target on to the next strategy, or a call site whose
+// TargetFQN and Resolved fields decide it, along with the type inference
+// fields when the target was resolved through a variable's type.
+type ResolutionStrategy interface {
+	Resolve(ctx ResolutionContext) (*core.CallSite, bool)
+}
+
+// ResolutionStrategyFunc adapts a function to ResolutionStrategy.
+type ResolutionStrategyFunc func(ctx ResolutionContext) (*core.CallSite, bool)
+
+// Resolve calls f(ctx).
+func (f ResolutionStrategyFunc) Resolve(ctx ResolutionContext) (*core.CallSite, bool) {
+	return f(ctx)
+}
+
+// Priorities of the built-in strategies. A custom strategy registered with
+// a higher priority than one of them runs before it.
+const (
+	PriorityMethodChain       = 100
+	PrioritySelfAttribute     = 90
+	PrioritySuperCall         = 80
+	PriorityClsInstantiation  = 70
+	PriorityAbstractMethod    = 65
+	PrioritySelfMethod        = 60
+	PriorityDispatchTable     = 55
+	PrioritySimpleName        = 50
+	PriorityProtocolMethod    = 45
+	PriorityTypeInference     = 40
+	PriorityImportedAttribute = 30
+	PriorityModuleAttribute   = 20
+)
+
+// builtinStrategy is a built-in resolution step. It returns the resolved FQN,
+// whether resolution succeeded, the type it went through, and whether the
+// step decided the target at all.
+type builtinStrategy func(ctx ResolutionContext) (string, bool, *core.TypeInfo, bool)
+
+// Resolve implements ResolutionStrategy.
+func (s builtinStrategy) Resolve(ctx ResolutionContext) (*core.CallSite, bool) {
+	fqn, resolved, typeInfo, ok := s(ctx)
+	if !ok {
+		return nil, false
+	}
+	callSite := &core.CallSite{Target: ctx.Target, TargetFQN: fqn, Resolved: resolved}
+	if typeInfo != nil {
+		callSite.ResolvedViaTypeInference = true
+		callSite.InferredType = typeInfo.TypeFQN
+		callSite.TypeConfidence = typeInfo.Confidence
+		callSite.TypeSource = typeInfo.Source
+	}
+	return callSite, true
+}
+
+// prioritizedStrategy is a strategy registered with a priority.
+type prioritizedStrategy struct {
+	strategy ResolutionStrategy
+	priority int
+}
+
+// builtinStrategies are the strategies resolveCallTarget tries, in order.
+var builtinStrategies = []prioritizedStrategy{
+	{builtinStrategy(resolveMethodChain), PriorityMethodChain},
+	{builtinStrategy(resolveSelfAttribute), PrioritySelfAttribute},
+	{builtinStrategy(resolveSuperCall), PrioritySuperCall},
+	{builtinStrategy(resolveClsInstantiation), PriorityClsInstantiation},
+	{builtinStrategy(resolveAbstractMethod), PriorityAbstractMethod},
+	{builtinStrategy(resolveSelfMethod), PrioritySelfMethod},
+	{builtinStrategy(resolveDispatchTable), PriorityDispatchTable},
+	{builtinStrategy(resolveSimpleName), PrioritySimpleName},
+	{builtinStrategy(resolveProtocolMethod), PriorityProtocolMethod},
+	{builtinStrategy(resolveTypeInference), PriorityTypeInference},
+	{builtinStrategy(resolveImportedAttribute), PriorityImportedAttribute},
+	{builtinStrategy(resolveModuleAttribute), PriorityModuleAttribute},
+}
+
+// defaultStrategies are builtinStrategies without their priorities.
+var defaultStrategies = orderedStrategies(builtinStrategies)
+
+// orderedStrategies returns the strategies of registered, highest priority
+// first. Strategies with equal priority keep their registration order.
+func orderedStrategies(registered []prioritizedStrategy) []ResolutionStrategy {
+	sorted := slices.Clone(registered)
+	slices.SortStableFunc(sorted, func(a, b prioritizedStrategy) int {
+		return b.priority - a.priority
+	})
+	strategies := make([]ResolutionStrategy, len(sorted))
+	for i, registeredStrategy := range sorted {
+		strategies[i] = registeredStrategy.strategy
+	}
+	return strategies
+}
+
+// Builder builds call graphs with custom resolution strategies alongside the
+// built-in ones, e.g. for RPC stubs or dependency injection containers the
+// built-in strategies cannot see through:
+//
+//	b := builder.NewBuilder()
+//	b.RegisterStrategy(rpcStrategy, builder.PrioritySimpleName+1)
+//	callGraph, err := b.BuildCallGraph(codeGraph, moduleRegistry, projectRoot, logger)
+type Builder struct {
+	strategies []prioritizedStrategy
+}
+
+// NewBuilder returns a Builder with the built-in resolution strategies.
+func NewBuilder() *Builder {
+	return &Builder{strategies: slices.Clone(builtinStrategies)}
+}
+
+// RegisterStrategy adds a resolution strategy. Strategies run from the
+// highest priority down until one decides the target; a strategy registered
+// with the same priority as an earlier one runs after it.
+func (b *Builder) RegisterStrategy(s ResolutionStrategy, priority int) {
+	b.strategies = append(b.strategies, prioritizedStrategy{strategy: s, priority: priority})
+}
+
+// BuildCallGraph is BuildCallGraph resolving calls with b's strategies.
+func (b *Builder) BuildCallGraph(codeGraph *graph.CodeGraph, registry *core.ModuleRegistry, projectRoot string, logger *output.Logger) (*core.CallGraph, error) {
+	return buildCallGraph(codeGraph, registry, projectRoot, logger, nil, nil, orderedStrategies(b.strategies), nil, nil)
+}
+
+// resolveWithStrategies resolves ctx.Target with the first of strategies
+// deciding it. Without a type engine or caller, only the import-based legacy
+// resolution runs.
+func resolveWithStrategies(strategies []ResolutionStrategy, ctx ResolutionContext) (string, bool, *core.TypeInfo) {
+	// Backward compatibility: if typeEngine or callerFQN not provided, skip type inference
+	if ctx.TypeEngine == nil || ctx.CallerFQN == "" {
+		fqn, resolved := resolveCallTargetLegacy(ctx.Target, ctx.ImportMap, ctx.Registry, ctx.CurrentModule, ctx.CodeGraph)
+		return fqn, resolved, nil
+	}
+
+	for _, strategy := range strategies {
+		callSite, ok := strategy.Resolve(ctx)
+		if !ok || callSite == nil {
+			continue
+		}
+		var typeInfo *core.TypeInfo
+		if callSite.ResolvedViaTypeInference {
+			typeInfo = &core.TypeInfo{
+				TypeFQN:    callSite.InferredType,
+				Confidence: callSite.TypeConfidence,
+				Source:     callSite.TypeSource,
+			}
+		}
+		return callSite.TargetFQN, callSite.Resolved, typeInfo
+	}
+
+	// Can't resolve - return as-is
+	return ctx.Target, false, nil
+}
+
+// resolveMethodChain resolves a.b().c() chains through the return types of
+// each call (ResolveChainedCall).
+func resolveMethodChain(ctx ResolutionContext) (string, bool, *core.TypeInfo, bool) {
+	// Phase 3 Task 11: Check for method chaining BEFORE other resolution
+	// Chains have pattern "()." indicating call followed by attribute access
+	if strings.Contains(ctx.Target, ").") {
+		chainFQN, chainResolved, chainType := resolution.ResolveChainedCall(
+			ctx.Target,
+			ctx.TypeEngine,
+			ctx.TypeEngine.Builtins,
+			ctx.Registry,
+			ctx.CodeGraph,
+			ctx.CallerFQN,
+			ctx.CurrentModule,
+			ctx.CallGraph,
+		)
+		if chainResolved {
+			return chainFQN, true, chainType, true
+		}
+		// Chain parsing attempted but failed - fall through to regular resolution
+	}
+	return "", false, nil, false
+}
+
+// resolveSelfAttribute resolves self.attr.method() through the type of the
+// attribute, including attributes inherited from third-party parent classes.
+func resolveSelfAttribute(ctx ResolutionContext) (string, bool, *core.TypeInfo, bool) {
+	// Phase 3 Task 12: Check for self.attribute.method() patterns BEFORE self.method()
+	// Pattern: self.attr.method (2+ dots starting with self.)
+	if strings.HasPrefix(ctx.Target, "self.") && strings.Count(ctx.Target, ".") >= 2 {
+		attrFQN, attrResolved, attrType := resolution.ResolveSelfAttributeCall(
+			ctx.Target,
+			ctx.CallerFQN,
+			ctx.TypeEngine,
+			ctx.TypeEngine.Builtins,
+			ctx.CallGraph,
+		)
+		if attrResolved {
+			return attrFQN, true, attrType, true
+		}
+
+		// PR #7: Fallback — check parent class attributes via third-party registry
+		// For self.attr.method where attr isn't in child class, try parent classes
+		if ctx.TypeEngine != nil && ctx.TypeEngine.ThirdPartyRemote != nil && ctx.CodeGraph != nil {
+			attrParts := strings.Split(ctx.Target, ".")
+			if len(attrParts) >= 3 {
+				attrName := attrParts[1]
+				methodOnAttr := attrParts[len(attrParts)-1]
+				callerParts := strings.Split(ctx.CallerFQN, ".")
+				if len(callerParts) >= 3 {
+					callerClassName := callerParts[len(callerParts)-2]
+					callerClassFQN := ctx.CurrentModule + "." + callerClassName
+					fqn, resolved, typeInfo := resolveInheritedSelfAttrMethod(
+						callerClassFQN, attrName, methodOnAttr,
+						ctx.CodeGraph, ctx.Registry, ctx.TypeEngine, ctx.Logger,
+					)
+					if resolved {
+						return fqn, true, typeInfo, true
+					}
+				}
+			}
+		}
+		// Attribute resolution attempted but failed - fall through
+	}
+	return "", false, nil, false
+}
+
+// resolveSuperCall resolves super().method() to the method of a parent class.
+func resolveSuperCall(ctx ResolutionContext) (string, bool, *core.TypeInfo, bool) {
+	// Phase 3: Handle super().method() calls - resolve to parent class method
+	if after, ok := strings.CutPrefix(ctx.Target, "super()."); ok {
+		methodName := after
+
+		// Extract current class name from callerFQN
+		// callerFQN format: "module.ClassName.methodName"
+		parts := strings.Split(ctx.CallerFQN, ".")
+
+		if len(parts) >= 3 {
+			// Current class info
+			className := parts[len(parts)-2]
+			currentClassFQN := ctx.CurrentModule + "." + className
+
+			// PR #7: Find the class node and resolve parent via imports + third-party
+			if ctx.CodeGraph != nil {
+				for _, node := range ctx.CodeGraph.Nodes {
+					if node.Type != "class_definition" && node.Type != "dataclass" {
+						continue
+					}
+					if node.File == "" || len(node.Interface) == 0 {
+						continue
+					}
+					modulePath, ok := ctx.Registry.FileToModule[node.File]
+					if !ok {
+						continue
+					}
+					nodeFQN := modulePath + "." + node.Name
+					if nodeFQN != currentClassFQN {
+						continue
+					}
+
+					// Found the class — resolve each parent and check for the method
+					for _, superClassName := range node.Interface {
+						parentFQN := resolution.ResolveParentClassFQN(
+							currentClassFQN, superClassName, node.File,
+							ctx.TypeEngine, ctx.Registry,
+						)
+						if parentFQN == "" {
+							continue
+						}
+
+						// Check userland first
+						parentMethodFQN := parentFQN + "." + methodName
+						if ctx.CallGraph != nil && ctx.CallGraph.Functions[parentMethodFQN] != nil {
+							return parentMethodFQN, true, nil, true
+						}
+
+						// Check third-party registry
+						if ctx.TypeEngine != nil && ctx.TypeEngine.ThirdPartyRemote != nil {
+							if loader, ok := ctx.TypeEngine.ThirdPartyRemote.(*cgregistry.ThirdPartyRegistryRemote); ok {
+								tpModule, tpClass := splitModuleAndName(parentFQN)
+								if tpClass != "" && loader.HasModule(tpModule) {
+									method := findThirdPartyClassMethod(loader, tpModule, tpClass, methodName, ctx.Logger)
+									if method != nil {
+										return parentFQN + "." + methodName, true, nil, true
+									}
+								}
+							}
+						}
+					}
+					break
+				}
+			}
+
+			// Heuristic fallback: Try common parent class names (Base suffix)
+			if ctx.CallGraph != nil {
+				parentMethodFQN := ctx.CurrentModule + "." + className + "Base." + methodName
+				if ctx.CallGraph.Functions[parentMethodFQN] != nil {
+					return parentMethodFQN, true, nil, true
+				}
+			}
+
+			// Try module-level function as last resort
+			moduleFQN := ctx.CurrentModule + "." + methodName
+			if ctx.CallGraph != nil && ctx.CallGraph.Functions[moduleFQN] != nil {
+				return moduleFQN, true, nil, true
+			}
+
+			// Return unresolved with descriptive FQN
+			return ctx.CurrentModule + ".super()." + methodName, false, nil, true
+		}
+
+		// Can't extract class from callerFQN
+		return "super()." + methodName, false, nil, true
+	}
+	return "", false, nil, false
+}
+
+// resolveClsInstantiation resolves cls(...) inside a classmethod to the
+// enclosing class.
+func resolveClsInstantiation(ctx ResolutionContext) (string, bool, *core.TypeInfo, bool) {
+	// Classmethods instantiate their class through cls(...)
+	if ctx.Target == "cls" {
+		parts := strings.Split(ctx.CallerFQN, ".")
+		if len(parts) >= 3 {
+			classFQN := ctx.CurrentModule + "." + parts[len(parts)-2]
+			// The enclosing scope must be a class, not an outer function.
+			if ctx.CallGraph != nil && ctx.CallGraph.Functions[classFQN] == nil {
+				return classFQN, true, nil, true
+			}
+		}
+	}
+	return "", false, nil, false
+}
+
+// resolveAbstractMethod resolves x.method() where x is typed as an ABC, and
+// self.method() in a method of the ABC, when the method is an
+// @abstractmethod. The call resolves to the abstract method; the worker adds
+// edges to its concrete overrides and flags the call as virtual dispatch.
+func resolveAbstractMethod(ctx ResolutionContext) (string, bool, *core.TypeInfo, bool) {
+	base, method, dotted := strings.Cut(ctx.Target, ".")
+	if !dotted || strings.Contains(method, ".") || ctx.TypeEngine == nil {
+		return "", false, nil, false
+	}
+
+	var candidates []string
+	confidence := float32(1.0)
+	if base == "self" || base == "cls" {
+		if dot := strings.LastIndex(ctx.CallerFQN, "."); dot > 0 {
+			candidates = append(candidates, ctx.CallerFQN[:dot])
+		}
+	} else if binding := variableBinding(ctx, base); binding != nil && binding.Type != nil {
+		// Annotations name local classes bare (def f(shape: Shape))
+		classFQN := strings.Trim(binding.Type.TypeFQN, `"'`)
+		candidates = append(candidates, classFQN, ctx.CurrentModule+"."+classFQN)
+		confidence = binding.Type.Confidence
+	}
+	for _, classFQN := range candidates {
+		methodFQN := classFQN + "." + method
+		if _, ok := ctx.TypeEngine.AbstractOverrides(methodFQN); ok {
+			return methodFQN, true, &core.TypeInfo{
+				TypeFQN:    classFQN,
+				Confidence: confidence,
+				Source:     "abstract_method",
+			}, true
+		}
+	}
+	return "", false, nil, false
+}
+
+// resolveSelfMethod resolves self.method() and cls.method() to a method of
+// the enclosing class, or of the module.
+func resolveSelfMethod(ctx ResolutionContext) (string, bool, *core.TypeInfo, bool) {
+	// Phase 2: Handle self.method() calls - resolve to current class method.
+	// cls.method() inside a classmethod resolves the same way.
+	methodName, isSelfCall := strings.CutPrefix(ctx.Target, "self.")
+	if !isSelfCall {
+		if clsMethod, ok := strings.CutPrefix(ctx.Target, "cls."); ok && !strings.Contains(clsMethod, ".") {
+			methodName, isSelfCall = clsMethod, true
+		}
+	}
+	if isSelfCall {
+
+		// Phase 2: Extract class name from callerFQN for class-qualified lookup
+		// callerFQN format: "module.ClassName.methodName" for methods
+		//                   "module.functionName" for module-level functions
+		parts := strings.Split(ctx.CallerFQN, ".")
+
+		// If callerFQN has 3+ parts, it's a class method
+		// parts = ["module", "ClassName", "methodName"] or more for nested modules
+		if len(parts) >= 3 {
+			// Extract class name (second-to-last part)
+			// For "module.ClassName.methodName" → className = "ClassName"
+			// For "app.models.User.save" → className = "User"
+			className := parts[len(parts)-2]
+
+			// Build class-qualified FQN: module.ClassName.methodName
+			classQualifiedFQN := ctx.CurrentModule + "." + className + "." + methodName
+
+			// Try class-qualified lookup first
+			if validateFQN(classQualifiedFQN, ctx.Registry) {
+				return classQualifiedFQN, true, nil, true
+			}
+
+			// Check if target exists in Functions map (more reliable than validateFQN)
+			if ctx.CallGraph != nil && ctx.CallGraph.Functions[classQualifiedFQN] != nil {
+				return classQualifiedFQN, true, nil, true
+			}
+		}
+
+		// Fall back to module-level method (backward compatibility)
+		// This handles cases where method might be at module level or
+		// when class extraction fails
+		moduleFQN := ctx.CurrentModule + "." + methodName
+		if validateFQN(moduleFQN, ctx.Registry) {
+			return moduleFQN, true, nil, true
+		}
+
+		// Check Functions map for module-level
+		if ctx.CallGraph != nil && ctx.CallGraph.Functions[moduleFQN] != nil {
+			return moduleFQN, true, nil, true
+		}
+
+		// Return unresolved but with module prefix
+		return moduleFQN, false, nil, true
+	}
+	return "", false, nil, false
+}
+
+// resolveDispatchTable resolves a call through an indexed container of
+// functions (handlers[key]() with handlers = {"a": handle_a, ...}) to the
+// first function the container holds. The key is not evaluated, so the
+// worker links every function in it (CallSite.DispatchTargets).
+func resolveDispatchTable(ctx ResolutionContext) (string, bool, *core.TypeInfo, bool) {
+	targets, binding := dispatchTableTargets(ctx)
+	if len(targets) == 0 {
+		return "", false, nil, false
+	}
+	return targets[0], true, &core.TypeInfo{
+		TypeFQN:    binding.Type.TypeFQN,
+		Confidence: binding.Type.Confidence,
+		Source:     "dispatch_table",
+	}, true
+}
+
+// dispatchTableTargets returns the FQNs of the project functions held by
+// the container a call target indexes (handlers in handlers[key]), and the
+// container's binding. Names that are not functions, such as data or
+// classes, are skipped.
+func dispatchTableTargets(ctx ResolutionContext) ([]string, *resolution.VariableBinding) {
+	name, key, indexed := strings.Cut(ctx.Target, "[")
+	if !indexed || !strings.HasSuffix(key, "]") || strings.Contains(name, ".") || ctx.TypeEngine == nil || ctx.CallGraph == nil {
+		return nil, nil
+	}
+	binding := variableBinding(ctx, name)
+	if binding == nil || binding.Type == nil || len(binding.Callables) == 0 {
+		return nil, nil
+	}
+
+	var targets []string
+	for _, reference := range binding.Callables {
+		fqn := ctx.CurrentModule + "." + reference
+		root, rest, dotted := strings.Cut(reference, ".")
+		if ctx.ImportMap != nil {
+			if imported, ok := ctx.ImportMap.Resolve(root); ok {
+				fqn = imported
+				if dotted {
+					fqn += "." + rest
+				}
+			}
+		}
+		if _, ok := ctx.CallGraph.Functions[fqn]; ok && !slices.Contains(targets, fqn) {
+			targets = append(targets, fqn)
+		}
+	}
+	return targets, binding
+}
+
+// resolveSimpleName resolves undotted names: builtins, callable instances,
+// imports, and functions of the current module.
+func resolveSimpleName(ctx ResolutionContext) (string, bool, *core.TypeInfo, bool) {
+	// Handle simple names (no dots)
+	if !strings.Contains(ctx.Target, ".") {
+		// Check if it's a Python built-in
+		if pythonBuiltins[ctx.Target] {
+			// Return as builtins.function for pattern matching
+			return "builtins." + ctx.Target, true, nil, true
+		}
+
+		// Calling an instance of a class that defines __call__
+		if fqn, typeInfo, ok := resolveCallableInstance(ctx.Target, ctx.CallerFQN, ctx.CurrentModule, ctx.TypeEngine, ctx.CallGraph); ok {
+			return fqn, true, typeInfo, true
+		}
+
+		// Try to resolve through imports
+		if fqn, ok := ctx.ImportMap.Resolve(ctx.Target); ok {
+			// Validate if it exists in registry
+			if validateFQN(fqn, ctx.Registry) {
+				return fqn, true, nil, true
+			}
+			// Check stdlib for imported names (e.g., from os import getcwd)
+			if ctx.TypeEngine != nil && ctx.TypeEngine.StdlibRemote != nil {
+				if remoteLoader, ok := ctx.TypeEngine.StdlibRemote.(*cgregistry.StdlibRegistryRemote); ok {
+					if validateStdlibFQN(fqn, remoteLoader, ctx.Logger) {
+						return fqn, true, nil, true
+					}
+				}
+			}
+			// Check third-party for imported names (e.g., from requests import Session)
+			if ctx.TypeEngine != nil && ctx.TypeEngine.ThirdPartyRemote != nil {
+				if loader, ok := ctx.TypeEngine.ThirdPartyRemote.(*cgregistry.ThirdPartyRegistryRemote); ok {
+					if validateThirdPartyFQN(fqn, loader, ctx.Logger) {
+						return fqn, true, nil, true
+					}
+				}
+			}
+			// Check callGraph.Functions directly (may differ from registry module keys)
+			if ctx.CallGraph != nil && ctx.CallGraph.Functions[fqn] != nil {
+				return fqn, true, nil, true
+			}
+			// Fix: strip leading package prefix and retry
+			// Import FQNs use full package path (e.g., label_studio.core.utils.params.get_env)
+			// but registry keys are relative to project root (e.g., core.utils.params.get_env)
+			if strippedFQN, ok := resolveWithPrefixStripping(fqn, ctx.Registry, ctx.CallGraph); ok {
+				return strippedFQN, true, nil, true
+			}
+			return fqn, false, nil, true
+		}
+
+		// Not in imports - might be in same module
+		sameLevelFQN := ctx.CurrentModule + "." + ctx.Target
+		if validateFQN(sameLevelFQN, ctx.Registry) {
+			return sameLevelFQN, true, nil, true
+		}
+
+		// Can't resolve - return as-is
+		return ctx.Target, false, nil, true
+	}
+	return "", false, nil, false
+}
+
+// variableBinding returns the binding of name in the caller's scope (or
+// the scope a global/nonlocal declaration rebinds it to), falling back to
+// the module scope.
+func variableBinding(ctx ResolutionContext, name string) *resolution.VariableBinding {
+	if binding := ctx.TypeEngine.GetVariableInScope(ctx.CallerFQN, name); binding != nil {
+		return binding
+	}
+	if moduleScope := ctx.TypeEngine.GetScope(ctx.CurrentModule); moduleScope != nil {
+		return moduleScope.GetVariable(name)
+	}
+	return nil
+}
+
+// resolveProtocolMethod resolves x.method() where x is annotated with a
+// typing.Protocol or abc.ABC class (def f(x: Drawable)) to the method the
+// protocol declares. The worker adds edges to the implementers' methods.
+func resolveProtocolMethod(ctx ResolutionContext) (string, bool, *core.TypeInfo, bool) {
+	base, rest, dotted := strings.Cut(ctx.Target, ".")
+	if !dotted || strings.Contains(rest, ".") || ctx.TypeEngine == nil || ctx.CallGraph == nil {
+		return "", false, nil, false
+	}
+	binding := variableBinding(ctx, base)
+	if binding == nil || binding.Type == nil {
+		return "", false, nil, false
+	}
+	protocolFQN, ok := ctx.TypeEngine.ResolveProtocolType(strings.Trim(binding.Type.TypeFQN, `"'`), ctx.CurrentModule)
+	if !ok {
+		return "", false, nil, false
+	}
+	methodFQN := protocolFQN + "." + rest
+	if _, ok := ctx.CallGraph.Functions[methodFQN]; !ok {
+		return "", false, nil, false
+	}
+	return methodFQN, true, &core.TypeInfo{
+		TypeFQN:    protocolFQN,
+		Confidence: binding.Type.Confidence,
+		Source:     "protocol",
+	}, true
+}
+
+// resolveTypeInference resolves var.method() through the inferred type of var.
+func resolveTypeInference(ctx ResolutionContext) (string, bool, *core.TypeInfo, bool) {
+	base, rest, _ := strings.Cut(ctx.Target, ".")
+
+	// Phase 2 Task 9: Try type inference for variable.method() calls
+	if ctx.TypeEngine != nil && ctx.CallerFQN != "" {
+		// Try function scope first, then fall back to module scope
+		if binding := variableBinding(ctx, base); binding != nil {
+			// Check if variable has type information
+			if binding.Type != nil {
+				typeFQN := binding.Type.TypeFQN
+
+				// Skip placeholders (call:, var:) - not yet resolved
+				if strings.HasPrefix(typeFQN, "call:") || strings.HasPrefix(typeFQN, "var:") {
+					// Continue to legacy resolution
+				} else {
+					// Check if it's a builtin type
+					if ctx.TypeEngine.Builtins != nil && strings.HasPrefix(typeFQN, "builtins.") {
+						method := ctx.TypeEngine.Builtins.GetMethod(typeFQN, rest)
+						if method != nil {
+							// Resolved to builtin method - return with type info
+							return typeFQN + "." + rest, true, binding.Type, true
+						}
+					}
+
+					// Phase 3: Enhanced instance.method() resolution
+					// Check if it's a project type (user-defined class/method)
+					methodFQN := typeFQN + "." + rest
+
+					// Phase 3: Try Functions map first with class-qualified FQN
+					// This is more reliable than codeGraph.Nodes for class methods
+					if ctx.CallGraph != nil {
+						if node := ctx.CallGraph.Functions[methodFQN]; node != nil {
+							// Found in Functions map with class-qualified FQN
+							if node.Type == "method" || node.Type == "function_definition" ||
+								node.Type == "constructor" || node.Type == "property" ||
+								node.Type == "special_method" {
+								return methodFQN, true, binding.Type, true
+							}
+						}
+					}
+
+					// Validate method exists in code graph (fallback)
+					if ctx.CodeGraph != nil {
+						if node, ok := ctx.CodeGraph.Nodes[methodFQN]; ok {
+							if node.Type == "method_declaration" || node.Type == "function_definition" {
+								// Resolved via code graph validation - return with type info
+								return methodFQN, true, binding.Type, true
+							}
+						}
+
+						// Legacy: Python class methods stored at module level
+						// Try stripping the class name and looking for module.method
+						// This is for backward compatibility with older indexing
+						lastDot := strings.LastIndex(typeFQN, ".")
+						if lastDot >= 0 {
+							modulePart := typeFQN[:lastDot]
+							className := typeFQN[lastDot+1:]
+
+							// Check if it looks like a Python class (PascalCase)
+							if len(className) > 0 && className[0] >= 'A' && className[0] <= 'Z' {
+								pythonMethodFQN := modulePart + "." + rest
+								if ctx.CallGraph != nil {
+									if node, ok := ctx.CallGraph.Functions[pythonMethodFQN]; ok {
+										if node.Type == "method_declaration" || node.Type == "function_definition" {
+											// Resolved via Python module-level method lookup
+											return pythonMethodFQN, true, binding.Type, true
+										}
+									}
+								}
+							}
+						}
+					}
+
+					// PR #6: Check third-party type registry for method on typed variable
+					if ctx.TypeEngine.ThirdPartyRemote != nil {
+						if loader, ok := ctx.TypeEngine.ThirdPartyRemote.(*cgregistry.ThirdPartyRegistryRemote); ok {
+							tpModule, tpClass := splitModuleAndName(typeFQN)
+							if tpClass != "" && loader.HasModule(tpModule) {
+								method := findThirdPartyClassMethod(loader, tpModule, tpClass, rest, ctx.Logger)
+								if method != nil {
+									return methodFQN, true, &core.TypeInfo{
+										TypeFQN:    typeFQN,
+										Confidence: binding.Type.Confidence,
+										Source:     "typeshed",
+									}, true
+								}
+							}
+						}
+					}
+
+					// Check stdlib type registry for method on typed variable.
+					if ctx.TypeEngine.StdlibRemote != nil {
+						if stdlibLoader, ok := ctx.TypeEngine.StdlibRemote.(*cgregistry.StdlibRegistryRemote); ok {
+							stModule, stClass := splitModuleAndName(typeFQN)
+							if stClass != "" && stdlibLoader.HasModule(stModule) {
+								method := stdlibLoader.GetClassMethod(stModule, stClass, rest, ctx.Logger)
+								if method != nil {
+									return methodFQN, true, &core.TypeInfo{
+										TypeFQN:    typeFQN,
+										Confidence: binding.Type.Confidence,
+										Source:     "stdlib",
+									}, true
+								}
+							}
+						}
+					}
+
+					// Heuristic: If type has good confidence (>= 0.7), assume method exists
+					if binding.Type.Confidence >= 0.7 {
+						// Resolved via confidence heuristic - return with type info
+						return methodFQN, true, binding.Type, true
+					}
+
+				}
+			}
+		}
+	}
+	return "", false, nil, false
+}
+
+// resolveImportedAttribute resolves mod.func() where mod is imported.
+func resolveImportedAttribute(ctx ResolutionContext) (string, bool, *core.TypeInfo, bool) {
+	base, rest, _ := strings.Cut(ctx.Target, ".")
+
+	// Try to resolve base through imports
+	if baseFQN, ok := ctx.ImportMap.Resolve(base); ok {
+		fullFQN := baseFQN + "." + rest
+		// Check if it's an ORM pattern (before validateFQN, since ORM methods don't exist in source)
+		if ormFQN, resolved := resolution.ResolveORMCall(ctx.Target, ctx.CurrentModule, ctx.Registry, ctx.CodeGraph); resolved {
+			return ormFQN, true, nil, true
+		}
+		// PR #3: Check stdlib registry before user project registry
+		if ctx.TypeEngine != nil && ctx.TypeEngine.StdlibRemote != nil {
+			if remoteLoader, ok := ctx.TypeEngine.StdlibRemote.(*cgregistry.StdlibRegistryRemote); ok {
+				if validateStdlibFQN(fullFQN, remoteLoader, ctx.Logger) {
+					return fullFQN, true, nil, true
+				}
+			}
+		}
+		// Offline fallback: attribute of a known stdlib submodule (os.path.join)
+		if isStdlibSubmoduleCall(fullFQN, ctx.Registry, ctx.TypeEngine) {
+			return fullFQN, true, nil, true
+		}
+		// PR #6: Check third-party registry before user project registry
+		if ctx.TypeEngine != nil && ctx.TypeEngine.ThirdPartyRemote != nil {
+			if loader, ok := ctx.TypeEngine.ThirdPartyRemote.(*cgregistry.ThirdPartyRegistryRemote); ok {
+				if validateThirdPartyFQN(fullFQN, loader, ctx.Logger) {
+					return fullFQN, true, nil, true
+				}
+			}
+		}
+		if validateFQN(fullFQN, ctx.Registry) {
+			return fullFQN, true, nil, true
+		}
+		// Check callGraph.Functions directly
+		if ctx.CallGraph != nil && ctx.CallGraph.Functions[fullFQN] != nil {
+			return fullFQN, true, nil, true
+		}
+		// Fix: strip leading package prefix and retry
+		if strippedFQN, ok := resolveWithPrefixStripping(fullFQN, ctx.Registry, ctx.CallGraph); ok {
+			return strippedFQN, true, nil, true
+		}
+		return fullFQN, false, nil, true
+	}
+	return "", false, nil, false
+}
+
+// resolveModuleAttribute resolves the remaining dotted names against the
+// current module, ORM patterns, and the stdlib and third-party registries.
+// It always decides.
+func resolveModuleAttribute(ctx ResolutionContext) (string, bool, *core.TypeInfo, bool) {
+	// Base not in imports - might be module-level access
+	// Try current module
+	fullFQN := ctx.CurrentModule + "." + ctx.Target
+	if validateFQN(fullFQN, ctx.Registry) {
+		return fullFQN, true, nil, true
+	}
+
+	// Before giving up, check if it's an ORM pattern (Django, SQLAlchemy, etc.)
+	// ORM methods are dynamically generated at runtime and won't be in source
+	if ormFQN, resolved := resolution.ResolveORMCall(ctx.Target, ctx.CurrentModule, ctx.Registry, ctx.CodeGraph); resolved {
+		return ormFQN, true, nil, true
+	}
+
+	// PR #3: Last resort - check if target is a stdlib call (e.g., os.path.join)
+	// This handles cases where stdlib modules are imported directly (import os.path)
+	if ctx.TypeEngine != nil && ctx.TypeEngine.StdlibRemote != nil {
+		if remoteLoader, ok := ctx.TypeEngine.StdlibRemote.(*cgregistry.StdlibRegistryRemote); ok {
+			if validateStdlibFQN(ctx.Target, remoteLoader, ctx.Logger) {
+				return ctx.Target, true, nil, true
+			}
+		}
+	}
+	// Offline fallback for the same (import os.path; os.path.join)
+	if isStdlibSubmoduleCall(ctx.Target, ctx.Registry, ctx.TypeEngine) {
+		return ctx.Target, true, nil, true
+	}
+
+	// PR #6: Last resort - check third-party registry
+	if ctx.TypeEngine != nil && ctx.TypeEngine.ThirdPartyRemote != nil {
+		if loader, ok := ctx.TypeEngine.ThirdPartyRemote.(*cgregistry.ThirdPartyRegistryRemote); ok {
+			if validateThirdPartyFQN(ctx.Target, loader, ctx.Logger) {
+				return ctx.Target, true, nil, true
+			}
+		}
+	}
+
+	// Can't resolve - return as-is
+	return ctx.Target, false, nil, true
+}
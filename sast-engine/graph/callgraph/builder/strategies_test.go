@@ -0,0 +1,86 @@
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/registry"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/resolution"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rpcStrategy resolves the rpc_call function injected by an RPC framework.
+var rpcStrategy = ResolutionStrategyFunc(func(ctx ResolutionContext) (*core.CallSite, bool) {
+	if ctx.Target != "rpc_call" {
+		return nil, false
+	}
+	return &core.CallSite{Target: ctx.Target, TargetFQN: "rpc.client.rpc_call", Resolved: true}, true
+})
+
+func buildWithStrategy(t *testing.T, priority int) *core.CallGraph {
+	t.Helper()
+	projectPath, err := filepath.Abs("../../../test-fixtures/python/custom_strategy")
+	require.NoError(t, err)
+
+	moduleRegistry, err := registry.BuildModuleRegistry(projectPath, false)
+	require.NoError(t, err)
+
+	b := NewBuilder()
+	b.RegisterStrategy(rpcStrategy, priority)
+	callGraph, err := b.BuildCallGraph(graph.Initialize(projectPath, nil), moduleRegistry, projectPath, output.NewLogger(output.VerbosityDefault))
+	require.NoError(t, err)
+	return callGraph
+}
+
+func TestBuilder_RegisterStrategy(t *testing.T) {
+	callGraph := buildWithStrategy(t, PrioritySimpleName+1)
+
+	assert.Contains(t, callGraph.Edges["app.get_user"], "rpc.client.rpc_call")
+
+	// Built-in strategies still resolve the calls the custom one passes on
+	assert.Contains(t, callGraph.Edges["app.helper"], "builtins.eval")
+}
+
+func TestBuilder_RegisterStrategyBelowBuiltin(t *testing.T) {
+	// The simple-name strategy decides rpc_call first, leaving it unresolved
+	callGraph := buildWithStrategy(t, PrioritySimpleName-1)
+
+	assert.NotContains(t, callGraph.Edges["app.get_user"], "rpc.client.rpc_call")
+}
+
+func TestOrderedStrategies(t *testing.T) {
+	named := func(name string) ResolutionStrategy {
+		return ResolutionStrategyFunc(func(ctx ResolutionContext) (*core.CallSite, bool) {
+			return &core.CallSite{TargetFQN: name}, true
+		})
+	}
+	strategies := orderedStrategies([]prioritizedStrategy{
+		{named("low"), 1},
+		{named("high"), 10},
+		{named("first"), 5},
+		{named("second"), 5},
+	})
+
+	var order []string
+	for _, strategy := range strategies {
+		callSite, _ := strategy.Resolve(ResolutionContext{})
+		order = append(order, callSite.TargetFQN)
+	}
+	assert.Equal(t, []string{"high", "first", "second", "low"}, order)
+}
+
+func TestResolveWithStrategies_Undecided(t *testing.T) {
+	fqn, resolved, typeInfo := resolveWithStrategies(nil, ResolutionContext{
+		Target:     "mystery",
+		CallerFQN:  "app.main",
+		ImportMap:  core.NewImportMap("app.py"),
+		TypeEngine: resolution.NewTypeInferenceEngine(core.NewModuleRegistry()),
+	})
+	assert.Equal(t, "mystery", fqn)
+	assert.False(t, resolved)
+	assert.Nil(t, typeInfo)
+}
@@ -1,13 +1,17 @@
 package builder
 
 import (
+	"cmp"
 	"log"
+	"maps"
+	"slices"
 
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/analysis/taint"
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/cfg"
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/extraction"
+	sitter "github.com/smacker/go-tree-sitter"
 )
 
 // GenerateTaintSummaries analyzes all Python functions for taint flows.
@@ -26,20 +30,57 @@ import (
 func GenerateTaintSummaries(callGraph *core.CallGraph, codeGraph *graph.CodeGraph, registry *core.ModuleRegistry) {
 	_ = codeGraph  // Reserved for future use
 	_ = registry   // Reserved for future use
+	astCache := NewBoundedASTCache(DefaultASTCacheSize)
+	defer astCache.Close()
+	generateTaintSummaries(callGraph, nil, astCache, nil)
+}
+
+// generateTaintSummaries is the internal implementation of GenerateTaintSummaries.
+// When include is non-nil, only functions for which it returns true are analyzed.
+// Trees come from astCache, so each file is parsed once rather than per function.
+// Functions are analyzed grouped by file, in sorted order.
+// Progress per analyzed function goes to progress, if non-nil.
+func generateTaintSummaries(callGraph *core.CallGraph, include func(funcFQN string) bool, astCache *ASTCache, progress *progressReporter) {
 	analyzed := 0
 	total := len(callGraph.Functions)
 
-	// Iterate over all indexed functions
-	for funcFQN, funcNode := range callGraph.Functions {
+	if progress != nil {
+		included := total
+		if include != nil {
+			included = 0
+			for funcFQN := range callGraph.Functions {
+				if include(funcFQN) {
+					included++
+				}
+			}
+		}
+		progress.start(PassTaintSummaries, included)
+		defer progress.finish()
+	}
+
+	// Visit functions file by file, so each file's tree is fetched once in
+	// a row and a bounded astCache does not evict it between functions
+	funcFQNs := slices.SortedFunc(maps.Keys(callGraph.Functions), func(a, b string) int {
+		return cmp.Or(
+			cmp.Compare(callGraph.Functions[a].File, callGraph.Functions[b].File),
+			cmp.Compare(a, b),
+		)
+	})
+	for _, funcFQN := range funcFQNs {
+		funcNode := callGraph.Functions[funcFQN]
+		if include != nil && !include(funcFQN) {
+			continue
+		}
+
 		// Read source code for this function's file
-		sourceCode, err := ReadFileBytes(funcNode.File)
+		sourceCode, err := astCache.ReadSource(funcNode.File)
 		if err != nil {
 			log.Printf("Warning: failed to read file %s for taint analysis: %v", funcNode.File, err)
 			continue
 		}
 
-		// Parse the Python file to get AST
-		tree, err := extraction.ParsePythonFile(sourceCode)
+		// Parse the Python file to get AST (shared across functions in the file)
+		tree, err := astCache.GetOrParse(funcNode.File, sourceCode)
 		if err != nil {
 			log.Printf("Warning: failed to parse %s for taint analysis: %v", funcNode.File, err)
 			continue
@@ -49,9 +90,6 @@ func GenerateTaintSummaries(callGraph *core.CallGraph, codeGraph *graph.CodeGrap
 		functionNode := FindFunctionAtLine(tree.RootNode(), funcNode.LineNumber)
 		if functionNode == nil {
 			log.Printf("Warning: could not find function %s at line %d", funcFQN, funcNode.LineNumber)
-			if tree != nil {
-				tree.Close()
-			}
 			continue
 		}
 
@@ -59,9 +97,6 @@ func GenerateTaintSummaries(callGraph *core.CallGraph, codeGraph *graph.CodeGrap
 		statements, err := extraction.ExtractStatements(funcNode.File, sourceCode, functionNode)
 		if err != nil {
 			log.Printf("Warning: failed to extract statements from %s: %v", funcFQN, err)
-			if tree != nil {
-				tree.Close()
-			}
 			continue
 		}
 
@@ -75,6 +110,11 @@ func GenerateTaintSummaries(callGraph *core.CallGraph, codeGraph *graph.CodeGrap
 			callGraph.CFGBlockStatements[funcFQN] = blockStmts
 		}
 
+		// Record module-level variables rebound via `global` (for Purity)
+		if writes := findGlobalWrites(functionNode, sourceCode, statements, blockStmts); len(writes) > 0 {
+			callGraph.GlobalWrites[funcFQN] = writes
+		}
+
 		// Step 2: Build def-use chains
 		defUseChain := core.BuildDefUseChains(statements)
 
@@ -93,15 +133,59 @@ func GenerateTaintSummaries(callGraph *core.CallGraph, codeGraph *graph.CodeGrap
 		callGraph.Summaries[funcFQN] = summary
 
 		analyzed++
+		progress.advance()
 
 		// Report progress every 1000 functions
 		if analyzed%1000 == 0 {
 			log.Printf("Analyzed %d/%d functions...", analyzed, total)
 		}
+	}
+}
 
-		// Clean up tree-sitter tree
-		if tree != nil {
-			tree.Close()
+// findGlobalWrites returns the sorted names a function declares `global` and
+// then assigns. Statements from every CFG block are checked, so writes nested
+// in if/for/while bodies count. Declarations in nested functions or classes
+// belong to those scopes and are ignored.
+func findGlobalWrites(functionNode *sitter.Node, sourceCode []byte, statements []*core.Statement, blockStmts cfg.BlockStatements) []string {
+	declared := make(map[string]bool)
+	var collect func(node *sitter.Node)
+	collect = func(node *sitter.Node) {
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			child := node.NamedChild(i)
+			switch child.Type() {
+			case "function_definition", "class_definition", "lambda":
+				continue
+			case "global_statement":
+				for j := 0; j < int(child.NamedChildCount()); j++ {
+					if name := child.NamedChild(j); name.Type() == "identifier" {
+						declared[name.Content(sourceCode)] = true
+					}
+				}
+			default:
+				collect(child)
+			}
 		}
 	}
+	if body := functionNode.ChildByFieldName("body"); body != nil {
+		collect(body)
+	}
+	if len(declared) == 0 {
+		return nil
+	}
+
+	written := make(map[string]bool)
+	for _, stmt := range statements {
+		if declared[stmt.Def] {
+			written[stmt.Def] = true
+		}
+	}
+	for _, stmts := range blockStmts {
+		for _, stmt := range stmts {
+			if declared[stmt.Def] {
+				written[stmt.Def] = true
+			}
+		}
+	}
+
+	return slices.Sorted(maps.Keys(written))
 }
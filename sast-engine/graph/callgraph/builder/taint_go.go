@@ -367,14 +367,14 @@ func extractGoPackageLevelVars(callGraph *core.CallGraph, fileCache map[string]*
 		}
 
 		// Create a synthetic function node so inter-procedural analysis can find it.
-		if _, exists := callGraph.Functions[initFQN]; !exists {
-			callGraph.Functions[initFQN] = &graph.Node{
+		if _, exists := callGraph.GetFunction(initFQN); !exists {
+			callGraph.AddFunction(initFQN, &graph.Node{
 				ID:       "init$vars:" + packagePath,
 				Type:     "init_function",
 				Name:     "init$vars",
 				Language: "go",
 				File:     filePath,
-			}
+			})
 		}
 
 		// Build def-use chains and summary for the synthetic scope.
@@ -0,0 +1,55 @@
+package builder
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/resolution"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCallGraph_BindingsOfType(t *testing.T) {
+	projectPath, err := filepath.Abs("../../../test-fixtures/python/type_bindings")
+	require.NoError(t, err)
+
+	codeGraph := graph.Initialize(projectPath, nil)
+	callGraph, _, err := BuildCallGraphFromPath(codeGraph, projectPath, output.NewLogger(output.VerbosityDefault))
+	require.NoError(t, err)
+
+	engine, ok := callGraph.TypeEngine.(*resolution.TypeInferenceEngine)
+	require.True(t, ok)
+
+	// Module-level, local, and return-propagated assignments are all found;
+	// the reassignment of current to a Ledger is a separate binding.
+	var found []string
+	for _, variable := range engine.VariablesOfType("models.Account") {
+		found = append(found, fmt.Sprintf("%s.%s:%d", variable.Scope, variable.Name, variable.Line))
+	}
+	assert.Equal(t, []string{
+		"service.system_account:3",
+		"service.open_account.account:11",
+		"service.transfer.target:18",
+		"service.transfer.current:19",
+	}, found)
+
+	bindings := engine.BindingsOfType("models.Account")
+	require.Len(t, bindings, 4)
+	assert.Equal(t, "account", bindings[1].VarName)
+	assert.Equal(t, "models.Account", bindings[1].Type.TypeFQN)
+
+	var ledgers []string
+	for _, binding := range engine.BindingsOfType("models.Ledger") {
+		ledgers = append(ledgers, fmt.Sprintf("%s:%d", binding.VarName, binding.Location.Line))
+	}
+	assert.Equal(t, []string{"ledger:12", "current:20"}, ledgers)
+
+	assert.Empty(t, engine.BindingsOfType("models.Missing"))
+
+	var provider core.TypedVariableProvider = engine
+	assert.Len(t, provider.VariablesOfType("models.Account"), 4)
+}
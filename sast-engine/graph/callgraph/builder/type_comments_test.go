@@ -0,0 +1,54 @@
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/resolution"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCallGraph_TypeComments(t *testing.T) {
+	projectPath, err := filepath.Abs("../../../test-fixtures/python/type_comments")
+	require.NoError(t, err)
+
+	codeGraph := graph.Initialize(projectPath, nil)
+	callGraph, _, err := BuildCallGraphFromPath(codeGraph, projectPath, output.NewLogger(output.VerbosityDefault))
+	require.NoError(t, err)
+
+	engine, ok := callGraph.TypeEngine.(*resolution.TypeInferenceEngine)
+	require.True(t, ok)
+
+	// A variable's type comment declares its type...
+	user := engine.GetScope("accounts.show").GetVariable("user")
+	require.NotNil(t, user)
+	assert.Equal(t, "accounts.User", user.Type.TypeFQN)
+	assert.Equal(t, "type_comment", user.Type.Source)
+
+	// ...and a signature type comment its parameters and return type.
+	repo := engine.GetScope("accounts.welcome").GetVariable("repo")
+	require.NotNil(t, repo)
+	assert.Equal(t, "accounts.Repository", repo.Type.TypeFQN)
+	returnType, ok := engine.GetReturnType("accounts.first")
+	require.True(t, ok)
+	assert.Equal(t, "accounts.User", returnType.TypeFQN)
+
+	targetOf := func(caller, target string) string {
+		for _, callSite := range callGraph.CallSites[caller] {
+			if callSite.Target == target {
+				return callSite.TargetFQN
+			}
+		}
+		return ""
+	}
+	assert.Equal(t, "accounts.User.greet", targetOf("accounts.show", "user.greet"))
+	assert.Equal(t, "accounts.Repository.find", targetOf("accounts.welcome", "repo.find"))
+	assert.Equal(t, "accounts.User.greet", targetOf("accounts.welcome", "user.greet"))
+	assert.Equal(t, "accounts.User.greet", targetOf("accounts.banner", "user.greet"))
+
+	// "# type: ignore" declares nothing
+	assert.NotEqual(t, "accounts.User.greet", targetOf("accounts.skipped", "user.greet"))
+}
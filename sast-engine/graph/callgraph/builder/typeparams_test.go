@@ -0,0 +1,48 @@
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCallGraph_TypeParameters(t *testing.T) {
+	projectPath, err := filepath.Abs("../../../test-fixtures/python/type_params")
+	require.NoError(t, err)
+
+	codeGraph := graph.Initialize(projectPath, nil)
+	callGraph, _, err := BuildCallGraphFromPath(codeGraph, projectPath, output.NewLogger(output.VerbosityDefault))
+	require.NoError(t, err)
+
+	tests := []struct {
+		function       string
+		typeParameters []string
+		parameters     []string
+	}{
+		{"generics.first", []string{"T"}, []string{"items: list[T]", "default: T | None = None"}},
+		{"generics.apply", []string{"**P", "R"}, []string{"func: Callable[P, R]", "*args: P.args", "**kwargs: P.kwargs"}},
+		{"generics.load_user", []string{"T: User"}, []string{"user: T"}},
+		{"generics.Box.get", []string{"U"}, []string{"self", "fallback: U"}},
+		{"generics.User.name", nil, []string{"self"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.function, func(t *testing.T) {
+			function, ok := callGraph.GetFunction(tt.function)
+			require.True(t, ok)
+			assert.Equal(t, tt.typeParameters, function.TypeParameters)
+			assert.Equal(t, tt.parameters, function.MethodArgumentsValue)
+			assert.Len(t, function.ParameterKinds, len(tt.parameters))
+		})
+	}
+
+	// A parameter annotated with a type parameter has the type of its bound,
+	// not a class named after the parameter
+	callSites := callGraph.CallSites["generics.load_user"]
+	require.Len(t, callSites, 1)
+	assert.Equal(t, "user.name", callSites[0].Target)
+	assert.NotEqual(t, "T.name", callSites[0].TargetFQN)
+}
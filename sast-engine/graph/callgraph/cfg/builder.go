@@ -2,6 +2,8 @@ package cfg
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	sitter "github.com/smacker/go-tree-sitter"
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
@@ -112,14 +114,14 @@ func (b *cfgBuilder) processBody(bodyNode *sitter.Node, currentBlockID string) s
 		case "with_statement":
 			currentBlockID = b.processWith(actualNode, stmtNode, currentBlockID)
 
-		case "return_statement":
+		case "return_statement", "raise_statement":
 			stmt := b.extractStatement(actualNode, stmtNode)
 			if stmt != nil {
 				b.appendStmt(currentBlockID, stmt)
 			}
-			// Return goes directly to exit
+			// Return and raise leave the function: go directly to exit
 			b.cfGraph.AddEdge(currentBlockID, b.cfGraph.ExitBlockID)
-			// Create a new unreachable block for any code after return
+			// Create a new unreachable block for any code after them
 			currentBlockID = b.newBlockID("after_return")
 			b.addBlock(currentBlockID, BlockTypeNormal)
 
@@ -137,21 +139,9 @@ func (b *cfgBuilder) processBody(bodyNode *sitter.Node, currentBlockID string) s
 // processIf handles if/elif/else statements.
 // Creates: condition block -> true branch, false branch -> merge block.
 func (b *cfgBuilder) processIf(ifNode, stmtNode *sitter.Node, predBlockID string) string {
-	// Create condition block
-	condBlockID := b.newBlockID("if_cond")
-	b.addBlock(condBlockID, BlockTypeConditional)
-	b.cfGraph.AddEdge(predBlockID, condBlockID)
-
-	// Extract condition uses as a statement
-	condNode := ifNode.ChildByFieldName("condition")
-	if condNode != nil {
-		condStmt := &core.Statement{
-			Type:       core.StatementTypeIf,
-			LineNumber: stmtNode.StartPoint().Row + 1,
-			Uses:       extractIdentifiers(condNode, b.sourceCode),
-		}
-		b.appendStmt(condBlockID, condStmt)
-	}
+	// Create condition block(s); a false edge leaves each
+	_, condBlockID, falseExits := b.addCondition("if_cond", BlockTypeConditional, core.StatementTypeIf,
+		ifNode.ChildByFieldName("condition"), stmtNode, predBlockID)
 
 	// Process consequence (true branch)
 	consequenceNode := ifNode.ChildByFieldName("consequence")
@@ -174,7 +164,9 @@ func (b *cfgBuilder) processIf(ifNode, stmtNode *sitter.Node, predBlockID string
 		// Check if it's an elif (elif_clause) or else (else_clause)
 		falseBlockID := b.newBlockID("if_false")
 		b.addBlock(falseBlockID, BlockTypeNormal)
-		b.cfGraph.AddEdge(condBlockID, falseBlockID)
+		for _, exitID := range falseExits {
+			b.cfGraph.AddEdge(exitID, falseBlockID)
+		}
 
 		// The alternative node might be an elif_clause or else_clause
 		// Both have a body child
@@ -204,13 +196,108 @@ func (b *cfgBuilder) processIf(ifNode, stmtNode *sitter.Node, predBlockID string
 	if falseEndID != "" && alternativeNode != nil {
 		b.cfGraph.AddEdge(falseEndID, mergeBlockID)
 	} else if alternativeNode == nil {
-		// No else: false edge from condition goes to merge
-		b.cfGraph.AddEdge(condBlockID, mergeBlockID)
+		// No else: false edges from the condition go to merge
+		for _, exitID := range falseExits {
+			b.cfGraph.AddEdge(exitID, mergeBlockID)
+		}
 	}
 
 	return mergeBlockID
 }
 
+// addCondition creates the block(s) testing condNode, linked from
+// predBlockID. A chained comparison (0 <= x < n) is shorthand for the
+// conjunction of its comparisons (0 <= x and x < n), so it gets one block
+// per comparison, each true edge leading to the next. Returns the first and
+// last blocks, and the blocks whose false edge leaves the condition, in
+// order; the caller adds the last block's true edge and the false edges.
+func (b *cfgBuilder) addCondition(label string, blockType BlockType, stmtType core.StatementType, condNode, stmtNode *sitter.Node, predBlockID string) (string, string, []string) {
+	comparisons := comparisonChain(condNode, b.sourceCode)
+	if comparisons == nil {
+		comparisons = []comparison{{}}
+		if condNode != nil {
+			comparisons[0] = comparison{
+				text: condNode.Content(b.sourceCode),
+				uses: extractIdentifiers(condNode, b.sourceCode),
+			}
+		}
+	}
+
+	var firstID, lastID string
+	var exits []string
+	for i, cmp := range comparisons {
+		blockID := b.newBlockID(label)
+		if i == 0 {
+			firstID = blockID
+			b.addBlock(blockID, blockType)
+			b.cfGraph.AddEdge(predBlockID, blockID)
+		} else {
+			b.addBlock(blockID, BlockTypeConditional)
+			b.cfGraph.AddEdge(lastID, blockID)
+		}
+		b.cfGraph.Blocks[blockID].Condition = cmp.text
+
+		// Extract condition uses as a statement
+		if condNode != nil {
+			b.appendStmt(blockID, &core.Statement{
+				Type:       stmtType,
+				LineNumber: stmtNode.StartPoint().Row + 1,
+				Uses:       cmp.uses,
+			})
+		}
+		exits = append(exits, blockID)
+		lastID = blockID
+	}
+	return firstID, lastID, exits
+}
+
+// comparison is one test of a condition.
+type comparison struct {
+	text string   // e.g., "x < len(a)"
+	uses []string // identifiers read by the test
+}
+
+// comparisonChain splits a chained comparison into its comparisons, each
+// between adjacent operands: 0 <= x < len(a) is 0 <= x and x < len(a), with
+// x evaluated once but used by both. Returns nil for any other condition,
+// including a comparison with a single operator.
+func comparisonChain(condNode *sitter.Node, sourceCode []byte) []comparison {
+	for condNode != nil && condNode.Type() == "parenthesized_expression" && condNode.NamedChildCount() == 1 {
+		condNode = condNode.NamedChild(0)
+	}
+	if condNode == nil || condNode.Type() != "comparison_operator" {
+		return nil
+	}
+
+	var operands []*sitter.Node
+	for i := 0; i < int(condNode.NamedChildCount()); i++ {
+		if operand := condNode.NamedChild(i); operand.Type() != "comment" {
+			operands = append(operands, operand)
+		}
+	}
+	if len(operands) < 3 {
+		return nil
+	}
+
+	comparisons := make([]comparison, 0, len(operands)-1)
+	for i := 0; i+1 < len(operands); i++ {
+		left, right := operands[i], operands[i+1]
+		// Operators are the tokens between operands; "not in" and "is not" span two
+		operator := strings.Join(strings.Fields(string(sourceCode[left.EndByte():right.StartByte()])), " ")
+		uses := extractIdentifiers(left, sourceCode)
+		for _, name := range extractIdentifiers(right, sourceCode) {
+			if !slices.Contains(uses, name) {
+				uses = append(uses, name)
+			}
+		}
+		comparisons = append(comparisons, comparison{
+			text: left.Content(sourceCode) + " " + operator + " " + right.Content(sourceCode),
+			uses: uses,
+		})
+	}
+	return comparisons
+}
+
 // processFor handles for-loop statements.
 // Creates: loop header -> loop body -> (back edge to header), after-loop block.
 func (b *cfgBuilder) processFor(forNode, stmtNode *sitter.Node, predBlockID string) string {
@@ -264,26 +351,15 @@ func (b *cfgBuilder) processFor(forNode, stmtNode *sitter.Node, predBlockID stri
 
 // processWhile handles while-loop statements.
 func (b *cfgBuilder) processWhile(whileNode, stmtNode *sitter.Node, predBlockID string) string {
-	headerBlockID := b.newBlockID("while_header")
-	b.addBlock(headerBlockID, BlockTypeLoop)
-	b.cfGraph.AddEdge(predBlockID, headerBlockID)
-
-	// Extract condition
-	condNode := whileNode.ChildByFieldName("condition")
-	if condNode != nil {
-		condStmt := &core.Statement{
-			Type:       core.StatementTypeWhile,
-			LineNumber: stmtNode.StartPoint().Row + 1,
-			Uses:       extractIdentifiers(condNode, b.sourceCode),
-		}
-		b.appendStmt(headerBlockID, condStmt)
-	}
+	// Header block(s) testing the condition; a false edge leaves each
+	headerBlockID, condBlockID, exits := b.addCondition("while_header", BlockTypeLoop, core.StatementTypeWhile,
+		whileNode.ChildByFieldName("condition"), stmtNode, predBlockID)
 
 	// Process body
 	bodyNode := whileNode.ChildByFieldName("body")
 	bodyBlockID := b.newBlockID("while_body")
 	b.addBlock(bodyBlockID, BlockTypeNormal)
-	b.cfGraph.AddEdge(headerBlockID, bodyBlockID)
+	b.cfGraph.AddEdge(condBlockID, bodyBlockID)
 
 	var bodyEndID string
 	if bodyNode != nil {
@@ -300,7 +376,9 @@ func (b *cfgBuilder) processWhile(whileNode, stmtNode *sitter.Node, predBlockID
 	// After-while block
 	afterBlockID := b.newBlockID("while_after")
 	b.addBlock(afterBlockID, BlockTypeNormal)
-	b.cfGraph.AddEdge(headerBlockID, afterBlockID)
+	for _, exitID := range exits {
+		b.cfGraph.AddEdge(exitID, afterBlockID)
+	}
 
 	return afterBlockID
 }
@@ -328,7 +406,9 @@ func (b *cfgBuilder) processTry(tryNode, _ *sitter.Node, predBlockID string) str
 		b.cfGraph.AddEdge(tryEndID, mergeBlockID)
 	}
 
-	// Process except_clause children
+	// Process except_clause children. Track whether every handler leaves the
+	// function: its last block is then unreachable (see processBody).
+	handlers, exitingHandlers := 0, 0
 	for i := 0; i < int(tryNode.ChildCount()); i++ {
 		child := tryNode.Child(i)
 		if child == nil {
@@ -380,6 +460,11 @@ func (b *cfgBuilder) processTry(tryNode, _ *sitter.Node, predBlockID string) str
 				catchEndID = catchBlockID
 			}
 
+			handlers++
+			if b.unreachable(catchEndID) {
+				exitingHandlers++
+			}
+
 			if catchEndID != "" {
 				b.cfGraph.AddEdge(catchEndID, mergeBlockID)
 			}
@@ -449,9 +534,23 @@ func (b *cfgBuilder) processTry(tryNode, _ *sitter.Node, predBlockID string) str
 		}
 	}
 
+	// Code after the try only runs if the try block did not raise
+	if handlers > 0 && exitingHandlers == handlers {
+		for _, stmt := range b.blockStmts[tryBlockID] {
+			stmt.Guarded = true
+		}
+	}
+
 	return mergeBlockID
 }
 
+// unreachable reports whether a block has no predecessors, like the block
+// processBody continues in after a return or raise.
+func (b *cfgBuilder) unreachable(blockID string) bool {
+	block, ok := b.cfGraph.GetBlock(blockID)
+	return ok && len(block.Predecessors) == 0
+}
+
 // processWith handles with-statements.
 // Creates a block with the context variable def, then processes the body.
 func (b *cfgBuilder) processWith(withNode, stmtNode *sitter.Node, predBlockID string) string {
@@ -2,6 +2,7 @@ package cfg
 
 import (
 	"context"
+	"os"
 	"testing"
 
 	sitter "github.com/smacker/go-tree-sitter"
@@ -215,6 +216,62 @@ func TestBuildCFG_TryExcept(t *testing.T) {
 	assert.GreaterOrEqual(t, totalStmts, 4)
 }
 
+func TestBuildCFG_TryExceptGuarded(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler string
+		guarded bool
+	}{
+		{"return", "return None", true},
+		{"raise", "raise BadRequest()", true},
+		{"fall through", "n = 0", false},
+		{"conditional return", "if strict:\n            return None", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := `def foo(value, strict):
+    try:
+        n = int(value)
+    except ValueError:
+        ` + tt.handler + `
+    sink(n)
+`
+			funcNode := parsePythonFunction(t, source)
+			cfg, blockStmts, err := BuildCFGFromAST("test.foo", funcNode, []byte(source))
+			require.NoError(t, err)
+
+			guarded := make(map[string]bool)
+			for _, stmts := range blockStmts {
+				for _, stmt := range stmts {
+					if stmt.Guarded {
+						guarded[stmt.Def] = true
+					}
+				}
+			}
+			if tt.guarded {
+				assert.Equal(t, map[string]bool{"n": true}, guarded)
+			} else {
+				assert.Empty(t, guarded)
+			}
+			assert.NotNil(t, cfg)
+		})
+	}
+}
+
+func TestBuildCFG_RaiseExits(t *testing.T) {
+	source := `def foo(x):
+    if x:
+        raise ValueError()
+    sink(x)
+`
+	funcNode := parsePythonFunction(t, source)
+	cfg, _, err := BuildCFGFromAST("test.foo", funcNode, []byte(source))
+	require.NoError(t, err)
+
+	// The raise branch goes straight to exit, as does the code after the if
+	assert.Len(t, cfg.GetPredecessors(cfg.ExitBlockID), 2)
+}
+
 func TestBuildCFG_WithStatement(t *testing.T) {
 	source := `def foo():
     with open(filename) as f:
@@ -658,3 +715,90 @@ func TestBuildCFG_LambdaCall(t *testing.T) {
 		}
 	}
 }
+
+// buildFixtureCFG builds the CFG of the named function in a fixture file.
+func buildFixtureCFG(t *testing.T, path, name string) (*ControlFlowGraph, BlockStatements) {
+	t.Helper()
+	source, err := os.ReadFile(path)
+	require.NoError(t, err)
+	parser := sitter.NewParser()
+	parser.SetLanguage(python.GetLanguage())
+	defer parser.Close()
+	tree, err := parser.ParseCtx(context.Background(), nil, source)
+	require.NoError(t, err)
+
+	root := tree.RootNode()
+	for i := 0; i < int(root.NamedChildCount()); i++ {
+		child := root.NamedChild(i)
+		if child.Type() == "function_definition" && child.ChildByFieldName("name").Content(source) == name {
+			cfg, blockStmts, err := BuildCFGFromAST("bounds."+name, child, source)
+			require.NoError(t, err)
+			return cfg, blockStmts
+		}
+	}
+	t.Fatalf("no function %s in %s", name, path)
+	return nil, nil
+}
+
+func TestBuildCFG_ChainedComparison(t *testing.T) {
+	const fixture = "../../../test-fixtures/python/chained_comparison/bounds.py"
+
+	cfg, blockStmts := buildFixtureCFG(t, fixture, "lookup")
+	var conditions []*BasicBlock
+	for _, block := range cfg.Blocks {
+		if block.Type == BlockTypeConditional {
+			conditions = append(conditions, block)
+		}
+	}
+	require.Len(t, conditions, 2, "0 <= index < len(items) is two comparisons")
+	first, second := conditions[0], conditions[1]
+	if second.Successors[0] == first.ID {
+		first, second = second, first
+	}
+	assert.Equal(t, "0 <= index", first.Condition)
+	assert.Equal(t, "index < len(items)", second.Condition)
+
+	// Both comparisons exit to the same false target
+	require.Len(t, first.Successors, 2)
+	require.Len(t, second.Successors, 2)
+	assert.Equal(t, second.ID, first.Successors[0])
+	assert.Equal(t, first.Successors[1], second.Successors[1])
+
+	// Each comparison reads its own operands; index is read by both
+	require.Len(t, blockStmts[first.ID], 1)
+	assert.Equal(t, []string{"index"}, blockStmts[first.ID][0].Uses)
+	require.Len(t, blockStmts[second.ID], 1)
+	assert.ElementsMatch(t, []string{"index", "len", "items"}, blockStmts[second.ID][0].Uses)
+
+	assert.Equal(t, 3, cfg.CyclomaticComplexity())
+
+	clamp, _ := buildFixtureCFG(t, fixture, "clamp")
+	assert.Equal(t, 3, clamp.CyclomaticComplexity())
+
+	positive, _ := buildFixtureCFG(t, fixture, "positive")
+	assert.Equal(t, 2, positive.CyclomaticComplexity())
+}
+
+func TestBuildCFG_ChainedComparisonWhile(t *testing.T) {
+	cfg, _ := buildFixtureCFG(t, "../../../test-fixtures/python/chained_comparison/bounds.py", "drain")
+
+	var header, bound *BasicBlock
+	for _, block := range cfg.Blocks {
+		switch block.Type {
+		case BlockTypeLoop:
+			header = block
+		case BlockTypeConditional:
+			bound = block
+		}
+	}
+	require.NotNil(t, header)
+	require.NotNil(t, bound)
+	assert.Equal(t, "0 < count", header.Condition)
+	assert.Equal(t, "count < limit", bound.Condition)
+	assert.Equal(t, bound.ID, header.Successors[0])
+
+	// The body loops back to the first comparison
+	bodyID := bound.Successors[0]
+	assert.Contains(t, cfg.Blocks[bodyID].Successors, header.ID)
+	assert.Equal(t, 3, cfg.CyclomaticComplexity())
+}
@@ -305,6 +305,19 @@ func (cfg *ControlFlowGraph) IsDominator(dominator, dominated string) bool {
 	return containsString(block.Dominators, dominator)
 }
 
+// CyclomaticComplexity returns the McCabe complexity of the function: one
+// plus the number of decision points, where a block with n successors
+// decides n-1 times. A chained comparison counts once per comparison.
+func (cfg *ControlFlowGraph) CyclomaticComplexity() int {
+	complexity := 1
+	for _, block := range cfg.Blocks {
+		if len(block.Successors) > 1 {
+			complexity += len(block.Successors) - 1
+		}
+	}
+	return complexity
+}
+
 // GetAllPaths returns all execution paths from entry to exit.
 // Used for exhaustive security analysis.
 // WARNING: Can be exponential in size for complex CFGs with loops.
@@ -39,6 +39,15 @@
 //   - BlockTypeCatch: Exception handlers
 //   - BlockTypeFinally: Finally blocks
 //
+// # Conditions
+//
+// A conditional or loop block records its test in Condition. A chained
+// comparison is split into the comparisons it is shorthand for, one block
+// each, so 0 <= i < len(a) is two decision points, as in the equivalent
+// 0 <= i and i < len(a). CyclomaticComplexity counts decision points:
+//
+//	complexity := cfGraph.CyclomaticComplexity() // 3 for: if 0 <= i < len(a)
+//
 // # Usage Example
 //
 //	// Build CFG for a function
@@ -0,0 +1,115 @@
+package core
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+)
+
+// ArgumentBinding pairs an argument of a call with the parameter it binds.
+type ArgumentBinding struct {
+	Argument      Argument
+	Parameter     string              // Parameter name; "" if no listed parameter accepts the argument
+	ParameterKind graph.ParameterKind // Kind of Parameter; "" if unbound
+}
+
+// pythonIdentifier matches a plain Python name.
+var pythonIdentifier = regexp.MustCompile(`^[A-Za-z_]\w*$`)
+
+// BindArguments maps the arguments of a call to the parameters of the Python
+// function it calls, following Python's binding rules: positional arguments
+// fill the positional-only and normal parameters in order, then a typed
+// *args; keyword arguments (name=value) bind the normal or keyword-only
+// parameter of that name, else a typed **kwargs. Keyword-only parameters
+// are never filled by position, nor positional-only ones by keyword.
+// Positional arguments after a *iterable are left unbound, as their
+// positions are unknown, and so are **mapping arguments.
+//
+// A method's self or cls parameter is skipped; the call binds it to the
+// receiver.
+func BindArguments(function *graph.Node, args []Argument) []ArgumentBinding {
+	names := make([]string, len(function.MethodArgumentsValue))
+	for i, param := range function.MethodArgumentsValue {
+		names[i] = parameterName(param)
+	}
+	kinds := function.ParameterKinds
+	if len(kinds) != len(names) {
+		kinds = make([]graph.ParameterKind, len(names))
+		for i := range kinds {
+			kinds[i] = graph.ParameterNormal
+		}
+	}
+	first := 0
+	if len(names) > 0 && (names[0] == "self" || names[0] == "cls") {
+		first = 1
+	}
+
+	bindings := make([]ArgumentBinding, len(args))
+	next, unpacked := first, false
+	for i, arg := range args {
+		bindings[i].Argument = arg
+		parameter := -1
+		switch {
+		case arg.IsDictSpread:
+		case strings.HasPrefix(arg.Value, "*"):
+			unpacked = true
+		default:
+			if keyword, ok := keywordArgument(arg.Value); ok {
+				parameter = keywordParameter(keyword, names[first:], kinds[first:])
+				if parameter >= 0 {
+					parameter += first
+				}
+			} else if !unpacked && next < len(names) {
+				// Positional parameters come first, then *args collects the rest
+				switch kinds[next] {
+				case graph.ParameterPositionalOnly, graph.ParameterNormal:
+					parameter = next
+					next++
+				case graph.ParameterVarPositional:
+					parameter = next
+				}
+			}
+		}
+		if parameter >= 0 {
+			bindings[i].Parameter = names[parameter]
+			bindings[i].ParameterKind = kinds[parameter]
+		}
+	}
+	return bindings
+}
+
+// keywordParameter returns the index of the parameter a keyword argument
+// binds, or -1.
+func keywordParameter(keyword string, names []string, kinds []graph.ParameterKind) int {
+	variadic := -1
+	for i, name := range names {
+		switch kinds[i] {
+		case graph.ParameterNormal, graph.ParameterKeywordOnly:
+			if name == keyword {
+				return i
+			}
+		case graph.ParameterVarKeyword:
+			variadic = i
+		}
+	}
+	return variadic
+}
+
+// keywordArgument returns the name of a name=value argument.
+func keywordArgument(value string) (string, bool) {
+	name, rest, ok := strings.Cut(value, "=")
+	name = strings.TrimSpace(name)
+	if !ok || strings.HasPrefix(rest, "=") || !pythonIdentifier.MatchString(name) {
+		return "", false
+	}
+	return name, true
+}
+
+// parameterName returns the name of a parameter as listed in
+// MethodArgumentsValue: "limit: int = 10" → "limit", "*args: str" → "args".
+func parameterName(param string) string {
+	name, _, _ := strings.Cut(param, "=")
+	name, _, _ = strings.Cut(name, ":")
+	return strings.TrimLeft(strings.TrimSpace(name), "*")
+}
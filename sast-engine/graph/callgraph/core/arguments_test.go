@@ -0,0 +1,66 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/stretchr/testify/assert"
+)
+
+// boundParameters returns the parameter each argument binds.
+func boundParameters(function *graph.Node, values ...string) []string {
+	args := make([]Argument, len(values))
+	for i, value := range values {
+		args[i] = Argument{Value: value, Position: i}
+	}
+	var parameters []string
+	for _, binding := range BindArguments(function, args) {
+		parameters = append(parameters, binding.Parameter)
+	}
+	return parameters
+}
+
+func TestBindArguments(t *testing.T) {
+	// def f(a, /, b, *, c)
+	function := &graph.Node{
+		MethodArgumentsValue: []string{"a", "b", "c"},
+		ParameterKinds:       []graph.ParameterKind{graph.ParameterPositionalOnly, graph.ParameterNormal, graph.ParameterKeywordOnly},
+	}
+
+	assert.Equal(t, []string{"a", "b", "c"}, boundParameters(function, "1", "2", "c=3"))
+	assert.Equal(t, []string{"a", "b", "c"}, boundParameters(function, "1", "b=2", "c=3"))
+	assert.Equal(t, []string{"a", "b", ""}, boundParameters(function, "1", "2", "3"), "c is keyword-only")
+	assert.Equal(t, []string{"b", ""}, boundParameters(function, "b=2", "a=1"), "a is positional-only")
+	assert.Equal(t, []string{"a", "b"}, boundParameters(function, "1", "x == y"), "a comparison binds by position")
+
+	bindings := BindArguments(function, []Argument{{Value: "1"}, {Value: "2"}, {Value: "c=3"}})
+	assert.Equal(t, graph.ParameterPositionalOnly, bindings[0].ParameterKind)
+	assert.Equal(t, graph.ParameterNormal, bindings[1].ParameterKind)
+	assert.Equal(t, graph.ParameterKeywordOnly, bindings[2].ParameterKind)
+}
+
+func TestBindArguments_Variadic(t *testing.T) {
+	// def log(self, level: int, *messages: str, sep: str = " ", **extra: str)
+	method := &graph.Node{
+		MethodArgumentsValue: []string{"self", "level: int", "*messages: str", `sep: str = " "`, "**extra: str"},
+		ParameterKinds: []graph.ParameterKind{
+			graph.ParameterNormal, graph.ParameterNormal, graph.ParameterVarPositional,
+			graph.ParameterKeywordOnly, graph.ParameterVarKeyword,
+		},
+	}
+
+	assert.Equal(t, []string{"level", "messages", "messages", "sep", "extra"},
+		boundParameters(method, "1", "a", "b", "sep='-'", "user=name"))
+	assert.Equal(t, []string{"", "level"}, boundParameters(method, "*parts", "level=2"),
+		"positions after *parts are unknown")
+
+	spread := BindArguments(method, []Argument{{Value: "**options", IsDictSpread: true}})
+	assert.Empty(t, spread[0].Parameter)
+}
+
+func TestBindArguments_WithoutKinds(t *testing.T) {
+	function := &graph.Node{MethodArgumentsValue: []string{"a", "b"}}
+
+	assert.Equal(t, []string{"a", "b", ""}, boundParameters(function, "1", "2", "3"))
+	assert.Equal(t, []string{"b", "a"}, boundParameters(function, "b=2", "a=1"))
+}
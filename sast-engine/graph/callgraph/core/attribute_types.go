@@ -27,4 +27,9 @@ type ClassAttributes struct {
 	Attributes map[string]*ClassAttribute    // Map from attribute name to attribute info
 	Methods    []string                      // List of method FQNs in this class
 	FilePath   string                        // Source file path where class is defined
+
+	// StoredParameters maps the __init__ parameters stored as is in an
+	// attribute (self.app = app) to the attribute's name, so that the
+	// attribute of an instance can be traced to a constructor argument.
+	StoredParameters map[string]string
 }
@@ -0,0 +1,174 @@
+package core
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+)
+
+// WeaklyConnectedComponents groups the functions of the call graph that are
+// connected by calls in either direction. Only edges between functions
+// defined in the graph count, so calls to a shared external function such
+// as builtins.print do not join components. Each component is sorted by FQN,
+// and components are ordered largest first, then by their first FQN.
+// A function without calls to or from other functions of the graph forms a
+// component of its own.
+func (cg *CallGraph) WeaklyConnectedComponents() [][]string {
+	parent := make(map[string]string, len(cg.Functions))
+	for fqn := range cg.Functions {
+		parent[fqn] = fqn
+	}
+	var find func(fqn string) string
+	find = func(fqn string) string {
+		if parent[fqn] != fqn {
+			parent[fqn] = find(parent[fqn])
+		}
+		return parent[fqn]
+	}
+	for caller, callees := range cg.Edges {
+		if _, ok := parent[caller]; !ok {
+			continue
+		}
+		for _, callee := range callees {
+			if _, ok := parent[callee]; !ok {
+				continue
+			}
+			// The smaller root wins, so roots do not depend on map order.
+			a, b := find(caller), find(callee)
+			if a > b {
+				a, b = b, a
+			}
+			parent[b] = a
+		}
+	}
+
+	groups := make(map[string][]string)
+	for _, fqn := range slices.Sorted(maps.Keys(parent)) {
+		root := find(fqn)
+		groups[root] = append(groups[root], fqn)
+	}
+	components := slices.Collect(maps.Values(groups))
+	slices.SortFunc(components, func(a, b []string) int {
+		if c := cmp.Compare(len(b), len(a)); c != 0 {
+			return c
+		}
+		return cmp.Compare(a[0], b[0])
+	})
+	return components
+}
+
+// StronglyConnectedComponents returns the groups of mutually recursive
+// functions: the strongly connected components of Edges that contain a
+// cycle, that is, more than one function, or one function calling itself.
+// Functions not on a cycle are left out. Each component is sorted by FQN,
+// and components are ordered largest first, then by their first FQN.
+func (cg *CallGraph) StronglyConnectedComponents() [][]string {
+	var components [][]string
+	for _, component := range stronglyConnectedComponents(cg.Edges) {
+		if len(component) > 1 || slices.Contains(cg.Edges[component[0]], component[0]) {
+			components = append(components, component)
+		}
+	}
+	slices.SortFunc(components, func(a, b []string) int {
+		if c := cmp.Compare(len(b), len(a)); c != 0 {
+			return c
+		}
+		return cmp.Compare(a[0], b[0])
+	})
+	return components
+}
+
+// stronglyConnectedComponents returns every strongly connected component of
+// the graph given by edges, single nodes included, each sorted. Components
+// come in reverse topological order: a component is listed after every
+// component it has an edge to. Nodes are visited in sorted order, so the
+// result is the same on every run.
+//
+// Tarjan's algorithm runs with an explicit stack rather than recursion, so
+// long chains do not overflow the goroutine stack.
+func stronglyConnectedComponents(edges map[string][]string) [][]string {
+	index := make(map[string]int)
+	lowLink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var components [][]string
+
+	// frame is a node being visited and the next of its successors to visit
+	type frame struct {
+		node string
+		next int
+	}
+	var frames []frame
+	enter := func(node string) {
+		index[node] = len(index)
+		lowLink[node] = index[node]
+		stack = append(stack, node)
+		onStack[node] = true
+		frames = append(frames, frame{node: node})
+	}
+
+	for _, root := range slices.Sorted(maps.Keys(edges)) {
+		if _, seen := index[root]; seen {
+			continue
+		}
+		enter(root)
+		for len(frames) > 0 {
+			top := &frames[len(frames)-1]
+			if successors := edges[top.node]; top.next < len(successors) {
+				successor := successors[top.next]
+				top.next++
+				if _, seen := index[successor]; !seen {
+					enter(successor)
+				} else if onStack[successor] {
+					lowLink[top.node] = min(lowLink[top.node], index[successor])
+				}
+				continue
+			}
+
+			// All successors visited: pass the low link up to the predecessor
+			node := top.node
+			frames = frames[:len(frames)-1]
+			if len(frames) > 0 {
+				predecessor := frames[len(frames)-1].node
+				lowLink[predecessor] = min(lowLink[predecessor], lowLink[node])
+			}
+			if lowLink[node] != index[node] {
+				continue
+			}
+
+			var component []string
+			for {
+				member := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[member] = false
+				component = append(component, member)
+				if member == node {
+					break
+				}
+			}
+			slices.Sort(component)
+			components = append(components, component)
+		}
+	}
+	return components
+}
+
+// HasCycle reports whether any function of the call graph can reach itself
+// through calls, directly or through other functions.
+func (cg *CallGraph) HasCycle() bool {
+	return len(cg.StronglyConnectedComponents()) > 0
+}
+
+// OrphanFunctions returns the functions of the call graph with neither
+// callers nor callees, external ones included, sorted by FQN. They are often
+// dead code, or reached only through calls that failed to resolve.
+func (cg *CallGraph) OrphanFunctions() []string {
+	var orphans []string
+	for fqn := range cg.Functions {
+		if len(cg.Edges[fqn]) == 0 && len(cg.ReverseEdges[fqn]) == 0 {
+			orphans = append(orphans, fqn)
+		}
+	}
+	slices.Sort(orphans)
+	return orphans
+}
@@ -0,0 +1,111 @@
+package core
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/stretchr/testify/assert"
+)
+
+// newIslandTestGraph returns the fan test graph with a disconnected island
+// of two functions calling each other and a function calling only print.
+func newIslandTestGraph() *CallGraph {
+	cg := newFanTestGraph()
+	for _, fqn := range []string{"jobs.run", "jobs.retry", "jobs.report"} {
+		cg.Functions[fqn] = &graph.Node{Name: fqn}
+	}
+	cg.AddEdge("jobs.run", "jobs.retry")
+	cg.AddEdge("jobs.retry", "jobs.run")
+	cg.AddEdge("jobs.report", "builtins.print")
+	cg.AddEdge("app.log", "builtins.print")
+	return cg
+}
+
+func TestCallGraph_WeaklyConnectedComponents(t *testing.T) {
+	components := newIslandTestGraph().WeaklyConnectedComponents()
+
+	assert.Equal(t, [][]string{
+		{"app.handle", "app.log", "app.main", "app.worker"},
+		{"jobs.retry", "jobs.run"},
+		{"app.idle"},
+		{"jobs.report"},
+	}, components, "a shared external callee does not join components")
+}
+
+func TestCallGraph_WeaklyConnectedComponents_Deterministic(t *testing.T) {
+	expected := newIslandTestGraph().WeaklyConnectedComponents()
+	for range 20 {
+		assert.Equal(t, expected, newIslandTestGraph().WeaklyConnectedComponents())
+	}
+}
+
+func TestCallGraph_StronglyConnectedComponents(t *testing.T) {
+	cg := newIslandTestGraph()
+	assert.Empty(t, newFanTestGraph().StronglyConnectedComponents(), "an acyclic graph has no recursive groups")
+
+	// A three-function cycle through an external callee, and direct recursion
+	cg.AddEdge("app.log", "app.format")
+	cg.AddEdge("app.format", "lib.render")
+	cg.AddEdge("lib.render", "app.log")
+	cg.AddEdge("app.idle", "app.idle")
+
+	assert.Equal(t, [][]string{
+		{"app.format", "app.log", "lib.render"},
+		{"jobs.retry", "jobs.run"},
+		{"app.idle"},
+	}, cg.StronglyConnectedComponents())
+}
+
+func TestCallGraph_StronglyConnectedComponents_Deterministic(t *testing.T) {
+	expected := newIslandTestGraph().StronglyConnectedComponents()
+	for range 20 {
+		assert.Equal(t, expected, newIslandTestGraph().StronglyConnectedComponents())
+	}
+}
+
+func TestCallGraph_StronglyConnectedComponents_DeepChain(t *testing.T) {
+	// A long call chain, visited without recursion
+	const depth = 100000
+	cg := NewCallGraph()
+	for i := range depth {
+		cg.Edges[fmt.Sprintf("f%d", i)] = []string{fmt.Sprintf("f%d", i+1)}
+	}
+	assert.Empty(t, cg.StronglyConnectedComponents())
+	assert.False(t, cg.HasCycle())
+
+	cg.Edges[fmt.Sprintf("f%d", depth)] = []string{"f0"}
+	components := cg.StronglyConnectedComponents()
+	assert.Len(t, components, 1)
+	assert.Len(t, components[0], depth+1)
+	assert.True(t, cg.HasCycle())
+}
+
+func TestStronglyConnectedComponents_ReverseTopologicalOrder(t *testing.T) {
+	// a -> {b, c} -> a, b -> d, d -> e -> d
+	edges := map[string][]string{
+		"a": {"b"},
+		"b": {"c", "d"},
+		"c": {"a"},
+		"d": {"e"},
+		"e": {"d"},
+	}
+	assert.Equal(t, [][]string{{"d", "e"}, {"a", "b", "c"}}, stronglyConnectedComponents(edges))
+
+	// Nodes that only appear as successors form components of their own
+	assert.Equal(t, [][]string{{"y"}, {"x"}}, stronglyConnectedComponents(map[string][]string{"x": {"y"}}))
+	assert.Empty(t, stronglyConnectedComponents(nil))
+}
+
+func TestCallGraph_HasCycle(t *testing.T) {
+	assert.False(t, newFanTestGraph().HasCycle())
+	assert.True(t, newIslandTestGraph().HasCycle())
+	assert.False(t, NewCallGraph().HasCycle())
+}
+
+func TestCallGraph_OrphanFunctions(t *testing.T) {
+	assert.Equal(t, []string{"app.idle"}, newIslandTestGraph().OrphanFunctions(),
+		"jobs.report calls an external function, so it is not an orphan")
+	assert.Empty(t, NewCallGraph().OrphanFunctions())
+	assert.Empty(t, NewCallGraph().WeaklyConnectedComponents())
+}
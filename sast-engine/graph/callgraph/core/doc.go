@@ -21,4 +21,28 @@
 //
 //	cg := core.NewCallGraph()
 //	cg.AddEdge("main.foo", "main.bar")
+//
+// IterFunctions and IterEdges stream the graph in a deterministic order
+// without copying it, and stop when the loop breaks:
+//
+//	for caller, callee := range cg.IterEdges {
+//	    fmt.Println(caller, "->", callee)
+//	}
+//
+// StronglyConnectedComponents groups mutually recursive functions, and
+// HasCycle reports whether there are any:
+//
+//	for _, group := range cg.StronglyConnectedComponents() {
+//	    fmt.Println("recursive:", group)
+//	}
+//
+// # Persistence
+//
+// WriteCallGraph saves a graph's functions, edges, call sites, and class
+// attributes as JSON with sorted keys, so files of two builds diff
+// cleanly; ReadCallGraph loads it back without rebuilding. Files carry
+// CallGraphSchemaVersion, and files of another version fail to load:
+//
+//	err := core.WriteCallGraph(f, cg)
+//	loaded, err := core.ReadCallGraph(f)
 package core
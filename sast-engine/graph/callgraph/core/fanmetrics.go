@@ -0,0 +1,60 @@
+package core
+
+import (
+	"cmp"
+	"slices"
+)
+
+// FanInfo holds the connectivity of a function in the call graph.
+// Functions with a high fan-out ("God functions") do too much; functions
+// with a high fan-in are widely depended on, so changing them is risky.
+type FanInfo struct {
+	FQN    string // Function FQN
+	FanIn  int    // Number of distinct callers
+	FanOut int    // Number of distinct callees
+}
+
+// FanMetrics returns the fan-in and fan-out of every function in the call
+// graph, keyed by FQN. Fan-in counts the callers in ReverseEdges and fan-out
+// the callees in Edges, both of which hold each caller-callee pair once.
+func (cg *CallGraph) FanMetrics() map[string]FanInfo {
+	metrics := make(map[string]FanInfo, len(cg.Functions))
+	for fqn := range cg.Functions {
+		metrics[fqn] = FanInfo{
+			FQN:    fqn,
+			FanIn:  len(cg.ReverseEdges[fqn]),
+			FanOut: len(cg.Edges[fqn]),
+		}
+	}
+	return metrics
+}
+
+// TopFanIn returns the n functions with the highest fan-in, highest first.
+// Ties are ordered by FQN. Returns all functions when n <= 0.
+func TopFanIn(metrics map[string]FanInfo, n int) []FanInfo {
+	return topFan(metrics, n, func(info FanInfo) int { return info.FanIn })
+}
+
+// TopFanOut returns the n functions with the highest fan-out, highest first.
+// Ties are ordered by FQN. Returns all functions when n <= 0.
+func TopFanOut(metrics map[string]FanInfo, n int) []FanInfo {
+	return topFan(metrics, n, func(info FanInfo) int { return info.FanOut })
+}
+
+// topFan sorts metrics by the count key selects, descending, and keeps n.
+func topFan(metrics map[string]FanInfo, n int, key func(FanInfo) int) []FanInfo {
+	infos := make([]FanInfo, 0, len(metrics))
+	for _, info := range metrics {
+		infos = append(infos, info)
+	}
+	slices.SortFunc(infos, func(a, b FanInfo) int {
+		if c := cmp.Compare(key(b), key(a)); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.FQN, b.FQN)
+	})
+	if n > 0 && n < len(infos) {
+		infos = infos[:n]
+	}
+	return infos
+}
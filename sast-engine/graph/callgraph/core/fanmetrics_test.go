@@ -0,0 +1,58 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/stretchr/testify/assert"
+)
+
+func newFanTestGraph() *CallGraph {
+	cg := NewCallGraph()
+	for _, fqn := range []string{"app.main", "app.handle", "app.worker", "app.log", "app.idle"} {
+		cg.Functions[fqn] = &graph.Node{Name: fqn}
+	}
+	cg.AddEdge("app.main", "app.handle")
+	cg.AddEdge("app.main", "app.worker")
+	cg.AddEdge("app.main", "app.log")
+	cg.AddEdge("app.main", "os.getenv")
+	cg.AddEdge("app.main", "app.log") // duplicate call, counted once
+	cg.AddEdge("app.handle", "app.log")
+	cg.AddEdge("app.worker", "app.log")
+	cg.AddEdge("app.worker", "app.handle")
+	return cg
+}
+
+func TestCallGraph_FanMetrics(t *testing.T) {
+	metrics := newFanTestGraph().FanMetrics()
+
+	assert.Len(t, metrics, 5, "only functions defined in the graph are measured")
+	assert.Equal(t, FanInfo{FQN: "app.main", FanIn: 0, FanOut: 4}, metrics["app.main"])
+	assert.Equal(t, FanInfo{FQN: "app.handle", FanIn: 2, FanOut: 1}, metrics["app.handle"])
+	assert.Equal(t, FanInfo{FQN: "app.worker", FanIn: 1, FanOut: 2}, metrics["app.worker"])
+	assert.Equal(t, FanInfo{FQN: "app.log", FanIn: 3, FanOut: 0}, metrics["app.log"])
+	assert.Equal(t, FanInfo{FQN: "app.idle", FanIn: 0, FanOut: 0}, metrics["app.idle"])
+	assert.NotContains(t, metrics, "os.getenv")
+}
+
+func TestTopFanInOut(t *testing.T) {
+	metrics := newFanTestGraph().FanMetrics()
+
+	top := TopFanIn(metrics, 2)
+	assert.Equal(t, []string{"app.log", "app.handle"}, fanFQNs(top))
+
+	top = TopFanOut(metrics, 3)
+	assert.Equal(t, []string{"app.main", "app.worker", "app.handle"}, fanFQNs(top))
+
+	// Ties are broken by FQN, and n <= 0 returns everything.
+	assert.Equal(t, []string{"app.log", "app.handle", "app.worker", "app.idle", "app.main"}, fanFQNs(TopFanIn(metrics, 0)))
+	assert.Len(t, TopFanOut(metrics, 10), 5)
+}
+
+func fanFQNs(infos []FanInfo) []string {
+	fqns := make([]string, 0, len(infos))
+	for _, info := range infos {
+		fqns = append(fqns, info.FQN)
+	}
+	return fqns
+}
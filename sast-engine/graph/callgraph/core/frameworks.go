@@ -67,6 +67,26 @@ var builtinFrameworks = []FrameworkDefinition{
 		Category:    "web",
 	},
 
+	// Template Engines
+	{
+		Name:        "Jinja2",
+		Prefixes:    []string{"jinja2."},
+		Description: "Jinja2 template engine",
+		Category:    "template",
+	},
+	{
+		Name:        "Mako",
+		Prefixes:    []string{"mako."},
+		Description: "Mako template engine",
+		Category:    "template",
+	},
+	{
+		Name:        "Chameleon",
+		Prefixes:    []string{"chameleon."},
+		Description: "Chameleon page templates",
+		Category:    "template",
+	},
+
 	// ORM and Database
 	{
 		Name:        "SQLAlchemy",
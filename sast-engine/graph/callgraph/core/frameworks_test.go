@@ -192,6 +192,27 @@ func TestIsKnownFramework_DataScience(t *testing.T) {
 	}
 }
 
+func TestIsKnownFramework_TemplateEngines(t *testing.T) {
+	tests := []struct {
+		name      string
+		fqn       string
+		framework string
+	}{
+		{"jinja2", "jinja2.Environment", "Jinja2"},
+		{"mako", "mako.template.Template.render", "Mako"},
+		{"chameleon", "chameleon.PageTemplate", "Chameleon"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isKnown, framework := IsKnownFramework(tt.fqn)
+			assert.True(t, isKnown)
+			assert.Equal(t, tt.framework, framework.Name)
+			assert.Equal(t, "template", framework.Category)
+		})
+	}
+}
+
 func TestIsKnownFramework_Stdlib(t *testing.T) {
 	tests := []struct {
 		name     string
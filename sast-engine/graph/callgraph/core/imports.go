@@ -0,0 +1,34 @@
+package core
+
+import (
+	"slices"
+)
+
+// AddModuleImport records that module imports imported. Self-imports and
+// duplicates are ignored.
+func (cg *CallGraph) AddModuleImport(module, imported string) {
+	if module == "" || imported == "" || module == imported {
+		return
+	}
+	if cg.ModuleImports == nil {
+		cg.ModuleImports = make(map[string][]string)
+	}
+	if !slices.Contains(cg.ModuleImports[module], imported) {
+		cg.ModuleImports[module] = append(cg.ModuleImports[module], imported)
+	}
+}
+
+// CircularImports returns the import cycles in ModuleImports. Each cycle is
+// a strongly connected component of the import graph with more than one
+// module, listed in sorted order; a.py importing b.py importing a.py yields
+// [["a", "b"]]. Cycles are ordered by their first module.
+func (cg *CallGraph) CircularImports() [][]string {
+	var cycles [][]string
+	for _, component := range stronglyConnectedComponents(cg.ModuleImports) {
+		if len(component) > 1 {
+			cycles = append(cycles, component)
+		}
+	}
+	slices.SortFunc(cycles, func(a, b []string) int { return slices.Compare(a, b) })
+	return cycles
+}
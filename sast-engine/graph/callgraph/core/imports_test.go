@@ -0,0 +1,52 @@
+package core
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCallGraph_AddModuleImport(t *testing.T) {
+	cg := NewCallGraph()
+	cg.AddModuleImport("app.views", "app.models")
+	cg.AddModuleImport("app.views", "app.models")
+	cg.AddModuleImport("app.views", "app.views")
+	cg.AddModuleImport("", "app.models")
+
+	assert.Equal(t, map[string][]string{"app.views": {"app.models"}}, cg.ModuleImports)
+}
+
+func TestCallGraph_CircularImports(t *testing.T) {
+	cg := NewCallGraph()
+	assert.Empty(t, cg.CircularImports())
+
+	// a -> b -> a, and c -> d -> e -> c, with f importing into the first cycle
+	cg.AddModuleImport("pkg.b", "pkg.a")
+	cg.AddModuleImport("pkg.a", "pkg.b")
+	cg.AddModuleImport("pkg.e", "pkg.c")
+	cg.AddModuleImport("pkg.c", "pkg.d")
+	cg.AddModuleImport("pkg.d", "pkg.e")
+	cg.AddModuleImport("pkg.f", "pkg.a")
+	cg.AddModuleImport("pkg.a", "pkg.utils")
+
+	assert.Equal(t, [][]string{
+		{"pkg.a", "pkg.b"},
+		{"pkg.c", "pkg.d", "pkg.e"},
+	}, cg.CircularImports())
+}
+
+func TestCallGraph_CircularImports_DeepChain(t *testing.T) {
+	// A long import chain closed into one cycle, visited without recursion
+	const depth = 100000
+	cg := NewCallGraph()
+	for i := range depth {
+		cg.AddModuleImport(fmt.Sprintf("m%d", i), fmt.Sprintf("m%d", i+1))
+	}
+	assert.Empty(t, cg.CircularImports())
+
+	cg.AddModuleImport(fmt.Sprintf("m%d", depth), "m0")
+	cycles := cg.CircularImports()
+	assert.Len(t, cycles, 1)
+	assert.Len(t, cycles[0], depth+1)
+}
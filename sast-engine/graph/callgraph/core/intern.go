@@ -0,0 +1,38 @@
+package core
+
+// StringPool interns strings so equal strings share one backing array.
+// Call resolution builds a fresh FQN string for every call site, so on large
+// projects the same caller and callee names are stored thousands of times
+// across Edges, ReverseEdges, and CallSites; interning keeps one copy each.
+//
+// A nil *StringPool is valid and returns strings unchanged. Like CallGraph,
+// a StringPool is not safe for concurrent use.
+type StringPool struct {
+	strings map[string]string
+}
+
+// NewStringPool creates an empty string pool.
+func NewStringPool() *StringPool {
+	return &StringPool{strings: make(map[string]string)}
+}
+
+// Intern returns the pooled copy of s, adding s to the pool if it is the
+// first string with its value.
+func (p *StringPool) Intern(s string) string {
+	if p == nil || s == "" {
+		return s
+	}
+	if pooled, ok := p.strings[s]; ok {
+		return pooled
+	}
+	p.strings[s] = s
+	return s
+}
+
+// Len returns the number of distinct strings in the pool.
+func (p *StringPool) Len() int {
+	if p == nil {
+		return 0
+	}
+	return len(p.strings)
+}
@@ -0,0 +1,149 @@
+package core
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sameStorage reports whether two strings share a backing array.
+func sameStorage(a, b string) bool {
+	return unsafe.StringData(a) == unsafe.StringData(b)
+}
+
+func TestStringPool_Intern(t *testing.T) {
+	pool := NewStringPool()
+
+	first := pool.Intern(strings.Clone("myapp.views.index"))
+	second := pool.Intern(strings.Clone("myapp.views.index"))
+	other := pool.Intern("myapp.views.detail")
+
+	assert.Equal(t, "myapp.views.index", second)
+	assert.True(t, sameStorage(first, second))
+	assert.False(t, sameStorage(first, other))
+	assert.Equal(t, 2, pool.Len())
+
+	assert.Equal(t, "", pool.Intern(""))
+	assert.Equal(t, 2, pool.Len())
+}
+
+func TestStringPool_Nil(t *testing.T) {
+	var pool *StringPool
+	s := strings.Clone("myapp.views.index")
+
+	assert.True(t, sameStorage(s, pool.Intern(s)))
+	assert.Equal(t, 0, pool.Len())
+}
+
+func TestCallGraph_InternsFQNs(t *testing.T) {
+	cg := NewCallGraph()
+
+	for _, caller := range []string{"app.a", "app.b"} {
+		callee := strings.Clone("app.utils.helper")
+		cg.AddEdge(strings.Clone(caller), callee)
+		cg.AddCallSite(strings.Clone(caller), CallSite{
+			Target:    strings.Clone("helper"),
+			TargetFQN: callee,
+			Location:  Location{File: strings.Clone("/src/app.py")},
+		})
+	}
+
+	a, b := cg.CallSites["app.a"][0], cg.CallSites["app.b"][0]
+	assert.Equal(t, a.TargetFQN, b.TargetFQN)
+	assert.True(t, sameStorage(a.TargetFQN, b.TargetFQN))
+	assert.True(t, sameStorage(a.Target, b.Target))
+	assert.True(t, sameStorage(a.Location.File, b.Location.File))
+	assert.True(t, sameStorage(cg.Edges["app.a"][0], cg.Edges["app.b"][0]))
+	assert.True(t, sameStorage(cg.Edges["app.a"][0], a.TargetFQN))
+
+	// Interning is transparent to lookups
+	assert.Equal(t, []string{"app.a", "app.b"}, cg.GetCallers("app.utils.helper"))
+	assert.Equal(t, []string{"app.utils.helper"}, cg.GetCallees("app.b"))
+}
+
+// addScaledCalls fills cg with callers × calls call sites and edges, building
+// every name afresh the way call resolution does. Targets repeat across
+// callers, as imported helpers do in a real project.
+func addScaledCalls(cg *CallGraph, callers, calls int) {
+	for i := range callers {
+		caller := fmt.Sprintf("project.package%d.module%d.function%d", i%10, i%100, i)
+		for j := range calls {
+			targetFQN := fmt.Sprintf("project.package%d.utils.helper_function_%d", j%10, j)
+			cg.AddCallSite(caller, CallSite{
+				Target:    fmt.Sprintf("utils.helper_function_%d", j),
+				TargetFQN: targetFQN,
+				Location:  Location{File: fmt.Sprintf("/src/project/package%d/module%d.py", i%10, i%100), Line: j + 1},
+				Resolved:  true,
+			})
+			cg.AddEdge(caller, targetFQN)
+		}
+	}
+}
+
+// newUninternedCallGraph returns an empty graph without a string pool.
+func newUninternedCallGraph() *CallGraph {
+	return &CallGraph{
+		Edges:        make(map[string][]string),
+		ReverseEdges: make(map[string][]string),
+		CallSites:    make(map[string][]CallSite),
+	}
+}
+
+// retainedBytes returns the heap still in use after build returns.
+func retainedBytes(build func() *CallGraph) uint64 {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	cg := build()
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	runtime.KeepAlive(cg)
+	if after.HeapAlloc < before.HeapAlloc {
+		return 0
+	}
+	return after.HeapAlloc - before.HeapAlloc
+}
+
+func TestCallGraph_InterningReducesRetainedMemory(t *testing.T) {
+	build := func(cg *CallGraph) func() *CallGraph {
+		return func() *CallGraph {
+			addScaledCalls(cg, 1000, 50)
+			return cg
+		}
+	}
+
+	interned := retainedBytes(build(NewCallGraph()))
+	plain := retainedBytes(build(newUninternedCallGraph()))
+
+	t.Logf("retained: interned=%d plain=%d", interned, plain)
+	assert.Less(t, interned, plain*9/10)
+}
+
+// BenchmarkCallGraph_AddCallSite measures building a scaled-up call graph
+// with and without interning; retained-B/op is the heap the graph keeps.
+func BenchmarkCallGraph_AddCallSite(b *testing.B) {
+	for _, bc := range []struct {
+		name string
+		new  func() *CallGraph
+	}{
+		{"interned", NewCallGraph},
+		{"plain", newUninternedCallGraph},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			var retained uint64
+			for b.Loop() {
+				retained += retainedBytes(func() *CallGraph {
+					cg := bc.new()
+					addScaledCalls(cg, 1000, 50)
+					return cg
+				})
+			}
+			b.ReportMetric(float64(retained)/float64(b.N), "retained-B/op")
+		})
+	}
+}
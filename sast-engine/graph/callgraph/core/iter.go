@@ -0,0 +1,44 @@
+package core
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+)
+
+// IterFunctions calls yield with each function of the call graph, ordered
+// by FQN, until yield returns false. It is a range-over-func iterator:
+//
+//	for fqn, node := range cg.IterFunctions {
+//	    if node.File == target {
+//	        break
+//	    }
+//	}
+//
+// Only the FQNs are collected, to sort them; use it rather than copying
+// Functions when a consumer may stop early or filters as it goes.
+func (cg *CallGraph) IterFunctions(yield func(fqn string, node *graph.Node) bool) {
+	for _, fqn := range slices.Sorted(maps.Keys(cg.Functions)) {
+		if !yield(fqn, cg.Functions[fqn]) {
+			return
+		}
+	}
+}
+
+// IterEdges calls yield with each edge of the call graph, ordered by caller
+// FQN and then callee FQN, until yield returns false. Like IterFunctions, it
+// is a range-over-func iterator:
+//
+//	for caller, callee := range cg.IterEdges {
+//	    fmt.Println(caller, "->", callee)
+//	}
+func (cg *CallGraph) IterEdges(yield func(caller, callee string) bool) {
+	for _, caller := range slices.Sorted(maps.Keys(cg.Edges)) {
+		for _, callee := range slices.Sorted(slices.Values(cg.Edges[caller])) {
+			if !yield(caller, callee) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCallGraph_IterFunctions(t *testing.T) {
+	cg := newFanTestGraph()
+
+	var all []string
+	for fqn, node := range cg.IterFunctions {
+		assert.Same(t, cg.Functions[fqn], node)
+		all = append(all, fqn)
+	}
+	assert.Equal(t, []string{"app.handle", "app.idle", "app.log", "app.main", "app.worker"}, all)
+
+	var visited []string
+	for fqn := range cg.IterFunctions {
+		if fqn == "app.main" {
+			break
+		}
+		visited = append(visited, fqn)
+	}
+	assert.Equal(t, []string{"app.handle", "app.idle", "app.log"}, visited)
+}
+
+func TestCallGraph_IterEdges(t *testing.T) {
+	cg := newFanTestGraph()
+
+	var all [][2]string
+	for caller, callee := range cg.IterEdges {
+		all = append(all, [2]string{caller, callee})
+	}
+	assert.Equal(t, [][2]string{
+		{"app.handle", "app.log"},
+		{"app.main", "app.handle"},
+		{"app.main", "app.log"},
+		{"app.main", "app.worker"},
+		{"app.main", "os.getenv"},
+		{"app.worker", "app.handle"},
+		{"app.worker", "app.log"},
+	}, all)
+
+	// Stop within a caller's callees
+	var visited [][2]string
+	for caller, callee := range cg.IterEdges {
+		visited = append(visited, [2]string{caller, callee})
+		if callee == "app.log" && caller == "app.main" {
+			break
+		}
+	}
+	assert.Equal(t, all[:3], visited)
+
+	var none int
+	for range NewCallGraph().IterEdges {
+		none++
+	}
+	assert.Zero(t, none)
+}
@@ -0,0 +1,41 @@
+package core
+
+import "strings"
+
+// Languages of the functions of a call graph.
+const (
+	LanguagePython = "python"
+	LanguageGo     = "go"
+)
+
+// languageSeparator ends the language namespace of an FQN.
+const languageSeparator = ":"
+
+// NamespacedFQN returns fqn in the namespace of language, as in
+// "go:github.com/example/app.main". Python FQNs are not namespaced, so a
+// graph combining languages keeps the FQNs the Python patterns match.
+func NamespacedFQN(language, fqn string) string {
+	if language == LanguagePython {
+		return fqn
+	}
+	return language + languageSeparator + fqn
+}
+
+// SplitLanguage returns the language of an FQN and the FQN without its
+// namespace (see NamespacedFQN). FQNs without a namespace are Python's.
+func SplitLanguage(fqn string) (language, name string) {
+	if language, name, ok := strings.Cut(fqn, languageSeparator); ok && !strings.ContainsAny(language, "./") {
+		return language, name
+	}
+	return LanguagePython, fqn
+}
+
+// ExternalEdge is a call that runs code of another language, e.g.
+// subprocess.run(["go", "run", "./cmd/worker"]) in Python.
+type ExternalEdge struct {
+	Caller   string   // FQN of the calling function
+	Language string   // Language of the code run
+	Command  string   // Program or script run, as written in the call
+	Target   string   // FQN of its entry point, "" if not in the graph
+	Location Location // Where the call occurs
+}
@@ -0,0 +1,30 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamespacedFQN(t *testing.T) {
+	assert.Equal(t, "go:github.com/example/app.main", NamespacedFQN(LanguageGo, "github.com/example/app.main"))
+	assert.Equal(t, "app.views.index", NamespacedFQN(LanguagePython, "app.views.index"))
+}
+
+func TestSplitLanguage(t *testing.T) {
+	tests := []struct {
+		fqn      string
+		language string
+		name     string
+	}{
+		{"go:github.com/example/app.main", LanguageGo, "github.com/example/app.main"},
+		{"go:fmt.Println", LanguageGo, "fmt.Println"},
+		{"app.views.index", LanguagePython, "app.views.index"},
+		{"github.com/example/app.main", LanguagePython, "github.com/example/app.main"},
+	}
+	for _, tt := range tests {
+		language, name := SplitLanguage(tt.fqn)
+		assert.Equal(t, tt.language, language, tt.fqn)
+		assert.Equal(t, tt.name, name, tt.fqn)
+	}
+}
@@ -0,0 +1,131 @@
+package core
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// mermaidUnresolvedClass is the Mermaid class applied to nodes for calls
+// that could not be resolved to a definition.
+const mermaidUnresolvedClass = "unresolved"
+
+// mermaidIDs assigns Mermaid node ids to graph nodes. Ids are FQNs reduced
+// to [A-Za-z0-9_], prefixed so they never start with a digit or collide
+// with keywords like "end", and suffixed when two nodes sanitize alike.
+type mermaidIDs struct {
+	ids  map[string]string
+	used map[string]bool
+}
+
+func newMermaidIDs() *mermaidIDs {
+	return &mermaidIDs{ids: make(map[string]string), used: make(map[string]bool)}
+}
+
+// id returns the node id for prefix+name, allocating one on first use.
+// Resolved and unresolved nodes use different prefixes, so a call target
+// that failed to resolve never shares a node with a function of that name.
+func (m *mermaidIDs) id(prefix, name string) string {
+	key := prefix + name
+	if id, ok := m.ids[key]; ok {
+		return id
+	}
+	var b strings.Builder
+	for _, r := range key {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	id := b.String()
+	for n := 2; m.used[id]; n++ {
+		id = fmt.Sprintf("%s_%d", b.String(), n)
+	}
+	m.ids[key] = id
+	m.used[id] = true
+	return id
+}
+
+// mermaidLabel quotes text as a Mermaid node label.
+func mermaidLabel(text string) string {
+	return `"` + strings.ReplaceAll(text, `"`, "#quot;") + `"`
+}
+
+// ToMermaid writes the call graph as a Mermaid "graph TD" flowchart, for
+// embedding in Markdown. Nodes are labelled with their FQNs; resolved calls
+// are solid edges, and unresolved call sites are dashed edges to nodes
+// styled with the "unresolved" class. Output is sorted, so the same graph
+// always renders the same text. A Subgraph renders just its neighborhood.
+func (cg *CallGraph) ToMermaid(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	ids := newMermaidIDs()
+
+	nodes := make(map[string]bool)
+	for fqn := range cg.Functions {
+		nodes[fqn] = true
+	}
+	for caller, callees := range cg.Edges {
+		nodes[caller] = true
+		for _, callee := range callees {
+			nodes[callee] = true
+		}
+	}
+	unresolved := make(map[string][]string)
+	for caller, callSites := range cg.CallSites {
+		for _, callSite := range callSites {
+			if !callSite.Resolved && callSite.Target != "" && !slices.Contains(unresolved[caller], callSite.Target) {
+				nodes[caller] = true
+				unresolved[caller] = append(unresolved[caller], callSite.Target)
+			}
+		}
+	}
+
+	fmt.Fprintln(bw, "graph TD")
+	fmt.Fprintf(bw, "    classDef %s stroke-dasharray: 5 5,color:#888\n", mermaidUnresolvedClass)
+
+	sortedNodes := sortedKeys(nodes)
+	for _, fqn := range sortedNodes {
+		fmt.Fprintf(bw, "    %s[%s]\n", ids.id("n_", fqn), mermaidLabel(fqn))
+	}
+
+	var unresolvedIDs []string
+	for _, caller := range sortedKeys(unresolved) {
+		targets := slices.Sorted(slices.Values(unresolved[caller]))
+		for _, target := range targets {
+			if _, seen := ids.ids["u_"+target]; !seen {
+				id := ids.id("u_", target)
+				unresolvedIDs = append(unresolvedIDs, id)
+				fmt.Fprintf(bw, "    %s[%s]\n", id, mermaidLabel(target))
+			}
+		}
+	}
+
+	for _, caller := range sortedNodes {
+		callees := slices.Sorted(slices.Values(cg.Edges[caller]))
+		for _, callee := range callees {
+			fmt.Fprintf(bw, "    %s --> %s\n", ids.id("n_", caller), ids.id("n_", callee))
+		}
+		targets := slices.Sorted(slices.Values(unresolved[caller]))
+		for _, target := range targets {
+			fmt.Fprintf(bw, "    %s -.-> %s\n", ids.id("n_", caller), ids.id("u_", target))
+		}
+	}
+
+	if len(unresolvedIDs) > 0 {
+		fmt.Fprintf(bw, "    class %s %s\n", strings.Join(unresolvedIDs, ","), mermaidUnresolvedClass)
+	}
+	return bw.Flush()
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
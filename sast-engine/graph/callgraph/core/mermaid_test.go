@@ -0,0 +1,107 @@
+package core
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mermaidLine matches the statements ToMermaid emits: the header, classDef
+// and class statements, node declarations, and solid or dashed edges.
+var mermaidLine = regexp.MustCompile(
+	`^(graph TD|    classDef \w+ .+|    class [\w,]+ \w+|    \w+\["[^"]*"\]|    \w+ (-->|-\.->) \w+)$`)
+
+func newMermaidTestGraph() *CallGraph {
+	cg := NewCallGraph()
+	for _, fqn := range []string{"app.views.index", "app.views.end", "app.utils.clean", "app.utils.load", "app.db.query"} {
+		cg.Functions[fqn] = &graph.Node{Name: fqn}
+	}
+	cg.AddEdge("app.views.index", "app.utils.clean")
+	cg.AddEdge("app.views.index", "app.utils.load")
+	cg.AddEdge("app.utils.load", "app.db.query")
+	cg.AddEdge("app.views.end", "app.utils.clean")
+	cg.AddCallSite("app.views.index", CallSite{Target: "render", Resolved: false})
+	cg.AddCallSite("app.views.index", CallSite{Target: "app.utils.clean", TargetFQN: "app.utils.clean", Resolved: true})
+	cg.AddCallSite("app.utils.load", CallSite{Target: `cache["key"].get`, Resolved: false})
+	return cg
+}
+
+func renderMermaid(t *testing.T, cg *CallGraph) []string {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, cg.ToMermaid(&buf))
+	return strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+}
+
+func countEdges(lines []string, arrow string) int {
+	count := 0
+	for _, line := range lines {
+		if strings.Contains(line, " "+arrow+" ") {
+			count++
+		}
+	}
+	return count
+}
+
+func TestCallGraph_ToMermaid(t *testing.T) {
+	lines := renderMermaid(t, newMermaidTestGraph())
+
+	require.NotEmpty(t, lines)
+	assert.Equal(t, "graph TD", lines[0])
+	for _, line := range lines {
+		assert.Regexp(t, mermaidLine, line)
+	}
+
+	assert.Equal(t, 4, countEdges(lines, "-->"))
+	assert.Equal(t, 2, countEdges(lines, "-.->"))
+	assert.Contains(t, lines, `    n_app_views_index["app.views.index"]`)
+	assert.Contains(t, lines, `    n_app_views_index --> n_app_utils_clean`)
+	assert.Contains(t, lines, `    n_app_views_index -.-> u_render`)
+	assert.Contains(t, lines, `    u_cache__key___get["cache[#quot;key#quot;].get"]`)
+	assert.Contains(t, lines, `    class u_cache__key___get,u_render unresolved`)
+}
+
+func TestCallGraph_ToMermaid_Deterministic(t *testing.T) {
+	first := renderMermaid(t, newMermaidTestGraph())
+	for range 5 {
+		assert.Equal(t, first, renderMermaid(t, newMermaidTestGraph()))
+	}
+}
+
+func TestCallGraph_ToMermaid_IDCollisions(t *testing.T) {
+	cg := NewCallGraph()
+	cg.AddEdge("app.run", "app_run")
+	cg.AddCallSite("app.run", CallSite{Target: "app.run", Resolved: false})
+
+	lines := renderMermaid(t, cg)
+	assert.Contains(t, lines, `    n_app_run["app.run"]`)
+	assert.Contains(t, lines, `    n_app_run_2["app_run"]`)
+	assert.Contains(t, lines, `    u_app_run["app.run"]`)
+	assert.Contains(t, lines, `    n_app_run --> n_app_run_2`)
+	assert.Contains(t, lines, `    n_app_run -.-> u_app_run`)
+}
+
+func TestCallGraph_Subgraph(t *testing.T) {
+	cg := newMermaidTestGraph()
+
+	sub := cg.Subgraph("app.utils.load", 1)
+	assert.ElementsMatch(t, []string{"app.utils.load", "app.views.index", "app.db.query"}, sortedKeys(sub.Functions))
+	assert.ElementsMatch(t, []string{"app.utils.load"}, sub.GetCallees("app.views.index"))
+	assert.Len(t, sub.CallSites["app.views.index"], 1, "resolved call to app.utils.clean leaves the neighborhood")
+
+	lines := renderMermaid(t, sub)
+	for _, line := range lines {
+		assert.Regexp(t, mermaidLine, line)
+	}
+	assert.Equal(t, 2, countEdges(lines, "-->"))
+	assert.Equal(t, 2, countEdges(lines, "-.->"))
+
+	assert.Len(t, cg.Subgraph("app.utils.load", 2).Functions, 4)
+	assert.Len(t, cg.Subgraph("app.utils.load", 3).Functions, 5)
+	assert.Empty(t, cg.Subgraph("app.missing", 2).Functions)
+}
@@ -0,0 +1,236 @@
+package core
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+)
+
+// CallGraphSchemaVersion is the version of the format WriteCallGraph
+// writes. It changes whenever a file of the previous version would be read
+// differently; ReadCallGraph rejects other versions.
+const CallGraphSchemaVersion = 1
+
+// callGraphFile is the JSON document of a persisted call graph.
+type callGraphFile struct {
+	SchemaVersion int                         `json:"schema_version"`
+	Functions     map[string]*functionRecord  `json:"functions"`
+	Edges         map[string][]string         `json:"edges"`
+	ReverseEdges  map[string][]string         `json:"reverse_edges"`
+	CallSites     map[string][]CallSite       `json:"call_sites"`
+	Attributes    map[string]*ClassAttributes `json:"attributes,omitempty"`
+}
+
+// functionRecord holds the fields of a function node a call graph's users
+// read. graph.Node as a whole does not serialize: its AST fields link
+// nodes to each other.
+type functionRecord struct {
+	ID                   string                `json:"id,omitempty"`
+	Type                 string                `json:"type,omitempty"`
+	Name                 string                `json:"name"`
+	File                 string                `json:"file,omitempty"`
+	LineNumber           uint32                `json:"line,omitempty"`
+	SourceLocation       *graph.SourceLocation `json:"source_location,omitempty"`
+	Language             string                `json:"language,omitempty"`
+	IsExternal           bool                  `json:"is_external,omitempty"`
+	Modifier             string                `json:"modifier,omitempty"`
+	ReturnType           string                `json:"return_type,omitempty"`
+	MethodArgumentsType  []string              `json:"argument_types,omitempty"`
+	MethodArgumentsValue []string              `json:"arguments,omitempty"`
+	ParameterKinds       []graph.ParameterKind `json:"parameter_kinds,omitempty"`
+	TypeParameters       []string              `json:"type_parameters,omitempty"`
+	PackageName          string                `json:"package,omitempty"`
+	SuperClass           string                `json:"super_class,omitempty"`
+	Interface            []string              `json:"interfaces,omitempty"`
+	Annotation           []string              `json:"annotations,omitempty"`
+	Routes               []graph.Route         `json:"routes,omitempty"`
+	Scope                string                `json:"scope,omitempty"`
+	Metadata             map[string]any        `json:"metadata,omitempty"`
+}
+
+// attributeLister is an AttributeProvider that can list its classes, as
+// registry.AttributeRegistry and AttributeMap do.
+type attributeLister interface {
+	AttributeProvider
+	GetAllClasses() []string
+}
+
+// AttributeMap is an AttributeProvider over class attributes keyed by class
+// FQN. ReadCallGraph restores a graph's attributes as one.
+type AttributeMap map[string]*ClassAttributes
+
+// GetClassAttributes returns the attributes of a class, or nil.
+func (m AttributeMap) GetClassAttributes(classFQN string) *ClassAttributes {
+	return m[classFQN]
+}
+
+// GetAllClasses returns the FQNs of the classes, sorted.
+func (m AttributeMap) GetAllClasses() []string {
+	return sortedKeys(m)
+}
+
+// WriteCallGraph writes the functions, edges, reverse edges, call sites,
+// and class attributes of cg as JSON, for ReadCallGraph to load without
+// rebuilding. The output is deterministic, so two graphs can be diffed:
+// keys are sorted, edge lists are sorted, and each function's call sites
+// are in source order. Other fields (statements, summaries, CFGs, type
+// engines) are not written.
+func WriteCallGraph(w io.Writer, cg *CallGraph) error {
+	file := callGraphFile{
+		SchemaVersion: CallGraphSchemaVersion,
+		Functions:     make(map[string]*functionRecord, len(cg.Functions)),
+		Edges:         sortedLists(cg.Edges),
+		ReverseEdges:  sortedLists(cg.ReverseEdges),
+		CallSites:     make(map[string][]CallSite, len(cg.CallSites)),
+	}
+	for fqn, node := range cg.Functions {
+		if node != nil {
+			file.Functions[fqn] = newFunctionRecord(node)
+		}
+	}
+	for caller, callSites := range cg.CallSites {
+		sorted := slices.Clone(callSites)
+		slices.SortStableFunc(sorted, func(a, b CallSite) int {
+			return cmp.Or(
+				cmp.Compare(a.Location.File, b.Location.File),
+				cmp.Compare(a.Location.Line, b.Location.Line),
+				cmp.Compare(a.Location.Column, b.Location.Column),
+				cmp.Compare(a.Target, b.Target),
+			)
+		})
+		file.CallSites[caller] = sorted
+	}
+	if attributes, ok := cg.Attributes.(attributeLister); ok {
+		file.Attributes = make(map[string]*ClassAttributes)
+		for _, classFQN := range attributes.GetAllClasses() {
+			if classAttributes := attributes.GetClassAttributes(classFQN); classAttributes != nil {
+				file.Attributes[classFQN] = classAttributes
+			}
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(file)
+}
+
+// ReadCallGraph loads a call graph written by WriteCallGraph. Its class
+// attributes, if any, are restored as an AttributeMap. Files of another
+// schema version are rejected, to be rebuilt, rather than misread.
+func ReadCallGraph(r io.Reader) (*CallGraph, error) {
+	var version struct {
+		SchemaVersion int `json:"schema_version"`
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading call graph: %w", err)
+	}
+	if err := json.Unmarshal(data, &version); err != nil {
+		return nil, fmt.Errorf("decoding call graph: %w", err)
+	}
+	if version.SchemaVersion != CallGraphSchemaVersion {
+		return nil, fmt.Errorf("call graph schema version %d is not supported (want %d); rebuild the call graph", version.SchemaVersion, CallGraphSchemaVersion)
+	}
+
+	var file callGraphFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("decoding call graph: %w", err)
+	}
+
+	cg := NewCallGraph()
+	for fqn, record := range file.Functions {
+		if record != nil {
+			cg.AddFunction(fqn, record.node())
+		}
+	}
+	for caller, callees := range file.Edges {
+		cg.Edges[cg.fqns.Intern(caller)] = cg.internAll(callees)
+	}
+	for callee, callers := range file.ReverseEdges {
+		cg.ReverseEdges[cg.fqns.Intern(callee)] = cg.internAll(callers)
+	}
+	for caller, callSites := range file.CallSites {
+		for _, callSite := range callSites {
+			cg.AddCallSite(caller, callSite)
+		}
+	}
+	if file.Attributes != nil {
+		cg.Attributes = AttributeMap(file.Attributes)
+	}
+	return cg, nil
+}
+
+// internAll interns each name of a list in place.
+func (cg *CallGraph) internAll(names []string) []string {
+	for i, name := range names {
+		names[i] = cg.fqns.Intern(name)
+	}
+	return names
+}
+
+// sortedLists returns a copy of lists with each list sorted.
+func sortedLists(lists map[string][]string) map[string][]string {
+	sorted := make(map[string][]string, len(lists))
+	for key, list := range lists {
+		sorted[key] = slices.Sorted(slices.Values(list))
+	}
+	return sorted
+}
+
+// newFunctionRecord copies the persisted fields of a function node.
+func newFunctionRecord(node *graph.Node) *functionRecord {
+	return &functionRecord{
+		ID:                   node.ID,
+		Type:                 node.Type,
+		Name:                 node.Name,
+		File:                 node.File,
+		LineNumber:           node.LineNumber,
+		SourceLocation:       node.SourceLocation,
+		Language:             node.Language,
+		IsExternal:           node.IsExternal,
+		Modifier:             node.Modifier,
+		ReturnType:           node.ReturnType,
+		MethodArgumentsType:  node.MethodArgumentsType,
+		MethodArgumentsValue: node.MethodArgumentsValue,
+		ParameterKinds:       node.ParameterKinds,
+		TypeParameters:       node.TypeParameters,
+		PackageName:          node.PackageName,
+		SuperClass:           node.SuperClass,
+		Interface:            node.Interface,
+		Annotation:           node.Annotation,
+		Routes:               node.Routes,
+		Scope:                node.Scope,
+		Metadata:             node.Metadata,
+	}
+}
+
+// node rebuilds the function node a record was made from.
+func (r *functionRecord) node() *graph.Node {
+	return &graph.Node{
+		ID:                   r.ID,
+		Type:                 r.Type,
+		Name:                 r.Name,
+		File:                 r.File,
+		LineNumber:           r.LineNumber,
+		SourceLocation:       r.SourceLocation,
+		Language:             r.Language,
+		IsExternal:           r.IsExternal,
+		Modifier:             r.Modifier,
+		ReturnType:           r.ReturnType,
+		MethodArgumentsType:  r.MethodArgumentsType,
+		MethodArgumentsValue: r.MethodArgumentsValue,
+		ParameterKinds:       r.ParameterKinds,
+		TypeParameters:       r.TypeParameters,
+		PackageName:          r.PackageName,
+		SuperClass:           r.SuperClass,
+		Interface:            r.Interface,
+		Annotation:           r.Annotation,
+		Routes:               r.Routes,
+		Scope:                r.Scope,
+		Metadata:             r.Metadata,
+	}
+}
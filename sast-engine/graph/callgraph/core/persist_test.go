@@ -0,0 +1,111 @@
+package core
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newPersistTestGraph returns a graph with functions, resolved and
+// unresolved call sites, and class attributes.
+func newPersistTestGraph() *CallGraph {
+	cg := NewCallGraph()
+	cg.AddFunction("app.views.search", &graph.Node{
+		ID:                   "f1",
+		Type:                 "function_definition",
+		Name:                 "search",
+		File:                 "/app/views.py",
+		LineNumber:           4,
+		Language:             "python",
+		MethodArgumentsValue: []string{"request", "limit=10"},
+		ParameterKinds:       []graph.ParameterKind{graph.ParameterNormal, graph.ParameterNormal},
+		Routes:               []graph.Route{{Method: "GET", Path: "/search"}},
+	})
+	cg.AddFunction("app.db.query", &graph.Node{Name: "query", File: "/app/db.py", LineNumber: 1, TypeParameters: []string{"T: Model"}})
+	cg.AddEdge("app.views.search", "app.db.query")
+	cg.AddEdge("app.views.search", "builtins.len")
+	cg.AddCallSite("app.views.search", CallSite{
+		Target:    "query",
+		TargetFQN: "app.db.query",
+		Resolved:  true,
+		Location:  Location{File: "/app/views.py", Line: 6, Column: 5},
+		Arguments: []Argument{
+			{Value: "term", IsVariable: true, Position: 0},
+			{Value: "sql=raw", Position: 1},
+		},
+		KeywordArgs: map[string]string{"sql": "raw"},
+	})
+	cg.AddCallSite("app.views.search", CallSite{
+		Target:        "helper.run",
+		Location:      Location{File: "/app/views.py", Line: 5, Column: 5},
+		FailureReason: "not_in_imports",
+	})
+	cg.Attributes = AttributeMap{
+		"app.models.User": {
+			ClassFQN: "app.models.User",
+			Attributes: map[string]*ClassAttribute{
+				"name": {Name: "name", Type: &TypeInfo{TypeFQN: "builtins.str", Confidence: 1, Source: "literal"}, AssignedIn: "__init__"},
+			},
+			Methods:  []string{"app.models.User.__init__"},
+			FilePath: "/app/models.py",
+		},
+	}
+	return cg
+}
+
+func TestWriteCallGraph_RoundTrip(t *testing.T) {
+	cg := newPersistTestGraph()
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteCallGraph(&buf, cg))
+	loaded, err := ReadCallGraph(&buf)
+	require.NoError(t, err)
+
+	assert.Equal(t, cg.Functions, loaded.Functions)
+	assert.Equal(t, []string{"app.db.query", "builtins.len"}, loaded.Edges["app.views.search"])
+	assert.Equal(t, []string{"app.views.search"}, loaded.ReverseEdges["app.db.query"])
+	assert.Equal(t, []string{"app.views.search"}, loaded.GetCallers("builtins.len"))
+
+	// Call sites come back in source order
+	callSites := loaded.CallSites["app.views.search"]
+	require.Len(t, callSites, 2)
+	assert.Equal(t, cg.CallSites["app.views.search"][1], callSites[0])
+	assert.Equal(t, cg.CallSites["app.views.search"][0], callSites[1])
+
+	attributes := loaded.GetAttributes()
+	require.NotNil(t, attributes)
+	assert.Equal(t, cg.Attributes.(AttributeMap)["app.models.User"], attributes.GetClassAttributes("app.models.User"))
+}
+
+func TestWriteCallGraph_Deterministic(t *testing.T) {
+	var expected bytes.Buffer
+	require.NoError(t, WriteCallGraph(&expected, newPersistTestGraph()))
+	assert.Contains(t, expected.String(), `"schema_version": 1`)
+
+	for range 10 {
+		cg := newPersistTestGraph()
+		// Insertion order of edges does not show in the output
+		cg.Edges["app.views.search"] = []string{"builtins.len", "app.db.query"}
+		var buf bytes.Buffer
+		require.NoError(t, WriteCallGraph(&buf, cg))
+		assert.Equal(t, expected.String(), buf.String())
+	}
+}
+
+func TestReadCallGraph_SchemaVersion(t *testing.T) {
+	_, err := ReadCallGraph(strings.NewReader(`{"schema_version": 99, "functions": {}}`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "schema version 99 is not supported")
+
+	_, err = ReadCallGraph(strings.NewReader(`{"functions": {}}`))
+	require.Error(t, err, "files without a version are rejected")
+	assert.Contains(t, err.Error(), "schema version 0")
+
+	_, err = ReadCallGraph(strings.NewReader(`not json`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "decoding call graph")
+}
@@ -0,0 +1,232 @@
+package core
+
+import (
+	"strings"
+	"sync"
+)
+
+// PurityClass classifies a function by its observable side effects.
+type PurityClass string
+
+const (
+	// PurityPure means the function performs no I/O and writes no globals,
+	// directly or through any function it calls.
+	PurityPure PurityClass = "pure"
+
+	// PurityImpure means the function, or something it calls, has side effects.
+	PurityImpure PurityClass = "impure"
+
+	// PurityUnknown means the FQN is not a function in the call graph, or
+	// the function has no known side effects but makes calls that cannot be
+	// classified: unresolved calls, or calls into code outside both the call
+	// graph and the effects tables.
+	PurityUnknown PurityClass = "unknown"
+)
+
+// purityRank orders the classes from best to worst; a function takes the
+// worst class of its own calls and its callees.
+var purityRank = map[PurityClass]int{PurityPure: 0, PurityUnknown: 1, PurityImpure: 2}
+
+// impureModules lists stdlib and common third-party modules whose functions
+// perform I/O, touch the process or OS, or depend on hidden global state.
+// A call whose resolved FQN starts with "<module>." is a side effect.
+var impureModules = []string{
+	"asyncio", "ftplib", "http", "io", "logging", "multiprocessing", "os",
+	"pathlib", "random", "requests", "secrets", "shutil", "signal", "smtplib",
+	"socket", "sqlite3", "subprocess", "sys", "tempfile", "threading", "urllib",
+	"webbrowser",
+}
+
+// impureFunctions lists individual functions with side effects whose modules
+// are otherwise pure.
+var impureFunctions = map[string]bool{
+	"builtins.open":       true,
+	"builtins.print":      true,
+	"builtins.input":      true,
+	"builtins.exec":       true,
+	"builtins.eval":       true,
+	"builtins.setattr":    true,
+	"builtins.delattr":    true,
+	"builtins.__import__": true,
+	"builtins.breakpoint": true,
+	"builtins.exit":       true,
+	"builtins.quit":       true,
+	"json.dump":           true,
+	"json.load":           true,
+	"pickle.dump":         true,
+	"pickle.load":         true,
+	"time.sleep":          true,
+}
+
+// pureFunctions lists exceptions inside impureModules that only compute values.
+var pureFunctions = map[string]bool{
+	"os.path.join":     true,
+	"os.path.basename": true,
+	"os.path.dirname":  true,
+	"os.path.split":    true,
+	"os.path.splitext": true,
+	"os.path.normpath": true,
+	"os.fspath":        true,
+}
+
+// pureModules lists stdlib modules whose functions only compute values. A
+// call whose resolved FQN starts with "<module>." and is not listed in
+// impureFunctions is free of side effects.
+var pureModules = []string{
+	"base64", "bisect", "builtins", "cmath", "collections", "copy",
+	"dataclasses", "decimal", "enum", "fractions", "functools", "hashlib",
+	"heapq", "itertools", "json", "math", "operator", "re", "statistics",
+	"string", "textwrap", "typing",
+}
+
+// IsSideEffectCall reports whether a call to the resolved FQN has side
+// effects according to the stdlib effects table.
+func IsSideEffectCall(targetFQN string) bool {
+	if pureFunctions[targetFQN] {
+		return false
+	}
+	if impureFunctions[targetFQN] {
+		return true
+	}
+	for _, module := range impureModules {
+		if strings.HasPrefix(targetFQN, module+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPureCall reports whether a call to the resolved FQN is known to be free
+// of side effects according to the stdlib effects table. A call that is
+// neither a side effect nor known pure, such as one into a third-party
+// library, cannot be classified.
+func IsPureCall(targetFQN string) bool {
+	if pureFunctions[targetFQN] {
+		return true
+	}
+	if IsSideEffectCall(targetFQN) {
+		return false
+	}
+	for _, module := range pureModules {
+		if strings.HasPrefix(targetFQN, module+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// purityIndex holds the purity class of every function of a call graph,
+// computed once.
+type purityIndex struct {
+	once    sync.Once
+	classes map[string]PurityClass
+}
+
+// Purity classifies a function as pure, impure, or unknown.
+//
+// A function is locally impure if it writes a global (see GlobalWrites) or
+// makes a call listed in the stdlib effects table (see IsSideEffectCall),
+// and locally unknown if it makes a call that is neither to a function of
+// the graph nor known pure (see IsPureCall). Classes propagate
+// conservatively from callees to callers: a caller is impure if any callee
+// is, and otherwise unknown if any callee is. Recursive cycles share one
+// classification.
+//
+// All functions are classified together on the first call, over the
+// strongly connected components of the graph, and the result is cached, so
+// later calls are map lookups. Changes to the graph after the first call
+// are not reflected.
+//
+// Parameters:
+//   - fqn: fully qualified name of the function
+//
+// Returns:
+//   - PurityPure, PurityImpure or PurityUnknown
+func (cg *CallGraph) Purity(fqn string) PurityClass {
+	index := cg.purity
+	if index == nil {
+		index = &purityIndex{}
+	}
+	index.once.Do(func() {
+		index.classes = cg.classifyPurity()
+	})
+	if class, ok := index.classes[fqn]; ok {
+		return class
+	}
+	return PurityUnknown
+}
+
+// classifyPurity computes the purity class of every function. Components of
+// the call graph restricted to its functions come callees first, so each
+// component is classified after everything it calls.
+func (cg *CallGraph) classifyPurity() map[string]PurityClass {
+	edges := make(map[string][]string, len(cg.Functions))
+	for fqn := range cg.Functions {
+		var callees []string
+		for _, callee := range cg.Edges[fqn] {
+			if _, ok := cg.Functions[callee]; ok {
+				callees = append(callees, callee)
+			}
+		}
+		for _, callSite := range cg.CallSites[fqn] {
+			if _, ok := cg.Functions[callSite.TargetFQN]; ok {
+				callees = append(callees, callSite.TargetFQN)
+			}
+		}
+		edges[fqn] = callees
+	}
+
+	classes := make(map[string]PurityClass, len(cg.Functions))
+	for _, component := range stronglyConnectedComponents(edges) {
+		class := PurityPure
+		for _, fqn := range component {
+			class = worsePurity(class, cg.localPurity(fqn))
+			for _, callee := range edges[fqn] {
+				// Members of the component itself are not classified yet
+				if calleeClass, ok := classes[callee]; ok {
+					class = worsePurity(class, calleeClass)
+				}
+			}
+		}
+		for _, fqn := range component {
+			classes[fqn] = class
+		}
+	}
+	return classes
+}
+
+// worsePurity returns the worse of two purity classes.
+func worsePurity(a, b PurityClass) PurityClass {
+	if purityRank[b] > purityRank[a] {
+		return b
+	}
+	return a
+}
+
+// localPurity classifies the function body itself: impure if it writes a
+// global or calls a known side-effecting function, unknown if it makes a
+// call that cannot be classified, and pure otherwise. Calls to functions of
+// the graph are left to propagation.
+func (cg *CallGraph) localPurity(fqn string) PurityClass {
+	if len(cg.GlobalWrites[fqn]) > 0 {
+		return PurityImpure
+	}
+	class := PurityPure
+	for _, callSite := range cg.CallSites[fqn] {
+		if IsSideEffectCall(callSite.TargetFQN) {
+			return PurityImpure
+		}
+		if _, ok := cg.Functions[callSite.TargetFQN]; ok {
+			continue
+		}
+		// Unshadowed builtins such as open() may resolve to a same-module
+		// FQN that has no definition; classify them by their builtin name.
+		if !strings.Contains(callSite.Target, ".") && IsSideEffectCall("builtins."+callSite.Target) {
+			return PurityImpure
+		}
+		if !callSite.Resolved || !IsPureCall(callSite.TargetFQN) {
+			class = PurityUnknown
+		}
+	}
+	return class
+}
@@ -0,0 +1,139 @@
+package core
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSideEffectCall(t *testing.T) {
+	tests := []struct {
+		fqn  string
+		want bool
+	}{
+		{"builtins.open", true},
+		{"builtins.print", true},
+		{"os.remove", true},
+		{"subprocess.run", true},
+		{"json.dump", true},
+		{"os.path.join", false},
+		{"json.dumps", false},
+		{"builtins.len", false},
+		{"math.sqrt", false},
+		{"myapp.os_helpers.run", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fqn, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsSideEffectCall(tt.fqn))
+		})
+	}
+}
+
+func TestIsPureCall(t *testing.T) {
+	tests := []struct {
+		fqn  string
+		want bool
+	}{
+		{"builtins.len", true},
+		{"math.sqrt", true},
+		{"json.dumps", true},
+		{"os.path.join", true},
+		{"json.dump", false},
+		{"os.remove", false},
+		{"requests.get", false},
+		{"numpy.array", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fqn, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsPureCall(tt.fqn))
+		})
+	}
+}
+
+func TestCallGraph_Purity(t *testing.T) {
+	cg := NewCallGraph()
+	for _, fqn := range []string{"app.add", "app.area", "app.read", "app.load", "app.bump", "app.ping", "app.pong", "app.fact"} {
+		cg.Functions[fqn] = &graph.Node{Name: fqn}
+	}
+
+	cg.AddEdge("app.area", "app.add")
+	cg.AddCallSite("app.area", CallSite{Target: "add", TargetFQN: "app.add", Resolved: true})
+
+	cg.AddEdge("app.read", "builtins.open")
+	cg.AddCallSite("app.read", CallSite{Target: "open", TargetFQN: "builtins.open", Resolved: true})
+	cg.AddEdge("app.load", "app.read")
+
+	// Builtin left resolved to a same-module FQN with no definition.
+	cg.Functions["app.log"] = &graph.Node{Name: "log"}
+	cg.AddCallSite("app.log", CallSite{Target: "print", TargetFQN: "app.print", Resolved: true})
+
+	cg.GlobalWrites["app.bump"] = []string{"counter"}
+
+	// Mutual recursion where one member is impure.
+	cg.AddEdge("app.ping", "app.pong")
+	cg.AddEdge("app.pong", "app.ping")
+	cg.AddEdge("app.pong", "app.read")
+
+	// Self recursion with no side effects.
+	cg.AddEdge("app.fact", "app.fact")
+
+	assert.Equal(t, PurityPure, cg.Purity("app.add"))
+	assert.Equal(t, PurityPure, cg.Purity("app.area"))
+	assert.Equal(t, PurityImpure, cg.Purity("app.read"))
+	assert.Equal(t, PurityImpure, cg.Purity("app.load"), "calling an impure function is impure")
+	assert.Equal(t, PurityImpure, cg.Purity("app.log"))
+	assert.Equal(t, PurityImpure, cg.Purity("app.bump"))
+	assert.Equal(t, PurityImpure, cg.Purity("app.ping"))
+	assert.Equal(t, PurityImpure, cg.Purity("app.pong"))
+	assert.Equal(t, PurityPure, cg.Purity("app.fact"))
+	assert.Equal(t, PurityUnknown, cg.Purity("app.missing"))
+}
+
+func TestCallGraph_Purity_UnknownCallees(t *testing.T) {
+	cg := NewCallGraph()
+	for _, fqn := range []string{"app.norm", "app.plot", "app.guess", "app.report", "app.save", "app.both"} {
+		cg.Functions[fqn] = &graph.Node{Name: fqn}
+	}
+
+	cg.AddCallSite("app.norm", CallSite{Target: "math.sqrt", TargetFQN: "math.sqrt", Resolved: true})
+	cg.AddCallSite("app.plot", CallSite{Target: "np.array", TargetFQN: "numpy.array", Resolved: true})
+	cg.AddCallSite("app.guess", CallSite{Target: "obj.run", Resolved: false})
+	cg.AddEdge("app.report", "app.plot")
+	cg.AddCallSite("app.save", CallSite{Target: "open", TargetFQN: "builtins.open", Resolved: true})
+	cg.AddEdge("app.both", "app.plot")
+	cg.AddEdge("app.both", "app.save")
+
+	assert.Equal(t, PurityPure, cg.Purity("app.norm"))
+	assert.Equal(t, PurityUnknown, cg.Purity("app.plot"), "third-party calls are not assumed pure")
+	assert.Equal(t, PurityUnknown, cg.Purity("app.guess"), "unresolved calls are not assumed pure")
+	assert.Equal(t, PurityUnknown, cg.Purity("app.report"), "unknown propagates to callers")
+	assert.Equal(t, PurityImpure, cg.Purity("app.both"), "impure wins over unknown")
+}
+
+func TestCallGraph_Purity_Cached(t *testing.T) {
+	cg := NewCallGraph()
+	cg.Functions["app.add"] = &graph.Node{Name: "add"}
+	assert.Equal(t, PurityPure, cg.Purity("app.add"))
+
+	// Classes are computed once, on the first call
+	cg.GlobalWrites["app.add"] = []string{"counter"}
+	assert.Equal(t, PurityPure, cg.Purity("app.add"))
+}
+
+func TestCallGraph_Purity_DeepChain(t *testing.T) {
+	// A long call chain ending in I/O, classified without recursion
+	const depth = 100000
+	cg := NewCallGraph()
+	for i := range depth + 1 {
+		cg.Functions[fmt.Sprintf("f%d", i)] = &graph.Node{}
+	}
+	for i := range depth {
+		cg.AddEdge(fmt.Sprintf("f%d", i), fmt.Sprintf("f%d", i+1))
+	}
+	cg.GlobalWrites[fmt.Sprintf("f%d", depth)] = []string{"counter"}
+	assert.Equal(t, PurityImpure, cg.Purity("f0"))
+}
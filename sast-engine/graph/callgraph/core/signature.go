@@ -0,0 +1,90 @@
+package core
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+)
+
+// FindBySignature returns the functions with a parameter of type paramType
+// and a return type of returnType, ordered by FQN. An empty pattern places
+// no constraint on that part of the signature; only annotated types match
+// a non-empty one.
+//
+// Patterns may use * for any run of characters, and are matched against a
+// type as written and against its last dotted segment, so "HttpRequest"
+// matches "django.http.HttpRequest" and "*Request" matches "*http.Request".
+//
+// Example:
+//
+//	handlers := callGraph.FindBySignature("HttpRequest", "")
+//	responses := callGraph.FindBySignature("", "*Response")
+func (cg *CallGraph) FindBySignature(paramType, returnType string) []*graph.Node {
+	fqns := make([]string, 0)
+	for fqn, node := range cg.Functions {
+		if returnType != "" && !typeMatches(returnType, node.ReturnType) {
+			continue
+		}
+		if paramType != "" && !slices.ContainsFunc(node.MethodArgumentsType, func(param string) bool {
+			return typeMatches(paramType, parameterType(param))
+		}) {
+			continue
+		}
+		fqns = append(fqns, fqn)
+	}
+	slices.Sort(fqns)
+
+	nodes := make([]*graph.Node, len(fqns))
+	for i, fqn := range fqns {
+		nodes[i] = cg.Functions[fqn]
+	}
+	return nodes
+}
+
+// parameterType returns the type of a MethodArgumentsType entry, which is
+// "name: type" for Python and Go and the bare type for Java.
+func parameterType(param string) string {
+	if _, typ, ok := strings.Cut(param, ": "); ok {
+		return typ
+	}
+	return param
+}
+
+// typeMatches reports whether typ, or its last dotted segment, matches
+// pattern. Quotes around forward references ("User") are ignored.
+func typeMatches(pattern, typ string) bool {
+	typ = strings.Trim(strings.TrimSpace(typ), `"'`)
+	if typ == "" {
+		return false
+	}
+	if wildcardMatch(pattern, typ) {
+		return true
+	}
+	if i := strings.LastIndex(typ, "."); i >= 0 {
+		return wildcardMatch(pattern, typ[i+1:])
+	}
+	return false
+}
+
+// wildcardMatch reports whether s matches pattern, where * matches any run
+// of characters and every other character matches itself.
+func wildcardMatch(pattern, s string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == s
+	}
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(part):]
+	}
+	return len(s) >= len(last) && strings.HasSuffix(s, last)
+}
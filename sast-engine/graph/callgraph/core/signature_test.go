@@ -0,0 +1,56 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWildcardMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		s       string
+		want    bool
+	}{
+		{"HttpRequest", "HttpRequest", true},
+		{"HttpRequest", "HttpRequests", false},
+		{"*", "anything", true},
+		{"*Request", "HttpRequest", true},
+		{"Http*", "HttpResponse", true},
+		{"*Resp*", "JsonResponse", true},
+		{"a*b*c", "axxbyyc", true},
+		{"a*b*c", "axxcyyb", false},
+		{"ab*ba", "aba", false},
+		{"list[*]", "list[str]", true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, wildcardMatch(tt.pattern, tt.s), "%q ~ %q", tt.pattern, tt.s)
+	}
+}
+
+func TestFindBySignature(t *testing.T) {
+	cg := NewCallGraph()
+	cg.Functions["app.view"] = &graph.Node{Name: "view", MethodArgumentsType: []string{"request: django.http.HttpRequest"}, ReturnType: "HttpResponse"}
+	cg.Functions["app.lazy"] = &graph.Node{Name: "lazy", MethodArgumentsType: []string{`request: "HttpRequest"`}}
+	cg.Functions["app.count"] = &graph.Node{Name: "count", MethodArgumentsType: []string{"items: list[str]"}, ReturnType: "int"}
+	cg.Functions["app.untyped"] = &graph.Node{Name: "untyped"}
+	cg.Functions["Server.handle"] = &graph.Node{Name: "handle", MethodArgumentsType: []string{"w: http.ResponseWriter", "r: *http.Request"}}
+	cg.Functions["Controller.show"] = &graph.Node{Name: "show", MethodArgumentsType: []string{"HttpServletRequest"}, ReturnType: "String"}
+
+	names := func(nodes []*graph.Node) []string {
+		result := make([]string, len(nodes))
+		for i, node := range nodes {
+			result[i] = node.Name
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"lazy", "view"}, names(cg.FindBySignature("HttpRequest", "")))
+	assert.Equal(t, []string{"show", "handle", "lazy", "view"}, names(cg.FindBySignature("*Request", "")))
+	assert.Equal(t, []string{"view"}, names(cg.FindBySignature("HttpRequest", "HttpResponse")))
+	assert.Equal(t, []string{"count"}, names(cg.FindBySignature("list[*]", "int")))
+	assert.Equal(t, []string{"show", "count", "view"}, names(cg.FindBySignature("", "*")))
+	assert.Len(t, cg.FindBySignature("", ""), 6)
+	assert.Empty(t, cg.FindBySignature("Request", "String"))
+}
@@ -1,5 +1,7 @@
 package core
 
+import "slices"
+
 // StatementType represents the type of statement in the code.
 type StatementType string
 
@@ -36,6 +38,12 @@ const (
 
 	// Expression represents expression statements (calls, attribute access, etc.).
 	StatementTypeExpression StatementType = "expression"
+
+	// Assert represents assert statements: assert condition, message.
+	StatementTypeAssert StatementType = "assert"
+
+	// TypeAlias represents type alias statements: type Alias[T] = expr.
+	StatementTypeTypeAlias StatementType = "type_alias"
 )
 
 // Statement represents a single statement in the code with def-use information.
@@ -82,6 +90,23 @@ type Statement struct {
 	// Empty string if the RHS is not a pure attribute access (e.g., calls, literals, binary ops).
 	AttributeAccess string
 
+	// Condition is the source text of the condition an assert statement checks.
+	// Example: for "assert user.is_admin, 'denied'", Condition = "user.is_admin"
+	// Empty string for other statements.
+	Condition string
+
+	// TypeParameters are the names of the type parameters a type alias
+	// statement declares.
+	// Example: for "type Pair[K, V] = tuple[K, V]", TypeParameters = ["K", "V"]
+	// Empty for other statements.
+	TypeParameters []string
+
+	// Guarded is true for statements in a try body whose except handlers all
+	// leave the function (return or raise), so code after the try only runs
+	// if the statement did not raise. Set from the CFG.
+	// Example: "n = int(value)" in "try: n = int(value) except ValueError: return"
+	Guarded bool
+
 	// NestedStatements contains statements inside this statement's body
 	// Used for if/for/while/with/try blocks
 	// Empty for simple statements like assignments
@@ -197,6 +222,26 @@ func (chain *DefUseChain) GetUses(varName string) []*Statement {
 	return []*Statement{}
 }
 
+// DefLines returns the lines defining a variable, in ascending order.
+func (chain *DefUseChain) DefLines(varName string) []uint32 {
+	return statementLines(chain.Defs[varName])
+}
+
+// UseLines returns the lines using a variable, in ascending order.
+func (chain *DefUseChain) UseLines(varName string) []uint32 {
+	return statementLines(chain.Uses[varName])
+}
+
+// statementLines returns the distinct line numbers of statements, sorted.
+func statementLines(statements []*Statement) []uint32 {
+	lines := make([]uint32, 0, len(statements))
+	for _, stmt := range statements {
+		lines = append(lines, stmt.LineNumber)
+	}
+	slices.Sort(lines)
+	return slices.Compact(lines)
+}
+
 // IsDefined returns true if the variable has at least one definition.
 func (chain *DefUseChain) IsDefined(varName string) bool {
 	return len(chain.Defs[varName]) > 0
@@ -414,6 +414,21 @@ func TestDefUseChainGetDefs(t *testing.T) {
 	assert.Equal(t, 0, len(nonExistent))
 }
 
+func TestDefUseChainLines(t *testing.T) {
+	// x = 1; x = x + 1; print(x, x) on one line; return x
+	chain := BuildDefUseChains([]*Statement{
+		{Type: StatementTypeAssignment, LineNumber: 1, Def: "x"},
+		{Type: StatementTypeAssignment, LineNumber: 2, Def: "x", Uses: []string{"x"}},
+		{Type: StatementTypeCall, LineNumber: 4, Uses: []string{"x"}},
+		{Type: StatementTypeCall, LineNumber: 4, Uses: []string{"x"}},
+		{Type: StatementTypeReturn, LineNumber: 3, Uses: []string{"x"}},
+	})
+
+	assert.Equal(t, []uint32{1, 2}, chain.DefLines("x"))
+	assert.Equal(t, []uint32{2, 3, 4}, chain.UseLines("x"))
+	assert.Empty(t, chain.DefLines("y"))
+}
+
 func TestDefUseChainGetUses(t *testing.T) {
 	chain := NewDefUseChain()
 	stmt1 := &Statement{Type: StatementTypeCall, LineNumber: 1, Uses: []string{"x"}}
@@ -0,0 +1,55 @@
+package core
+
+import "strings"
+
+// StdlibSubmodules are common stdlib submodules reached by attribute access
+// on their imported parent package, as in import os; os.path.join(...).
+// os.path is imported by os itself; the others are usually loaded by the
+// parent package or by another import and are used the same way.
+var StdlibSubmodules = map[string]bool{
+	"os.path":               true,
+	"urllib.parse":          true,
+	"urllib.request":        true,
+	"urllib.error":          true,
+	"xml.etree.ElementTree": true,
+	"xml.dom.minidom":       true,
+	"xml.sax.saxutils":      true,
+	"email.utils":           true,
+	"email.mime.text":       true,
+	"email.mime.multipart":  true,
+	"logging.handlers":      true,
+	"logging.config":        true,
+	"concurrent.futures":    true,
+	"importlib.util":        true,
+	"http.client":           true,
+	"http.cookies":          true,
+	"http.server":           true,
+	"json.decoder":          true,
+	"unittest.mock":         true,
+	"collections.abc":       true,
+	"html.parser":           true,
+	"xmlrpc.client":         true,
+}
+
+// StdlibSubmoduleAttribute reports whether fqn names an attribute of one
+// of StdlibSubmodules, such as "os.path.join", and returns the submodule
+// ("os.path"). A submodule shadowed by a project module or package of the
+// same name does not count.
+func (mr *ModuleRegistry) StdlibSubmoduleAttribute(fqn string) (string, bool) {
+	lastDot := strings.LastIndex(fqn, ".")
+	if lastDot <= 0 {
+		return "", false
+	}
+	submodule := fqn[:lastDot]
+	if !StdlibSubmodules[submodule] {
+		return "", false
+	}
+	root, _, _ := strings.Cut(submodule, ".")
+	if _, shadowed := mr.Modules[submodule]; shadowed {
+		return "", false
+	}
+	if _, shadowed := mr.Modules[root]; shadowed {
+		return "", false
+	}
+	return submodule, true
+}
@@ -0,0 +1,29 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModuleRegistry_StdlibSubmoduleAttribute(t *testing.T) {
+	registry := NewModuleRegistry()
+
+	submodule, ok := registry.StdlibSubmoduleAttribute("os.path.join")
+	assert.True(t, ok)
+	assert.Equal(t, "os.path", submodule)
+
+	submodule, ok = registry.StdlibSubmoduleAttribute("xml.etree.ElementTree.parse")
+	assert.True(t, ok)
+	assert.Equal(t, "xml.etree.ElementTree", submodule)
+
+	for _, fqn := range []string{"os.getcwd", "os.path", "os.missing.join", "join"} {
+		_, ok := registry.StdlibSubmoduleAttribute(fqn)
+		assert.False(t, ok, fqn)
+	}
+
+	// A project package named os shadows the stdlib
+	registry.AddModule("os", "/project/os/__init__.py")
+	_, ok = registry.StdlibSubmoduleAttribute("os.path.join")
+	assert.False(t, ok)
+}
@@ -0,0 +1,48 @@
+package core
+
+// Subgraph returns the neighborhood of fqn: the functions reachable from it,
+// or reaching it, within depth call edges. The result is a new CallGraph
+// holding those functions, the edges between them, and their call sites that
+// stay inside the neighborhood or failed to resolve. Returns an empty graph
+// if fqn has no node in the call graph.
+func (cg *CallGraph) Subgraph(fqn string, depth int) *CallGraph {
+	sub := NewCallGraph()
+	if _, ok := cg.Functions[fqn]; !ok && len(cg.Edges[fqn]) == 0 && len(cg.ReverseEdges[fqn]) == 0 {
+		return sub
+	}
+
+	included := map[string]bool{fqn: true}
+	frontier := []string{fqn}
+	for range depth {
+		var next []string
+		for _, node := range frontier {
+			for _, neighbor := range append(cg.GetCallees(node), cg.GetCallers(node)...) {
+				if !included[neighbor] {
+					included[neighbor] = true
+					next = append(next, neighbor)
+				}
+			}
+		}
+		frontier = next
+	}
+
+	for node := range included {
+		if function, ok := cg.GetFunction(node); ok {
+			sub.AddFunction(node, function)
+		}
+		for _, callee := range cg.Edges[node] {
+			if included[callee] {
+				sub.AddEdge(node, callee)
+			}
+		}
+		for _, callSite := range cg.CallSites[node] {
+			if !callSite.Resolved || included[callSite.TargetFQN] {
+				sub.AddCallSite(node, callSite)
+			}
+		}
+		if statements, ok := cg.Statements[node]; ok {
+			sub.Statements[node] = statements
+		}
+	}
+	return sub
+}
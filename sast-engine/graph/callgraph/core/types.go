@@ -1,6 +1,8 @@
 package core
 
 import (
+	"fmt"
+	"os"
 	"slices"
 
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
@@ -14,6 +16,25 @@ type Location struct {
 	Column int    // Column number (1-indexed)
 }
 
+// SourceMapper translates a line in an analyzed file back to the original
+// source it was generated from, such as a notebook cell or a template.
+// Returning the inputs unchanged means the location needs no translation.
+type SourceMapper func(file string, line int) (origFile string, origLine int)
+
+// ChainSourceMappers returns a mapper applying first, then second to its
+// result. Either may be nil.
+func ChainSourceMappers(first, second SourceMapper) SourceMapper {
+	if first == nil {
+		return second
+	}
+	if second == nil {
+		return first
+	}
+	return func(file string, line int) (string, int) {
+		return second(first(file, line))
+	}
+}
+
 // CallSite represents a function/method call location in the source code.
 // It captures both the syntactic information (where the call is) and
 // semantic information (what is being called and with what arguments).
@@ -25,6 +46,11 @@ type CallSite struct {
 	TargetFQN     string     // Fully qualified name after resolution (e.g., "myapp.utils.sanitize")
 	FailureReason string     // Why resolution failed (empty if Resolved=true)
 
+	// KeywordArgs maps the names of the call's keyword arguments to the
+	// expressions they pass: execute(sql=query) → {"sql": "query"}. They
+	// are also in Arguments, as "name=value". Nil if there are none.
+	KeywordArgs map[string]string
+
 	// Phase 2: Type inference metadata
 	ResolvedViaTypeInference bool    // Was this resolved using type inference?
 	InferredType             string  // The inferred type FQN (e.g., "builtins.str", "test.User")
@@ -34,23 +60,33 @@ type CallSite struct {
 	// IsStdlib is true when the resolved target is a Go standard library function.
 	// Set during Go call graph construction when StdlibLoader is available.
 	IsStdlib bool
+
+	// VirtualDispatch is true when the target is an abstract method: the call
+	// runs one of its concrete overrides, listed in DispatchTargets. A call
+	// through a dispatch table (handlers[key]()) lists the table's functions
+	// in DispatchTargets without VirtualDispatch.
+	VirtualDispatch bool
+	DispatchTargets []string
+
+	// DispatchFunction is set when the call is to a @singledispatch
+	// function: TargetFQN is then the implementation chosen for the first
+	// argument's type, DispatchType ("" if unknown, choosing the function's
+	// own body).
+	DispatchFunction string
+	DispatchType     string
 }
 
-// Resolution failure reason categories for diagnostics:
-// - "external_framework" - Call to Django, REST framework, pytest, stdlib, etc.
-// - "orm_pattern" - Django ORM patterns like Model.objects.filter()
-// - "attribute_chain" - Method calls on return values like response.json()
-// - "variable_method" - Method calls on variables like value.split()
-// - "super_call" - Calls via super() to parent class methods
-// - "not_in_imports" - Simple function call not found in imports
-// - "unknown" - Unresolved for other reasons
+// Resolution failure reason categories for diagnostics are the
+// ResolutionFailure constants in unresolved.go.
 
 // Argument represents a single argument passed to a function call.
 // Tracks both the value/expression and metadata about the argument.
 type Argument struct {
-	Value      string // The argument expression as a string
-	IsVariable bool   // Whether this argument is a variable reference
-	Position   int    // Position in the argument list (0-indexed)
+	Value        string // The argument expression as a string
+	IsVariable   bool   // Whether this argument is a variable reference
+	Position     int    // Position in the argument list (0-indexed)
+	IsDictSpread bool   // Whether this is a **mapping argument spread into keyword arguments
+	IsListSpread bool   // Whether this is a *iterable argument spread into positional arguments
 }
 
 // ParameterSymbol represents a typed function/method parameter as a standalone symbol.
@@ -124,9 +160,28 @@ type CallGraph struct {
 	// Key: function FQN, Value: opaque interface (cfg.BlockStatements) to avoid import cycle.
 	CFGBlockStatements map[string]any
 
+	// GlobalWrites records module-level variables each function rebinds
+	// through a `global` declaration. Used for purity classification.
+	// Populated during call graph Pass 5 (taint summary generation).
+	// Key: function FQN, Value: sorted variable names
+	GlobalWrites map[string][]string
+
+	// ModuleImports is the module-level import graph: the project modules
+	// each module imports. Imports of stdlib and third-party modules are not
+	// recorded. Populated during call site resolution (Pass 4).
+	// Key: module FQN, Value: imported module FQNs, in insertion order
+	ModuleImports map[string][]string
+
+	// ExternalEdges are calls that leave the caller's language, such as a
+	// Python subprocess running a Go program. Those whose entry point is a
+	// function of the graph are also in Edges.
+	// Populated by builder.LinkCrossLanguageCalls.
+	ExternalEdges []ExternalEdge
+
 	// Attribute registry for class attributes and instance variables
 	// Populated during call graph construction (Phase 3: Extract Class Attributes)
 	// Enables symbol search to find class fields and properties
+	// Set with SetAttributes, which rejects types other than AttributeProvider
 	Attributes any // *registry.AttributeRegistry (interface{} to avoid import cycle)
 
 	// Type inference engine for querying module-level variable types (Python)
@@ -151,10 +206,30 @@ type CallGraph struct {
 	// Implements dsl.InheritanceChecker interface.
 	StdlibRemote any
 
+	// SourceMapper translates reported locations back to original sources.
+	// Locations stored in the graph stay in analyzed-file coordinates so
+	// call sites, statements and CFGs line up; use OriginalLocation when
+	// reporting. Nil means no translation.
+	SourceMapper SourceMapper
+
+	// Sources holds the contents of analyzed files that are not on disk,
+	// such as in-memory buffers and the Python extracted from notebooks,
+	// keyed by path. Use ReadSource to read any analyzed file.
+	Sources map[string][]byte
+
 	// GoStructFieldIndex maps "pkgPath.TypeName.FieldName" → resolved field type FQN.
 	// Populated during call graph construction (Pass 4 setup) from struct_definition nodes.
 	// Used by resolveGoCallTarget Source 4 to resolve chained field access like a.Field.Method().
 	GoStructFieldIndex map[string]string
+
+	// fqns interns the FQNs, targets, and file paths stored by AddEdge,
+	// AddCallSite, and AddFunction. Nil (no interning) for graphs not
+	// created with NewCallGraph.
+	fqns *StringPool
+
+	// purity caches the purity classes computed on the first call to
+	// Purity. Nil (no caching) for graphs not created with NewCallGraph.
+	purity *purityIndex
 }
 
 // NewCallGraph creates and initializes a new CallGraph instance.
@@ -170,17 +245,24 @@ func NewCallGraph() *CallGraph {
 		Statements:         make(map[string][]*Statement),
 		CFGs:               make(map[string]any),
 		CFGBlockStatements: make(map[string]any),
+		GlobalWrites:       make(map[string][]string),
+		ModuleImports:      make(map[string][]string),
 		GoStructFieldIndex: make(map[string]string),
+		fqns:               NewStringPool(),
+		purity:             &purityIndex{},
 	}
 }
 
 // AddEdge adds a directed edge from caller to callee in the call graph.
-// Automatically updates both forward and reverse edges.
+// Automatically updates both forward and reverse edges. The names are
+// interned, so repeated FQNs share storage.
 //
 // Parameters:
 //   - caller: fully qualified name of the calling function
 //   - callee: fully qualified name of the called function
 func (cg *CallGraph) AddEdge(caller, callee string) {
+	caller, callee = cg.fqns.Intern(caller), cg.fqns.Intern(callee)
+
 	// Add forward edge
 	if !contains(cg.Edges[caller], callee) {
 		cg.Edges[caller] = append(cg.Edges[caller], callee)
@@ -194,11 +276,16 @@ func (cg *CallGraph) AddEdge(caller, callee string) {
 
 // AddCallSite adds a call site to the call graph.
 // This stores detailed information about where and how a function is called.
+// The caller, target, target FQN, and file path are interned.
 //
 // Parameters:
 //   - caller: fully qualified name of the calling function
 //   - callSite: detailed information about the call
 func (cg *CallGraph) AddCallSite(caller string, callSite CallSite) {
+	caller = cg.fqns.Intern(caller)
+	callSite.Target = cg.fqns.Intern(callSite.Target)
+	callSite.TargetFQN = cg.fqns.Intern(callSite.TargetFQN)
+	callSite.Location.File = cg.fqns.Intern(callSite.Location.File)
 	cg.CallSites[caller] = append(cg.CallSites[caller], callSite)
 }
 
@@ -232,6 +319,76 @@ func (cg *CallGraph) GetCallees(caller string) []string {
 	return []string{}
 }
 
+// Callers returns a copy of the functions that call callee, so the result
+// can be modified without changing the graph.
+func (cg *CallGraph) Callers(callee string) []string {
+	return slices.Clone(cg.GetCallers(callee))
+}
+
+// Callees returns a copy of the functions called by caller, so the result
+// can be modified without changing the graph.
+func (cg *CallGraph) Callees(caller string) []string {
+	return slices.Clone(cg.GetCallees(caller))
+}
+
+// GetFunction returns the function or method node indexed under fqn.
+func (cg *CallGraph) GetFunction(fqn string) (*graph.Node, bool) {
+	node, ok := cg.Functions[fqn]
+	return node, ok && node != nil
+}
+
+// AddFunction indexes a function or method node under its fully qualified
+// name, replacing any node already indexed there. A nil node or empty FQN
+// is ignored.
+func (cg *CallGraph) AddFunction(fqn string, node *graph.Node) {
+	if fqn == "" || node == nil {
+		return
+	}
+	if cg.Functions == nil {
+		cg.Functions = make(map[string]*graph.Node)
+	}
+	cg.Functions[cg.fqns.Intern(fqn)] = node
+}
+
+// SetAttributes attaches the class attribute registry. Returns an error,
+// leaving the registry unchanged, unless attributes is nil or implements
+// AttributeProvider.
+func (cg *CallGraph) SetAttributes(attributes any) error {
+	if attributes != nil {
+		if _, ok := attributes.(AttributeProvider); !ok {
+			return fmt.Errorf("attribute registry must implement AttributeProvider, got %T", attributes)
+		}
+	}
+	cg.Attributes = attributes
+	return nil
+}
+
+// GetAttributes returns the class attribute registry, or nil if none is
+// attached or Attributes holds some other type.
+func (cg *CallGraph) GetAttributes() AttributeProvider {
+	provider, _ := cg.Attributes.(AttributeProvider)
+	return provider
+}
+
+// OriginalLocation translates loc through the call graph's SourceMapper.
+// Returns loc unchanged when no mapper is set.
+func (cg *CallGraph) OriginalLocation(loc Location) Location {
+	if cg.SourceMapper == nil || loc.File == "" {
+		return loc
+	}
+	loc.File, loc.Line = cg.SourceMapper(loc.File, loc.Line)
+	return loc
+}
+
+// ReadSource returns the contents of an analyzed file, from Sources when
+// it is not on disk.
+func (cg *CallGraph) ReadSource(file string) ([]byte, error) {
+	if sourceCode, ok := cg.Sources[file]; ok {
+		return sourceCode, nil
+	}
+	return os.ReadFile(file)
+}
+
 // GetGoTypeEngine returns the Go type inference engine.
 // Returns nil if no type engine has been attached to this call graph.
 func (cg *CallGraph) GetGoTypeEngine() GoTypeProvider {
@@ -473,12 +630,35 @@ type ModuleVariableInfo struct {
 	Source     string  // How the type was inferred (e.g., "literal", "class_instantiation")
 }
 
+// AttributeProvider looks up the attributes of a class.
+// Implemented by registry.AttributeRegistry.
+type AttributeProvider interface {
+	GetClassAttributes(classFQN string) *ClassAttributes
+}
+
 // ModuleVariableProvider provides type information for module-level variables.
 // Implemented by resolution.TypeInferenceEngine.
 type ModuleVariableProvider interface {
 	GetModuleVariableType(modulePath string, varName string, line uint32) *ModuleVariableInfo
 }
 
+// TypedVariable is a variable binding whose inferred type is known.
+type TypedVariable struct {
+	Scope      string  // Function FQN, or module path for module-level variables
+	Name       string  // Variable name
+	TypeFQN    string  // Inferred type (e.g., "myapp.models.User")
+	File       string  // File containing the assignment
+	Line       uint32  // Line of the assignment
+	Confidence float64 // Confidence score (0.0-1.0)
+	Source     string  // How the type was inferred (e.g., "class_instantiation")
+}
+
+// TypedVariableProvider finds variable bindings by inferred type.
+// Implemented by resolution.TypeInferenceEngine.
+type TypedVariableProvider interface {
+	VariablesOfType(typeFQN string) []TypedVariable
+}
+
 // GoTypeProvider provides access to Go type information.
 // This interface avoids import cycles between core and resolution packages.
 // Implemented by *resolution.GoTypeInferenceEngine.
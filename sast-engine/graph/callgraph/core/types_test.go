@@ -574,3 +574,84 @@ func TestContains(t *testing.T) {
 		})
 	}
 }
+
+func TestCallGraph_OriginalLocation(t *testing.T) {
+	cg := NewCallGraph()
+	loc := Location{File: "/tmp/cell_3.py", Line: 4, Column: 2}
+
+	assert.Equal(t, loc, cg.OriginalLocation(loc), "no mapper leaves locations unchanged")
+
+	cg.SourceMapper = func(file string, line int) (string, int) {
+		return "/tmp/analysis.ipynb", line + 20
+	}
+	assert.Equal(t, Location{File: "/tmp/analysis.ipynb", Line: 24, Column: 2}, cg.OriginalLocation(loc))
+	assert.Equal(t, Location{}, cg.OriginalLocation(Location{}), "unknown locations are not mapped")
+}
+
+func TestCallGraph_CallersAndCallees(t *testing.T) {
+	cg := NewCallGraph()
+	cg.AddEdge("app.views.index", "app.db.query")
+	cg.AddEdge("app.views.detail", "app.db.query")
+	cg.AddEdge("app.views.index", "app.db.query")
+
+	assert.Equal(t, []string{"app.views.index", "app.views.detail"}, cg.Callers("app.db.query"))
+	assert.Equal(t, []string{"app.db.query"}, cg.Callees("app.views.index"))
+	assert.Empty(t, cg.Callers("app.views.index"))
+	assert.Empty(t, cg.Callees("app.db.query"))
+
+	// Every forward edge has a matching reverse edge
+	for caller, callees := range cg.Edges {
+		for _, callee := range callees {
+			assert.Contains(t, cg.ReverseEdges[callee], caller)
+		}
+	}
+
+	callers := cg.Callers("app.db.query")
+	callers[0] = "app.views.other"
+	assert.Equal(t, "app.views.index", cg.ReverseEdges["app.db.query"][0], "Callers returns a copy")
+}
+
+func TestCallGraph_AddFunction(t *testing.T) {
+	cg := NewCallGraph()
+	node := &graph.Node{ID: "1", Name: "index"}
+
+	cg.AddFunction("app.views.index", node)
+	got, ok := cg.GetFunction("app.views.index")
+	assert.True(t, ok)
+	assert.Same(t, node, got)
+
+	cg.AddFunction("app.views.detail", nil)
+	cg.AddFunction("", node)
+	_, ok = cg.GetFunction("app.views.detail")
+	assert.False(t, ok)
+	assert.Len(t, cg.Functions, 1)
+
+	empty := &CallGraph{}
+	empty.AddFunction("app.views.index", node)
+	_, ok = empty.GetFunction("app.views.index")
+	assert.True(t, ok)
+}
+
+type fakeAttributeProvider struct{}
+
+func (fakeAttributeProvider) GetClassAttributes(classFQN string) *ClassAttributes {
+	return &ClassAttributes{ClassFQN: classFQN}
+}
+
+func TestCallGraph_SetAttributes(t *testing.T) {
+	cg := NewCallGraph()
+
+	err := cg.SetAttributes(fakeAttributeProvider{})
+	assert.NoError(t, err)
+	assert.Equal(t, "app.User", cg.GetAttributes().GetClassAttributes("app.User").ClassFQN)
+
+	err = cg.SetAttributes("not a registry")
+	assert.EqualError(t, err, "attribute registry must implement AttributeProvider, got string")
+	assert.Equal(t, fakeAttributeProvider{}, cg.Attributes, "rejected registry leaves Attributes unchanged")
+
+	assert.NoError(t, cg.SetAttributes(nil))
+	assert.Nil(t, cg.GetAttributes())
+
+	cg.Attributes = map[string]string{}
+	assert.Nil(t, cg.GetAttributes(), "malformed Attributes is not a provider")
+}
@@ -0,0 +1,77 @@
+package core
+
+import (
+	"cmp"
+	"slices"
+)
+
+// ResolutionFailure is the category recorded in CallSite.FailureReason when
+// a call target could not be resolved.
+type ResolutionFailure string
+
+const (
+	FailureExternalFramework ResolutionFailure = "external_framework" // Third-party framework call
+	FailureStdlibUnresolved  ResolutionFailure = "stdlib_unresolved"  // Stdlib module known, function not found
+	FailureORMPattern        ResolutionFailure = "orm_pattern"        // Django ORM call like Model.objects.filter()
+	FailureAttributeChain    ResolutionFailure = "attribute_chain"    // Method call on a return value or object
+	FailureVariableMethod    ResolutionFailure = "variable_method"    // Method call on a variable of unknown type
+	FailureSuperCall         ResolutionFailure = "super_call"         // Call through super()
+	FailureNotInImports      ResolutionFailure = "not_in_imports"     // Simple name neither defined nor imported
+	FailureUnresolvedGoCall  ResolutionFailure = "unresolved_go_call" // Go call with no known target
+	FailureUnknown           ResolutionFailure = "unknown"            // Any other reason
+)
+
+// UnresolvedCall is an unresolved call site together with its caller.
+type UnresolvedCall struct {
+	Caller   string   // FQN of the calling function
+	CallSite CallSite // The unresolved call
+}
+
+// UnresolvedReport counts the unresolved call sites of the call graph by
+// failure category. Call sites without a recorded reason count as
+// FailureUnknown.
+func (cg *CallGraph) UnresolvedReport() map[ResolutionFailure]int {
+	report := make(map[ResolutionFailure]int)
+	for _, callSites := range cg.CallSites {
+		for _, callSite := range callSites {
+			if !callSite.Resolved {
+				report[failureOf(callSite)]++
+			}
+		}
+	}
+	return report
+}
+
+// UnresolvedCalls returns the unresolved call sites of the call graph by
+// failure category, each ordered by caller FQN, then position.
+func (cg *CallGraph) UnresolvedCalls() map[ResolutionFailure][]UnresolvedCall {
+	calls := make(map[ResolutionFailure][]UnresolvedCall)
+	for caller, callSites := range cg.CallSites {
+		for _, callSite := range callSites {
+			if !callSite.Resolved {
+				failure := failureOf(callSite)
+				calls[failure] = append(calls[failure], UnresolvedCall{Caller: caller, CallSite: callSite})
+			}
+		}
+	}
+	for _, unresolved := range calls {
+		slices.SortFunc(unresolved, func(a, b UnresolvedCall) int {
+			if c := cmp.Compare(a.Caller, b.Caller); c != 0 {
+				return c
+			}
+			if c := cmp.Compare(a.CallSite.Location.Line, b.CallSite.Location.Line); c != 0 {
+				return c
+			}
+			return cmp.Compare(a.CallSite.Location.Column, b.CallSite.Location.Column)
+		})
+	}
+	return calls
+}
+
+// failureOf returns the failure category of an unresolved call site.
+func failureOf(callSite CallSite) ResolutionFailure {
+	if callSite.FailureReason == "" {
+		return FailureUnknown
+	}
+	return ResolutionFailure(callSite.FailureReason)
+}
@@ -0,0 +1,42 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newUnresolvedTestGraph() *CallGraph {
+	cg := NewCallGraph()
+	cg.AddCallSite("app.main", CallSite{Target: "helper", Location: Location{Line: 3}, Resolved: true, TargetFQN: "app.helper"})
+	cg.AddCallSite("app.main", CallSite{Target: "render", Location: Location{Line: 5}, FailureReason: "not_in_imports"})
+	cg.AddCallSite("app.main", CallSite{Target: "conn.pool.cursor", Location: Location{Line: 4}, FailureReason: "attribute_chain"})
+	cg.AddCallSite("app.export", CallSite{Target: "session.pool.flush", Location: Location{Line: 9}, FailureReason: "attribute_chain"})
+	cg.AddCallSite("app.export", CallSite{Target: "mystery", Location: Location{Line: 10}})
+	return cg
+}
+
+func TestCallGraph_UnresolvedReport(t *testing.T) {
+	report := newUnresolvedTestGraph().UnresolvedReport()
+
+	assert.Equal(t, map[ResolutionFailure]int{
+		FailureAttributeChain: 2,
+		FailureNotInImports:   1,
+		FailureUnknown:        1,
+	}, report, "resolved calls are not counted; a missing reason counts as unknown")
+}
+
+func TestCallGraph_UnresolvedCalls(t *testing.T) {
+	calls := newUnresolvedTestGraph().UnresolvedCalls()
+
+	require.Len(t, calls[FailureAttributeChain], 2)
+	assert.Equal(t, "app.export", calls[FailureAttributeChain][0].Caller)
+	assert.Equal(t, "session.pool.flush", calls[FailureAttributeChain][0].CallSite.Target)
+	assert.Equal(t, "app.main", calls[FailureAttributeChain][1].Caller)
+	assert.Equal(t, "conn.pool.cursor", calls[FailureAttributeChain][1].CallSite.Target)
+
+	require.Len(t, calls[FailureUnknown], 1)
+	assert.Equal(t, "mystery", calls[FailureUnknown][0].CallSite.Target)
+	assert.NotContains(t, calls, ResolutionFailure(""))
+}
@@ -0,0 +1,75 @@
+package callgraph
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// dedupeKey identifies a unique vulnerability: one pattern, one source, one sink.
+type dedupeKey struct {
+	patternID  string
+	sourceFile string
+	sourceLine uint32
+	sinkFile   string
+	sinkLine   uint32
+}
+
+// DedupeMatches collapses matches that report the same vulnerability through
+// different paths. Matches are keyed by (PatternID, source location, sink
+// location); the highest-confidence match in each group is kept, with the
+// shortest path breaking ties, and the other paths are recorded in its
+// AlternatePaths, shortest first.
+//
+// Groups keep the order of their first match, so the result is
+// deterministic for a deterministic input. The input slice is not modified.
+func DedupeMatches(matches []SecurityMatch) []SecurityMatch {
+	var keys []dedupeKey
+	groups := make(map[dedupeKey][]SecurityMatch)
+	for _, match := range matches {
+		key := dedupeKey{match.PatternID, match.SourceFile, match.SourceLine, match.SinkFile, match.SinkLine}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], match)
+	}
+
+	result := make([]SecurityMatch, 0, len(keys))
+	for _, key := range keys {
+		group := groups[key]
+		// Best first: highest confidence, then shortest path, then path text.
+		slices.SortStableFunc(group, func(a, b SecurityMatch) int {
+			if c := cmp.Compare(b.Confidence, a.Confidence); c != 0 {
+				return c
+			}
+			return comparePaths(a.DataFlowPath, b.DataFlowPath)
+		})
+
+		representative := group[0]
+		seen := map[string]bool{pathKey(representative.DataFlowPath): true}
+		var alternates [][]string
+		for _, match := range group {
+			for _, path := range append([][]string{match.DataFlowPath}, match.AlternatePaths...) {
+				if key := pathKey(path); len(path) > 0 && !seen[key] {
+					seen[key] = true
+					alternates = append(alternates, path)
+				}
+			}
+		}
+		slices.SortFunc(alternates, comparePaths)
+
+		representative.AlternatePaths = alternates
+		result = append(result, representative)
+	}
+	return result
+}
+
+// pathKey joins a path into a comparable string.
+func pathKey(path []string) string {
+	return strings.Join(path, "\x00")
+}
+
+// comparePaths orders paths by length, then lexicographically.
+func comparePaths(a, b []string) int {
+	return cmp.Or(cmp.Compare(len(a), len(b)), slices.Compare(a, b))
+}
@@ -0,0 +1,48 @@
+package callgraph
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyze_TwoPathsToOneSink(t *testing.T) {
+	projectPath, err := filepath.Abs("../../test-fixtures/python/two_paths")
+	require.NoError(t, err)
+
+	// handle reaches eval() in run both directly and through helper
+	result, err := Analyze(projectPath, AnalyzeOptions{})
+	require.NoError(t, err)
+
+	var injections []SecurityMatch
+	for _, match := range result.Matches {
+		if match.PatternID == "CODE-INJECTION-001" {
+			injections = append(injections, match)
+		}
+	}
+	require.Len(t, injections, 1, "both paths report the same vulnerability")
+	assert.Equal(t, []string{"views.handle", "views.run"}, injections[0].DataFlowPath, "shortest path is kept")
+	assert.Equal(t, [][]string{{"views.handle", "views.helper", "views.run"}}, injections[0].AlternatePaths)
+	raw := AnalyzePatterns(result.CallGraph, result.PatternRegistry)
+	assert.Greater(t, len(raw), len(result.Matches), "the paths are separate matches before dedupe")
+}
+
+func TestDedupeMatches_DistinctKeysKeepOrder(t *testing.T) {
+	matches := []SecurityMatch{
+		{PatternID: "SQL-INJECTION-001", SinkFile: "/app/a.py", SinkLine: 9},
+		{PatternID: "CODE-INJECTION-001", SinkFile: "/app/a.py", SinkLine: 9},
+		{PatternID: "SQL-INJECTION-001", SinkFile: "/app/a.py", SinkLine: 4},
+		{PatternID: "SQL-INJECTION-001", SinkFile: "/app/a.py", SinkLine: 9},
+	}
+
+	deduped := DedupeMatches(matches)
+
+	require.Len(t, deduped, 3)
+	assert.Equal(t, "SQL-INJECTION-001", deduped[0].PatternID)
+	assert.Equal(t, "CODE-INJECTION-001", deduped[1].PatternID)
+	assert.Equal(t, uint32(4), deduped[2].SinkLine)
+	assert.Empty(t, deduped[0].AlternatePaths, "identical paths are not alternates")
+	assert.Empty(t, DedupeMatches(nil))
+}
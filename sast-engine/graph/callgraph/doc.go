@@ -90,4 +90,14 @@
 //
 //	// Analyze for security patterns
 //	matches := callgraph.AnalyzePatterns(callGraph, patternRegistry)
+//
+// Or run the whole pipeline in one call:
+//
+//	result, err := callgraph.Analyze(projectPath, callgraph.AnalyzeOptions{})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, match := range result.Matches {
+//	    fmt.Printf("%s: %s -> %s\n", match.PatternName, match.SourceFQN, match.SinkFQN)
+//	}
 package callgraph
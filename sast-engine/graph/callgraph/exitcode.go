@@ -0,0 +1,32 @@
+package callgraph
+
+import "github.com/shivasurya/code-pathfinder/sast-engine/output"
+
+// ExitCode is the CI gate decision for the analysis, as the scan command
+// makes it (see output.GateExitCode): output.ExitCodeFindings when any
+// match has one of the failOn severities, output.ExitCodeSuccess otherwise.
+// Suppressed matches, e.g. baselined (see AnalyzeOptions.Baseline) or
+// allowlisted ones, never fail. For a threshold, pass
+// output.SeveritiesAtOrAbove:
+//
+//	os.Exit(int(result.ExitCode(output.SeveritiesAtOrAbove("high"))))
+func (r *AnalysisResult) ExitCode(failOn []string) output.ExitCode {
+	return output.GateExitCode(gateFindings(r.Matches), failOn, false)
+}
+
+// ExitSummary explains ExitCode's decision (see output.ExitSummary).
+func (r *AnalysisResult) ExitSummary(failOn []string) string {
+	return output.ExitSummary(gateFindings(r.Matches), failOn)
+}
+
+// gateFindings returns the gate findings of matches.
+func gateFindings(matches []SecurityMatch) []output.GateFinding {
+	findings := make([]output.GateFinding, 0, len(matches))
+	for _, match := range matches {
+		findings = append(findings, output.GateFinding{
+			Severity:   match.Severity,
+			Suppressed: match.Suppression != "",
+		})
+	}
+	return findings
+}
@@ -0,0 +1,27 @@
+package callgraph
+
+import (
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/patterns"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalysisResult_ExitCode(t *testing.T) {
+	accepted := SecurityMatch{PatternID: "SQL-INJECTION-001", Severity: "critical", Suppression: patterns.SuppressedByBaseline}
+	result := &AnalysisResult{Matches: []SecurityMatch{
+		accepted,
+		{PatternID: "INSECURE-COOKIE-001", Severity: "medium"},
+	}}
+
+	// The baselined critical match does not fail the gate
+	assert.Equal(t, output.ExitCodeSuccess, result.ExitCode(output.SeveritiesAtOrAbove("high")))
+	assert.Equal(t, "passing: no findings match critical,high; 1 suppressed",
+		result.ExitSummary(output.SeveritiesAtOrAbove("high")))
+
+	assert.Equal(t, output.ExitCodeFindings, result.ExitCode(output.SeveritiesAtOrAbove("medium")))
+	assert.Equal(t, "failing: 1 finding match critical,high,medium (1 medium); 1 suppressed",
+		result.ExitSummary(output.SeveritiesAtOrAbove("medium")))
+	assert.Equal(t, output.ExitCodeSuccess, result.ExitCode(nil))
+}
@@ -56,8 +56,20 @@ func ExtractClassAttributes(
 	}
 	defer tree.Close()
 
-	root := tree.RootNode()
+	ExtractClassAttributesFromAST(filePath, sourceCode, tree.RootNode(), modulePath, typeEngine, attrRegistry)
+	return nil
+}
 
+// ExtractClassAttributesFromAST is like ExtractClassAttributes but works on an
+// already-parsed tree, so callers sharing an AST across passes skip re-parsing.
+func ExtractClassAttributesFromAST(
+	filePath string,
+	sourceCode []byte,
+	root *sitter.Node,
+	modulePath string,
+	typeEngine *resolution.TypeInferenceEngine,
+	attrRegistry *registry.AttributeRegistry,
+) {
 	// Find all class definitions in file
 	classes := findClassNodes(root, sourceCode)
 
@@ -98,12 +110,11 @@ func ExtractClassAttributes(
 		)
 
 		classAttrs.Attributes = attributeMap
+		classAttrs.StoredParameters = extractStoredParameters(methodNodes, sourceCode)
 
 		// Add to registry
 		attrRegistry.AddClassAttributes(classAttrs)
 	}
-
-	return nil
 }
 
 // findClassNodes finds all class_definition nodes in the AST.
@@ -307,6 +318,59 @@ func findSelfAttributeAssignments(methodNode *sitter.Node, sourceCode []byte) []
 	return assignments
 }
 
+// extractStoredParameters finds the __init__ parameters assigned unchanged
+// to an attribute (self.app = app) among a class's methods, mapping each
+// parameter to the attribute. Returns nil if there are none.
+func extractStoredParameters(methodNodes []*sitter.Node, sourceCode []byte) map[string]string {
+	var stored map[string]string
+	for _, methodNode := range methodNodes {
+		if extractMethodName(methodNode, sourceCode) != "__init__" {
+			continue
+		}
+		parameters := parameterNames(methodNode, sourceCode)
+		for _, assignment := range findSelfAttributeAssignments(methodNode, sourceCode) {
+			if assignment.RightSide.Type() != "identifier" {
+				continue
+			}
+			paramName := assignment.RightSide.Content(sourceCode)
+			if !parameters[paramName] || paramName == "self" {
+				continue
+			}
+			if stored == nil {
+				stored = make(map[string]string)
+			}
+			stored[paramName] = assignment.AttributeName
+		}
+	}
+	return stored
+}
+
+// parameterNames returns the names of a function's parameters, with or
+// without type annotations and defaults.
+func parameterNames(functionNode *sitter.Node, sourceCode []byte) map[string]bool {
+	names := make(map[string]bool)
+	params := functionNode.ChildByFieldName("parameters")
+	if params == nil {
+		return names
+	}
+	for i := 0; i < int(params.NamedChildCount()); i++ {
+		param := params.NamedChild(i)
+		switch param.Type() {
+		case "identifier":
+			names[param.Content(sourceCode)] = true
+		case "default_parameter", "typed_default_parameter":
+			if name := param.ChildByFieldName("name"); name != nil {
+				names[name.Content(sourceCode)] = true
+			}
+		case "typed_parameter":
+			if param.NamedChildCount() > 0 && param.NamedChild(0).Type() == "identifier" {
+				names[param.NamedChild(0).Content(sourceCode)] = true
+			}
+		}
+	}
+	return names
+}
+
 // inferAttributeType infers the type of an attribute using 6 strategies.
 func inferAttributeType(
 	assignment AttributeAssignment,
@@ -52,3 +52,33 @@ class SimpleWrapper:
 	require.NotNil(t, attr)
 	assert.Equal(t, "builtins.dict", attr.Type.TypeFQN)
 }
+
+func TestExtractClassAttributes_StoredParameters(t *testing.T) {
+	source := []byte(`
+class Middleware:
+    def __init__(self, app, name: str, level=1, *, handler: Handler = None):
+        self.app = app
+        self.label = name
+        self.level = level + 1
+        self.handler = handler or Handler()
+
+    def reset(self, app):
+        self.previous = app
+
+class Plain:
+    def __init__(self):
+        self.value = 1
+`)
+
+	moduleRegistry := core.NewModuleRegistry()
+	typeEngine := resolution.NewTypeInferenceEngine(moduleRegistry)
+	typeEngine.Attributes = registry.NewAttributeRegistry()
+
+	err := ExtractClassAttributes("test.py", source, "test_module", typeEngine, typeEngine.Attributes)
+	require.NoError(t, err)
+
+	// Only __init__ parameters assigned unchanged are stored
+	assert.Equal(t, map[string]string{"app": "app", "name": "label"},
+		typeEngine.Attributes.GetClassAttributes("test_module.Middleware").StoredParameters)
+	assert.Nil(t, typeEngine.Attributes.GetClassAttributes("test_module.Plain").StoredParameters)
+}
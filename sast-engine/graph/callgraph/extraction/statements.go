@@ -59,6 +59,12 @@ func ExtractStatements(filePath string, sourceCode []byte, functionNode *sitter.
 		case "return_statement":
 			stmt = extractReturn(actualNode, sourceCode)
 
+		case "assert_statement":
+			stmt = extractAssert(actualNode, sourceCode)
+
+		case "type_alias_statement":
+			stmt = extractTypeAlias(actualNode, sourceCode)
+
 		// Skip control flow statements (requires path sensitivity)
 		case "if_statement", "while_statement", "for_statement", "with_statement", "try_statement":
 			continue
@@ -468,6 +474,21 @@ func extractReturn(node *sitter.Node, sourceCode []byte) *core.Statement {
 	return stmt
 }
 
+// extractAssert processes assert statements.
+// Returns a Statement with the condition text and Uses for its identifiers;
+// the optional message is ignored.
+func extractAssert(node *sitter.Node, sourceCode []byte) *core.Statement {
+	condition := node.NamedChild(0)
+	if condition == nil {
+		return nil
+	}
+	return &core.Statement{
+		Type:      core.StatementTypeAssert,
+		Condition: condition.Content(sourceCode),
+		Uses:      extractIdentifiers(condition, sourceCode),
+	}
+}
+
 // extractIdentifiers recursively extracts all identifiers from an AST node.
 // Returns a deduplicated list of identifier names (filters out keywords).
 func extractIdentifiers(node *sitter.Node, sourceCode []byte) []string {
@@ -537,3 +558,47 @@ func ParsePythonFile(sourceCode []byte) (*sitter.Tree, error) {
 
 	return tree, nil
 }
+
+// ExtractStatementsFromBytes extracts the statements of a function from
+// in-memory Python source, such as an editor buffer that is not on disk.
+// functionName is the function's name, or "Class.method" for a method;
+// nested classes are dotted the same way. The first matching definition
+// is used.
+func ExtractStatementsFromBytes(src []byte, functionName string) ([]*core.Statement, error) {
+	tree, err := ParsePythonFile(src)
+	if err != nil {
+		return nil, err
+	}
+	defer tree.Close()
+
+	functionNode := findQualifiedFunction(tree.RootNode(), src, functionName, "")
+	if functionNode == nil {
+		return nil, fmt.Errorf("function %s not found", functionName)
+	}
+	return ExtractStatements("", src, functionNode)
+}
+
+// findQualifiedFunction returns the first function_definition whose dotted
+// name, qualified by its enclosing classes, equals name.
+func findQualifiedFunction(node *sitter.Node, sourceCode []byte, name, prefix string) *sitter.Node {
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+		childPrefix := prefix
+		switch child.Type() {
+		case "function_definition":
+			if nameNode := child.ChildByFieldName("name"); nameNode != nil {
+				if prefix+nameNode.Content(sourceCode) == name {
+					return child
+				}
+			}
+		case "class_definition":
+			if nameNode := child.ChildByFieldName("name"); nameNode != nil {
+				childPrefix = prefix + nameNode.Content(sourceCode) + "."
+			}
+		}
+		if found := findQualifiedFunction(child, sourceCode, name, childPrefix); found != nil {
+			return found
+		}
+	}
+	return nil
+}
@@ -316,6 +316,27 @@ def foo():
 	assert.Equal(t, 0, len(stmt.Uses))
 }
 
+func TestExtractStatements_Assert(t *testing.T) {
+	source := `
+def foo(request):
+    assert request.user.is_authenticated, "login required"
+`
+	tree, funcNode, sourceBytes := parsePythonFunction(t, source, "foo")
+	defer tree.Close()
+
+	statements, err := ExtractStatements("test.py", sourceBytes, funcNode)
+
+	require.NoError(t, err)
+	require.Equal(t, 1, len(statements))
+
+	stmt := statements[0]
+	assert.Equal(t, core.StatementTypeAssert, stmt.Type)
+	assert.Equal(t, "request.user.is_authenticated", stmt.Condition)
+	assert.Contains(t, stmt.Uses, "request")
+	assert.Equal(t, "", stmt.CallTarget)
+	assert.Equal(t, uint32(3), stmt.LineNumber)
+}
+
 //
 // ========== IDENTIFIER EXTRACTION TESTS ==========
 //
@@ -1348,3 +1369,29 @@ func TestExtractStatements_CallChain_AttributeAccess(t *testing.T) {
 func TestExtractReturn_NilNode(t *testing.T) {
 	assert.Nil(t, extractReturn(nil, []byte("")))
 }
+
+func TestExtractStatementsFromBytes(t *testing.T) {
+	src := []byte(`
+def helper():
+    return 1
+
+class Handler:
+    def get(self, request):
+        name = request.args.get("name")
+        return render(name)
+`)
+
+	statements, err := ExtractStatementsFromBytes(src, "Handler.get")
+	require.NoError(t, err)
+	require.Len(t, statements, 2)
+	assert.Equal(t, "name", statements[0].Def)
+	assert.Equal(t, uint32(7), statements[0].LineNumber)
+	assert.Equal(t, core.StatementTypeReturn, statements[1].Type)
+
+	statements, err = ExtractStatementsFromBytes(src, "helper")
+	require.NoError(t, err)
+	assert.Len(t, statements, 1)
+
+	_, err = ExtractStatementsFromBytes(src, "get")
+	assert.Error(t, err, "methods are only found by their class-qualified name")
+}
@@ -0,0 +1,124 @@
+package extraction
+
+import (
+	"slices"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Python 3.12 (PEP 695) declares type parameters in brackets after the name
+// of a function, class, or type alias:
+//
+//	def first[T](items: list[T]) -> T: ...
+//	class Box[T: Item]: ...
+//	type Pair[K, V] = tuple[K, V]
+//
+// A type parameter is not a class: an annotation naming one says nothing
+// about the argument's type beyond its bound, if any.
+
+// typeParameter is a declared type parameter and its bound ("" if it has
+// none, or is constrained to several types).
+type typeParameter struct {
+	name  string
+	bound string
+}
+
+// typeParameters returns the type parameters of a type_parameter node, in
+// declaration order. Defaults (PEP 696) do not parse yet and are skipped.
+func typeParameters(node *sitter.Node, sourceCode []byte) []typeParameter {
+	if node == nil {
+		return nil
+	}
+	var params []typeParameter
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		param := node.NamedChild(i)
+		if param.Type() != "type" || param.NamedChildCount() == 0 {
+			continue
+		}
+		declared := param.NamedChild(0)
+		switch declared.Type() {
+		case "identifier":
+			params = append(params, typeParameter{name: declared.Content(sourceCode)})
+		case "splat_type":
+			// *Ts and **P
+			if declared.NamedChildCount() > 0 {
+				params = append(params, typeParameter{name: declared.NamedChild(0).Content(sourceCode)})
+			}
+		case "constrained_type":
+			// T: Bound, or T: (A, B) for constraints
+			if declared.NamedChildCount() < 2 {
+				continue
+			}
+			tp := typeParameter{name: declared.NamedChild(0).Content(sourceCode)}
+			if bound := declared.NamedChild(1); bound.NamedChildCount() == 0 || bound.NamedChild(0).Type() != "tuple" {
+				tp.bound = bound.Content(sourceCode)
+			}
+			params = append(params, tp)
+		}
+	}
+	return params
+}
+
+// typeParametersInScope returns the type parameters visible in a function:
+// its own and those of its enclosing classes and functions. Inner
+// declarations shadow outer ones.
+func typeParametersInScope(funcNode *sitter.Node, sourceCode []byte) map[string]typeParameter {
+	var definitions []*sitter.Node
+	for node := funcNode; node != nil; node = node.Parent() {
+		if node.Type() == "function_definition" || node.Type() == "class_definition" {
+			definitions = append(definitions, node)
+		}
+	}
+	slices.Reverse(definitions)
+
+	var inScope map[string]typeParameter
+	for _, definition := range definitions {
+		for _, param := range typeParameters(definition.ChildByFieldName("type_parameters"), sourceCode) {
+			if inScope == nil {
+				inScope = make(map[string]typeParameter)
+			}
+			inScope[param.name] = param
+		}
+	}
+	return inScope
+}
+
+// extractTypeAlias processes type alias statements like
+// "type Pair[K, V] = tuple[K, V]". Returns a Statement defining the alias,
+// with its type parameters and Uses for the names its value refers to,
+// other than those parameters.
+func extractTypeAlias(node *sitter.Node, sourceCode []byte) *core.Statement {
+	if node.NamedChildCount() < 2 {
+		return nil
+	}
+	left, value := node.NamedChild(0), node.NamedChild(1)
+	if left.NamedChildCount() == 0 {
+		return nil
+	}
+
+	stmt := &core.Statement{Type: core.StatementTypeTypeAlias}
+	switch alias := left.NamedChild(0); alias.Type() {
+	case "identifier":
+		stmt.Def = alias.Content(sourceCode)
+	case "generic_type":
+		if alias.NamedChildCount() == 0 {
+			return nil
+		}
+		stmt.Def = alias.NamedChild(0).Content(sourceCode)
+		for i := 1; i < int(alias.NamedChildCount()); i++ {
+			if child := alias.NamedChild(i); child.Type() == "type_parameter" {
+				for _, param := range typeParameters(child, sourceCode) {
+					stmt.TypeParameters = append(stmt.TypeParameters, param.name)
+				}
+			}
+		}
+	default:
+		return nil
+	}
+
+	stmt.Uses = slices.DeleteFunc(extractIdentifiers(value, sourceCode), func(name string) bool {
+		return slices.Contains(stmt.TypeParameters, name)
+	})
+	return stmt
+}
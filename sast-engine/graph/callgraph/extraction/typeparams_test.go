@@ -0,0 +1,121 @@
+package extraction
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/registry"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/resolution"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypeParameters(t *testing.T) {
+	sourceCode := []byte("def f[T, U: User, V: (int, str), *Ts, **P](x): pass\n")
+	tree, err := ParsePythonFile(sourceCode)
+	require.NoError(t, err)
+	defer tree.Close()
+
+	funcNode := tree.RootNode().NamedChild(0)
+	params := typeParameters(funcNode.ChildByFieldName("type_parameters"), sourceCode)
+	assert.Equal(t, []typeParameter{
+		{name: "T"},
+		{name: "U", bound: "User"},
+		{name: "V"},
+		{name: "Ts"},
+		{name: "P"},
+	}, params)
+
+	assert.Nil(t, typeParameters(nil, sourceCode))
+}
+
+func TestTypeParametersInScope(t *testing.T) {
+	sourceCode := []byte(`
+class Box[T: Item]:
+    def get[U](self, fallback: U) -> T | U:
+        pass
+
+    def put[T](self, item: T):
+        pass
+`)
+	tree, err := ParsePythonFile(sourceCode)
+	require.NoError(t, err)
+	defer tree.Close()
+
+	get := findQualifiedFunction(tree.RootNode(), sourceCode, "Box.get", "")
+	require.NotNil(t, get)
+	assert.Equal(t, map[string]typeParameter{
+		"T": {name: "T", bound: "Item"},
+		"U": {name: "U"},
+	}, typeParametersInScope(get, sourceCode))
+
+	put := findQualifiedFunction(tree.RootNode(), sourceCode, "Box.put", "")
+	require.NotNil(t, put)
+	assert.Equal(t, typeParameter{name: "T"}, typeParametersInScope(put, sourceCode)["T"], "method type parameter shadows the class's")
+}
+
+func TestExtractStatements_TypeAlias(t *testing.T) {
+	sourceCode, err := os.ReadFile("../../../test-fixtures/python/type_params/generics.py")
+	require.NoError(t, err)
+
+	statements, err := ExtractStatementsFromBytes(sourceCode, "pairs")
+	require.NoError(t, err)
+	require.Len(t, statements, 4)
+
+	assert.Equal(t, core.StatementTypeTypeAlias, statements[0].Type)
+	assert.Equal(t, "Pair", statements[0].Def)
+	assert.Equal(t, []string{"tuple", "K", "V"}, statements[0].Uses)
+	assert.Empty(t, statements[0].TypeParameters)
+
+	assert.Equal(t, core.StatementTypeTypeAlias, statements[1].Type)
+	assert.Equal(t, "Table", statements[1].Def)
+	assert.Equal(t, []string{"R"}, statements[1].TypeParameters)
+	assert.Equal(t, []string{"dict", "list", "Pair"}, statements[1].Uses, "the alias's own type parameters are not uses")
+
+	assert.Equal(t, "zipped", statements[2].Def)
+	assert.Equal(t, []string{"zip", "keys", "values"}, statements[2].Uses)
+	assert.Equal(t, core.StatementTypeReturn, statements[3].Type)
+}
+
+func TestExtractVariableAssignments_TypeParameterAnnotations(t *testing.T) {
+	fixture, err := filepath.Abs("../../../test-fixtures/python/type_params")
+	require.NoError(t, err)
+	filePath := filepath.Join(fixture, "generics.py")
+	sourceCode, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+
+	modRegistry, err := registry.BuildModuleRegistry(fixture, false)
+	require.NoError(t, err)
+	typeEngine := resolution.NewTypeInferenceEngine(modRegistry)
+	typeEngine.Builtins = registry.NewBuiltinRegistry()
+
+	require.NoError(t, ExtractVariableAssignments(filePath, sourceCode, typeEngine, modRegistry, typeEngine.Builtins, nil))
+
+	// annotatedType returns the type a parameter's annotation binds
+	annotatedType := func(scopeFQN, name string) string {
+		scope := typeEngine.GetScope(scopeFQN)
+		require.NotNil(t, scope, scopeFQN)
+		for _, binding := range scope.Variables[name] {
+			if binding.Type.Source == "param_annotation" {
+				return binding.Type.TypeFQN
+			}
+		}
+		return ""
+	}
+
+	tests := []struct {
+		scope, name, typeFQN string
+	}{
+		{"generics.first", "items", "builtins.list"},
+		{"generics.first", "default", ""},
+		{"generics.load_user", "user", "User"},
+		{"generics.Box.__init__", "item", ""},
+		{"generics.Box.get", "fallback", ""},
+		{"generics.pairs", "limit", "builtins.int"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.typeFQN, annotatedType(tt.scope, tt.name), "%s in %s", tt.name, tt.scope)
+	}
+}
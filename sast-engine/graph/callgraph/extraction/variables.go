@@ -57,29 +57,43 @@ func ExtractVariableAssignments(
 	}
 	defer tree.Close()
 
+	ExtractVariableAssignmentsFromAST(filePath, sourceCode, tree.RootNode(), typeEngine, registry, builtinRegistry, importMap)
+	return nil
+}
+
+// ExtractVariableAssignmentsFromAST is like ExtractVariableAssignments but works
+// on an already-parsed tree, so callers sharing an AST across passes skip re-parsing.
+func ExtractVariableAssignmentsFromAST(
+	filePath string,
+	sourceCode []byte,
+	root *sitter.Node,
+	typeEngine *resolution.TypeInferenceEngine,
+	registry *core.ModuleRegistry,
+	builtinRegistry *registry.BuiltinRegistry,
+	importMap *core.ImportMap,
+) {
 	// Get module FQN for this file
 	modulePath, exists := registry.FileToModule[filePath]
 	if !exists {
 		// If file not in registry, skip (e.g., external files)
-		return nil
+		return
 	}
 
 	// Traverse AST to find assignments
 	// Class context is tracked during traversal by detecting class_definition nodes
 	traverseForAssignments(
-		tree.RootNode(),
+		root,
 		sourceCode,
 		filePath,
 		modulePath,
 		"",
 		"",
+		nil,
 		typeEngine,
 		registry,
 		builtinRegistry,
 		importMap,
 	)
-
-	return nil
 }
 
 // traverseForAssignments recursively traverses AST to find assignment statements.
@@ -91,6 +105,8 @@ func ExtractVariableAssignments(
 //   - modulePath: module FQN
 //   - currentFunction: current function FQN (empty if module-level)
 //   - currentClass: current class name (empty if not in a class)
+//   - rebound: names the current function declares global or nonlocal,
+//     mapped to the scope their assignments bind in
 //   - typeEngine: type inference engine
 //   - builtinRegistry: builtin types registry
 //   - importMap: import mappings for resolving class instantiations
@@ -101,6 +117,7 @@ func traverseForAssignments(
 	modulePath string,
 	currentFunction string,
 	currentClass string,
+	rebound map[string]string,
 	typeEngine *resolution.TypeInferenceEngine,
 	registry *core.ModuleRegistry,
 	builtinRegistry *registry.BuiltinRegistry,
@@ -124,6 +141,7 @@ func traverseForAssignments(
 	if nodeType == "function_definition" {
 		functionName := extractFunctionName(node, sourceCode)
 		if functionName != "" {
+			enclosingFunction := currentFunction
 			// Match Pass 1's FQN scheme exactly so call-site lookups
 			// (resolveCallTarget → typeEngine.GetScope(callerFQN)) find the
 			// bindings created here.
@@ -155,6 +173,12 @@ func traverseForAssignments(
 				typeEngine.AddScope(resolution.NewFunctionScope(currentFunction))
 			}
 
+			// global and nonlocal names bind in an outer scope
+			rebound = declaredRebindings(node, sourceCode, modulePath, enclosingFunction, rebound)
+			if len(rebound) > 0 {
+				typeEngine.GetScope(currentFunction).Rebound = rebound
+			}
+
 			// Extract typed parameters as variable bindings so method calls
 			// on them (e.g., `bundle.extract()` where `bundle: tarfile.TarFile`)
 			// can be resolved by Phase B receiver-type matching.
@@ -167,6 +191,19 @@ func traverseForAssignments(
 				builtinRegistry,
 				importMap,
 			)
+
+			// Legacy code declares parameter types in a signature type
+			// comment instead: `def f(bundle):  # type: (tarfile.TarFile) -> None`
+			processSignatureTypeComment(
+				node,
+				sourceCode,
+				filePath,
+				modulePath,
+				currentFunction,
+				typeEngine,
+				builtinRegistry,
+				importMap,
+			)
 		}
 	}
 
@@ -178,6 +215,7 @@ func traverseForAssignments(
 			filePath,
 			modulePath,
 			currentFunction,
+			rebound,
 			typeEngine,
 			registry,
 			builtinRegistry,
@@ -195,6 +233,7 @@ func traverseForAssignments(
 			filePath,
 			modulePath,
 			currentFunction,
+			rebound,
 			typeEngine,
 			registry,
 			builtinRegistry,
@@ -202,6 +241,20 @@ func traverseForAssignments(
 		)
 	}
 
+	// Process `for var in gen()` bindings: the loop variable takes the type
+	// the generator yields
+	if nodeType == "for_statement" {
+		processForStatement(
+			node,
+			sourceCode,
+			filePath,
+			modulePath,
+			currentFunction,
+			rebound,
+			typeEngine,
+		)
+	}
+
 	// Recurse to children
 	for i := 0; i < int(node.ChildCount()); i++ {
 		child := node.Child(i)
@@ -212,6 +265,7 @@ func traverseForAssignments(
 			modulePath,
 			currentFunction,
 			currentClass,
+			rebound,
 			typeEngine,
 			registry,
 			builtinRegistry,
@@ -226,6 +280,7 @@ func traverseForAssignments(
 //   - var = "literal" (literal inference)
 //   - var = func() (return type inference - Task 2 Phase 1)
 //   - var = obj.method() (method return type - Task 2 Phase 1)
+//   - var = ...  # type: T (declared by a type comment)
 //
 // Parameters:
 //   - node: assignment AST node
@@ -242,6 +297,7 @@ func processAssignment(
 	filePath string,
 	modulePath string,
 	currentFunction string,
+	rebound map[string]string,
 	typeEngine *resolution.TypeInferenceEngine,
 	registry *core.ModuleRegistry,
 	builtinRegistry *registry.BuiltinRegistry,
@@ -282,8 +338,12 @@ func processAssignment(
 		return
 	}
 
-	// Infer type from right side
-	typeInfo := inferTypeFromExpression(rightNode, sourceCode, modulePath, registry, builtinRegistry, importMap)
+	// A type comment declares the type outright: `user = load(row)  # type: User`.
+	// Otherwise infer it from the right side
+	typeInfo := resolution.ResolveTypeComment(resolution.VariableTypeComment(node, sourceCode), modulePath, importMap, builtinRegistry)
+	if typeInfo == nil {
+		typeInfo = inferTypeFromExpression(rightNode, sourceCode, modulePath, registry, builtinRegistry, importMap)
+	}
 	if typeInfo == nil {
 		return
 	}
@@ -307,12 +367,13 @@ func processAssignment(
 		}
 	}
 
-	// Add to function scope or module-level scope
-	scopeFQN := currentFunction
-	if scopeFQN == "" {
-		// Module-level variable - use module path as scope name
-		scopeFQN = modulePath
-	}
+	// Track the names a container literal holds, so that indexing and
+	// calling it (handlers[key]()) can be linked to each of them
+	binding.Callables = containerReferences(rightNode, sourceCode)
+
+	// Add to function scope, module-level scope, or the outer scope a
+	// global/nonlocal declaration binds it in
+	scopeFQN := assignmentScope(varName, modulePath, currentFunction, rebound)
 
 	scope := typeEngine.GetScope(scopeFQN)
 	if scope == nil {
@@ -323,6 +384,95 @@ func processAssignment(
 	scope.Variables[varName] = append(scope.Variables[varName], binding)
 }
 
+// containerReferences returns the names and dotted references a list,
+// tuple, or set literal holds, or that a dict literal holds as values, in
+// order. Other elements, such as literals and calls, are skipped.
+func containerReferences(node *sitter.Node, sourceCode []byte) []string {
+	var values []*sitter.Node
+	switch node.Type() {
+	case "dictionary":
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			if pair := node.NamedChild(i); pair.Type() == "pair" {
+				if value := pair.ChildByFieldName("value"); value != nil {
+					values = append(values, value)
+				}
+			}
+		}
+	case "list", "tuple", "set":
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			values = append(values, node.NamedChild(i))
+		}
+	default:
+		return nil
+	}
+
+	var references []string
+	for _, value := range values {
+		if value.Type() == "identifier" || value.Type() == "attribute" {
+			references = append(references, value.Content(sourceCode))
+		}
+	}
+	return references
+}
+
+// assignmentScope returns the FQN of the scope an assignment to varName
+// binds in: the scope a global or nonlocal declaration names, else the
+// current function, else the module.
+func assignmentScope(varName, modulePath, currentFunction string, rebound map[string]string) string {
+	if scopeFQN, ok := rebound[varName]; ok {
+		return scopeFQN
+	}
+	if currentFunction == "" {
+		// Module-level variable - use module path as scope name
+		return modulePath
+	}
+	return currentFunction
+}
+
+// declaredRebindings returns the names a function declares global or
+// nonlocal, mapped to the scope their assignments bind in:
+//
+//	global x    → modulePath
+//	nonlocal x  → enclosingFunction, or the scope it rebinds x to
+//
+// Declarations inside nested functions and classes belong to those scopes
+// and are ignored. Returns nil if the function declares neither.
+func declaredRebindings(funcNode *sitter.Node, sourceCode []byte, modulePath, enclosingFunction string, enclosing map[string]string) map[string]string {
+	var rebound map[string]string
+	var collect func(node *sitter.Node)
+	collect = func(node *sitter.Node) {
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			child := node.NamedChild(i)
+			switch child.Type() {
+			case "function_definition", "class_definition", "lambda":
+				continue
+			case "global_statement", "nonlocal_statement":
+				scopeFQN := modulePath
+				for j := 0; j < int(child.NamedChildCount()); j++ {
+					nameNode := child.NamedChild(j)
+					if nameNode.Type() != "identifier" {
+						continue
+					}
+					name := nameNode.Content(sourceCode)
+					if child.Type() == "nonlocal_statement" {
+						scopeFQN = assignmentScope(name, modulePath, enclosingFunction, enclosing)
+					}
+					if rebound == nil {
+						rebound = make(map[string]string)
+					}
+					rebound[name] = scopeFQN
+				}
+			default:
+				collect(child)
+			}
+		}
+	}
+	if body := funcNode.ChildByFieldName("body"); body != nil {
+		collect(body)
+	}
+	return rebound
+}
+
 // processTypedParameters walks a function definition's parameter list and
 // adds a typed VariableBinding for each `typed_parameter` /
 // `typed_default_parameter`. This enables receiver-type matching for code
@@ -334,6 +484,10 @@ func processAssignment(
 //   - Bare identifiers → look up in importMap; if found, use FQN
 //   - Builtin names (int, str, list, ...) → builtins.<name>
 //   - Otherwise → use the stripped name as-is (best-effort)
+//
+// A PEP 695 type parameter of the function or an enclosing class stands
+// for its bound (def f[T: User](u: T) binds u to User); unbound ones bind
+// no type.
 func processTypedParameters(
 	funcNode *sitter.Node,
 	sourceCode []byte,
@@ -352,6 +506,7 @@ func processTypedParameters(
 	if scope == nil {
 		return
 	}
+	typeParams := typeParametersInScope(funcNode, sourceCode)
 
 	for i := 0; i < int(params.ChildCount()); i++ {
 		param := params.Child(i)
@@ -386,7 +541,15 @@ func processTypedParameters(
 			continue
 		}
 
-		typeFQN := resolveParamType(typeNode.Content(sourceCode), importMap, builtinRegistry)
+		annotation := typeNode.Content(sourceCode)
+		if param, ok := typeParams[stripTypeHintWrappers(strings.TrimSpace(annotation))]; ok {
+			// A type parameter: only its bound is known
+			if param.bound == "" {
+				continue
+			}
+			annotation = param.bound
+		}
+		typeFQN := resolveParamType(annotation, importMap, builtinRegistry)
 		if typeFQN == "" {
 			continue
 		}
@@ -408,6 +571,73 @@ func processTypedParameters(
 	}
 }
 
+// processSignatureTypeComment adds a typed VariableBinding for each
+// parameter whose type a signature type comment declares, pairing the
+// comment's types with the parameters in order. self and cls are not listed
+// in the comment. Parameters with an inline annotation keep it.
+func processSignatureTypeComment(
+	funcNode *sitter.Node,
+	sourceCode []byte,
+	filePath string,
+	modulePath string,
+	currentFunction string,
+	typeEngine *resolution.TypeInferenceEngine,
+	builtinRegistry *registry.BuiltinRegistry,
+	importMap *core.ImportMap,
+) {
+	declared, _, ok := resolution.SignatureTypeComment(funcNode, sourceCode)
+	params := funcNode.ChildByFieldName("parameters")
+	scope := typeEngine.GetScope(currentFunction)
+	if !ok || params == nil || scope == nil {
+		return
+	}
+
+	position := 0
+	for i := 0; i < int(params.NamedChildCount()); i++ {
+		param := params.NamedChild(i)
+		identNode := param
+		switch param.Type() {
+		case "identifier":
+		case "default_parameter":
+			identNode = param.ChildByFieldName("name")
+		case "list_splat_pattern", "dictionary_splat_pattern", "typed_parameter", "typed_default_parameter":
+			identNode = nil
+		default:
+			// Separators such as * and / take no type
+			continue
+		}
+		if identNode != nil {
+			name := identNode.Content(sourceCode)
+			if name == "self" || name == "cls" {
+				continue
+			}
+		}
+		if position >= len(declared) {
+			return
+		}
+		paramType := declared[position]
+		position++
+		if identNode == nil || identNode.Type() != "identifier" {
+			continue
+		}
+
+		typeInfo := resolution.ResolveTypeComment(paramType, modulePath, importMap, builtinRegistry)
+		if typeInfo == nil {
+			continue
+		}
+		paramName := identNode.Content(sourceCode)
+		scope.Variables[paramName] = append(scope.Variables[paramName], &resolution.VariableBinding{
+			VarName: paramName,
+			Type:    typeInfo,
+			Location: resolution.Location{
+				File:   filePath,
+				Line:   identNode.StartPoint().Row + 1,
+				Column: identNode.StartPoint().Column + 1,
+			},
+		})
+	}
+}
+
 // resolveParamType normalizes a parameter annotation source string to an FQN.
 func resolveParamType(annotation string, importMap *core.ImportMap, builtinRegistry *registry.BuiltinRegistry) string {
 	trimmed := strings.TrimSpace(annotation)
@@ -467,6 +697,7 @@ func processWithStatement(
 	filePath string,
 	modulePath string,
 	currentFunction string,
+	rebound map[string]string,
 	typeEngine *resolution.TypeInferenceEngine,
 	registry *core.ModuleRegistry,
 	builtinRegistry *registry.BuiltinRegistry,
@@ -538,6 +769,7 @@ func processWithStatement(
 					Line:   aliasNode.StartPoint().Row + 1,
 					Column: aliasNode.StartPoint().Column + 1,
 				},
+				WithTarget: true,
 			}
 			if valueNode.Type() == "call" {
 				if calleeName := extractCalleeName(valueNode, sourceCode); calleeName != "" {
@@ -545,10 +777,7 @@ func processWithStatement(
 				}
 			}
 
-			scopeFQN := currentFunction
-			if scopeFQN == "" {
-				scopeFQN = modulePath
-			}
+			scopeFQN := assignmentScope(varName, modulePath, currentFunction, rebound)
 			scope := typeEngine.GetScope(scopeFQN)
 			if scope == nil {
 				scope = resolution.NewFunctionScope(scopeFQN)
@@ -559,6 +788,77 @@ func processWithStatement(
 	}
 }
 
+// processForStatement binds the variable of `for var in f()` to an element
+// of f's result, resolved to what f yields when f is a generator function
+// (see UpdateVariableBindingsWithFunctionReturns). Iterating a variable
+// assigned from a call (`items = f()`, then `for var in items`) binds the
+// same way. Tuple targets and other iterables are skipped.
+func processForStatement(
+	node *sitter.Node,
+	sourceCode []byte,
+	filePath string,
+	modulePath string,
+	currentFunction string,
+	rebound map[string]string,
+	typeEngine *resolution.TypeInferenceEngine,
+) {
+	leftNode := node.ChildByFieldName("left")
+	rightNode := node.ChildByFieldName("right")
+	if leftNode == nil || rightNode == nil || leftNode.Type() != "identifier" {
+		return
+	}
+	varName := leftNode.Content(sourceCode)
+
+	var iterated string
+	switch rightNode.Type() {
+	case "call":
+		if function := rightNode.ChildByFieldName("function"); function != nil {
+			iterated = extractCalleeName(function, sourceCode)
+		}
+	case "identifier":
+		// The iterable's binding so far holds a placeholder for its call
+		scope := typeEngine.GetScope(assignmentScope(rightNode.Content(sourceCode), modulePath, currentFunction, rebound))
+		if scope == nil {
+			return
+		}
+		binding := scope.GetVariable(rightNode.Content(sourceCode))
+		if binding == nil || binding.Type == nil || binding.WithTarget || binding.ForTarget {
+			return
+		}
+		iterated, _ = strings.CutPrefix(binding.Type.TypeFQN, "call:")
+		if iterated == binding.Type.TypeFQN {
+			return
+		}
+	}
+	if iterated == "" {
+		return
+	}
+
+	binding := &resolution.VariableBinding{
+		VarName: varName,
+		Type: &core.TypeInfo{
+			TypeFQN:    "call:" + iterated,
+			Confidence: 0.5,
+			Source:     "function_call_placeholder",
+		},
+		Location: resolution.Location{
+			File:   filePath,
+			Line:   leftNode.StartPoint().Row + 1,
+			Column: leftNode.StartPoint().Column + 1,
+		},
+		AssignedFrom: iterated,
+		ForTarget:    true,
+	}
+
+	scopeFQN := assignmentScope(varName, modulePath, currentFunction, rebound)
+	scope := typeEngine.GetScope(scopeFQN)
+	if scope == nil {
+		scope = resolution.NewFunctionScope(scopeFQN)
+		typeEngine.AddScope(scope)
+	}
+	scope.Variables[varName] = append(scope.Variables[varName], binding)
+}
+
 // inferTypeFromExpression infers the type of an expression.
 //
 // Currently handles:
@@ -610,6 +910,12 @@ func inferTypeFromExpression(
 			return classType
 		}
 
+		// d.get(key, default) and getattr(obj, name, default) return the
+		// default when the key or attribute is missing
+		if defaultType := inferFromDefaultArgument(node, sourceCode, modulePath, registry, builtinRegistry, importMap); defaultType != nil {
+			return defaultType
+		}
+
 		// Not a class instantiation - create placeholder for function call
 		// This will be resolved later by UpdateVariableBindingsWithFunctionReturns()
 		for i := 0; i < int(node.ChildCount()); i++ {
@@ -627,6 +933,20 @@ func inferTypeFromExpression(
 		}
 	}
 
+	// Handle attribute reads - create placeholder for enum member access
+	// (Color.RED, Color.RED.value, c.name). Resolved by
+	// ResolveEnumMemberBindings(), which drops non-enum reads.
+	if nodeType == "attribute" {
+		if chain := attributeChain(node, sourceCode); chain != "" {
+			return &core.TypeInfo{
+				TypeFQN:    "attr:" + chain,
+				Confidence: 0.5,
+				Source:     "attribute_placeholder",
+			}
+		}
+		return nil
+	}
+
 	// Handle boolean operators (or, and)
 	// Supports conditional patterns: x = param or Class()
 	if nodeType == "boolean_operator" {
@@ -788,6 +1108,100 @@ func inferFromBooleanOp(
 	return nil
 }
 
+// inferFromDefaultArgument infers the type of a lookup with a default:
+// d.get(key, default) or getattr(obj, name, default).
+//
+// The result is the union of the looked-up value's type and the default's.
+// Bindings hold a single type and the value types of dicts and dynamic
+// attributes are not tracked, so the default's type stands for the union:
+//
+//   - config.get("backend", LocalBackend()) → LocalBackend (confidence: 0.72)
+//   - getattr(settings, "cache", MemoryCache()) → MemoryCache
+//   - config.get("backend", None) → nil (the value type is unknown)
+//
+// get calls on imported names (requests.get(url, params)) are not dict
+// lookups and are skipped.
+//
+// Returns:
+//   - TypeInfo of the default with reduced confidence, or nil
+func inferFromDefaultArgument(
+	node *sitter.Node,
+	sourceCode []byte,
+	modulePath string,
+	registry *core.ModuleRegistry,
+	builtinRegistry *registry.BuiltinRegistry,
+	importMap *core.ImportMap,
+) *core.TypeInfo {
+	function := node.ChildByFieldName("function")
+	arguments := node.ChildByFieldName("arguments")
+	if function == nil || arguments == nil {
+		return nil
+	}
+
+	var positional []*sitter.Node
+	for i := 0; i < int(arguments.NamedChildCount()); i++ {
+		arg := arguments.NamedChild(i)
+		switch arg.Type() {
+		case "comment":
+		case "keyword_argument", "list_splat", "dictionary_splat":
+			return nil
+		default:
+			positional = append(positional, arg)
+		}
+	}
+
+	var defaultNode *sitter.Node
+	var source string
+	switch {
+	case function.Type() == "identifier" && function.Content(sourceCode) == "getattr" && len(positional) == 3:
+		defaultNode, source = positional[2], "getattr_default_"
+	case function.Type() == "attribute" && len(positional) == 2:
+		attr := function.ChildByFieldName("attribute")
+		if attr == nil || attr.Content(sourceCode) != "get" {
+			return nil
+		}
+		receiver := attributeChain(function.ChildByFieldName("object"), sourceCode)
+		root, _, _ := strings.Cut(receiver, ".")
+		if importMap != nil {
+			if _, imported := importMap.Resolve(root); imported {
+				return nil
+			}
+		}
+		defaultNode, source = positional[1], "dict_get_default_"
+	default:
+		return nil
+	}
+
+	defaultType := inferTypeFromExpression(defaultNode, sourceCode, modulePath, registry, builtinRegistry, importMap)
+	if defaultType == nil || defaultType.TypeFQN == "builtins.NoneType" {
+		return nil
+	}
+	defaultType.Confidence *= 0.9
+	defaultType.Source = source + defaultType.Source
+	return defaultType
+}
+
+// attributeChain returns the dotted name of an attribute read made only of
+// identifiers (e.g., "Color.RED.value"), or "" for chains through calls or
+// subscripts.
+func attributeChain(node *sitter.Node, sourceCode []byte) string {
+	if node == nil {
+		return ""
+	}
+	switch node.Type() {
+	case "identifier":
+		return node.Content(sourceCode)
+	case "attribute":
+		object := attributeChain(node.ChildByFieldName("object"), sourceCode)
+		attr := node.ChildByFieldName("attribute")
+		if object == "" || attr == nil {
+			return ""
+		}
+		return object + "." + attr.Content(sourceCode)
+	}
+	return ""
+}
+
 // extractFunctionName extracts the function name from a function_definition node.
 func extractFunctionName(node *sitter.Node, sourceCode []byte) string {
 	if node.Type() != "function_definition" {
@@ -1142,3 +1142,165 @@ def f(count: int = 5):
 		assert.Contains(t, []string{"builtins.int", "int"}, countBindings[0].Type.TypeFQN)
 	}
 }
+
+func TestExtractVariableAssignments_GlobalAndNonlocal(t *testing.T) {
+	sourceCode := []byte(`
+counter = 0
+
+def reset():
+    global counter
+    counter = "reset"
+    local = 1
+
+def outer():
+    total = 0
+    def inner():
+        nonlocal total
+        total = "done"
+        def innermost():
+            nonlocal total
+            total = 3.5
+    def shadow():
+        total = []
+`)
+
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "test.py")
+	err := os.WriteFile(filePath, sourceCode, 0644)
+	assert.NoError(t, err)
+
+	modRegistry, err := registry.BuildModuleRegistry(tmpDir, false)
+	assert.NoError(t, err)
+
+	typeEngine := resolution.NewTypeInferenceEngine(modRegistry)
+	typeEngine.Builtins = registry.NewBuiltinRegistry()
+
+	err = ExtractVariableAssignments(filePath, sourceCode, typeEngine, modRegistry, typeEngine.Builtins, nil)
+	assert.NoError(t, err)
+
+	// global: the write binds in the module scope
+	moduleScope := typeEngine.GetScope("test")
+	assert.NotNil(t, moduleScope)
+	assert.Len(t, moduleScope.Variables["counter"], 2)
+	assert.Equal(t, "builtins.str", moduleScope.GetVariable("counter").Type.TypeFQN)
+
+	reset := typeEngine.GetScope("test.reset")
+	assert.NotNil(t, reset)
+	assert.NotContains(t, reset.Variables, "counter")
+	assert.Contains(t, reset.Variables, "local")
+	assert.Equal(t, map[string]string{"counter": "test"}, reset.Rebound)
+	assert.Equal(t, "builtins.str", typeEngine.GetVariableInScope("test.reset", "counter").Type.TypeFQN)
+
+	// nonlocal: the write binds in the enclosing function, through chains
+	outer := typeEngine.GetScope("test.outer")
+	assert.NotNil(t, outer)
+	assert.Len(t, outer.Variables["total"], 3)
+	assert.Equal(t, "builtins.float", outer.GetVariable("total").Type.TypeFQN)
+	assert.Equal(t, map[string]string{"total": "test.outer"}, typeEngine.GetScope("test.outer.inner.innermost").Rebound)
+
+	// A plain assignment in a nested function stays local
+	shadow := typeEngine.GetScope("test.outer.shadow")
+	assert.NotNil(t, shadow)
+	assert.Len(t, shadow.Variables["total"], 1)
+	assert.Nil(t, shadow.Rebound)
+}
+
+func TestExtractVariableAssignments_LookupDefaults(t *testing.T) {
+	sourceCode := []byte(`
+import requests
+
+def load(config, settings, url, params):
+    backend = config.get("backend", Backend())
+    timeout = config.get("timeout", 30)
+    cache = getattr(settings, "cache", [])
+    missing = config.get("missing", None)
+    named = config.get("named", default=Backend())
+    response = requests.get(url, params)
+`)
+
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "test.py")
+	err := os.WriteFile(filePath, sourceCode, 0644)
+	assert.NoError(t, err)
+
+	modRegistry, err := registry.BuildModuleRegistry(tmpDir, false)
+	assert.NoError(t, err)
+
+	typeEngine := resolution.NewTypeInferenceEngine(modRegistry)
+	typeEngine.Builtins = registry.NewBuiltinRegistry()
+
+	importMap := core.NewImportMap(filePath)
+	importMap.AddImport("requests", "requests")
+
+	err = ExtractVariableAssignments(filePath, sourceCode, typeEngine, modRegistry, typeEngine.Builtins, importMap)
+	assert.NoError(t, err)
+
+	scope := typeEngine.GetScope("test.load")
+	if !assert.NotNil(t, scope) {
+		return
+	}
+	binding := func(name string) *resolution.VariableBinding {
+		t.Helper()
+		if !assert.Len(t, scope.Variables[name], 1, name) {
+			return &resolution.VariableBinding{Type: &core.TypeInfo{}}
+		}
+		return scope.Variables[name][0]
+	}
+
+	backend := binding("backend")
+	assert.Equal(t, "test.Backend", backend.Type.TypeFQN)
+	assert.Equal(t, "dict_get_default_class_instantiation_local", backend.Type.Source)
+	assert.InDelta(t, 0.72, backend.Type.Confidence, 0.01) // 0.8 (base for class) * 0.9 (default penalty)
+
+	assert.Equal(t, "builtins.int", binding("timeout").Type.TypeFQN)
+
+	cache := binding("cache")
+	assert.Equal(t, "builtins.list", cache.Type.TypeFQN)
+	assert.Equal(t, "getattr_default_literal", cache.Type.Source)
+
+	// Lookups the default says nothing about stay call placeholders
+	assert.Equal(t, "call:config.get", binding("missing").Type.TypeFQN)
+	assert.Equal(t, "call:config.get", binding("named").Type.TypeFQN)
+	assert.Equal(t, "call:requests.get", binding("response").Type.TypeFQN)
+}
+
+func TestExtractVariableAssignments_ContainerCallables(t *testing.T) {
+	sourceCode := []byte(`
+def route(action):
+    handlers = {"a": handle_a, "b": views.handle_b, "c": "literal", "d": make()}
+    steps = [step_one, step_two]
+    pair = (first, 2)
+    sizes = {"small": 1}
+`)
+
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "test.py")
+	err := os.WriteFile(filePath, sourceCode, 0644)
+	assert.NoError(t, err)
+
+	modRegistry, err := registry.BuildModuleRegistry(tmpDir, false)
+	assert.NoError(t, err)
+
+	typeEngine := resolution.NewTypeInferenceEngine(modRegistry)
+	typeEngine.Builtins = registry.NewBuiltinRegistry()
+
+	err = ExtractVariableAssignments(filePath, sourceCode, typeEngine, modRegistry, typeEngine.Builtins, core.NewImportMap(filePath))
+	assert.NoError(t, err)
+
+	scope := typeEngine.GetScope("test.route")
+	if !assert.NotNil(t, scope) {
+		return
+	}
+	callables := func(name string) []string {
+		t.Helper()
+		if !assert.Len(t, scope.Variables[name], 1, name) {
+			return nil
+		}
+		return scope.Variables[name][0].Callables
+	}
+
+	assert.Equal(t, []string{"handle_a", "views.handle_b"}, callables("handlers"))
+	assert.Equal(t, []string{"step_one", "step_two"}, callables("steps"))
+	assert.Equal(t, []string{"first"}, callables("pair"))
+	assert.Empty(t, callables("sizes"))
+}
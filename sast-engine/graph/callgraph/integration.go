@@ -2,6 +2,8 @@ package callgraph
 
 import (
 	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
 	"os"
 	"strings"
 
@@ -15,6 +17,8 @@ import (
 
 // SecurityMatch represents a detected security vulnerability.
 type SecurityMatch struct {
+	ID            string   // Stable identifier of the finding (see FindingID)
+	PatternID     string   // ID of the matched pattern (e.g., "SQL-INJECTION-001")
 	Severity      string   // "critical", "high", "medium", "low"
 	PatternName   string   // Name of the security pattern
 	Description   string   // Description of the vulnerability
@@ -31,6 +35,18 @@ type SecurityMatch struct {
 	SinkLine      uint32   // Sink line number
 	SinkCode      string   // Sink code snippet
 	DataFlowPath  []string // Path from source to sink
+	Context       string   // Additional context (e.g., the purpose of a weak hash)
+	Explanation   []patterns.FlowStep // Source-to-sink steps of a taint match
+	Status        patterns.MatchStatus // Whether the data flow relies only on confident call resolutions
+	Remediation   string               // How to fix the match, if the pattern knows
+	Suppression   patterns.Suppression // Why the match is reported but exempted, if it is
+	Confidence    float64              // How certain the call graph is of DataFlowPath, 0.0-1.0
+	Fingerprint   string               // Identifies the finding across scans (see output.Fingerprint)
+	Score         float64              // Triage rank, 0.0-1.0 (see output.RiskScore)
+
+	// AlternatePaths holds the data flow paths of duplicate matches merged
+	// into this one (see DedupeMatches). The primary path is DataFlowPath.
+	AlternatePaths [][]string
 }
 
 // InitializeCallGraph builds a complete call graph with all analysis components.
@@ -64,15 +80,19 @@ func InitializeCallGraph(codeGraph *graph.CodeGraph, projectPath string, logger
 		return nil, nil, nil, err
 	}
 
-	// Initialize pattern registry
+	// Initialize pattern registry with the project's framework sources
 	patternRegistry := patterns.NewPatternRegistry()
+	patternRegistry.Framework = patterns.DetectRulesetFramework(callGraph)
 	patternRegistry.LoadDefaultPatterns()
 
 	return callGraph, moduleRegistry, patternRegistry, nil
 }
 
 // AnalyzePatterns detects security vulnerabilities using the pattern registry.
-// It analyzes the call graph against all loaded security patterns.
+// It analyzes the call graph against all enabled security patterns, returning
+// every match of each (see patterns.PatternRegistry.FindMatches).
+// Matches whose sink is allowlisted for their module (see
+// patterns.PatternRegistry.SinkAllowlist) are kept, with Suppression set.
 //
 // Parameters:
 //   - callGraph: the call graph to analyze
@@ -88,6 +108,24 @@ func AnalyzePatterns(callGraph *core.CallGraph, patternRegistry *patterns.Patter
 		patterns.PatternTypeSourceSink,
 		patterns.PatternTypeMissingSanitizer,
 		patterns.PatternTypeDangerousFunction,
+		patterns.PatternTypeOpenRedirect,
+		patterns.PatternTypeAutoescapeOff,
+		patterns.PatternTypeWeakCrypto,
+		patterns.PatternTypeInsecureTLS,
+		patterns.PatternTypeTemplateSink,
+		patterns.PatternTypeMassAssignment,
+		patterns.PatternTypeNoSQLInjection,
+		patterns.PatternTypeInsecureTempFile,
+		patterns.PatternTypeMutableDefault,
+		patterns.PatternTypeSSTI,
+		patterns.PatternTypeAssertAuth,
+		patterns.PatternTypeInsecureCookie,
+		patterns.PatternTypeSQLInjection,
+		patterns.PatternTypeLogFormat,
+		patterns.PatternTypeLDAPInjection,
+		patterns.PatternTypeXPathInjection,
+		patterns.PatternTypeIndexError,
+		patterns.PatternTypeHeaderInjection,
 	}
 
 	for _, patternType := range patternTypes {
@@ -96,10 +134,13 @@ func AnalyzePatterns(callGraph *core.CallGraph, patternRegistry *patterns.Patter
 
 		// Check each pattern against the call graph
 		for _, pattern := range patternsOfType {
-			match := patternRegistry.MatchPattern(pattern, callGraph)
-			if match.Matched {
+			if !patternRegistry.IsEnabled(pattern.ID) {
+				continue
+			}
+			for _, match := range patternRegistry.FindMatches(pattern, callGraph) {
 				// Convert PatternMatchDetails to SecurityMatch
 				securityMatch := SecurityMatch{
+					PatternID:    pattern.ID,
 					Severity:     string(pattern.Severity),
 					PatternName:  pattern.Name,
 					Description:  pattern.Description,
@@ -110,6 +151,11 @@ func AnalyzePatterns(callGraph *core.CallGraph, patternRegistry *patterns.Patter
 					SinkFQN:      match.SinkFQN,
 					SinkCall:     match.SinkCall,
 					DataFlowPath: match.DataFlowPath,
+					Context:      match.Context,
+					Explanation:  match.Explanation,
+					Status:       match.Status,
+					Confidence:   match.Confidence,
+					Remediation:  match.Remediation,
 				}
 
 				// Look up source location and code
@@ -117,9 +163,10 @@ func AnalyzePatterns(callGraph *core.CallGraph, patternRegistry *patterns.Patter
 					if callSites, ok := callGraph.CallSites[match.SourceFQN]; ok {
 						for _, site := range callSites {
 							if site.Target == match.SourceCall || site.TargetFQN == match.SourceCall {
-								securityMatch.SourceFile = site.Location.File
-								securityMatch.SourceLine = uint32(site.Location.Line)
-								securityMatch.SourceCode = getCodeSnippet(site.Location.File, site.Location.Line)
+								location := callGraph.OriginalLocation(site.Location)
+								securityMatch.SourceFile = location.File
+								securityMatch.SourceLine = uint32(location.Line)
+								securityMatch.SourceCode = sourceSnippet(callGraph, site.Location.File, site.Location.Line)
 								break
 							}
 						}
@@ -131,15 +178,34 @@ func AnalyzePatterns(callGraph *core.CallGraph, patternRegistry *patterns.Patter
 					if callSites, ok := callGraph.CallSites[match.SinkFQN]; ok {
 						for _, site := range callSites {
 							if site.Target == match.SinkCall || site.TargetFQN == match.SinkCall {
-								securityMatch.SinkFile = site.Location.File
-								securityMatch.SinkLine = uint32(site.Location.Line)
-								securityMatch.SinkCode = getCodeSnippet(site.Location.File, site.Location.Line)
+								location := callGraph.OriginalLocation(site.Location)
+								securityMatch.SinkFile = location.File
+								securityMatch.SinkLine = uint32(location.Line)
+								securityMatch.SinkCode = sourceSnippet(callGraph, site.Location.File, site.Location.Line)
+								if patternRegistry.IsSinkAllowlisted(site.TargetFQN, match.SinkFQN) {
+									securityMatch.Suppression = patterns.SuppressedByAllowlist
+								}
 								break
 							}
 						}
 					}
 				}
 
+				// Matches without a sink call (e.g., mutable defaults) are
+				// located at the function definition
+				if securityMatch.SinkFile == "" && match.SinkFQN != "" {
+					if function, ok := callGraph.Functions[match.SinkFQN]; ok {
+						location := callGraph.OriginalLocation(core.Location{File: function.File, Line: int(function.LineNumber)})
+						securityMatch.SinkFile = location.File
+						securityMatch.SinkLine = uint32(location.Line)
+						securityMatch.SinkCode = sourceSnippet(callGraph, function.File, int(function.LineNumber))
+					}
+				}
+
+				securityMatch.ID = FindingID(securityMatch)
+				securityMatch.Fingerprint = matchFingerprint(securityMatch)
+				securityMatch.Score = output.RiskScore(securityMatch.Severity, securityMatch.Confidence,
+					patterns.ReachableFromEntrypoint(match.SourceFQN, callGraph))
 				matches = append(matches, securityMatch)
 			}
 		}
@@ -148,6 +214,41 @@ func AnalyzePatterns(callGraph *core.CallGraph, patternRegistry *patterns.Patter
 	return matches
 }
 
+// FindingID returns a stable identifier for a match: its pattern ID and a
+// hash of the functions and calls of its data flow, e.g.
+// "CODE-INJECTION-001:5d41402a". Line numbers are left out, so the ID
+// survives edits that move the code without changing the flow.
+func FindingID(match SecurityMatch) string {
+	parts := append([]string{match.PatternID, match.SourceFQN, match.SourceCall, match.SinkFQN, match.SinkCall}, match.DataFlowPath...)
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return match.PatternID + ":" + hex.EncodeToString(sum[:4])
+}
+
+// matchFingerprint returns the fingerprint of a match: its pattern, the
+// function of its sink, and its sink line. Matches without a sink line fall
+// back to the sink call and context.
+func matchFingerprint(match SecurityMatch) string {
+	code := match.SinkCode
+	if code == "" {
+		code = match.SinkCall + "\x00" + match.Context
+	}
+	return output.Fingerprint(match.PatternID, match.SinkFQN, code)
+}
+
+// sourceSnippet returns a line of code like getCodeSnippet, reading
+// in-memory sources of the call graph (e.g., notebook modules) first.
+func sourceSnippet(callGraph *core.CallGraph, filePath string, lineNumber int) string {
+	content, ok := callGraph.Sources[filePath]
+	if !ok {
+		return getCodeSnippet(filePath, lineNumber)
+	}
+	lines := strings.Split(string(content), "\n")
+	if lineNumber < 1 || lineNumber > len(lines) {
+		return ""
+	}
+	return strings.TrimSpace(lines[lineNumber-1])
+}
+
 // getCodeSnippet reads a line of code from a file.
 // Returns the line at the specified line number (1-indexed).
 // Returns empty string if the file cannot be read or line number is invalid.
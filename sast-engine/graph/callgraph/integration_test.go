@@ -131,6 +131,31 @@ def safe_function():
 		assert.Equal(t, 0, len(matches))
 	})
 
+	t.Run("locates matches without a sink call at the function", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		testFile := filepath.Join(tmpDir, "lint.py")
+		code := `
+def collect(item, items=[]):
+    items.append(item)
+    return items
+`
+		err := os.WriteFile(testFile, []byte(code), 0644)
+		require.NoError(t, err)
+
+		codeGraph := graph.Initialize(tmpDir, nil)
+		callGraph, _, patternRegistry, err := InitializeCallGraph(codeGraph, tmpDir, output.NewLogger(output.VerbosityDefault))
+		require.NoError(t, err)
+
+		matches := AnalyzePatterns(callGraph, patternRegistry)
+
+		require.Len(t, matches, 1)
+		assert.Equal(t, "Mutable default argument", matches[0].PatternName)
+		assert.Equal(t, testFile, matches[0].SinkFile)
+		assert.Equal(t, uint32(2), matches[0].SinkLine)
+		assert.Contains(t, matches[0].SinkCode, "items=[]")
+		assert.Contains(t, matches[0].Context, "parameter items")
+	})
+
 	t.Run("populates all security match fields", func(t *testing.T) {
 		tmpDir := t.TempDir()
 		testFile := filepath.Join(tmpDir, "test.py")
@@ -272,3 +297,25 @@ func TestSecurityMatchStruct(t *testing.T) {
 		assert.Len(t, match.DataFlowPath, 2)
 	})
 }
+
+func TestFindingID(t *testing.T) {
+	match := SecurityMatch{
+		PatternID:    "CODE-INJECTION-001",
+		SourceFQN:    "app.calculate",
+		SourceCall:   "input",
+		SinkFQN:      "app.run",
+		SinkCall:     "eval",
+		SinkLine:     2,
+		DataFlowPath: []string{"app.calculate", "app.helper", "app.run"},
+	}
+	id := FindingID(match)
+	assert.Regexp(t, `^CODE-INJECTION-001:[0-9a-f]{8}$`, id)
+
+	moved := match
+	moved.SinkLine = 12
+	assert.Equal(t, id, FindingID(moved), "moving the code keeps the ID")
+
+	rerouted := match
+	rerouted.DataFlowPath = []string{"app.calculate", "app.run"}
+	assert.NotEqual(t, id, FindingID(rerouted))
+}
@@ -0,0 +1,196 @@
+// Package notebook extracts the Python code of Jupyter notebooks (.ipynb)
+// so they can be analyzed like Python modules.
+//
+// The code cells of a notebook are concatenated, in order, into a synthetic
+// module analyzed under a virtual path next to the notebook (SourcePath).
+// Each line of the module remembers the cell and line it came from, and
+// SourceMapper translates findings back to them:
+//
+//	nb, err := notebook.Load("analysis.ipynb")
+//	origin, _ := nb.Origin(12) // {Cell: 3, Line: 2}
+//
+// IPython magics (%matplotlib inline) and shell escapes (!pip install) are
+// not Python; their lines become pass statements so line numbers still
+// match. Cells run by a non-Python cell magic (%%bash) are commented out.
+package notebook
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// Extension is the file extension of Jupyter notebooks.
+const Extension = ".ipynb"
+
+// sourceSuffix is appended to a notebook path to form its SourcePath.
+const sourceSuffix = ".py"
+
+// pythonCellMagics are cell magics whose body is still Python.
+var pythonCellMagics = map[string]bool{
+	"time":    true,
+	"timeit":  true,
+	"capture": true,
+}
+
+// Origin is the place in a notebook a line of its synthetic module came
+// from. Cell is the 1-based position of the cell in the notebook, counting
+// markdown and raw cells; Line is 1-based within the cell.
+type Origin struct {
+	Cell int
+	Line int
+}
+
+// Notebook is a notebook's code extracted into a synthetic Python module.
+type Notebook struct {
+	Path    string   // Path of the .ipynb file
+	Source  []byte   // Code cells, concatenated in order
+	origins []Origin // Origin of each line of Source
+}
+
+// notebookFile is the part of the nbformat 4 schema the extractor reads.
+type notebookFile struct {
+	NBFormat int `json:"nbformat"`
+	Cells    []struct {
+		CellType string          `json:"cell_type"`
+		Source   json.RawMessage `json:"source"`
+	} `json:"cells"`
+}
+
+// Load reads and extracts the notebook at path.
+func Load(path string) (*Notebook, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(path, data)
+}
+
+// Parse extracts the code cells of a notebook in nbformat 4, read from
+// path.
+func Parse(path string, data []byte) (*Notebook, error) {
+	var file notebookFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parsing notebook %s: %w", path, err)
+	}
+	if file.NBFormat < 4 {
+		return nil, fmt.Errorf("notebook %s: unsupported nbformat %d", path, file.NBFormat)
+	}
+
+	nb := &Notebook{Path: path}
+	var source strings.Builder
+	for i, cell := range file.Cells {
+		if cell.CellType != "code" {
+			continue
+		}
+		text, err := cellSource(cell.Source)
+		if err != nil {
+			return nil, fmt.Errorf("notebook %s, cell %d: %w", path, i+1, err)
+		}
+		if text == "" {
+			continue
+		}
+		lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+		for j, line := range pythonLines(lines) {
+			source.WriteString(line)
+			source.WriteByte('\n')
+			nb.origins = append(nb.origins, Origin{Cell: i + 1, Line: j + 1})
+		}
+	}
+	nb.Source = []byte(source.String())
+	return nb, nil
+}
+
+// cellSource returns a cell's source, stored either as one string or as a
+// list of lines.
+func cellSource(raw json.RawMessage) (string, error) {
+	if len(raw) == 0 {
+		return "", nil
+	}
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text, nil
+	}
+	var lines []string
+	if err := json.Unmarshal(raw, &lines); err != nil {
+		return "", fmt.Errorf("source is neither a string nor a list of strings")
+	}
+	return strings.Join(lines, ""), nil
+}
+
+// pythonLines replaces the IPython syntax in a cell's lines with Python of
+// the same line count.
+func pythonLines(lines []string) []string {
+	result := make([]string, len(lines))
+	if magic, ok := strings.CutPrefix(lines[0], "%%"); ok {
+		name, _, _ := strings.Cut(magic, " ")
+		if !pythonCellMagics[name] {
+			for i, line := range lines {
+				result[i] = "# " + line
+			}
+			return result
+		}
+	}
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " \t")
+		if strings.HasPrefix(trimmed, "%") || strings.HasPrefix(trimmed, "!") {
+			line = line[:len(line)-len(trimmed)] + "pass  # " + trimmed
+		}
+		result[i] = line
+	}
+	return result
+}
+
+// Origin returns the cell and line that line of Source came from.
+func (nb *Notebook) Origin(line int) (Origin, bool) {
+	if line < 1 || line > len(nb.origins) {
+		return Origin{}, false
+	}
+	return nb.origins[line-1], true
+}
+
+// SourcePath returns the virtual path a notebook's synthetic module is
+// analyzed under: "analysis.ipynb" → "analysis.ipynb.py".
+func SourcePath(notebookPath string) string {
+	return notebookPath + sourceSuffix
+}
+
+// IsSourcePath reports whether path is the SourcePath of a notebook.
+func IsSourcePath(path string) bool {
+	return strings.HasSuffix(path, Extension+sourceSuffix)
+}
+
+// NotebookPath returns the notebook whose SourcePath is sourcePath.
+func NotebookPath(sourcePath string) string {
+	return strings.TrimSuffix(sourcePath, sourceSuffix)
+}
+
+// CellFile names a cell of a notebook as a location's file:
+// "analysis.ipynb#cell3".
+func CellFile(notebookPath string, cell int) string {
+	return fmt.Sprintf("%s#cell%d", notebookPath, cell)
+}
+
+// SourceMapper translates locations in the synthetic modules of notebooks
+// to the cell they came from, reported as CellFile and the line within the
+// cell. Other locations are returned unchanged.
+func SourceMapper(notebooks []*Notebook) core.SourceMapper {
+	bySource := make(map[string]*Notebook, len(notebooks))
+	for _, nb := range notebooks {
+		bySource[SourcePath(nb.Path)] = nb
+	}
+	return func(file string, line int) (string, int) {
+		nb, ok := bySource[file]
+		if !ok {
+			return file, line
+		}
+		origin, ok := nb.Origin(line)
+		if !ok {
+			return nb.Path, line
+		}
+		return CellFile(nb.Path, origin.Cell), origin.Line
+	}
+}
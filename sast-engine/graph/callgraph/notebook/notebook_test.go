@@ -0,0 +1,116 @@
+package notebook
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_ConcatenatesCodeCells(t *testing.T) {
+	data := []byte(`{
+		"nbformat": 4,
+		"cells": [
+			{"cell_type": "markdown", "source": ["# Title\n"]},
+			{"cell_type": "code", "source": ["x = 1\n", "y = 2\n"]},
+			{"cell_type": "raw", "source": "raw text"},
+			{"cell_type": "code", "source": "print(x + y)"}
+		]
+	}`)
+
+	nb, err := Parse("demo.ipynb", data)
+	require.NoError(t, err)
+
+	assert.Equal(t, "x = 1\ny = 2\nprint(x + y)\n", string(nb.Source))
+	for line, want := range map[int]Origin{1: {Cell: 2, Line: 1}, 2: {Cell: 2, Line: 2}, 3: {Cell: 4, Line: 1}} {
+		origin, ok := nb.Origin(line)
+		require.True(t, ok)
+		assert.Equal(t, want, origin, "line %d", line)
+	}
+	_, ok := nb.Origin(4)
+	assert.False(t, ok)
+	_, ok = nb.Origin(0)
+	assert.False(t, ok)
+}
+
+func TestParse_Magics(t *testing.T) {
+	data := []byte(`{
+		"nbformat": 4,
+		"cells": [
+			{"cell_type": "code", "source": "%matplotlib inline\nif True:\n    !ls\n"},
+			{"cell_type": "code", "source": "%%bash\necho hi\n"},
+			{"cell_type": "code", "source": "%%time\nz = 3\n"}
+		]
+	}`)
+
+	nb, err := Parse("magics.ipynb", data)
+	require.NoError(t, err)
+
+	assert.Equal(t, "pass  # %matplotlib inline\n"+
+		"if True:\n"+
+		"    pass  # !ls\n"+
+		"# %%bash\n"+
+		"# echo hi\n"+
+		"pass  # %%time\n"+
+		"z = 3\n", string(nb.Source))
+}
+
+func TestParse_Errors(t *testing.T) {
+	_, err := Parse("bad.ipynb", []byte("not json"))
+	assert.Error(t, err)
+
+	_, err = Parse("old.ipynb", []byte(`{"nbformat": 3, "worksheets": []}`))
+	assert.ErrorContains(t, err, "unsupported nbformat 3")
+
+	_, err = Parse("cell.ipynb", []byte(`{"nbformat": 4, "cells": [{"cell_type": "code", "source": 42}]}`))
+	assert.ErrorContains(t, err, "cell 1")
+}
+
+func TestLoad_Fixture(t *testing.T) {
+	path, err := filepath.Abs("../../../test-fixtures/python/notebook_eval/analysis.ipynb")
+	require.NoError(t, err)
+
+	nb, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, path, nb.Path)
+	assert.Contains(t, string(nb.Source), "return eval(expression)")
+
+	_, err = Load("/nonexistent/notebook.ipynb")
+	assert.Error(t, err)
+}
+
+func TestPaths(t *testing.T) {
+	source := SourcePath("/proj/analysis.ipynb")
+	assert.Equal(t, "/proj/analysis.ipynb.py", source)
+	assert.True(t, IsSourcePath(source))
+	assert.False(t, IsSourcePath("/proj/analysis.py"))
+	assert.Equal(t, "/proj/analysis.ipynb", NotebookPath(source))
+	assert.Equal(t, "/proj/analysis.ipynb#cell3", CellFile("/proj/analysis.ipynb", 3))
+}
+
+func TestSourceMapper(t *testing.T) {
+	nb, err := Parse("/proj/a.ipynb", []byte(`{"nbformat": 4, "cells": [
+		{"cell_type": "markdown", "source": "intro"},
+		{"cell_type": "code", "source": "a = 1\nb = 2\n"},
+		{"cell_type": "code", "source": "c = 3\n"}
+	]}`))
+	require.NoError(t, err)
+	mapper := SourceMapper([]*Notebook{nb})
+
+	file, line := mapper("/proj/a.ipynb.py", 3)
+	assert.Equal(t, "/proj/a.ipynb#cell3", file)
+	assert.Equal(t, 1, line)
+
+	file, line = mapper("/proj/a.ipynb.py", 2)
+	assert.Equal(t, "/proj/a.ipynb#cell2", file)
+	assert.Equal(t, 2, line)
+
+	file, line = mapper("/proj/a.ipynb.py", 9)
+	assert.Equal(t, "/proj/a.ipynb", file)
+	assert.Equal(t, 9, line)
+
+	file, line = mapper("/proj/other.py", 7)
+	assert.Equal(t, "/proj/other.py", file)
+	assert.Equal(t, 7, line)
+}
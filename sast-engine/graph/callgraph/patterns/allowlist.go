@@ -0,0 +1,82 @@
+package patterns
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"gopkg.in/yaml.v3"
+)
+
+// Suppression is why a finding is reported but not counted as one.
+type Suppression string
+
+const (
+	// SuppressedByAllowlist marks a sink call exempted in its caller's
+	// module by PatternRegistry.SinkAllowlist.
+	SuppressedByAllowlist Suppression = "allowlist"
+
+	// SuppressedByBaseline marks a finding accepted in a baseline of an
+	// earlier scan (see callgraph.AnalyzeOptions.Baseline).
+	SuppressedByBaseline Suppression = "baseline"
+)
+
+// SinkExemption exempts calls to one sink made from one module, e.g. raw
+// SQL run on purpose by migration scripts. It is narrower than disabling
+// the pattern: the same sink is still flagged everywhere else.
+type SinkExemption struct {
+	Sink   string `yaml:"sink"`   // Resolved sink FQN, e.g. "sqlite3.Cursor.execute"
+	Module string `yaml:"module"` // Caller module, or a package covering it, e.g. "myapp.migrations"
+}
+
+// sinkAllowlistConfig is the YAML rule config for sink exemptions:
+//
+//	sink_allowlist:
+//	  - sink: sqlite3.Cursor.execute
+//	    module: myapp.migrations
+type sinkAllowlistConfig struct {
+	SinkAllowlist []SinkExemption `yaml:"sink_allowlist"`
+}
+
+// LoadSinkAllowlistConfig adds the sink exemptions in a YAML rule config to
+// the registry's SinkAllowlist.
+func (pr *PatternRegistry) LoadSinkAllowlistConfig(data []byte) error {
+	var config sinkAllowlistConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("failed to parse sink allowlist config: %w", err)
+	}
+	for _, exemption := range config.SinkAllowlist {
+		if exemption.Sink == "" || exemption.Module == "" {
+			return fmt.Errorf("sink allowlist entry needs both sink and module")
+		}
+		pr.SinkAllowlist = append(pr.SinkAllowlist, exemption)
+	}
+	return nil
+}
+
+// IsSinkAllowlisted reports whether a call to sink (a resolved FQN) from
+// caller is exempted by SinkAllowlist. caller is a function FQN, or a module
+// FQN for module-level code; it is covered by an exemption for its module
+// or any package above it.
+func (pr *PatternRegistry) IsSinkAllowlisted(sink, caller string) bool {
+	for _, exemption := range pr.SinkAllowlist {
+		if sink == exemption.Sink && (caller == exemption.Module || strings.HasPrefix(caller, exemption.Module+".")) {
+			return true
+		}
+	}
+	return false
+}
+
+// SinkSuppression returns SuppressedByAllowlist when a call on line of
+// caller resolves to a sink exempted in caller's module, "" otherwise.
+func (pr *PatternRegistry) SinkSuppression(caller string, line int, callGraph *core.CallGraph) Suppression {
+	if len(pr.SinkAllowlist) == 0 {
+		return ""
+	}
+	for _, callSite := range callGraph.CallSites[caller] {
+		if callSite.Location.Line == line && pr.IsSinkAllowlisted(callSite.TargetFQN, caller) {
+			return SuppressedByAllowlist
+		}
+	}
+	return ""
+}
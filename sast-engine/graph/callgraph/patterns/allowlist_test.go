@@ -0,0 +1,30 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSinkAllowlisted(t *testing.T) {
+	registry := NewPatternRegistry()
+	require.NoError(t, registry.LoadSinkAllowlistConfig([]byte(`
+sink_allowlist:
+  - sink: sqlite3.Cursor.execute
+    module: myapp.migrations
+`)))
+
+	assert.True(t, registry.IsSinkAllowlisted("sqlite3.Cursor.execute", "myapp.migrations"))
+	assert.True(t, registry.IsSinkAllowlisted("sqlite3.Cursor.execute", "myapp.migrations.m0001.forward"))
+	assert.False(t, registry.IsSinkAllowlisted("sqlite3.Cursor.execute", "myapp.views.search"))
+	assert.False(t, registry.IsSinkAllowlisted("sqlite3.Cursor.execute", "myapp.migrations_old.run"))
+	assert.False(t, registry.IsSinkAllowlisted("os.system", "myapp.migrations.m0001.forward"))
+}
+
+func TestLoadSinkAllowlistConfig_Invalid(t *testing.T) {
+	registry := NewPatternRegistry()
+	assert.Error(t, registry.LoadSinkAllowlistConfig([]byte("sink_allowlist: [")))
+	assert.Error(t, registry.LoadSinkAllowlistConfig([]byte("sink_allowlist:\n  - sink: os.system\n")))
+	assert.Empty(t, registry.SinkAllowlist)
+}
@@ -0,0 +1,76 @@
+package patterns
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// AssertAuthVocabulary are identifiers that mark an assert condition as an
+// authentication or authorization check. A condition matches when one of
+// its dotted or called names equals an entry; extend it to cover a
+// project's own permission helpers.
+var AssertAuthVocabulary = []string{
+	"is_authenticated",
+	"is_anonymous",
+	"is_admin",
+	"is_superuser",
+	"is_staff",
+	"is_owner",
+	"is_authorized",
+	"has_perm",
+	"has_perms",
+	"has_permission",
+	"has_role",
+	"check_permission",
+}
+
+// authIdentifier returns the first identifier in condition that is in
+// AssertAuthVocabulary, or "" if there is none.
+//
+//	"request.user.is_authenticated"     → "is_authenticated"
+//	"user.has_perm('app.delete_post')"  → "has_perm"
+//	"len(items) > 0"                    → ""
+func authIdentifier(condition string) string {
+	words := strings.FieldsFunc(condition, func(r rune) bool {
+		return r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		if slices.Contains(AssertAuthVocabulary, word) {
+			return word
+		}
+	}
+	return ""
+}
+
+// findAssertAuth returns a match for every assert whose condition names an
+// auth check (see authIdentifier), ordered by function FQN and line.
+// python -O strips asserts, so the check silently disappears in optimized
+// deployments. SinkFQN is the function and Context names the condition.
+func (pr *PatternRegistry) findAssertAuth(pattern *Pattern, callGraph *core.CallGraph) []*PatternMatchDetails {
+	functions := make([]string, 0, len(callGraph.Statements))
+	for fqn := range callGraph.Statements {
+		functions = append(functions, fqn)
+	}
+	slices.Sort(functions)
+
+	var matches []*PatternMatchDetails
+	for _, fqn := range functions {
+		for _, stmt := range callGraph.Statements[fqn] {
+			if stmt.Type != core.StatementTypeAssert || authIdentifier(stmt.Condition) == "" {
+				continue
+			}
+			matches = append(matches, &PatternMatchDetails{
+				Matched:           true,
+				IsIntraProcedural: true,
+				SinkFQN:           fqn,
+				DataFlowPath:      []string{fqn},
+				Context:           fmt.Sprintf("assert %s at line %d is removed under python -O", stmt.Condition, stmt.LineNumber),
+			})
+		}
+	}
+	return matches
+}
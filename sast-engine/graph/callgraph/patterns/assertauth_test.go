@@ -0,0 +1,54 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthIdentifier(t *testing.T) {
+	tests := []struct {
+		condition string
+		expected  string
+	}{
+		{"request.user.is_authenticated", "is_authenticated"},
+		{"user.has_perm('app.delete_post')", "has_perm"},
+		{"not user.is_anonymous", "is_anonymous"},
+		{"len(items) > 0", ""},
+		{"is_administrator", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.condition, func(t *testing.T) {
+			assert.Equal(t, tt.expected, authIdentifier(tt.condition))
+		})
+	}
+}
+
+func TestAssertAuth(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "assert_auth")
+
+	patternRegistry := NewPatternRegistry()
+	patternRegistry.LoadDefaultPatterns()
+	pattern, ok := patternRegistry.GetPattern("ASSERT-AUTH-001")
+	require.True(t, ok)
+
+	found := make(map[string]*PatternMatchDetails)
+	for _, match := range patternRegistry.findAssertAuth(pattern, callGraph) {
+		found[match.SinkFQN] = match
+	}
+
+	require.Contains(t, found, "app.dashboard")
+	assert.Equal(t, "assert request.user.is_authenticated at line 5 is removed under python -O", found["app.dashboard"].Context)
+	assert.Contains(t, found, "app.delete_post")
+
+	// Asserts on ordinary invariants and real permission checks are not flagged
+	assert.NotContains(t, found, "app.average")
+	assert.NotContains(t, found, "app.settings_page")
+	assert.Len(t, found, 2)
+
+	match := patternRegistry.MatchPattern(pattern, callGraph)
+	require.True(t, match.Matched)
+	assert.Equal(t, "app.dashboard", match.SinkFQN)
+	assert.Nil(t, match.Explanation)
+}
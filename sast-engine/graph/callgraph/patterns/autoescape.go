@@ -0,0 +1,299 @@
+package patterns
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// jinjaEnvironmentClasses construct Jinja environments. Templates loaded
+// from an environment inherit its autoescape setting.
+var jinjaEnvironmentClasses = []string{
+	"jinja2.Environment",
+	"jinja2.environment.Environment",
+	"jinja2.sandbox.SandboxedEnvironment",
+}
+
+// jinjaTemplateClasses construct a Jinja template directly from source.
+var jinjaTemplateClasses = []string{
+	"jinja2.Template",
+	"jinja2.environment.Template",
+}
+
+// jinjaTemplateLoaders are Environment methods that return a template.
+var jinjaTemplateLoaders = []string{"get_template", "from_string", "select_template", "get_or_select_template"}
+
+// JinjaRenderMethods are Template methods that render context data to output.
+var JinjaRenderMethods = []string{"render", "render_async", "stream", "generate"}
+
+// Kinds of Jinja objects created with autoescaping disabled.
+const (
+	jinjaEnvironment = "environment"
+	jinjaTemplate    = "template"
+)
+
+// unsafeJinjaObject is a Jinja environment or template constructed with
+// autoescape=False.
+type unsafeJinjaObject struct {
+	Scope       string // Function FQN, or module path for module-level objects
+	Variable    string // Variable bound to the object ("" if not known)
+	Line        int    // Line of the constructor call
+	Kind        string // jinjaEnvironment or jinjaTemplate
+	ClassFQN    string // Resolved constructor (e.g., "jinja2.Environment")
+	ModuleLevel bool   // Whether Scope is a module rather than a function
+}
+
+// autoescapeDisabled reports whether a constructor call passes
+// autoescape=False. Omitted or computed values (e.g., select_autoescape())
+// are not flagged.
+func autoescapeDisabled(callSite *core.CallSite) bool {
+	for _, arg := range callSite.Arguments {
+		keyword, value, ok := splitKeywordArgument(arg.Value)
+		if ok && keyword == "autoescape" && value == "False" {
+			return true
+		}
+	}
+	return false
+}
+
+// jinjaObjectKind returns the kind of Jinja object a resolved constructor
+// creates, or "" if it is not a Jinja constructor.
+func jinjaObjectKind(targetFQN string) string {
+	switch {
+	case slices.Contains(jinjaEnvironmentClasses, targetFQN):
+		return jinjaEnvironment
+	case slices.Contains(jinjaTemplateClasses, targetFQN):
+		return jinjaTemplate
+	default:
+		return ""
+	}
+}
+
+// findUnsafeJinjaObjects returns every Jinja environment or template built
+// with autoescape=False, ordered by scope and line.
+func findUnsafeJinjaObjects(callGraph *core.CallGraph) []unsafeJinjaObject {
+	var objects []unsafeJinjaObject
+	for _, scope := range sortedCallers(callGraph) {
+		for _, callSite := range sortedCallSites(callGraph, scope) {
+			kind := jinjaObjectKind(callSite.TargetFQN)
+			if kind == "" || !autoescapeDisabled(&callSite) {
+				continue
+			}
+			_, isFunction := callGraph.Functions[scope]
+			objects = append(objects, unsafeJinjaObject{
+				Scope:       scope,
+				Variable:    definedAtLine(callGraph.Statements[scope], callSite.Location.Line),
+				Line:        callSite.Location.Line,
+				Kind:        kind,
+				ClassFQN:    callSite.TargetFQN,
+				ModuleLevel: !isFunction,
+			})
+		}
+	}
+	return objects
+}
+
+// definedAtLine returns the variable assigned by the statement at line.
+func definedAtLine(statements []*core.Statement, line int) string {
+	for _, stmt := range statements {
+		if int(stmt.LineNumber) == line && stmt.Def != "" {
+			return stmt.Def
+		}
+	}
+	return ""
+}
+
+// findAutoescapeOff returns every autoescape-off finding for the pattern,
+// ordered by function FQN and line.
+func (pr *PatternRegistry) findAutoescapeOff(pattern *Pattern, callGraph *core.CallGraph) []*PatternMatchDetails {
+	objects := findUnsafeJinjaObjects(callGraph)
+
+	var matches []*PatternMatchDetails
+	if len(pattern.Sources) == 0 {
+		for _, object := range objects {
+			matches = append(matches, &PatternMatchDetails{
+				Matched:           true,
+				IsIntraProcedural: true,
+				SourceFQN:         object.Scope,
+				SinkFQN:           object.Scope,
+				SinkCall:          object.ClassFQN,
+				DataFlowPath:      []string{object.Scope},
+			})
+		}
+		return matches
+	}
+
+	if len(objects) == 0 {
+		return nil
+	}
+
+	for _, caller := range sortedCallers(callGraph) {
+		if _, ok := callGraph.Functions[caller]; !ok {
+			continue
+		}
+		for _, callSite := range sortedCallSites(callGraph, caller) {
+			if !isUnsafeRender(caller, &callSite, objects, callGraph) {
+				continue
+			}
+			source := renderedTaintSource(caller, &callSite, callGraph, pattern)
+			if source == "" {
+				continue
+			}
+			matches = append(matches, &PatternMatchDetails{
+				Matched:           true,
+				IsIntraProcedural: true,
+				SourceFQN:         caller,
+				SourceCall:        source,
+				SinkFQN:           caller,
+				SinkCall:          "jinja2.Template." + callSite.Target[strings.LastIndex(callSite.Target, ".")+1:],
+				DataFlowPath:      []string{caller},
+			})
+		}
+	}
+	return matches
+}
+
+// isUnsafeRender reports whether a call renders a template whose
+// autoescaping is disabled, either directly (template.render(...)) or
+// through a chained load (env.from_string(...).render(...)).
+func isUnsafeRender(caller string, callSite *core.CallSite, objects []unsafeJinjaObject, callGraph *core.CallGraph) bool {
+	parts := strings.Split(callSite.Target, ".")
+	if len(parts) < 2 || !slices.Contains(JinjaRenderMethods, parts[len(parts)-1]) {
+		return false
+	}
+
+	unsafe := unsafeVariablesAt(caller, callSite.Location.Line, objects, callGraph)
+	switch len(parts) {
+	case 2:
+		// template.render(...)
+		return unsafe[parts[0]] == jinjaTemplate
+	case 3:
+		// env.get_template(...).render(...)
+		return unsafe[parts[0]] == jinjaEnvironment && slices.Contains(jinjaTemplateLoaders, parts[1])
+	default:
+		return false
+	}
+}
+
+// unsafeVariablesAt returns the variables in caller that hold an unsafe Jinja
+// object just before line, mapped to their kind. Module-level environments
+// are included when the type engine links the name to an unsafe constructor;
+// local assignments are replayed in order, so reassignment clears a variable.
+func unsafeVariablesAt(caller string, line int, objects []unsafeJinjaObject, callGraph *core.CallGraph) map[string]string {
+	unsafe := make(map[string]string)
+	local := make(map[string]bool)
+
+	for _, stmt := range callGraph.Statements[caller] {
+		if int(stmt.LineNumber) >= line || stmt.Def == "" {
+			continue
+		}
+		local[stmt.Def] = true
+		delete(unsafe, stmt.Def)
+
+		for _, object := range objects {
+			if object.Scope == caller && object.Line == int(stmt.LineNumber) && object.Variable == stmt.Def {
+				unsafe[stmt.Def] = object.Kind
+			}
+		}
+
+		// template = env.get_template("page.html")
+		if receiver, method, ok := strings.Cut(stmt.CallChain, "."); ok && slices.Contains(jinjaTemplateLoaders, method) {
+			if unsafe[receiver] == jinjaEnvironment || (!local[receiver] && isUnsafeModuleEnvironment(caller, receiver, objects, callGraph)) {
+				unsafe[stmt.Def] = jinjaTemplate
+			}
+		}
+	}
+
+	// Module-level environments used directly: env.from_string(...).render(...)
+	for _, name := range moduleVariablesReferenced(caller, callGraph) {
+		if !local[name] && unsafe[name] == "" && isUnsafeModuleEnvironment(caller, name, objects, callGraph) {
+			unsafe[name] = jinjaEnvironment
+		}
+	}
+
+	return unsafe
+}
+
+// moduleVariablesReferenced returns the receivers of caller's call sites,
+// which may name module-level objects.
+func moduleVariablesReferenced(caller string, callGraph *core.CallGraph) []string {
+	var names []string
+	for _, callSite := range callGraph.CallSites[caller] {
+		if receiver, _, ok := strings.Cut(callSite.Target, "."); ok && isIdentifier(receiver) && !slices.Contains(names, receiver) {
+			names = append(names, receiver)
+		}
+	}
+	return names
+}
+
+// isUnsafeModuleEnvironment reports whether name is a module-level variable
+// of caller's module bound by an unsafe Environment constructor. The type
+// engine links the name to the binding on the constructor's line.
+func isUnsafeModuleEnvironment(caller, name string, objects []unsafeJinjaObject, callGraph *core.CallGraph) bool {
+	if callGraph.TypeEngine == nil {
+		return false
+	}
+	for _, object := range objects {
+		if !object.ModuleLevel || object.Kind != jinjaEnvironment || !strings.HasPrefix(caller, object.Scope+".") {
+			continue
+		}
+		//nolint:gosec // line numbers are positive
+		binding := callGraph.TypeEngine.GetModuleVariableType(object.Scope, name, uint32(object.Line))
+		if binding != nil && binding.TypeFQN == object.ClassFQN {
+			return true
+		}
+	}
+	return false
+}
+
+// renderedTaintSource returns the source whose data reaches any argument of
+// a render call, or "" if all context values are untainted.
+func renderedTaintSource(caller string, callSite *core.CallSite, callGraph *core.CallGraph, pattern *Pattern) string {
+	for _, arg := range callSite.Arguments {
+		value := arg.Value
+		if _, keywordValue, ok := splitKeywordArgument(value); ok {
+			value = keywordValue
+		}
+		if source := matchingSource(value, pattern.Sources); source != "" {
+			return source
+		}
+		if !isIdentifier(value) {
+			continue
+		}
+		if source := taintedArgumentSource(caller, callSite, value, callGraph, pattern); source != "" {
+			return source
+		}
+	}
+	return ""
+}
+
+// sortedCallers returns the callers with call sites, sorted by FQN.
+func sortedCallers(callGraph *core.CallGraph) []string {
+	callers := make([]string, 0, len(callGraph.CallSites))
+	for caller := range callGraph.CallSites {
+		callers = append(callers, caller)
+	}
+	sort.Strings(callers)
+	return callers
+}
+
+// sortedCallSites returns a copy of caller's call sites in source order.
+func sortedCallSites(callGraph *core.CallGraph, caller string) []core.CallSite {
+	callSites := slices.Clone(callGraph.CallSites[caller])
+	slices.SortStableFunc(callSites, func(a, b core.CallSite) int {
+		return compareLocations(a.Location, b.Location)
+	})
+	return callSites
+}
+
+// compareLocations orders locations by file, line, then column.
+func compareLocations(a, b core.Location) int {
+	return cmp.Or(
+		cmp.Compare(a.File, b.File),
+		cmp.Compare(a.Line, b.Line),
+		cmp.Compare(a.Column, b.Column),
+	)
+}
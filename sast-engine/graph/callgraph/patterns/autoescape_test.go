@@ -0,0 +1,87 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutoescapeOff_UnsafeConstruction(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "jinja_autoescape")
+	registry := NewPatternRegistry()
+	registry.LoadDefaultPatterns()
+	pattern, ok := registry.GetPattern("JINJA-AUTOESCAPE-001")
+	require.True(t, ok)
+
+	var found []string
+	for _, match := range registry.findAutoescapeOff(pattern, callGraph) {
+		found = append(found, match.SinkFQN+" "+match.SinkCall)
+	}
+
+	// safe_env uses select_autoescape() and is not flagged.
+	assert.Equal(t, []string{
+		"app jinja2.Environment",
+		"app.direct_template jinja2.Template",
+		"app.inline jinja2.Environment",
+	}, found)
+	assert.Equal(t, SeverityMedium, pattern.Severity)
+}
+
+func TestAutoescapeOff_TaintedRender(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "jinja_autoescape")
+	registry := NewPatternRegistry()
+	registry.LoadDefaultPatterns()
+	pattern, ok := registry.GetPattern("XSS-JINJA-001")
+	require.True(t, ok)
+
+	found := make(map[string]*PatternMatchDetails)
+	for _, match := range registry.findAutoescapeOff(pattern, callGraph) {
+		found[match.SinkFQN] = match
+	}
+
+	tests := []struct {
+		function string
+		source   string
+	}{
+		{"app.greet", "request.args"},           // module-level env → get_template → render
+		{"app.inline", "request.args"},          // local env, chained from_string().render()
+		{"app.direct_template", "request.form"}, // Template(..., autoescape=False)
+	}
+	for _, tt := range tests {
+		t.Run(tt.function, func(t *testing.T) {
+			match, ok := found[tt.function]
+			require.True(t, ok, "%s should flag", tt.function)
+			assert.Equal(t, tt.source, match.SourceCall)
+			assert.Equal(t, "jinja2.Template.render", match.SinkCall)
+		})
+	}
+
+	// Safe environment, constant context and escaped input are not findings.
+	assert.NotContains(t, found, "app.safe_greet")
+	assert.NotContains(t, found, "app.static_page")
+	assert.NotContains(t, found, "app.escaped_greet")
+	assert.Len(t, found, len(tests))
+
+	assert.Equal(t, SeverityHigh, pattern.Severity)
+	assert.True(t, registry.MatchPattern(pattern, callGraph).Matched)
+}
+
+func TestAutoescapeDisabled(t *testing.T) {
+	tests := []struct {
+		name string
+		args []core.Argument
+		want bool
+	}{
+		{"explicit false", []core.Argument{{Value: "autoescape=False"}}, true},
+		{"explicit true", []core.Argument{{Value: "autoescape=True"}}, false},
+		{"select_autoescape", []core.Argument{{Value: "autoescape=select_autoescape()"}}, false},
+		{"omitted", []core.Argument{{Value: `loader=FileSystemLoader("t")`}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, autoescapeDisabled(&core.CallSite{Arguments: tt.args}))
+		})
+	}
+}
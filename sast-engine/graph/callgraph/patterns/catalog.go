@@ -0,0 +1,46 @@
+package patterns
+
+import (
+	"slices"
+	"sort"
+)
+
+// RuleMetadata describes a registered pattern without its matching
+// configuration, for tools listing the available rules.
+type RuleMetadata struct {
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Type        PatternType `json:"type"`
+	Severity    Severity    `json:"severity"`
+	CWE         string      `json:"cwe"`
+	OWASP       string      `json:"owasp,omitempty"`
+	Enabled     bool        `json:"enabled"`
+}
+
+// IsEnabled reports whether the pattern with this ID is run by analysis,
+// i.e. it is not listed in DisabledPatterns.
+func (pr *PatternRegistry) IsEnabled(id string) bool {
+	return !slices.Contains(pr.DisabledPatterns, id)
+}
+
+// Catalog returns the metadata of every registered pattern, ordered by ID.
+func (pr *PatternRegistry) Catalog() []RuleMetadata {
+	catalog := make([]RuleMetadata, 0, len(pr.Patterns))
+	for _, pattern := range pr.Patterns {
+		catalog = append(catalog, RuleMetadata{
+			ID:          pattern.ID,
+			Name:        pattern.Name,
+			Description: pattern.Description,
+			Type:        pattern.Type,
+			Severity:    pattern.Severity,
+			CWE:         pattern.CWE,
+			OWASP:       pattern.OWASP,
+			Enabled:     pr.IsEnabled(pattern.ID),
+		})
+	}
+	sort.Slice(catalog, func(i, j int) bool {
+		return catalog[i].ID < catalog[j].ID
+	})
+	return catalog
+}
@@ -0,0 +1,37 @@
+package patterns
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalog(t *testing.T) {
+	registry := NewPatternRegistry()
+	registry.LoadDefaultPatterns()
+	registry.DisabledPatterns = []string{"MUTABLE-DEFAULT-001"}
+
+	catalog := registry.Catalog()
+	assert.Len(t, catalog, len(registry.Patterns))
+	assert.True(t, sort.SliceIsSorted(catalog, func(i, j int) bool {
+		return catalog[i].ID < catalog[j].ID
+	}))
+
+	for _, rule := range catalog {
+		pattern, ok := registry.GetPattern(rule.ID)
+		if !assert.True(t, ok, rule.ID) {
+			continue
+		}
+		assert.Equal(t, pattern.Name, rule.Name)
+		assert.Equal(t, pattern.Type, rule.Type)
+		assert.NotEmpty(t, rule.Name, rule.ID)
+		assert.NotEmpty(t, rule.Description, rule.ID)
+		assert.NotEmpty(t, rule.Severity, rule.ID)
+		// Lints flag code quality issues, not weaknesses
+		if rule.Type != PatternTypeMutableDefault {
+			assert.Regexp(t, `^CWE-\d+$`, rule.CWE, rule.ID)
+		}
+		assert.Equal(t, rule.ID != "MUTABLE-DEFAULT-001", rule.Enabled, rule.ID)
+	}
+}
@@ -0,0 +1,144 @@
+package patterns
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// ArgumentPosition locates an argument of a call: its position, and the
+// keyword it may be passed by instead ("" for positional-only parameters).
+// Index is -1 for keyword-only parameters (def run(*, sql)), matched by
+// name alone. Argv narrows a subprocess argv argument to some of its
+// elements.
+type ArgumentPosition struct {
+	Index   int
+	Keyword string
+	Argv    ArgvPart
+}
+
+// CodeExecutionArguments maps the builtins that run Python source given as a
+// string to the argument holding it. Their other arguments (the globals and
+// locals dicts, compile's filename and mode) are not code.
+var CodeExecutionArguments = map[string]ArgumentPosition{
+	"builtins.eval":    {Index: 0},
+	"builtins.exec":    {Index: 0},
+	"builtins.compile": {Index: 0, Keyword: "source"},
+}
+
+// DynamicImportArguments maps the functions importing a module by name to
+// the argument naming it.
+var DynamicImportArguments = map[string]ArgumentPosition{
+	"builtins.__import__":     {Index: 0, Keyword: "name"},
+	"importlib.import_module": {Index: 0, Keyword: "name"},
+}
+
+// sinkArgumentParts returns the expressions a call passes in the sink
+// argument that pattern's SinkArguments name for its target: the argument
+// itself, or the argv elements it selects. An argv list held in a variable
+// is replaced by the list literal last assigned to it, and fromVariable
+// reports that the parts come from that assignment. Returns false if the
+// target is not listed.
+func sinkArgumentParts(pattern *Pattern, caller string, callSite *core.CallSite, callGraph *core.CallGraph, sources sourceLines) (parts []string, fromVariable, ok bool) {
+	position, ok := pattern.SinkArguments[callSite.TargetFQN]
+	if !ok {
+		return nil, false, false
+	}
+	expr := openArgument(callSite, position.Index, position.Keyword)
+	if expr == "" {
+		return nil, false, true
+	}
+	if position.Argv.selectsElements() && isIdentifier(expr) {
+		if value := resolveVariableValue(caller, expr, callSite.Location, callGraph, sources); value != "" {
+			if _, isList := sequenceElements(value); isList {
+				expr, fromVariable = value, true
+			}
+		}
+	}
+	return position.Argv.selectArgv(callSite, expr), fromVariable, true
+}
+
+// findSinkCalls returns the calls to pattern's sinks. With SinkArguments,
+// only calls to the listed FQNs count, and only if their sink argument is
+// not a string literal, which cannot carry taint from another function.
+func (pr *PatternRegistry) findSinkCalls(pattern *Pattern, callGraph *core.CallGraph) []callInfo {
+	if len(pattern.SinkArguments) == 0 {
+		return pr.findCallsByFunctions(pattern.Sinks, callGraph)
+	}
+	sources := newSourceLines(callGraph)
+	var calls []callInfo
+	for caller, callSites := range callGraph.CallSites {
+		for i := range callSites {
+			parts, _, ok := sinkArgumentParts(pattern, caller, &callSites[i], callGraph, sources)
+			if !ok || !slices.ContainsFunc(parts, mayCarryTaint) {
+				continue
+			}
+			calls = append(calls, callInfo{caller: caller, target: callSites[i].TargetFQN})
+		}
+	}
+	return calls
+}
+
+// mayCarryTaint reports whether an expression is not a plain string
+// literal, which cannot carry taint from another function.
+func mayCarryTaint(expr string) bool {
+	parts, literal := stringLiteralParts(expr)
+	return expr != "" && (!literal || slices.ContainsFunc(parts, stringPart.isFString))
+}
+
+// DetectionsAtSinkArguments keeps the taint detections in caller whose
+// tainted variable is passed as the sink argument named by pattern's
+// SinkArguments, e.g. the code given to exec rather than its globals dict,
+// or the program of a subprocess argv list rather than its options.
+// Detections at calls to unlisted targets are dropped. Patterns without
+// SinkArguments keep every detection.
+func DetectionsAtSinkArguments(pattern *Pattern, caller string, detections []*core.TaintInfo, callGraph *core.CallGraph) []*core.TaintInfo {
+	if len(pattern.SinkArguments) == 0 {
+		return detections
+	}
+	sources := newSourceLines(callGraph)
+	var kept []*core.TaintInfo
+	for _, detection := range detections {
+		for i := range callGraph.CallSites[caller] {
+			callSite := &callGraph.CallSites[caller][i]
+			if uint32(callSite.Location.Line) != detection.SinkLine { //nolint:gosec
+				continue
+			}
+			parts, fromVariable, ok := sinkArgumentParts(pattern, caller, callSite, callGraph, sources)
+			if !ok {
+				continue
+			}
+			if slices.ContainsFunc(parts, func(part string) bool {
+				if fromVariable {
+					// The argv variable is tainted as a whole; find the
+					// elements its taint came through
+					return referencesDerivedVariable(part, callGraph.Statements[caller], callSite.Location.Line, detection.SourceLine)
+				}
+				return referencesVariable(part, detection.SourceVar)
+			}) {
+				kept = append(kept, detection)
+				break
+			}
+		}
+	}
+	return kept
+}
+
+// referencesVariable reports whether an expression reads a variable,
+// including inside f-string replacement fields. Names inside other string
+// literals and attribute names do not count.
+func referencesVariable(expr, name string) bool {
+	if slices.ContainsFunc(fStringFields(expr), func(field string) bool {
+		return referencesVariable(field, name)
+	}) {
+		return true
+	}
+	code := stringLiteral.ReplaceAllString(expr, `""`)
+	for _, match := range variableReference.FindAllStringSubmatch(code, -1) {
+		if match[2] == name {
+			return true
+		}
+	}
+	return strings.TrimSpace(code) == name
+}
@@ -0,0 +1,109 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func codeInjectionCallGraph() *core.CallGraph {
+	callGraph := core.NewCallGraph()
+	callGraph.CallSites["app.run"] = []core.CallSite{
+		{
+			Target:    "exec",
+			TargetFQN: "builtins.exec",
+			Location:  core.Location{Line: 3},
+			Arguments: []core.Argument{
+				{Value: `"result = 1"`, Position: 0},
+				{Value: "namespace", IsVariable: true, Position: 1},
+			},
+		},
+		{
+			Target:    "exec",
+			TargetFQN: "builtins.exec",
+			Location:  core.Location{Line: 5},
+			Arguments: []core.Argument{
+				{Value: `f"print({code})"`, Position: 0},
+			},
+		},
+		{
+			Target:    "compile",
+			TargetFQN: "re.compile",
+			Location:  core.Location{Line: 7},
+			Arguments: []core.Argument{{Value: "pattern", IsVariable: true, Position: 0}},
+		},
+		{
+			Target:    "compile",
+			TargetFQN: "builtins.compile",
+			Location:  core.Location{Line: 9},
+			Arguments: []core.Argument{
+				{Value: `"<input>"`, Position: 0},
+				{Value: "source=text", Position: 1},
+			},
+		},
+	}
+	return callGraph
+}
+
+func TestFindSinkCalls_SinkArguments(t *testing.T) {
+	registry := NewPatternRegistry()
+	registry.LoadDefaultPatterns()
+	pattern, ok := registry.GetPattern("CODE-INJECTION-001")
+	require.True(t, ok)
+
+	calls := registry.findSinkCalls(pattern, codeInjectionCallGraph())
+
+	// The constant exec and re.compile are not sinks.
+	targets := make([]string, 0, len(calls))
+	for _, call := range calls {
+		targets = append(targets, call.target)
+	}
+	assert.ElementsMatch(t, []string{"builtins.exec", "builtins.compile"}, targets)
+}
+
+func TestDetectionsAtSinkArguments(t *testing.T) {
+	registry := NewPatternRegistry()
+	registry.LoadDefaultPatterns()
+	pattern, ok := registry.GetPattern("CODE-INJECTION-001")
+	require.True(t, ok)
+
+	detections := []*core.TaintInfo{
+		{SourceVar: "namespace", SinkLine: 3},
+		{SourceVar: "code", SinkLine: 5},
+		{SourceVar: "pattern", SinkLine: 7},
+		{SourceVar: "text", SinkLine: 9},
+	}
+	kept := DetectionsAtSinkArguments(pattern, "app.run", detections, codeInjectionCallGraph())
+
+	vars := make([]string, 0, len(kept))
+	for _, detection := range kept {
+		vars = append(vars, detection.SourceVar)
+	}
+	assert.Equal(t, []string{"code", "text"}, vars)
+
+	// Patterns without SinkArguments keep every detection.
+	unfiltered := &Pattern{ID: "CUSTOM"}
+	assert.Len(t, DetectionsAtSinkArguments(unfiltered, "app.run", detections, codeInjectionCallGraph()), 4)
+}
+
+func TestReferencesVariable(t *testing.T) {
+	tests := []struct {
+		expr string
+		name string
+		want bool
+	}{
+		{"code", "code", true},
+		{"code + suffix", "code", true},
+		{`f"run({code})"`, "code", true},
+		{`"code"`, "code", false},
+		{"obj.code", "code", false},
+		{"codes", "code", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			assert.Equal(t, tt.want, referencesVariable(tt.expr, tt.name))
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package patterns
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// CommandExecutionFunctions run a command through the shell or as a
+// subprocess. Data reaching the command string, unless quoted with
+// shlex.quote, can inject further commands.
+var CommandExecutionFunctions = []string{
+	"os.system",
+	"os.popen",
+	"subprocess.run",
+	"subprocess.call",
+	"subprocess.check_call",
+	"subprocess.check_output",
+	"subprocess.Popen",
+	"subprocess.getoutput",
+	"subprocess.getstatusoutput",
+}
+
+// SubprocessFunctions take the program to run as an argv list (or a lone
+// program name), passed to the shell only with shell=True.
+var SubprocessFunctions = []string{
+	"subprocess.run",
+	"subprocess.call",
+	"subprocess.check_call",
+	"subprocess.check_output",
+	"subprocess.Popen",
+}
+
+// ArgvPart selects the part of a subprocess call's args argument that a
+// pattern checks, depending on whether a shell runs it.
+type ArgvPart int
+
+const (
+	ArgvWhole      ArgvPart = iota // The whole argument, with or without a shell
+	ArgvShell                      // The whole argument, only with shell=True
+	ArgvExecutable                 // Without a shell: the first element of an argv list, or the argument itself
+	ArgvArguments                  // Without a shell: the elements of an argv list after the first
+)
+
+// ShellCommandArguments maps the functions running a command string
+// through the shell to the argument holding it. Subprocess calls only
+// count with shell=True; their argv lists are checked element-wise
+// through ArgvArgumentsOf.
+var ShellCommandArguments = map[string]ArgumentPosition{
+	"os.system":                  {Index: 0, Keyword: "command"},
+	"os.popen":                   {Index: 0, Keyword: "cmd"},
+	"subprocess.getoutput":       {Index: 0, Keyword: "cmd"},
+	"subprocess.getstatusoutput": {Index: 0, Keyword: "cmd"},
+	"subprocess.run":             {Index: 0, Keyword: "args", Argv: ArgvShell},
+	"subprocess.call":            {Index: 0, Keyword: "args", Argv: ArgvShell},
+	"subprocess.check_call":      {Index: 0, Keyword: "args", Argv: ArgvShell},
+	"subprocess.check_output":    {Index: 0, Keyword: "args", Argv: ArgvShell},
+	"subprocess.Popen":           {Index: 0, Keyword: "args", Argv: ArgvShell},
+}
+
+// ArgvArgumentsOf maps each of SubprocessFunctions to its args argument,
+// narrowed to part.
+func ArgvArgumentsOf(part ArgvPart) map[string]ArgumentPosition {
+	arguments := make(map[string]ArgumentPosition, len(SubprocessFunctions))
+	for _, function := range SubprocessFunctions {
+		arguments[function] = ArgumentPosition{Index: 0, Keyword: "args", Argv: part}
+	}
+	return arguments
+}
+
+// runsInShell reports whether a subprocess call passes shell=True (or any
+// value that is not a falsy literal).
+func runsInShell(callSite *core.CallSite) bool {
+	value, ok := keywordArgument(callSite, "shell")
+	return ok && !isFalsyLiteral(value)
+}
+
+// selectArgv returns the parts of a call's args expression that part
+// selects. An expression that is not a list or tuple literal, such as a
+// program name or a list built elsewhere, counts as the executable.
+func (part ArgvPart) selectArgv(callSite *core.CallSite, expr string) []string {
+	switch part {
+	case ArgvWhole:
+		return []string{expr}
+	case ArgvShell:
+		if runsInShell(callSite) {
+			return []string{expr}
+		}
+		return nil
+	}
+	if runsInShell(callSite) {
+		return nil
+	}
+	elements, ok := sequenceElements(expr)
+	switch {
+	case !ok && part == ArgvExecutable:
+		return []string{expr}
+	case !ok || len(elements) == 0:
+		return nil
+	case part == ArgvExecutable:
+		return elements[:1]
+	default:
+		return elements[1:]
+	}
+}
+
+// selectsElements reports whether part checks argv elements.
+func (part ArgvPart) selectsElements() bool {
+	return part == ArgvExecutable || part == ArgvArguments
+}
+
+// sequenceElements splits a list or tuple literal into its element
+// expressions, and reports false for other expressions.
+func sequenceElements(expr string) ([]string, bool) {
+	expr = strings.TrimSpace(expr)
+	if len(expr) < 2 {
+		return nil, false
+	}
+	if !(expr[0] == '[' && expr[len(expr)-1] == ']') && !(expr[0] == '(' && expr[len(expr)-1] == ')') {
+		return nil, false
+	}
+
+	var elements []string
+	depth, start := 0, 1
+	var quote byte
+	for i := 1; i < len(expr)-1; i++ {
+		c := expr[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(' || c == '[' || c == '{':
+			depth++
+		case c == ')' || c == ']' || c == '}':
+			depth--
+			if depth < 0 {
+				// The brackets do not enclose the expression: [a] + [b]
+				return nil, false
+			}
+		case c == ',' && depth == 0:
+			elements = append(elements, strings.TrimSpace(expr[start:i]))
+			start = i + 1
+		}
+	}
+	if last := strings.TrimSpace(expr[start : len(expr)-1]); last != "" {
+		elements = append(elements, last)
+	}
+	return elements, true
+}
+
+// derivesFrom reports whether the value of name before line was computed,
+// through assignments in statements, from the variable defined on
+// sourceLine.
+func derivesFrom(statements []*core.Statement, name string, line int, sourceLine uint32, depth int) bool {
+	if depth > maxHandleDepth {
+		return false
+	}
+	def := lastDefinitionBefore(statements, name, line)
+	if def == nil {
+		return false
+	}
+	if def.LineNumber == sourceLine {
+		return true
+	}
+	return slices.ContainsFunc(def.Uses, func(use string) bool {
+		return derivesFrom(statements, use, int(def.LineNumber), sourceLine, depth+1)
+	})
+}
+
+// referencesDerivedVariable reports whether an expression reads a variable
+// derived from the variable defined on sourceLine (see derivesFrom).
+func referencesDerivedVariable(expr string, statements []*core.Statement, line int, sourceLine uint32) bool {
+	code := stringLiteral.ReplaceAllString(expr, `""`)
+	for _, match := range variableReference.FindAllStringSubmatch(code, -1) {
+		if derivesFrom(statements, match[2], line, sourceLine, 0) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandInjection_EnvironmentSources(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "env_command")
+
+	registry := NewPatternRegistry()
+	registry.TaintEnvironment = true
+	registry.LoadDefaultPatterns()
+	pattern, ok := registry.GetPattern("COMMAND-INJECTION-001")
+	require.True(t, ok)
+
+	match := registry.MatchPattern(pattern, callGraph)
+	require.True(t, match.Matched)
+	assert.True(t, match.IsIntraProcedural)
+	assert.Equal(t, "deploy.checkout", match.SinkFQN)
+
+	tests := []struct {
+		function string
+		source   string
+		sink     string
+		flagged  bool
+	}{
+		{"deploy.checkout", "os.environ", "subprocess.run", true},
+		{"deploy.notify", "os.getenv", "os.system", true},
+		{"deploy.checkout_quoted", "os.environ.get", "subprocess.run", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.function, func(t *testing.T) {
+			match := registry.checkIntraProceduralTaint(
+				callInfo{caller: tt.function, target: tt.source},
+				callInfo{caller: tt.function, target: tt.sink},
+				callGraph, pattern)
+			assert.Equal(t, tt.flagged, match != nil)
+		})
+	}
+}
+
+func TestCommandInjection_EnvironmentTrustedByDefault(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "env_command")
+
+	registry := NewPatternRegistry()
+	registry.LoadDefaultPatterns()
+	pattern, ok := registry.GetPattern("COMMAND-INJECTION-001")
+	require.True(t, ok)
+	assert.NotContains(t, pattern.Sources, "os.environ")
+
+	match := registry.MatchPattern(pattern, callGraph)
+	assert.False(t, match.Matched)
+}
+
+func TestSelectArgv(t *testing.T) {
+	call := func(args ...string) *core.CallSite {
+		callSite := &core.CallSite{}
+		for i, arg := range args {
+			callSite.Arguments = append(callSite.Arguments, core.Argument{Value: arg, Position: i})
+		}
+		return callSite
+	}
+	argv := `[tool, "--input", path]`
+
+	tests := []struct {
+		name     string
+		callSite *core.CallSite
+		part     ArgvPart
+		want     []string
+	}{
+		{"executable of a list", call(argv), ArgvExecutable, []string{"tool"}},
+		{"arguments of a list", call(argv), ArgvArguments, []string{`"--input"`, "path"}},
+		{"executable of a tuple", call(`(tool, path)`), ArgvExecutable, []string{"tool"}},
+		{"lone program name", call("program"), ArgvExecutable, []string{"program"}},
+		{"lone program has no arguments", call("program"), ArgvArguments, nil},
+		{"no elements with a shell", call(argv, "shell=True"), ArgvExecutable, nil},
+		{"shell=False is no shell", call(argv, "shell=False"), ArgvArguments, []string{`"--input"`, "path"}},
+		{"shell command", call(`"ping " + host`, "shell=True"), ArgvShell, []string{`"ping " + host`}},
+		{"no shell command without a shell", call(argv), ArgvShell, nil},
+		{"whole argument", call(argv), ArgvWhole, []string{argv}},
+		{"concatenated lists are not a literal", call(`[tool] + extra`), ArgvExecutable, []string{`[tool] + extra`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr := openArgument(tt.callSite, 0, "args")
+			assert.Equal(t, tt.want, tt.part.selectArgv(tt.callSite, expr))
+		})
+	}
+}
+
+func TestSequenceElements(t *testing.T) {
+	elements, ok := sequenceElements(`["convert", f"{name}.png", opts["size"], ("a", "b")]`)
+	require.True(t, ok)
+	assert.Equal(t, []string{`"convert"`, `f"{name}.png"`, `opts["size"]`, `("a", "b")`}, elements)
+
+	elements, ok = sequenceElements(`["a, b",]`)
+	require.True(t, ok)
+	assert.Equal(t, []string{`"a, b"`}, elements)
+
+	_, ok = sequenceElements("argv")
+	assert.False(t, ok)
+	_, ok = sequenceElements("[a] + [b]")
+	assert.False(t, ok)
+}
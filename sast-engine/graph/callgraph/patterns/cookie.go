@@ -0,0 +1,126 @@
+package patterns
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// CookieSetterMethods are the response methods of Django, Flask, and the
+// other web frameworks that set a cookie. They all take secure, httponly,
+// and samesite keyword arguments, which default to off.
+var CookieSetterMethods = []string{"set_cookie", "set_signed_cookie"}
+
+// CookieFlags are the keyword arguments a cookie needs to be protected from
+// interception (secure), script access (httponly), and cross-site requests
+// (samesite).
+var CookieFlags = []string{"secure", "httponly", "samesite"}
+
+// SessionCookieSettings are the Django and Flask settings controlling the
+// session and CSRF cookie flags, as passed to app.config.update(...) or
+// settings.configure(...).
+var SessionCookieSettings = []string{
+	"SESSION_COOKIE_SECURE",
+	"SESSION_COOKIE_HTTPONLY",
+	"SESSION_COOKIE_SAMESITE",
+	"CSRF_COOKIE_SECURE",
+	"CSRF_COOKIE_HTTPONLY",
+	"CSRF_COOKIE_SAMESITE",
+}
+
+// isFalsyLiteral reports whether a Python literal disables a cookie flag.
+// samesite="None" turns SameSite protection off as well.
+func isFalsyLiteral(value string) bool {
+	switch strings.Trim(value, `"'`) {
+	case "False", "None", "0", "":
+		return true
+	}
+	return false
+}
+
+// isCookieSetter reports whether a call sets a cookie through a web
+// framework: a CookieSetterMethods call that resolves into a web framework,
+// or, when the receiver's type is unknown, one in a caller that calls into
+// a web framework.
+func isCookieSetter(callSite *core.CallSite, callerUsesWebFramework bool) bool {
+	method := callSite.Target[strings.LastIndex(callSite.Target, ".")+1:]
+	if !slices.Contains(CookieSetterMethods, method) || !strings.Contains(callSite.Target, ".") {
+		return false
+	}
+	return core.GetFrameworkCategory(callSite.TargetFQN) == "web" || callerUsesWebFramework
+}
+
+// usesWebFramework reports whether any call in callSites resolves into a web
+// framework (see core.GetFrameworkCategory).
+func usesWebFramework(callSites []core.CallSite) bool {
+	return slices.ContainsFunc(callSites, func(site core.CallSite) bool {
+		return core.GetFrameworkCategory(site.TargetFQN) == "web"
+	})
+}
+
+// insecureCookieFlags returns the cookie flags of a set_cookie call that are
+// missing or set to a falsy literal, e.g. ["secure=False", "missing httponly"].
+// Flags set through variables are assumed to be set.
+func insecureCookieFlags(callSite *core.CallSite) []string {
+	var insecure []string
+	for _, flag := range CookieFlags {
+		value, ok := keywordArgument(callSite, flag)
+		switch {
+		case !ok:
+			insecure = append(insecure, "missing "+flag)
+		case isFalsyLiteral(value):
+			insecure = append(insecure, flag+"="+value)
+		}
+	}
+	return insecure
+}
+
+// insecureSessionSettings returns the SessionCookieSettings a call sets to
+// a falsy literal, e.g. ["SESSION_COOKIE_SECURE=False"].
+func insecureSessionSettings(callSite *core.CallSite) []string {
+	var insecure []string
+	for _, setting := range SessionCookieSettings {
+		if value, ok := keywordArgument(callSite, setting); ok && isFalsyLiteral(value) {
+			insecure = append(insecure, setting+"="+value)
+		}
+	}
+	return insecure
+}
+
+// findInsecureCookies returns every set_cookie call missing secure,
+// httponly, or samesite, or setting one to a falsy literal, and every
+// settings call turning a SessionCookieSettings flag off, ordered by caller
+// FQN and line. Context lists the offending flags. Taint is not required.
+func (pr *PatternRegistry) findInsecureCookies(pattern *Pattern, callGraph *core.CallGraph) []*PatternMatchDetails {
+	var matches []*PatternMatchDetails
+	for _, caller := range sortedCallers(callGraph) {
+		callSites := sortedCallSites(callGraph, caller)
+		webFramework := usesWebFramework(callSites)
+		for i := range callSites {
+			callSite := &callSites[i]
+			context := ""
+			if isCookieSetter(callSite, webFramework) {
+				if flags := insecureCookieFlags(callSite); len(flags) > 0 {
+					context = "insecure cookie: " + strings.Join(flags, ", ")
+				}
+			} else if settings := insecureSessionSettings(callSite); len(settings) > 0 {
+				context = "insecure session cookie: " + strings.Join(settings, ", ")
+			}
+			if context == "" {
+				continue
+			}
+
+			matches = append(matches, &PatternMatchDetails{
+				Matched:           true,
+				IsIntraProcedural: true,
+				SourceFQN:         caller,
+				SinkFQN:           caller,
+				SinkCall:          callSite.TargetFQN,
+				DataFlowPath:      []string{caller},
+				Context:           context,
+			})
+		}
+	}
+	return matches
+}
@@ -0,0 +1,38 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsecureCookie(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "insecure_cookie")
+
+	patternRegistry := NewPatternRegistry()
+	patternRegistry.LoadDefaultPatterns()
+	pattern, ok := patternRegistry.GetPattern("INSECURE-COOKIE-001")
+	require.True(t, ok)
+
+	found := make(map[string]string)
+	for _, match := range patternRegistry.findInsecureCookies(pattern, callGraph) {
+		found[match.SinkFQN] = match.Context
+	}
+
+	assert.Equal(t, map[string]string{
+		"app":             "insecure session cookie: SESSION_COOKIE_SECURE=False",
+		"app.login":       "insecure cookie: missing secure, missing httponly, missing samesite",
+		"app.remember":    "insecure cookie: secure=False",
+		"app.flask_login": "insecure cookie: samesite=None",
+	}, found)
+
+	// A fully flagged cookie, and set_cookie outside a web framework, are
+	// not flagged
+	assert.NotContains(t, found, "app.secure_login")
+	assert.NotContains(t, found, "app.browser_helper")
+
+	match := patternRegistry.MatchPattern(pattern, callGraph)
+	require.True(t, match.Matched)
+	assert.Equal(t, "app", match.SinkFQN)
+}
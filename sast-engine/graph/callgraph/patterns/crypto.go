@@ -0,0 +1,227 @@
+package patterns
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// WeakHashFunctions are hash constructors unsuitable for security purposes.
+// hashlib.new is only flagged when it names a weak algorithm.
+var WeakHashFunctions = []string{
+	"hashlib.md5",
+	"hashlib.sha1",
+	"hashlib.new",
+	"Crypto.Hash.MD5.new",
+	"Crypto.Hash.SHA1.new",
+	"Cryptodome.Hash.MD5.new",
+	"Cryptodome.Hash.SHA1.new",
+	"cryptography.hazmat.primitives.hashes.MD5",
+	"cryptography.hazmat.primitives.hashes.SHA1",
+}
+
+// WeakCipherFunctions are broken ciphers and cipher constructors that may be
+// configured with ECB mode. AES and Triple DES constructors are only flagged
+// when called with MODE_ECB.
+var WeakCipherFunctions = []string{
+	"Crypto.Cipher.DES.new",
+	"Crypto.Cipher.ARC4.new",
+	"Crypto.Cipher.Blowfish.new",
+	"Crypto.Cipher.AES.new",
+	"Crypto.Cipher.DES3.new",
+	"Cryptodome.Cipher.DES.new",
+	"Cryptodome.Cipher.ARC4.new",
+	"Cryptodome.Cipher.Blowfish.new",
+	"Cryptodome.Cipher.AES.new",
+	"Cryptodome.Cipher.DES3.new",
+	"cryptography.hazmat.primitives.ciphers.algorithms.ARC4",
+	"cryptography.hazmat.primitives.ciphers.algorithms.Blowfish",
+	"cryptography.hazmat.primitives.ciphers.algorithms.TripleDES",
+	"cryptography.hazmat.primitives.ciphers.modes.ECB",
+}
+
+// InsecureRandomFunctions are Mersenne Twister functions from the random
+// module. They are predictable, so they are only flagged when used to
+// produce tokens, passwords, keys, and similar secrets.
+var InsecureRandomFunctions = []string{
+	"random.random",
+	"random.randint",
+	"random.randrange",
+	"random.getrandbits",
+	"random.choice",
+	"random.choices",
+	"random.sample",
+	"random.uniform",
+}
+
+// securityContextKeywords name values whose generation or hashing needs a
+// cryptographically strong primitive.
+var securityContextKeywords = []string{
+	"password", "passwd", "pwd", "secret", "token", "key", "apikey", "nonce",
+	"salt", "otp", "session", "csrf", "signature", "credential", "auth",
+}
+
+// weakCryptoSuppression is the inline comment that marks a weak primitive as
+// intentional, e.g. "hashlib.md5(data)  # nosec: cache key".
+const weakCryptoSuppression = "nosec"
+
+// weakCryptoReason describes why a call to a weak API is flagged, or returns
+// "" if the call's arguments make it safe (e.g., AES in GCM mode).
+func weakCryptoReason(targetFQN string, callSite *core.CallSite) string {
+	name := targetFQN[strings.LastIndex(targetFQN, ".")+1:]
+	if name == "new" {
+		name = strings.TrimSuffix(targetFQN, ".new")
+		name = name[strings.LastIndex(name, ".")+1:]
+	}
+
+	switch {
+	case targetFQN == "hashlib.new":
+		algorithm := firstPositionalArgument(callSite)
+		algorithm = strings.ToLower(strings.Trim(algorithm, `"'`))
+		if algorithm != "md5" && algorithm != "sha1" {
+			return ""
+		}
+		return "weak hash " + strings.ToUpper(algorithm)
+	case slices.Contains(WeakHashFunctions, targetFQN):
+		return "weak hash " + strings.ToUpper(name)
+	case slices.Contains(InsecureRandomFunctions, targetFQN):
+		return "non-cryptographic random " + targetFQN
+	case name == "ECB":
+		return "ECB cipher mode"
+	case name == "AES" || name == "DES3":
+		if !hasArgumentContaining(callSite, "MODE_ECB") {
+			return ""
+		}
+		return name + " in ECB mode"
+	default:
+		return "weak cipher " + name
+	}
+}
+
+// firstPositionalArgument returns the first non-keyword argument of a call.
+func firstPositionalArgument(callSite *core.CallSite) string {
+	for _, arg := range callSite.Arguments {
+		if _, _, ok := splitKeywordArgument(arg.Value); !ok {
+			return arg.Value
+		}
+	}
+	return ""
+}
+
+// hasArgumentContaining reports whether any argument's text contains s.
+func hasArgumentContaining(callSite *core.CallSite, s string) bool {
+	for _, arg := range callSite.Arguments {
+		if strings.Contains(arg.Value, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// notUsedForSecurity reports whether a hash call passes
+// usedforsecurity=False, the stdlib's marker for non-security digests.
+func notUsedForSecurity(callSite *core.CallSite) bool {
+	for _, arg := range callSite.Arguments {
+		keyword, value, ok := splitKeywordArgument(arg.Value)
+		if ok && keyword == "usedforsecurity" && value == "False" {
+			return true
+		}
+	}
+	return false
+}
+
+// securityContext returns the first security keyword found in the variable
+// assigned by the call, the call's arguments, or the enclosing function's
+// name, together with the name it was found in. Cipher arguments are not
+// considered, since every cipher takes a key.
+func securityContext(caller, variable string, callSite *core.CallSite) (keyword, name string) {
+	candidates := []string{variable}
+	if !slices.Contains(WeakCipherFunctions, callSite.TargetFQN) {
+		for _, arg := range callSite.Arguments {
+			if isIdentifier(arg.Value) {
+				candidates = append(candidates, arg.Value)
+			}
+		}
+	}
+	candidates = append(candidates, caller[strings.LastIndex(caller, ".")+1:])
+
+	for _, candidate := range candidates {
+		for _, part := range strings.Split(strings.ToLower(candidate), "_") {
+			for _, keyword := range securityContextKeywords {
+				if part == keyword || part == keyword+"s" {
+					return keyword, candidate
+				}
+			}
+		}
+	}
+	return "", ""
+}
+
+// isWeakCryptoAllowed reports whether caller is covered by the registry's
+// allowlist, either exactly or as a member of a listed module or class.
+func (pr *PatternRegistry) isWeakCryptoAllowed(caller string) bool {
+	for _, allowed := range pr.WeakCryptoAllowlist {
+		if caller == allowed || strings.HasPrefix(caller, allowed+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// findWeakCrypto returns every call to one of the pattern's
+// DangerousFunctions that uses a weak primitive, ordered by caller FQN and
+// line. Taint is not required. Functions listed in
+// PatternRegistry.WeakCryptoAllowlist, hash calls passing
+// usedforsecurity=False, and lines marked "# nosec" are skipped.
+func (pr *PatternRegistry) findWeakCrypto(pattern *Pattern, callGraph *core.CallGraph) []*PatternMatchDetails {
+	sources := newSourceLines(callGraph)
+
+	var matches []*PatternMatchDetails
+	for _, caller := range sortedCallers(callGraph) {
+		if pr.isWeakCryptoAllowed(caller) {
+			continue
+		}
+		for _, callSite := range sortedCallSites(callGraph, caller) {
+			if !slices.Contains(pattern.DangerousFunctions, callSite.TargetFQN) {
+				continue
+			}
+			reason := weakCryptoReason(callSite.TargetFQN, &callSite)
+			if reason == "" || notUsedForSecurity(&callSite) {
+				continue
+			}
+
+			variable := definedAtLine(callGraph.Statements[caller], callSite.Location.Line)
+			keyword, name := securityContext(caller, variable, &callSite)
+			if keyword == "" && slices.Contains(InsecureRandomFunctions, callSite.TargetFQN) {
+				continue
+			}
+			if hasSuppressionComment(sources, callSite.Location) {
+				continue
+			}
+
+			context := reason
+			if keyword != "" {
+				context += " used for " + keyword + " (" + name + ")"
+			}
+			matches = append(matches, &PatternMatchDetails{
+				Matched:           true,
+				IsIntraProcedural: true,
+				SourceFQN:         caller,
+				SinkFQN:           caller,
+				SinkCall:          callSite.TargetFQN,
+				DataFlowPath:      []string{caller},
+				Context:           context,
+			})
+		}
+	}
+	return matches
+}
+
+// hasSuppressionComment reports whether the source line at loc carries a
+// "# nosec" comment.
+func hasSuppressionComment(sources sourceLines, loc core.Location) bool {
+	line := sources.line(loc.File, loc.Line)
+	comment := strings.IndexByte(line, '#')
+	return comment >= 0 && strings.Contains(line[comment:], weakCryptoSuppression)
+}
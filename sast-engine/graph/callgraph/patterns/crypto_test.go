@@ -0,0 +1,105 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func weakCryptoFindings(t *testing.T, registry *PatternRegistry, patternID string, callGraph *core.CallGraph) map[string]string {
+	t.Helper()
+	pattern, ok := registry.GetPattern(patternID)
+	require.True(t, ok)
+
+	found := make(map[string]string)
+	for _, match := range registry.findWeakCrypto(pattern, callGraph) {
+		found[match.SinkFQN] = match.Context
+	}
+	return found
+}
+
+func TestWeakCrypto_HashesAndCiphers(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "weak_crypto")
+	registry := NewPatternRegistry()
+	registry.LoadDefaultPatterns()
+
+	// strong_hash (sha256), encrypt_gcm (AES-GCM), file_checksum
+	// (usedforsecurity=False), and etag (# nosec) are not flagged.
+	assert.Equal(t, map[string]string{
+		"encryption.encrypt_des":        "weak cipher DES",
+		"encryption.encrypt_ecb":        "AES in ECB mode",
+		"encryption.encrypt_hazmat_ecb": "ECB cipher mode",
+		"hashing.cache_digest":          "weak hash SHA1",
+		"hashing.hash_password":         "weak hash MD5 used for password (hash_password)",
+		"hashing.named_hash":            "weak hash MD5",
+		"hashing.sign_token":            "weak hash SHA1 used for signature (signature)",
+	}, weakCryptoFindings(t, registry, "WEAK-CRYPTO-001", callGraph))
+}
+
+func TestWeakCrypto_RandomTokens(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "weak_crypto")
+	registry := NewPatternRegistry()
+	registry.LoadDefaultPatterns()
+
+	// shuffle_deck uses random outside a security context; secure_token uses
+	// the secrets module.
+	assert.Equal(t, map[string]string{
+		"tokens.generate_reset_token": "non-cryptographic random random.choice used for token (token)",
+		"tokens.make_session_id":      "non-cryptographic random random.getrandbits used for session (make_session_id)",
+	}, weakCryptoFindings(t, registry, "WEAK-RANDOM-001", callGraph))
+}
+
+func TestWeakCrypto_Allowlist(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "weak_crypto")
+	registry := NewPatternRegistry()
+	registry.WeakCryptoAllowlist = []string{"hashing.cache_digest", "encryption"}
+	registry.LoadDefaultPatterns()
+
+	found := weakCryptoFindings(t, registry, "WEAK-CRYPTO-001", callGraph)
+	assert.NotContains(t, found, "hashing.cache_digest")
+	assert.NotContains(t, found, "encryption.encrypt_des")
+	assert.Contains(t, found, "hashing.hash_password")
+}
+
+func TestWeakCrypto_MatchPattern(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "weak_crypto")
+	registry := NewPatternRegistry()
+	registry.LoadDefaultPatterns()
+	pattern, ok := registry.GetPattern("WEAK-CRYPTO-001")
+	require.True(t, ok)
+
+	match := registry.MatchPattern(pattern, callGraph)
+	require.True(t, match.Matched)
+	assert.Equal(t, "encryption.encrypt_des", match.SinkFQN)
+	assert.Equal(t, "Crypto.Cipher.DES.new", match.SinkCall)
+	assert.Equal(t, "CWE-327", pattern.CWE)
+}
+
+func TestWeakCryptoReason(t *testing.T) {
+	tests := []struct {
+		name      string
+		targetFQN string
+		args      []string
+		want      string
+	}{
+		{"md5", "hashlib.md5", []string{"data"}, "weak hash MD5"},
+		{"hashlib.new sha1", "hashlib.new", []string{"'SHA1'", "data"}, "weak hash SHA1"},
+		{"hashlib.new sha256", "hashlib.new", []string{`"sha256"`}, ""},
+		{"AES CBC", "Crypto.Cipher.AES.new", []string{"key", "AES.MODE_CBC"}, ""},
+		{"DES3 ECB", "Crypto.Cipher.DES3.new", []string{"key", "DES3.MODE_ECB"}, "DES3 in ECB mode"},
+		{"ARC4", "Cryptodome.Cipher.ARC4.new", []string{"key"}, "weak cipher ARC4"},
+		{"TripleDES", "cryptography.hazmat.primitives.ciphers.algorithms.TripleDES", []string{"key"}, "weak cipher TripleDES"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			callSite := &core.CallSite{TargetFQN: tt.targetFQN}
+			for _, arg := range tt.args {
+				callSite.Arguments = append(callSite.Arguments, core.Argument{Value: arg})
+			}
+			assert.Equal(t, tt.want, weakCryptoReason(tt.targetFQN, callSite))
+		})
+	}
+}
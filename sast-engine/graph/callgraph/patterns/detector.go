@@ -1,10 +1,13 @@
 package patterns
 
 import (
+	"cmp"
+	"fmt"
 	"log"
 	"slices"
 	"strings"
 
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/analysis/taint"
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/extraction"
@@ -22,6 +25,67 @@ const (
 
 	// PatternTypeDangerousFunction detects calls to dangerous functions.
 	PatternTypeDangerousFunction PatternType = "dangerous-function"
+
+	// PatternTypeOpenRedirect detects tainted URLs passed to framework redirects.
+	PatternTypeOpenRedirect PatternType = "open-redirect"
+
+	// PatternTypeAutoescapeOff detects Jinja environments with autoescaping
+	// disabled and tainted data rendered through them.
+	PatternTypeAutoescapeOff PatternType = "autoescape-off"
+
+	// PatternTypeWeakCrypto detects weak hashes, ciphers, and cipher modes,
+	// and non-cryptographic randomness used for secrets.
+	PatternTypeWeakCrypto PatternType = "weak-crypto"
+
+	// PatternTypeInsecureTLS detects disabled TLS certificate verification.
+	PatternTypeInsecureTLS PatternType = "insecure-tls"
+
+	// PatternTypeTemplateSink detects tainted data passed to the template
+	// render sinks of the detected framework.
+	PatternTypeTemplateSink PatternType = "template-sink"
+
+	// PatternTypeMassAssignment detects request data spread into ORM writes.
+	PatternTypeMassAssignment PatternType = "mass-assignment"
+
+	// PatternTypeNoSQLInjection detects request data used as a MongoDB query.
+	PatternTypeNoSQLInjection PatternType = "nosql-injection"
+
+	// PatternTypeInsecureTempFile detects racy temporary file creation.
+	PatternTypeInsecureTempFile PatternType = "insecure-temp-file"
+
+	// PatternTypeMutableDefault detects mutable parameter default values.
+	PatternTypeMutableDefault PatternType = "mutable-default"
+
+	// PatternTypeSSTI detects tainted data used as a template's source.
+	PatternTypeSSTI PatternType = "ssti"
+
+	// PatternTypeAssertAuth detects assert statements used as auth checks.
+	PatternTypeAssertAuth PatternType = "assert-auth"
+
+	// PatternTypeInsecureCookie detects cookies set without security flags.
+	PatternTypeInsecureCookie PatternType = "insecure-cookie"
+
+	// PatternTypeSQLInjection detects tainted data built into SQL statements.
+	PatternTypeSQLInjection PatternType = "sql-injection"
+
+	// PatternTypeLogFormat detects tainted data used as a logging format
+	// string.
+	PatternTypeLogFormat PatternType = "log-format"
+
+	// PatternTypeLDAPInjection detects tainted data built into an LDAP
+	// search filter.
+	PatternTypeLDAPInjection PatternType = "ldap-injection"
+
+	// PatternTypeXPathInjection detects tainted data built into an XPath
+	// expression.
+	PatternTypeXPathInjection PatternType = "xpath-injection"
+
+	// PatternTypeIndexError detects indexing provably out of range.
+	PatternTypeIndexError PatternType = "index-error"
+
+	// PatternTypeHeaderInjection detects tainted data set as a response
+	// header.
+	PatternTypeHeaderInjection PatternType = "header-injection"
 )
 
 // Severity indicates the risk level of a security pattern match.
@@ -54,14 +118,72 @@ type Pattern struct {
 	// DangerousFunctions for PatternTypeDangerousFunction
 	DangerousFunctions []string
 
+	// Propagators decide which calls carry taint to their result. When
+	// empty, any tainted argument taints a call's result.
+	Propagators []taint.Propagator
+
 	CWE   string // Common Weakness Enumeration
 	OWASP string // OWASP Top 10 category
+
+	// Remediation is a hint on fixing a match, reported with matches whose
+	// matcher has no more specific one.
+	Remediation string
+
+	// MaxPathLength bounds the calls between the source and sink functions
+	// of an inter-procedural flow; longer flows are not searched or
+	// reported. 0 means unlimited.
+	MaxPathLength int
+
+	// SinkArguments, when set, limits the sinks of source-sink and
+	// missing-sanitizer patterns to calls whose target FQN it lists, and
+	// to taint passed as the listed argument of each (e.g., the code given
+	// to eval, not its globals dict).
+	SinkArguments map[string]ArgumentPosition
 }
 
 // PatternRegistry manages security patterns.
 type PatternRegistry struct {
 	Patterns       map[string]*Pattern        // Pattern ID -> Pattern
 	PatternsByType map[PatternType][]*Pattern // Type -> Patterns
+
+	// TrustDjangoSession excludes request.session from the Django sources
+	// used by LoadDefaultPatterns. Session data is tainted by default.
+	TrustDjangoSession bool
+
+	// Framework selects the request sources of the patterns loaded by
+	// LoadDefaultPatterns: those of one of FrameworkRulesets (see
+	// FrameworkRuleset). When empty, the Django and Flask sources are used.
+	Framework string
+
+	// GlobalSources are added to the request sources of the patterns loaded
+	// by LoadDefaultPatterns, whatever the Framework.
+	GlobalSources []string
+
+	// TaintEnvironment adds EnvironmentSources to the sources of the code,
+	// command, and SQL injection patterns loaded by LoadDefaultPatterns.
+	// Environment variables are trusted by default.
+	TaintEnvironment bool
+
+	// DisabledPatterns lists the IDs of patterns that stay registered, and
+	// are listed by Catalog, but are skipped by analysis.
+	DisabledPatterns []string
+
+	// WeakCryptoAllowlist lists function FQNs, or module and class prefixes,
+	// whose weak-crypto calls are known non-security uses (e.g., checksums).
+	WeakCryptoAllowlist []string
+
+	// SinkAllowlist exempts sink calls made from specific modules. Analysis
+	// still reports them, marked SuppressedByAllowlist. Populated by
+	// LoadSinkAllowlistConfig.
+	SinkAllowlist []SinkExemption
+
+	// RenderSinks adds template render sinks per framework name to the
+	// built-in RenderSinks. Populated by LoadRenderSinkConfig.
+	RenderSinks map[string][]string
+
+	// Propagators are added to every pattern's Propagators when matching.
+	// Populated by LoadPropagatorConfig.
+	Propagators []taint.Propagator
 }
 
 // NewPatternRegistry creates a new pattern registry.
@@ -89,37 +211,437 @@ func (pr *PatternRegistry) GetPatternsByType(patternType PatternType) []*Pattern
 	return pr.PatternsByType[patternType]
 }
 
-// LoadDefaultPatterns loads the hardcoded example pattern.
+// LoadDefaultPatterns loads the hardcoded example patterns.
 // Additional patterns will be loaded from queries in future PRs.
 func (pr *PatternRegistry) LoadDefaultPatterns() {
-	// Example hardcoded pattern: Code injection via eval()
+	// Code injection via the source argument of eval, exec, and compile
+	pr.AddPattern(&Pattern{
+		ID:            "CODE-INJECTION-001",
+		Name:          "Code injection via eval with user input",
+		Description:   "Detects code injection when user input flows to the code argument of eval(), exec(), or compile() without sanitization",
+		Type:          PatternTypeMissingSanitizer,
+		Severity:      SeverityCritical,
+		Sources:       slices.Concat(pr.requestSources(), []string{"input", "raw_input", "request.query_params.get"}, pr.environmentSources()),
+		Sinks:         []string{"eval", "exec", "compile"},
+		SinkArguments: CodeExecutionArguments,
+		Sanitizers:    []string{"sanitize", "escape", "validate"},
+		CWE:           "CWE-94",
+		OWASP:         "A03:2021-Injection",
+	})
+
+	// Importing a module named by user input runs its top-level code
+	pr.AddPattern(&Pattern{
+		ID:            "CODE-INJECTION-002",
+		Name:          "Dynamic import of a user-controlled module",
+		Description:   "Detects user input used as the module name of __import__() or importlib.import_module()",
+		Type:          PatternTypeMissingSanitizer,
+		Severity:      SeverityHigh,
+		Sources:       slices.Concat(pr.requestSources(), []string{"input", "raw_input", "request.query_params.get"}, pr.environmentSources()),
+		Sinks:         []string{"__import__", "import_module"},
+		SinkArguments: DynamicImportArguments,
+		Sanitizers:    []string{"sanitize", "validate"},
+		CWE:           "CWE-470",
+		OWASP:         "A03:2021-Injection",
+		Remediation:   "map user input to modules through an allowlist instead of importing it by name",
+	})
+
+	// OS command injection via shell commands built from tainted data
+	pr.AddPattern(&Pattern{
+		ID:            "COMMAND-INJECTION-001",
+		Name:          "OS command injection with user input",
+		Description:   "Detects tainted data reaching a shell command run by os.system, os.popen, or subprocess with shell=True without shell quoting",
+		Type:          PatternTypeMissingSanitizer,
+		Severity:      SeverityCritical,
+		Sources:       slices.Concat(pr.requestSources(), pr.environmentSources()),
+		Sinks:         CommandExecutionFunctions,
+		SinkArguments: ShellCommandArguments,
+		Sanitizers:    []string{"shlex.quote", "pipes.quote"},
+		CWE:           "CWE-78",
+		OWASP:         "A03:2021-Injection",
+	})
+
+	// Without a shell, the first argv element is the program to run...
+	pr.AddPattern(&Pattern{
+		ID:            "COMMAND-INJECTION-002",
+		Name:          "Subprocess runs a user-controlled program",
+		Description:   "Detects tainted data used as the executable of a subprocess call: the first element of its argv list, or a lone program name",
+		Type:          PatternTypeMissingSanitizer,
+		Severity:      SeverityHigh,
+		Sources:       slices.Concat(pr.requestSources(), pr.environmentSources()),
+		Sinks:         SubprocessFunctions,
+		SinkArguments: ArgvArgumentsOf(ArgvExecutable),
+		CWE:           "CWE-78",
+		OWASP:         "A03:2021-Injection",
+		Remediation:   "choose the program from an allowlist instead of running it by name",
+	})
+
+	// ...and later elements are arguments the program may read as options
+	pr.AddPattern(&Pattern{
+		ID:            "COMMAND-INJECTION-003",
+		Name:          "User input in subprocess arguments",
+		Description:   "Detects tainted data passed as an argument after the program in a subprocess argv list, where the program may interpret it as an option",
+		Type:          PatternTypeMissingSanitizer,
+		Severity:      SeverityMedium,
+		Sources:       slices.Concat(pr.requestSources(), pr.environmentSources()),
+		Sinks:         SubprocessFunctions,
+		SinkArguments: ArgvArgumentsOf(ArgvArguments),
+		CWE:           "CWE-88",
+		OWASP:         "A03:2021-Injection",
+		Remediation:   `end options with "--" before user input, or validate it does not start with "-"`,
+	})
+
+	// Open redirect via request-controlled redirect targets
+	pr.AddPattern(&Pattern{
+		ID:          "OPEN-REDIRECT-001",
+		Name:        "Open redirect via user-controlled URL",
+		Description: "Detects request data used as the target URL of a framework redirect without host validation",
+		Type:        PatternTypeOpenRedirect,
+		Severity:    SeverityMedium,
+		Sources:     pr.requestSources(),
+		Sinks:       allRedirectFunctions(),
+		Sanitizers:  RedirectSanitizers,
+		CWE:         "CWE-601",
+		OWASP:       "A01:2021-Broken Access Control",
+	})
+
+	// Jinja autoescaping disabled: the configuration alone, and request
+	// data rendered through it (higher severity)
+	pr.AddPattern(&Pattern{
+		ID:          "JINJA-AUTOESCAPE-001",
+		Name:        "Jinja environment with autoescaping disabled",
+		Description: "Detects Jinja Environment or Template constructed with autoescape=False",
+		Type:        PatternTypeAutoescapeOff,
+		Severity:    SeverityMedium,
+		CWE:         "CWE-79",
+		OWASP:       "A03:2021-Injection",
+	})
+	pr.AddPattern(&Pattern{
+		ID:          "XSS-JINJA-001",
+		Name:        "XSS via template rendered with autoescaping disabled",
+		Description: "Detects request data rendered through a Jinja environment or template constructed with autoescape=False",
+		Type:        PatternTypeAutoescapeOff,
+		Severity:    SeverityHigh,
+		Sources:     pr.requestSources(),
+		Sinks:       JinjaRenderMethods,
+		Sanitizers:  []string{"escape", "markupsafe.escape"},
+		CWE:         "CWE-79",
+		OWASP:       "A03:2021-Injection",
+	})
+
+	// Weak cryptography, flagged regardless of taint. Findings carry the
+	// detected purpose (e.g., "used for password") in Context.
+	pr.AddPattern(&Pattern{
+		ID:                 "WEAK-CRYPTO-001",
+		Name:               "Weak cryptographic primitive",
+		Description:        "Detects MD5/SHA-1 hashing, broken ciphers such as DES and RC4, and ECB cipher mode",
+		Type:               PatternTypeWeakCrypto,
+		Severity:           SeverityMedium,
+		DangerousFunctions: append(slices.Clone(WeakHashFunctions), WeakCipherFunctions...),
+		CWE:                "CWE-327",
+		OWASP:              "A02:2021-Cryptographic Failures",
+	})
 	pr.AddPattern(&Pattern{
-		ID:          "CODE-INJECTION-001",
-		Name:        "Code injection via eval with user input",
-		Description: "Detects code injection when user input flows to eval() without sanitization",
-		Type:        PatternTypeMissingSanitizer,
+		ID:                 "WEAK-RANDOM-001",
+		Name:               "Predictable random values used for secrets",
+		Description:        "Detects the random module used to generate tokens, passwords, keys, or other secrets",
+		Type:               PatternTypeWeakCrypto,
+		Severity:           SeverityMedium,
+		DangerousFunctions: InsecureRandomFunctions,
+		CWE:                "CWE-338",
+		OWASP:              "A02:2021-Cryptographic Failures",
+	})
+
+	// Disabled certificate validation, flagged regardless of taint
+	pr.AddPattern(&Pattern{
+		ID:                 "INSECURE-TLS-001",
+		Name:               "TLS certificate verification disabled",
+		Description:        "Detects HTTP requests made with verify=False and SSL contexts created without certificate validation",
+		Type:               PatternTypeInsecureTLS,
+		Severity:           SeverityHigh,
+		Sinks:              TLSVerifyModules,
+		DangerousFunctions: UnverifiedTLSFunctions,
+		CWE:                "CWE-295",
+		OWASP:              "A07:2021-Identification and Authentication Failures",
+	})
+
+	// Request data in template output or source; render sinks are chosen
+	// per call from the framework the target resolves into
+	pr.AddPattern(&Pattern{
+		ID:          "XSS-TEMPLATE-001",
+		Name:        "XSS via template render sink",
+		Description: "Detects request data passed to a template engine's unescaped render or template compilation API",
+		Type:        PatternTypeTemplateSink,
+		Severity:    SeverityHigh,
+		Sources:     pr.requestSources(),
+		Sinks:       allRenderSinks(),
+		Sanitizers:  []string{"escape", "markupsafe.escape", "html.escape"},
+		CWE:         "CWE-79",
+		OWASP:       "A03:2021-Injection",
+	})
+
+	// Request data compiled as a template, rather than rendered into one
+	pr.AddPattern(&Pattern{
+		ID:          "SSTI-001",
+		Name:        "Server-side template injection",
+		Description: "Detects request data used as the source of a template; pass it as template context instead",
+		Type:        PatternTypeSSTI,
+		Severity:    SeverityCritical,
+		Sources:     pr.requestSources(),
+		Sinks:       templateSourceFunctions(),
+		CWE:         "CWE-1336",
+		OWASP:       "A03:2021-Injection",
+	})
+
+	// Request data spread as **kwargs into model fields; form and
+	// serializer output is limited to declared fields
+	pr.AddPattern(&Pattern{
+		ID:          "MASS-ASSIGNMENT-001",
+		Name:        "Mass assignment of request data to ORM model",
+		Description: "Detects request data spread with ** into ORM create/update calls or model constructors that are saved",
+		Type:        PatternTypeMassAssignment,
+		Severity:    SeverityHigh,
+		Sources:     pr.requestSources(),
+		Sinks:       ORMWriteMethods,
+		Sanitizers:  MassAssignmentSanitizers,
+		CWE:         "CWE-915",
+		OWASP:       "A08:2021-Software and Data Integrity Failures",
+	})
+
+	// Request data as a whole PyMongo query document, or in a $where
+	// clause, lets the client inject query operators
+	pr.AddPattern(&Pattern{
+		ID:          "NOSQL-INJECTION-001",
+		Name:        "NoSQL injection via MongoDB query",
+		Description: "Detects request data used as a PyMongo query document or interpolated into a $where clause",
+		Type:        PatternTypeNoSQLInjection,
+		Severity:    SeverityHigh,
+		Sources:     pr.requestSources(),
+		Sinks:       PyMongoQueryMethods,
+		CWE:         "CWE-943",
+		OWASP:       "A03:2021-Injection",
+	})
+
+	// mktemp and fixed /tmp paths can be pre-created or symlinked by
+	// another user, flagged regardless of taint
+	pr.AddPattern(&Pattern{
+		ID:                 "INSECURE-TEMPFILE-001",
+		Name:               "Insecure temporary file",
+		Description:        "Detects tempfile.mktemp and files written at predictable /tmp paths; use tempfile.mkstemp or NamedTemporaryFile instead",
+		Type:               PatternTypeInsecureTempFile,
+		Severity:           SeverityMedium,
+		Sources:            pr.requestSources(),
+		Sinks:              FileOpenFunctions,
+		DangerousFunctions: InsecureTempFileFunctions,
+		CWE:                "CWE-377",
+		OWASP:              "A01:2021-Broken Access Control",
+	})
+
+	// Lint: a mutable default is created once and shared across calls
+	pr.AddPattern(&Pattern{
+		ID:          "MUTABLE-DEFAULT-001",
+		Name:        "Mutable default argument",
+		Description: "Detects list, dict, and set parameter defaults, which are shared across calls; default to None and create the container in the body",
+		Type:        PatternTypeMutableDefault,
+		Severity:    SeverityLow,
+	})
+
+	// python -O strips asserts, so an assert cannot enforce access control
+	pr.AddPattern(&Pattern{
+		ID:          "ASSERT-AUTH-001",
+		Name:        "Assert used for access control",
+		Description: "Detects assert statements checking authentication or permissions, which are removed when Python runs with -O; raise PermissionDenied or return an error response instead",
+		Type:        PatternTypeAssertAuth,
+		Severity:    SeverityMedium,
+		CWE:         "CWE-285",
+		OWASP:       "A01:2021-Broken Access Control",
+	})
+
+	// Flagged regardless of taint: the missing flag is the weakness
+	pr.AddPattern(&Pattern{
+		ID:          "INSECURE-COOKIE-001",
+		Name:        "Cookie without Secure, HttpOnly, or SameSite",
+		Description: "Detects set_cookie calls missing secure=True, httponly=True, or samesite, and session cookie settings turned off; such cookies leak over HTTP, to scripts, or to cross-site requests",
+		Type:        PatternTypeInsecureCookie,
+		Severity:    SeverityMedium,
+		CWE:         "CWE-614",
+		OWASP:       "A05:2021-Security Misconfiguration",
+	})
+
+	// SQL statements built from request data with f-strings, concatenation,
+	// or % formatting instead of query parameters
+	pr.AddPattern(&Pattern{
+		ID:          "SQL-INJECTION-001",
+		Name:        "SQL injection via string-built query",
+		Description: "Detects request data interpolated into a SQL statement passed to execute",
+		Type:        PatternTypeSQLInjection,
 		Severity:    SeverityCritical,
-		Sources:     []string{"request.GET", "request.POST", "input", "raw_input", "request.query_params.get"},
-		Sinks:       []string{"eval", "exec"},
-		Sanitizers:  []string{"sanitize", "escape", "validate"},
-		CWE:         "CWE-94",
+		Sources:     slices.Concat(pr.requestSources(), pr.environmentSources()),
+		Sinks:       SQLExecuteMethods,
+		CWE:         "CWE-89",
 		OWASP:       "A03:2021-Injection",
+		Remediation: "use parameterized query: cursor.execute('... %s ...', (param,))",
+	})
+
+	// Request data as the format string of a logging call can forge log
+	// lines with newlines or break formatting with % directives
+	pr.AddPattern(&Pattern{
+		ID:          "LOG-FORMAT-001",
+		Name:        "Request data used as logging format string",
+		Description: "Detects request data passed as the format string of a logging call, allowing log injection; pass it as an argument instead",
+		Type:        PatternTypeLogFormat,
+		Severity:    SeverityMedium,
+		Sources:     pr.requestSources(),
+		Sinks:       LoggingMethods,
+		CWE:         "CWE-134",
+		OWASP:       "A09:2021-Security Logging and Monitoring Failures",
+		Remediation: "pass request data as a format argument: logger.info(\"%s\", value)",
+	})
+
+	// Request data in an LDAP search filter can add clauses such as
+	// *)(uid=* that widen or bypass the search
+	pr.AddPattern(&Pattern{
+		ID:          "LDAP-INJECTION-001",
+		Name:        "LDAP injection via search filter",
+		Description: "Detects request data built into the filter of a python-ldap or ldap3 search without escaping",
+		Type:        PatternTypeLDAPInjection,
+		Severity:    SeverityHigh,
+		Sources:     pr.requestSources(),
+		Sinks:       LDAPSearchMethods,
+		Sanitizers:  LDAPFilterEscapers,
+		CWE:         "CWE-90",
+		OWASP:       "A03:2021-Injection",
+		Remediation: "escape filter values: ldap.filter.escape_filter_chars(value)",
+	})
+
+	// Request data in an XPath expression can rewrite its predicates, e.g.
+	// ' or '1'='1 to select every node
+	pr.AddPattern(&Pattern{
+		ID:          "XPATH-INJECTION-001",
+		Name:        "XPath injection via lxml expression",
+		Description: "Detects request data built into an XPath expression evaluated or compiled by lxml",
+		Type:        PatternTypeXPathInjection,
+		Severity:    SeverityHigh,
+		Sources:     pr.requestSources(),
+		Sinks:       XPathFunctions,
+		CWE:         "CWE-643",
+		OWASP:       "A03:2021-Injection",
+		Remediation: "pass request data as an XPath variable: tree.xpath(\"//user[@name=$name]\", name=value)",
+	})
+
+	// Correctness: constant propagation proves a list index out of range
+	pr.AddPattern(&Pattern{
+		ID:          "INDEX-ERROR-001",
+		Name:        "Index out of range",
+		Description: "Detects subscripts of a list or tuple of known length with an index that is provably out of range, such as a[len(a)], which raise IndexError",
+		Type:        PatternTypeIndexError,
+		Severity:    SeverityLow,
+		CWE:         "CWE-129",
+	})
+
+	// CRLF in a header value splits the response or injects headers
+	pr.AddPattern(&Pattern{
+		ID:          "HEADER-INJECTION-001",
+		Name:        "HTTP header injection",
+		Description: "Detects request data set as a response header, e.g. response[\"Location\"] = request.GET[\"next\"], without stripping CR and LF",
+		Type:        PatternTypeHeaderInjection,
+		Severity:    SeverityMedium,
+		Sources:     pr.requestSources(),
+		Sinks:       allHeaderFunctions(),
+		Sanitizers:  HeaderSanitizers,
+		CWE:         "CWE-113",
+		OWASP:       "A03:2021-Injection",
+		Remediation: `strip "\r" and "\n" from the value, or reject values containing them`,
 	})
 }
 
 // MatchPattern checks if a call graph matches a pattern.
-// Returns detailed match information if a vulnerability is found.
+// Returns detailed match information if a vulnerability is found: the first
+// of FindMatches. Returns nil for an unknown pattern type.
 func (pr *PatternRegistry) MatchPattern(pattern *Pattern, callGraph *core.CallGraph) *PatternMatchDetails {
+	matches, known := pr.findMatches(pattern, callGraph)
+	if !known {
+		return nil
+	}
+	if len(matches) == 0 {
+		return &PatternMatchDetails{Matched: false}
+	}
+	return matches[0]
+}
+
+// FindMatches returns every match of a pattern in the call graph, e.g. one
+// per source-sink flow, in a deterministic order. A rule that already
+// matched therefore still reports a new flow elsewhere in the project.
+func (pr *PatternRegistry) FindMatches(pattern *Pattern, callGraph *core.CallGraph) []*PatternMatchDetails {
+	matches, _ := pr.findMatches(pattern, callGraph)
+	return matches
+}
+
+// findMatches returns every match of a pattern, with Explanation, Status,
+// Confidence, and Remediation set, and whether the pattern type is known.
+func (pr *PatternRegistry) findMatches(pattern *Pattern, callGraph *core.CallGraph) ([]*PatternMatchDetails, bool) {
+	if len(pr.Propagators) > 0 {
+		withPropagators := *pattern
+		withPropagators.Propagators = append(slices.Clip(pattern.Propagators), pr.Propagators...)
+		pattern = &withPropagators
+	}
+
+	var matches []*PatternMatchDetails
 	switch pattern.Type {
 	case PatternTypeDangerousFunction:
-		return pr.matchDangerousFunction(pattern, callGraph)
+		matches = pr.findDangerousFunctions(pattern, callGraph)
 	case PatternTypeSourceSink:
-		return pr.matchSourceSink(pattern, callGraph)
+		matches = pr.findSourceSinks(pattern, callGraph)
 	case PatternTypeMissingSanitizer:
-		return pr.matchMissingSanitizer(pattern, callGraph)
+		matches = pr.findMissingSanitizers(pattern, callGraph)
+	case PatternTypeOpenRedirect:
+		matches = pr.findOpenRedirects(pattern, callGraph)
+	case PatternTypeAutoescapeOff:
+		matches = pr.findAutoescapeOff(pattern, callGraph)
+	case PatternTypeWeakCrypto:
+		matches = pr.findWeakCrypto(pattern, callGraph)
+	case PatternTypeInsecureTLS:
+		matches = pr.findInsecureTLS(pattern, callGraph)
+	case PatternTypeTemplateSink:
+		matches = pr.findTemplateSinks(pattern, callGraph)
+	case PatternTypeMassAssignment:
+		matches = pr.findMassAssignments(pattern, callGraph)
+	case PatternTypeNoSQLInjection:
+		matches = pr.findNoSQLInjections(pattern, callGraph)
+	case PatternTypeInsecureTempFile:
+		matches = pr.findInsecureTempFiles(pattern, callGraph)
+	case PatternTypeMutableDefault:
+		matches = pr.findMutableDefaults(pattern, callGraph)
+	case PatternTypeSSTI:
+		matches = pr.findSSTI(pattern, callGraph)
+	case PatternTypeAssertAuth:
+		matches = pr.findAssertAuth(pattern, callGraph)
+	case PatternTypeInsecureCookie:
+		matches = pr.findInsecureCookies(pattern, callGraph)
+	case PatternTypeSQLInjection:
+		matches = pr.findSQLInjections(pattern, callGraph)
+	case PatternTypeLogFormat:
+		matches = pr.findLogFormats(pattern, callGraph)
+	case PatternTypeLDAPInjection:
+		matches = pr.findLDAPInjections(pattern, callGraph)
+	case PatternTypeXPathInjection:
+		matches = pr.findXPathInjections(pattern, callGraph)
+	case PatternTypeIndexError:
+		matches = pr.findIndexErrors(pattern, callGraph)
+	case PatternTypeHeaderInjection:
+		matches = pr.findHeaderInjections(pattern, callGraph)
 	default:
-		return nil
+		return nil, false
+	}
+
+	for _, match := range matches {
+		match.Explanation = explainMatch(match, callGraph)
+		match.Status = FlowStatus(match.DataFlowPath, callGraph)
+		match.Confidence = flowConfidence(match.DataFlowPath, callGraph)
+		if match.Remediation == "" {
+			match.Remediation = pattern.Remediation
+		}
 	}
+	return matches, true
 }
 
 // PatternMatchDetails contains detailed information about a pattern match.
@@ -131,101 +653,123 @@ type PatternMatchDetails struct {
 	SinkFQN           string   // Fully qualified name of function containing the sink call
 	SinkCall          string   // The actual dangerous call (e.g., "eval", "exec")
 	DataFlowPath      []string // Complete path from source to sink
+	Context           string   // Why the match matters (e.g., "weak hash MD5 used for password (hash_password)")
+
+	// Explanation walks a taint match from source to sink, one step per
+	// call (see FlowStep). Nil for matches without a data flow.
+	Explanation []FlowStep
+
+	// Status tells whether every call on DataFlowPath resolved with high
+	// confidence (see MatchStatus). Set by MatchPattern.
+	Status MatchStatus
+
+	// Confidence is how certain the call graph is of DataFlowPath, from 0.0
+	// to 1.0 (see flowConfidence). Set by MatchPattern.
+	Confidence float64
+
+	// Remediation suggests a fix, e.g. the parameterized form of a SQL
+	// statement built with an f-string. Defaults to Pattern.Remediation.
+	Remediation string
 }
 
-// matchDangerousFunction checks if any dangerous function is called.
-func (pr *PatternRegistry) matchDangerousFunction(pattern *Pattern, callGraph *core.CallGraph) *PatternMatchDetails {
-	for caller, callSites := range callGraph.CallSites {
-		for _, callSite := range callSites {
+// findDangerousFunctions returns every call to a dangerous function,
+// ordered by caller FQN and line.
+func (pr *PatternRegistry) findDangerousFunctions(pattern *Pattern, callGraph *core.CallGraph) []*PatternMatchDetails {
+	var matches []*PatternMatchDetails
+	for _, caller := range sortedCallers(callGraph) {
+		for _, callSite := range sortedCallSites(callGraph, caller) {
 			for _, dangerousFunc := range pattern.DangerousFunctions {
 				if matchesFunctionName(callSite.TargetFQN, dangerousFunc) ||
 					matchesFunctionName(callSite.Target, dangerousFunc) {
-					return &PatternMatchDetails{
+					matches = append(matches, &PatternMatchDetails{
 						Matched:      true,
 						SourceFQN:    caller,
 						SinkFQN:      callSite.TargetFQN,
 						DataFlowPath: []string{caller, callSite.TargetFQN},
-					}
+					})
+					break
 				}
 			}
 		}
 	}
-	return &PatternMatchDetails{Matched: false}
+	return matches
 }
 
-// matchSourceSink checks if there's a path from source to sink.
-func (pr *PatternRegistry) matchSourceSink(pattern *Pattern, callGraph *core.CallGraph) *PatternMatchDetails {
-	sourceCalls := pr.findCallsByFunctions(pattern.Sources, callGraph)
+// findSourceSinks returns a match for every call path from a source to a
+// sink (see flowPaths), ordered by source, then sink.
+func (pr *PatternRegistry) findSourceSinks(pattern *Pattern, callGraph *core.CallGraph) []*PatternMatchDetails {
+	sourceCalls := pr.findSources(pattern.Sources, callGraph)
 	if len(sourceCalls) == 0 {
-		return &PatternMatchDetails{Matched: false}
+		return nil
 	}
 
-	sinkCalls := pr.findCallsByFunctions(pattern.Sinks, callGraph)
+	sinkCalls := pr.findSinkCalls(pattern, callGraph)
 	if len(sinkCalls) == 0 {
-		return &PatternMatchDetails{Matched: false}
+		return nil
 	}
 
+	// Sort for deterministic results
+	sourceCalls = sortCallInfo(sourceCalls)
+	sinkCalls = sortCallInfo(sinkCalls)
+
+	paths := pr.newFlowPaths(callGraph, pattern.MaxPathLength)
+	var matches []*PatternMatchDetails
 	for _, source := range sourceCalls {
 		for _, sink := range sinkCalls {
-			path := pr.findPath(source.caller, sink.caller, callGraph)
-			if len(path) > 0 {
-				return &PatternMatchDetails{
+			for _, path := range paths.between(source.caller, sink.caller) {
+				matches = append(matches, &PatternMatchDetails{
 					Matched:      true,
 					SourceFQN:    source.caller,
+					SourceCall:   source.target,
 					SinkFQN:      sink.caller,
+					SinkCall:     sink.target,
 					DataFlowPath: path,
-				}
+				})
 			}
 		}
 	}
-
-	return &PatternMatchDetails{Matched: false}
+	return matches
 }
 
-// matchMissingSanitizer checks if there's a path from source to sink without sanitization.
-func (pr *PatternRegistry) matchMissingSanitizer(pattern *Pattern, callGraph *core.CallGraph) *PatternMatchDetails {
-	sourceCalls := pr.findCallsByFunctions(pattern.Sources, callGraph)
+// findMissingSanitizers returns a match for every call path from a source
+// to a sink that no sanitizer is on (see flowPaths), or a taint flow when
+// they are in the same function, ordered by source, then sink.
+func (pr *PatternRegistry) findMissingSanitizers(pattern *Pattern, callGraph *core.CallGraph) []*PatternMatchDetails {
+	sourceCalls := pr.findSources(pattern.Sources, callGraph)
 	if len(sourceCalls) == 0 {
-		return &PatternMatchDetails{Matched: false}
+		return nil
 	}
 
-	sinkCalls := pr.findCallsByFunctions(pattern.Sinks, callGraph)
+	sinkCalls := pr.findSinkCalls(pattern, callGraph)
 	if len(sinkCalls) == 0 {
-		return &PatternMatchDetails{Matched: false}
+		return nil
 	}
 
 	sanitizerCalls := pr.findCallsByFunctions(pattern.Sanitizers, callGraph)
 
 	// Sort for deterministic results
-	sortCallInfo(sourceCalls)
-	sortCallInfo(sinkCalls)
+	sourceCalls = sortCallInfo(sourceCalls)
+	sinkCalls = sortCallInfo(sinkCalls)
 
+	paths := pr.newFlowPaths(callGraph, pattern.MaxPathLength)
+	var matches []*PatternMatchDetails
 	for _, source := range sourceCalls {
 		for _, sink := range sinkCalls {
 			// Check intra-procedural taint flow using on-demand taint analysis
 			if source.caller == sink.caller {
-				intraMatch := pr.checkIntraProceduralTaint(source, sink, callGraph, pattern)
-				if intraMatch != nil {
-					return intraMatch // Vulnerability found!
+				if intraMatch := pr.checkIntraProceduralTaint(source, sink, callGraph, pattern); intraMatch != nil {
+					matches = append(matches, intraMatch) // Vulnerability found!
 				}
-				continue // No taint flow, skip
+				continue
 			}
 
-			path := pr.findPath(source.caller, sink.caller, callGraph)
-			if len(path) > 1 { // Require at least 2 functions in path
+			for _, path := range paths.between(source.caller, sink.caller) {
 				// Check if any sanitizer is on the path
-				hasSanitizer := false
-				for _, sanitizer := range sanitizerCalls {
-					// Check if sanitizer is in the path
-					if slices.Contains(path, sanitizer.caller) {
-						hasSanitizer = true
-					}
-					if hasSanitizer {
-						break
-					}
-				}
+				hasSanitizer := slices.ContainsFunc(sanitizerCalls, func(sanitizer callInfo) bool {
+					return slices.Contains(path, sanitizer.caller)
+				})
 				if !hasSanitizer {
-					return &PatternMatchDetails{
+					matches = append(matches, &PatternMatchDetails{
 						Matched:           true,
 						IsIntraProcedural: false, // Explicit flag for inter-procedural
 						SourceFQN:         source.caller,
@@ -233,13 +777,12 @@ func (pr *PatternRegistry) matchMissingSanitizer(pattern *Pattern, callGraph *co
 						SinkFQN:           sink.caller,
 						SinkCall:          sink.target,
 						DataFlowPath:      path,
-					}
+					})
 				}
 			}
 		}
 	}
-
-	return &PatternMatchDetails{Matched: false}
+	return matches
 }
 
 // callInfo stores information about a function call location.
@@ -264,6 +807,27 @@ func (pr *PatternRegistry) findCallsByFunctions(functionNames []string, callGrap
 	return calls
 }
 
+// findSources finds all source occurrences: calls to source functions plus
+// attribute accesses on dotted sources (e.g., x = request.FILES["f"]), which
+// do not produce call sites.
+func (pr *PatternRegistry) findSources(sources []string, callGraph *core.CallGraph) []callInfo {
+	calls := pr.findCallsByFunctions(sources, callGraph)
+	for caller, statements := range callGraph.Statements {
+		for _, stmt := range statements {
+			if stmt.AttributeAccess == "" {
+				continue
+			}
+			for _, source := range sources {
+				if strings.Contains(source, ".") && matchesFunctionName(stmt.AttributeAccess, source) {
+					calls = append(calls, callInfo{caller: caller, target: stmt.AttributeAccess})
+					break
+				}
+			}
+		}
+	}
+	return calls
+}
+
 // hasPath checks if there's a path from caller to callee in the call graph.
 func (pr *PatternRegistry) hasPath(from, to string, callGraph *core.CallGraph) bool {
 	if from == to {
@@ -298,10 +862,14 @@ func (pr *PatternRegistry) dfsPath(current, target string, callGraph *core.CallG
 
 // findPath finds the complete path from source to sink in the call graph.
 // Returns the path as a slice of function FQNs, or empty slice if no path exists.
-func (pr *PatternRegistry) findPath(from, to string, callGraph *core.CallGraph) []string {
+// A positive maxLength limits the search to paths of at most that many calls.
+func (pr *PatternRegistry) findPath(from, to string, callGraph *core.CallGraph, maxLength int) []string {
 	if from == to {
 		return []string{from}
 	}
+	if maxLength > 0 {
+		return pr.bfsPathWithin(from, to, callGraph, maxLength)
+	}
 
 	visited := make(map[string]bool)
 	path := make([]string, 0)
@@ -340,16 +908,150 @@ func (pr *PatternRegistry) dfsPathWithTrace(current, target string, callGraph *c
 	return false
 }
 
-// sortCallInfo sorts callInfo slices by caller FQN for deterministic results.
-func sortCallInfo(calls []callInfo) {
-	// Simple bubble sort - good enough for small slices
-	for i := range calls {
-		for j := i + 1; j < len(calls); j++ {
-			if calls[i].caller > calls[j].caller {
-				calls[i], calls[j] = calls[j], calls[i]
+// bfsPathWithin performs breadth-first search for the shortest path of at
+// most maxLength calls, not expanding functions beyond that depth.
+func (pr *PatternRegistry) bfsPathWithin(from, to string, callGraph *core.CallGraph, maxLength int) []string {
+	parent := map[string]string{from: ""}
+	frontier := []string{from}
+	for depth := 0; depth < maxLength && len(frontier) > 0; depth++ {
+		var next []string
+		for _, current := range frontier {
+			for _, callee := range callGraph.GetCallees(current) {
+				if _, seen := parent[callee]; seen {
+					continue
+				}
+				parent[callee] = current
+				if callee == to {
+					path := []string{to}
+					for fn := current; fn != ""; fn = parent[fn] {
+						path = append(path, fn)
+					}
+					slices.Reverse(path)
+					return path
+				}
+				next = append(next, callee)
+			}
+		}
+		frontier = next
+	}
+	return []string{}
+}
+
+// maxFlowPaths caps the paths reported from one source function to one sink
+// function, so densely connected call graphs stay cheap to match.
+const maxFlowPaths = 8
+
+// flowPaths finds every call path from source functions to sink functions.
+// Each sink's call distances are computed once, so functions that cannot
+// reach a sink are skipped without a search.
+type flowPaths struct {
+	registry  *PatternRegistry
+	callGraph *core.CallGraph
+	maxLength int
+	callers   map[string][]string       // callee → callers, from callGraph.Edges
+	distances map[string]map[string]int // sink → function → fewest calls to reach it
+}
+
+func (pr *PatternRegistry) newFlowPaths(callGraph *core.CallGraph, maxLength int) *flowPaths {
+	return &flowPaths{registry: pr, callGraph: callGraph, maxLength: maxLength}
+}
+
+// between returns up to maxFlowPaths call paths from one function to
+// another, shortest first, always including the one findPath returns. Each
+// path visits a function at most once and, when maxLength is set, makes at
+// most maxLength calls. Nil when there is no path.
+func (f *flowPaths) between(from, to string) [][]string {
+	if from == to {
+		return [][]string{{from}}
+	}
+	distance := f.distancesTo(to)
+	if _, ok := distance[from]; !ok {
+		return nil
+	}
+	first := f.registry.findPath(from, to, f.callGraph, f.maxLength)
+	if len(first) == 0 {
+		return nil
+	}
+
+	seen := map[string]bool{strings.Join(first, "\x00"): true}
+	var others [][]string
+	path := []string{from}
+	onPath := map[string]bool{from: true}
+	var walk func(current string)
+	walk = func(current string) {
+		for _, callee := range f.callGraph.GetCallees(current) {
+			if len(others) == maxFlowPaths-1 {
+				return
+			}
+			calls, ok := distance[callee]
+			if !ok || onPath[callee] || (f.maxLength > 0 && len(path)+calls > f.maxLength) {
+				continue
+			}
+			path = append(path, callee)
+			if callee == to {
+				if key := strings.Join(path, "\x00"); !seen[key] {
+					seen[key] = true
+					others = append(others, slices.Clone(path))
+				}
+			} else {
+				onPath[callee] = true
+				walk(callee)
+				delete(onPath, callee)
+			}
+			path = path[:len(path)-1]
+		}
+	}
+	walk(from)
+
+	paths := append([][]string{first}, others...)
+	slices.SortFunc(paths, func(a, b []string) int {
+		return cmp.Or(cmp.Compare(len(a), len(b)), slices.Compare(a, b))
+	})
+	return paths
+}
+
+// distancesTo returns the fewest calls from each function that reaches to,
+// through a breadth-first search of its callers.
+func (f *flowPaths) distancesTo(to string) map[string]int {
+	if distance, ok := f.distances[to]; ok {
+		return distance
+	}
+	if f.callers == nil {
+		f.callers = make(map[string][]string)
+		for caller, callees := range f.callGraph.Edges {
+			for _, callee := range callees {
+				f.callers[callee] = append(f.callers[callee], caller)
 			}
 		}
+		f.distances = make(map[string]map[string]int)
 	}
+
+	distance := map[string]int{to: 0}
+	frontier := []string{to}
+	for len(frontier) > 0 {
+		var next []string
+		for _, current := range frontier {
+			for _, caller := range f.callers[current] {
+				if _, seen := distance[caller]; !seen {
+					distance[caller] = distance[current] + 1
+					next = append(next, caller)
+				}
+			}
+		}
+		frontier = next
+	}
+	f.distances[to] = distance
+	return distance
+}
+
+// sortCallInfo sorts calls by caller FQN, then target, and drops
+// duplicates, for deterministic results: the calls are collected in map
+// iteration order, and a function may call a source twice.
+func sortCallInfo(calls []callInfo) []callInfo {
+	slices.SortFunc(calls, func(a, b callInfo) int {
+		return cmp.Or(strings.Compare(a.caller, b.caller), strings.Compare(a.target, b.target))
+	})
+	return slices.Compact(calls)
 }
 
 // matchesFunctionName checks if a function name matches a pattern.
@@ -395,6 +1097,39 @@ func matchesFunctionName(fqn, pattern string) bool {
 	return false
 }
 
+// functionStatements returns the statements of a function. A function with
+// a taint summary had its statements stored when the summary was built, so
+// they are reused; others are extracted from the function's source.
+func functionStatements(functionFQN string, funcNode *graph.Node, callGraph *core.CallGraph) ([]*core.Statement, error) {
+	if _, ok := callGraph.Summaries[functionFQN]; ok {
+		if statements, ok := callGraph.Statements[functionFQN]; ok {
+			return statements, nil
+		}
+	}
+
+	sourceCode, err := callGraph.ReadSource(funcNode.File)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", funcNode.File, err)
+	}
+
+	tree, err := extraction.ParsePythonFile(sourceCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse file %s: %w", funcNode.File, err)
+	}
+	defer tree.Close()
+
+	functionNode := findFunctionAtLine(tree.RootNode(), funcNode.LineNumber)
+	if functionNode == nil {
+		return nil, fmt.Errorf("could not find function at line %d in %s", funcNode.LineNumber, funcNode.File)
+	}
+
+	statements, err := extraction.ExtractStatements(funcNode.File, sourceCode, functionNode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract statements from %s: %w", functionFQN, err)
+	}
+	return statements, nil
+}
+
 // checkIntraProceduralTaint checks if source and sink in same function have taint flow.
 // Uses on-demand taint analysis with pattern-specific sources/sinks to verify actual data flow.
 // Returns non-nil PatternMatchDetails if vulnerable, nil otherwise.
@@ -413,32 +1148,9 @@ func (pr *PatternRegistry) checkIntraProceduralTaint(
 		return nil
 	}
 
-	// Read the source file
-	sourceCode, err := readFileBytes(funcNode.File)
-	if err != nil {
-		log.Printf("Failed to read file %s: %v", funcNode.File, err)
-		return nil
-	}
-
-	// Parse the file to get AST
-	tree, err := extraction.ParsePythonFile(sourceCode)
-	if err != nil {
-		log.Printf("Failed to parse file %s: %v", funcNode.File, err)
-		return nil
-	}
-	defer tree.Close()
-
-	// Find the function node at the line number
-	functionNode := findFunctionAtLine(tree.RootNode(), funcNode.LineNumber)
-	if functionNode == nil {
-		log.Printf("Could not find function at line %d in %s", funcNode.LineNumber, funcNode.File)
-		return nil
-	}
-
-	// Extract statements from the function
-	statements, err := extraction.ExtractStatements(funcNode.File, sourceCode, functionNode)
+	statements, err := functionStatements(functionFQN, funcNode, callGraph)
 	if err != nil {
-		log.Printf("Failed to extract statements from %s: %v", functionFQN, err)
+		log.Printf("Skipping taint check of %s: %v", functionFQN, err)
 		return nil
 	}
 
@@ -446,23 +1158,25 @@ func (pr *PatternRegistry) checkIntraProceduralTaint(
 	defUseChain := core.BuildDefUseChains(statements)
 
 	// Run taint analysis with pattern-specific sources/sinks
-	summary := taint.AnalyzeIntraProceduralTaint(
+	summary := taint.AnalyzeIntraProceduralTaintWithPropagators(
 		functionFQN,
 		statements,
 		defUseChain,
-		pattern.Sources,    // Use pattern's sources
-		pattern.Sinks,      // Use pattern's sinks
-		pattern.Sanitizers, // Use pattern's sanitizers
+		pattern.Sources,     // Use pattern's sources
+		pattern.Sinks,       // Use pattern's sinks
+		pattern.Sanitizers,  // Use pattern's sanitizers
+		pattern.Propagators, // Use pattern's propagators
 	)
 
-	// Check if taint analysis found vulnerabilities
-	if !summary.HasDetections() {
+	// Check if taint analysis found vulnerabilities at the sink arguments
+	detections := DetectionsAtSinkArguments(pattern, functionFQN, summary.Detections, callGraph)
+	if len(detections) == 0 {
 		return nil // No taint flow detected
 	}
 
 	// ✅ Vulnerability confirmed via taint analysis!
 	log.Printf("Intra-procedural vulnerability detected in %s: %d detection(s)",
-		functionFQN, summary.GetDetectionCount())
+		functionFQN, len(detections))
 
 	// Build match details
 	return &PatternMatchDetails{
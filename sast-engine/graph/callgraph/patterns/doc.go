@@ -23,6 +23,412 @@
 //	        match.SourceFQN, match.SinkFQN)
 //	}
 //
+// MatchPattern returns the first match. FindMatches returns every match,
+// e.g. one per source-sink flow, so a second flow of an already matched
+// rule is reported too:
+//
+//	for _, match := range registry.FindMatches(pattern, callGraph) {
+//	    fmt.Printf("%s -> %s\n", match.SourceFQN, match.SinkFQN)
+//	}
+//
+// Source-sink patterns report one match per call path, up to eight per
+// source and sink. callgraph.DedupeMatches, which Analyze applies, merges
+// matches of one source and sink location into the best one, with the
+// other paths in its AlternatePaths.
+//
+// # Framework Rulesets
+//
+// The request sources of the default patterns are those of the registry's
+// Framework: Django, Flask, FastAPI, or aiohttp (FrameworkRulesets). The
+// analysis pipeline picks the framework the project imports with
+// DetectRulesetFramework; without one, the Django and Flask sources are
+// used. GlobalSources are added whatever the framework:
+//
+//	registry.Framework = DetectRulesetFramework(callGraph)
+//	registry.GlobalSources = []string{"myapp.tenant.get_input"}
+//	registry.LoadDefaultPatterns()
+//
+// FrameworkRuleset returns the default patterns of one framework.
+//
+// # Open Redirect
+//
+// PatternTypeOpenRedirect flags request data reaching the URL argument of a
+// framework redirect. The redirect set is chosen per call from the detected
+// framework (RedirectFunctions), and a URL passed to a validator such as
+// url_has_allowed_host_and_scheme beforehand is treated as sanitized:
+//
+//	registry.LoadDefaultPatterns()
+//	pattern, _ := registry.GetPattern("OPEN-REDIRECT-001")
+//	match := registry.MatchPattern(pattern, callGraph)
+//
+// # Jinja Autoescaping
+//
+// PatternTypeAutoescapeOff tracks Jinja Environment and Template objects
+// constructed with autoescape=False, including module-level environments
+// linked to functions through type inference. JINJA-AUTOESCAPE-001 flags the
+// construction; XSS-JINJA-001 flags request data passed to render() on a
+// template loaded from such an environment:
+//
+//	pattern, _ := registry.GetPattern("XSS-JINJA-001")
+//	match := registry.MatchPattern(pattern, callGraph)
+//
+// # Weak Cryptography
+//
+// PatternTypeWeakCrypto flags weak primitives without requiring taint: MD5
+// and SHA-1 (WEAK-CRYPTO-001), broken ciphers and ECB mode, and the random
+// module when the assigned variable, an argument, or the enclosing function
+// names a secret such as a token or password (WEAK-RANDOM-001). Context
+// records the detected purpose. Non-security uses are suppressed with
+// usedforsecurity=False, a "# nosec" comment, or WeakCryptoAllowlist:
+//
+//	registry.WeakCryptoAllowlist = []string{"myapp.cache"}
+//	registry.LoadDefaultPatterns()
+//
+// # Insecure TLS
+//
+// PatternTypeInsecureTLS flags requests and httpx calls whose verify
+// argument is False, including a local or module-level variable assigned
+// False, and calls to ssl._create_unverified_context (INSECURE-TLS-001).
+//
+// # Template Render Sinks
+//
+// PatternTypeTemplateSink flags request data passed to a template render
+// sink (XSS-TEMPLATE-001). Sinks are selected per call from the framework
+// its target resolves into (RenderSinks), e.g. mako.template.Template.render
+// for Mako. Rule configs add sinks per framework:
+//
+//	err := registry.LoadRenderSinkConfig([]byte(`
+//	render_sinks:
+//	  Mako:
+//	    - mako.template.Template.render_unicode
+//	`))
+//
+// # Server-Side Template Injection
+//
+// PatternTypeSSTI flags request data used as the source of a template
+// (SSTI-001): the first argument of jinja2.Template, Environment.from_string,
+// render_template_string, and the other functions in
+// TemplateSourceArguments. The engine evaluates template source, so this is
+// code execution rather than XSS. Request data passed as context is safe:
+//
+//	render_template_string(request.args["t"])                   # flagged
+//	render_template_string("{{ name }}", name=request.args["n"])  # not flagged
+//
+// # Mass Assignment
+//
+// PatternTypeMassAssignment flags request data spread with ** into an ORM
+// write (MASS-ASSIGNMENT-001): Model.objects.create/update and friends, or a
+// model constructor whose instance is later saved. Spreading
+// form.cleaned_data or serializer.validated_data, or a dict comprehension
+// limited to an allowlist of fields, is treated as sanitized:
+//
+//	data = {f: request.POST[f] for f in ALLOWED_FIELDS}
+//	Profile.objects.create(**data)  # not flagged
+//
+// # NoSQL Injection
+//
+// PatternTypeNoSQLInjection flags request data used as a MongoDB query
+// (NOSQL-INJECTION-001): a tainted query document passed to a PyMongo
+// query method, or a $where clause built from tainted strings. The
+// receiver must be a PyMongo handle, confirmed through the type engine or
+// the assignments leading back to a MongoClient:
+//
+//	users = MongoClient().shop.users
+//	users.find(request.json)  # flagged: {"$ne": null} matches every user
+//
+// # LDAP and XPath Injection
+//
+// PatternTypeLDAPInjection flags request data built into the filter of a
+// python-ldap or ldap3 search (LDAP-INJECTION-001), on a connection traced
+// back to ldap.initialize or ldap3.Connection. Values escaped with
+// LDAPFilterEscapers, inline or beforehand, are safe.
+// PatternTypeXPathInjection flags request data built into an expression
+// given to an lxml xpath method or XPath class (XPATH-INJECTION-001), but
+// not values passed as XPath variables:
+//
+//	conn.search_s(base, ldap.SCOPE_SUBTREE, f"(uid={name})")                       # flagged
+//	conn.search_s(base, ldap.SCOPE_SUBTREE, f"(uid={escape_filter_chars(name)})")  # not flagged
+//	tree.xpath("//user[@name='" + name + "']")                                     # flagged
+//	tree.xpath("//user[@name=$name]", name=name)                                   # not flagged
+//
+// # Insecure Temporary Files
+//
+// PatternTypeInsecureTempFile flags tempfile.mktemp and files opened for
+// writing at fixed paths in /tmp, /var/tmp, or /dev/shm
+// (INSECURE-TEMPFILE-001). Another user can create or symlink such a path
+// first. Exclusive creation (mode "x", os.O_EXCL) is not flagged, and a
+// path built from request data records its source:
+//
+//	open("/tmp/" + name, "w")  # flagged
+//	fd, path = tempfile.mkstemp()  # not flagged
+//
+// # Mutable Default Arguments
+//
+// PatternTypeMutableDefault is a lint flagging parameters whose default is
+// a list, dict, or set (MUTABLE-DEFAULT-001). The default is built once,
+// when the function is defined, so every call shares and mutates it:
+//
+//	def add(item, items=[]):    # flagged
+//	def add(item, items=None):  # not flagged
+//
+// # Assert-Based Access Control
+//
+// PatternTypeAssertAuth flags assert statements whose condition names an
+// identifier in AssertAuthVocabulary, such as is_authenticated or has_perm
+// (ASSERT-AUTH-001). Python run with -O drops asserts, and the check with
+// them:
+//
+//	assert request.user.is_authenticated  # flagged
+//	assert len(items) > 0                 # not flagged
+//
+// # Index Errors
+//
+// PatternTypeIndexError is a correctness check flagging subscripts provably
+// out of range (INDEX-ERROR-001). A constant-folding pass over a function's
+// statements tracks the lengths of list and tuple literals and integer
+// values, through len() and arithmetic. Only straight-line code the
+// statements fully show is trusted, so a list passed to a call, modified,
+// or defined before a branch or loop is never flagged:
+//
+//	a = [1, 2]
+//	a[2]         # flagged
+//	a[len(a)-1]  # not flagged
+//	a[i]         # not flagged
+//
+// # Insecure Cookies
+//
+// PatternTypeInsecureCookie flags set_cookie calls on web framework responses
+// that leave secure, httponly, or samesite off, by omission or with a falsy
+// literal, and app.config.update or settings.configure calls turning off a
+// SESSION_COOKIE_* or CSRF_COOKIE_* flag (INSECURE-COOKIE-001):
+//
+//	response.set_cookie("sid", sid)                                           # flagged
+//	response.set_cookie("sid", sid, secure=True, httponly=True, samesite="Lax")  # not flagged
+//
+// # Header Injection
+//
+// PatternTypeHeaderInjection flags request data set as a response header
+// (HEADER-INJECTION-001), where CR or LF split the response. Headers are set
+// by item assignment to a Django response or to any framework's
+// response.headers, or through the header API of the framework the file
+// imports (HeaderFunctions): Flask headers.add, Tornado set_header, and so
+// on. Values with their newlines stripped by replace or re.sub, or passed
+// to a HeaderSanitizers function, are safe:
+//
+//	response["Location"] = request.GET["next"]                        # flagged
+//	response["Location"] = next_url.replace("\r", "").replace("\n", "")  # not flagged
+//
+// # SQL Injection
+//
+// PatternTypeSQLInjection flags request data built into the statement passed
+// to a SQLExecuteMethods call, with an f-string, concatenation, or %
+// formatting (SQL-INJECTION-001), including Django's Manager.raw. Data
+// passed as query parameters is safe. The statement may be passed by
+// keyword (execute(sql=...), raw(raw_query=...)). An f-string implicitly concatenated with other literals ("SELECT * "
+// f"WHERE id = {id}") counts as one statement. For an f-string, the match's
+// Remediation spells out the parameterized call; other matches get the
+// pattern's generic hint:
+//
+//	cursor.execute(f"SELECT * FROM users WHERE name = '{name}'")
+//	// use parameterized query: cursor.execute("SELECT * FROM users WHERE name = %s", (name,))
+//
+// # Logging Format Strings
+//
+// PatternTypeLogFormat flags request data used as the format string of a
+// logging call (LOG-FORMAT-001), on the logging module or a logger-named
+// receiver. It can forge log lines with newlines or break formatting with %
+// directives. Data passed as a format argument, or with its newlines
+// stripped by replace or re.sub, is safe:
+//
+//	logger.info(username)        # flagged
+//	logger.info("%s", username)  # not flagged
+//
+// # Code Injection
+//
+// CODE-INJECTION-001 flags user input reaching the code argument of eval,
+// exec, or compile, and CODE-INJECTION-002 the module name passed to
+// __import__ or importlib.import_module. Pattern.SinkArguments limits a
+// sink to the argument that is executed or imported, so tainted globals or
+// locals dicts and same-named calls such as re.compile are not flagged:
+//
+//	exec(request.form["code"])              # flagged
+//	exec("result = 1", dict(request.args))  # not flagged
+//
+// # Command Injection
+//
+// COMMAND-INJECTION-001 flags user input in a command run by the shell:
+// os.system, os.popen, or a subprocess call with shell=True. Without a
+// shell, subprocess argv lists are checked element-wise (ArgumentPosition's
+// Argv): a tainted program, the first element or a lone program name, is
+// COMMAND-INJECTION-002 (high), and a tainted later argument, which the
+// program may read as an option, COMMAND-INJECTION-003 (medium). An argv
+// list held in a variable is checked through the literal assigned to it:
+//
+//	subprocess.run("ping " + host, shell=True)    # COMMAND-INJECTION-001
+//	subprocess.run([tool, "--version"])           # COMMAND-INJECTION-002
+//	subprocess.run(["convert", name, "out.png"])  # COMMAND-INJECTION-003
+//
+// # Environment Variables
+//
+// Environment variables are trusted by default. Where another party can set
+// them, as on shared CI runners, TaintEnvironment adds EnvironmentSources to
+// the injection patterns, so COMMAND-INJECTION-001 flags a command built from
+// one:
+//
+//	registry.TaintEnvironment = true
+//	registry.LoadDefaultPatterns()
+//	// branch = os.environ["BRANCH"]; os.system("git checkout " + branch)  # flagged
+//
+// # Taint Propagators
+//
+// By default a call's result is tainted when any argument is. Propagators
+// (Pattern.Propagators, or LoadPropagatorConfig for every pattern) name
+// the calls that pass taint on instead; all other calls return untainted
+// values:
+//
+//	err := registry.LoadPropagatorConfig([]byte(`
+//	propagators:
+//	  - fqn: copy.deepcopy
+//	    fromArg: 0
+//	    toReturn: true
+//	`))
+//
+// # Path Length Limits
+//
+// Pattern.MaxPathLength bounds the calls an inter-procedural flow may
+// take from the source function to the sink function. The search stops
+// at the limit, so rules that only make sense for short flows stay fast
+// and precise on large graphs; 0 means unlimited:
+//
+//	// get_input -> parse -> execute: 2 calls, matched
+//	// get_input -> parse -> validate -> store -> execute: 4 calls, not matched
+//	pattern.MaxPathLength = 2
+//
+// # Explanations
+//
+// A taint match's Explanation lists the steps from source to sink, one per
+// call on its DataFlowPath, with the location of each:
+//
+//	for _, step := range match.Explanation {
+//	    fmt.Printf("%s (%s)\n", step.Description, step.FQN)
+//	}
+//	// user input read at request.GET (app.search)
+//	// passed to helper at line 5 (app.helper)
+//	// reaches execute at line 9 (app.helper)
+//
+// # Match Status
+//
+// MatchPattern marks a match MatchStatusConfirmed when every call on its data
+// flow path resolved directly or through type inference with confidence of
+// at least ConfirmedEdgeConfidence, and MatchStatusPotential otherwise.
+// It also sets Confidence, the weakest resolution on the path: 1.0 for a
+// direct call, the type confidence for an inferred one, and
+// UnresolvedEdgeConfidence for an edge no resolved call backs.
+// FlowStatus derives the status of any data flow path. Report only
+// confirmed flows to cut noise with callgraph.AnalyzeOptions.Status, the
+// scan command's --status flag, or explain_finding's status argument:
+//
+//	result, err := callgraph.Analyze(projectPath, callgraph.AnalyzeOptions{
+//	    Status: patterns.MatchStatusConfirmed,
+//	})
+//
+// # Baselines
+//
+// Findings are identified across scans by output.Fingerprint, which hashes
+// the rule, enclosing function, and sink line rather than line numbers.
+// Analyze sets callgraph.SecurityMatch.Fingerprint, and callgraph.DiffFindings
+// reports the findings introduced since a baseline scan.
+//
+// To adopt scanning on an existing codebase, callgraph.SaveBaseline
+// snapshots the current findings to a file. Analyze with
+// AnalyzeOptions.Baseline keeps reporting them, with Suppression set to
+// SuppressedByBaseline, so only new findings count:
+//
+//	err := callgraph.SaveBaseline(result.Matches, "baseline.json")
+//	result, err = callgraph.Analyze(projectPath, callgraph.AnalyzeOptions{Baseline: "baseline.json"})
+//
+// The scan command does the same with --write-baseline and --baseline.
+//
+// # CI Gating
+//
+// Analyze's result makes the same CI gate decision as the scan command's
+// --fail-on flag (see output.GateExitCode): AnalysisResult.ExitCode fails
+// when any unsuppressed match has one of the given severities, so baselined
+// and allowlisted findings never fail. ExitSummary explains the decision:
+//
+//	failOn := output.SeveritiesAtOrAbove("high")
+//	fmt.Println(result.ExitSummary(failOn))
+//	// failing: 2 findings match critical,high (1 critical, 1 high); 4 suppressed
+//	os.Exit(int(result.ExitCode(failOn)))
+//
+// # Risk Scores
+//
+// Every SecurityMatch Analyze returns has a Score (see output.RiskScore)
+// that weights its severity, path confidence, and whether an HTTP
+// entrypoint (a function with routes) reaches its source, so flows from
+// user-facing routes rank above those in internal scripts (see
+// ReachableFromEntrypoint). SortMatchesByScore ranks them, highest first:
+//
+//	callgraph.SortMatchesByScore(result.Matches)
+//
+// The scan command scores its findings the same way, emits the score in
+// JSON and SARIF output, and ranks them with --sort=score.
+//
+// # Summaries
+//
+// callgraph.Summarize counts findings by file, module, severity, and rule,
+// with totals and the files with the most findings, for dashboards and CI
+// summaries. Analyze sets AnalysisResult.Summary, which marshals to JSON:
+//
+//	data, _ := json.Marshal(result.Summary)
+//
+// # Rule Catalog
+//
+// Catalog lists the metadata of every registered pattern without running
+// analysis, so tools can offer rule selection or check that expected rules
+// are present. Patterns in DisabledPatterns are listed with Enabled false
+// and skipped by analysis:
+//
+//	registry.DisabledPatterns = []string{"MUTABLE-DEFAULT-001"}
+//	for _, rule := range registry.Catalog() {
+//	    fmt.Println(rule.ID, rule.CWE, rule.Enabled)
+//	}
+//
+// # Sink Allowlist
+//
+// SinkAllowlist exempts a sink in one module, or package, without
+// disabling the rule, e.g. raw SQL run on purpose by migrations. Analysis
+// still reports exempted flows and matches, with Suppression set to
+// SuppressedByAllowlist:
+//
+//	registry.SinkAllowlist = []patterns.SinkExemption{
+//	    {Sink: "sqlite3.Cursor.execute", Module: "myapp.migrations"},
+//	}
+//
+// LoadSinkAllowlistConfig reads the same entries from a YAML rule config.
+//
+// # Rule Validation
+//
+// ValidateRuleset checks rules against fixtures with known verdicts, each
+// expected to fire or to stay clean, and reports the false positives and
+// false negatives of every rule, so rule authors catch regressions without
+// a full scan:
+//
+//	report := patterns.ValidateRuleset(rules, []patterns.Fixture{
+//	    {Name: "eval of request data", CallGraph: vulnerable, RuleID: "CODE-INJECTION-001", Expect: patterns.ExpectFire},
+//	    {Name: "safe views", CallGraph: safe, Expect: patterns.ExpectClean},
+//	})
+//	if !report.OK() { ... }
+//
+// # Re-evaluation
+//
+// ReevaluateMatches checks edited rules against a call graph that is
+// already built, reusing the statements stored with its taint summaries, so
+// rule authoring does not wait on a rebuild:
+//
+//	pattern.Sinks = append(pattern.Sinks, "compile")
+//	matches := patterns.ReevaluateMatches(callGraph, callGraph.Summaries, registry)
+//
 // # Framework Detection
 //
 //	framework := patterns.DetectFramework(importMap)
@@ -30,4 +436,14 @@
 //	    fmt.Printf("Using %s (%s)\n",
 //	        framework.Name, framework.Category)
 //	}
+//
+// DetectProjectFramework weighs the whole call graph instead, and lists the
+// framework's entry points: functions decorated by a Flask application or
+// blueprint variable (RouteRegistrarTypes), whatever it is named and
+// wherever it is defined:
+//
+//	framework := patterns.DetectProjectFramework(callGraph)
+//	for _, entryPoint := range framework.EntryPoints {
+//	    fmt.Println(entryPoint.FQN, entryPoint.Registrar) // portal.views.delete_user portal.admin.admin_bp
+//	}
 package patterns
@@ -0,0 +1,96 @@
+package patterns
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// FlowStep is one step of a match's data flow, in terms a reviewer can
+// follow: where the input is read, each call it is passed through, and the
+// sink it reaches.
+type FlowStep struct {
+	Description string        // e.g., "passed to helper at line 5"
+	FQN         string        // Function the step happens in, or is passed to
+	Location    core.Location // Where the step happens; zero if unknown
+}
+
+// explainMatch builds the flow steps of a taint match from its source call,
+// the call chain in DataFlowPath, and its sink call. Matches without both a
+// source and a sink call (e.g., dangerous function use) have no flow and
+// return nil.
+func explainMatch(match *PatternMatchDetails, callGraph *core.CallGraph) []FlowStep {
+	if match.SourceCall == "" || match.SinkCall == "" {
+		return nil
+	}
+
+	steps := []FlowStep{{
+		Description: "user input read at " + match.SourceCall,
+		FQN:         match.SourceFQN,
+		Location:    sourceLocation(match.SourceFQN, match.SourceCall, callGraph),
+	}}
+
+	for i := 0; i+1 < len(match.DataFlowPath); i++ {
+		caller, callee := match.DataFlowPath[i], match.DataFlowPath[i+1]
+		location := callLocation(caller, callGraph, func(site core.CallSite) bool {
+			return site.TargetFQN == callee
+		})
+		steps = append(steps, FlowStep{
+			Description: describeStep("passed to", callee, location),
+			FQN:         callee,
+			Location:    location,
+		})
+	}
+
+	location := callLocation(match.SinkFQN, callGraph, func(site core.CallSite) bool {
+		return site.Target == match.SinkCall || site.TargetFQN == match.SinkCall
+	})
+	return append(steps, FlowStep{
+		Description: describeStep("reaches", match.SinkCall, location),
+		FQN:         match.SinkFQN,
+		Location:    location,
+	})
+}
+
+// describeStep phrases a step as "<verb> <name> at line N", using the last
+// segment of fqn as the name.
+func describeStep(verb, fqn string, location core.Location) string {
+	name := fqn[strings.LastIndex(fqn, ".")+1:]
+	if location.Line == 0 {
+		return verb + " " + name
+	}
+	return fmt.Sprintf("%s %s at line %d", verb, name, location.Line)
+}
+
+// callLocation returns the location of the first call site in caller, in
+// source order, that matches, translated to the original source.
+func callLocation(caller string, callGraph *core.CallGraph, matches func(core.CallSite) bool) core.Location {
+	for _, site := range sortedCallSites(callGraph, caller) {
+		if matches(site) {
+			return callGraph.OriginalLocation(site.Location)
+		}
+	}
+	return core.Location{}
+}
+
+// sourceLocation locates a source: a call to it, or for attribute sources
+// such as request.GET, the statement that reads it.
+func sourceLocation(caller, source string, callGraph *core.CallGraph) core.Location {
+	location := callLocation(caller, callGraph, func(site core.CallSite) bool {
+		return site.Target == source || site.TargetFQN == source
+	})
+	if location.Line != 0 {
+		return location
+	}
+	function, ok := callGraph.Functions[caller]
+	if !ok {
+		return location
+	}
+	for _, stmt := range callGraph.Statements[caller] {
+		if stmt.AttributeAccess == source {
+			return callGraph.OriginalLocation(core.Location{File: function.File, Line: int(stmt.LineNumber)})
+		}
+	}
+	return location
+}
@@ -0,0 +1,40 @@
+package patterns
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchPattern_Explanation(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "explain")
+
+	patternRegistry := NewPatternRegistry()
+	patternRegistry.LoadDefaultPatterns()
+	pattern, ok := patternRegistry.GetPattern("CODE-INJECTION-001")
+	require.True(t, ok)
+
+	match := patternRegistry.MatchPattern(pattern, callGraph)
+	require.True(t, match.Matched)
+
+	var descriptions, fqns []string
+	for _, step := range match.Explanation {
+		descriptions = append(descriptions, step.Description)
+		fqns = append(fqns, step.FQN)
+		assert.Equal(t, "app.py", filepath.Base(step.Location.File))
+	}
+	assert.Equal(t, []string{
+		"user input read at builtins.input",
+		"passed to helper at line 11",
+		"passed to run at line 6",
+		"reaches eval at line 2",
+	}, descriptions)
+	assert.Equal(t, []string{"app.calculate", "app.helper", "app.run", "app.run"}, fqns)
+}
+
+func TestMatchPattern_NoExplanationWithoutFlow(t *testing.T) {
+	match := &PatternMatchDetails{Matched: true, SinkFQN: "app.run", DataFlowPath: []string{"app.run", "builtins.eval"}}
+	assert.Nil(t, explainMatch(match, nil))
+}
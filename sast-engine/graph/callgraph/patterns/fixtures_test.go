@@ -0,0 +1,33 @@
+package patterns
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/builder"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/registry"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/require"
+)
+
+// buildFixtureCallGraph builds the call graph of the fixture project
+// test-fixtures/python/<name>.
+func buildFixtureCallGraph(t *testing.T, name string) *core.CallGraph {
+	t.Helper()
+	projectPath, err := filepath.Abs(filepath.Join("../../../test-fixtures/python", name))
+	require.NoError(t, err)
+	return buildProject(t, projectPath)
+}
+
+// buildProject builds the call graph of the Python project at projectPath.
+func buildProject(t *testing.T, projectPath string) *core.CallGraph {
+	t.Helper()
+	codeGraph := graph.Initialize(projectPath, nil)
+	moduleRegistry, err := registry.BuildModuleRegistry(projectPath, false)
+	require.NoError(t, err)
+	callGraph, err := builder.BuildCallGraph(codeGraph, moduleRegistry, projectPath, output.NewLogger(output.VerbosityDefault))
+	require.NoError(t, err)
+	return callGraph
+}
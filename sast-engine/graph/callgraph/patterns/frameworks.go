@@ -1,7 +1,13 @@
 package patterns
 
 import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/resolution"
 )
 
 // Framework represents a detected framework.
@@ -9,6 +15,33 @@ type Framework struct {
 	Name     string
 	Version  string
 	Category string
+
+	// EntryPoints are the request handlers registered on the project's
+	// route registrars, ordered by file and line. Only set by
+	// DetectProjectFramework.
+	EntryPoints []EntryPoint
+}
+
+// EntryPoint is a function a route registrar's decorator registers as an
+// HTTP request handler. Its request.* reads are taint sources, and its
+// parameters are seeded from the request (see resolution.RouteSourceStatements).
+type EntryPoint struct {
+	FQN       string        // Handler function (e.g., "portal.views.delete_user")
+	File      string        // File defining the handler
+	Line      uint32        // Line of the def
+	Registrar string        // Registrar variable (e.g., "portal.admin.admin_bp")
+	Decorator string        // Decorator as written (e.g., "admin_bp.route")
+	Routes    []graph.Route // Routes the decorators register
+}
+
+// RouteRegistrarTypes are the types whose instances register route
+// handlers with decorators: Flask applications and blueprints. Any variable
+// assigned from one of them, under any name, is a registrar.
+var RouteRegistrarTypes = []string{
+	"flask.Flask",
+	"flask.app.Flask",
+	"flask.Blueprint",
+	"flask.blueprints.Blueprint",
 }
 
 // DetectFramework detects which framework is used based on imports.
@@ -50,3 +83,149 @@ func GetFrameworkCategory(importPath string) string {
 func GetFrameworkName(importPath string) string {
 	return core.GetFrameworkName(importPath)
 }
+
+// DetectProjectFramework returns the framework of FrameworkRulesets the
+// project uses (see DetectRulesetFramework) with its entry points, or nil if
+// it uses none.
+func DetectProjectFramework(callGraph *core.CallGraph) *Framework {
+	name := DetectRulesetFramework(callGraph)
+	if name == "" {
+		return nil
+	}
+	return &Framework{
+		Name:        name,
+		Category:    "web",
+		EntryPoints: FindEntryPoints(callGraph),
+	}
+}
+
+// FindEntryPoints returns the functions decorated with a route method
+// (resolution.RouteDecoratorMethods) of a module-level route registrar: a
+// variable assigned from one of RouteRegistrarTypes. The decorator's
+// receiver is resolved through the file's imports, so a blueprint defined in
+// one module and decorated in another is found:
+//
+//	# portal/admin.py
+//	admin_bp = Blueprint("admin", __name__)
+//
+//	# portal/views.py
+//	from portal.admin import admin_bp
+//
+//	@admin_bp.route("/delete")  → entry point, registrar portal.admin.admin_bp
+//	def delete_user(): ...
+//
+// Decorators on other objects with the same method names (e.g.,
+// @cache.get("key")) are not entry points.
+func FindEntryPoints(callGraph *core.CallGraph) []EntryPoint {
+	typeEngine, ok := callGraph.TypeEngine.(*resolution.TypeInferenceEngine)
+	if !ok || typeEngine == nil {
+		return nil
+	}
+
+	// Registrars by FQN, and by file and name for use in their own module
+	registrars := make(map[string]bool)
+	local := make(map[string]string)
+	for _, typeFQN := range RouteRegistrarTypes {
+		for _, variable := range typeEngine.VariablesOfType(typeFQN) {
+			if _, isFunction := callGraph.Functions[variable.Scope]; isFunction {
+				continue
+			}
+			fqn := variable.Scope + "." + variable.Name
+			registrars[fqn] = true
+			local[variable.File+"\x00"+variable.Name] = fqn
+		}
+	}
+	if len(registrars) == 0 {
+		return nil
+	}
+
+	var entryPoints []EntryPoint
+	for fqn, function := range callGraph.Functions {
+		if function == nil {
+			continue
+		}
+		for _, decorator := range function.Annotation {
+			dot := strings.LastIndexByte(decorator, '.')
+			if dot <= 0 || !slices.Contains(resolution.RouteDecoratorMethods, decorator[dot+1:]) {
+				continue
+			}
+			registrar := resolveRegistrar(decorator[:dot], function.File, local, typeEngine.GetImportMap(function.File))
+			if !registrars[registrar] {
+				continue
+			}
+			entryPoints = append(entryPoints, EntryPoint{
+				FQN:       fqn,
+				File:      function.File,
+				Line:      function.LineNumber,
+				Registrar: registrar,
+				Decorator: decorator,
+				Routes:    function.Routes,
+			})
+			break
+		}
+	}
+
+	slices.SortFunc(entryPoints, func(a, b EntryPoint) int {
+		return cmp.Or(
+			cmp.Compare(a.File, b.File),
+			cmp.Compare(a.Line, b.Line),
+			cmp.Compare(a.FQN, b.FQN),
+		)
+	})
+	return entryPoints
+}
+
+// resolveRegistrar returns the FQN a decorator receiver refers to in file:
+// a registrar defined in the file itself, or the import its first name is
+// bound to ("admin_bp" → "portal.admin.admin_bp", "admin.admin_bp" →
+// "portal.admin.admin_bp" after `from portal import admin`).
+func resolveRegistrar(receiver, file string, local map[string]string, importMap *core.ImportMap) string {
+	if fqn, ok := local[file+"\x00"+receiver]; ok {
+		return fqn
+	}
+	if importMap == nil {
+		return ""
+	}
+	head, rest, hasRest := strings.Cut(receiver, ".")
+	imported, ok := importMap.Imports[head]
+	if !ok {
+		return ""
+	}
+	if hasRest {
+		return imported + "." + rest
+	}
+	return imported
+}
+
+// ReachableFromEntrypoint reports whether fqn is an HTTP entrypoint or is
+// called, through any chain of callers, from one. Findings it reports true
+// for are exposed to user input from a route, unlike those in internal
+// scripts.
+func ReachableFromEntrypoint(fqn string, callGraph *core.CallGraph) bool {
+	if fqn == "" || callGraph == nil {
+		return false
+	}
+	visited := map[string]bool{fqn: true}
+	queue := []string{fqn}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if isHTTPEntrypoint(current, callGraph) {
+			return true
+		}
+		for _, caller := range callGraph.GetCallers(current) {
+			if !visited[caller] {
+				visited[caller] = true
+				queue = append(queue, caller)
+			}
+		}
+	}
+	return false
+}
+
+// isHTTPEntrypoint reports whether a function handles HTTP requests: it has
+// a route from a decorator or a Django URLconf.
+func isHTTPEntrypoint(fqn string, callGraph *core.CallGraph) bool {
+	function, ok := callGraph.GetFunction(fqn)
+	return ok && (len(function.Routes) > 0 || resolution.IsRouteHandler(function.Annotation))
+}
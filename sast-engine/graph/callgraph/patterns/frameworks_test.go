@@ -1,10 +1,13 @@
 package patterns
 
 import (
+	"path/filepath"
 	"testing"
 
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDetectFramework_Django(t *testing.T) {
@@ -161,3 +164,80 @@ func TestFrameworkStruct(t *testing.T) {
 	assert.Equal(t, "1.0.0", fw.Version)
 	assert.Equal(t, "test", fw.Category)
 }
+
+func TestFindEntryPoints_Blueprints(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "blueprints")
+
+	entryPoints := FindEntryPoints(callGraph)
+	require.Len(t, entryPoints, 2, "@cache.get and undecorated functions are not entry points")
+
+	assert.Equal(t, "portal.views.delete_user", entryPoints[0].FQN)
+	assert.Equal(t, "portal.admin.admin_bp", entryPoints[0].Registrar, "imported blueprint resolves to its defining module")
+	assert.Equal(t, "admin_bp.route", entryPoints[0].Decorator)
+	assert.Equal(t, []graph.Route{{Method: "POST", Path: "/delete"}}, entryPoints[0].Routes)
+	assert.Equal(t, "views.py", filepath.Base(entryPoints[0].File))
+	assert.Equal(t, uint32(11), entryPoints[0].Line)
+
+	assert.Equal(t, "portal.views.health", entryPoints[1].FQN)
+	assert.Equal(t, "portal.views.app", entryPoints[1].Registrar)
+}
+
+func TestFindEntryPoints_NoTypeEngine(t *testing.T) {
+	assert.Nil(t, FindEntryPoints(core.NewCallGraph()))
+}
+
+func TestResolveRegistrar(t *testing.T) {
+	importMap := core.NewImportMap("views.py")
+	importMap.AddImport("admin_bp", "portal.admin.admin_bp")
+	importMap.AddImport("admin", "portal.admin")
+	local := map[string]string{"views.py\x00app": "portal.views.app"}
+
+	assert.Equal(t, "portal.views.app", resolveRegistrar("app", "views.py", local, importMap))
+	assert.Equal(t, "portal.admin.admin_bp", resolveRegistrar("admin_bp", "views.py", local, importMap))
+	assert.Equal(t, "portal.admin.admin_bp", resolveRegistrar("admin.admin_bp", "views.py", local, importMap))
+	assert.Empty(t, resolveRegistrar("cache", "views.py", local, importMap))
+	assert.Empty(t, resolveRegistrar("app", "other.py", local, nil))
+}
+
+func TestDetectProjectFramework(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "blueprints")
+
+	framework := DetectProjectFramework(callGraph)
+	require.NotNil(t, framework)
+	assert.Equal(t, FrameworkFlask, framework.Name)
+	assert.Equal(t, "web", framework.Category)
+	assert.Len(t, framework.EntryPoints, 2)
+
+	assert.Nil(t, DetectProjectFramework(core.NewCallGraph()))
+}
+
+func TestBlueprintHandler_RequestSource(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "blueprints")
+	registry := NewPatternRegistry()
+	registry.Framework = DetectRulesetFramework(callGraph)
+	registry.LoadDefaultPatterns()
+	pattern, ok := registry.GetPattern("COMMAND-INJECTION-001")
+	require.True(t, ok)
+
+	match := registry.MatchPattern(pattern, callGraph)
+	require.NotNil(t, match)
+	assert.True(t, match.Matched)
+	assert.Equal(t, "portal.views.delete_user", match.SourceFQN)
+	assert.Equal(t, "request.form", match.SourceCall)
+}
+
+func TestReachableFromEntrypoint(t *testing.T) {
+	cg := core.NewCallGraph()
+	cg.AddFunction("app.views.search", &graph.Node{Name: "search", Routes: []graph.Route{{Method: "GET", Path: "/search"}}})
+	cg.AddFunction("app.db.query", &graph.Node{Name: "query"})
+	cg.AddFunction("app.api.create", &graph.Node{Name: "create", Annotation: []string{"router.post"}})
+	cg.AddFunction("scripts.backfill.run", &graph.Node{Name: "run"})
+	cg.AddEdge("app.views.search", "app.db.query")
+
+	assert.True(t, ReachableFromEntrypoint("app.views.search", cg))
+	assert.True(t, ReachableFromEntrypoint("app.db.query", cg), "called from a route")
+	assert.True(t, ReachableFromEntrypoint("app.api.create", cg), "route registered by decorator")
+	assert.False(t, ReachableFromEntrypoint("scripts.backfill.run", cg))
+	assert.False(t, ReachableFromEntrypoint("", cg))
+	assert.False(t, ReachableFromEntrypoint("app.views.search", nil))
+}
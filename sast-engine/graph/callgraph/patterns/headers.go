@@ -0,0 +1,297 @@
+package patterns
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/resolution"
+)
+
+// headerSetterMethods maps a framework name (as returned by GetFrameworkName)
+// to the response methods that set a header from a (name, value) pair.
+var headerSetterMethods = map[string][]string{
+	"Django":    {"headers.setdefault"},
+	"Flask":     {"headers.add", "headers.set", "headers.setdefault"},
+	"FastAPI":   {"headers.append", "headers.setdefault"},
+	"Starlette": {"headers.append", "headers.setdefault"},
+	"Tornado":   {"set_header", "add_header"},
+	"Bottle":    {"set_header", "add_header"},
+	"aiohttp":   {"headers.add", "headers.setdefault"},
+}
+
+// itemHeaderResponses maps a framework name to its response classes whose
+// item assignment sets a header: response["Location"] = url in Django.
+// Every framework sets headers through the headers mapping of a response.
+var itemHeaderResponses = map[string][]string{
+	"Django": {"HttpResponse", "HttpResponseRedirect", "HttpResponsePermanentRedirect", "JsonResponse", "StreamingHttpResponse", "FileResponse"},
+}
+
+// headerValueKeywords are the parameter names header setters use for the
+// value: Tornado set_header(name, value), Werkzeug headers.add(_key, _value).
+var headerValueKeywords = []string{"value", "_value"}
+
+// headerAssignment matches an item assignment, capturing the target, key,
+// and value: response["Location"] = url.
+var headerAssignment = regexp.MustCompile(`^\s*([A-Za-z_][\w.]*)\[([^\]]+)\]\s*=([^=].*)$`)
+
+// newlineStripper is the sanitizer that statements stripping newlines are
+// rewritten to call (see headerStatements).
+const newlineStripper = "<strip-newlines>"
+
+// HeaderSanitizers percent-encode their argument for use in a URL or
+// header, which escapes CR and LF.
+var HeaderSanitizers = []string{"quote", "quote_plus", "iri_to_uri", "escape_uri_path"}
+
+// HeaderFunctions returns the response methods setting a header for a
+// framework name (e.g., "Flask", "Tornado"). Returns nil for frameworks
+// without a known header API.
+func HeaderFunctions(framework string) []string {
+	return headerSetterMethods[framework]
+}
+
+// allHeaderFunctions returns the header setters of every framework, sorted
+// and without duplicates.
+func allHeaderFunctions() []string {
+	var all []string
+	for _, functions := range headerSetterMethods {
+		all = append(all, functions...)
+	}
+	slices.Sort(all)
+	return slices.Compact(all)
+}
+
+// headerFramework returns the web framework with a header API imported by
+// file, the first by imported FQN, or "" if there is none.
+func headerFramework(callGraph *core.CallGraph, file string) string {
+	typeEngine, ok := callGraph.TypeEngine.(*resolution.TypeInferenceEngine)
+	if !ok || typeEngine == nil {
+		return ""
+	}
+	importMap := typeEngine.GetImportMap(file)
+	if importMap == nil {
+		return ""
+	}
+	imports := make([]string, 0, len(importMap.Imports))
+	for _, fqn := range importMap.Imports {
+		imports = append(imports, fqn)
+	}
+	slices.Sort(imports)
+	for _, fqn := range imports {
+		if framework := GetFrameworkName(fqn); HeaderFunctions(framework) != nil {
+			return framework
+		}
+	}
+	return ""
+}
+
+// isHeaderSetter reports whether a call sets a response header through the
+// header API of the framework it resolves into or, when unresolved, of the
+// framework its file imports.
+func isHeaderSetter(callSite *core.CallSite, fileFramework string) bool {
+	framework := GetFrameworkName(callSite.TargetFQN)
+	if HeaderFunctions(framework) == nil {
+		framework = fileFramework
+	}
+	for _, method := range HeaderFunctions(framework) {
+		if strings.HasSuffix(callSite.Target, "."+method) {
+			return true
+		}
+	}
+	return false
+}
+
+// headerValueArgument returns the expression a header setter sets as the
+// value: a value keyword argument if present, otherwise the second
+// positional argument. Returns "" if the call has none.
+func headerValueArgument(callSite *core.CallSite) string {
+	var positional string
+	for _, arg := range callSite.Arguments {
+		keyword, value, ok := splitKeywordArgument(arg.Value)
+		if !ok {
+			if positional == "" && arg.Position == 1 {
+				positional = arg.Value
+			}
+			continue
+		}
+		if slices.Contains(headerValueKeywords, keyword) {
+			return value
+		}
+	}
+	return positional
+}
+
+// isHeaderTarget reports whether an item assignment to target in caller,
+// at line, sets a header: target is the headers mapping of a response, or
+// a variable last assigned a response of itemHeaderResponses.
+func isHeaderTarget(caller, target string, line int, callGraph *core.CallGraph, fileFramework string) bool {
+	if strings.HasSuffix(target, ".headers") {
+		return fileFramework != ""
+	}
+	if !isIdentifier(target) {
+		return false
+	}
+	var binding *core.Statement
+	for _, stmt := range callGraph.Statements[caller] {
+		if stmt.Def == target && int(stmt.LineNumber) < line {
+			binding = stmt
+		}
+	}
+	if binding == nil {
+		return false
+	}
+	for _, site := range callGraph.CallSites[caller] {
+		if site.Location.Line != int(binding.LineNumber) {
+			continue
+		}
+		for _, class := range itemHeaderResponses[GetFrameworkName(site.TargetFQN)] {
+			if matchesFunctionName(site.TargetFQN, class) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// headerStatements returns the statements of caller with those stripping
+// newlines from the value they assign rewritten as calls to
+// newlineStripper, a sanitizer of the header patterns.
+func headerStatements(caller string, callGraph *core.CallGraph) []*core.Statement {
+	statements := make([]*core.Statement, 0, len(callGraph.Statements[caller]))
+	for _, stmt := range callGraph.Statements[caller] {
+		if stmt.Def != "" && newlineStripping.MatchString(stmt.CallTarget) {
+			stripped := *stmt
+			stripped.CallTarget = newlineStripper
+			stmt = &stripped
+		}
+		statements = append(statements, stmt)
+	}
+	return statements
+}
+
+// taintedHeaderSource returns the source whose data reaches a header value
+// set at a call site (real or standing for an item assignment), or "" if
+// none does or its newlines were stripped.
+func taintedHeaderSource(caller string, callSite *core.CallSite, value string, callGraph *core.CallGraph, pattern *Pattern) string {
+	if newlineStripping.MatchString(value) {
+		return ""
+	}
+	for _, field := range fStringFields(value) {
+		if source := taintedHeaderSource(caller, callSite, field, callGraph, pattern); source != "" {
+			return source
+		}
+	}
+	code := stringLiteral.ReplaceAllString(value, `""`)
+	for _, source := range pattern.Sources {
+		if strings.Contains(code, source) {
+			return source
+		}
+	}
+
+	sanitized := *pattern
+	sanitized.Sanitizers = append(slices.Clone(pattern.Sanitizers), newlineStripper)
+	statements := headerStatements(caller, callGraph)
+	for _, match := range variableReference.FindAllStringSubmatch(code, -1) {
+		if source := taintedArgumentSourceIn(caller, callSite, match[2], statements, callGraph, &sanitized); source != "" {
+			return source
+		}
+	}
+	return ""
+}
+
+// findHeaderInjections returns every response header set from tainted data,
+// ordered by function FQN and line: through the framework's header setters
+// (see HeaderFunctions), or by item assignment to a response or its headers
+// mapping. A header value can hold CR/LF and split the response, so values
+// whose newlines were stripped (value.replace("\n", ""), re.sub(r"[\r\n]",
+// "", value)) or passed to a sanitizer are safe.
+func (pr *PatternRegistry) findHeaderInjections(pattern *Pattern, callGraph *core.CallGraph) []*PatternMatchDetails {
+	sources := newSourceLines(callGraph)
+
+	var matches []*PatternMatchDetails
+	for _, caller := range sortedCallers(callGraph) {
+		function, ok := callGraph.Functions[caller]
+		if !ok {
+			continue
+		}
+		fileFramework := headerFramework(callGraph, function.File)
+		if fileFramework == "" {
+			continue
+		}
+
+		type headerSink struct {
+			site  *core.CallSite
+			value string
+			call  string
+		}
+		var sinks []headerSink
+		callSites := sortedCallSites(callGraph, caller)
+		for i := range callSites {
+			if isHeaderSetter(&callSites[i], fileFramework) {
+				sinks = append(sinks, headerSink{&callSites[i], headerValueArgument(&callSites[i]), callSites[i].Target})
+			}
+		}
+		for _, line := range functionBodyLines(function, sources) {
+			match := headerAssignment.FindStringSubmatch(sources.line(function.File, line))
+			if match == nil || !isHeaderTarget(caller, match[1], line, callGraph, fileFramework) {
+				continue
+			}
+			site := &core.CallSite{
+				Target:    match[1] + ".__setitem__",
+				TargetFQN: match[1] + ".__setitem__",
+				Location:  core.Location{File: function.File, Line: line},
+			}
+			sinks = append(sinks, headerSink{site, strings.TrimSpace(match[3]), match[1] + "[" + match[2] + "]"})
+		}
+		slices.SortStableFunc(sinks, func(a, b headerSink) int {
+			return a.site.Location.Line - b.site.Location.Line
+		})
+
+		for _, sink := range sinks {
+			if sink.value == "" {
+				continue
+			}
+			source := taintedHeaderSource(caller, sink.site, sink.value, callGraph, pattern)
+			if source == "" {
+				continue
+			}
+			matches = append(matches, &PatternMatchDetails{
+				Matched:           true,
+				IsIntraProcedural: true,
+				SourceFQN:         caller,
+				SourceCall:        source,
+				SinkFQN:           caller,
+				SinkCall:          sink.call,
+				DataFlowPath:      []string{caller},
+				Context:           sink.value + " set as header in " + sink.call,
+			})
+		}
+	}
+	return matches
+}
+
+// functionBodyLines returns the line numbers of a function's body: the lines
+// after its def indented deeper than it, up to the first one that is not
+// (a closing parenthesis of the signature excepted).
+func functionBodyLines(function *graph.Node, sources sourceLines) []int {
+	def := sources.line(function.File, int(function.LineNumber))
+	indent := len(def) - len(strings.TrimLeft(def, " \t"))
+
+	var lines []int
+	for line := int(function.LineNumber) + 1; ; line++ {
+		text := sources.line(function.File, line)
+		trimmed := strings.TrimLeft(text, " \t")
+		if trimmed == "" {
+			if line > len(sources[function.File]) {
+				return lines
+			}
+			continue
+		}
+		if len(text)-len(trimmed) <= indent && !strings.HasPrefix(trimmed, "#") && !strings.HasPrefix(trimmed, ")") {
+			return lines
+		}
+		lines = append(lines, line)
+	}
+}
@@ -0,0 +1,85 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeaderInjection(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "header_injection")
+
+	patternRegistry := NewPatternRegistry()
+	patternRegistry.LoadDefaultPatterns()
+	pattern, ok := patternRegistry.GetPattern("HEADER-INJECTION-001")
+	require.True(t, ok)
+
+	found := make(map[string]*PatternMatchDetails)
+	for _, match := range patternRegistry.findHeaderInjections(pattern, callGraph) {
+		found[match.SinkFQN] = match
+	}
+
+	tests := []struct {
+		function string
+		sinkCall string
+		source   string
+	}{
+		{"views.set_location", `response["Location"]`, "request.GET"},
+		{"views.set_language", `response["Content-Language"]`, "request.GET"},
+		{"app.download", `response.headers["Content-Disposition"]`, "request.args"},
+		{"app.trace", "response.headers.set", "request.headers"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.function, func(t *testing.T) {
+			match, ok := found[tt.function]
+			require.True(t, ok, "expected a finding in %s", tt.function)
+			assert.Equal(t, tt.sinkCall, match.SinkCall)
+			assert.Equal(t, tt.source, match.SourceCall)
+		})
+	}
+
+	// Newlines stripped before the header is set
+	assert.NotContains(t, found, "views.set_language_stripped")
+	assert.NotContains(t, found, "app.download_stripped")
+	assert.NotContains(t, found, "views.set_static_header")
+	// Item assignment to a dict is not a header
+	assert.NotContains(t, found, "app.cache")
+	assert.Len(t, found, len(tests))
+
+	match := patternRegistry.MatchPattern(pattern, callGraph)
+	require.True(t, match.Matched)
+	assert.Equal(t, "app.download", match.SinkFQN)
+}
+
+func TestIsHeaderSetter(t *testing.T) {
+	tests := []struct {
+		target    string
+		targetFQN string
+		framework string
+		want      bool
+	}{
+		{"self.set_header", "tornado.web.RequestHandler.set_header", "", true},
+		{"self.set_header", "self.set_header", "Tornado", true},
+		{"response.headers.add", "response.headers.add", "Flask", true},
+		{"response.headers.append", "response.headers.append", "FastAPI", true},
+		{"response.headers.append", "response.headers.append", "Flask", false},
+		{"items.add", "items.add", "Flask", false},
+		{"self.set_header", "self.set_header", "", false},
+	}
+	for _, tt := range tests {
+		callSite := &core.CallSite{Target: tt.target, TargetFQN: tt.targetFQN}
+		assert.Equal(t, tt.want, isHeaderSetter(callSite, tt.framework), "%s in %q", tt.target, tt.framework)
+	}
+}
+
+func TestHeaderValueArgument(t *testing.T) {
+	positional := &core.CallSite{Arguments: []core.Argument{{Value: `"X-Trace"`, Position: 0}, {Value: "trace_id", Position: 1}}}
+	assert.Equal(t, "trace_id", headerValueArgument(positional))
+
+	keyword := &core.CallSite{Arguments: []core.Argument{{Value: `"X-Trace"`, Position: 0}, {Value: "_value=trace_id", Position: 1}}}
+	assert.Equal(t, "trace_id", headerValueArgument(keyword))
+
+	assert.Empty(t, headerValueArgument(&core.CallSite{Arguments: []core.Argument{{Value: `"X-Trace"`}}}))
+}
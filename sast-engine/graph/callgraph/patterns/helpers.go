@@ -2,7 +2,9 @@ package patterns
 
 import (
 	"os"
+	"strings"
 
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
 	sitter "github.com/smacker/go-tree-sitter"
 )
 
@@ -11,6 +13,38 @@ func readFileBytes(filePath string) ([]byte, error) {
 	return os.ReadFile(filePath)
 }
 
+// sourceLines caches the lines of source files read while matching, keyed by
+// file path. Files that cannot be read cache as empty.
+type sourceLines map[string][]string
+
+// newSourceLines returns a sourceLines cache seeded with the in-memory
+// sources of callGraph, such as notebook modules, which are not on disk.
+func newSourceLines(callGraph *core.CallGraph) sourceLines {
+	sources := make(sourceLines, len(callGraph.Sources))
+	for file, content := range callGraph.Sources {
+		sources[file] = strings.Split(string(content), "\n")
+	}
+	return sources
+}
+
+// line returns the 1-indexed line of file, or "" if it is out of range.
+func (s sourceLines) line(file string, lineNumber int) string {
+	if file == "" || lineNumber < 1 {
+		return ""
+	}
+	lines, ok := s[file]
+	if !ok {
+		if content, err := readFileBytes(file); err == nil {
+			lines = strings.Split(string(content), "\n")
+		}
+		s[file] = lines
+	}
+	if lineNumber > len(lines) {
+		return ""
+	}
+	return lines[lineNumber-1]
+}
+
 // findFunctionAtLine finds a function node at a specific line number in the AST.
 func findFunctionAtLine(root *sitter.Node, lineNumber uint32) *sitter.Node {
 	if root == nil {
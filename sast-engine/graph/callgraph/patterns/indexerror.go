@@ -0,0 +1,520 @@
+package patterns
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// constant is a value known by constant propagation: the length of a list
+// or tuple, or an integer. since is the earliest line of the definitions it
+// was folded from; nothing the statements do not show may run after it.
+type constant struct {
+	value int
+	since uint32
+}
+
+// constantEnv holds the constants known at a statement of a function.
+type constantEnv struct {
+	lengths map[string]constant // list and tuple variables
+	ints    map[string]constant // integer variables
+}
+
+// findIndexErrors returns a match for every subscript of a list or tuple
+// whose index is out of range, as in a = [1, 2]; a[2] or a[len(a)], ordered
+// by function FQN and line. Lengths and indices are folded from literals,
+// integer variables, len(), and + - * // % over a function's statements.
+//
+// Only provable cases are flagged: the statements cover straight-line code
+// only, so the list, and every constant the index folds, must be defined by
+// statements with nothing between them and the subscript but other
+// statements; a list passed to a call, aliased, or modified is forgotten;
+// and functions with nested functions, lambdas, or global declarations,
+// which could change a list by name, are skipped. SinkFQN is the function
+// and Context names the subscript.
+func (pr *PatternRegistry) findIndexErrors(pattern *Pattern, callGraph *core.CallGraph) []*PatternMatchDetails {
+	functions := make([]string, 0, len(callGraph.Statements))
+	for fqn := range callGraph.Statements {
+		functions = append(functions, fqn)
+	}
+	slices.Sort(functions)
+
+	sources := newSourceLines(callGraph)
+	var matches []*PatternMatchDetails
+	for _, fqn := range functions {
+		function, ok := callGraph.Functions[fqn]
+		if !ok {
+			continue
+		}
+		statements := callGraph.Statements[fqn]
+		visible := make(map[uint32]bool, len(statements))
+		for _, stmt := range statements {
+			visible[stmt.LineNumber] = true
+		}
+		// provable reports whether nothing hidden from the statements runs
+		// between since and line.
+		provable := func(since, line uint32) bool {
+			for l := function.LineNumber + 1; l < line; l++ {
+				if hidesClosure(sources.line(function.File, int(l))) {
+					return false
+				}
+			}
+			for l := since + 1; l < line; l++ {
+				text := strings.TrimSpace(sources.line(function.File, int(l)))
+				if text != "" && !strings.HasPrefix(text, "#") && !visible[l] {
+					return false
+				}
+			}
+			return true
+		}
+
+		env := constantEnv{lengths: make(map[string]constant), ints: make(map[string]constant)}
+		for _, stmt := range statements {
+			texts := statementTexts(stmt)
+			for _, text := range texts {
+				for _, sub := range subscripts(text) {
+					length, ok := env.lengths[sub.name]
+					if !ok {
+						continue
+					}
+					index, ok := env.fold(sub.index)
+					if !ok || (index.value < length.value && index.value >= -length.value) {
+						continue
+					}
+					if !provable(min(length.since, index.since), stmt.LineNumber) {
+						continue
+					}
+					matches = append(matches, &PatternMatchDetails{
+						Matched:           true,
+						IsIntraProcedural: true,
+						SinkFQN:           fqn,
+						DataFlowPath:      []string{fqn},
+						Context: fmt.Sprintf("%s[%s] at line %d is out of range: %s has %d elements",
+							sub.name, sub.index, stmt.LineNumber, sub.name, length.value),
+					})
+				}
+			}
+			env.update(stmt, texts)
+		}
+	}
+	return matches
+}
+
+// update applies a statement to env: lists it may modify are forgotten,
+// and its definition, if any, is folded.
+func (env constantEnv) update(stmt *core.Statement, texts []string) {
+	for _, use := range stmt.Uses {
+		if _, ok := env.lengths[use]; !ok {
+			continue
+		}
+		shown, modified := false, false
+		for _, text := range texts {
+			shown = shown || slices.Contains(identifiers(text), use)
+			modified = modified || mentionsBeyondReads(text, use)
+		}
+		// A use the statement's texts do not show may modify the list too
+		if modified || !shown {
+			delete(env.lengths, use)
+		}
+	}
+
+	if stmt.Type != core.StatementTypeAssignment || stmt.Def == "" {
+		return
+	}
+	def := stmt.Def
+	augmented := slices.Contains(stmt.Uses, def) && stmt.CallTarget == ""
+	var length, value constant
+	var isLength, isInt bool
+	if !augmented {
+		length, isLength = env.sequenceLength(stmt.CallTarget)
+		if !isLength {
+			value, isInt = env.fold(stmt.CallTarget)
+		}
+	}
+	delete(env.lengths, def)
+	delete(env.ints, def)
+	if isLength {
+		length.since = min(length.since, stmt.LineNumber)
+		env.lengths[def] = length
+	} else if isInt {
+		value.since = min(value.since, stmt.LineNumber)
+		env.ints[def] = value
+	}
+}
+
+// statementTexts returns the expression source texts of a statement.
+func statementTexts(stmt *core.Statement) []string {
+	var texts []string
+	for _, text := range []string{stmt.CallTarget, stmt.CallChain, stmt.Condition} {
+		if text != "" {
+			texts = append(texts, text)
+		}
+	}
+	return append(texts, stmt.CallArgs...)
+}
+
+// hidesClosure reports whether a source line starts a nested function or
+// class, contains a lambda, or declares names global or nonlocal; any of
+// them could change a local list by name.
+func hidesClosure(line string) bool {
+	text := strings.TrimSpace(line)
+	for _, keyword := range []string{"def ", "async def ", "class ", "global ", "nonlocal "} {
+		if strings.HasPrefix(text, keyword) {
+			return true
+		}
+	}
+	return slices.Contains(identifiers(text), "lambda")
+}
+
+// subscript is an indexing expression name[index].
+type subscript struct {
+	name  string
+	index string
+}
+
+// subscripts returns the subscripts of plain names in expr with a single
+// index (not a slice or a tuple), including nested ones.
+//
+//	"a[len(a)] + b[i][0]" → a[len(a)], b[i]
+func subscripts(expr string) []subscript {
+	var subs []subscript
+	scanIdentifiers(expr, func(name string, start, end int) {
+		if start > 0 && expr[start-1] == '.' || end >= len(expr) || expr[end] != '[' {
+			return
+		}
+		closing := matchingBracket(expr, end)
+		if closing < 0 {
+			return
+		}
+		index := strings.TrimSpace(expr[end+1 : closing])
+		if len(splitTopLevel(index, ',')) > 1 || len(splitTopLevel(index, ':')) > 1 {
+			return
+		}
+		subs = append(subs, subscript{name: name, index: index})
+	})
+	return subs
+}
+
+// mentionsBeyondReads reports whether expr uses name other than by
+// indexing it (name[i]) or taking its length (len(name)).
+func mentionsBeyondReads(expr, name string) bool {
+	mentioned := false
+	scanIdentifiers(expr, func(identifier string, start, end int) {
+		if identifier != name || start > 0 && expr[start-1] == '.' {
+			return
+		}
+		if end < len(expr) && expr[end] == '[' {
+			return
+		}
+		// len(name)
+		before, isCall := strings.CutSuffix(strings.TrimRight(expr[:start], " "), "(")
+		fn := strings.TrimRight(before, " ")
+		if isCall && strings.HasPrefix(strings.TrimLeft(expr[end:], " "), ")") && strings.HasSuffix(fn, "len") &&
+			(len(fn) == 3 || !isIdentifierByte(fn[len(fn)-4]) && fn[len(fn)-4] != '.') {
+			return
+		}
+		mentioned = true
+	})
+	return mentioned
+}
+
+// identifiers returns the identifiers in expr outside string literals.
+func identifiers(expr string) []string {
+	var names []string
+	scanIdentifiers(expr, func(name string, _, _ int) {
+		names = append(names, name)
+	})
+	return names
+}
+
+// scanIdentifiers calls fn with each identifier in expr outside string
+// literals, and its start and end offsets.
+func scanIdentifiers(expr string, fn func(name string, start, end int)) {
+	for i := 0; i < len(expr); {
+		switch c := expr[i]; {
+		case c == '"' || c == '\'':
+			i = skipString(expr, i)
+		case isIdentifierByte(c) && (c < '0' || c > '9'):
+			start := i
+			for i < len(expr) && isIdentifierByte(expr[i]) {
+				i++
+			}
+			// String prefixes such as f"..." or rb'...' are not names
+			if i < len(expr) && (expr[i] == '"' || expr[i] == '\'') && len(expr[start:i]) <= 2 {
+				continue
+			}
+			fn(expr[start:i], start, i)
+		case c >= '0' && c <= '9':
+			for i < len(expr) && isIdentifierByte(expr[i]) {
+				i++
+			}
+		default:
+			i++
+		}
+	}
+}
+
+// skipString returns the offset after the string literal starting at i.
+func skipString(expr string, i int) int {
+	quote := expr[i]
+	for i++; i < len(expr); i++ {
+		switch expr[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1
+		}
+	}
+	return len(expr)
+}
+
+// matchingBracket returns the offset of the bracket closing the one at
+// open, or -1.
+func matchingBracket(expr string, open int) int {
+	depth := 0
+	for i := open; i < len(expr); {
+		switch expr[i] {
+		case '"', '\'':
+			i = skipString(expr, i)
+			continue
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+		i++
+	}
+	return -1
+}
+
+// splitTopLevel splits expr at the occurrences of sep outside brackets and
+// string literals.
+func splitTopLevel(expr string, sep byte) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(expr); {
+		switch c := expr[i]; {
+		case c == '"' || c == '\'':
+			i = skipString(expr, i)
+			continue
+		case c == '(' || c == '[' || c == '{':
+			depth++
+		case c == ')' || c == ']' || c == '}':
+			depth--
+		case c == sep && depth == 0:
+			parts = append(parts, expr[start:i])
+			start = i + 1
+		}
+		i++
+	}
+	return append(parts, expr[start:])
+}
+
+// sequenceLength folds the length of a list or tuple literal, possibly
+// repeated by a constant: "[1, 2]" → 2, "(x,)" → 1, "[0] * n" → n.
+// Comprehensions and starred elements have no known length.
+func (env constantEnv) sequenceLength(expr string) (constant, bool) {
+	expr = strings.TrimSpace(expr)
+	if factors := splitTopLevel(expr, '*'); len(factors) == 2 && !strings.Contains(expr, "**") {
+		for i, factor := range factors {
+			length, ok := env.sequenceLength(factor)
+			if !ok {
+				continue
+			}
+			count, ok := env.fold(factors[1-i])
+			if !ok {
+				return constant{}, false
+			}
+			return constant{value: length.value * max(count.value, 0), since: min(length.since, count.since)}, true
+		}
+		return constant{}, false
+	}
+
+	if expr == "" || matchingBracket(expr, 0) != len(expr)-1 {
+		return constant{}, false
+	}
+	open, inner := expr[0], strings.TrimSpace(expr[1:len(expr)-1])
+	if open != '[' && open != '(' {
+		return constant{}, false
+	}
+	if inner == "" {
+		return constant{since: math.MaxUint32}, true
+	}
+	elements := splitTopLevel(inner, ',')
+	if strings.TrimSpace(elements[len(elements)-1]) == "" {
+		elements = elements[:len(elements)-1]
+	} else if open == '(' && len(elements) == 1 {
+		return constant{}, false // a parenthesized expression
+	}
+	for _, element := range elements {
+		element = strings.TrimSpace(element)
+		if element == "" || strings.HasPrefix(element, "*") || slices.Contains(identifiers(element), "for") {
+			return constant{}, false
+		}
+	}
+	return constant{value: len(elements), since: math.MaxUint32}, true
+}
+
+// fold evaluates an integer expression of literals, known integer
+// variables, len() of known lists, unary -, and + - * // %, with Python's
+// floor semantics. ok is false for anything else.
+func (env constantEnv) fold(expr string) (constant, bool) {
+	tokens, ok := tokenize(expr)
+	if !ok || len(tokens) == 0 {
+		return constant{}, false
+	}
+	p := &folder{env: env, tokens: tokens}
+	value, ok := p.sum()
+	if !ok || p.pos != len(tokens) {
+		return constant{}, false
+	}
+	return value, true
+}
+
+// tokenize splits an integer expression into tokens. ok is false when expr
+// holds anything but names, integer literals, and the folded operators.
+func tokenize(expr string) ([]string, bool) {
+	var tokens []string
+	for i := 0; i < len(expr); {
+		switch c := expr[i]; {
+		case c == ' ' || c == '\t':
+			i++
+		case strings.HasPrefix(expr[i:], "//"):
+			tokens = append(tokens, "//")
+			i += 2
+		case strings.ContainsRune("+-*%()", rune(c)):
+			if c == '*' && strings.HasPrefix(expr[i:], "**") {
+				return nil, false
+			}
+			tokens = append(tokens, string(c))
+			i++
+		case isIdentifierByte(c):
+			start := i
+			for i < len(expr) && isIdentifierByte(expr[i]) {
+				i++
+			}
+			tokens = append(tokens, expr[start:i])
+		default:
+			return nil, false
+		}
+	}
+	return tokens, true
+}
+
+// folder is a recursive descent evaluator over the tokens of an integer
+// expression.
+type folder struct {
+	env    constantEnv
+	tokens []string
+	pos    int
+}
+
+// next returns the current token, or "" at the end.
+func (p *folder) next() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+// sum folds term (("+" | "-") term)*.
+func (p *folder) sum() (constant, bool) {
+	left, ok := p.product()
+	for ok && (p.next() == "+" || p.next() == "-") {
+		op := p.next()
+		p.pos++
+		var right constant
+		if right, ok = p.product(); ok {
+			if op == "+" {
+				left.value += right.value
+			} else {
+				left.value -= right.value
+			}
+			left.since = min(left.since, right.since)
+		}
+	}
+	return left, ok
+}
+
+// product folds unary (("*" | "//" | "%") unary)*.
+func (p *folder) product() (constant, bool) {
+	left, ok := p.unary()
+	for ok && (p.next() == "*" || p.next() == "//" || p.next() == "%") {
+		op := p.next()
+		p.pos++
+		var right constant
+		if right, ok = p.unary(); !ok {
+			break
+		}
+		switch op {
+		case "*":
+			left.value *= right.value
+		case "//", "%":
+			if right.value == 0 {
+				return constant{}, false
+			}
+			quotient := left.value / right.value
+			if (left.value%right.value != 0) && ((left.value < 0) != (right.value < 0)) {
+				quotient--
+			}
+			if op == "//" {
+				left.value = quotient
+			} else {
+				left.value -= quotient * right.value
+			}
+		}
+		left.since = min(left.since, right.since)
+	}
+	return left, ok
+}
+
+// unary folds ("-" | "+") unary | primary.
+func (p *folder) unary() (constant, bool) {
+	switch p.next() {
+	case "-":
+		p.pos++
+		value, ok := p.unary()
+		value.value = -value.value
+		return value, ok
+	case "+":
+		p.pos++
+		return p.unary()
+	}
+	return p.primary()
+}
+
+// primary folds an integer literal, a known integer variable, len(name) of
+// a known list, or a parenthesized sum.
+func (p *folder) primary() (constant, bool) {
+	token := p.next()
+	p.pos++
+	switch {
+	case token == "(":
+		value, ok := p.sum()
+		if !ok || p.next() != ")" {
+			return constant{}, false
+		}
+		p.pos++
+		return value, true
+	case token == "len":
+		if p.pos+2 >= len(p.tokens) || p.tokens[p.pos] != "(" || p.tokens[p.pos+2] != ")" {
+			return constant{}, false
+		}
+		length, ok := p.env.lengths[p.tokens[p.pos+1]]
+		p.pos += 3
+		return length, ok
+	case token != "" && token[0] >= '0' && token[0] <= '9':
+		value, err := strconv.ParseInt(strings.ReplaceAll(token, "_", ""), 10, 32)
+		return constant{value: int(value), since: math.MaxUint32}, err == nil
+	default:
+		value, ok := p.env.ints[token]
+		return value, ok
+	}
+}
@@ -0,0 +1,113 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexError_Fixture(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "index_error")
+
+	registry := NewPatternRegistry()
+	registry.LoadDefaultPatterns()
+	pattern, ok := registry.GetPattern("INDEX-ERROR-001")
+	require.True(t, ok)
+
+	contexts := make(map[string]string)
+	for _, match := range registry.findIndexErrors(pattern, callGraph) {
+		assert.True(t, match.IsIntraProcedural)
+		contexts[match.SinkFQN] = match.Context
+	}
+
+	assert.Equal(t, map[string]string{
+		"app.past_end":         "a[2] at line 6 is out of range: a has 2 elements",
+		"app.length_index":     "items[len(items)] at line 11 is out of range: items has 3 elements",
+		"app.negative_index":   "pair[-3] at line 16 is out of range: pair has 2 elements",
+		"app.folded_constants": "grid[last] at line 23 is out of range: grid has 2 elements",
+	}, contexts)
+
+	match := registry.MatchPattern(pattern, callGraph)
+	require.True(t, match.Matched)
+	assert.Equal(t, "app.folded_constants", match.SinkFQN)
+}
+
+func TestConstantEnv_Fold(t *testing.T) {
+	env := constantEnv{
+		lengths: map[string]constant{"a": {value: 3}},
+		ints:    map[string]constant{"n": {value: 4}},
+	}
+	tests := []struct {
+		expr  string
+		value int
+		ok    bool
+	}{
+		{"2", 2, true},
+		{"-1", -1, true},
+		{"len(a) - 1", 2, true},
+		{"n * 2 + 1", 9, true},
+		{"(n + 1) // 2", 2, true},
+		{"-7 // 2", -4, true},
+		{"-7 % 3", 2, true},
+		{"1_000", 1000, true},
+		{"n // 0", 0, false},
+		{"2 ** 3", 0, false},
+		{"i", 0, false},
+		{"len(b)", 0, false},
+		{"f(1)", 0, false},
+		{"1.5", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			value, ok := env.fold(tt.expr)
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.value, value.value)
+			}
+		})
+	}
+}
+
+func TestConstantEnv_SequenceLength(t *testing.T) {
+	env := constantEnv{ints: map[string]constant{"n": {value: 3}}}
+	tests := []struct {
+		expr   string
+		length int
+		ok     bool
+	}{
+		{"[1, 2]", 2, true},
+		{"[]", 0, true},
+		{"()", 0, true},
+		{"(1,)", 1, true},
+		{"[1, 2,]", 2, true},
+		{"[f(1, 2), [3, 4], 'a,b']", 3, true},
+		{"[0] * n", 3, true},
+		{"2 * (1, 2)", 4, true},
+		{"(1)", 0, false},
+		{"[x for x in y]", 0, false},
+		{"[*a, 1]", 0, false},
+		{"[1] + [2]", 0, false},
+		{"[0] * m", 0, false},
+		{"list(a)", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			length, ok := env.sequenceLength(tt.expr)
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.length, length.value)
+			}
+		})
+	}
+}
+
+func TestSubscripts(t *testing.T) {
+	assert.Equal(t, []subscript{{"a", "len(a)"}, {"b", "i"}},
+		subscripts(`a[len(a)] + b[i][0] + c.d[1] + e[1:2] + f[1, 2] + "g[0]"`))
+	assert.True(t, mentionsBeyondReads("a.append(3)", "a"))
+	assert.True(t, mentionsBeyondReads("f(a)", "a"))
+	assert.False(t, mentionsBeyondReads("a[0] + len(a)", "a"))
+	assert.False(t, mentionsBeyondReads("b.a + 'a'", "a"))
+}
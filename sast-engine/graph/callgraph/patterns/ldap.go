@@ -0,0 +1,160 @@
+package patterns
+
+import (
+	"regexp"
+	"slices"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// LDAPSearchMethods are the search methods of python-ldap LDAPObject and
+// ldap3 Connection objects, which take a search filter.
+var LDAPSearchMethods = []string{"search", "search_s", "search_st", "search_ext", "search_ext_s"}
+
+// LDAPFilterEscapers escape the special characters of values put in an LDAP
+// search filter (ldap.filter and ldap3.utils.conv). filter_format escapes
+// the values it formats into a filter. Bare names cover imported functions.
+var LDAPFilterEscapers = []string{
+	"escape_filter_chars", "filter_format",
+	"ldap.filter.escape_filter_chars", "ldap.filter.filter_format", "ldap3.utils.conv.escape_filter_chars",
+}
+
+// pythonLDAPModules and ldap3Modules are the packages whose connections
+// take the search filter as their third and second argument.
+var (
+	pythonLDAPModules = []string{"ldap"}
+	ldap3Modules      = []string{"ldap3"}
+)
+
+// calleeCall matches the callee and opening parenthesis of a call.
+var calleeCall = regexp.MustCompile(`([A-Za-z_][\w.]*)\(`)
+
+// ldapFilterArgument returns the search filter passed to a search method,
+// or "" if the receiver is not a python-ldap or ldap3 connection (see
+// isModuleHandle).
+func ldapFilterArgument(caller, receiver string, callSite *core.CallSite, callGraph *core.CallGraph, sources sourceLines) string {
+	file, line := callSite.Location.File, callSite.Location.Line
+	switch {
+	case isModuleHandle(ldap3Modules, caller, receiver, file, line, 0, callGraph, sources):
+		// Connection.search(search_base, search_filter, ...)
+		return openArgument(callSite, 1, "search_filter")
+	case isModuleHandle(pythonLDAPModules, caller, receiver, file, line, 0, callGraph, sources):
+		// LDAPObject.search_s(base, scope, filterstr, ...)
+		return openArgument(callSite, 2, "filterstr")
+	}
+	return ""
+}
+
+// withoutSanitizerCalls replaces each call to one of sanitizers in expr,
+// with its arguments, by a literal, so values escaped inline are not
+// reported: f"(uid={escape_filter_chars(name)})" becomes f"(uid={0})".
+// Callees match sanitizers as in the taint engine (see matchesFunctionName);
+// methods called on other expressions are not sanitizers.
+func withoutSanitizerCalls(expr string, sanitizers []string) string {
+	for offset := 0; ; {
+		match := calleeCall.FindStringSubmatchIndex(expr[offset:])
+		if match == nil {
+			return expr
+		}
+		start, open := offset+match[2], offset+match[1]-1
+		callee := expr[start:open]
+		end := closingParen(expr, open)
+		isSanitizer := start == 0 || expr[start-1] != '.'
+		isSanitizer = isSanitizer && end > 0 && slices.ContainsFunc(sanitizers, func(sanitizer string) bool {
+			return matchesFunctionName(callee, sanitizer)
+		})
+		if !isSanitizer {
+			offset = open + 1
+			continue
+		}
+		expr = expr[:start] + "0" + expr[end+1:]
+		offset = start + 1
+	}
+}
+
+// closingParen returns the index of the parenthesis closing the one at
+// open, or -1 if it is unbalanced. Parentheses inside string literals are
+// not counted.
+func closingParen(expr string, open int) int {
+	depth := 0
+	var quote byte
+	for i := open; i < len(expr); i++ {
+		c := expr[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// findLDAPInjections returns every LDAP search whose filter is built from
+// tainted data, ordered by function FQN and line. The receiver must be a
+// python-ldap or ldap3 connection, so unrelated search methods are not
+// flagged. Values escaped by one of the pattern's Sanitizers, inline or
+// before being assigned to a variable, are not tainted.
+func (pr *PatternRegistry) findLDAPInjections(pattern *Pattern, callGraph *core.CallGraph) []*PatternMatchDetails {
+	return findTaintedArguments(pattern, callGraph, "LDAP filter", func(caller string, callSite *core.CallSite, sources sourceLines) string {
+		receiver, method, ok := cutLast(callSite.Target, ".")
+		if !ok || !slices.Contains(pattern.Sinks, method) {
+			return ""
+		}
+		return ldapFilterArgument(caller, receiver, callSite, callGraph, sources)
+	})
+}
+
+// findTaintedArguments returns every call whose argument, as selected by
+// argumentOf ("" for calls that are not sinks), is built from tainted data,
+// ordered by function FQN and line. Calls to the pattern's Sanitizers in
+// the argument are not followed. kind names the argument in each match's
+// Context (e.g., "LDAP filter").
+func findTaintedArguments(
+	pattern *Pattern,
+	callGraph *core.CallGraph,
+	kind string,
+	argumentOf func(caller string, callSite *core.CallSite, sources sourceLines) string,
+) []*PatternMatchDetails {
+	sources := newSourceLines(callGraph)
+	var matches []*PatternMatchDetails
+	for _, caller := range sortedCallers(callGraph) {
+		if _, ok := callGraph.Functions[caller]; !ok {
+			continue
+		}
+		callSites := sortedCallSites(callGraph, caller)
+		for i := range callSites {
+			callSite := &callSites[i]
+			argument := argumentOf(caller, callSite, sources)
+			if argument == "" {
+				continue
+			}
+			source := taintedExpressionSource(caller, callSite, withoutSanitizerCalls(argument, pattern.Sanitizers), callGraph, pattern)
+			if source == "" {
+				continue
+			}
+			matches = append(matches, &PatternMatchDetails{
+				Matched:           true,
+				IsIntraProcedural: true,
+				SourceFQN:         caller,
+				SourceCall:        source,
+				SinkFQN:           caller,
+				SinkCall:          callSite.Target,
+				DataFlowPath:      []string{caller},
+				Context:           kind + " " + argument + " built from request data",
+			})
+		}
+	}
+	return matches
+}
@@ -0,0 +1,42 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLDAPInjection_SearchFilter(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "ldap_injection")
+	registry := NewPatternRegistry()
+	registry.LoadDefaultPatterns()
+	pattern, ok := registry.GetPattern("LDAP-INJECTION-001")
+	require.True(t, ok)
+
+	found := make(map[string]string)
+	for _, match := range registry.findLDAPInjections(pattern, callGraph) {
+		found[match.SinkFQN] = match.SourceCall + ": " + match.Context
+	}
+
+	// Escaped values, a constant filter, and search on an object that is
+	// not an LDAP connection are not flagged.
+	assert.Equal(t, map[string]string{
+		"directory.find_user":  `request.args: LDAP filter f"(uid={username})" built from request data`,
+		"directory.find_group": `request.form: LDAP filter "(cn=" + group + ")" built from request data`,
+	}, found)
+
+	match := registry.MatchPattern(pattern, callGraph)
+	require.NotNil(t, match)
+	assert.True(t, match.Matched)
+	assert.Equal(t, "CWE-90", pattern.CWE)
+}
+
+func TestWithoutSanitizerCalls(t *testing.T) {
+	sanitizers := LDAPFilterEscapers
+	assert.Equal(t, `f"(uid={0})"`, withoutSanitizerCalls(`f"(uid={escape_filter_chars(name)})"`, sanitizers))
+	assert.Equal(t, `0`, withoutSanitizerCalls(`ldap.filter.filter_format("(uid=%s)", [name])`, sanitizers))
+	assert.Equal(t, `"(cn=" + 0 + ")" + group`, withoutSanitizerCalls(`"(cn=" + escape_filter_chars(g(")")) + ")" + group`, sanitizers))
+	assert.Equal(t, `quote(name)`, withoutSanitizerCalls(`quote(name)`, sanitizers))
+	assert.Equal(t, `obj.escape_filter_chars_x(name)`, withoutSanitizerCalls(`obj.escape_filter_chars_x(name)`, sanitizers))
+}
@@ -0,0 +1,102 @@
+package patterns
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// LoggingMethods are the logging module functions and Logger methods whose
+// first argument is the message format string. Logger.log takes the level
+// first and the format second.
+var LoggingMethods = []string{"debug", "info", "warning", "warn", "error", "exception", "critical", "fatal", "log"}
+
+// newlineStripping matches a replace or re.sub call removing carriage
+// returns or newlines, which keeps request data from forging log lines.
+var newlineStripping = regexp.MustCompile(`(\.replace|\bre\.sub)\(\s*[rRbBuU]?["'][^"']*\\[rn]`)
+
+// loggingFormatArgument returns the format string passed to a logging
+// call, or "" if callSite is not a logging call. A logging call is a
+// LoggingMethods function of the logging module or a method on a receiver
+// named like a logger (logger, log, self.logger, app.logger).
+func loggingFormatArgument(callSite *core.CallSite, methods []string) string {
+	receiver, method, ok := cutLast(callSite.Target, ".")
+	if !ok || !slices.Contains(methods, method) {
+		return ""
+	}
+	receiverName := receiver[strings.LastIndex(receiver, ".")+1:]
+	if !strings.HasPrefix(callSite.TargetFQN, "logging.") && !strings.Contains(strings.ToLower(receiverName), "log") {
+		return ""
+	}
+	if method == "log" {
+		return openArgument(callSite, 1, "msg")
+	}
+	return openArgument(callSite, 0, "msg")
+}
+
+// newlinesStripped reports whether a logging format expression has its
+// newlines removed, inline or in the assignment to the variable it names.
+func newlinesStripped(caller string, callSite *core.CallSite, expr string, callGraph *core.CallGraph, sources sourceLines) bool {
+	if newlineStripping.MatchString(expr) {
+		return true
+	}
+	var definition *core.Statement
+	for _, stmt := range callGraph.Statements[caller] {
+		if stmt.Def == expr && int(stmt.LineNumber) < callSite.Location.Line && (definition == nil || stmt.LineNumber > definition.LineNumber) {
+			definition = stmt
+		}
+	}
+	if definition == nil {
+		return false
+	}
+	return newlineStripping.MatchString(sources.line(callSite.Location.File, int(definition.LineNumber)))
+}
+
+// findLogFormats returns every logging call whose format string is built
+// from tainted data, ordered by function FQN and line. Tainted values
+// passed as format arguments, as in logger.info("%s", name), are not
+// flagged, nor is a format string whose newlines were stripped.
+func (pr *PatternRegistry) findLogFormats(pattern *Pattern, callGraph *core.CallGraph) []*PatternMatchDetails {
+	sources := newSourceLines(callGraph)
+	var matches []*PatternMatchDetails
+	for _, caller := range sortedCallers(callGraph) {
+		if _, ok := callGraph.Functions[caller]; !ok {
+			continue
+		}
+		callSites := sortedCallSites(callGraph, caller)
+		for i := range callSites {
+			callSite := &callSites[i]
+			format := loggingFormatArgument(callSite, pattern.Sinks)
+			if format == "" || newlinesStripped(caller, callSite, format, callGraph, sources) {
+				continue
+			}
+
+			source := ""
+			if _, params, isFString := parameterizeFString(format); isFString {
+				for _, param := range params {
+					if source = taintedExpressionSource(caller, callSite, param, callGraph, pattern); source != "" {
+						break
+					}
+				}
+			} else {
+				source = taintedExpressionSource(caller, callSite, format, callGraph, pattern)
+			}
+			if source == "" {
+				continue
+			}
+			matches = append(matches, &PatternMatchDetails{
+				Matched:           true,
+				IsIntraProcedural: true,
+				SourceFQN:         caller,
+				SourceCall:        source,
+				SinkFQN:           caller,
+				SinkCall:          callSite.Target,
+				DataFlowPath:      []string{caller},
+				Context:           "logging format string " + format + " built from request data",
+			})
+		}
+	}
+	return matches
+}
@@ -0,0 +1,54 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogFormat(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "log_format")
+
+	patternRegistry := NewPatternRegistry()
+	patternRegistry.LoadDefaultPatterns()
+	pattern, ok := patternRegistry.GetPattern("LOG-FORMAT-001")
+	require.True(t, ok)
+
+	found := make(map[string]*PatternMatchDetails)
+	for _, match := range patternRegistry.findLogFormats(pattern, callGraph) {
+		found[match.SinkFQN] = match
+	}
+
+	tests := []struct {
+		function string
+		sinkCall string
+		source   string
+	}{
+		{"app.login", "logger.info", "request.form"},
+		{"app.audit", "logging.warning", "request.args"},
+		{"app.track", "current_app.logger.error", "request.args"},
+		{"app.level", "logger.log", "request.args"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.function, func(t *testing.T) {
+			match, ok := found[tt.function]
+			require.True(t, ok, "expected a finding in %s", tt.function)
+			assert.Equal(t, tt.sinkCall, match.SinkCall)
+			assert.Equal(t, tt.source, match.SourceCall)
+		})
+	}
+
+	// Request data passed as a format argument is not interpreted
+	assert.NotContains(t, found, "app.search")
+	// Newlines stripped before logging
+	assert.NotContains(t, found, "app.stripped")
+	assert.NotContains(t, found, "app.substituted")
+	assert.NotContains(t, found, "app.constant")
+	assert.Len(t, found, len(tests))
+
+	match := patternRegistry.MatchPattern(pattern, callGraph)
+	require.True(t, match.Matched)
+	assert.Equal(t, "app.audit", match.SinkFQN)
+	assert.Contains(t, match.Remediation, `logger.info("%s", value)`)
+}
@@ -0,0 +1,212 @@
+package patterns
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/resolution"
+)
+
+// ORMWriteMethods are ORM manager and query methods that write the keyword
+// arguments they receive as model fields, e.g. Model.objects.create(**data)
+// or Model.objects.filter(...).update(**data).
+var ORMWriteMethods = []string{"create", "update", "get_or_create", "update_or_create"}
+
+// ORMPersistMethods persist a model instance built by a constructor call:
+// instance.save() in Django, session.add(instance) in SQLAlchemy.
+var ORMPersistMethods = []string{"save", "add", "merge"}
+
+// MassAssignmentSanitizers are attributes holding only the fields a form or
+// serializer declares, such as form.cleaned_data or
+// serializer.validated_data. Spreading them is safe.
+var MassAssignmentSanitizers = []string{"cleaned_data", "validated_data"}
+
+// fieldAllowlistComprehension matches a dict comprehension that keeps only
+// allowlisted fields: iterating over the allowlist,
+// {f: data[f] for f in FIELDS}, or filtering by it,
+// {k: v for k, v in data.items() if k in FIELDS}.
+var fieldAllowlistComprehension = regexp.MustCompile(
+	`^\{.*\bfor\s+\w+\s+in\s+(\w+|\(.*\)|\[.*\])\s*\}$|^\{.*\bif\s+\w+\s+in\s+(\w+|\(.*\)|\[.*\]|\{.*\})\s*\}$`)
+
+// isFieldAllowlist reports whether an expression names a fixed field list:
+// a literal tuple, list or set, an UPPER_CASE constant, or a name mentioning
+// fields or allowed keys.
+func isFieldAllowlist(expr string) bool {
+	if expr == "" {
+		return false
+	}
+	if strings.ContainsRune("([{", rune(expr[0])) {
+		return true
+	}
+	lower := strings.ToLower(expr)
+	return expr == strings.ToUpper(expr) || strings.Contains(lower, "field") || strings.Contains(lower, "allow")
+}
+
+// isAllowlistedDict reports whether rhs builds a dict from allowlisted
+// fields only.
+func isAllowlistedDict(rhs string) bool {
+	match := fieldAllowlistComprehension.FindStringSubmatch(strings.TrimSpace(rhs))
+	if match == nil {
+		return false
+	}
+	return isFieldAllowlist(match[1]) || isFieldAllowlist(match[2])
+}
+
+// isSanitizedSpread reports whether a spread expression reads a form's or
+// serializer's validated fields.
+func isSanitizedSpread(expr string, sanitizers []string) bool {
+	for _, sanitizer := range sanitizers {
+		if expr == sanitizer || strings.HasSuffix(expr, "."+sanitizer) {
+			return true
+		}
+	}
+	return false
+}
+
+// ormWriteMethod returns the ORM write method a call target invokes
+// ("create" for "Profile.objects.create"), or "" if it is not an ORM write.
+func ormWriteMethod(callSite *core.CallSite) string {
+	isORM, _, method := resolution.IsORMPattern(callSite.Target)
+	if !isORM {
+		return ""
+	}
+	method = method[strings.LastIndex(method, ".")+1:]
+	if !slices.Contains(ORMWriteMethods, method) {
+		return ""
+	}
+	return method
+}
+
+// isPersistedInstance reports whether the instance assigned to variable at
+// line is later saved (instance.save()) or added to a session
+// (session.add(instance)) in caller.
+func isPersistedInstance(caller, variable string, line int, callGraph *core.CallGraph) bool {
+	if variable == "" {
+		return false
+	}
+	for _, callSite := range callGraph.CallSites[caller] {
+		if callSite.Location.Line <= line {
+			continue
+		}
+		receiver, method, ok := cutLast(callSite.Target, ".")
+		if !ok || !slices.Contains(ORMPersistMethods, method) {
+			continue
+		}
+		if receiver == variable {
+			return true
+		}
+		for _, arg := range callSite.Arguments {
+			if arg.Value == variable {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// cutLast slices s around the last instance of sep.
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+// findMassAssignments returns every ORM write that receives tainted request
+// data as a **mapping spread, ordered by function FQN and line. A write is a
+// manager or query method in ORMWriteMethods, or a constructor call whose
+// result is later persisted with one of ORMPersistMethods.
+func (pr *PatternRegistry) findMassAssignments(pattern *Pattern, callGraph *core.CallGraph) []*PatternMatchDetails {
+	sources := newSourceLines(callGraph)
+
+	var matches []*PatternMatchDetails
+	for _, caller := range sortedCallers(callGraph) {
+		if _, ok := callGraph.Functions[caller]; !ok {
+			continue
+		}
+		for _, callSite := range sortedCallSites(callGraph, caller) {
+			for _, arg := range callSite.Arguments {
+				if !arg.IsDictSpread {
+					continue
+				}
+				if ormWriteMethod(&callSite) == "" {
+					variable := definedAtLine(callGraph.Statements[caller], callSite.Location.Line)
+					if !isPersistedInstance(caller, variable, callSite.Location.Line, callGraph) {
+						continue
+					}
+				}
+
+				spread := strings.TrimPrefix(arg.Value, "**")
+				source := pr.taintedSpreadSource(caller, &callSite, spread, callGraph, pattern, sources)
+				if source == "" {
+					continue
+				}
+				matches = append(matches, &PatternMatchDetails{
+					Matched:           true,
+					IsIntraProcedural: true,
+					SourceFQN:         caller,
+					SourceCall:        source,
+					SinkFQN:           caller,
+					SinkCall:          callSite.Target,
+					DataFlowPath:      []string{caller},
+					Context:           "**" + spread + " spread into " + callSite.Target,
+				})
+			}
+		}
+	}
+	return matches
+}
+
+// taintedSpreadSource returns the source whose data reaches a spread
+// mapping, or "" if the mapping is untainted or limited to allowlisted
+// fields.
+func (pr *PatternRegistry) taintedSpreadSource(
+	caller string,
+	callSite *core.CallSite,
+	spread string,
+	callGraph *core.CallGraph,
+	pattern *Pattern,
+	sources sourceLines,
+) string {
+	if isSanitizedSpread(spread, pattern.Sanitizers) {
+		return ""
+	}
+
+	// Model.objects.create(**request.POST)
+	if source := matchingSource(spread, pattern.Sources); source != "" {
+		return source
+	}
+	if !isIdentifier(spread) {
+		return ""
+	}
+
+	// data = {f: request.POST[f] for f in FIELDS}, or data = form.cleaned_data
+	if def := lastDefinitionBefore(callGraph.Statements[caller], spread, callSite.Location.Line); def != nil {
+		if isSanitizedSpread(def.AttributeAccess, pattern.Sanitizers) {
+			return ""
+		}
+		if rhs := assignedLiteral(strings.TrimSpace(sources.line(callSite.Location.File, int(def.LineNumber))), spread); isAllowlistedDict(rhs) {
+			return ""
+		}
+	}
+
+	// data = request.POST.dict(); Model.objects.create(**data)
+	return taintedArgumentSource(caller, callSite, spread, callGraph, pattern)
+}
+
+// lastDefinitionBefore returns the last statement, including nested ones,
+// that assigns name before line.
+func lastDefinitionBefore(statements []*core.Statement, name string, line int) *core.Statement {
+	var last *core.Statement
+	for _, stmt := range statements {
+		for _, inner := range stmt.AllStatements() {
+			if inner.Def == name && int(inner.LineNumber) < line &&
+				(last == nil || inner.LineNumber > last.LineNumber) {
+				last = inner
+			}
+		}
+	}
+	return last
+}
@@ -0,0 +1,61 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMassAssignment_DjangoViews(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "mass_assignment")
+	registry := NewPatternRegistry()
+	registry.LoadDefaultPatterns()
+	pattern, ok := registry.GetPattern("MASS-ASSIGNMENT-001")
+	require.True(t, ok)
+
+	found := make(map[string]string)
+	for _, match := range registry.findMassAssignments(pattern, callGraph) {
+		found[match.SinkFQN] = match.SourceCall + ": " + match.Context
+	}
+
+	// The allowlist comprehension, cleaned_data, explicit keyword arguments,
+	// and a spread into a non-ORM call are not flagged.
+	assert.Equal(t, map[string]string{
+		"views.create_profile": "request.POST: **request.POST spread into Profile.objects.create",
+		"views.update_profile": "request.POST: **data spread into Profile.objects.filter.update",
+		"views.build_profile":  "request.POST: **request.POST.dict() spread into Profile",
+	}, found)
+}
+
+func TestMassAssignment_MatchPattern(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "mass_assignment")
+	registry := NewPatternRegistry()
+	registry.LoadDefaultPatterns()
+	pattern, ok := registry.GetPattern("MASS-ASSIGNMENT-001")
+	require.True(t, ok)
+
+	match := registry.MatchPattern(pattern, callGraph)
+	require.NotNil(t, match)
+	assert.True(t, match.Matched)
+	assert.Equal(t, "views.build_profile", match.SinkFQN)
+}
+
+func TestIsAllowlistedDict(t *testing.T) {
+	tests := []struct {
+		rhs  string
+		want bool
+	}{
+		{"{f: request.POST[f] for f in PROFILE_FIELDS}", true},
+		{"{f: data[f] for f in (\"name\", \"bio\")}", true},
+		{"{k: v for k, v in request.POST.items() if k in allowed_keys}", true},
+		{"{k: v for k, v in request.POST.items()}", false},
+		{"{k: v for k, v in request.POST.items() if v}", false},
+		{"request.POST.dict()", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rhs, func(t *testing.T) {
+			assert.Equal(t, tt.want, isAllowlistedDict(tt.rhs))
+		})
+	}
+}
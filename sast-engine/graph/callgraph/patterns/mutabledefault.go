@@ -0,0 +1,65 @@
+package patterns
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// MutableDefaultConstructors are calls that build a new mutable container.
+// As a parameter default they run once, at definition time, like a literal.
+var MutableDefaultConstructors = []string{"list()", "dict()", "set()", "bytearray()"}
+
+// mutableDefault returns the name and default of a parameter whose default
+// is a list, dict, or set literal (or comprehension) or an empty mutable
+// constructor call. The default is evaluated once and shared by every
+// call, so mutations leak between calls. ok is false for other parameters.
+//
+//	"items=[]"          → "items", "[]", true
+//	"opts: dict = {}"   → "opts", "{}", true
+//	"items=None"        → false
+func mutableDefault(param string) (name, value string, ok bool) {
+	name, value, found := strings.Cut(param, "=")
+	if !found {
+		return "", "", false
+	}
+	name, _, _ = strings.Cut(name, ":")
+	name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+	if strings.HasPrefix(value, "[") || strings.HasPrefix(value, "{") ||
+		slices.Contains(MutableDefaultConstructors, strings.ReplaceAll(value, " ", "")) {
+		return name, value, true
+	}
+	return "", "", false
+}
+
+// findMutableDefaults returns a match for every parameter with a mutable
+// default (see mutableDefault), ordered by function FQN and parameter
+// position. The parameter metadata of each function is enough; no data
+// flow is involved. SinkFQN is the function and Context names the
+// parameter.
+func (pr *PatternRegistry) findMutableDefaults(pattern *Pattern, callGraph *core.CallGraph) []*PatternMatchDetails {
+	functions := make([]string, 0, len(callGraph.Functions))
+	for fqn := range callGraph.Functions {
+		functions = append(functions, fqn)
+	}
+	slices.Sort(functions)
+
+	var matches []*PatternMatchDetails
+	for _, fqn := range functions {
+		for _, param := range callGraph.Functions[fqn].MethodArgumentsValue {
+			name, value, ok := mutableDefault(param)
+			if !ok {
+				continue
+			}
+			matches = append(matches, &PatternMatchDetails{
+				Matched:           true,
+				IsIntraProcedural: true,
+				SinkFQN:           fqn,
+				DataFlowPath:      []string{fqn},
+				Context:           "parameter " + name + " defaults to mutable " + value + ", shared across calls",
+			})
+		}
+	}
+	return matches
+}
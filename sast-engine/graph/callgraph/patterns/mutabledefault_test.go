@@ -0,0 +1,57 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMutableDefault(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "mutable_default")
+
+	registry := NewPatternRegistry()
+	registry.LoadDefaultPatterns()
+	pattern, ok := registry.GetPattern("MUTABLE-DEFAULT-001")
+	require.True(t, ok)
+
+	var found [][2]string
+	for _, match := range registry.findMutableDefaults(pattern, callGraph) {
+		found = append(found, [2]string{match.SinkFQN, match.Context})
+	}
+	assert.Equal(t, [][2]string{
+		{"app.Cache.__init__", "parameter entries defaults to mutable {}, shared across calls"},
+		{"app.append_item", "parameter items defaults to mutable [], shared across calls"},
+		{"app.merge", "parameter overrides defaults to mutable {}, shared across calls"},
+		{"app.merge", "parameter seen defaults to mutable set(), shared across calls"},
+	}, found, "None, string, and tuple defaults are not flagged")
+
+	match := registry.MatchPattern(pattern, callGraph)
+	require.True(t, match.Matched)
+	assert.Equal(t, "app.Cache.__init__", match.SinkFQN)
+}
+
+func TestMutableDefaultParameter(t *testing.T) {
+	tests := []struct {
+		param string
+		name  string
+		value string
+		ok    bool
+	}{
+		{"items=[]", "items", "[]", true},
+		{"opts: dict = {}", "opts", "{}", true},
+		{"tags={1, 2}", "tags", "{1, 2}", true},
+		{"squares=[i * i for i in range(3)]", "squares", "[i * i for i in range(3)]", true},
+		{"buf=bytearray()", "buf", "bytearray()", true},
+		{"items=None", "", "", false},
+		{"sep='[]'", "", "", false},
+		{"size=(1, 2)", "", "", false},
+		{"items", "", "", false},
+	}
+	for _, tt := range tests {
+		name, value, ok := mutableDefault(tt.param)
+		assert.Equal(t, tt.ok, ok, tt.param)
+		assert.Equal(t, tt.name, name, tt.param)
+		assert.Equal(t, tt.value, value, tt.param)
+	}
+}
@@ -0,0 +1,254 @@
+package patterns
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/resolution"
+)
+
+// PyMongoQueryMethods are collection methods whose first argument is a
+// MongoDB query document (or aggregation pipeline). A query built from
+// request data lets the client supply operators such as $ne, $gt, or
+// $where, changing which documents match.
+var PyMongoQueryMethods = []string{
+	"find", "find_one",
+	"find_one_and_delete", "find_one_and_replace", "find_one_and_update",
+	"update", "update_one", "update_many", "replace_one",
+	"delete_one", "delete_many", "remove",
+	"count_documents", "aggregate",
+}
+
+// PyMongoModules are the packages whose clients, databases, and collections
+// are MongoDB handles. Attribute or item access on a client yields a
+// database, and on a database a collection (client.shop.users).
+var PyMongoModules = []string{"pymongo", "motor", "flask_pymongo"}
+
+// nosqlQueryKeywords are the parameter names of the query argument.
+var nosqlQueryKeywords = []string{"filter", "spec", "pipeline"}
+
+// maxHandleDepth bounds how many assignments isModuleHandle follows.
+const maxHandleDepth = 5
+
+// stringLiteral matches a single- or double-quoted Python string literal.
+var stringLiteral = regexp.MustCompile(`"(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*'`)
+
+// variableReference matches a name that is not an attribute or keyword
+// argument (the name in x, but not in obj.x).
+var variableReference = regexp.MustCompile(`(^|[^.\w])([A-Za-z_]\w*)`)
+
+// isModuleType reports whether a type or call FQN belongs to one of modules.
+func isModuleType(fqn string, modules []string) bool {
+	for _, module := range modules {
+		if strings.HasPrefix(fqn, module+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// isModuleHandle reports whether expr, evaluated in scope at line, is an
+// object of one of modules, such as a PyMongo client, database, or
+// collection. The expression must start with a call into modules
+// (MongoClient().shop.users, ldap.initialize(uri)) or with a variable the
+// type engine inferred as a type of modules. Variables without a type are followed to their
+// assignment (db = client.shop), in the function or at module level.
+func isModuleHandle(modules []string, scope, expr, file string, line, depth int, callGraph *core.CallGraph, sources sourceLines) bool {
+	if depth > maxHandleDepth {
+		return false
+	}
+	// A dotted call into modules: ldap.initialize(uri)
+	if callee, _, isCall := strings.Cut(expr, "("); isCall && strings.Contains(callee, ".") {
+		if callSite := callSiteAt(callGraph, file, line, callee); callSite != nil && isModuleType(callSite.TargetFQN, modules) {
+			return true
+		}
+	}
+	root := expr
+	if idx := strings.IndexAny(expr, ".[("); idx >= 0 {
+		root = expr[:idx]
+		if expr[idx] == '(' {
+			callSite := callSiteAt(callGraph, file, line, root)
+			return callSite != nil && isModuleType(callSite.TargetFQN, modules)
+		}
+	}
+	if !isIdentifier(root) {
+		return false
+	}
+
+	engine, _ := callGraph.TypeEngine.(*resolution.TypeInferenceEngine)
+	if engine != nil {
+		if functionScope := engine.GetScope(scope); functionScope != nil {
+			//nolint:gosec // line numbers are positive
+			if binding := functionScope.GetVariableAtLine(root, uint32(line)); binding != nil && binding.Type != nil && isModuleType(binding.Type.TypeFQN, modules) {
+				return true
+			}
+		}
+		if engine.Registry != nil {
+			if modulePath, ok := engine.Registry.FileToModule[file]; ok {
+				if info := engine.GetModuleVariableType(modulePath, root, 0); info != nil && isModuleType(info.TypeFQN, modules) {
+					return true
+				}
+			}
+		}
+	}
+
+	// users = db.users; users.find(...)
+	if def := lastDefinitionBefore(callGraph.Statements[scope], root, line); def != nil {
+		rhs := assignedLiteral(strings.TrimSpace(sources.line(file, int(def.LineNumber))), root)
+		return rhs != "" && isModuleHandle(modules, scope, rhs, file, int(def.LineNumber), depth+1, callGraph, sources)
+	}
+
+	// db = client.shop at module level
+	for lineNumber := line - 1; lineNumber >= 1; lineNumber-- {
+		text := sources.line(file, lineNumber)
+		if text == "" || text[0] == ' ' || text[0] == '\t' {
+			continue
+		}
+		if rhs := assignedLiteral(text, root); rhs != "" {
+			return isModuleHandle(modules, scope, rhs, file, lineNumber, depth+1, callGraph, sources)
+		}
+	}
+	return false
+}
+
+// callSiteAt returns the call of target at file and line, or nil. Module
+// level calls are recorded under the module, so all callers are searched.
+func callSiteAt(callGraph *core.CallGraph, file string, line int, target string) *core.CallSite {
+	for _, caller := range sortedCallers(callGraph) {
+		for i, callSite := range callGraph.CallSites[caller] {
+			if callSite.Location.File == file && callSite.Location.Line == line && callSite.Target == target {
+				return &callGraph.CallSites[caller][i]
+			}
+		}
+	}
+	return nil
+}
+
+// nosqlQueryArgument returns the query document passed to a query method:
+// a filter-like keyword argument if present, otherwise the first positional
+// argument.
+func nosqlQueryArgument(callSite *core.CallSite) string {
+	var positional string
+	for _, arg := range callSite.Arguments {
+		keyword, value, ok := splitKeywordArgument(arg.Value)
+		if !ok {
+			if positional == "" && arg.Position == 0 {
+				positional = arg.Value
+			}
+			continue
+		}
+		if slices.Contains(nosqlQueryKeywords, keyword) {
+			return value
+		}
+	}
+	return positional
+}
+
+// findNoSQLInjections returns every PyMongo query whose query document is
+// tainted as a whole, or holds a $where clause built from tainted data,
+// ordered by function FQN and line. The receiver must be a PyMongo handle
+// (see isModuleHandle), so unrelated find methods are not flagged. Tainted
+// values inside a literal document are only flagged under $where, where
+// they are evaluated as JavaScript.
+func (pr *PatternRegistry) findNoSQLInjections(pattern *Pattern, callGraph *core.CallGraph) []*PatternMatchDetails {
+	sources := newSourceLines(callGraph)
+
+	var matches []*PatternMatchDetails
+	for _, caller := range sortedCallers(callGraph) {
+		if _, ok := callGraph.Functions[caller]; !ok {
+			continue
+		}
+		callSites := sortedCallSites(callGraph, caller)
+		for i := range callSites {
+			callSite := &callSites[i]
+			receiver, method, ok := cutLast(callSite.Target, ".")
+			if !ok || !slices.Contains(pattern.Sinks, method) {
+				continue
+			}
+			query := nosqlQueryArgument(callSite)
+			if query == "" {
+				continue
+			}
+			if !isModuleHandle(PyMongoModules, caller, receiver, callSite.Location.File, callSite.Location.Line, 0, callGraph, sources) {
+				continue
+			}
+
+			source, context := "", ""
+			switch {
+			case strings.Contains(query, "$where"):
+				source = taintedExpressionSource(caller, callSite, query, callGraph, pattern)
+				context = "$where built from tainted data in " + callSite.Target
+			default:
+				source = matchingSource(query, pattern.Sources)
+				if source == "" && isIdentifier(query) {
+					source = taintedArgumentSource(caller, callSite, query, callGraph, pattern)
+				}
+				context = query + " used as query in " + callSite.Target
+			}
+			if source == "" {
+				continue
+			}
+			matches = append(matches, &PatternMatchDetails{
+				Matched:           true,
+				IsIntraProcedural: true,
+				SourceFQN:         caller,
+				SourceCall:        source,
+				SinkFQN:           caller,
+				SinkCall:          callSite.Target,
+				DataFlowPath:      []string{caller},
+				Context:           context,
+			})
+		}
+	}
+	return matches
+}
+
+// taintedExpressionSource returns the source whose data reaches any part of
+// an expression: a source read directly inside it, or a variable it
+// references, including inside f-string replacement fields. String literals
+// are otherwise ignored.
+func taintedExpressionSource(caller string, callSite *core.CallSite, expr string, callGraph *core.CallGraph, pattern *Pattern) string {
+	for _, field := range fStringFields(expr) {
+		if source := taintedExpressionSource(caller, callSite, field, callGraph, pattern); source != "" {
+			return source
+		}
+	}
+	code := stringLiteral.ReplaceAllString(expr, `""`)
+	for _, source := range pattern.Sources {
+		if strings.Contains(code, source) {
+			return source
+		}
+	}
+	for _, match := range variableReference.FindAllStringSubmatch(code, -1) {
+		if source := taintedArgumentSource(caller, callSite, match[2], callGraph, pattern); source != "" {
+			return source
+		}
+	}
+	return ""
+}
+
+// fStringFields returns the expressions interpolated by the f-strings in an
+// expression, e.g. "x" for "WHERE id = " + f"{x}".
+func fStringFields(expr string) []string {
+	var fields []string
+	for _, match := range stringLiteral.FindAllStringIndex(expr, -1) {
+		start := match[0]
+		for start > 0 && start > match[0]-2 && strings.ContainsRune("fFrRbBuU", rune(expr[start-1])) {
+			start--
+		}
+		if start > 0 && isIdentifierByte(expr[start-1]) {
+			continue
+		}
+		if _, params, ok := parameterizeFString(expr[start:match[1]]); ok {
+			fields = append(fields, params...)
+		}
+	}
+	return fields
+}
+
+// isIdentifierByte reports whether c can appear in a Python identifier.
+func isIdentifierByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
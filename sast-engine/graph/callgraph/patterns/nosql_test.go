@@ -0,0 +1,42 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoSQLInjection_PyMongo(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "nosql_injection")
+	registry := NewPatternRegistry()
+	registry.LoadDefaultPatterns()
+	pattern, ok := registry.GetPattern("NOSQL-INJECTION-001")
+	require.True(t, ok)
+
+	found := make(map[string]string)
+	for _, match := range registry.findNoSQLInjections(pattern, callGraph) {
+		found[match.SinkFQN] = match.SourceCall + ": " + match.Context
+	}
+
+	// Equality on a route parameter, a constant query, and find on a
+	// non-PyMongo object are not flagged.
+	assert.Equal(t, map[string]string{
+		"app.search_users": "request.json: request.json used as query in users.find",
+		"app.login":        "request.get_json: credentials used as query in collection.find_one",
+		"app.where":        "request.args: $where built from tainted data in db.users.find",
+	}, found)
+}
+
+func TestNoSQLInjection_MatchPattern(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "nosql_injection")
+	registry := NewPatternRegistry()
+	registry.LoadDefaultPatterns()
+	pattern, ok := registry.GetPattern("NOSQL-INJECTION-001")
+	require.True(t, ok)
+
+	match := registry.MatchPattern(pattern, callGraph)
+	require.NotNil(t, match)
+	assert.True(t, match.Matched)
+	assert.Equal(t, "app.login", match.SinkFQN)
+}
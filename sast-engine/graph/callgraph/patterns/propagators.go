@@ -0,0 +1,38 @@
+package patterns
+
+import (
+	"fmt"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/analysis/taint"
+	"gopkg.in/yaml.v3"
+)
+
+// propagatorConfig is the YAML rule config for taint propagators:
+//
+//	propagators:
+//	  - fqn: copy.deepcopy
+//	    fromArg: 0
+//	    toReturn: true
+//	  - fqn: str.strip
+//	    fromArg: -1 # receiver
+//	    toReturn: true
+type propagatorConfig struct {
+	Propagators []taint.Propagator `yaml:"propagators"`
+}
+
+// LoadPropagatorConfig adds the propagators in a YAML rule config to the
+// registry. Once any are loaded, taint only passes through calls they
+// list (see taint.Propagator).
+func (pr *PatternRegistry) LoadPropagatorConfig(data []byte) error {
+	var config propagatorConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("failed to parse propagator config: %w", err)
+	}
+	for _, propagator := range config.Propagators {
+		if propagator.FQN == "" {
+			return fmt.Errorf("propagator missing fqn")
+		}
+		pr.Propagators = append(pr.Propagators, propagator)
+	}
+	return nil
+}
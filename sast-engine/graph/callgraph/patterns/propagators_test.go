@@ -0,0 +1,53 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/analysis/taint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPropagatorConfig_OpenRedirect(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "propagators")
+
+	// Without propagators, every call passes taint through.
+	registry := NewPatternRegistry()
+	registry.LoadDefaultPatterns()
+	pattern, ok := registry.GetPattern("OPEN-REDIRECT-001")
+	require.True(t, ok)
+	assert.ElementsMatch(t, []string{"views.copied_redirect", "views.normalized_redirect"},
+		redirectSinkFQNs(registry.findOpenRedirects(pattern, callGraph)))
+
+	// With a config, only listed calls do.
+	require.NoError(t, registry.LoadPropagatorConfig([]byte(`
+propagators:
+  - fqn: copy.deepcopy
+    fromArg: 0
+    toReturn: true
+`)))
+	assert.Equal(t, []taint.Propagator{{FQN: "copy.deepcopy", FromArg: 0, ToReturn: true}}, registry.Propagators)
+
+	match := registry.MatchPattern(pattern, callGraph)
+	require.True(t, match.Matched)
+	assert.Equal(t, "views.copied_redirect", match.SinkFQN)
+
+	withPropagators := *pattern
+	withPropagators.Propagators = registry.Propagators
+	assert.Equal(t, []string{"views.copied_redirect"}, redirectSinkFQNs(registry.findOpenRedirects(&withPropagators, callGraph)))
+	assert.Empty(t, pattern.Propagators, "registry propagators do not modify the pattern")
+}
+
+func TestLoadPropagatorConfig_Invalid(t *testing.T) {
+	registry := NewPatternRegistry()
+	assert.Error(t, registry.LoadPropagatorConfig([]byte("propagators: [unclosed")))
+	assert.Error(t, registry.LoadPropagatorConfig([]byte("propagators:\n  - fromArg: 0\n")))
+}
+
+func redirectSinkFQNs(matches []*PatternMatchDetails) []string {
+	fqns := make([]string, 0, len(matches))
+	for _, match := range matches {
+		fqns = append(fqns, match.SinkFQN)
+	}
+	return fqns
+}
@@ -0,0 +1,281 @@
+package patterns
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/analysis/taint"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/resolution"
+)
+
+// redirectFunctions maps a framework name (as returned by GetFrameworkName)
+// to the functions and response classes that redirect to a URL argument.
+var redirectFunctions = map[string][]string{
+	"Django":    {"redirect", "HttpResponseRedirect", "HttpResponsePermanentRedirect"},
+	"Flask":     {"redirect"},
+	"FastAPI":   {"RedirectResponse"},
+	"Starlette": {"RedirectResponse"},
+}
+
+// redirectURLKeywords are the parameter names redirect functions use for the
+// target URL: Django redirect(to=...), HttpResponseRedirect(redirect_to=...),
+// Flask redirect(location=...), RedirectResponse(url=...).
+var redirectURLKeywords = []string{"to", "redirect_to", "location", "url"}
+
+// RedirectSanitizers are validators that check a redirect target stays on an
+// allowed host. They return a boolean and are used as guards, so a redirect
+// is considered safe when its URL was passed to one earlier in the function.
+var RedirectSanitizers = []string{
+	"url_has_allowed_host_and_scheme",
+	"is_safe_url",
+}
+
+// RedirectFunctions returns the redirect functions for a framework name
+// (e.g., "Django", "Flask"). Returns nil for frameworks without a known
+// redirect API.
+func RedirectFunctions(framework string) []string {
+	return redirectFunctions[framework]
+}
+
+// allRedirectFunctions returns the redirect functions of every framework,
+// sorted and without duplicates.
+func allRedirectFunctions() []string {
+	var all []string
+	for _, functions := range redirectFunctions {
+		all = append(all, functions...)
+	}
+	slices.Sort(all)
+	return slices.Compact(all)
+}
+
+// isRedirectCall reports whether a call site is a framework redirect.
+// The framework is detected from the resolved target, so a project-local
+// function that happens to be named redirect is not treated as a sink.
+func isRedirectCall(callSite *core.CallSite) bool {
+	framework := GetFrameworkName(callSite.TargetFQN)
+	for _, function := range RedirectFunctions(framework) {
+		if matchesFunctionName(callSite.TargetFQN, function) {
+			return true
+		}
+	}
+	return false
+}
+
+// redirectURLArgument returns the expression passed as the redirect target:
+// a url-like keyword argument if present, otherwise the first positional
+// argument. Returns "" if the call has no URL argument.
+func redirectURLArgument(callSite *core.CallSite) string {
+	var positional string
+	for _, arg := range callSite.Arguments {
+		keyword, value, ok := splitKeywordArgument(arg.Value)
+		if !ok {
+			if positional == "" && arg.Position == 0 {
+				positional = arg.Value
+			}
+			continue
+		}
+		if slices.Contains(redirectURLKeywords, keyword) {
+			return value
+		}
+	}
+	return positional
+}
+
+// splitKeywordArgument splits "name=value" into its parts.
+// Returns ok=false for positional arguments.
+func splitKeywordArgument(arg string) (string, string, bool) {
+	name, value, ok := strings.Cut(arg, "=")
+	if !ok || !isIdentifier(name) || strings.HasPrefix(value, "=") {
+		return "", "", false
+	}
+	return name, strings.TrimSpace(value), true
+}
+
+// matchingSource returns the source an expression reads directly, such as
+// request.GET["next"] or request.args.get("next"), or "" if none.
+func matchingSource(expr string, sources []string) string {
+	cleanExpr := expr
+	if idx := strings.IndexAny(expr, "[("); idx >= 0 {
+		cleanExpr = expr[:idx]
+	}
+	for _, source := range sources {
+		if matchesFunctionName(cleanExpr, source) {
+			return source
+		}
+	}
+	return ""
+}
+
+// isIdentifier reports whether expr is a plain Python variable name.
+func isIdentifier(expr string) bool {
+	if expr == "" || (expr[0] >= '0' && expr[0] <= '9') {
+		return false
+	}
+	for _, r := range expr {
+		if r != '_' && (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// findOpenRedirects returns every open redirect in the call graph, ordered by
+// function FQN and redirect line.
+func (pr *PatternRegistry) findOpenRedirects(pattern *Pattern, callGraph *core.CallGraph) []*PatternMatchDetails {
+	var matches []*PatternMatchDetails
+	for _, caller := range sortedCallers(callGraph) {
+		callSites := sortedCallSites(callGraph, caller)
+		for i := range callSites {
+			callSite := &callSites[i]
+			if !isRedirectCall(callSite) {
+				continue
+			}
+			source := pr.taintedRedirectSource(caller, callSite, callGraph, pattern)
+			if source == "" {
+				continue
+			}
+			matches = append(matches, &PatternMatchDetails{
+				Matched:           true,
+				IsIntraProcedural: true,
+				SourceFQN:         caller,
+				SourceCall:        source,
+				SinkFQN:           caller,
+				SinkCall:          callSite.TargetFQN,
+				DataFlowPath:      []string{caller},
+			})
+		}
+	}
+	return matches
+}
+
+// taintedRedirectSource returns the source whose data reaches the URL
+// argument of a redirect call, or "" if the URL is untainted or was checked
+// by a redirect sanitizer.
+func (pr *PatternRegistry) taintedRedirectSource(
+	caller string,
+	callSite *core.CallSite,
+	callGraph *core.CallGraph,
+	pattern *Pattern,
+) string {
+	urlArg := redirectURLArgument(callSite)
+	if urlArg == "" {
+		return ""
+	}
+
+	// redirect(request.GET["next"])
+	if source := matchingSource(urlArg, pattern.Sources); source != "" {
+		return source
+	}
+
+	if !isIdentifier(urlArg) || redirectURLValidated(caller, urlArg, callSite, callGraph, pattern) {
+		return ""
+	}
+
+	// next_url = request.GET["next"]; ...; redirect(next_url)
+	return taintedArgumentSource(caller, callSite, urlArg, callGraph, pattern)
+}
+
+// taintedArgumentSource returns the source whose data reaches argVar at a
+// call site, or "" if none does. Only statements before the call are
+// analyzed, followed by a synthetic sink that uses nothing but argVar, so
+// taint in other arguments of the same call does not count. Parameters of
+// route handlers are seeded as sources.
+func taintedArgumentSource(
+	caller string,
+	callSite *core.CallSite,
+	argVar string,
+	callGraph *core.CallGraph,
+	pattern *Pattern,
+) string {
+	return taintedArgumentSourceIn(caller, callSite, argVar, callGraph.Statements[caller], callGraph, pattern)
+}
+
+// taintedArgumentSourceIn is taintedArgumentSource over the given statements
+// of caller instead of those of the call graph.
+func taintedArgumentSourceIn(
+	caller string,
+	callSite *core.CallSite,
+	argVar string,
+	callerStatements []*core.Statement,
+	callGraph *core.CallGraph,
+	pattern *Pattern,
+) string {
+	sinkLine := uint32(callSite.Location.Line) //nolint:gosec
+
+	// Route handler parameters are filled from the request
+	statements := resolution.RouteSourceStatements(callGraph.Functions[caller])
+	for _, stmt := range callerStatements {
+		if stmt.LineNumber < sinkLine {
+			statements = append(statements, stmt)
+		}
+	}
+	statements = append(statements, &core.Statement{
+		Type:       core.StatementTypeCall,
+		LineNumber: sinkLine,
+		CallTarget: callSite.TargetFQN,
+		Uses:       []string{argVar},
+	})
+
+	summary := taint.AnalyzeIntraProceduralTaintWithPropagators(
+		caller,
+		statements,
+		core.BuildDefUseChains(statements),
+		pattern.Sources,
+		[]string{callSite.TargetFQN},
+		pattern.Sanitizers,
+		pattern.Propagators,
+	)
+	for _, detection := range summary.Detections {
+		if detection.SinkLine == sinkLine {
+			return sourceAtLine(statements, detection.SourceLine, pattern.Sources)
+		}
+	}
+	return ""
+}
+
+// redirectURLValidated reports whether urlVar is passed to a sanitizer in
+// caller before the redirect. Validators such as
+// url_has_allowed_host_and_scheme guard an if statement rather than
+// returning a cleaned value, so this is checked on call sites.
+func redirectURLValidated(caller, urlVar string, redirect *core.CallSite, callGraph *core.CallGraph, pattern *Pattern) bool {
+	for _, callSite := range callGraph.CallSites[caller] {
+		if callSite.Location.Line > redirect.Location.Line {
+			continue
+		}
+		isSanitizer := false
+		for _, sanitizer := range pattern.Sanitizers {
+			if matchesFunctionName(callSite.TargetFQN, sanitizer) || matchesFunctionName(callSite.Target, sanitizer) {
+				isSanitizer = true
+				break
+			}
+		}
+		if !isSanitizer {
+			continue
+		}
+		for _, arg := range callSite.Arguments {
+			if arg.IsVariable && arg.Value == urlVar {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sourceAtLine returns the source read by the statement at line, falling
+// back to the statement's call target.
+func sourceAtLine(statements []*core.Statement, line uint32, sources []string) string {
+	for _, stmt := range statements {
+		if stmt.LineNumber != line {
+			continue
+		}
+		if source := matchingSource(stmt.CallTarget, sources); source != "" {
+			return source
+		}
+		if source := matchingSource(stmt.AttributeAccess, sources); source != "" {
+			return source
+		}
+		return stmt.CallTarget
+	}
+	return ""
+}
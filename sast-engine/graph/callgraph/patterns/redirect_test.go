@@ -0,0 +1,122 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadOpenRedirectPattern(t *testing.T) (*PatternRegistry, *Pattern) {
+	t.Helper()
+	registry := NewPatternRegistry()
+	registry.LoadDefaultPatterns()
+	pattern, ok := registry.GetPattern("OPEN-REDIRECT-001")
+	require.True(t, ok)
+	return registry, pattern
+}
+
+func TestOpenRedirect_Fixture(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "open_redirect")
+	registry, pattern := loadOpenRedirectPattern(t)
+
+	found := make(map[string]*PatternMatchDetails)
+	for _, match := range registry.findOpenRedirects(pattern, callGraph) {
+		found[match.SinkFQN] = match
+	}
+
+	tests := []struct {
+		function string
+		source   string
+		sink     string
+	}{
+		{"views.login_redirect", "request.GET", "django.shortcuts.redirect"},
+		{"views.logout_redirect", "request.GET", "django.http.HttpResponseRedirect"},
+		{"flask_app.flask_next", "request.args", "flask.redirect"},
+		{"flask_app.flask_keyword", "request.args", "flask.redirect"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.function, func(t *testing.T) {
+			match, ok := found[tt.function]
+			require.True(t, ok, "%s should flag", tt.function)
+			assert.True(t, match.IsIntraProcedural)
+			assert.Equal(t, tt.source, match.SourceCall)
+			assert.Equal(t, tt.sink, match.SinkCall)
+		})
+	}
+
+	// Validated URL, taint only in a non-URL argument, and a project-local
+	// redirect helper are not findings.
+	assert.NotContains(t, found, "views.safe_login_redirect")
+	assert.NotContains(t, found, "views.fixed_redirect")
+	assert.NotContains(t, found, "helpers.log_next")
+	assert.Len(t, found, len(tests))
+}
+
+func TestOpenRedirect_MatchPattern(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "open_redirect")
+	registry, pattern := loadOpenRedirectPattern(t)
+
+	match := registry.MatchPattern(pattern, callGraph)
+	require.True(t, match.Matched)
+	assert.Equal(t, "flask_app.flask_keyword", match.SinkFQN, "first match in FQN order")
+
+	assert.Equal(t, PatternTypeOpenRedirect, pattern.Type)
+	assert.Equal(t, "CWE-601", pattern.CWE)
+	assert.Contains(t, pattern.Sanitizers, "url_has_allowed_host_and_scheme")
+}
+
+func TestOpenRedirect_NoFrameworkNoMatch(t *testing.T) {
+	registry, pattern := loadOpenRedirectPattern(t)
+	callGraph := core.NewCallGraph()
+	callGraph.AddCallSite("app.view", core.CallSite{
+		Target:    "redirect",
+		TargetFQN: "app.redirect",
+		Location:  core.Location{File: "/app.py", Line: 3},
+		Arguments: []core.Argument{{Value: `request.GET["next"]`, Position: 0}},
+	})
+
+	assert.False(t, registry.MatchPattern(pattern, callGraph).Matched)
+}
+
+func TestRedirectFunctions(t *testing.T) {
+	assert.ElementsMatch(t, []string{"redirect", "HttpResponseRedirect", "HttpResponsePermanentRedirect"}, RedirectFunctions("Django"))
+	assert.Equal(t, []string{"redirect"}, RedirectFunctions("Flask"))
+	assert.Equal(t, []string{"RedirectResponse"}, RedirectFunctions("FastAPI"))
+	assert.Nil(t, RedirectFunctions("SQLAlchemy"))
+}
+
+func TestRedirectURLArgument(t *testing.T) {
+	tests := []struct {
+		name string
+		args []core.Argument
+		want string
+	}{
+		{"positional", []core.Argument{{Value: "next_url", IsVariable: true}}, "next_url"},
+		{"keyword", []core.Argument{{Value: "location=target"}}, "target"},
+		{"keyword after positional", []core.Argument{{Value: "to=target", Position: 0}, {Value: "permanent=True", Position: 1}}, "target"},
+		{"non-url keyword only", []core.Argument{{Value: `"/home"`}, {Value: "permanent=flag", Position: 1}}, `"/home"`},
+		{"comparison is positional", []core.Argument{{Value: "a == b"}}, "a == b"},
+		{"no arguments", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, redirectURLArgument(&core.CallSite{Arguments: tt.args}))
+		})
+	}
+}
+
+func TestOpenRedirect_RouteParameter(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "decorators")
+
+	patternRegistry, pattern := loadOpenRedirectPattern(t)
+	found := make(map[string]string)
+	for _, match := range patternRegistry.findOpenRedirects(pattern, callGraph) {
+		found[match.SinkFQN] = match.SourceCall
+	}
+
+	// The URL parameter of the routed handler is request data; the same
+	// parameter of an undecorated helper is not.
+	assert.Equal(t, map[string]string{"routes.go": "request.view_args"}, found)
+}
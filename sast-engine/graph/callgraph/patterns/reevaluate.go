@@ -0,0 +1,36 @@
+package patterns
+
+import (
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// ReevaluateMatches runs the enabled patterns of registry over an already
+// built call graph, so a rule change is checked without rebuilding the
+// graph. summaries are the taint summaries of that build (normally
+// callGraph.Summaries); the statements stored with each summarized function
+// are reused by the intra-procedural taint checks instead of re-parsing its
+// file. Results equal a full rebuild with the same rules.
+//
+// Returns the matches keyed by pattern ID; patterns that do not match are
+// omitted.
+//
+// Example:
+//
+//	pattern, _ := registry.GetPattern("CODE-INJECTION-001")
+//	pattern.Sinks = append(pattern.Sinks, "compile")
+//	matches := patterns.ReevaluateMatches(callGraph, callGraph.Summaries, registry)
+func ReevaluateMatches(callGraph *core.CallGraph, summaries map[string]*core.TaintSummary, registry *PatternRegistry) map[string]*PatternMatchDetails {
+	built := *callGraph
+	built.Summaries = summaries
+
+	matches := make(map[string]*PatternMatchDetails)
+	for _, pattern := range registry.Patterns {
+		if !registry.IsEnabled(pattern.ID) {
+			continue
+		}
+		if match := registry.MatchPattern(pattern, &built); match != nil && match.Matched {
+			matches[pattern.ID] = match
+		}
+	}
+	return matches
+}
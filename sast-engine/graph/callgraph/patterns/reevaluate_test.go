@@ -0,0 +1,44 @@
+package patterns
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReevaluateMatches(t *testing.T) {
+	projectPath := t.TempDir()
+	fixture, err := os.ReadFile("../../../test-fixtures/python/django_sources/views.py")
+	require.NoError(t, err)
+	viewsPath := filepath.Join(projectPath, "views.py")
+	require.NoError(t, os.WriteFile(viewsPath, fixture, 0644))
+
+	defaults := NewPatternRegistry()
+	defaults.LoadDefaultPatterns()
+	codeInjection, ok := defaults.GetPattern("CODE-INJECTION-001")
+	require.True(t, ok)
+	rule := *codeInjection
+	rule.Sinks = []string{"exec"}
+	rules := NewPatternRegistry()
+	rules.AddPattern(&rule)
+
+	callGraph := buildProject(t, projectPath)
+	assert.Empty(t, ReevaluateMatches(callGraph, callGraph.Summaries, rules), "the views only eval")
+
+	// The rule author adds eval as a sink
+	rule.Sinks = append(rule.Sinks, "eval")
+	rebuilt := buildProject(t, projectPath)
+	expected := ReevaluateMatches(rebuilt, nil, rules)
+	require.Contains(t, expected, "CODE-INJECTION-001")
+
+	// Reevaluation needs neither a rebuild nor the sources of summarized functions
+	require.NoError(t, os.Remove(viewsPath))
+	assert.Equal(t, expected, ReevaluateMatches(callGraph, callGraph.Summaries, rules))
+	assert.Empty(t, ReevaluateMatches(callGraph, nil, rules), "without summaries the deleted sources are read")
+
+	rules.DisabledPatterns = []string{"CODE-INJECTION-001"}
+	assert.Empty(t, ReevaluateMatches(callGraph, callGraph.Summaries, rules))
+}
@@ -0,0 +1,81 @@
+package patterns
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/resolution"
+)
+
+// Frameworks with a ruleset bundle (see FrameworkRuleset).
+const (
+	FrameworkDjango  = "Django"
+	FrameworkFlask   = "Flask"
+	FrameworkFastAPI = "FastAPI"
+	FrameworkAiohttp = "aiohttp"
+)
+
+// FrameworkRulesets lists the frameworks with a ruleset bundle.
+var FrameworkRulesets = []string{FrameworkDjango, FrameworkFlask, FrameworkFastAPI, FrameworkAiohttp}
+
+// rulesetFrameworks maps the frameworks recognized by core.IsKnownFramework
+// to the ruleset covering them. Django REST framework runs on Django, and
+// FastAPI requests are Starlette's.
+var rulesetFrameworks = map[string]string{
+	"Django":                FrameworkDjango,
+	"Django REST Framework": FrameworkDjango,
+	"Flask":                 FrameworkFlask,
+	"FastAPI":               FrameworkFastAPI,
+	"Starlette":             FrameworkFastAPI,
+	"aiohttp":               FrameworkAiohttp,
+}
+
+// FrameworkRuleset returns the default patterns (see LoadDefaultPatterns)
+// with the request sources of one framework of FrameworkRulesets only, so
+// that, e.g., a Flask application is not checked for Django request
+// attributes. Patterns are ordered by ID. Returns nil for other names.
+func FrameworkRuleset(name string) []*Pattern {
+	if !slices.Contains(FrameworkRulesets, name) {
+		return nil
+	}
+	registry := NewPatternRegistry()
+	registry.Framework = name
+	registry.LoadDefaultPatterns()
+	return slices.SortedFunc(maps.Values(registry.Patterns), func(a, b *Pattern) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+}
+
+// DetectRulesetFramework returns the framework of FrameworkRulesets imported
+// by the most files of callGraph, or "" if none is. Ties go to the first in
+// FrameworkRulesets. Unlike DetectFramework, which reports the first known
+// framework of a single file, it weighs every import of every file.
+func DetectRulesetFramework(callGraph *core.CallGraph) string {
+	typeEngine, ok := callGraph.TypeEngine.(*resolution.TypeInferenceEngine)
+	if !ok || typeEngine == nil {
+		return ""
+	}
+
+	files := make(map[string]int)
+	typeEngine.ForEachImportMap(func(_ string, importMap *core.ImportMap) {
+		imported := make(map[string]bool)
+		for _, fqn := range importMap.Imports {
+			if isKnown, framework := core.IsKnownFramework(fqn); isKnown && rulesetFrameworks[framework.Name] != "" {
+				imported[rulesetFrameworks[framework.Name]] = true
+			}
+		}
+		for name := range imported {
+			files[name]++
+		}
+	})
+
+	detected := ""
+	for _, name := range FrameworkRulesets {
+		if files[name] > files[detected] {
+			detected = name
+		}
+	}
+	return detected
+}
@@ -0,0 +1,106 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rulesetSources returns the sources of the CODE-INJECTION-001 pattern of
+// ruleset.
+func rulesetSources(t *testing.T, ruleset []*Pattern) []string {
+	t.Helper()
+	for _, pattern := range ruleset {
+		if pattern.ID == "CODE-INJECTION-001" {
+			return pattern.Sources
+		}
+	}
+	require.Fail(t, "CODE-INJECTION-001 not in ruleset")
+	return nil
+}
+
+func TestFrameworkRuleset(t *testing.T) {
+	tests := []struct {
+		framework string
+		includes  []string
+		excludes  []string
+	}{
+		{FrameworkDjango, []string{"request.GET", "request.session"}, []string{"request.args", "request.query_params"}},
+		{FrameworkFlask, []string{"request.args", "request.view_args"}, []string{"request.GET", "request.session"}},
+		{FrameworkFastAPI, []string{"request.query_params", "request.path_params"}, []string{"request.GET", "request.args"}},
+		{FrameworkAiohttp, []string{"request.match_info", "request.post"}, []string{"request.GET", "request.args"}},
+	}
+
+	defaults := NewPatternRegistry()
+	defaults.LoadDefaultPatterns()
+
+	for _, tt := range tests {
+		t.Run(tt.framework, func(t *testing.T) {
+			ruleset := FrameworkRuleset(tt.framework)
+			require.Len(t, ruleset, len(defaults.Patterns))
+			for i := 1; i < len(ruleset); i++ {
+				assert.Less(t, ruleset[i-1].ID, ruleset[i].ID)
+			}
+
+			sources := rulesetSources(t, ruleset)
+			for _, source := range tt.includes {
+				assert.Contains(t, sources, source)
+			}
+			for _, source := range tt.excludes {
+				assert.NotContains(t, sources, source)
+			}
+		})
+	}
+}
+
+func TestFrameworkRuleset_Unknown(t *testing.T) {
+	assert.Nil(t, FrameworkRuleset("Tornado"))
+	assert.Nil(t, FrameworkRuleset(""))
+}
+
+func TestLoadDefaultPatterns_GlobalSources(t *testing.T) {
+	registry := NewPatternRegistry()
+	registry.Framework = FrameworkFlask
+	registry.GlobalSources = []string{"get_tenant_input"}
+	registry.LoadDefaultPatterns()
+
+	pattern, ok := registry.GetPattern("CODE-INJECTION-001")
+	require.True(t, ok)
+	assert.Contains(t, pattern.Sources, "get_tenant_input")
+	assert.Contains(t, pattern.Sources, "request.args")
+	assert.NotContains(t, pattern.Sources, "request.GET")
+}
+
+func TestLoadDefaultPatterns_NoFramework(t *testing.T) {
+	registry := NewPatternRegistry()
+	registry.LoadDefaultPatterns()
+
+	pattern, ok := registry.GetPattern("CODE-INJECTION-001")
+	require.True(t, ok)
+	assert.Contains(t, pattern.Sources, "request.GET")
+	assert.Contains(t, pattern.Sources, "request.args")
+}
+
+func TestDetectRulesetFramework(t *testing.T) {
+	assert.Equal(t, FrameworkFlask, DetectRulesetFramework(buildFixtureCallGraph(t, "framework_rulesets")))
+	assert.Empty(t, DetectRulesetFramework(buildFixtureCallGraph(t, "django_sources")), "the views import nothing")
+
+	assert.Empty(t, DetectRulesetFramework(core.NewCallGraph()))
+}
+
+func TestFlaskProject_LoadsFlaskSources(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "framework_rulesets")
+
+	registry := NewPatternRegistry()
+	registry.Framework = DetectRulesetFramework(callGraph)
+	registry.LoadDefaultPatterns()
+	pattern, ok := registry.GetPattern("CODE-INJECTION-001")
+	require.True(t, ok)
+
+	match := registry.MatchPattern(pattern, callGraph)
+	require.True(t, match.Matched)
+	assert.Equal(t, "app.flask_view", match.SinkFQN)
+	assert.NotContains(t, pattern.Sources, "request.GET", "Django-only sources are not loaded")
+}
@@ -0,0 +1,118 @@
+package patterns
+
+import "slices"
+
+// DjangoRequestSources are Django HttpRequest attributes that carry untrusted
+// client input. Indexing and attribute access on them (request.FILES["f"],
+// request.META.get("HTTP_HOST")) are treated as tainted.
+var DjangoRequestSources = []string{
+	"request.GET",
+	"request.POST",
+	"request.FILES",
+	"request.COOKIES",
+	"request.META",
+	"request.body",
+}
+
+// DjangoSessionSources are Django session stores. Session data lives on the
+// server but is frequently populated from request input, so it is treated as
+// lower-trust and tainted unless PatternRegistry.TrustDjangoSession is set.
+var DjangoSessionSources = []string{
+	"request.session",
+}
+
+// DjangoSources returns the Django taint source set.
+// Session sources are included only when includeSession is true.
+func DjangoSources(includeSession bool) []string {
+	sources := make([]string, 0, len(DjangoRequestSources)+len(DjangoSessionSources))
+	sources = append(sources, DjangoRequestSources...)
+	if includeSession {
+		sources = append(sources, DjangoSessionSources...)
+	}
+	return sources
+}
+
+// FlaskRequestSources are Flask request attributes that carry untrusted
+// client input. request.view_args also stands for the parameters of
+// route-decorated handlers (resolution.RouteParameterSource).
+var FlaskRequestSources = []string{
+	"request.view_args",
+	"request.args",
+	"request.form",
+	"request.values",
+	"request.cookies",
+	"request.headers",
+	"request.json",
+	"request.get_json",
+	"request.data",
+}
+
+// FastAPIRequestSources are FastAPI (Starlette) Request attributes that
+// carry untrusted client input. As for Flask, request.view_args stands for
+// the parameters of route-decorated handlers.
+var FastAPIRequestSources = []string{
+	"request.view_args",
+	"request.query_params",
+	"request.path_params",
+	"request.headers",
+	"request.cookies",
+	"request.json",
+	"request.form",
+	"request.body",
+}
+
+// AiohttpRequestSources are aiohttp web.Request attributes and coroutines
+// that carry untrusted client input.
+var AiohttpRequestSources = []string{
+	"request.query",
+	"request.rel_url",
+	"request.match_info",
+	"request.headers",
+	"request.cookies",
+	"request.json",
+	"request.post",
+	"request.text",
+	"request.read",
+	"request.content",
+}
+
+// requestSources returns the request sources of the registry's Framework
+// (see FrameworkRuleset), or the Django and Flask sources when it has none,
+// followed by its GlobalSources.
+func (pr *PatternRegistry) requestSources() []string {
+	var sources []string
+	switch pr.Framework {
+	case FrameworkDjango:
+		sources = DjangoSources(!pr.TrustDjangoSession)
+	case FrameworkFlask:
+		sources = FlaskRequestSources
+	case FrameworkFastAPI:
+		sources = FastAPIRequestSources
+	case FrameworkAiohttp:
+		sources = AiohttpRequestSources
+	default:
+		sources = slices.Concat(DjangoSources(!pr.TrustDjangoSession), FlaskRequestSources)
+	}
+	return slices.Concat(sources, pr.GlobalSources)
+}
+
+// EnvironmentSources read process environment variables. Attribute and
+// index access on os.environ (os.environ["X"], os.environ.get("X")) count
+// as reads. The environment is trusted by default, but in CI runners and
+// multi-tenant hosts it may be set by another party; set
+// PatternRegistry.TaintEnvironment to treat it as tainted.
+var EnvironmentSources = []string{
+	"os.environ",
+	"os.environb",
+	"os.getenv",
+	"os.getenvb",
+}
+
+// environmentSources returns EnvironmentSources if pr.TaintEnvironment is
+// set, and nil otherwise.
+func (pr *PatternRegistry) environmentSources() []string {
+	if !pr.TaintEnvironment {
+		return nil
+	}
+	return EnvironmentSources
+}
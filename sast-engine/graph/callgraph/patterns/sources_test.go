@@ -0,0 +1,93 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDjangoSources_EachSourceFlags(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "django_sources")
+	registry := NewPatternRegistry()
+
+	tests := []struct {
+		source   string
+		function string
+	}{
+		{"request.FILES", "views.files_view"},
+		{"request.COOKIES", "views.cookies_view"},
+		{"request.META", "views.meta_view"},
+		{"request.body", "views.body_view"},
+		{"request.session", "views.session_view"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			pattern := &Pattern{
+				ID:      "DJANGO-SOURCE-TEST",
+				Type:    PatternTypeMissingSanitizer,
+				Sources: []string{tt.source},
+				Sinks:   []string{"eval"},
+			}
+
+			match := registry.MatchPattern(pattern, callGraph)
+			require.True(t, match.Matched, "%s should flag", tt.source)
+			assert.True(t, match.IsIntraProcedural)
+			assert.Equal(t, tt.function, match.SourceFQN)
+			assert.Equal(t, tt.function, match.SinkFQN)
+		})
+	}
+}
+
+func TestDjangoSources_SafeViewNotSource(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "django_sources")
+	registry := NewPatternRegistry()
+
+	for _, source := range registry.findSources(DjangoSources(true), callGraph) {
+		assert.NotEqual(t, "views.safe_view", source.caller)
+	}
+}
+
+func TestDjangoSources(t *testing.T) {
+	withSession := DjangoSources(true)
+	withoutSession := DjangoSources(false)
+
+	for _, source := range []string{"request.GET", "request.POST", "request.FILES", "request.COOKIES", "request.META", "request.body"} {
+		assert.Contains(t, withSession, source)
+		assert.Contains(t, withoutSession, source)
+	}
+	assert.Contains(t, withSession, "request.session")
+	assert.NotContains(t, withoutSession, "request.session")
+}
+
+func TestLoadDefaultPatterns_DjangoSessionConfigurable(t *testing.T) {
+	tainted := NewPatternRegistry()
+	tainted.LoadDefaultPatterns()
+	pattern, ok := tainted.GetPattern("CODE-INJECTION-001")
+	require.True(t, ok)
+	assert.Contains(t, pattern.Sources, "request.FILES")
+	assert.Contains(t, pattern.Sources, "request.session")
+
+	trusted := NewPatternRegistry()
+	trusted.TrustDjangoSession = true
+	trusted.LoadDefaultPatterns()
+	pattern, ok = trusted.GetPattern("CODE-INJECTION-001")
+	require.True(t, ok)
+	assert.Contains(t, pattern.Sources, "request.FILES")
+	assert.NotContains(t, pattern.Sources, "request.session")
+}
+
+func TestDjangoSources_SessionTrustedDoesNotFlag(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "django_sources")
+
+	registry := NewPatternRegistry()
+	registry.TrustDjangoSession = true
+	registry.LoadDefaultPatterns()
+	pattern, ok := registry.GetPattern("CODE-INJECTION-001")
+	require.True(t, ok)
+
+	for _, source := range registry.findSources(pattern.Sources, callGraph) {
+		assert.NotEqual(t, "views.session_view", source.caller)
+	}
+}
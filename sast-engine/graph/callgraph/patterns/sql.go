@@ -0,0 +1,276 @@
+package patterns
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// SQLExecuteMethods are the DB-API cursor and connection methods, also
+// offered by SQLAlchemy connections and sessions, and the Django manager
+// method raw, whose first argument is the SQL statement to run.
+var SQLExecuteMethods = []string{"execute", "executemany", "executescript", "raw"}
+
+// sqlQueryKeywords are the parameter names of the statement argument:
+// execute(sql=...), Django's raw(raw_query=...), and so on.
+var sqlQueryKeywords = []string{"sql", "operation", "statement", "raw_query"}
+
+// sqlQueryArgument returns the statement passed to an execute method: a
+// statement keyword argument if present, otherwise the first positional
+// argument.
+func sqlQueryArgument(callSite *core.CallSite) string {
+	for _, keyword := range sqlQueryKeywords {
+		if value, ok := keywordArgument(callSite, keyword); ok {
+			return value
+		}
+	}
+	return openArgument(callSite, 0, "")
+}
+
+// stringPart is one literal of a string expression: its prefix letters,
+// quotes, and the text between them, escapes kept.
+type stringPart struct {
+	prefix string
+	quote  string
+	body   string
+}
+
+// isFString reports whether a literal is an f-string.
+func (p stringPart) isFString() bool {
+	return strings.ContainsAny(p.prefix, "fF")
+}
+
+// stringLiteralParts splits an expression made only of string literals into
+// them: one literal, or adjacent literals, which Python concatenates
+// implicitly ("SELECT * " f"FROM {table}"). ok is false for any other
+// expression.
+func stringLiteralParts(expr string) ([]stringPart, bool) {
+	var parts []stringPart
+	rest := strings.TrimSpace(expr)
+	for rest != "" {
+		quoteAt := strings.IndexAny(rest, `"'`)
+		if quoteAt < 0 || quoteAt > 2 || strings.Trim(rest[:quoteAt], "fFrRbBuU") != "" {
+			return nil, false
+		}
+		literal := rest[quoteAt:]
+		quote := literal[:1]
+		if strings.HasPrefix(literal, strings.Repeat(quote, 3)) {
+			quote = strings.Repeat(quote, 3)
+		}
+		end := closingQuote(literal, quote)
+		if end < 0 {
+			return nil, false
+		}
+		parts = append(parts, stringPart{prefix: rest[:quoteAt], quote: quote, body: literal[len(quote):end]})
+		// Literals may be split across lines with a backslash continuation
+		rest = strings.TrimLeft(literal[end+len(quote):], " \t\r\n\\")
+	}
+	return parts, len(parts) > 0
+}
+
+// closingQuote returns the index of the quote closing a literal opened by
+// quote, skipping escaped characters, or -1.
+func closingQuote(literal, quote string) int {
+	for i := len(quote); i < len(literal); i++ {
+		if literal[i] == '\\' {
+			i++
+			continue
+		}
+		if strings.HasPrefix(literal[i:], quote) {
+			return i
+		}
+	}
+	return -1
+}
+
+// requote escapes the body of a literal quoted with from for use between
+// to quotes.
+func requote(body, from, to string) string {
+	if from == to || len(to) == 3 {
+		return body
+	}
+	var quoted strings.Builder
+	for i := 0; i < len(body); i++ {
+		switch {
+		case body[i] == '\\' && i+1 < len(body):
+			quoted.WriteString(body[i : i+2])
+			i++
+		case body[i] == to[0]:
+			quoted.WriteString(`\` + to)
+		case body[i] == '\n':
+			quoted.WriteString(`\n`)
+		default:
+			quoted.WriteByte(body[i])
+		}
+	}
+	return quoted.String()
+}
+
+// parameterizeFString rewrites an f-string into a query with %s
+// placeholders and the expressions it interpolated, dropping format specs
+// and the quotes a placeholder was wrapped in. An f-string implicitly
+// concatenated with other literals is rewritten as a whole:
+//
+//	f"SELECT * FROM users WHERE name = '{name}'"
+//	→ "SELECT * FROM users WHERE name = %s", [name]
+//	"SELECT * FROM users " f"WHERE id = {user_id}"
+//	→ "SELECT * FROM users WHERE id = %s", [user_id]
+func parameterizeFString(expr string) (string, []string, bool) {
+	parts, ok := stringLiteralParts(expr)
+	if !ok || !slices.ContainsFunc(parts, stringPart.isFString) {
+		return "", nil, false
+	}
+	quote := parts[0].quote
+
+	var query strings.Builder
+	var params []string
+	for _, part := range parts {
+		body := requote(part.body, part.quote, quote)
+		if !part.isFString() {
+			query.WriteString(body)
+			continue
+		}
+		for i := 0; i < len(body); i++ {
+			switch {
+			case strings.HasPrefix(body[i:], "{{"), strings.HasPrefix(body[i:], "}}"):
+				query.WriteByte(body[i])
+				i++
+			case body[i] == '{':
+				end := replacementFieldEnd(body, i)
+				if end < 0 {
+					return "", nil, false
+				}
+				params = append(params, replacementFieldExpression(body[i+1:end]))
+				// '{name}' becomes %s, not '%s'
+				text := query.String()
+				if wrapper := placeholderQuote(text); wrapper != "" && strings.HasPrefix(body[end+1:], wrapper) {
+					query.Reset()
+					query.WriteString(strings.TrimSuffix(text, wrapper))
+					end += len(wrapper)
+				}
+				query.WriteString("%s")
+				i = end
+			default:
+				query.WriteByte(body[i])
+			}
+		}
+	}
+	if len(params) == 0 {
+		return "", nil, false
+	}
+	return quote + query.String() + quote, params, true
+}
+
+// placeholderQuote returns the SQL quote, possibly backslash-escaped, that
+// text ends with, or "".
+func placeholderQuote(text string) string {
+	for _, quote := range []string{`\'`, `\"`, `'`, `"`} {
+		if strings.HasSuffix(text, quote) {
+			return quote
+		}
+	}
+	return ""
+}
+
+// replacementFieldEnd returns the index of the brace closing the
+// replacement field opened at start, or -1.
+func replacementFieldEnd(body string, start int) int {
+	depth := 0
+	for i := start; i < len(body); i++ {
+		switch body[i] {
+		case '{', '[', '(':
+			depth++
+		case '}', ']', ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// replacementFieldExpression strips the conversion (!r) and format spec
+// (:>10) from the contents of a replacement field.
+func replacementFieldExpression(field string) string {
+	depth := 0
+	for i := 0; i < len(field); i++ {
+		switch field[i] {
+		case '[', '(', '{':
+			depth++
+		case ']', ')', '}':
+			depth--
+		case '!', ':':
+			if depth == 0 && !strings.HasPrefix(field[i:], "!=") {
+				return strings.TrimSpace(field[:i])
+			}
+		}
+	}
+	return strings.TrimSpace(field)
+}
+
+// sqlRemediation returns the parameterized form of an execute call whose
+// statement is an f-string, e.g. use parameterized query:
+// cursor.execute("SELECT * FROM users WHERE name = %s", (name,)).
+func sqlRemediation(callSite *core.CallSite, query string) (string, bool) {
+	parameterized, params, ok := parameterizeFString(query)
+	if !ok {
+		return "", false
+	}
+	return "use parameterized query: " + callSite.Target + "(" + parameterized + ", (" + strings.Join(params, ", ") + ",))", true
+}
+
+// findSQLInjections returns every execute call whose statement is built
+// from tainted data, ordered by function FQN and line. Tainted values
+// passed as query parameters are not flagged. When the statement is an
+// f-string, Remediation spells out the parameterized call.
+func (pr *PatternRegistry) findSQLInjections(pattern *Pattern, callGraph *core.CallGraph) []*PatternMatchDetails {
+	var matches []*PatternMatchDetails
+	for _, caller := range sortedCallers(callGraph) {
+		if _, ok := callGraph.Functions[caller]; !ok {
+			continue
+		}
+		callSites := sortedCallSites(callGraph, caller)
+		for i := range callSites {
+			callSite := &callSites[i]
+			_, method, ok := cutLast(callSite.Target, ".")
+			if !ok || !slices.Contains(pattern.Sinks, method) {
+				continue
+			}
+			query := sqlQueryArgument(callSite)
+			if query == "" {
+				continue
+			}
+
+			source, context := "", "SQL statement built from tainted data in "+callSite.Target
+			remediation, isFString := sqlRemediation(callSite, query)
+			if isFString {
+				_, params, _ := parameterizeFString(query)
+				for _, param := range params {
+					if source = taintedExpressionSource(caller, callSite, param, callGraph, pattern); source != "" {
+						break
+					}
+				}
+				context = "f-string SQL statement built from tainted data in " + callSite.Target
+			} else {
+				source = taintedExpressionSource(caller, callSite, query, callGraph, pattern)
+			}
+			if source == "" {
+				continue
+			}
+			matches = append(matches, &PatternMatchDetails{
+				Matched:           true,
+				IsIntraProcedural: true,
+				SourceFQN:         caller,
+				SourceCall:        source,
+				SinkFQN:           caller,
+				SinkCall:          callSite.Target,
+				DataFlowPath:      []string{caller},
+				Context:           context,
+				Remediation:       remediation,
+			})
+		}
+	}
+	return matches
+}
@@ -0,0 +1,114 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLInjection_Remediation(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "sql_injection")
+	registry := NewPatternRegistry()
+	registry.LoadDefaultPatterns()
+	pattern, ok := registry.GetPattern("SQL-INJECTION-001")
+	require.True(t, ok)
+
+	found := make(map[string]string)
+	for _, match := range registry.findSQLInjections(pattern, callGraph) {
+		found[match.SinkFQN] = match.Remediation
+	}
+
+	// Query parameters and f-strings of constants are not flagged
+	assert.Equal(t, map[string]string{
+		"app.search": `use parameterized query: cursor.execute("SELECT * FROM users WHERE name = %s", (name,))`,
+		"app.orders": `use parameterized query: conn.execute("SELECT * FROM orders WHERE status = %s LIMIT %s", (status, limit,))`,
+		"app.report": "",
+		"app.tags":   "",
+		"app.people": `use parameterized query: Person.objects.raw("SELECT * FROM people WHERE city = %s", (city,))`,
+	}, found)
+}
+
+func TestSQLInjection_MatchPattern(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "sql_injection")
+	registry := NewPatternRegistry()
+	registry.LoadDefaultPatterns()
+	pattern, ok := registry.GetPattern("SQL-INJECTION-001")
+	require.True(t, ok)
+
+	match := registry.MatchPattern(pattern, callGraph)
+	require.NotNil(t, match)
+	assert.True(t, match.Matched)
+	assert.Equal(t, "app.orders", match.SinkFQN)
+	assert.Equal(t, "request.args", match.SourceCall)
+	assert.NotEmpty(t, match.Remediation)
+}
+
+func TestSQLInjection_ImplicitConcatenation(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "implicit_concatenation")
+
+	patternRegistry := NewPatternRegistry()
+	patternRegistry.LoadDefaultPatterns()
+	pattern, ok := patternRegistry.GetPattern("SQL-INJECTION-001")
+	require.True(t, ok)
+
+	found := make(map[string]*PatternMatchDetails)
+	for _, match := range patternRegistry.findSQLInjections(pattern, callGraph) {
+		found[match.SinkFQN] = match
+	}
+
+	// An f-string concatenated with literals taints the whole statement,
+	// inline or assigned first
+	require.Contains(t, found, "app.accounts")
+	assert.Equal(t, "request.args", found["app.accounts"].SourceCall)
+	assert.Equal(t, `use parameterized query: cursor.execute("SELECT * FROM accounts WHERE email = %s", (email,))`, found["app.accounts"].Remediation)
+	require.Contains(t, found, "app.roles")
+	assert.Equal(t, "request.args", found["app.roles"].SourceCall)
+	// Concatenated literals with query parameters are safe
+	assert.NotContains(t, found, "app.teams")
+}
+
+func TestParameterizeFString(t *testing.T) {
+	tests := []struct {
+		expr   string
+		query  string
+		params []string
+		ok     bool
+	}{
+		{`f"SELECT * FROM t WHERE id = {user_id}"`, `"SELECT * FROM t WHERE id = %s"`, []string{"user_id"}, true},
+		{`f'WHERE a = "{a}" AND b = {b:>10}'`, `'WHERE a = %s AND b = %s'`, []string{"a", "b"}, true},
+		{`rf"""SELECT {row["id"]} {{literal}}"""`, `"""SELECT %s {literal}"""`, []string{`row["id"]`}, true},
+		{`f"WHERE x = {a != b}"`, `"WHERE x = %s"`, []string{"a != b"}, true},
+		{`"SELECT * FROM t " f"WHERE id = {user_id}"`, `"SELECT * FROM t WHERE id = %s"`, []string{"user_id"}, true},
+		{`f'WHERE name = "{name}"' " ORDER BY {col}"`, `'WHERE name = %s ORDER BY {col}'`, []string{"name"}, true},
+		{"f\"WHERE a = {a}\"\n    ' AND b = \"x\"'", `"WHERE a = %s AND b = \"x\""`, []string{"a"}, true},
+		{`"SELECT " "1"`, "", nil, false},
+		{`"SELECT " + f"{x}"`, "", nil, false},
+		{`f"SELECT 1"`, "", nil, false},
+		{`"SELECT {x}"`, "", nil, false},
+		{`query`, "", nil, false},
+	}
+	for _, tt := range tests {
+		query, params, ok := parameterizeFString(tt.expr)
+		assert.Equal(t, tt.ok, ok, tt.expr)
+		assert.Equal(t, tt.query, query, tt.expr)
+		assert.Equal(t, tt.params, params, tt.expr)
+	}
+}
+
+func TestMatchPattern_DefaultRemediation(t *testing.T) {
+	registry := NewPatternRegistry()
+	pattern := &Pattern{
+		ID:                 "TEST-001",
+		Type:               PatternTypeDangerousFunction,
+		Remediation:        "avoid eval",
+		DangerousFunctions: []string{"eval"},
+	}
+	callGraph := core.NewCallGraph()
+	callGraph.AddCallSite("app.main", core.CallSite{Target: "eval", TargetFQN: "builtins.eval", Resolved: true})
+
+	match := registry.MatchPattern(pattern, callGraph)
+	require.True(t, match.Matched)
+	assert.Equal(t, "avoid eval", match.Remediation)
+}
@@ -0,0 +1,69 @@
+package patterns
+
+import (
+	"slices"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// TemplateSourceArguments maps the functions that compile a template from a
+// string to the keyword naming that string. The template source is also the
+// first positional argument; every other argument is template data, which
+// the engine substitutes rather than evaluates.
+var TemplateSourceArguments = map[string]string{
+	"jinja2.Template":                     "source",
+	"jinja2.Environment.from_string":      "source",
+	"flask.render_template_string":        "source",
+	"django.template.Template":            "template_string",
+	"mako.template.Template":              "text",
+	"chameleon.PageTemplate":              "body",
+	"chameleon.zpt.template.PageTemplate": "body",
+}
+
+// templateSourceFunctions returns the keys of TemplateSourceArguments, sorted.
+func templateSourceFunctions() []string {
+	functions := make([]string, 0, len(TemplateSourceArguments))
+	for function := range TemplateSourceArguments {
+		functions = append(functions, function)
+	}
+	slices.Sort(functions)
+	return functions
+}
+
+// findSSTI returns every template construction whose template source is
+// tainted, ordered by function FQN and line. Tainted template data, such as
+// render_template_string("{{ name }}", name=name), is not flagged.
+func (pr *PatternRegistry) findSSTI(pattern *Pattern, callGraph *core.CallGraph) []*PatternMatchDetails {
+	var matches []*PatternMatchDetails
+	for _, caller := range sortedCallers(callGraph) {
+		if _, ok := callGraph.Functions[caller]; !ok {
+			continue
+		}
+		callSites := sortedCallSites(callGraph, caller)
+		for i := range callSites {
+			callSite := &callSites[i]
+			if !slices.Contains(pattern.Sinks, callSite.TargetFQN) {
+				continue
+			}
+			template := openArgument(callSite, 0, TemplateSourceArguments[callSite.TargetFQN])
+			if template == "" {
+				continue
+			}
+			source := taintedExpressionSource(caller, callSite, template, callGraph, pattern)
+			if source == "" {
+				continue
+			}
+			matches = append(matches, &PatternMatchDetails{
+				Matched:           true,
+				IsIntraProcedural: true,
+				SourceFQN:         caller,
+				SourceCall:        source,
+				SinkFQN:           caller,
+				SinkCall:          callSite.TargetFQN,
+				DataFlowPath:      []string{caller},
+				Context:           "template source " + template + " built from request data",
+			})
+		}
+	}
+	return matches
+}
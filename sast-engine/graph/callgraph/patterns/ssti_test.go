@@ -0,0 +1,49 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSSTI(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "ssti")
+
+	patternRegistry := NewPatternRegistry()
+	patternRegistry.LoadDefaultPatterns()
+	pattern, ok := patternRegistry.GetPattern("SSTI-001")
+	require.True(t, ok)
+
+	found := make(map[string]*PatternMatchDetails)
+	for _, match := range patternRegistry.findSSTI(pattern, callGraph) {
+		found[match.SinkFQN] = match
+	}
+
+	tests := []struct {
+		function string
+		sinkCall string
+		source   string
+	}{
+		{"app.preview", "flask.render_template_string", "request.args"},
+		{"app.banner", "jinja2.Template", "request.form"},
+		{"app.compile_keyword", "jinja2.Environment.from_string", "request.args"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.function, func(t *testing.T) {
+			match, ok := found[tt.function]
+			require.True(t, ok, "expected a finding in %s", tt.function)
+			assert.Equal(t, tt.sinkCall, match.SinkCall)
+			assert.Equal(t, tt.source, match.SourceCall)
+		})
+	}
+
+	// Request data passed as template context is not evaluated
+	assert.NotContains(t, found, "app.greet")
+	assert.NotContains(t, found, "app.static_page")
+	assert.Len(t, found, len(tests))
+
+	match := patternRegistry.MatchPattern(pattern, callGraph)
+	require.True(t, match.Matched)
+	assert.Equal(t, "app.banner", match.SinkFQN)
+}
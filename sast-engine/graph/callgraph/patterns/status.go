@@ -0,0 +1,97 @@
+package patterns
+
+import (
+	"fmt"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// MatchStatus tells whether a match's data flow rests entirely on calls the
+// call graph resolved with certainty, or on at least one speculative edge.
+type MatchStatus string
+
+const (
+	// MatchStatusConfirmed means every call on the data flow path resolved
+	// directly or through a high-confidence type inference.
+	MatchStatusConfirmed MatchStatus = "confirmed"
+
+	// MatchStatusPotential means some call on the path is unresolved or was
+	// resolved through a type inference below ConfirmedEdgeConfidence.
+	MatchStatusPotential MatchStatus = "potential"
+)
+
+// ConfirmedEdgeConfidence is the minimum type inference confidence for a
+// call resolved through type inference to count as a confirmed edge.
+const ConfirmedEdgeConfidence float32 = 0.9
+
+// ParseMatchStatus parses a status name, as given to the scan command's
+// --status flag or the explain_finding tool. An empty name parses to "",
+// which filters nothing.
+func ParseMatchStatus(name string) (MatchStatus, error) {
+	switch status := MatchStatus(name); status {
+	case "", MatchStatusConfirmed, MatchStatusPotential:
+		return status, nil
+	default:
+		return "", fmt.Errorf("invalid status %q: must be %q or %q", name, MatchStatusConfirmed, MatchStatusPotential)
+	}
+}
+
+// FlowStatus derives the status of a data flow path: confirmed when each
+// step from one function to the next is a confirmed call (see
+// confirmedCall). Single-function paths are always confirmed.
+func FlowStatus(path []string, callGraph *core.CallGraph) MatchStatus {
+	for i := 1; i < len(path); i++ {
+		if path[i-1] != path[i] && !confirmedCall(path[i-1], path[i], callGraph) {
+			return MatchStatusPotential
+		}
+	}
+	return MatchStatusConfirmed
+}
+
+// UnresolvedEdgeConfidence is the confidence given to a step of a data flow
+// path that no resolved call site backs, e.g. an edge added by name.
+const UnresolvedEdgeConfidence = 0.5
+
+// flowConfidence derives the confidence of a data flow path: the weakest of
+// its steps, where a step backed by a direct resolved call counts 1.0, one
+// resolved through type inference counts its TypeConfidence, and any other
+// counts UnresolvedEdgeConfidence. Single-function paths have confidence 1.0.
+func flowConfidence(path []string, callGraph *core.CallGraph) float64 {
+	confidence := 1.0
+	for i := 1; i < len(path); i++ {
+		if path[i-1] != path[i] {
+			confidence = min(confidence, callConfidence(path[i-1], path[i], callGraph))
+		}
+	}
+	return confidence
+}
+
+// callConfidence returns the confidence of caller's best resolved call to
+// callee (see flowConfidence).
+func callConfidence(caller, callee string, callGraph *core.CallGraph) float64 {
+	best := UnresolvedEdgeConfidence
+	for _, callSite := range callGraph.CallSites[caller] {
+		if !callSite.Resolved || callSite.TargetFQN != callee {
+			continue
+		}
+		if !callSite.ResolvedViaTypeInference {
+			return 1.0
+		}
+		best = max(best, float64(callSite.TypeConfidence))
+	}
+	return best
+}
+
+// confirmedCall reports whether caller has a resolved call to callee that
+// did not rely on a type inference below ConfirmedEdgeConfidence.
+func confirmedCall(caller, callee string, callGraph *core.CallGraph) bool {
+	for _, callSite := range callGraph.CallSites[caller] {
+		if !callSite.Resolved || callSite.TargetFQN != callee {
+			continue
+		}
+		if !callSite.ResolvedViaTypeInference || callSite.TypeConfidence >= ConfirmedEdgeConfidence {
+			return true
+		}
+	}
+	return false
+}
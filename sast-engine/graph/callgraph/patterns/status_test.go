@@ -0,0 +1,109 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// statusCallGraph builds get_input() -> process() -> execute_code(), where
+// get_input reads input() and execute_code calls eval(). The call from
+// get_input to process is resolved with the given call site.
+func statusCallGraph(processCall core.CallSite) *core.CallGraph {
+	callGraph := core.NewCallGraph()
+	callGraph.AddCallSite("myapp.get_input", core.CallSite{Target: "input", TargetFQN: "builtins.input", Resolved: true})
+	callGraph.AddCallSite("myapp.get_input", processCall)
+	callGraph.AddCallSite("myapp.process", core.CallSite{Target: "execute_code", TargetFQN: "myapp.execute_code", Resolved: true})
+	callGraph.AddCallSite("myapp.execute_code", core.CallSite{Target: "eval", TargetFQN: "builtins.eval", Resolved: true})
+	callGraph.AddEdge("myapp.get_input", "myapp.process")
+	callGraph.AddEdge("myapp.process", "myapp.execute_code")
+	return callGraph
+}
+
+func TestMatchPattern_Status(t *testing.T) {
+	registry := NewPatternRegistry()
+	pattern := &Pattern{
+		ID:      "TEST-SOURCE-SINK",
+		Type:    PatternTypeSourceSink,
+		Sources: []string{"input"},
+		Sinks:   []string{"eval"},
+	}
+
+	tests := []struct {
+		name        string
+		processCall core.CallSite
+		want        MatchStatus
+		confidence  float64
+	}{
+		{
+			name:        "direct call",
+			processCall: core.CallSite{Target: "process", TargetFQN: "myapp.process", Resolved: true},
+			want:        MatchStatusConfirmed,
+			confidence:  1.0,
+		},
+		{
+			name: "high-confidence type inference",
+			processCall: core.CallSite{
+				Target: "worker.process", TargetFQN: "myapp.process", Resolved: true,
+				ResolvedViaTypeInference: true, TypeConfidence: 1.0, TypeSource: "class_instantiation_local",
+			},
+			want:       MatchStatusConfirmed,
+			confidence: 1.0,
+		},
+		{
+			name: "type-inferred guess",
+			processCall: core.CallSite{
+				Target: "worker.process", TargetFQN: "myapp.process", Resolved: true,
+				ResolvedViaTypeInference: true, TypeConfidence: 0.5, TypeSource: "heuristic",
+			},
+			want:       MatchStatusPotential,
+			confidence: 0.5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match := registry.MatchPattern(pattern, statusCallGraph(tt.processCall))
+			require.True(t, match.Matched)
+			assert.Equal(t, []string{"myapp.get_input", "myapp.process", "myapp.execute_code"}, match.DataFlowPath)
+			assert.Equal(t, tt.want, match.Status)
+			assert.Equal(t, tt.confidence, match.Confidence)
+		})
+	}
+}
+
+func TestFlowStatus(t *testing.T) {
+	callGraph := core.NewCallGraph()
+	callGraph.AddCallSite("app.view", core.CallSite{Target: "helper", TargetFQN: "app.helper", Resolved: false})
+
+	assert.Equal(t, MatchStatusConfirmed, FlowStatus([]string{"app.view"}, callGraph))
+	assert.Equal(t, MatchStatusConfirmed, FlowStatus(nil, callGraph))
+	assert.Equal(t, MatchStatusPotential, FlowStatus([]string{"app.view", "app.helper"}, callGraph), "unresolved call")
+	assert.Equal(t, MatchStatusPotential, FlowStatus([]string{"app.view", "app.other"}, callGraph), "no call site")
+}
+
+func TestFlowConfidence(t *testing.T) {
+	callGraph := core.NewCallGraph()
+	callGraph.AddCallSite("app.view", core.CallSite{Target: "helper", TargetFQN: "app.helper", Resolved: true})
+	callGraph.AddCallSite("app.helper", core.CallSite{
+		Target: "db.run", TargetFQN: "app.run", Resolved: true,
+		ResolvedViaTypeInference: true, TypeConfidence: 0.75,
+	})
+
+	assert.Equal(t, 1.0, flowConfidence([]string{"app.view"}, callGraph))
+	assert.Equal(t, 1.0, flowConfidence([]string{"app.view", "app.helper"}, callGraph))
+	assert.Equal(t, 0.75, flowConfidence([]string{"app.view", "app.helper", "app.run"}, callGraph), "weakest step")
+	assert.Equal(t, UnresolvedEdgeConfidence, flowConfidence([]string{"app.view", "app.other"}, callGraph), "no call site")
+}
+
+func TestParseMatchStatus(t *testing.T) {
+	for _, name := range []string{"", "confirmed", "potential"} {
+		status, err := ParseMatchStatus(name)
+		require.NoError(t, err)
+		assert.Equal(t, MatchStatus(name), status)
+	}
+
+	_, err := ParseMatchStatus("likely")
+	assert.ErrorContains(t, err, `invalid status "likely"`)
+}
@@ -0,0 +1,144 @@
+package patterns
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// InsecureTempFileFunctions return a temporary file name without creating
+// the file, so another process can create or symlink it first. Use
+// tempfile.mkstemp or tempfile.NamedTemporaryFile instead.
+var InsecureTempFileFunctions = []string{"tempfile.mktemp"}
+
+// FileOpenFunctions open a file by path. Writing through them to a fixed
+// path in a shared temporary directory follows planted symlinks.
+var FileOpenFunctions = []string{"builtins.open", "io.open", "codecs.open", "os.open"}
+
+// predictableTempPath matches an expression starting with a path in a
+// world-writable temporary directory: "/tmp/x", f"/var/tmp/{x}", "/tmp".
+var predictableTempPath = regexp.MustCompile(`^[rRbBfFuU]{0,2}["'](?:/tmp|/var/tmp|/dev/shm)(?:/|["'])`)
+
+// writeOpenFlags are os.open flags that write to or create the file.
+var writeOpenFlags = []string{"O_WRONLY", "O_RDWR", "O_CREAT", "O_APPEND", "O_TRUNC"}
+
+// isPredictableTempPath reports whether a path expression names a fixed
+// location in a shared temporary directory, either directly ("/tmp/" + name,
+// os.path.join("/tmp", name), tempfile.gettempdir() + "/x") or through a
+// variable assigned such a path.
+func isPredictableTempPath(caller, expr string, loc core.Location, callGraph *core.CallGraph, sources sourceLines) bool {
+	if isIdentifier(expr) {
+		expr = resolveVariableValue(caller, expr, loc, callGraph, sources)
+	}
+	expr = strings.TrimPrefix(expr, "os.path.join(")
+	return predictableTempPath.MatchString(expr) || strings.HasPrefix(expr, "tempfile.gettempdir()")
+}
+
+// fileOpenFunction returns the FileOpenFunctions entry a call resolves to.
+// The builtin open is not resolved by the builder, so an open() that is not
+// a project function is treated as builtins.open.
+func fileOpenFunction(callSite *core.CallSite, callGraph *core.CallGraph) string {
+	if callSite.Target == "open" {
+		if _, local := callGraph.Functions[callSite.TargetFQN]; !local {
+			return "builtins.open"
+		}
+	}
+	if slices.Contains(FileOpenFunctions, callSite.TargetFQN) {
+		return callSite.TargetFQN
+	}
+	return ""
+}
+
+// openArgument returns the argument at position, or the named keyword
+// argument, of a call. A position of -1 takes the keyword argument only.
+// A *iterable spread at or before position may fill it, and is returned
+// in its place, as the positions of later arguments are unknown.
+func openArgument(callSite *core.CallSite, position int, name string) string {
+	if value, ok := keywordArgument(callSite, name); ok {
+		return value
+	}
+	if position < 0 {
+		return ""
+	}
+	for _, arg := range callSite.Arguments {
+		if arg.IsDictSpread {
+			continue
+		}
+		if arg.IsListSpread {
+			if arg.Position <= position {
+				return arg.Value
+			}
+			break
+		}
+		if _, _, ok := splitKeywordArgument(arg.Value); !ok && arg.Position == position {
+			return arg.Value
+		}
+	}
+	return ""
+}
+
+// opensForWriting reports whether a file open call writes to or creates a
+// file that may already exist. Exclusive creation (mode "x", os.O_EXCL)
+// fails on an existing file or symlink, so it is not a write.
+func opensForWriting(function string, callSite *core.CallSite) bool {
+	if function == "os.open" {
+		flags := openArgument(callSite, 1, "flags")
+		if strings.Contains(flags, "O_EXCL") {
+			return false
+		}
+		return slices.ContainsFunc(writeOpenFlags, func(flag string) bool {
+			return strings.Contains(flags, flag)
+		})
+	}
+	mode := strings.Trim(openArgument(callSite, 1, "mode"), `"'`)
+	return strings.ContainsAny(mode, "wa+") && !strings.Contains(mode, "x")
+}
+
+// findInsecureTempFiles returns every call to DangerousFunctions (mktemp)
+// and every file opened for writing at a predictable temporary path,
+// ordered by caller FQN and line. Taint is not required; when request data
+// reaches the path, the match records its source.
+func (pr *PatternRegistry) findInsecureTempFiles(pattern *Pattern, callGraph *core.CallGraph) []*PatternMatchDetails {
+	sources := newSourceLines(callGraph)
+
+	var matches []*PatternMatchDetails
+	for _, caller := range sortedCallers(callGraph) {
+		callSites := sortedCallSites(callGraph, caller)
+		for i := range callSites {
+			callSite := &callSites[i]
+			sinkCall, source, context := callSite.TargetFQN, "", ""
+			if slices.Contains(pattern.DangerousFunctions, callSite.TargetFQN) {
+				context = callSite.TargetFQN + " is racy; use tempfile.mkstemp"
+			} else if function := fileOpenFunction(callSite, callGraph); function != "" && slices.Contains(pattern.Sinks, function) {
+				path := openArgument(callSite, 0, "file")
+				if path == "" {
+					path = openArgument(callSite, 0, "path")
+				}
+				if path == "" || !opensForWriting(function, callSite) ||
+					!isPredictableTempPath(caller, path, callSite.Location, callGraph, sources) {
+					continue
+				}
+				sinkCall = function
+				source = taintedExpressionSource(caller, callSite, path, callGraph, pattern)
+				context = "predictable temporary path " + path + " opened for writing; use tempfile.mkstemp"
+			}
+			if context == "" {
+				continue
+			}
+
+			matches = append(matches, &PatternMatchDetails{
+				Matched:           true,
+				IsIntraProcedural: true,
+				SourceFQN:         caller,
+				SourceCall:        source,
+				SinkFQN:           caller,
+				SinkCall:          sinkCall,
+				DataFlowPath:      []string{caller},
+				Context:           context,
+			})
+		}
+	}
+	return matches
+}
@@ -0,0 +1,110 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsecureTempFile(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "temp_files")
+	registry := NewPatternRegistry()
+	registry.LoadDefaultPatterns()
+	pattern, ok := registry.GetPattern("INSECURE-TEMPFILE-001")
+	require.True(t, ok)
+
+	found := make(map[string]*PatternMatchDetails)
+	for _, match := range registry.findInsecureTempFiles(pattern, callGraph) {
+		found[match.SinkFQN] = match
+	}
+
+	tests := []struct {
+		function string
+		sinkCall string
+		source   string
+	}{
+		{"storage.mktemp_report", "tempfile.mktemp", ""},
+		{"storage.concat_upload", "builtins.open", ""},
+		{"storage.fstring_cache", "builtins.open", ""},
+		{"storage.module_path_report", "builtins.open", ""},
+		{"storage.joined_upload", "os.open", "request.args"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.function, func(t *testing.T) {
+			match, ok := found[tt.function]
+			require.True(t, ok, "expected a finding in %s", tt.function)
+			assert.Equal(t, tt.sinkCall, match.SinkCall)
+			assert.Equal(t, tt.source, match.SourceCall)
+		})
+	}
+
+	// mkstemp, NamedTemporaryFile, reading a /tmp file, and exclusive
+	// creation are not flagged.
+	assert.NotContains(t, found, "storage.mkstemp_report")
+	assert.NotContains(t, found, "storage.named_temp_report")
+	assert.NotContains(t, found, "storage.read_tmp_config")
+	assert.NotContains(t, found, "storage.exclusive_lock")
+	assert.Len(t, found, len(tests))
+
+	match := registry.MatchPattern(pattern, callGraph)
+	require.True(t, match.Matched)
+	assert.Equal(t, "storage.concat_upload", match.SinkFQN, "first match in FQN order")
+}
+
+func TestIsPredictableTempPath(t *testing.T) {
+	callGraph := core.NewCallGraph()
+	sources := make(sourceLines)
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{`"/tmp/out.txt"`, true},
+		{`"/tmp"`, true},
+		{`f'/dev/shm/{key}'`, true},
+		{`os.path.join("/var/tmp", name)`, true},
+		{`tempfile.gettempdir() + "/out.txt"`, true},
+		{`"/tmpfiles/out.txt"`, false},
+		{`"/home/app/tmp/out.txt"`, false},
+		{`path`, false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, isPredictableTempPath("app.f", tt.expr, core.Location{}, callGraph, sources), tt.expr)
+	}
+}
+
+func TestOpenArgument(t *testing.T) {
+	args := func(values ...string) []core.Argument {
+		var arguments []core.Argument
+		for i, value := range values {
+			arguments = append(arguments, core.Argument{
+				Value:        value,
+				Position:     i,
+				IsListSpread: len(value) > 1 && value[0] == '*' && value[1] != '*',
+				IsDictSpread: len(value) > 1 && value[:2] == "**",
+			})
+		}
+		return arguments
+	}
+	tests := []struct {
+		name     string
+		callSite *core.CallSite
+		position int
+		keyword  string
+		expected string
+	}{
+		{"positional", &core.CallSite{Arguments: args("path", `"w"`)}, 1, "mode", `"w"`},
+		{"keyword", &core.CallSite{Arguments: args("path", `mode="w"`), KeywordArgs: map[string]string{"mode": `"w"`}}, 1, "mode", `"w"`},
+		{"keyword only", &core.CallSite{Arguments: args("query"), KeywordArgs: map[string]string{"sql": "query"}}, -1, "sql", "query"},
+		{"keyword only passed by position", &core.CallSite{Arguments: args("query")}, -1, "sql", ""},
+		{"spread may fill it", &core.CallSite{Arguments: args("path", "*rest")}, 1, "mode", "*rest"},
+		{"unknown after spread", &core.CallSite{Arguments: args("*rest", `"w"`)}, 1, "mode", "*rest"},
+		{"dict spread skipped", &core.CallSite{Arguments: args("path", "**options")}, 1, "mode", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, openArgument(tt.callSite, tt.position, tt.keyword))
+		})
+	}
+}
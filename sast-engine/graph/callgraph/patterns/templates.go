@@ -0,0 +1,121 @@
+package patterns
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"gopkg.in/yaml.v3"
+)
+
+// templateRenderSinks maps a framework name (as returned by
+// GetFrameworkName) to the resolved functions through which tainted data
+// becomes unescaped template output or template source: Mako renders
+// without autoescaping, and every engine compiles its Template argument.
+var templateRenderSinks = map[string][]string{
+	"Django": {
+		"django.template.Template",
+		"django.utils.safestring.mark_safe",
+	},
+	"Flask": {
+		"flask.render_template_string",
+	},
+	"Jinja2": {
+		"jinja2.Template",
+		"jinja2.Environment.from_string",
+	},
+	"Mako": {
+		"mako.template.Template",
+		"mako.template.Template.render",
+	},
+	"Chameleon": {
+		"chameleon.PageTemplate",
+		"chameleon.zpt.template.PageTemplate",
+	},
+}
+
+// RenderSinks returns the built-in template render sinks for a framework
+// name (e.g., "Django", "Mako"). Returns nil for frameworks without a known
+// template API.
+func RenderSinks(framework string) []string {
+	return templateRenderSinks[framework]
+}
+
+// allRenderSinks returns the built-in render sinks of every framework,
+// sorted and without duplicates.
+func allRenderSinks() []string {
+	var all []string
+	for _, sinks := range templateRenderSinks {
+		all = append(all, sinks...)
+	}
+	slices.Sort(all)
+	return slices.Compact(all)
+}
+
+// renderSinkConfig is the YAML rule config for template render sinks:
+//
+//	render_sinks:
+//	  Mako:
+//	    - mako.template.Template.render_unicode
+type renderSinkConfig struct {
+	RenderSinks map[string][]string `yaml:"render_sinks"`
+}
+
+// LoadRenderSinkConfig adds the render sinks in a YAML rule config to the
+// registry. Sinks are keyed by framework name and extend the built-in
+// RenderSinks for that framework.
+func (pr *PatternRegistry) LoadRenderSinkConfig(data []byte) error {
+	var config renderSinkConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("failed to parse render sink config: %w", err)
+	}
+	if pr.RenderSinks == nil {
+		pr.RenderSinks = make(map[string][]string)
+	}
+	for framework, sinks := range config.RenderSinks {
+		pr.RenderSinks[framework] = append(pr.RenderSinks[framework], sinks...)
+	}
+	return nil
+}
+
+// isRenderSinkCall reports whether a call site is a template render sink of
+// the framework its target resolves into, so a project-local render()
+// helper is not treated as a sink.
+func (pr *PatternRegistry) isRenderSinkCall(callSite *core.CallSite) bool {
+	framework := GetFrameworkName(callSite.TargetFQN)
+	if framework == "" {
+		return false
+	}
+	return slices.Contains(RenderSinks(framework), callSite.TargetFQN) ||
+		slices.Contains(pr.RenderSinks[framework], callSite.TargetFQN)
+}
+
+// findTemplateSinks returns every call that passes tainted data to a
+// framework render sink, ordered by function FQN and line.
+func (pr *PatternRegistry) findTemplateSinks(pattern *Pattern, callGraph *core.CallGraph) []*PatternMatchDetails {
+	var matches []*PatternMatchDetails
+	for _, caller := range sortedCallers(callGraph) {
+		if _, ok := callGraph.Functions[caller]; !ok {
+			continue
+		}
+		for _, callSite := range sortedCallSites(callGraph, caller) {
+			if !pr.isRenderSinkCall(&callSite) {
+				continue
+			}
+			source := renderedTaintSource(caller, &callSite, callGraph, pattern)
+			if source == "" {
+				continue
+			}
+			matches = append(matches, &PatternMatchDetails{
+				Matched:           true,
+				IsIntraProcedural: true,
+				SourceFQN:         caller,
+				SourceCall:        source,
+				SinkFQN:           caller,
+				SinkCall:          callSite.TargetFQN,
+				DataFlowPath:      []string{caller},
+			})
+		}
+	}
+	return matches
+}
@@ -0,0 +1,66 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func templateSinkFindings(t *testing.T, registry *PatternRegistry, callGraph *core.CallGraph) map[string]string {
+	t.Helper()
+	pattern, ok := registry.GetPattern("XSS-TEMPLATE-001")
+	require.True(t, ok)
+
+	found := make(map[string]string)
+	for _, match := range registry.findTemplateSinks(pattern, callGraph) {
+		found[match.SinkFQN] = match.SourceCall + " -> " + match.SinkCall
+	}
+	return found
+}
+
+func TestTemplateSink_Mako(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "template_sinks")
+	registry := NewPatternRegistry()
+	registry.LoadDefaultPatterns()
+
+	// static_greeting renders a literal, local_render calls a project
+	// function named render, and render_unicode is not a built-in sink.
+	assert.Equal(t, map[string]string{
+		"views.greet":                 "request.args -> mako.template.Template.render",
+		"views.compile_user_template": "request.form -> mako.template.Template",
+	}, templateSinkFindings(t, registry, callGraph))
+}
+
+func TestTemplateSink_RenderSinkConfig(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "template_sinks")
+	registry := NewPatternRegistry()
+	registry.LoadDefaultPatterns()
+
+	require.NoError(t, registry.LoadRenderSinkConfig([]byte(`
+render_sinks:
+  Mako:
+    - mako.template.Template.render_unicode
+`)))
+
+	found := templateSinkFindings(t, registry, callGraph)
+	assert.Equal(t, "request.args -> mako.template.Template.render_unicode", found["views.greet_unicode"])
+	assert.Contains(t, found, "views.greet", "config sinks extend the built-in ones")
+}
+
+func TestLoadRenderSinkConfig_Invalid(t *testing.T) {
+	registry := NewPatternRegistry()
+	err := registry.LoadRenderSinkConfig([]byte("render_sinks: [unclosed"))
+	assert.Error(t, err)
+}
+
+func TestRenderSinks(t *testing.T) {
+	assert.Contains(t, RenderSinks("Mako"), "mako.template.Template.render")
+	assert.Contains(t, RenderSinks("Django"), "django.utils.safestring.mark_safe")
+	assert.Nil(t, RenderSinks("Unknown"))
+
+	registry := NewPatternRegistry()
+	assert.True(t, registry.isRenderSinkCall(&core.CallSite{TargetFQN: "mako.template.Template.render"}))
+	assert.False(t, registry.isRenderSinkCall(&core.CallSite{TargetFQN: "myapp.views.render"}))
+}
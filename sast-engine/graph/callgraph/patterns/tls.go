@@ -0,0 +1,135 @@
+package patterns
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// TLSVerifyModules are HTTP client libraries whose request functions and
+// session methods take a verify argument controlling certificate validation.
+var TLSVerifyModules = []string{"requests", "httpx"}
+
+// UnverifiedTLSFunctions create SSL contexts that skip certificate and
+// hostname validation.
+var UnverifiedTLSFunctions = []string{
+	"ssl._create_unverified_context",
+}
+
+// acceptsVerifyArgument reports whether a resolved call belongs to one of
+// the given HTTP client modules.
+func acceptsVerifyArgument(targetFQN string, modules []string) bool {
+	for _, module := range modules {
+		if strings.HasPrefix(targetFQN, module+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// keywordArgument returns the value of the named keyword argument: from
+// KeywordArgs when extraction recorded them, otherwise from the name=value
+// arguments (e.g., of call sites built by hand).
+func keywordArgument(callSite *core.CallSite, name string) (string, bool) {
+	if callSite.KeywordArgs != nil {
+		value, ok := callSite.KeywordArgs[name]
+		return value, ok
+	}
+	for _, arg := range callSite.Arguments {
+		if keyword, value, ok := splitKeywordArgument(arg.Value); ok && keyword == name {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// assignedLiteral returns the right-hand side of a simple assignment to name
+// on the given source line ("verify_ssl = False" and "VERIFY: bool = False"
+// yield "False"), or "" if the line is not such an assignment.
+func assignedLiteral(line, name string) string {
+	lhs, rhs, ok := strings.Cut(line, "=")
+	lhs, _, _ = strings.Cut(lhs, ":")
+	if !ok || strings.TrimSpace(lhs) != name || strings.HasPrefix(rhs, "=") {
+		return ""
+	}
+	if comment := strings.IndexByte(rhs, '#'); comment >= 0 {
+		rhs = rhs[:comment]
+	}
+	return strings.TrimSpace(rhs)
+}
+
+// resolveVariableValue returns the literal last assigned to name before the
+// call at loc: a local assignment in caller, or else a module-level
+// assignment in the same file. Returns "" if the value is not a simple
+// literal assignment.
+func resolveVariableValue(caller, name string, loc core.Location, callGraph *core.CallGraph, sources sourceLines) string {
+	defLine := 0
+	for _, stmt := range callGraph.Statements[caller] {
+		for _, inner := range stmt.AllStatements() {
+			if inner.Def == name && int(inner.LineNumber) < loc.Line {
+				defLine = max(defLine, int(inner.LineNumber))
+			}
+		}
+	}
+	if defLine > 0 {
+		return assignedLiteral(strings.TrimSpace(sources.line(loc.File, defLine)), name)
+	}
+
+	// Module-level constants are unindented assignments earlier in the file.
+	value := ""
+	for lineNumber := 1; lineNumber < loc.Line; lineNumber++ {
+		line := sources.line(loc.File, lineNumber)
+		if line == "" || line[0] == ' ' || line[0] == '\t' {
+			continue
+		}
+		if literal := assignedLiteral(line, name); literal != "" {
+			value = literal
+		}
+	}
+	return value
+}
+
+// findInsecureTLS returns every call that disables certificate validation,
+// ordered by caller FQN and line. Calls into the pattern's Sinks (HTTP
+// client modules) are flagged when verify is False, either literally or
+// through a variable assigned False; calls to DangerousFunctions (unverified
+// SSL context factories) are always flagged. Taint is not required.
+func (pr *PatternRegistry) findInsecureTLS(pattern *Pattern, callGraph *core.CallGraph) []*PatternMatchDetails {
+	sources := newSourceLines(callGraph)
+
+	var matches []*PatternMatchDetails
+	for _, caller := range sortedCallers(callGraph) {
+		for _, callSite := range sortedCallSites(callGraph, caller) {
+			context := ""
+			switch {
+			case slices.Contains(pattern.DangerousFunctions, callSite.TargetFQN):
+				context = "unverified SSL context"
+			case acceptsVerifyArgument(callSite.TargetFQN, pattern.Sinks):
+				verify, ok := keywordArgument(&callSite, "verify")
+				if !ok {
+					continue
+				}
+				if verify == "False" {
+					context = "verify=False"
+				} else if isIdentifier(verify) && resolveVariableValue(caller, verify, callSite.Location, callGraph, sources) == "False" {
+					context = "verify=" + verify + " (False)"
+				}
+			}
+			if context == "" {
+				continue
+			}
+
+			matches = append(matches, &PatternMatchDetails{
+				Matched:           true,
+				IsIntraProcedural: true,
+				SourceFQN:         caller,
+				SinkFQN:           caller,
+				SinkCall:          callSite.TargetFQN,
+				DataFlowPath:      []string{caller},
+				Context:           context,
+			})
+		}
+	}
+	return matches
+}
@@ -0,0 +1,62 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsecureTLS(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "insecure_tls")
+	registry := NewPatternRegistry()
+	registry.LoadDefaultPatterns()
+	pattern, ok := registry.GetPattern("INSECURE-TLS-001")
+	require.True(t, ok)
+
+	found := make(map[string]*PatternMatchDetails)
+	for _, match := range registry.findInsecureTLS(pattern, callGraph) {
+		found[match.SinkFQN] = match
+	}
+
+	tests := []struct {
+		function string
+		sinkCall string
+		context  string
+	}{
+		{"client.fetch_unverified", "requests.get", "verify=False"},
+		{"client.fetch_with_flag", "requests.post", "verify=verify_ssl (False)"},
+		{"client.fetch_with_module_flag", "httpx.get", "verify=VERIFY_CERTS (False)"},
+		{"client.fetch_with_session", "requests.Session.get", "verify=False"},
+		{"client.open_unverified", "ssl._create_unverified_context", "unverified SSL context"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.function, func(t *testing.T) {
+			match, ok := found[tt.function]
+			require.True(t, ok, "expected a finding in %s", tt.function)
+			assert.Equal(t, tt.sinkCall, match.SinkCall)
+			assert.Equal(t, tt.context, match.Context)
+		})
+	}
+
+	// verify=True via a variable, a CA bundle path, and a default SSL context
+	// are not flagged.
+	assert.Len(t, found, len(tests))
+	assert.Equal(t, SeverityHigh, pattern.Severity)
+}
+
+func TestAssignedLiteral(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+	}{
+		{"verify_ssl = False", "False"},
+		{"verify_ssl: bool = False  # dev only", "False"},
+		{"verify_ssl == False", ""},
+		{"other = False", ""},
+		{"verify_ssl = load_setting()", "load_setting()"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, assignedLiteral(tt.line, "verify_ssl"), tt.line)
+	}
+}
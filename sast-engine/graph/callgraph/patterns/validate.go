@@ -0,0 +1,118 @@
+package patterns
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// Expectation is whether a rule should report a finding on a fixture.
+type Expectation string
+
+const (
+	ExpectFire  Expectation = "fire"  // The fixture holds a case the rule must report
+	ExpectClean Expectation = "clean" // The fixture is safe; the rule must stay quiet
+)
+
+// Fixture is code with a known verdict that a rule set is validated against.
+type Fixture struct {
+	Name      string          // Shown in the report (e.g., "eval of request data")
+	CallGraph *core.CallGraph // The fixture's call graph, built with its taint summaries
+	RuleID    string          // Rule the expectation applies to; "" for every rule
+	Expect    Expectation
+}
+
+// RuleValidation is the outcome of one rule's fixtures. Each list holds
+// fixture names in fixture order.
+type RuleValidation struct {
+	RuleID         string
+	Passed         []string // Fixtures where the rule behaved as expected
+	FalsePositives []string // Clean fixtures the rule reported
+	FalseNegatives []string // Fire fixtures the rule missed
+}
+
+// OK reports whether the rule met every expectation.
+func (v RuleValidation) OK() bool {
+	return len(v.FalsePositives) == 0 && len(v.FalseNegatives) == 0
+}
+
+// ValidationReport is the result of ValidateRuleset.
+type ValidationReport struct {
+	Rules        []RuleValidation // One per rule with fixtures, sorted by rule ID
+	UnknownRules []string         // Fixtures naming a rule missing from the rule set
+}
+
+// OK reports whether every rule met its expectations and every fixture
+// named a known rule.
+func (r ValidationReport) OK() bool {
+	return len(r.UnknownRules) == 0 && !slices.ContainsFunc(r.Rules, func(v RuleValidation) bool {
+		return !v.OK()
+	})
+}
+
+// ValidateRuleset runs rules over fixtures with known verdicts and reports,
+// per rule, the clean fixtures it fired on (false positives) and the fire
+// fixtures it missed (false negatives). It is a harness for rule
+// development: a rule change that breaks an intended case or flags a safe
+// one shows up without a full scan.
+//
+// A fixture with a RuleID is checked against that rule only; one without is
+// checked against every rule, which suits clean fixtures that no rule may
+// report. Rules without fixtures are left out of the report.
+//
+// Example:
+//
+//	report := patterns.ValidateRuleset(rules, []patterns.Fixture{
+//	    {Name: "eval of request data", CallGraph: vulnerable, RuleID: "CODE-INJECTION-001", Expect: patterns.ExpectFire},
+//	    {Name: "safe views", CallGraph: safe, Expect: patterns.ExpectClean},
+//	})
+//	for _, rule := range report.Rules {
+//	    fmt.Println(rule.RuleID, rule.FalsePositives, rule.FalseNegatives)
+//	}
+func ValidateRuleset(rules []*Pattern, fixtures []Fixture) ValidationReport {
+	registry := NewPatternRegistry()
+	for _, rule := range rules {
+		registry.AddPattern(rule)
+	}
+
+	var report ValidationReport
+	validations := make(map[string]*RuleValidation)
+	for _, fixture := range fixtures {
+		checked := rules
+		if fixture.RuleID != "" {
+			rule, ok := registry.GetPattern(fixture.RuleID)
+			if !ok {
+				report.UnknownRules = append(report.UnknownRules, fixture.Name)
+				continue
+			}
+			checked = []*Pattern{rule}
+		}
+
+		for _, rule := range checked {
+			validation, ok := validations[rule.ID]
+			if !ok {
+				validation = &RuleValidation{RuleID: rule.ID}
+				validations[rule.ID] = validation
+			}
+			match := registry.MatchPattern(rule, fixture.CallGraph)
+			fired := match != nil && match.Matched
+			switch {
+			case fired == (fixture.Expect == ExpectFire):
+				validation.Passed = append(validation.Passed, fixture.Name)
+			case fired:
+				validation.FalsePositives = append(validation.FalsePositives, fixture.Name)
+			default:
+				validation.FalseNegatives = append(validation.FalseNegatives, fixture.Name)
+			}
+		}
+	}
+
+	for _, validation := range validations {
+		report.Rules = append(report.Rules, *validation)
+	}
+	slices.SortFunc(report.Rules, func(a, b RuleValidation) int {
+		return cmp.Compare(a.RuleID, b.RuleID)
+	})
+	return report
+}
@@ -0,0 +1,61 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRuleset(t *testing.T) {
+	codeInjection := buildFixtureCallGraph(t, "code_injection")
+	djangoSources := buildFixtureCallGraph(t, "django_sources")
+	simpleProject := buildFixtureCallGraph(t, "simple_project")
+
+	registry := NewPatternRegistry()
+	registry.LoadDefaultPatterns()
+	evalRule, ok := registry.GetPattern("CODE-INJECTION-001")
+	require.True(t, ok)
+	importRule, ok := registry.GetPattern("CODE-INJECTION-002")
+	require.True(t, ok)
+
+	// A copy of the eval rule with a misspelled sink, and a rule flagging
+	// every print call
+	brokenRule := *evalRule
+	brokenRule.ID = "BROKEN-EVAL"
+	brokenRule.Sinks = []string{"evaluate"}
+	brokenRule.SinkArguments = nil
+	noisyRule := &Pattern{
+		ID:                 "NOISY-PRINT",
+		Type:               PatternTypeDangerousFunction,
+		DangerousFunctions: []string{"print"},
+	}
+
+	fixtures := []Fixture{
+		{Name: "flask eval", CallGraph: codeInjection, RuleID: "CODE-INJECTION-001", Expect: ExpectFire},
+		{Name: "django eval", CallGraph: djangoSources, RuleID: "CODE-INJECTION-001", Expect: ExpectFire},
+		{Name: "flask import_module", CallGraph: codeInjection, RuleID: "CODE-INJECTION-002", Expect: ExpectFire},
+		{Name: "flask eval", CallGraph: codeInjection, RuleID: "BROKEN-EVAL", Expect: ExpectFire},
+		{Name: "django eval", CallGraph: djangoSources, RuleID: "BROKEN-EVAL", Expect: ExpectFire},
+		{Name: "simple project", CallGraph: simpleProject, Expect: ExpectClean},
+		{Name: "missing rule", CallGraph: simpleProject, RuleID: "NO-SUCH-RULE", Expect: ExpectFire},
+	}
+
+	report := ValidateRuleset([]*Pattern{evalRule, importRule, &brokenRule, noisyRule}, fixtures)
+
+	assert.Equal(t, ValidationReport{
+		Rules: []RuleValidation{
+			{RuleID: "BROKEN-EVAL", Passed: []string{"simple project"}, FalseNegatives: []string{"flask eval", "django eval"}},
+			{RuleID: "CODE-INJECTION-001", Passed: []string{"flask eval", "django eval", "simple project"}},
+			{RuleID: "CODE-INJECTION-002", Passed: []string{"flask import_module", "simple project"}},
+			{RuleID: "NOISY-PRINT", FalsePositives: []string{"simple project"}},
+		},
+		UnknownRules: []string{"missing rule"},
+	}, report)
+	assert.False(t, report.OK())
+	assert.True(t, report.Rules[1].OK())
+	assert.False(t, report.Rules[0].OK())
+
+	// Without the broken rules and the bad fixture, the rule set passes
+	assert.True(t, ValidateRuleset([]*Pattern{evalRule, importRule}, fixtures[:3]).OK())
+}
@@ -0,0 +1,46 @@
+package patterns
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// XPathFunctions evaluate or compile the XPath expression given as their
+// first argument: the xpath method of lxml elements and trees, and the lxml
+// XPath and ETXPath classes.
+var XPathFunctions = []string{"xpath", "XPath", "ETXPath"}
+
+// xpathExpressionArgument returns the expression passed to an XPath
+// function, or "" if callSite is not one. The xpath method is recognized on
+// any receiver, the classes only when they resolve into lxml. Values passed
+// as XPath variables (tree.xpath("//a[@id=$id]", id=value)) are not part of
+// the expression.
+func xpathExpressionArgument(callSite *core.CallSite, functions []string) string {
+	receiver, name, ok := cutLast(callSite.Target, ".")
+	if !ok {
+		name = callSite.Target
+	}
+	if !slices.Contains(functions, name) {
+		return ""
+	}
+	if name == "xpath" {
+		if receiver == "" {
+			return ""
+		}
+		return openArgument(callSite, 0, "_path")
+	}
+	if !strings.HasPrefix(callSite.TargetFQN, "lxml.") {
+		return ""
+	}
+	return openArgument(callSite, 0, "path")
+}
+
+// findXPathInjections returns every XPath evaluation or compilation whose
+// expression is built from tainted data, ordered by function FQN and line.
+func (pr *PatternRegistry) findXPathInjections(pattern *Pattern, callGraph *core.CallGraph) []*PatternMatchDetails {
+	return findTaintedArguments(pattern, callGraph, "XPath expression", func(_ string, callSite *core.CallSite, _ sourceLines) string {
+		return xpathExpressionArgument(callSite, pattern.Sinks)
+	})
+}
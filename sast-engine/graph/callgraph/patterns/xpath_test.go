@@ -0,0 +1,33 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXPathInjection_Expression(t *testing.T) {
+	callGraph := buildFixtureCallGraph(t, "xpath_injection")
+	registry := NewPatternRegistry()
+	registry.LoadDefaultPatterns()
+	pattern, ok := registry.GetPattern("XPATH-INJECTION-001")
+	require.True(t, ok)
+
+	found := make(map[string]string)
+	for _, match := range registry.findXPathInjections(pattern, callGraph) {
+		found[match.SinkFQN] = match.SourceCall + ": " + match.Context
+	}
+
+	// Request data passed as an XPath variable, and constant expressions,
+	// are not flagged.
+	assert.Equal(t, map[string]string{
+		"catalog.find_book":     `request.args: XPath expression "//book[title='" + title + "']" built from request data`,
+		"catalog.compile_query": `request.args: XPath expression f"//book[author='{author}']" built from request data`,
+	}, found)
+
+	match := registry.MatchPattern(pattern, callGraph)
+	require.NotNil(t, match)
+	assert.True(t, match.Matched)
+	assert.Equal(t, "CWE-643", pattern.CWE)
+}
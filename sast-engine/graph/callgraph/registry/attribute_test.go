@@ -1,11 +1,13 @@
 package registry
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewAttributeRegistry(t *testing.T) {
@@ -318,3 +320,20 @@ func TestUpdateExistingAttribute(t *testing.T) {
 	assert.Equal(t, float32(1.0), retrieved.Type.Confidence)
 	assert.Equal(t, "annotation", retrieved.Type.Source)
 }
+
+func TestAttributeRegistry_WriteCallGraph(t *testing.T) {
+	registry := NewAttributeRegistry()
+	registry.AddClassAttributes(&core.ClassAttributes{
+		ClassFQN:   "myapp.User",
+		Attributes: map[string]*core.ClassAttribute{"name": {Name: "name", AssignedIn: "__init__"}},
+		FilePath:   "/myapp/models.py",
+	})
+	cg := core.NewCallGraph()
+	require.NoError(t, cg.SetAttributes(registry))
+
+	var buf bytes.Buffer
+	require.NoError(t, core.WriteCallGraph(&buf, cg))
+	loaded, err := core.ReadCallGraph(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, registry.GetClassAttributes("myapp.User"), loaded.GetAttributes().GetClassAttributes("myapp.User"))
+}
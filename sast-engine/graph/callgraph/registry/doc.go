@@ -19,6 +19,12 @@
 //
 // The registry automatically skips common directories like venv, __pycache__, .git, etc.
 //
+// BuildModuleRegistryWithNotebooks also registers Jupyter notebooks, under
+// the notebook.SourcePath of their synthetic module:
+//
+//	registry, err := registry.BuildModuleRegistryWithNotebooks("/path/to/project", false)
+//	// reports/analysis.ipynb → "reports.analysis"
+//
 // # Builtin Registry
 //
 // BuiltinRegistry provides type information for Python builtin types and functions:
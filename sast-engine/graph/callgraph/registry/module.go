@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/notebook"
 )
 
 // skipDirs lists directory names that should be excluded during module registry building.
@@ -39,6 +40,8 @@ var skipDirs = map[string]bool{
 	"site-packages": true, // If embedded Python packages
 	"docs":          true, // Documentation
 	"examples":      true, // Example code
+
+	".ipynb_checkpoints": true, // Jupyter autosaved notebook copies
 }
 
 // shouldSkipFile checks if a Python file should be excluded from analysis.
@@ -104,7 +107,23 @@ func shouldSkipFile(filename string, skipTests bool) bool {
 //	//   /path/to/myapp/utils/helpers.py → "myapp.utils.helpers"
 //	//   (skips test_*.py files if skipTests=true)
 func BuildModuleRegistry(rootPath string, skipTests bool) (*core.ModuleRegistry, error) {
+	return buildModuleRegistry(rootPath, skipTests, false)
+}
+
+// BuildModuleRegistryWithNotebooks is BuildModuleRegistry that also
+// registers Jupyter notebooks. A notebook is registered under the module
+// path of its name ("reports/analysis.ipynb" → "reports.analysis"), with
+// its notebook.SourcePath as the file; the builder extracts the code cells
+// into that synthetic module. A Python file of the same module wins.
+func BuildModuleRegistryWithNotebooks(rootPath string, skipTests bool) (*core.ModuleRegistry, error) {
+	return buildModuleRegistry(rootPath, skipTests, true)
+}
+
+// buildModuleRegistry implements BuildModuleRegistry, registering notebooks
+// too when includeNotebooks is set.
+func buildModuleRegistry(rootPath string, skipTests, includeNotebooks bool) (*core.ModuleRegistry, error) {
 	registry := core.NewModuleRegistry()
+	notebooks := make(map[string]string) // module path -> notebook source path
 
 	// Verify root path exists
 	if _, err := os.Stat(rootPath); os.IsNotExist(err) {
@@ -133,6 +152,18 @@ func BuildModuleRegistry(rootPath string, skipTests bool) (*core.ModuleRegistry,
 			return nil
 		}
 
+		// Notebooks are registered once every Python file is
+		if includeNotebooks && strings.HasSuffix(path, notebook.Extension) {
+			pythonPath := strings.TrimSuffix(path, notebook.Extension) + ".py"
+			if shouldSkipFile(filepath.Base(pythonPath), skipTests) {
+				return nil
+			}
+			if modulePath, convertErr := convertToModulePath(pythonPath, absRoot); convertErr == nil {
+				notebooks[modulePath] = notebook.SourcePath(path)
+			}
+			return nil
+		}
+
 		// Only process Python files
 		if !strings.HasSuffix(path, ".py") {
 			return nil
@@ -162,6 +193,12 @@ func BuildModuleRegistry(rootPath string, skipTests bool) (*core.ModuleRegistry,
 		return nil, err
 	}
 
+	for modulePath, sourcePath := range notebooks {
+		if _, exists := registry.Modules[modulePath]; !exists {
+			registry.AddModule(modulePath, sourcePath)
+		}
+	}
+
 	return registry, nil
 }
 
@@ -210,6 +247,19 @@ func convertToModulePath(filePath, rootPath string) (string, error) {
 		return "", err
 	}
 
+	return RelativeModulePath(relPath), nil
+}
+
+// RelativeModulePath converts a Python file path relative to the project
+// root into a module path, applying rules 2-4 of convertToModulePath. It
+// suits files that have no location on disk, such as in-memory sources
+// keyed by virtual path.
+//
+// Examples:
+//
+//	"myapp/views.py"          → "myapp.views"
+//	"myapp/utils/__init__.py" → "myapp.utils"
+func RelativeModulePath(relPath string) string {
 	// Remove .py extension
 	relPath = strings.TrimSuffix(relPath, ".py")
 
@@ -224,7 +274,7 @@ func convertToModulePath(filePath, rootPath string) (string, error) {
 	modulePath := filepath.ToSlash(relPath) // Normalize to forward slashes
 	modulePath = strings.ReplaceAll(modulePath, "/", ".")
 
-	return modulePath, nil
+	return modulePath
 }
 
 // shouldSkipDirectory determines if a directory should be excluded from scanning.
@@ -681,3 +681,36 @@ func TestConvertToModulePath_RelErrors(t *testing.T) {
 //
 // These are defensive error checks that should never trigger in normal operation.
 // Current coverage: 93%, which represents all testable paths.
+
+func TestBuildModuleRegistryWithNotebooks(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, dir := range []string{"reports", ".ipynb_checkpoints"} {
+		require.NoError(t, os.Mkdir(filepath.Join(tmpDir, dir), 0755))
+	}
+	files := []string{
+		"app.py",
+		"app.ipynb", // shadowed by app.py
+		"reports/analysis.ipynb",
+		"test_notebook.ipynb",
+		".ipynb_checkpoints/analysis-checkpoint.ipynb",
+	}
+	for _, file := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, file), []byte("{}"), 0644))
+	}
+
+	plain, err := BuildModuleRegistry(tmpDir, true)
+	require.NoError(t, err)
+	assert.Len(t, plain.Modules, 1, "notebooks are opt-in")
+
+	registry, err := BuildModuleRegistryWithNotebooks(tmpDir, true)
+	require.NoError(t, err)
+	assert.Len(t, registry.Modules, 2)
+
+	appPath, ok := registry.GetModulePath("app")
+	require.True(t, ok)
+	assert.True(t, strings.HasSuffix(appPath, "app.py"), "a Python file wins over a notebook")
+
+	analysisPath, ok := registry.GetModulePath("reports.analysis")
+	require.True(t, ok)
+	assert.True(t, strings.HasSuffix(analysisPath, filepath.Join("reports", "analysis.ipynb.py")))
+}
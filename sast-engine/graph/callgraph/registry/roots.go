@@ -0,0 +1,82 @@
+package registry
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// ModuleConflict is a top-level package or module defined under more than
+// one project root.
+type ModuleConflict struct {
+	Package string   // Top-level package name (e.g., "billing")
+	Roots   []string // Absolute roots defining it, in argument order
+}
+
+// ModuleConflictError reports the packages that BuildModuleRegistryFromRoots
+// found under several roots. Merging them would let one root's modules
+// silently shadow the other's.
+type ModuleConflictError struct {
+	Conflicts []ModuleConflict
+}
+
+func (e *ModuleConflictError) Error() string {
+	descriptions := make([]string, 0, len(e.Conflicts))
+	for _, conflict := range e.Conflicts {
+		descriptions = append(descriptions, fmt.Sprintf("%s (%s)", conflict.Package, strings.Join(conflict.Roots, ", ")))
+	}
+	return "package defined in multiple roots: " + strings.Join(descriptions, "; ")
+}
+
+// BuildModuleRegistryFromRoots builds one module registry over several
+// project roots, such as the services of a monorepo. Each root is walked
+// as by BuildModuleRegistry, so module paths are relative to their own
+// root and imports between roots resolve through the merged registry.
+//
+// Two roots defining the same top-level package or module are reported as
+// a *ModuleConflictError instead of letting one shadow the other.
+//
+// Example:
+//
+//	registry, err := BuildModuleRegistryFromRoots([]string{"services/a", "services/b"}, false)
+//	// services/a/billing/invoice.py → "billing.invoice"
+//	// services/b/shop/checkout.py   → "shop.checkout"
+func BuildModuleRegistryFromRoots(roots []string, skipTests bool) (*core.ModuleRegistry, error) {
+	merged := core.NewModuleRegistry()
+	definedBy := make(map[string][]string) // top-level package -> roots
+
+	for _, root := range roots {
+		absRoot, err := filepath.Abs(root)
+		if err != nil {
+			return nil, err // nolint:wrapcheck // Defensive check, untestable
+		}
+		rootRegistry, err := BuildModuleRegistry(absRoot, skipTests)
+		if err != nil {
+			return nil, err
+		}
+		for modulePath, filePath := range rootRegistry.Modules {
+			topLevel, _, _ := strings.Cut(modulePath, ".")
+			if topLevel != "" && !slices.Contains(definedBy[topLevel], absRoot) {
+				definedBy[topLevel] = append(definedBy[topLevel], absRoot)
+			}
+			merged.AddModule(modulePath, filePath)
+		}
+	}
+
+	var conflicts []ModuleConflict
+	for topLevel, definingRoots := range definedBy {
+		if len(definingRoots) > 1 {
+			conflicts = append(conflicts, ModuleConflict{Package: topLevel, Roots: definingRoots})
+		}
+	}
+	if len(conflicts) > 0 {
+		slices.SortFunc(conflicts, func(a, b ModuleConflict) int {
+			return strings.Compare(a.Package, b.Package)
+		})
+		return nil, &ModuleConflictError{Conflicts: conflicts}
+	}
+	return merged, nil
+}
@@ -0,0 +1,39 @@
+package registry
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildModuleRegistryFromRoots(t *testing.T) {
+	services, err := filepath.Abs("../../../test-fixtures/python/multi_root/services")
+	require.NoError(t, err)
+
+	registry, err := BuildModuleRegistryFromRoots([]string{filepath.Join(services, "a"), filepath.Join(services, "b")}, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, filepath.Join(services, "a", "billing", "invoice.py"), registry.Modules["billing.invoice"])
+	assert.Equal(t, filepath.Join(services, "b", "shop", "checkout.py"), registry.Modules["shop.checkout"])
+	assert.Equal(t, "shop", registry.FileToModule[filepath.Join(services, "b", "shop", "__init__.py")])
+}
+
+func TestBuildModuleRegistryFromRoots_Conflict(t *testing.T) {
+	services, err := filepath.Abs("../../../test-fixtures/python/multi_root/services")
+	require.NoError(t, err)
+	roots := []string{filepath.Join(services, "legacy"), filepath.Join(services, "b"), filepath.Join(services, "a")}
+
+	registry, err := BuildModuleRegistryFromRoots(roots, false)
+	assert.Nil(t, registry)
+	var conflictErr *ModuleConflictError
+	require.ErrorAs(t, err, &conflictErr)
+	assert.Equal(t, []ModuleConflict{{Package: "billing", Roots: []string{roots[0], roots[2]}}}, conflictErr.Conflicts)
+	assert.Contains(t, err.Error(), "billing (")
+}
+
+func TestBuildModuleRegistryFromRoots_MissingRoot(t *testing.T) {
+	_, err := BuildModuleRegistryFromRoots([]string{"/nonexistent/root"}, false)
+	assert.Error(t, err)
+}
@@ -0,0 +1,26 @@
+package resolution
+
+// AddAbstractMethod records an @abstractmethod and the overrides defined by
+// concrete subclasses of its class, where calls to it actually go.
+// Thread-safe for concurrent writes.
+//
+// Parameters:
+//   - methodFQN: fully qualified name of the abstract method (e.g., "app.Shape.area")
+//   - overrides: FQNs of the overriding methods (e.g., "app.Circle.area")
+func (te *TypeInferenceEngine) AddAbstractMethod(methodFQN string, overrides []string) {
+	te.typeMutex.Lock()
+	defer te.typeMutex.Unlock()
+	if te.AbstractMethods == nil {
+		te.AbstractMethods = make(map[string][]string)
+	}
+	te.AbstractMethods[methodFQN] = overrides
+}
+
+// AbstractOverrides returns the concrete overrides of an abstract method,
+// and whether methodFQN is abstract. Thread-safe for concurrent reads.
+func (te *TypeInferenceEngine) AbstractOverrides(methodFQN string) ([]string, bool) {
+	te.typeMutex.RLock()
+	defer te.typeMutex.RUnlock()
+	overrides, ok := te.AbstractMethods[methodFQN]
+	return overrides, ok
+}
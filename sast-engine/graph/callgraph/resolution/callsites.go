@@ -44,8 +44,6 @@ import (
 //	    {Caller: "process_data", Callee: "db.query", Args: ["result"]}
 //	  ]
 func ExtractCallSites(filePath string, sourceCode []byte, importMap *core.ImportMap) ([]*core.CallSite, error) {
-	var callSites []*core.CallSite
-
 	// Parse with tree-sitter
 	parser := sitter.NewParser()
 	parser.SetLanguage(python.GetLanguage())
@@ -57,11 +55,19 @@ func ExtractCallSites(filePath string, sourceCode []byte, importMap *core.Import
 	}
 	defer tree.Close()
 
+	return ExtractCallSitesFromAST(filePath, sourceCode, tree.RootNode(), importMap), nil
+}
+
+// ExtractCallSitesFromAST is like ExtractCallSites but works on an
+// already-parsed tree, so callers sharing an AST across passes skip re-parsing.
+func ExtractCallSitesFromAST(filePath string, sourceCode []byte, root *sitter.Node, importMap *core.ImportMap) []*core.CallSite {
+	var callSites []*core.CallSite
+
 	// Traverse AST to find call expressions
 	// We need to track the current function/method context as we traverse
-	traverseForCalls(tree.RootNode(), sourceCode, filePath, importMap, "", &callSites)
+	traverseForCalls(root, sourceCode, filePath, importMap, "", &callSites)
 
-	return callSites, nil
+	return callSites
 }
 
 // traverseForCalls recursively traverses the AST to find call expressions.
@@ -155,8 +161,10 @@ func processCallExpression(
 	// Get arguments
 	argumentsNode := node.ChildByFieldName("arguments")
 	var args []*core.Argument
+	var keywordArgs map[string]string
 	if argumentsNode != nil {
 		args = extractArguments(argumentsNode, sourceCode)
+		keywordArgs = extractKeywordArguments(argumentsNode, sourceCode)
 	}
 
 	// Create source location
@@ -169,9 +177,10 @@ func processCallExpression(
 	return &core.CallSite{
 		Target:    callee,
 		Location:  *location,
-		Arguments: convertArgumentsToSlice(args),
-		Resolved:  false,
-		TargetFQN: "", // Will be set during resolution phase
+		Arguments:   convertArgumentsToSlice(args),
+		KeywordArgs: keywordArgs,
+		Resolved:    false,
+		TargetFQN:   "", // Will be set during resolution phase
 	}
 }
 
@@ -231,11 +240,15 @@ func extractCalleeName(node *sitter.Node, sourceCode []byte) string {
 // Handles both positional and keyword arguments.
 //
 // Note: The Argument struct doesn't distinguish between positional and keyword arguments.
-// For keyword arguments (name=value), we store them as "name=value" in the Value field.
+// For keyword arguments (name=value), we store them as "name=value" in the Value field;
+// extractKeywordArguments maps them by name. Splats are flagged, and keep their place
+// in the argument list.
 //
 // Examples:
 //   - (a, b, c) → [Arg{Value: "a", Position: 0}, Arg{Value: "b", Position: 1}, ...]
 //   - (x, y=2, z=foo) → [Arg{Value: "x", Position: 0}, Arg{Value: "y=2", Position: 1}, ...]
+//   - (*rows, **data) → [Arg{Value: "*rows", Position: 0, IsListSpread: true},
+//     Arg{Value: "**data", Position: 1, IsDictSpread: true}]
 //
 // Parameters:
 //   - argumentsNode: argument_list AST node
@@ -256,9 +269,11 @@ func extractArguments(argumentsNode *sitter.Node, sourceCode []byte) []*core.Arg
 		// For all argument types, just extract the full content
 		// This handles both positional and keyword arguments
 		arg := &core.Argument{
-			Value:      child.Content(sourceCode),
-			IsVariable: child.Type() == "identifier",
-			Position:   i,
+			Value:        child.Content(sourceCode),
+			IsVariable:   child.Type() == "identifier",
+			Position:     i,
+			IsDictSpread: child.Type() == "dictionary_splat",
+			IsListSpread: child.Type() == "list_splat",
 		}
 		args = append(args, arg)
 	}
@@ -266,6 +281,29 @@ func extractArguments(argumentsNode *sitter.Node, sourceCode []byte) []*core.Arg
 	return args
 }
 
+// extractKeywordArguments maps the keyword arguments of an argument_list
+// node to the expressions they pass: (x, sql=query) → {"sql": "query"}.
+// Returns nil if there are none.
+func extractKeywordArguments(argumentsNode *sitter.Node, sourceCode []byte) map[string]string {
+	var keywordArgs map[string]string
+	for i := 0; i < int(argumentsNode.NamedChildCount()); i++ {
+		child := argumentsNode.NamedChild(i)
+		if child == nil || child.Type() != "keyword_argument" {
+			continue
+		}
+		name := child.ChildByFieldName("name")
+		value := child.ChildByFieldName("value")
+		if name == nil || value == nil {
+			continue
+		}
+		if keywordArgs == nil {
+			keywordArgs = make(map[string]string)
+		}
+		keywordArgs[name.Content(sourceCode)] = value.Content(sourceCode)
+	}
+	return keywordArgs
+}
+
 // convertArgumentsToSlice converts a slice of Argument pointers to a slice of Argument values.
 func convertArgumentsToSlice(args []*core.Argument) []core.Argument {
 	result := make([]core.Argument, len(args))
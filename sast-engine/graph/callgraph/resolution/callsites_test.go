@@ -316,6 +316,52 @@ def process():
 	assert.Equal(t, "value=42", callSites[0].Arguments[1].Value)
 
 	assert.Equal(t, "enabled=True", callSites[0].Arguments[2].Value)
+
+	assert.Equal(t, map[string]string{"name": `"test"`, "value": "42", "enabled": "True"}, callSites[0].KeywordArgs)
+}
+
+func TestExtractArguments_KeywordArgs(t *testing.T) {
+	sourceCode := []byte(`
+def search(request):
+    cursor.execute(sql=f"SELECT * FROM t WHERE id = {request.GET['id']}", params=None)
+    User.objects.raw("SELECT 1")
+`)
+
+	importMap := core.NewImportMap("/test/file.py")
+	callSites, err := ExtractCallSites("/test/file.py", sourceCode, importMap)
+
+	require.NoError(t, err)
+	require.Len(t, callSites, 2)
+	assert.Equal(t, map[string]string{
+		"sql":    `f"SELECT * FROM t WHERE id = {request.GET['id']}"`,
+		"params": "None",
+	}, callSites[0].KeywordArgs)
+	assert.Nil(t, callSites[1].KeywordArgs, "positional arguments only")
+}
+
+func TestExtractArguments_DictSpread(t *testing.T) {
+	sourceCode := []byte(`
+def process(request, data):
+    save(data, *extra, mode="x", **request.POST)
+`)
+
+	importMap := core.NewImportMap("/test/file.py")
+	callSites, err := ExtractCallSites("/test/file.py", sourceCode, importMap)
+
+	require.NoError(t, err)
+	require.Len(t, callSites, 1)
+	require.Len(t, callSites[0].Arguments, 4)
+
+	assert.False(t, callSites[0].Arguments[0].IsDictSpread)
+	assert.False(t, callSites[0].Arguments[1].IsDictSpread, "*args is not a dict spread")
+	assert.False(t, callSites[0].Arguments[2].IsDictSpread)
+	assert.True(t, callSites[0].Arguments[3].IsDictSpread)
+	assert.Equal(t, "**request.POST", callSites[0].Arguments[3].Value)
+
+	assert.True(t, callSites[0].Arguments[1].IsListSpread)
+	assert.Equal(t, 1, callSites[0].Arguments[1].Position)
+	assert.False(t, callSites[0].Arguments[3].IsListSpread, "**kwargs is not a list spread")
+	assert.Equal(t, map[string]string{"mode": `"x"`}, callSites[0].KeywordArgs)
 }
 
 func TestExtractCalleeName_Identifier(t *testing.T) {
@@ -319,12 +319,9 @@ func resolveFirstChainStep(
 	varName := step.MethodName
 
 	// Check function scope first
-	functionScope := typeEngine.GetScope(callerFQN)
-	if functionScope != nil {
-		if binding := functionScope.GetVariable(varName); binding != nil {
-			if binding.Type != nil {
-				return binding.Type, varName, true
-			}
+	if binding := typeEngine.GetVariableInScope(callerFQN, varName); binding != nil {
+		if binding.Type != nil {
+			return binding.Type, varName, true
 		}
 	}
 
@@ -0,0 +1,189 @@
+package resolution
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/registry"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Decorators change what a function effectively is. This file keeps their
+// semantics in one place:
+//
+//   - @contextmanager turns a generator into a context manager factory:
+//     calling it returns a context manager, and `with f() as x` binds x to
+//     the value the generator yields.
+//   - Route decorators (@app.route, @router.get, ...) register a request
+//     handler whose parameters the framework fills from the request.
+
+// ContextManagerDecorators turn a generator function into a context manager
+// factory.
+var ContextManagerDecorators = []string{
+	"contextmanager",
+	"contextlib.contextmanager",
+	"asynccontextmanager",
+	"contextlib.asynccontextmanager",
+}
+
+// ContextManagerTypeFQN is the type returned by calling a @contextmanager
+// function.
+const ContextManagerTypeFQN = "contextlib._GeneratorContextManager"
+
+// RouteDecoratorMethods are the methods of Flask, Quart, Sanic, and FastAPI
+// applications, blueprints, and routers that register a route handler
+// (e.g., @app.route, @bp.get, @router.post).
+var RouteDecoratorMethods = []string{
+	"route", "get", "post", "put", "patch", "delete", "head", "options",
+	"websocket", "api_route",
+}
+
+// RouteParameterSource is the taint source seeded for the parameters of a
+// route handler. Frameworks fill them from the URL path, query string, or
+// body; Flask exposes the URL values as request.view_args.
+const RouteParameterSource = "request.view_args"
+
+// IsContextManagerDecorator reports whether a decorator name (as recorded on
+// graph nodes, without arguments) is @contextmanager or its async variant.
+func IsContextManagerDecorator(decorator string) bool {
+	return slices.Contains(ContextManagerDecorators, decorator)
+}
+
+// IsRouteDecorator reports whether a decorator name registers a route
+// handler: a method in RouteDecoratorMethods called on an application,
+// blueprint, or router object, such as "app.route" or "router.get".
+func IsRouteDecorator(decorator string) bool {
+	receiver, method, ok := cutLastDot(decorator)
+	return ok && receiver != "" && slices.Contains(RouteDecoratorMethods, method)
+}
+
+// IsRouteHandler reports whether any of a function's decorators registers it
+// as a route handler.
+func IsRouteHandler(decorators []string) bool {
+	return slices.ContainsFunc(decorators, IsRouteDecorator)
+}
+
+// RouteParameters returns the names of the parameters a route-decorated
+// function receives from the request, in declaration order. Returns nil for
+// functions that are not route handlers. self and cls are skipped.
+//
+// Example:
+//
+//	@app.route("/users/<user_id>")
+//	def show_user(user_id, fmt="html"):  → ["user_id", "fmt"]
+func RouteParameters(function *graph.Node) []string {
+	if function == nil || !IsRouteHandler(function.Annotation) {
+		return nil
+	}
+	var names []string
+	for _, param := range function.MethodArgumentsValue {
+		name, _, _ := strings.Cut(param, "=")
+		name, _, _ = strings.Cut(name, ":")
+		name = strings.TrimSpace(name)
+		if name == "" || name == "self" || name == "cls" {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// cutLastDot splits a dotted name at its last dot.
+func cutLastDot(name string) (before, after string, found bool) {
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		return name[:i], name[i+1:], true
+	}
+	return "", name, false
+}
+
+// functionDecorators returns the decorator names of a function_definition
+// node, without "@" or call arguments (e.g., "app.route"), matching the
+// names recorded on graph nodes.
+func functionDecorators(functionNode *sitter.Node, sourceCode []byte) []string {
+	parent := functionNode.Parent()
+	if parent == nil || parent.Type() != "decorated_definition" {
+		return nil
+	}
+	var decorators []string
+	for i := 0; i < int(parent.NamedChildCount()); i++ {
+		child := parent.NamedChild(i)
+		if child.Type() != "decorator" {
+			continue
+		}
+		name := strings.TrimPrefix(child.Content(sourceCode), "@")
+		name, _, _ = strings.Cut(name, "(")
+		decorators = append(decorators, strings.TrimSpace(name))
+	}
+	return decorators
+}
+
+// isContextManagerFunction reports whether a function_definition node is
+// decorated with @contextmanager.
+func isContextManagerFunction(functionNode *sitter.Node, sourceCode []byte) bool {
+	return slices.ContainsFunc(functionDecorators(functionNode, sourceCode), IsContextManagerDecorator)
+}
+
+// extractYieldTypes records the values a generator yields, bound by `with`
+// for @contextmanager functions and by iteration otherwise, as yield
+// statements of functionFQN. Nested functions and classes are skipped,
+// since their yields belong to their own scopes.
+func extractYieldTypes(
+	node *sitter.Node,
+	sourceCode []byte,
+	filePath string,
+	modulePath string,
+	functionFQN string,
+	returns *[]*ReturnStatement,
+	builtinRegistry *registry.BuiltinRegistry,
+	importMap *core.ImportMap,
+) {
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+		switch child.Type() {
+		case "function_definition", "class_definition", "lambda":
+			continue
+		case "yield":
+			if child.NamedChildCount() == 0 || strings.HasPrefix(child.Content(sourceCode), "yield from") {
+				continue
+			}
+			valueNode := child.NamedChild(0)
+			yieldType := resolveClsInstantiation(valueNode, sourceCode, functionFQN)
+			if yieldType == nil {
+				yieldType = inferReturnType(valueNode, sourceCode, modulePath, builtinRegistry, importMap)
+			}
+			if yieldType != nil {
+				*returns = append(*returns, &ReturnStatement{
+					FunctionFQN: functionFQN,
+					ReturnType:  yieldType,
+					Location: Location{
+						File:   filePath,
+						Line:   child.StartPoint().Row + 1,
+						Column: child.StartPoint().Column + 1,
+					},
+					Yield: true,
+				})
+			}
+		}
+		extractYieldTypes(child, sourceCode, filePath, modulePath, functionFQN, returns, builtinRegistry, importMap)
+	}
+}
+
+// RouteSourceStatements returns synthetic statements that seed taint for a
+// route handler's parameters: each parameter is defined by a read of
+// RouteParameterSource on the function's def line. Prepend them to the
+// function's statements before taint analysis. Returns nil for functions
+// that are not route handlers.
+func RouteSourceStatements(function *graph.Node) []*core.Statement {
+	var seeds []*core.Statement
+	for _, param := range RouteParameters(function) {
+		seeds = append(seeds, &core.Statement{
+			Type:       core.StatementTypeAssignment,
+			LineNumber: function.LineNumber,
+			Def:        param,
+			CallTarget: RouteParameterSource,
+		})
+	}
+	return seeds
+}
@@ -0,0 +1,91 @@
+package resolution
+
+import (
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRouteDecorator(t *testing.T) {
+	tests := []struct {
+		decorator string
+		want      bool
+	}{
+		{"app.route", true},
+		{"bp.route", true},
+		{"router.get", true},
+		{"app.post", true},
+		{"api.v1.router.delete", true},
+		{"route", false},
+		{"property", false},
+		{"name.setter", false},
+		{"login_required", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.decorator, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsRouteDecorator(tt.decorator))
+		})
+	}
+}
+
+func TestIsContextManagerDecorator(t *testing.T) {
+	assert.True(t, IsContextManagerDecorator("contextmanager"))
+	assert.True(t, IsContextManagerDecorator("contextlib.asynccontextmanager"))
+	assert.False(t, IsContextManagerDecorator("cached_property"))
+}
+
+func TestRouteParameters(t *testing.T) {
+	handler := &graph.Node{
+		Name:                 "show_user",
+		Annotation:           []string{"login_required", "app.route"},
+		MethodArgumentsValue: []string{"self", "user_id: int", "fmt=\"html\"", "page: int = 1"},
+	}
+	assert.Equal(t, []string{"user_id", "fmt", "page"}, RouteParameters(handler))
+
+	helper := &graph.Node{Name: "helper", MethodArgumentsValue: []string{"user_id"}}
+	assert.Nil(t, RouteParameters(helper))
+	assert.Nil(t, RouteParameters(nil))
+}
+
+func TestRouteSourceStatements(t *testing.T) {
+	handler := &graph.Node{
+		Name:                 "go",
+		LineNumber:           7,
+		Annotation:           []string{"app.route"},
+		MethodArgumentsValue: []string{"target"},
+	}
+	seeds := RouteSourceStatements(handler)
+	require.Len(t, seeds, 1)
+	assert.Equal(t, core.StatementTypeAssignment, seeds[0].Type)
+	assert.Equal(t, "target", seeds[0].Def)
+	assert.Equal(t, RouteParameterSource, seeds[0].CallTarget)
+	assert.Equal(t, uint32(7), seeds[0].LineNumber)
+}
+
+func TestExtractReturnTypes_ContextManager(t *testing.T) {
+	source := []byte(`from contextlib import contextmanager
+
+@contextmanager
+def opened():
+    yield "handle"
+
+def plain():
+    return 1
+`)
+	returns, withValues, err := ExtractReturnTypes("test.py", source, "test", nil, nil)
+	require.NoError(t, err)
+	assert.True(t, withValues["test.opened"])
+
+	merged := MergeReturnTypes(returns)
+	require.Contains(t, merged, "test.opened")
+	assert.Equal(t, ContextManagerTypeFQN, merged["test.opened"].TypeFQN)
+	assert.Equal(t, "builtins.int", merged["test.plain"].TypeFQN)
+
+	enter := MergeEnterTypes(returns)
+	require.Contains(t, enter, "test.opened")
+	assert.Equal(t, "builtins.str", enter["test.opened"].TypeFQN)
+	assert.NotContains(t, enter, "test.plain")
+}
@@ -30,6 +30,35 @@
 //	    Type:    &core.TypeInfo{TypeFQN: "myapp.models.User"},
 //	})
 //
+// # Enum Members
+//
+// Members registered with AddEnumMember type attribute reads on the enum:
+// Color.RED is a Color, Color.RED.value has the type of RED's value, and
+// .name is a str. ResolveEnumMemberBindings applies this to the "attr:"
+// placeholders recorded during variable extraction:
+//
+//	engine.AddEnumMember("app.Color", "RED", intType)
+//	engine.ResolveEnumMemberBindings()
+//
+// # Properties
+//
+// Getters registered with AddProperty (@property and @cached_property) type
+// attribute reads on their class as the getter's return type, preferring its
+// annotation. ResolvePropertyBindings runs once call results are typed, so
+// report = load_report(); title = report.title types title:
+//
+//	engine.AddProperty("app.Report.title", strType)
+//	engine.ResolvePropertyBindings()
+//
+// # Single Dispatch
+//
+// AddSingleDispatch records a @singledispatch function with its registered
+// implementations, and SingleDispatchImplementation picks the one a call
+// runs for the type of its first argument:
+//
+//	engine.AddSingleDispatch("app.render", map[string]string{"builtins.int": "app._render_int"})
+//	impl, _ := engine.SingleDispatchImplementation("app.render", "builtins.int") // app._render_int
+//
 // # Breaking Circular Dependencies
 //
 // This package was created to resolve the circular dependency between
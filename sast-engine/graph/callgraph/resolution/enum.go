@@ -0,0 +1,172 @@
+package resolution
+
+import (
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// AddEnumMember records a member of an enum class and the type of its value.
+// valueType may be nil when the value cannot be inferred; the member still
+// types as the enum. Thread-safe for concurrent writes.
+//
+// Parameters:
+//   - enumFQN: fully qualified name of the enum class (e.g., "app.Color")
+//   - member: member name (e.g., "RED")
+//   - valueType: type of the member's value, or nil
+func (te *TypeInferenceEngine) AddEnumMember(enumFQN, member string, valueType *core.TypeInfo) {
+	te.typeMutex.Lock()
+	defer te.typeMutex.Unlock()
+	if te.Enums == nil {
+		te.Enums = make(map[string]map[string]*core.TypeInfo)
+	}
+	if te.Enums[enumFQN] == nil {
+		te.Enums[enumFQN] = make(map[string]*core.TypeInfo)
+	}
+	te.Enums[enumFQN][member] = valueType
+}
+
+// IsEnum reports whether typeFQN is a known enum class.
+// Thread-safe for concurrent reads.
+func (te *TypeInferenceEngine) IsEnum(typeFQN string) bool {
+	te.typeMutex.RLock()
+	defer te.typeMutex.RUnlock()
+	_, ok := te.Enums[typeFQN]
+	return ok
+}
+
+// ResolveEnumMemberBindings resolves the "attr:" placeholders left by
+// attribute reads such as x = Color.RED:
+//
+//	Color.RED        → app.Color
+//	Color.RED.value  → type of RED's value (builtins.int for RED = 1)
+//	Color.RED.name   → builtins.str
+//	c.value          → the value type shared by every member, when c is a Color
+//
+// Placeholders that are not enum accesses are removed, as no type was
+// inferred for them, except reads of a property, which are left for
+// ResolvePropertyBindings. Must be called AFTER ExtractVariableAssignments
+// and BEFORE ResolveReturnVariableReferences.
+func (te *TypeInferenceEngine) ResolveEnumMemberBindings() {
+	// Resolve until no binding changes, so x = Color.RED; y = x.value
+	// resolves regardless of the order scopes are visited in.
+	for changed := true; changed; {
+		changed = false
+		for _, scope := range te.Scopes {
+			for _, bindings := range scope.Variables {
+				for _, binding := range bindings {
+					if binding == nil || binding.Type == nil || !strings.HasPrefix(binding.Type.TypeFQN, "attr:") {
+						continue
+					}
+					if resolved := te.resolveEnumAccess(scope.FunctionFQN, strings.TrimPrefix(binding.Type.TypeFQN, "attr:")); resolved != nil {
+						binding.Type = resolved
+						changed = true
+					}
+				}
+			}
+		}
+	}
+
+	te.dropAttributePlaceholders(te.readsProperty)
+}
+
+// dropAttributePlaceholders removes the "attr:" bindings left unresolved,
+// except those whose chain keep reports true.
+func (te *TypeInferenceEngine) dropAttributePlaceholders(keep func(chain string) bool) {
+	for _, scope := range te.Scopes {
+		for varName, bindings := range scope.Variables {
+			kept := bindings[:0]
+			for _, binding := range bindings {
+				if binding != nil && binding.Type != nil && strings.HasPrefix(binding.Type.TypeFQN, "attr:") &&
+					(keep == nil || !keep(strings.TrimPrefix(binding.Type.TypeFQN, "attr:"))) {
+					continue
+				}
+				kept = append(kept, binding)
+			}
+			if len(kept) == 0 {
+				delete(scope.Variables, varName)
+			} else {
+				scope.Variables[varName] = kept
+			}
+		}
+	}
+}
+
+// resolveEnumAccess types an attribute chain read in scopeFQN, e.g.
+// "Color.RED.value", or returns nil if it is not an enum access.
+func (te *TypeInferenceEngine) resolveEnumAccess(scopeFQN, chain string) *core.TypeInfo {
+	parts := strings.Split(chain, ".")
+	if len(parts) < 2 {
+		return nil
+	}
+
+	// Instance receiver: c.value / c.name where c is typed as an enum.
+	if binding := te.GetVariableInScope(scopeFQN, parts[0]); binding != nil && binding.Type != nil {
+		if len(parts) != 2 || !te.IsEnum(binding.Type.TypeFQN) {
+			return nil
+		}
+		return te.enumMemberAttribute(binding.Type.TypeFQN, "", parts[1], binding.Type.Confidence)
+	}
+
+	// Class receiver: Color.RED, Color.RED.value / Color.RED.name.
+	enumFQN := te.resolveClassReceiver(scopeFQN, parts[0])
+	if enumFQN == "" || len(parts) > 3 {
+		return nil
+	}
+	te.typeMutex.RLock()
+	_, isMember := te.Enums[enumFQN][parts[1]]
+	te.typeMutex.RUnlock()
+	if !isMember {
+		return nil
+	}
+	if len(parts) == 2 {
+		return &core.TypeInfo{
+			TypeFQN:    enumFQN,
+			Confidence: 0.95,
+			Source:     "enum_member",
+		}
+	}
+	return te.enumMemberAttribute(enumFQN, parts[1], parts[2], 0.95)
+}
+
+// enumMemberAttribute types .value or .name on a member of enumFQN. An empty
+// member means any member, so .value types only if all members agree.
+func (te *TypeInferenceEngine) enumMemberAttribute(enumFQN, member, attr string, confidence float32) *core.TypeInfo {
+	switch attr {
+	case "name":
+		return &core.TypeInfo{
+			TypeFQN:    "builtins.str",
+			Confidence: confidence,
+			Source:     "enum_name",
+		}
+	case "value":
+		valueType := te.enumValueType(enumFQN, member)
+		if valueType == nil {
+			return nil
+		}
+		return &core.TypeInfo{
+			TypeFQN:    valueType.TypeFQN,
+			Confidence: valueType.Confidence * confidence,
+			Source:     "enum_value",
+		}
+	}
+	return nil
+}
+
+// enumValueType returns the value type of member, or for an empty member
+// the type every member's value shares. Returns nil if unknown or mixed.
+func (te *TypeInferenceEngine) enumValueType(enumFQN, member string) *core.TypeInfo {
+	te.typeMutex.RLock()
+	defer te.typeMutex.RUnlock()
+	if member != "" {
+		return te.Enums[enumFQN][member]
+	}
+	var shared *core.TypeInfo
+	for _, valueType := range te.Enums[enumFQN] {
+		if valueType == nil || (shared != nil && shared.TypeFQN != valueType.TypeFQN) {
+			return nil
+		}
+		shared = valueType
+	}
+	return shared
+}
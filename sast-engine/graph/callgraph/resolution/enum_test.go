@@ -0,0 +1,48 @@
+package resolution
+
+import (
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveEnumMemberBindings(t *testing.T) {
+	engine := NewTypeInferenceEngine(&core.ModuleRegistry{
+		Modules:      map[string]string{"app": "/app.py"},
+		FileToModule: map[string]string{"/app.py": "app"},
+	})
+	intType := &core.TypeInfo{TypeFQN: "builtins.int", Confidence: 1.0}
+	strType := &core.TypeInfo{TypeFQN: "builtins.str", Confidence: 1.0}
+	engine.AddEnumMember("app.Code", "OK", intType)
+	engine.AddEnumMember("app.Code", "UNKNOWN", strType)
+
+	scope := NewFunctionScope("app.handle")
+	for name, chain := range map[string]string{
+		"code":    "Code.OK",
+		"value":   "code.value",
+		"unknown": "Code.UNKNOWN.value",
+		"missing": "Code.MISSING",
+		"other":   "request.user",
+	} {
+		scope.AddVariable(&VariableBinding{
+			VarName: name,
+			Type:    &core.TypeInfo{TypeFQN: "attr:" + chain, Confidence: 0.5},
+		})
+	}
+	engine.AddScope(scope)
+
+	engine.ResolveEnumMemberBindings()
+
+	require.NotNil(t, scope.GetVariable("code"))
+	assert.Equal(t, "app.Code", scope.GetVariable("code").Type.TypeFQN)
+	assert.Equal(t, "builtins.str", scope.GetVariable("unknown").Type.TypeFQN)
+
+	// Code members have int and str values, so an unknown member's value is untyped
+	assert.Nil(t, scope.GetVariable("value"))
+	assert.Nil(t, scope.GetVariable("missing"))
+	assert.Nil(t, scope.GetVariable("other"))
+	assert.True(t, engine.IsEnum("app.Code"))
+	assert.False(t, engine.IsEnum("app.Other"))
+}
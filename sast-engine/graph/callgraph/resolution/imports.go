@@ -53,8 +53,6 @@ import (
 //	    "settings": "myapp.config.settings"
 //	  }
 func ExtractImports(filePath string, sourceCode []byte, registry *core.ModuleRegistry) (*core.ImportMap, error) {
-	importMap := core.NewImportMap(filePath)
-
 	// Parse with tree-sitter
 	parser := sitter.NewParser()
 	parser.SetLanguage(python.GetLanguage())
@@ -66,10 +64,18 @@ func ExtractImports(filePath string, sourceCode []byte, registry *core.ModuleReg
 	}
 	defer tree.Close()
 
+	return ExtractImportsFromAST(filePath, sourceCode, tree.RootNode(), registry), nil
+}
+
+// ExtractImportsFromAST is like ExtractImports but works on an already-parsed
+// tree, so callers sharing an AST across passes skip re-parsing.
+func ExtractImportsFromAST(filePath string, sourceCode []byte, root *sitter.Node, registry *core.ModuleRegistry) *core.ImportMap {
+	importMap := core.NewImportMap(filePath)
+
 	// Traverse AST to find import statements
-	traverseForImports(tree.RootNode(), sourceCode, importMap, filePath, registry)
+	traverseForImports(root, sourceCode, importMap, filePath, registry)
 
-	return importMap, nil
+	return importMap
 }
 
 // traverseForImports recursively traverses the AST to find import statements.
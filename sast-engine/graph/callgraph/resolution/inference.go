@@ -1,6 +1,8 @@
 package resolution
 
 import (
+	"cmp"
+	"slices"
 	"strings"
 	"sync"
 
@@ -14,6 +16,8 @@ import (
 type TypeInferenceEngine struct {
 	Scopes         map[string]*FunctionScope   // Function FQN -> scope
 	ReturnTypes    map[string]*core.TypeInfo   // Function FQN -> return type
+	EnterTypes     map[string]*core.TypeInfo   // @contextmanager function FQN -> type bound by "with f() as x"
+	ElementTypes   map[string]*core.TypeInfo   // Generator function FQN -> type bound by "for x in f()"
 	Builtins       *registry.BuiltinRegistry   // Builtin types registry
 	Registry       *core.ModuleRegistry        // Module registry reference
 	Attributes     *registry.AttributeRegistry // Class attributes registry (Phase 3 Task 12)
@@ -21,6 +25,11 @@ type TypeInferenceEngine struct {
 	StdlibRemote     any                         // Remote loader for lazy module loading (PR #3)
 	ThirdPartyRemote any                         // Remote loader for third-party type registries (PR #4)
 	ImportMaps       map[string]*core.ImportMap  // File path -> ImportMap (P0 fix: for attribute placeholder resolution)
+	Enums            map[string]map[string]*core.TypeInfo // Enum class FQN -> member name -> value type
+	Protocols        map[string][]string                  // Protocol/ABC class FQN -> implementing class FQNs
+	Properties       map[string]*core.TypeInfo            // @property/@cached_property getter FQN -> annotated type (nil if unannotated)
+	AbstractMethods  map[string][]string                  // @abstractmethod FQN -> concrete override FQNs
+	SingleDispatch   map[string]map[string]string         // @singledispatch FQN -> dispatch type FQN -> implementation FQN
 	scopeMutex     sync.RWMutex                // Protects Scopes map for concurrent access
 	typeMutex      sync.RWMutex                // Protects ReturnTypes map for concurrent access
 	importMutex    sync.RWMutex                // Protects ImportMaps for concurrent access
@@ -42,7 +51,14 @@ func NewTypeInferenceEngine(registry *core.ModuleRegistry) *TypeInferenceEngine
 	return &TypeInferenceEngine{
 		Scopes:      make(map[string]*FunctionScope),
 		ReturnTypes: make(map[string]*core.TypeInfo),
+		EnterTypes:  make(map[string]*core.TypeInfo),
+		ElementTypes: make(map[string]*core.TypeInfo),
 		ImportMaps:  make(map[string]*core.ImportMap),
+		Enums:       make(map[string]map[string]*core.TypeInfo),
+		Protocols:   make(map[string][]string),
+		Properties:  make(map[string]*core.TypeInfo),
+		AbstractMethods: make(map[string][]string),
+		SingleDispatch:  make(map[string]map[string]string),
 		Registry:    registry,
 	}
 }
@@ -61,6 +77,30 @@ func (te *TypeInferenceEngine) GetScope(functionFQN string) *FunctionScope {
 	return te.Scopes[functionFQN]
 }
 
+// GetVariableInScope returns the last binding of varName visible in a
+// function scope. A name the function declares global or nonlocal is
+// looked up in the scope it is rebound to (see FunctionScope.Rebound).
+// Thread-safe for concurrent reads.
+//
+// Parameters:
+//   - functionFQN: fully qualified name of the function
+//   - varName: variable name to look up
+//
+// Returns:
+//   - VariableBinding if found, nil otherwise
+func (te *TypeInferenceEngine) GetVariableInScope(functionFQN, varName string) *VariableBinding {
+	scope := te.GetScope(functionFQN)
+	if scope == nil {
+		return nil
+	}
+	if target, ok := scope.Rebound[varName]; ok {
+		if scope = te.GetScope(target); scope == nil {
+			return nil
+		}
+	}
+	return scope.GetVariable(varName)
+}
+
 // AddScope adds or updates a function scope in the engine.
 // Thread-safe for concurrent writes.
 //
@@ -128,6 +168,24 @@ func (te *TypeInferenceEngine) GetReturnType(functionFQN string) (*core.TypeInfo
 	return typeInfo, ok
 }
 
+// GetEnterType retrieves the type a @contextmanager function's yield binds in
+// `with f() as x`. Thread-safe for concurrent reads.
+func (te *TypeInferenceEngine) GetEnterType(functionFQN string) (*core.TypeInfo, bool) {
+	te.typeMutex.RLock()
+	defer te.typeMutex.RUnlock()
+	typeInfo, ok := te.EnterTypes[functionFQN]
+	return typeInfo, ok
+}
+
+// GetElementType retrieves the type a generator function's yield binds in
+// `for x in f()`. Thread-safe for concurrent reads.
+func (te *TypeInferenceEngine) GetElementType(functionFQN string) (*core.TypeInfo, bool) {
+	te.typeMutex.RLock()
+	defer te.typeMutex.RUnlock()
+	typeInfo, ok := te.ElementTypes[functionFQN]
+	return typeInfo, ok
+}
+
 // ResolveVariableType resolves the type of a variable assignment from a function call.
 // It looks up the return type of the called function and propagates it with confidence decay.
 // Thread-safe for concurrent reads.
@@ -239,6 +297,13 @@ func (te *TypeInferenceEngine) UpdateVariableBindingsWithFunctionReturns() {
 							// This variable will be resolved in a future iteration
 							continue
 						}
+					} else if classFQN := te.resolveClassReceiver(scope.FunctionFQN, receiver); classFQN != "" && !strings.Contains(methodName, ".") {
+						// Class receiver: User.from_dict() → "models.User.from_dict"
+						// Enables classmethod factories to type their result.
+						funcFQN = classFQN + "." + methodName
+						if _, ok := te.GetReturnType(funcFQN); !ok {
+							funcFQN = funcName
+						}
 					} else {
 						// Not a variable - assume it's a module path (e.g., "logging.getLogger")
 						funcFQN = funcName
@@ -256,8 +321,31 @@ func (te *TypeInferenceEngine) UpdateVariableBindingsWithFunctionReturns() {
 					}
 				}
 
-				// Resolve type
+				// Resolve type; `with f() as x` binds what a @contextmanager f yields
 				resolvedType := te.ResolveVariableType(funcFQN, binding.Type.Confidence)
+				if binding.WithTarget {
+					if enterType, ok := te.GetEnterType(funcFQN); ok &&
+						!strings.HasPrefix(enterType.TypeFQN, "call:") && !strings.HasPrefix(enterType.TypeFQN, "var:") {
+						resolvedType = &core.TypeInfo{
+							TypeFQN:    enterType.TypeFQN,
+							Confidence: enterType.Confidence * binding.Type.Confidence * 0.95,
+							Source:     "context_manager_yield",
+						}
+					}
+				}
+				// `for x in f()` binds what a generator f yields, and nothing
+				// known for other functions
+				if binding.ForTarget {
+					resolvedType = nil
+					if elementType, ok := te.GetElementType(funcFQN); ok &&
+						!strings.HasPrefix(elementType.TypeFQN, "call:") && !strings.HasPrefix(elementType.TypeFQN, "var:") {
+						resolvedType = &core.TypeInfo{
+							TypeFQN:    elementType.TypeFQN,
+							Confidence: elementType.Confidence * binding.Type.Confidence * 0.95,
+							Source:     "generator_yield",
+						}
+					}
+				}
 				if resolvedType != nil {
 					scope.Variables[varName][i].Type = resolvedType
 					scope.Variables[varName][i].AssignedFrom = funcFQN
@@ -267,6 +355,34 @@ func (te *TypeInferenceEngine) UpdateVariableBindingsWithFunctionReturns() {
 	}
 }
 
+// resolveClassReceiver resolves a PascalCase receiver name used in scopeFQN to a
+// class FQN, through the file's imports first and then the scope's own module.
+// Returns "" if the receiver does not look like a class or the module is unknown.
+func (te *TypeInferenceEngine) resolveClassReceiver(scopeFQN, receiver string) string {
+	if !isPascalCase(receiver) || te.Registry == nil {
+		return ""
+	}
+
+	// Find the module containing the scope (longest matching prefix).
+	modulePath := scopeFQN
+	filePath, ok := te.Registry.Modules[modulePath]
+	for !ok {
+		lastDot := strings.LastIndex(modulePath, ".")
+		if lastDot < 0 {
+			return ""
+		}
+		modulePath = modulePath[:lastDot]
+		filePath, ok = te.Registry.Modules[modulePath]
+	}
+
+	if importMap := te.GetImportMap(filePath); importMap != nil {
+		if fqn, ok := importMap.Resolve(receiver); ok && fqn != "" {
+			return fqn
+		}
+	}
+	return modulePath + "." + receiver
+}
+
 // ResolveReturnVariableReferences resolves "var:varName" placeholders in return types
 // by looking up the variable's type in the function's scope.
 // This handles the common pattern:
@@ -275,12 +391,23 @@ func (te *TypeInferenceEngine) UpdateVariableBindingsWithFunctionReturns() {
 //	    result = some_expression
 //	    return result  # return type was "var:result", resolved to type of result
 //
+// Yield types of @contextmanager and generator functions are resolved the
+// same way.
+//
 // Must be called AFTER ExtractVariableAssignments and BEFORE UpdateVariableBindingsWithFunctionReturns.
 func (te *TypeInferenceEngine) ResolveReturnVariableReferences() {
 	te.typeMutex.Lock()
 	defer te.typeMutex.Unlock()
 
-	for funcFQN, returnType := range te.ReturnTypes {
+	te.resolveVariableReferences(te.ReturnTypes)
+	te.resolveVariableReferences(te.EnterTypes)
+	te.resolveVariableReferences(te.ElementTypes)
+}
+
+// resolveVariableReferences resolves the "var:" placeholders in types, a map
+// of function FQN to return or yield type. The caller holds typeMutex.
+func (te *TypeInferenceEngine) resolveVariableReferences(types map[string]*core.TypeInfo) {
+	for funcFQN, returnType := range types {
 		if returnType == nil || !strings.HasPrefix(returnType.TypeFQN, "var:") {
 			continue
 		}
@@ -297,13 +424,87 @@ func (te *TypeInferenceEngine) ResolveReturnVariableReferences() {
 		}
 		// Only resolve if the variable has a concrete type (not another placeholder)
 		if strings.HasPrefix(binding.Type.TypeFQN, "call:") ||
-			strings.HasPrefix(binding.Type.TypeFQN, "var:") {
+			strings.HasPrefix(binding.Type.TypeFQN, "var:") ||
+			strings.HasPrefix(binding.Type.TypeFQN, "attr:") {
 			continue
 		}
-		te.ReturnTypes[funcFQN] = &core.TypeInfo{
+		types[funcFQN] = &core.TypeInfo{
 			TypeFQN:    binding.Type.TypeFQN,
 			Confidence: returnType.Confidence * binding.Type.Confidence,
 			Source:     "return_variable_resolved",
 		}
 	}
 }
+
+// BindingsOfType returns every variable binding, across all function and
+// module scopes, whose inferred type is typeFQN. Each reassignment is a
+// separate binding. Results are ordered by file, line, then variable name.
+// Thread-safe for concurrent reads.
+//
+// Parameters:
+//   - typeFQN: fully qualified type name (e.g., "myapp.models.User")
+//
+// Returns:
+//   - copies of the matching bindings, empty if none
+func (te *TypeInferenceEngine) BindingsOfType(typeFQN string) []VariableBinding {
+	scoped := te.scopedBindingsOfType(typeFQN)
+	bindings := make([]VariableBinding, len(scoped))
+	for i, entry := range scoped {
+		bindings[i] = *entry.binding
+	}
+	return bindings
+}
+
+// VariablesOfType is BindingsOfType with each binding's scope attached.
+// It implements core.TypedVariableProvider.
+func (te *TypeInferenceEngine) VariablesOfType(typeFQN string) []core.TypedVariable {
+	scoped := te.scopedBindingsOfType(typeFQN)
+	variables := make([]core.TypedVariable, len(scoped))
+	for i, entry := range scoped {
+		variables[i] = core.TypedVariable{
+			Scope:      entry.scope,
+			Name:       entry.binding.VarName,
+			TypeFQN:    entry.binding.Type.TypeFQN,
+			File:       entry.binding.Location.File,
+			Line:       entry.binding.Location.Line,
+			Confidence: float64(entry.binding.Type.Confidence),
+			Source:     entry.binding.Type.Source,
+		}
+	}
+	return variables
+}
+
+// scopedBinding pairs a binding with the FQN of the scope that holds it.
+type scopedBinding struct {
+	scope   string
+	binding *VariableBinding
+}
+
+// scopedBindingsOfType scans every scope for bindings of typeFQN. Bindings
+// are updated by later inference passes, so the scan runs at query time
+// rather than indexing bindings as they are added.
+func (te *TypeInferenceEngine) scopedBindingsOfType(typeFQN string) []scopedBinding {
+	te.scopeMutex.RLock()
+	defer te.scopeMutex.RUnlock()
+
+	var matches []scopedBinding
+	for scopeFQN, scope := range te.Scopes {
+		for _, bindings := range scope.Variables {
+			for _, binding := range bindings {
+				if binding != nil && binding.Type != nil && binding.Type.TypeFQN == typeFQN {
+					matches = append(matches, scopedBinding{scope: scopeFQN, binding: binding})
+				}
+			}
+		}
+	}
+
+	slices.SortFunc(matches, func(a, b scopedBinding) int {
+		return cmp.Or(
+			cmp.Compare(a.binding.Location.File, b.binding.Location.File),
+			cmp.Compare(a.binding.Location.Line, b.binding.Location.Line),
+			cmp.Compare(a.binding.VarName, b.binding.VarName),
+			cmp.Compare(a.scope, b.scope),
+		)
+	})
+	return matches
+}
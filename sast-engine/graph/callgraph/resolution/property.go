@@ -0,0 +1,106 @@
+package resolution
+
+import (
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// AddProperty records a @property or @cached_property getter. Reading the
+// attribute calls the getter, so it types as the getter's return type.
+// Thread-safe for concurrent writes.
+//
+// Parameters:
+//   - propertyFQN: fully qualified name of the getter (e.g., "app.Report.title")
+//   - annotated: the getter's return annotation, or nil to use the inferred return type
+func (te *TypeInferenceEngine) AddProperty(propertyFQN string, annotated *core.TypeInfo) {
+	te.typeMutex.Lock()
+	defer te.typeMutex.Unlock()
+	if te.Properties == nil {
+		te.Properties = make(map[string]*core.TypeInfo)
+	}
+	te.Properties[propertyFQN] = annotated
+}
+
+// readsProperty reports whether an attribute chain, e.g. "report.title",
+// reads an attribute that some class defines as a property.
+func (te *TypeInferenceEngine) readsProperty(chain string) bool {
+	receiver, name, ok := strings.Cut(chain, ".")
+	if !ok || receiver == "" || strings.Contains(name, ".") {
+		return false
+	}
+	te.typeMutex.RLock()
+	defer te.typeMutex.RUnlock()
+	for propertyFQN := range te.Properties {
+		if strings.HasSuffix(propertyFQN, "."+name) {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolvePropertyBindings resolves the "attr:" placeholders left by
+// ResolveEnumMemberBindings for property reads:
+//
+//	title = report.title  → return type of Report.title, when report is a Report
+//	heading = self.title  → the same, inside a method of Report
+//
+// Unresolved placeholders are removed. Must be called AFTER the "call:"
+// placeholders are resolved, so receivers such as report = load_report()
+// have their types.
+func (te *TypeInferenceEngine) ResolvePropertyBindings() {
+	for changed := true; changed; {
+		changed = false
+		for _, scope := range te.Scopes {
+			for _, bindings := range scope.Variables {
+				for _, binding := range bindings {
+					if binding == nil || binding.Type == nil || !strings.HasPrefix(binding.Type.TypeFQN, "attr:") {
+						continue
+					}
+					if resolved := te.resolvePropertyRead(scope.FunctionFQN, strings.TrimPrefix(binding.Type.TypeFQN, "attr:")); resolved != nil {
+						binding.Type = resolved
+						changed = true
+					}
+				}
+			}
+		}
+	}
+	te.dropAttributePlaceholders(nil)
+}
+
+// resolvePropertyRead types a property read "receiver.name" in scopeFQN, or
+// returns nil if the receiver's class has no such property or its return
+// type is unknown.
+func (te *TypeInferenceEngine) resolvePropertyRead(scopeFQN, chain string) *core.TypeInfo {
+	receiver, name, _ := strings.Cut(chain, ".")
+
+	var classFQN string
+	confidence := float32(1.0)
+	if binding := te.GetVariableInScope(scopeFQN, receiver); binding != nil && binding.Type != nil {
+		classFQN = binding.Type.TypeFQN
+		confidence = binding.Type.Confidence
+	} else if receiver == "self" {
+		if lastDot := strings.LastIndex(scopeFQN, "."); lastDot > 0 {
+			classFQN = scopeFQN[:lastDot]
+		}
+	}
+	if classFQN == "" || strings.Contains(classFQN, ":") {
+		return nil
+	}
+
+	propertyFQN := classFQN + "." + name
+	te.typeMutex.RLock()
+	returnType, isProperty := te.Properties[propertyFQN]
+	if returnType == nil {
+		returnType = te.ReturnTypes[propertyFQN]
+	}
+	te.typeMutex.RUnlock()
+	if !isProperty || returnType == nil || returnType.TypeFQN == "" || strings.Contains(returnType.TypeFQN, ":") {
+		return nil
+	}
+	return &core.TypeInfo{
+		TypeFQN:    returnType.TypeFQN,
+		Confidence: returnType.Confidence * confidence,
+		Source:     "property",
+	}
+}
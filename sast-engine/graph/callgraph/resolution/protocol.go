@@ -0,0 +1,43 @@
+package resolution
+
+// AddProtocol records an interface class (a typing.Protocol or abc.ABC
+// subclass) and the concrete classes defining every method it declares.
+// Thread-safe for concurrent writes.
+//
+// Parameters:
+//   - protocolFQN: fully qualified name of the protocol (e.g., "app.Drawable")
+//   - implementers: FQNs of the classes implementing it (e.g., "app.Circle")
+func (te *TypeInferenceEngine) AddProtocol(protocolFQN string, implementers []string) {
+	te.typeMutex.Lock()
+	defer te.typeMutex.Unlock()
+	if te.Protocols == nil {
+		te.Protocols = make(map[string][]string)
+	}
+	te.Protocols[protocolFQN] = implementers
+}
+
+// ResolveProtocolType returns the protocol an annotation names, as written
+// in module currentModule: typeFQN itself, or a protocol of currentModule
+// for a bare class name (def f(x: Drawable) next to class Drawable).
+// Thread-safe for concurrent reads.
+func (te *TypeInferenceEngine) ResolveProtocolType(typeFQN, currentModule string) (string, bool) {
+	te.typeMutex.RLock()
+	defer te.typeMutex.RUnlock()
+	if _, ok := te.Protocols[typeFQN]; ok {
+		return typeFQN, true
+	}
+	if local := currentModule + "." + typeFQN; currentModule != "" {
+		if _, ok := te.Protocols[local]; ok {
+			return local, true
+		}
+	}
+	return "", false
+}
+
+// ProtocolImplementers returns the classes implementing a protocol.
+// Thread-safe for concurrent reads.
+func (te *TypeInferenceEngine) ProtocolImplementers(protocolFQN string) []string {
+	te.typeMutex.RLock()
+	defer te.typeMutex.RUnlock()
+	return te.Protocols[protocolFQN]
+}
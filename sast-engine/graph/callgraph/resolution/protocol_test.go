@@ -0,0 +1,33 @@
+package resolution
+
+import (
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveProtocolType(t *testing.T) {
+	engine := NewTypeInferenceEngine(&core.ModuleRegistry{
+		Modules:      map[string]string{"app": "/app.py"},
+		FileToModule: map[string]string{"/app.py": "app"},
+	})
+	engine.AddProtocol("app.Drawable", []string{"app.Circle"})
+
+	protocol, ok := engine.ResolveProtocolType("app.Drawable", "other")
+	assert.True(t, ok)
+	assert.Equal(t, "app.Drawable", protocol)
+
+	// A bare annotation names a protocol of the current module
+	protocol, ok = engine.ResolveProtocolType("Drawable", "app")
+	assert.True(t, ok)
+	assert.Equal(t, "app.Drawable", protocol)
+
+	_, ok = engine.ResolveProtocolType("Drawable", "other")
+	assert.False(t, ok)
+	_, ok = engine.ResolveProtocolType("app.Circle", "app")
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{"app.Circle"}, engine.ProtocolImplementers("app.Drawable"))
+	assert.Empty(t, engine.ProtocolImplementers("app.Circle"))
+}
@@ -16,6 +16,30 @@ type ReturnStatement struct {
 	FunctionFQN string
 	ReturnType  *core.TypeInfo
 	Location    Location
+	Yield       bool // Value yielded by a generator, bound by "with f() as x" or "for x in f()"
+}
+
+// GeneratorTypeFQN is the type returned by calling a generator function or
+// evaluating a generator expression.
+const GeneratorTypeFQN = "builtins.Generator"
+
+// isGeneratorFunction reports whether a function_definition node yields in
+// its own body, making it a generator function. Yields in nested functions,
+// classes, and lambdas belong to those scopes.
+func isGeneratorFunction(node *sitter.Node) bool {
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+		switch child.Type() {
+		case "function_definition", "class_definition", "lambda":
+			continue
+		case "yield":
+			return true
+		}
+		if isGeneratorFunction(child) {
+			return true
+		}
+	}
+	return false
 }
 
 // ExtractReturnTypes analyzes return statements in all functions in a file.
@@ -40,11 +64,25 @@ func ExtractReturnTypes(
 	}
 	defer tree.Close()
 
+	returns, functionsWithReturnValues := ExtractReturnTypesFromAST(filePath, sourceCode, tree.RootNode(), modulePath, builtinRegistry, importMap)
+	return returns, functionsWithReturnValues, nil
+}
+
+// ExtractReturnTypesFromAST is like ExtractReturnTypes but works on an
+// already-parsed tree, so callers sharing an AST across passes skip re-parsing.
+func ExtractReturnTypesFromAST(
+	filePath string,
+	sourceCode []byte,
+	root *sitter.Node,
+	modulePath string,
+	builtinRegistry *registry.BuiltinRegistry,
+	importMap *core.ImportMap,
+) ([]*ReturnStatement, map[string]bool) {
 	var returns []*ReturnStatement
 	functionsWithReturnValues := make(map[string]bool)
-	traverseForReturns(tree.RootNode(), sourceCode, filePath, modulePath, "", &returns, functionsWithReturnValues, builtinRegistry, importMap)
+	traverseForReturns(root, sourceCode, filePath, modulePath, "", &returns, functionsWithReturnValues, builtinRegistry, importMap)
 
-	return returns, functionsWithReturnValues, nil
+	return returns, functionsWithReturnValues
 }
 
 func traverseForReturns(
@@ -90,6 +128,62 @@ func traverseForReturns(
 				// Method inside a class or nested function
 				newFunction = currentFunction + "." + funcName
 			}
+
+			// @contextmanager: the call returns a context manager, and the
+			// yielded value is what `with f() as x` binds
+			if isContextManagerFunction(node, sourceCode) {
+				functionsWithReturnValues[newFunction] = true
+				*returns = append(*returns, &ReturnStatement{
+					FunctionFQN: newFunction,
+					ReturnType: &core.TypeInfo{
+						TypeFQN:    ContextManagerTypeFQN,
+						Confidence: 1.0,
+						Source:     "decorator_contextmanager",
+					},
+					Location: Location{
+						File:   filePath,
+						Line:   node.StartPoint().Row + 1,
+						Column: node.StartPoint().Column + 1,
+					},
+				})
+				extractYieldTypes(node, sourceCode, filePath, modulePath, newFunction, returns, builtinRegistry, importMap)
+			} else if isGeneratorFunction(node) {
+				// Other generators return a generator, and iterating it
+				// binds the yielded values: `for x in f()`
+				functionsWithReturnValues[newFunction] = true
+				*returns = append(*returns, &ReturnStatement{
+					FunctionFQN: newFunction,
+					ReturnType: &core.TypeInfo{
+						TypeFQN:    GeneratorTypeFQN,
+						Confidence: 1.0,
+						Source:     "generator_function",
+					},
+					Location: Location{
+						File:   filePath,
+						Line:   node.StartPoint().Row + 1,
+						Column: node.StartPoint().Column + 1,
+					},
+				})
+				extractYieldTypes(node, sourceCode, filePath, modulePath, newFunction, returns, builtinRegistry, importMap)
+			}
+
+			// A signature type comment declares the return type of legacy
+			// code: `def find(name):  # type: (str) -> User`. Added before
+			// the function's returns, it wins ties with their inferred types.
+			if _, declared, ok := SignatureTypeComment(node, sourceCode); ok {
+				if returnType := ResolveTypeComment(declared, modulePath, importMap, builtinRegistry); returnType != nil {
+					functionsWithReturnValues[newFunction] = true
+					*returns = append(*returns, &ReturnStatement{
+						FunctionFQN: newFunction,
+						ReturnType:  returnType,
+						Location: Location{
+							File:   filePath,
+							Line:   node.StartPoint().Row + 1,
+							Column: node.StartPoint().Column + 1,
+						},
+					})
+				}
+			}
 		}
 	}
 
@@ -110,7 +204,10 @@ func traverseForReturns(
 			// Track that this function has at least one return <expr> statement
 			functionsWithReturnValues[newFunction] = true
 
-			returnType := inferReturnType(valueNode, sourceCode, modulePath, builtinRegistry, importMap)
+			returnType := resolveClsInstantiation(valueNode, sourceCode, newFunction)
+			if returnType == nil {
+				returnType = inferReturnType(valueNode, sourceCode, modulePath, builtinRegistry, importMap)
+			}
 			if returnType != nil {
 				stmt := &ReturnStatement{
 					FunctionFQN: newFunction,
@@ -414,7 +511,7 @@ func inferReturnType(
 
 	case "generator_expression":
 		return &core.TypeInfo{
-			TypeFQN:    "builtins.Generator",
+			TypeFQN:    GeneratorTypeFQN,
 			Confidence: 0.9,
 			Source:     "return_generator",
 		}
@@ -424,11 +521,51 @@ func inferReturnType(
 }
 
 // MergeReturnTypes combines multiple return statements for same function.
-// Takes the highest confidence return type.
+// Takes the highest confidence return type. Yield statements are skipped;
+// see MergeEnterTypes and MergeElementTypes.
 func MergeReturnTypes(statements []*ReturnStatement) map[string]*core.TypeInfo {
+	return mergeStatementTypes(statements, func(stmt *ReturnStatement) bool {
+		return !stmt.Yield
+	})
+}
+
+// MergeEnterTypes combines the yield statements of @contextmanager functions
+// into the type each binds in `with f() as x`, taking the highest confidence
+// yielded type.
+func MergeEnterTypes(statements []*ReturnStatement) map[string]*core.TypeInfo {
+	return mergeStatementTypes(statements, yieldsOf(statements, ContextManagerTypeFQN))
+}
+
+// MergeElementTypes combines the yield statements of generator functions
+// into the type iterating each binds in `for x in f()`, taking the highest
+// confidence yielded type.
+func MergeElementTypes(statements []*ReturnStatement) map[string]*core.TypeInfo {
+	return mergeStatementTypes(statements, yieldsOf(statements, GeneratorTypeFQN))
+}
+
+// yieldsOf returns a filter for the yield statements of functions whose
+// return type is returnTypeFQN.
+func yieldsOf(statements []*ReturnStatement, returnTypeFQN string) func(*ReturnStatement) bool {
+	functions := make(map[string]bool)
+	for _, stmt := range statements {
+		if !stmt.Yield && stmt.ReturnType.TypeFQN == returnTypeFQN {
+			functions[stmt.FunctionFQN] = true
+		}
+	}
+	return func(stmt *ReturnStatement) bool {
+		return stmt.Yield && functions[stmt.FunctionFQN]
+	}
+}
+
+// mergeStatementTypes merges the statements include accepts by function,
+// keeping the highest confidence type.
+func mergeStatementTypes(statements []*ReturnStatement, include func(*ReturnStatement) bool) map[string]*core.TypeInfo {
 	merged := make(map[string]*core.TypeInfo)
 
 	for _, stmt := range statements {
+		if !include(stmt) {
+			continue
+		}
 		existing, ok := merged[stmt.FunctionFQN]
 		if !ok {
 			merged[stmt.FunctionFQN] = stmt.ReturnType
@@ -453,6 +590,16 @@ func (te *TypeInferenceEngine) AddReturnTypesToEngine(returnTypes map[string]*co
 	maps.Copy(te.ReturnTypes, returnTypes)
 }
 
+// AddEnterTypesToEngine populates TypeInferenceEngine with the types
+// @contextmanager functions bind in `with f() as x`.
+// Thread-safe for concurrent writes.
+func (te *TypeInferenceEngine) AddEnterTypesToEngine(enterTypes map[string]*core.TypeInfo) {
+	te.typeMutex.Lock()
+	defer te.typeMutex.Unlock()
+
+	maps.Copy(te.EnterTypes, enterTypes)
+}
+
 // isPascalCase checks if a string is in PascalCase (likely a class name).
 func isPascalCase(s string) bool {
 	if len(s) == 0 {
@@ -576,6 +723,67 @@ func ResolveClassInstantiation(
 	return nil
 }
 
+// resolveClsInstantiation infers the return type of `return cls(...)` inside a
+// method as the enclosing class. This covers classmethod factories such as:
+//
+//	class User:
+//	    @classmethod
+//	    def from_dict(cls, data):
+//	        return cls(data["name"])  # → module.User
+//
+// Returns nil if the node is not a cls(...) call or the function is not a method.
+func resolveClsInstantiation(node *sitter.Node, sourceCode []byte, functionFQN string) *core.TypeInfo {
+	if node == nil || node.Type() != "call" {
+		return nil
+	}
+	funcNode := node.ChildByFieldName("function")
+	if funcNode == nil || funcNode.Type() != "identifier" || funcNode.Content(sourceCode) != "cls" {
+		return nil
+	}
+
+	if !isMethodDefinition(enclosingFunction(node)) {
+		return nil
+	}
+
+	lastDot := strings.LastIndex(functionFQN, ".")
+	if lastDot < 0 {
+		return nil
+	}
+
+	return &core.TypeInfo{
+		TypeFQN:    functionFQN[:lastDot],
+		Confidence: 0.95,
+		Source:     "return_cls_instantiation",
+	}
+}
+
+// enclosingFunction returns the nearest function_definition containing node.
+func enclosingFunction(node *sitter.Node) *sitter.Node {
+	for parent := node.Parent(); parent != nil; parent = parent.Parent() {
+		if parent.Type() == "function_definition" {
+			return parent
+		}
+	}
+	return nil
+}
+
+// isMethodDefinition reports whether a function_definition is defined directly
+// in a class body, with or without decorators.
+func isMethodDefinition(funcNode *sitter.Node) bool {
+	if funcNode == nil {
+		return false
+	}
+	parent := funcNode.Parent()
+	if parent != nil && parent.Type() == "decorated_definition" {
+		parent = parent.Parent()
+	}
+	if parent == nil || parent.Type() != "block" {
+		return false
+	}
+	classNode := parent.Parent()
+	return classNode != nil && classNode.Type() == "class_definition"
+}
+
 // extractFunctionNameFromNode extracts the function name from a function_definition node.
 func extractFunctionNameFromNode(node *sitter.Node, sourceCode []byte) string {
 	if node.Type() != "function_definition" {
@@ -608,3 +816,13 @@ func extractClassNameFromNode(node *sitter.Node, sourceCode []byte) string {
 
 	return ""
 }
+
+// AddElementTypesToEngine populates TypeInferenceEngine with the types
+// generator functions bind in `for x in f()`.
+// Thread-safe for concurrent writes.
+func (te *TypeInferenceEngine) AddElementTypesToEngine(elementTypes map[string]*core.TypeInfo) {
+	te.typeMutex.Lock()
+	defer te.typeMutex.Unlock()
+
+	maps.Copy(te.ElementTypes, elementTypes)
+}
@@ -165,3 +165,26 @@ def maybe_user(flag):
 	assert.NotNil(t, merged["test.maybe_user"])
 	assert.Contains(t, []string{"test.User", "builtins.NoneType"}, merged["test.maybe_user"].TypeFQN)
 }
+
+func TestExtractReturnTypes_ClsInstantiation(t *testing.T) {
+	sourceCode := []byte(`
+class User:
+    @classmethod
+    def from_dict(cls, data):
+        return cls(data["name"])
+
+def make(cls):
+    return cls()
+`)
+
+	returns, _, err := ExtractReturnTypes("models.py", sourceCode, "models", nil, nil)
+	require.NoError(t, err)
+	merged := MergeReturnTypes(returns)
+
+	require.Contains(t, merged, "models.User.from_dict")
+	assert.Equal(t, "models.User", merged["models.User.from_dict"].TypeFQN)
+	assert.Equal(t, "return_cls_instantiation", merged["models.User.from_dict"].Source)
+
+	// Outside a class body cls is an ordinary parameter.
+	assert.Equal(t, "call:cls", merged["models.make"].TypeFQN)
+}
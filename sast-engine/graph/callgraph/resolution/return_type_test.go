@@ -793,6 +793,42 @@ def lazy_items(items):
 	assert.Equal(t, "return_generator", returns[0].ReturnType.Source)
 }
 
+func TestExtractReturnTypes_GeneratorFunction(t *testing.T) {
+	sourceCode := []byte(`
+from contextlib import contextmanager
+
+def numbers():
+    yield 1
+    yield 2
+
+def wrapper():
+    def inner():
+        yield "nested"
+    return inner
+
+@contextmanager
+def opened():
+    yield "handle"
+`)
+
+	builtinRegistry := registry.NewBuiltinRegistry()
+	returns, withValues, err := ExtractReturnTypes("test.py", sourceCode, "test", builtinRegistry, nil)
+	require.NoError(t, err)
+	assert.True(t, withValues["test.numbers"])
+
+	merged := MergeReturnTypes(returns)
+	assert.Equal(t, GeneratorTypeFQN, merged["test.numbers"].TypeFQN)
+	assert.Equal(t, "generator_function", merged["test.numbers"].Source)
+	assert.Equal(t, GeneratorTypeFQN, merged["test.wrapper.inner"].TypeFQN)
+	assert.NotEqual(t, GeneratorTypeFQN, merged["test.wrapper"].TypeFQN, "a nested function's yield belongs to it")
+
+	elements := MergeElementTypes(returns)
+	assert.Equal(t, "builtins.int", elements["test.numbers"].TypeFQN)
+	assert.Equal(t, "builtins.str", elements["test.wrapper.inner"].TypeFQN)
+	assert.NotContains(t, elements, "test.opened", "a @contextmanager yield is bound by with, not iteration")
+	assert.NotContains(t, MergeEnterTypes(returns), "test.numbers")
+}
+
 func TestExtractReturnTypes_ConditionalExprFalseBranchHigherConf(t *testing.T) {
 	// When both sides are concrete but differ, and false branch has higher confidence
 	sourceCode := []byte(`
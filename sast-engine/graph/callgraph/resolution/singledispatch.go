@@ -0,0 +1,35 @@
+package resolution
+
+// AddSingleDispatch records a @functools.singledispatch function and the
+// implementations registered on it with @<function>.register, keyed by the
+// FQN of the type each handles. Thread-safe for concurrent writes.
+//
+// Parameters:
+//   - functionFQN: fully qualified name of the generic function (e.g., "app.render")
+//   - implementations: dispatch type FQN -> implementation FQN (e.g., "builtins.int" -> "app._render_int")
+func (te *TypeInferenceEngine) AddSingleDispatch(functionFQN string, implementations map[string]string) {
+	te.typeMutex.Lock()
+	defer te.typeMutex.Unlock()
+	if te.SingleDispatch == nil {
+		te.SingleDispatch = make(map[string]map[string]string)
+	}
+	te.SingleDispatch[functionFQN] = implementations
+}
+
+// SingleDispatchImplementation returns the function a call to a
+// singledispatch function runs when its first argument has type argType:
+// the implementation registered for argType, else the generic function's
+// own body. ok is false when functionFQN is not a singledispatch function.
+// Thread-safe for concurrent reads.
+func (te *TypeInferenceEngine) SingleDispatchImplementation(functionFQN, argType string) (string, bool) {
+	te.typeMutex.RLock()
+	defer te.typeMutex.RUnlock()
+	implementations, ok := te.SingleDispatch[functionFQN]
+	if !ok {
+		return "", false
+	}
+	if implementation, registered := implementations[argType]; registered && argType != "" {
+		return implementation, true
+	}
+	return functionFQN, true
+}
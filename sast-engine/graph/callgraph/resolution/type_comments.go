@@ -0,0 +1,144 @@
+package resolution
+
+import (
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/registry"
+)
+
+// typeCommentPrefix starts a PEP 484 type comment, the annotation syntax of
+// code written before Python 3.6 variable annotations.
+const typeCommentPrefix = "type:"
+
+// parseTypeComment returns the type a comment declares, or "" when it is
+// not a type comment or is "# type: ignore".
+func parseTypeComment(comment string) string {
+	text := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(comment), "#"))
+	declared, ok := strings.CutPrefix(text, typeCommentPrefix)
+	if !ok {
+		return ""
+	}
+	// A second comment may follow: "# type: int  # noqa"
+	declared, _, _ = strings.Cut(declared, "#")
+	declared = strings.TrimSpace(declared)
+	if declared == "ignore" || strings.HasPrefix(declared, "ignore[") {
+		return ""
+	}
+	return declared
+}
+
+// VariableTypeComment returns the type declared by the type comment ending
+// the line of an assignment node, or "".
+//
+// Example:
+//
+//	user = load(row)  # type: User  → "User"
+func VariableTypeComment(assignment *sitter.Node, sourceCode []byte) string {
+	statement := assignment.Parent()
+	if statement == nil || statement.Type() != "expression_statement" {
+		return ""
+	}
+	comment := statement.NextSibling()
+	if comment == nil || comment.Type() != "comment" || comment.StartPoint().Row != assignment.EndPoint().Row {
+		return ""
+	}
+	return parseTypeComment(comment.Content(sourceCode))
+}
+
+// SignatureTypeComment parses the signature type comment of a
+// function_definition node, on the def line or the line after it. params
+// holds the declared type of each parameter, in order and without self or
+// cls; it is nil for the "(...)" form, which declares the return type only.
+//
+// Example:
+//
+//	def find(name, limit):  # type: (str, int) -> User
+//	→ params ["str", "int"], returns "User"
+func SignatureTypeComment(funcNode *sitter.Node, sourceCode []byte) (params []string, returns string, ok bool) {
+	for i := 0; i < int(funcNode.NamedChildCount()); i++ {
+		comment := funcNode.NamedChild(i)
+		if comment.Type() != "comment" {
+			continue
+		}
+		declared := parseTypeComment(comment.Content(sourceCode))
+		arguments, result, found := strings.Cut(declared, "->")
+		arguments = strings.TrimSpace(arguments)
+		if !found || !strings.HasPrefix(arguments, "(") || !strings.HasSuffix(arguments, ")") {
+			continue
+		}
+		arguments = strings.TrimSpace(arguments[1 : len(arguments)-1])
+		if arguments != "..." && arguments != "" {
+			params = splitTypeList(arguments)
+		}
+		return params, strings.TrimSpace(result), true
+	}
+	return nil, "", false
+}
+
+// splitTypeList splits a comma-separated list of types at its top level,
+// keeping the commas inside brackets: "Dict[str, int], User" → two types.
+func splitTypeList(list string) []string {
+	var types []string
+	depth, start := 0, 0
+	for i, ch := range list {
+		switch ch {
+		case '[', '(':
+			depth++
+		case ']', ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				types = append(types, strings.TrimSpace(list[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(types, strings.TrimSpace(list[start:]))
+}
+
+// ResolveTypeComment resolves a type declared in a type comment: builtins to
+// "builtins.<name>", names imported through importMap to their FQN, other
+// bare names to a class of modulePath, and other dotted names as written.
+// Optional[T] declares T, and *args or **kwargs prefixes are dropped.
+// Returns nil for None, Any, and other generic or union types.
+func ResolveTypeComment(declared, modulePath string, importMap *core.ImportMap, builtinRegistry *registry.BuiltinRegistry) *core.TypeInfo {
+	declared = strings.Trim(strings.TrimLeft(strings.TrimSpace(declared), "*"), `"'`)
+	for _, wrapper := range []string{"Optional[", "typing.Optional["} {
+		if inner, ok := strings.CutPrefix(declared, wrapper); ok && strings.HasSuffix(inner, "]") {
+			declared = strings.TrimSpace(strings.TrimSuffix(inner, "]"))
+		}
+	}
+	if declared == "" || declared == "None" || declared == "Any" || strings.ContainsAny(declared, "[|, ") {
+		return nil
+	}
+
+	typeFQN := declared
+	head, rest, dotted := strings.Cut(declared, ".")
+	if imported, ok := resolveTypeCommentImport(importMap, head); ok {
+		typeFQN = imported
+		if dotted {
+			typeFQN += "." + rest
+		}
+	} else if !dotted && builtinRegistry != nil && builtinRegistry.GetType("builtins."+declared) != nil {
+		typeFQN = "builtins." + declared
+	} else if !dotted {
+		typeFQN = modulePath + "." + declared
+	}
+	return &core.TypeInfo{
+		TypeFQN:    typeFQN,
+		Confidence: 0.95,
+		Source:     "type_comment",
+	}
+}
+
+// resolveTypeCommentImport resolves a name through a file's import map,
+// which may be nil.
+func resolveTypeCommentImport(importMap *core.ImportMap, name string) (string, bool) {
+	if importMap == nil {
+		return "", false
+	}
+	return importMap.Resolve(name)
+}
@@ -0,0 +1,76 @@
+package resolution
+
+import (
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignatureTypeComment(t *testing.T) {
+	code := `
+def find(name, limit):  # type: (str, Dict[str, int]) -> User
+    pass
+
+def close(self):
+    # type: (...) -> None
+    pass
+
+def plain(x):  # a note
+    pass
+`
+	root := parseCode(t, code)
+	signature := func(i int) ([]string, string, bool) {
+		return SignatureTypeComment(root.NamedChild(i), []byte(code))
+	}
+
+	params, returns, ok := signature(0)
+	require.True(t, ok)
+	assert.Equal(t, []string{"str", "Dict[str, int]"}, params)
+	assert.Equal(t, "User", returns)
+
+	params, returns, ok = signature(1)
+	require.True(t, ok)
+	assert.Nil(t, params)
+	assert.Equal(t, "None", returns)
+
+	_, _, ok = signature(2)
+	assert.False(t, ok)
+}
+
+func TestVariableTypeComment(t *testing.T) {
+	code := "user = load(row)  # type: User  # noqa\nother = load(row)  # type: ignore\nlast = load(row)\n# type: int\n"
+	root := parseCode(t, code)
+	assignment := func(i int) string {
+		return VariableTypeComment(root.NamedChild(i).NamedChild(0), []byte(code))
+	}
+
+	assert.Equal(t, "User", assignment(0))
+	assert.Empty(t, assignment(2)) // "# type: ignore"
+	assert.Empty(t, assignment(4)) // The comment is on the next line
+}
+
+func TestResolveTypeComment(t *testing.T) {
+	importMap := core.NewImportMap("app.py")
+	importMap.AddImport("Account", "myapp.models.Account")
+	importMap.AddImport("models", "myapp.models")
+	builtins := registry.NewBuiltinRegistry()
+
+	resolve := func(declared string) string {
+		typeInfo := ResolveTypeComment(declared, "app", importMap, builtins)
+		if typeInfo == nil {
+			return ""
+		}
+		return typeInfo.TypeFQN
+	}
+	assert.Equal(t, "myapp.models.Account", resolve("Account"))
+	assert.Equal(t, "myapp.models.Profile", resolve("models.Profile"))
+	assert.Equal(t, "builtins.str", resolve("str"))
+	assert.Equal(t, "app.User", resolve("User"))
+	assert.Equal(t, "app.User", resolve("Optional[User]"))
+	assert.Equal(t, "builtins.int", resolve("*int"))
+	assert.Empty(t, resolve("None"))
+	assert.Empty(t, resolve("List[User]"))
+}
@@ -20,6 +20,9 @@ type VariableBinding struct {
 	Type         *core.TypeInfo  // Inferred type information
 	AssignedFrom string          // FQN of function that assigned this value (if from function call)
 	Location     Location        // Source location of the assignment
+	WithTarget   bool            // Bound by "with ... as", so the value is the context manager's enter result
+	ForTarget    bool            // Bound by "for ... in", so the value is an element of the iterated object
+	Callables    []string        // Names stored as values of a container literal, e.g. the functions of a dispatch table
 }
 
 // FunctionScope represents the type environment within a function.
@@ -29,6 +32,11 @@ type FunctionScope struct {
 	FunctionFQN string                         // Fully qualified name of the function
 	Variables   map[string][]*VariableBinding  // Variable name -> bindings (per-assignment)
 	ReturnType  *core.TypeInfo                 // Inferred return type of the function
+
+	// Rebound maps names the function declares global or nonlocal to the
+	// FQN of the scope holding their bindings (the module, or an enclosing
+	// function). Assignments to them are recorded in that scope.
+	Rebound map[string]string
 }
 
 // NewFunctionScope creates a new function scope with initialized maps.
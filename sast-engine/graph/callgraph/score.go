@@ -0,0 +1,15 @@
+package callgraph
+
+import (
+	"cmp"
+	"slices"
+)
+
+// SortMatchesByScore orders matches by Score, highest first, so the
+// riskiest findings are triaged first. Ties keep their order, so sorting
+// stays deterministic.
+func SortMatchesByScore(matches []SecurityMatch) {
+	slices.SortStableFunc(matches, func(a, b SecurityMatch) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+}
@@ -0,0 +1,42 @@
+package callgraph
+
+import (
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/patterns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzePatterns_Score(t *testing.T) {
+	// A route handler runs eval() on input(); an internal script runs
+	// exec() on a config value
+	cg := core.NewCallGraph()
+	cg.AddFunction("app.views.search", &graph.Node{Name: "search", Routes: []graph.Route{{Method: "GET", Path: "/search"}}})
+	cg.AddFunction("scripts.backfill.run", &graph.Node{Name: "run"})
+	cg.AddCallSite("app.views.search", core.CallSite{Target: "input", TargetFQN: "builtins.input", Resolved: true})
+	cg.AddCallSite("app.views.search", core.CallSite{Target: "eval", TargetFQN: "builtins.eval", Resolved: true})
+	cg.AddCallSite("scripts.backfill.run", core.CallSite{Target: "read_config", TargetFQN: "scripts.config.read_config", Resolved: true})
+	cg.AddCallSite("scripts.backfill.run", core.CallSite{Target: "exec", TargetFQN: "builtins.exec", Resolved: true})
+	registry := patterns.NewPatternRegistry()
+	registry.AddPattern(&patterns.Pattern{
+		ID: "TEST-INTERNAL-LOW", Type: patterns.PatternTypeSourceSink, Severity: patterns.SeverityLow,
+		Sources: []string{"read_config"}, Sinks: []string{"exec"},
+	})
+	registry.AddPattern(&patterns.Pattern{
+		ID: "TEST-ROUTE-CRITICAL", Type: patterns.PatternTypeSourceSink, Severity: patterns.SeverityCritical,
+		Sources: []string{"input"}, Sinks: []string{"eval"},
+	})
+
+	matches := AnalyzePatterns(cg, registry)
+	require.Len(t, matches, 2)
+	assert.Equal(t, "TEST-INTERNAL-LOW", matches[0].PatternID)
+	assert.InDelta(t, 0.125, matches[0].Score, 1e-9, "low severity, internal")
+	assert.InDelta(t, 1.0, matches[1].Score, 1e-9, "critical, reached from a route")
+
+	SortMatchesByScore(matches)
+	assert.Equal(t, "TEST-ROUTE-CRITICAL", matches[0].PatternID)
+	assert.Equal(t, "TEST-INTERNAL-LOW", matches[1].PatternID)
+}
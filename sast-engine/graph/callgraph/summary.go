@@ -0,0 +1,76 @@
+package callgraph
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// topFilesLimit is the number of files listed in Summary.TopFiles.
+const topFilesLimit = 10
+
+// Summary counts findings for dashboards and CI summaries. Findings are
+// attributed to the file and module of their sink. Suppressed findings
+// (see SecurityMatch.Suppression) are only counted in Suppressed.
+type Summary struct {
+	Total      int            `json:"total"`
+	Suppressed int            `json:"suppressed"`
+	ByFile     map[string]int `json:"by_file"`     //nolint:tagliatelle
+	ByModule   map[string]int `json:"by_module"`   //nolint:tagliatelle // Only findings in files the registry maps to a module
+	BySeverity map[string]int `json:"by_severity"` //nolint:tagliatelle
+	ByRule     map[string]int `json:"by_rule"`     //nolint:tagliatelle // Pattern ID -> count
+	TopFiles   []FileCount    `json:"top_files"`   //nolint:tagliatelle // Files with the most findings, most first
+}
+
+// FileCount is the number of findings in a file.
+type FileCount struct {
+	File  string `json:"file"`
+	Count int    `json:"count"`
+}
+
+// Summarize counts matches by file, module, severity, and rule, mapping
+// files to modules through moduleRegistry, which may be nil. Analyze sets
+// AnalysisResult.Summary to the summary of its matches.
+func Summarize(matches []SecurityMatch, moduleRegistry *core.ModuleRegistry) Summary {
+	summary := Summary{
+		ByFile:     make(map[string]int),
+		ByModule:   make(map[string]int),
+		BySeverity: make(map[string]int),
+		ByRule:     make(map[string]int),
+		TopFiles:   []FileCount{},
+	}
+	for _, match := range matches {
+		if match.Suppression != "" {
+			summary.Suppressed++
+			continue
+		}
+		summary.Total++
+		if file := match.SinkFile; file != "" {
+			summary.ByFile[file]++
+			if moduleRegistry != nil {
+				if module, ok := moduleRegistry.FileToModule[file]; ok {
+					summary.ByModule[module]++
+				}
+			}
+		}
+		if match.Severity != "" {
+			summary.BySeverity[match.Severity]++
+		}
+		summary.ByRule[match.PatternID]++
+	}
+
+	for file, count := range summary.ByFile {
+		summary.TopFiles = append(summary.TopFiles, FileCount{File: file, Count: count})
+	}
+	slices.SortFunc(summary.TopFiles, func(a, b FileCount) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.File, b.File)
+	})
+	if len(summary.TopFiles) > topFilesLimit {
+		summary.TopFiles = summary.TopFiles[:topFilesLimit]
+	}
+	return summary
+}
@@ -0,0 +1,82 @@
+package callgraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/patterns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarize(t *testing.T) {
+	at := func(patternID, severity, file string, line uint32) SecurityMatch {
+		return SecurityMatch{PatternID: patternID, Severity: severity, SinkFile: file, SinkLine: line}
+	}
+	accepted := at("SQL-INJECTION-001", "critical", "/app/auth.py", 9)
+	accepted.Suppression = patterns.SuppressedByBaseline
+	matches := []SecurityMatch{
+		at("SQL-INJECTION-001", "critical", "/app/views.py", 10),
+		at("SQL-INJECTION-001", "critical", "/app/views.py", 20),
+		at("SSTI-001", "critical", "/app/views.py", 30),
+		at("INSECURE-COOKIE-001", "medium", "/app/auth.py", 5),
+		accepted,
+	}
+	moduleRegistry := &core.ModuleRegistry{FileToModule: map[string]string{
+		"/app/views.py": "app.views",
+		"/app/auth.py":  "app.auth",
+	}}
+
+	summary := Summarize(matches, moduleRegistry)
+
+	data, err := json.Marshal(summary)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"total": 4,
+		"suppressed": 1,
+		"by_file": {"/app/views.py": 3, "/app/auth.py": 1},
+		"by_module": {"app.views": 3, "app.auth": 1},
+		"by_severity": {"critical": 3, "medium": 1},
+		"by_rule": {"SQL-INJECTION-001": 2, "SSTI-001": 1, "INSECURE-COOKIE-001": 1},
+		"top_files": [{"file": "/app/views.py", "count": 3}, {"file": "/app/auth.py", "count": 1}]
+	}`, string(data))
+
+	// Without a registry, modules are unknown
+	assert.Empty(t, Summarize(matches, nil).ByModule)
+	assert.Equal(t, summary.ByFile, Summarize(matches, nil).ByFile)
+}
+
+func TestSummarize_TopFilesLimit(t *testing.T) {
+	var matches []SecurityMatch
+	for i := range topFilesLimit + 2 {
+		file := fmt.Sprintf("%c.py", 'a'+i)
+		for range i + 1 {
+			matches = append(matches, SecurityMatch{SinkFile: file})
+		}
+	}
+
+	summary := Summarize(matches, nil)
+
+	require.Len(t, summary.TopFiles, topFilesLimit)
+	assert.Equal(t, FileCount{"l.py", 12}, summary.TopFiles[0])
+	assert.Equal(t, FileCount{"c.py", 3}, summary.TopFiles[topFilesLimit-1])
+	assert.Len(t, summary.ByFile, topFilesLimit+2)
+	assert.Empty(t, summary.BySeverity, "matches without a severity are not counted by severity")
+}
+
+func TestAnalyze_Summary(t *testing.T) {
+	projectPath, err := filepath.Abs("../../test-fixtures/python/django_sources")
+	require.NoError(t, err)
+
+	result, err := Analyze(projectPath, AnalyzeOptions{})
+	require.NoError(t, err)
+	require.NotEmpty(t, result.Matches)
+
+	assert.Equal(t, Summarize(result.Matches, result.ModuleRegistry), result.Summary)
+	assert.Equal(t, len(result.Matches), result.Summary.Total)
+	assert.Equal(t, len(result.Matches), result.Summary.ByFile[filepath.Join(projectPath, "views.py")])
+	assert.Equal(t, len(result.Matches), result.Summary.ByModule["views"])
+}
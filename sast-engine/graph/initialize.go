@@ -3,6 +3,7 @@ package graph
 import (
 	"context"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -180,3 +181,45 @@ func Initialize(directory string, callbacks *ProgressCallbacks) *CodeGraph {
 
 	return codeGraph
 }
+
+// InitializeFromSources builds a code graph from in-memory source files keyed
+// by virtual path, for buffers that are not on disk (editor plugins, the
+// playground). Nodes record the virtual paths as their files. Java, Python,
+// and Go sources are parsed; other paths are ignored.
+func InitializeFromSources(sources map[string][]byte) *CodeGraph {
+	codeGraph := NewCodeGraph()
+	parser := sitter.NewParser()
+	defer parser.Close()
+
+	files := make([]string, 0, len(sources))
+	for file := range sources {
+		files = append(files, file)
+	}
+	slices.Sort(files)
+
+	for _, file := range files {
+		switch filepath.Ext(file) {
+		case ".java":
+			parser.SetLanguage(java.GetLanguage())
+		case ".py":
+			parser.SetLanguage(python.GetLanguage())
+		case ".go":
+			parser.SetLanguage(golang.GetLanguage())
+		default:
+			Log("Unsupported file type:", file)
+			continue
+		}
+
+		sourceCode := sources[file]
+		tree, err := parser.ParseCtx(context.TODO(), nil, sourceCode)
+		if err != nil {
+			Log("Error parsing file:", err)
+			continue
+		}
+		buildGraphFromAST(tree.RootNode(), sourceCode, codeGraph, nil, file)
+		tree.Close()
+	}
+
+	ResolveTransitiveInheritance(codeGraph)
+	return codeGraph
+}
@@ -631,3 +631,34 @@ func TestInitializeWithPythonFileReadError(t *testing.T) {
 		t.Errorf("OnProgress should be called once for unreadable Python file, got %d", progressCalls)
 	}
 }
+
+func TestInitializeFromSources(t *testing.T) {
+	sources := map[string][]byte{
+		"app/greet.py": []byte(`
+def greet(name):
+    return f"Hello, {name}!"
+`),
+		"notes.txt": []byte("not source code"),
+	}
+
+	graph := InitializeFromSources(sources)
+
+	if graph == nil {
+		t.Fatal("InitializeFromSources should return a non-nil graph")
+	}
+	hasFunctionNode := false
+	for _, node := range graph.Nodes {
+		if node.File == "notes.txt" {
+			t.Error("Expected unsupported files to be ignored")
+		}
+		if node.Type == "function_definition" && node.Name == "greet" {
+			hasFunctionNode = true
+			if node.File != "app/greet.py" {
+				t.Errorf("Expected node file to be the virtual path, got %s", node.File)
+			}
+		}
+	}
+	if !hasFunctionNode {
+		t.Error("Expected to find greet function node")
+	}
+}
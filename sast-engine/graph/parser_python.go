@@ -39,11 +39,196 @@ func extractDecorators(node *sitter.Node, sourceCode []byte) []string {
 	return decorators
 }
 
+// routeDecoratorMethods maps the decorator methods of Flask, Quart, Sanic, and
+// FastAPI applications, blueprints, and routers to the HTTP method they
+// register. "" marks route and api_route, which take a methods= list.
+var routeDecoratorMethods = map[string]string{
+	"route": "", "api_route": "",
+	"get": "GET", "post": "POST", "put": "PUT", "patch": "PATCH",
+	"delete": "DELETE", "head": "HEAD", "options": "OPTIONS",
+	"websocket": "WEBSOCKET",
+}
+
+// extractRoutes returns the routes registered by the route decorators of a
+// decorated_definition node, one per HTTP method. The path is the first
+// positional string argument (or path=/rule=); route and api_route default
+// to GET when no methods= list is given.
+//
+//	@app.route("/x", methods=["POST", "PUT"])  → POST /x, PUT /x
+//	@router.get("/y")                          → GET /y
+func extractRoutes(node *sitter.Node, sourceCode []byte) []Route {
+	if node.Type() != "decorated_definition" {
+		return nil
+	}
+	var routes []Route
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		decorator := node.NamedChild(i)
+		if decorator.Type() != "decorator" || decorator.NamedChildCount() == 0 {
+			continue
+		}
+		call := decorator.NamedChild(0)
+		if call.Type() != "call" {
+			continue
+		}
+		function := call.ChildByFieldName("function")
+		if function == nil || function.Type() != "attribute" {
+			continue
+		}
+		method, ok := routeDecoratorMethods[function.ChildByFieldName("attribute").Content(sourceCode)]
+		if !ok {
+			continue
+		}
+
+		path, hasPath := "", false
+		methods := []string{method}
+		if method == "" {
+			methods = []string{"GET"}
+		}
+		arguments := call.ChildByFieldName("arguments")
+		for j := 0; arguments != nil && j < int(arguments.NamedChildCount()); j++ {
+			argument := arguments.NamedChild(j)
+			if value, isString := pythonStringValue(argument, sourceCode); isString && !hasPath {
+				path, hasPath = value, true
+				continue
+			}
+			if argument.Type() != "keyword_argument" {
+				continue
+			}
+			name := argument.ChildByFieldName("name").Content(sourceCode)
+			value := argument.ChildByFieldName("value")
+			switch {
+			case name == "path" || name == "rule":
+				path, hasPath = pythonStringValue(value, sourceCode)
+			case name == "methods" && method == "":
+				methods = pythonStringList(value, sourceCode)
+			}
+		}
+		if !hasPath {
+			continue
+		}
+		for _, m := range methods {
+			routes = append(routes, Route{Method: strings.ToUpper(m), Path: path})
+		}
+	}
+	return routes
+}
+
+// pythonTypeParameters returns the PEP 695 type parameters of a function or
+// class definition as written, or nil if it declares none.
+//
+//	def first[T, *Ts, **P](...)   → ["T", "*Ts", "**P"]
+//	class Box[T: Item]:           → ["T: Item"]
+func pythonTypeParameters(node *sitter.Node, sourceCode []byte) []string {
+	typeParameters := node.ChildByFieldName("type_parameters")
+	if typeParameters == nil {
+		return nil
+	}
+	var params []string
+	for i := 0; i < int(typeParameters.NamedChildCount()); i++ {
+		// Defaults (PEP 696) do not parse yet and come back as ERROR nodes
+		if param := typeParameters.NamedChild(i); param.Type() == "type" {
+			params = append(params, param.Content(sourceCode))
+		}
+	}
+	return params
+}
+
+// pythonStringValue returns the value of a string literal node, without
+// prefix and quotes. ok is false for other nodes.
+func pythonStringValue(node *sitter.Node, sourceCode []byte) (value string, ok bool) {
+	if node == nil || node.Type() != "string" {
+		return "", false
+	}
+	text := strings.TrimLeft(node.Content(sourceCode), "rRbBuUfF")
+	return strings.Trim(text, `"'`), true
+}
+
+// pythonStringList returns the string literals in a list or tuple node.
+func pythonStringList(node *sitter.Node, sourceCode []byte) []string {
+	var values []string
+	if node == nil || (node.Type() != "list" && node.Type() != "tuple") {
+		return values
+	}
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		if value, ok := pythonStringValue(node.NamedChild(i), sourceCode); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 // hasDecorator checks if a list of decorators contains a specific decorator.
 func hasDecorator(decorators []string, name string) bool {
 	return slices.Contains(decorators, name)
 }
 
+// MetadataMemoization is the Node.Metadata key recording how a function's
+// result is cached: "lru_cache", "cache", or "cached_property".
+const MetadataMemoization = "memoization"
+
+// memoizingDecorators are the functools decorators that cache results.
+var memoizingDecorators = map[string]bool{
+	"lru_cache":       true,
+	"cache":           true,
+	"cached_property": true,
+}
+
+// memoization returns the memoizing decorator among decorators, written
+// bare or as functools.<name>, or "" if there is none.
+func memoization(decorators []string) string {
+	for _, decorator := range decorators {
+		name := strings.TrimPrefix(decorator, "functools.")
+		if memoizingDecorators[name] {
+			return name
+		}
+	}
+	return ""
+}
+
+// MetadataDispatchType is the Node.Metadata key recording the argument type
+// a function registered with @<generic>.register handles, for
+// functools.singledispatch: "int" for @process.register(int), or the
+// annotation of the first parameter for a bare @process.register.
+const MetadataDispatchType = "dispatch_type"
+
+// registeredDispatchType returns the type a function decorated with
+// @<generic>.register is registered for, or "" if it is not registered.
+func registeredDispatchType(node *sitter.Node, sourceCode []byte, parameters []string) string {
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		decorator := node.NamedChild(i)
+		if decorator.Type() != "decorator" || decorator.NamedChildCount() == 0 {
+			continue
+		}
+		expression := decorator.NamedChild(0)
+		switch {
+		case expression.Type() == "call":
+			function := expression.ChildByFieldName("function")
+			arguments := expression.ChildByFieldName("arguments")
+			if !isRegisterAttribute(function, sourceCode) || arguments == nil || arguments.NamedChildCount() == 0 {
+				continue
+			}
+			if argument := arguments.NamedChild(0); argument.Type() != "keyword_argument" {
+				return argument.Content(sourceCode)
+			}
+		case isRegisterAttribute(expression, sourceCode) && len(parameters) > 0:
+			annotation, _, _ := strings.Cut(parameters[0], "=")
+			_, annotation, _ = strings.Cut(annotation, ":")
+			return strings.TrimSpace(annotation)
+		}
+	}
+	return ""
+}
+
+// isRegisterAttribute reports whether node is an attribute ending in
+// .register.
+func isRegisterAttribute(node *sitter.Node, sourceCode []byte) bool {
+	if node == nil || node.Type() != "attribute" {
+		return false
+	}
+	attribute := node.ChildByFieldName("attribute")
+	return attribute != nil && attribute.Content(sourceCode) == "register"
+}
+
 // isConstantName checks if a variable name follows Python constant naming convention.
 // Constants are typically all uppercase with underscores (e.g., MAX_SIZE, API_KEY).
 func isConstantName(name string) bool {
@@ -84,6 +269,23 @@ func isSpecialMethod(functionName string) bool {
 	return strings.HasPrefix(functionName, "__") && strings.HasSuffix(functionName, "__")
 }
 
+// pythonVisibility infers the visibility Python conveys by naming, in the
+// terms Java and Go nodes use: "__name" is name-mangled and private,
+// "_name" is protected by convention, and everything else, including
+// special methods like __init__, is public.
+func pythonVisibility(name string) string {
+	switch {
+	case isSpecialMethod(name):
+		return "public"
+	case strings.HasPrefix(name, "__"):
+		return "private"
+	case strings.HasPrefix(name, "_"):
+		return "protected"
+	default:
+		return "public"
+	}
+}
+
 // isInterface checks if a class is a Python interface (Protocol or ABC).
 // Checks if any base class is "Protocol", "ABC", or ends with those names.
 func isInterface(superClasses []string) bool {
@@ -125,6 +327,21 @@ func isDataclass(decorators []string) bool {
 	return false
 }
 
+// parameterKind returns the kind of a parameter listed in a function's
+// MethodArgumentsValue, given the kind its position implies. Typed *args
+// and **kwargs ("*args: int") are listed too and are variadic.
+func parameterKind(param *sitter.Node, positional ParameterKind) ParameterKind {
+	if param.Type() == "typed_parameter" && param.NamedChildCount() > 0 {
+		switch param.NamedChild(0).Type() {
+		case "list_splat_pattern":
+			return ParameterVarPositional
+		case "dictionary_splat_pattern":
+			return ParameterVarKeyword
+		}
+	}
+	return positional
+}
+
 // parsePythonFunctionDefinition parses Python function definitions.
 // Handles decorators to distinguish between regular functions, methods, properties, and constructors.
 // Distinguishes methods (functions inside classes) from module-level functions.
@@ -140,12 +357,26 @@ func parsePythonFunctionDefinition(node *sitter.Node, sourceCode []byte, graph *
 
 	parametersNode := node.ChildByFieldName("parameters")
 	var methodArgumentsType []string
+	var parameterKinds []ParameterKind
 	if parametersNode != nil {
+		kind := ParameterNormal
 		for i := 0; i < int(parametersNode.NamedChildCount()); i++ {
 			param := parametersNode.NamedChild(i)
 			switch param.Type() {
 			case "identifier", "typed_parameter", "default_parameter", "typed_default_parameter":
 				parameters = append(parameters, param.Content(sourceCode))
+				parameterKinds = append(parameterKinds, parameterKind(param, kind))
+				if parameterKinds[len(parameterKinds)-1] == ParameterVarPositional {
+					kind = ParameterKeywordOnly
+				}
+			case "positional_separator":
+				// Everything before / is positional-only
+				for j := range parameterKinds {
+					parameterKinds[j] = ParameterPositionalOnly
+				}
+			case "keyword_separator", "list_splat_pattern":
+				// Everything after * or an untyped *args is keyword-only
+				kind = ParameterKeywordOnly
 			}
 			// Extract typed parameters for MethodArgumentsType in "name: type" format.
 			switch param.Type() {
@@ -206,12 +437,17 @@ func parsePythonFunctionDefinition(node *sitter.Node, sourceCode []byte, graph *
 	}
 
 	// Check for decorators (parent might be decorated_definition).
-	var decorators []string
+	decorators := []string{}
+	var routes []Route
+	dispatchType := ""
 	if node.Parent() != nil && node.Parent().Type() == "decorated_definition" {
 		decorators = extractDecorators(node.Parent(), sourceCode)
+		routes = extractRoutes(node.Parent(), sourceCode)
+		dispatchType = registeredDispatchType(node.Parent(), sourceCode, parameters)
 
 		// If function has @property decorator, mark it as property type.
-		if hasDecorator(decorators, "property") {
+		// A @cached_property is read the same way, computed once.
+		if hasDecorator(decorators, "property") || memoization(decorators) == "cached_property" {
 			nodeType = "property"
 		}
 	}
@@ -228,14 +464,27 @@ func parsePythonFunctionDefinition(node *sitter.Node, sourceCode []byte, graph *
 			EndByte:   node.EndByte(),
 		},
 		LineNumber:           lineNumber,
+		Modifier:             pythonVisibility(functionName),
 		ReturnType:           returnType,
 		MethodArgumentsType:  methodArgumentsType,
 		MethodArgumentsValue: parameters,
+		ParameterKinds:       parameterKinds,
+		TypeParameters:       pythonTypeParameters(node, sourceCode),
 		Annotation:           decorators,
+		Routes:               routes,
 		File:                 file,
 		isPythonSourceFile:   true,
 		Language:             "python",
 	}
+	if memoized := memoization(decorators); memoized != "" {
+		functionNode.Metadata = map[string]any{MetadataMemoization: memoized}
+	}
+	if dispatchType != "" {
+		if functionNode.Metadata == nil {
+			functionNode.Metadata = make(map[string]any)
+		}
+		functionNode.Metadata[MetadataDispatchType] = dispatchType
+	}
 	graph.AddNode(functionNode)
 	return functionNode
 }
@@ -275,7 +524,7 @@ func parsePythonClassDefinition(node *sitter.Node, sourceCode []byte, graph *Cod
 	}
 
 	// Check for decorators (parent might be decorated_definition).
-	var decorators []string
+	decorators := []string{}
 	if node.Parent() != nil && node.Parent().Type() == "decorated_definition" {
 		decorators = extractDecorators(node.Parent(), sourceCode)
 
@@ -285,6 +534,13 @@ func parsePythonClassDefinition(node *sitter.Node, sourceCode []byte, graph *Cod
 		}
 	}
 
+	// The first base is the superclass; Interface keeps every base, which
+	// inheritance resolution walks.
+	superClass := ""
+	if len(superClasses) > 0 {
+		superClass = superClasses[0]
+	}
+
 	classLineNumber := node.StartPoint().Row + 1
 	classNode := &Node{
 		ID:   GenerateMethodID("class:"+className, []string{}, file, classLineNumber),
@@ -296,8 +552,11 @@ func parsePythonClassDefinition(node *sitter.Node, sourceCode []byte, graph *Cod
 			EndByte:   node.EndByte(),
 		},
 		LineNumber:         classLineNumber,
+		Modifier:           pythonVisibility(className),
+		SuperClass:         superClass,
 		Interface:          superClasses,
 		Annotation:         decorators,
+		TypeParameters:     pythonTypeParameters(node, sourceCode),
 		File:               file,
 		isPythonSourceFile: true,
 		Language:           "python",
@@ -336,6 +336,64 @@ func TestExtractDecorators_NotDecoratedDefinition(t *testing.T) {
 	}
 }
 
+func TestExtractRoutes(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		expected []Route
+	}{
+		{
+			name:     "Flask route with methods",
+			code:     "@app.route(\"/x\", methods=[\"POST\", \"put\"])\ndef create():\n    pass",
+			expected: []Route{{Method: "POST", Path: "/x"}, {Method: "PUT", Path: "/x"}},
+		},
+		{
+			name:     "Flask route defaults to GET",
+			code:     "@bp.route('/users/<int:id>')\ndef show(id):\n    pass",
+			expected: []Route{{Method: "GET", Path: "/users/<int:id>"}},
+		},
+		{
+			name:     "FastAPI method decorator",
+			code:     "@app.get(\"/y\", response_model=Item)\nasync def read():\n    pass",
+			expected: []Route{{Method: "GET", Path: "/y"}},
+		},
+		{
+			name:     "Path keyword",
+			code:     "@router.delete(path=\"/items/{id}\")\ndef remove(id):\n    pass",
+			expected: []Route{{Method: "DELETE", Path: "/items/{id}"}},
+		},
+		{
+			name:     "Other decorators",
+			code:     "@login_required\n@cache.get(timeout=5)\ndef page():\n    pass",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := sitter.NewParser()
+			parser.SetLanguage(python.GetLanguage())
+			defer parser.Close()
+
+			tree, err := parser.ParseCtx(context.Background(), nil, []byte(tt.code))
+			if err != nil {
+				t.Fatalf("Failed to parse: %v", err)
+			}
+			defer tree.Close()
+
+			decorated := tree.RootNode().NamedChild(0)
+			if decorated == nil || decorated.Type() != "decorated_definition" {
+				t.Fatal("No decorated_definition node found")
+			}
+
+			routes := extractRoutes(decorated, []byte(tt.code))
+			if !slices.Equal(routes, tt.expected) {
+				t.Errorf("Expected routes %v, got %v", tt.expected, routes)
+			}
+		})
+	}
+}
+
 func TestHasDecorator(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -755,6 +813,96 @@ func TestIsSpecialMethod(t *testing.T) {
 	}
 }
 
+func TestPythonVisibility(t *testing.T) {
+	tests := map[string]string{
+		"get_value":  "public",
+		"__init__":   "public",
+		"__str__":    "public",
+		"_helper":    "protected",
+		"_Internal":  "protected",
+		"__secret":   "private",
+		"__mangled_": "private",
+	}
+	for name, expected := range tests {
+		if got := pythonVisibility(name); got != expected {
+			t.Errorf("pythonVisibility(%q) = %q, want %q", name, got, expected)
+		}
+	}
+}
+
+// TestDeclarationFieldsPopulated verifies that functions, methods, and
+// classes all carry a modifier and non-nil decorator and base lists.
+func TestDeclarationFieldsPopulated(t *testing.T) {
+	code := `
+def helper():
+    pass
+
+class _Base:
+    pass
+
+class Service(_Base, Mixin):
+    @staticmethod
+    def __build(value):
+        return value
+
+    def _reset(self):
+        pass
+`
+	parser := sitter.NewParser()
+	parser.SetLanguage(python.GetLanguage())
+	defer parser.Close()
+
+	tree, err := parser.ParseCtx(context.Background(), nil, []byte(code))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	defer tree.Close()
+
+	graph := NewCodeGraph()
+	buildGraphFromAST(tree.RootNode(), []byte(code), graph, nil, "test.py")
+
+	nodes := make(map[string]*Node)
+	for _, node := range graph.Nodes {
+		switch node.Type {
+		case "function_definition", "method", "class_definition":
+			nodes[node.Name] = node
+		}
+	}
+
+	tests := []struct {
+		name       string
+		modifier   string
+		decorators []string
+		superClass string
+		interfaces []string
+	}{
+		{"helper", "public", []string{}, "", nil},
+		{"__build", "private", []string{"staticmethod"}, "", nil},
+		{"_reset", "protected", []string{}, "", nil},
+		{"_Base", "protected", []string{}, "", []string{}},
+		{"Service", "public", []string{}, "_Base", []string{"_Base", "Mixin"}},
+	}
+	for _, tt := range tests {
+		node, ok := nodes[tt.name]
+		if !ok {
+			t.Errorf("%s not found", tt.name)
+			continue
+		}
+		if node.Modifier != tt.modifier {
+			t.Errorf("%s: expected modifier %q, got %q", tt.name, tt.modifier, node.Modifier)
+		}
+		if node.Annotation == nil || !slices.Equal(node.Annotation, tt.decorators) {
+			t.Errorf("%s: expected decorators %v, got %#v", tt.name, tt.decorators, node.Annotation)
+		}
+		if node.SuperClass != tt.superClass {
+			t.Errorf("%s: expected superclass %q, got %q", tt.name, tt.superClass, node.SuperClass)
+		}
+		if tt.interfaces != nil && (node.Interface == nil || !slices.Equal(node.Interface, tt.interfaces)) {
+			t.Errorf("%s: expected interfaces %v, got %#v", tt.name, tt.interfaces, node.Interface)
+		}
+	}
+}
+
 func TestIsInterface(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -2630,3 +2778,194 @@ func findNodeByNameAndType(graph *CodeGraph, name string, nodeType string) *Node
 	}
 	return nil
 }
+
+// TestParsePythonFunctionDefinition_ParameterKinds tests that the / and *
+// markers set how each parameter binds.
+func TestParsePythonFunctionDefinition_ParameterKinds(t *testing.T) {
+	tests := []struct {
+		name          string
+		code          string
+		expectedKinds []ParameterKind
+	}{
+		{
+			name:          "Normal only",
+			code:          "def f(a, b=1):\n    pass",
+			expectedKinds: []ParameterKind{ParameterNormal, ParameterNormal},
+		},
+		{
+			name:          "All three kinds",
+			code:          "def f(a, /, b, *, c):\n    pass",
+			expectedKinds: []ParameterKind{ParameterPositionalOnly, ParameterNormal, ParameterKeywordOnly},
+		},
+		{
+			name:          "Untyped *args makes later parameters keyword-only",
+			code:          "def f(a, *args, flag=False, **kwargs):\n    pass",
+			expectedKinds: []ParameterKind{ParameterNormal, ParameterKeywordOnly},
+		},
+		{
+			name:          "Typed variadics",
+			code:          "def f(a: int, /, *args: str, key: str, **options: int):\n    pass",
+			expectedKinds: []ParameterKind{ParameterPositionalOnly, ParameterVarPositional, ParameterKeywordOnly, ParameterVarKeyword},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := sitter.NewParser()
+			parser.SetLanguage(python.GetLanguage())
+			defer parser.Close()
+
+			tree, err := parser.ParseCtx(context.Background(), nil, []byte(tt.code))
+			if err != nil {
+				t.Fatalf("Failed to parse: %v", err)
+			}
+			defer tree.Close()
+
+			funcNode := findNodeByType(tree.RootNode(), "function_definition")
+			if funcNode == nil {
+				t.Fatal("No function_definition node found")
+			}
+
+			node := parsePythonFunctionDefinition(funcNode, []byte(tt.code), NewCodeGraph(), "test.py", nil)
+
+			if len(node.ParameterKinds) != len(node.MethodArgumentsValue) {
+				t.Fatalf("Expected a kind per parameter %v, got %v", node.MethodArgumentsValue, node.ParameterKinds)
+			}
+			if len(node.ParameterKinds) != len(tt.expectedKinds) {
+				t.Fatalf("Expected kinds %v, got %v", tt.expectedKinds, node.ParameterKinds)
+			}
+			for i, expected := range tt.expectedKinds {
+				if node.ParameterKinds[i] != expected {
+					t.Errorf("Parameter %q: expected kind %q, got %q", node.MethodArgumentsValue[i], expected, node.ParameterKinds[i])
+				}
+			}
+		})
+	}
+}
+
+// TestParsePythonTypeParameters tests that PEP 695 type parameters are
+// recorded on function and class nodes without shifting their parameters.
+func TestParsePythonTypeParameters(t *testing.T) {
+	tests := []struct {
+		name           string
+		code           string
+		nodeType       string
+		expectedParams []string
+		expectedArgs   []string
+	}{
+		{
+			name:           "Generic function",
+			code:           "def first[T](items: list[T], default: T = None) -> T:\n    pass",
+			nodeType:       "function_definition",
+			expectedParams: []string{"T"},
+			expectedArgs:   []string{"items: list[T]", "default: T = None"},
+		},
+		{
+			name:           "Bounds and variadics",
+			code:           "def apply[T: User, *Ts, **P](func, *args):\n    pass",
+			nodeType:       "function_definition",
+			expectedParams: []string{"T: User", "*Ts", "**P"},
+			expectedArgs:   []string{"func"},
+		},
+		{
+			name:           "Non-generic function",
+			code:           "def plain(a):\n    pass",
+			nodeType:       "function_definition",
+			expectedParams: nil,
+			expectedArgs:   []string{"a"},
+		},
+		{
+			name:           "Generic class",
+			code:           "class Box[T: (int, str)](Base):\n    pass",
+			nodeType:       "class_definition",
+			expectedParams: []string{"T: (int, str)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := sitter.NewParser()
+			parser.SetLanguage(python.GetLanguage())
+			defer parser.Close()
+
+			tree, err := parser.ParseCtx(context.Background(), nil, []byte(tt.code))
+			if err != nil {
+				t.Fatalf("Failed to parse: %v", err)
+			}
+			defer tree.Close()
+
+			definition := findNodeByType(tree.RootNode(), tt.nodeType)
+			if definition == nil {
+				t.Fatalf("No %s node found", tt.nodeType)
+			}
+
+			var node *Node
+			if tt.nodeType == "class_definition" {
+				node = parsePythonClassDefinition(definition, []byte(tt.code), NewCodeGraph(), "test.py")
+				if node.SuperClass != "Base" {
+					t.Errorf("Expected superclass Base, got %q", node.SuperClass)
+				}
+			} else {
+				node = parsePythonFunctionDefinition(definition, []byte(tt.code), NewCodeGraph(), "test.py", nil)
+				if !slices.Equal(node.MethodArgumentsValue, tt.expectedArgs) {
+					t.Errorf("Expected parameters %v, got %v", tt.expectedArgs, node.MethodArgumentsValue)
+				}
+			}
+			if !slices.Equal(node.TypeParameters, tt.expectedParams) {
+				t.Errorf("Expected type parameters %v, got %v", tt.expectedParams, node.TypeParameters)
+			}
+		})
+	}
+}
+
+// TestParsePythonFunctionDefinition_DispatchType tests that functions
+// registered on a singledispatch function record the type they handle.
+func TestParsePythonFunctionDefinition_DispatchType(t *testing.T) {
+	tests := []struct {
+		name         string
+		code         string
+		expectedType string
+	}{
+		{
+			name:         "Type argument",
+			code:         "@render.register(list)\ndef _(value):\n    pass",
+			expectedType: "list",
+		},
+		{
+			name:         "First parameter annotation",
+			code:         "@render.register\ndef _(value: int, width=0):\n    pass",
+			expectedType: "int",
+		},
+		{
+			name:         "Not registered",
+			code:         "@singledispatch\ndef render(value):\n    pass",
+			expectedType: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := sitter.NewParser()
+			parser.SetLanguage(python.GetLanguage())
+			defer parser.Close()
+
+			tree, err := parser.ParseCtx(context.Background(), nil, []byte(tt.code))
+			if err != nil {
+				t.Fatalf("Failed to parse: %v", err)
+			}
+			defer tree.Close()
+
+			funcNode := findNodeByType(tree.RootNode(), "function_definition")
+			if funcNode == nil {
+				t.Fatal("No function_definition node found")
+			}
+
+			node := parsePythonFunctionDefinition(funcNode, []byte(tt.code), NewCodeGraph(), "test.py", nil)
+
+			dispatchType, _ := node.Metadata[MetadataDispatchType].(string)
+			if dispatchType != tt.expectedType {
+				t.Errorf("Expected dispatch type %q, got %q", tt.expectedType, dispatchType)
+			}
+		})
+	}
+}
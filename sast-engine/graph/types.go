@@ -37,6 +37,9 @@ type Node struct {
 	isGoSourceFile       bool
 	ThrowsExceptions     []string
 	Annotation           []string
+	Routes               []Route         // HTTP routes the function handles, from route decorators or URLconf
+	ParameterKinds       []ParameterKind // How each MethodArgumentsValue entry binds (Python)
+	TypeParameters       []string        // PEP 695 type parameters as written, e.g. "T: User" or "**P" (Python)
 	JavaDoc              *model.Javadoc
 	BinaryExpr           *model.BinaryExpr
 	ClassInstanceExpr    *model.ClassInstanceExpr
@@ -54,6 +57,28 @@ type Node struct {
 	Metadata             map[string]any // Generic key-value store for language/tool-specific metadata
 }
 
+// ParameterKind is how a call binds a Python parameter, as in
+// inspect.Parameter.kind: def f(a, /, b, *args, c, **kwargs) has a
+// positional-only, b normal, args variadic positional, c keyword-only, and
+// kwargs variadic keyword.
+type ParameterKind string
+
+const (
+	ParameterPositionalOnly ParameterKind = "positional_only" // Before /; never bound by keyword
+	ParameterNormal         ParameterKind = "normal"          // Bound by position or keyword
+	ParameterVarPositional  ParameterKind = "var_positional"  // *args: collects extra positional arguments
+	ParameterKeywordOnly    ParameterKind = "keyword_only"    // After * or *args; never bound by position
+	ParameterVarKeyword     ParameterKind = "var_keyword"     // **kwargs: collects extra keyword arguments
+)
+
+// Route is an HTTP method and path template a handler is registered for,
+// e.g., {Method: "POST", Path: "/users/<int:id>"}. Method is "ANY" for
+// routes that accept every method, such as Django URLconf entries.
+type Route struct {
+	Method string
+	Path   string
+}
+
 // GetCodeSnippet returns the code snippet for this node.
 // If SourceLocation is set, it reads from the file (lazy loading).
 // Otherwise, it returns the deprecated CodeSnippet field for backward compatibility.
@@ -224,6 +224,12 @@ func (h *HTTPServer) healthHandler(w http.ResponseWriter, r *http.Request) {
 
 // setCORSHeaders sets CORS headers based on configuration.
 func (h *HTTPServer) setCORSHeaders(w http.ResponseWriter, r *http.Request) {
+	SetCORSHeaders(w, r, h.config.AllowedOrigins)
+}
+
+// SetCORSHeaders sets the CORS headers of a response for a POST API, echoing
+// the request's origin when allowedOrigins contains it or "*".
+func SetCORSHeaders(w http.ResponseWriter, r *http.Request, allowedOrigins []string) {
 	origin := r.Header.Get("Origin")
 	if origin == "" {
 		origin = "*"
@@ -231,7 +237,7 @@ func (h *HTTPServer) setCORSHeaders(w http.ResponseWriter, r *http.Request) {
 
 	// Check if origin is allowed.
 	allowed := false
-	for _, o := range h.config.AllowedOrigins {
+	for _, o := range allowedOrigins {
 		if o == "*" || o == origin {
 			allowed = true
 			break
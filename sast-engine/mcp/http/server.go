@@ -0,0 +1,122 @@
+// Package http serves the MCP tools over a small JSON REST API, for clients
+// that do not speak MCP, such as the playground. Each tool is called with a
+// POST of its arguments:
+//
+//	POST /tool/find_symbol
+//	{"name": "validate_user"}
+//
+// The response body is the tool's JSON result, with status 200, or 400 when
+// the tool reports an error. Unknown tools get 404.
+package http
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/mcp"
+)
+
+// maxBodySize limits the size of a tool call's arguments.
+const maxBodySize = 1 << 20
+
+// Handler serves the tools of an MCP server over REST.
+type Handler struct {
+	server *mcp.Server
+	config *mcp.HTTPConfig
+	mux    *http.ServeMux
+}
+
+// NewHandler creates a REST handler for the tools of server. A nil config
+// uses mcp.DefaultHTTPConfig.
+func NewHandler(server *mcp.Server, config *mcp.HTTPConfig) *Handler {
+	if config == nil {
+		config = mcp.DefaultHTTPConfig()
+	}
+
+	h := &Handler{server: server, config: config, mux: http.NewServeMux()}
+	h.mux.HandleFunc("POST /tool/{name}", h.handleTool)
+	h.mux.HandleFunc("OPTIONS /tool/{name}", h.handlePreflight)
+	return h
+}
+
+// NewServer creates an HTTP server for the REST API at config.Address, with
+// the config's timeouts. A nil config uses mcp.DefaultHTTPConfig.
+func NewServer(server *mcp.Server, config *mcp.HTTPConfig) *http.Server {
+	if config == nil {
+		config = mcp.DefaultHTTPConfig()
+	}
+
+	return &http.Server{
+		Addr:         config.Address,
+		Handler:      NewHandler(server, config),
+		ReadTimeout:  config.ReadTimeout,
+		WriteTimeout: config.WriteTimeout,
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// handlePreflight answers CORS preflight requests.
+func (h *Handler) handlePreflight(w http.ResponseWriter, r *http.Request) {
+	mcp.SetCORSHeaders(w, r, h.config.AllowedOrigins)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleTool calls the tool named in the path with the arguments in the
+// request body.
+func (h *Handler) handleTool(w http.ResponseWriter, r *http.Request) {
+	mcp.SetCORSHeaders(w, r, h.config.AllowedOrigins)
+
+	name := r.PathValue("name")
+	if !h.server.HasTool(name) {
+		writeError(w, http.StatusNotFound, "Unknown tool: "+name)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Failed to read request body")
+		return
+	}
+	defer r.Body.Close()
+
+	// An empty body calls the tool without arguments.
+	var args map[string]any
+	if strings.TrimSpace(string(body)) != "" {
+		if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+			writeError(w, http.StatusUnsupportedMediaType, "Content-Type must be application/json")
+			return
+		}
+		if err := json.Unmarshal(body, &args); err != nil {
+			writeError(w, http.StatusBadRequest, "Arguments must be a JSON object: "+err.Error())
+			return
+		}
+	}
+
+	result, isError := h.server.CallTool(name, args)
+	status := http.StatusOK
+	if isError {
+		status = http.StatusBadRequest
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if json.Valid([]byte(result)) {
+		_, _ = io.WriteString(w, result)
+		return
+	}
+	// Tools return JSON, but do not rely on it.
+	_ = json.NewEncoder(w).Encode(map[string]string{"result": result})
+}
+
+// writeError writes an error response.
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
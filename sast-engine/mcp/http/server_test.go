@@ -0,0 +1,125 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/builder"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/registry"
+	"github.com/shivasurya/code-pathfinder/sast-engine/mcp"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestAPI serves the REST API over the index of a fixture project.
+func newTestAPI(t *testing.T) *httptest.Server {
+	t.Helper()
+	projectPath, err := filepath.Abs("../../test-fixtures/python/parameter_kinds")
+	require.NoError(t, err)
+	codeGraph := graph.Initialize(projectPath, nil)
+	moduleRegistry, err := registry.BuildModuleRegistry(projectPath, false)
+	require.NoError(t, err)
+	callGraph, err := builder.BuildCallGraph(codeGraph, moduleRegistry, projectPath, output.NewLogger(output.VerbosityDefault))
+	require.NoError(t, err)
+
+	server := mcp.NewServer(projectPath, "3.11", callGraph, moduleRegistry, codeGraph, time.Second, true)
+	api := httptest.NewServer(NewHandler(server, &mcp.HTTPConfig{AllowedOrigins: []string{"https://playground.example"}}))
+	t.Cleanup(api.Close)
+	return api
+}
+
+// callTool posts args to the tool endpoint and decodes the JSON response.
+func callTool(t *testing.T, api *httptest.Server, tool, args string) (int, map[string]any) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, api.URL+"/tool/"+tool, strings.NewReader(args))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var result map[string]any
+	require.NoError(t, json.Unmarshal(body, &result), string(body))
+	return resp.StatusCode, result
+}
+
+func TestHandler_FindSymbol(t *testing.T) {
+	api := newTestAPI(t)
+
+	status, result := callTool(t, api, "find_symbol", `{"name": "send"}`)
+
+	assert.Equal(t, http.StatusOK, status)
+	matches, ok := result["matches"].([]any)
+	require.True(t, ok, result)
+	require.NotEmpty(t, matches)
+	assert.Equal(t, "notify.send", matches[0].(map[string]any)["fqn"])
+}
+
+func TestHandler_GetCallers(t *testing.T) {
+	api := newTestAPI(t)
+
+	status, result := callTool(t, api, "get_callers", `{"function": "send"}`)
+
+	assert.Equal(t, http.StatusOK, status)
+	callers, ok := result["callers"].([]any)
+	require.True(t, ok, result)
+	var fqns []string
+	for _, caller := range callers {
+		fqns = append(fqns, caller.(map[string]any)["fqn"].(string))
+	}
+	assert.ElementsMatch(t, []string{"notify.notify_all", "notify.notify_admin"}, fqns)
+}
+
+func TestHandler_Errors(t *testing.T) {
+	api := newTestAPI(t)
+
+	status, result := callTool(t, api, "no_such_tool", `{}`)
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Contains(t, result["error"], "no_such_tool")
+
+	status, result = callTool(t, api, "find_symbol", `["send"]`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, result["error"], "JSON object")
+
+	status, result = callTool(t, api, "get_callers", `{}`)
+	assert.Equal(t, http.StatusBadRequest, status, "the tool reports the missing argument")
+	assert.Contains(t, result, "error")
+}
+
+func TestHandler_CORS(t *testing.T) {
+	api := newTestAPI(t)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodOptions, api.URL+"/tool/find_symbol", nil)
+	require.NoError(t, err)
+	req.Header.Set("Origin", "https://playground.example")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, "https://playground.example", resp.Header.Get("Access-Control-Allow-Origin"))
+	assert.Contains(t, resp.Header.Get("Access-Control-Allow-Methods"), "POST")
+}
+
+func TestNewServer(t *testing.T) {
+	config := &mcp.HTTPConfig{Address: ":9191", ReadTimeout: 3 * time.Second, WriteTimeout: 4 * time.Second}
+
+	server := NewServer(nil, config)
+
+	assert.Equal(t, ":9191", server.Addr)
+	assert.Equal(t, 3*time.Second, server.ReadTimeout)
+	assert.Equal(t, 4*time.Second, server.WriteTimeout)
+	assert.IsType(t, &Handler{}, server.Handler)
+}
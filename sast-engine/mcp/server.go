@@ -11,6 +11,7 @@ import (
 
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/patterns"
 	"github.com/shivasurya/code-pathfinder/sast-engine/updatecheck"
 )
 
@@ -38,6 +39,10 @@ type Server struct {
 	goVersion        string
 	goModuleRegistry *core.GoModuleRegistry
 
+	// patternRegistry holds the rules list_rules reports. Set via
+	// SetPatternRegistry; nil means the default patterns.
+	patternRegistry *patterns.PatternRegistry
+
 	// updateInfo is populated once at server construction via a synchronous
 	// updatecheck.Check call (5 s timeout). It is immutable for the lifetime
 	// of the process — no goroutine, no locking, no Close() needed.
@@ -361,10 +366,7 @@ func (s *Server) handleToolsCall(req *JSONRPCRequest) *JSONRPCResponse {
 
 	fmt.Fprintf(os.Stderr, "Tool call: %s\n", params.Name)
 
-	// Track tool call metrics.
-	metrics := s.analytics.StartToolCall(params.Name)
-	result, isError := s.executeTool(params.Name, params.Arguments)
-	s.analytics.EndToolCall(metrics, !isError)
+	result, isError := s.CallTool(params.Name, params.Arguments)
 
 	return SuccessResponse(req.ID, ToolResult{
 		Content: []ContentBlock{
@@ -377,6 +379,26 @@ func (s *Server) handleToolsCall(req *JSONRPCRequest) *JSONRPCResponse {
 	})
 }
 
+// CallTool executes the named tool with the given arguments, as a tools/call
+// request does, and returns its JSON result and whether it is an error.
+func (s *Server) CallTool(name string, args map[string]any) (string, bool) {
+	// Track tool call metrics.
+	metrics := s.analytics.StartToolCall(name)
+	result, isError := s.executeTool(name, args)
+	s.analytics.EndToolCall(metrics, !isError)
+	return result, isError
+}
+
+// HasTool reports whether the server provides a tool with the given name.
+func (s *Server) HasTool(name string) bool {
+	for _, tool := range s.getToolDefinitions() {
+		if tool.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 // handleStatus returns the current indexing status, enriched with any
 // available update-check fields.
 func (s *Server) handleStatus(req *JSONRPCRequest) *JSONRPCResponse {
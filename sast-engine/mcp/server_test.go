@@ -388,7 +388,7 @@ func TestHandleToolsList(t *testing.T) {
 
 	result, ok := resp.Result.(ToolsListResult)
 	require.True(t, ok)
-	assert.Equal(t, 13, len(result.Tools)) // PR-03: 13 tools (added status)
+	assert.Equal(t, 25, len(result.Tools))
 }
 
 func TestHandleToolsCall_GetIndexInfo(t *testing.T) {
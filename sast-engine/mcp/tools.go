@@ -1,8 +1,11 @@
 package mcp
 
 import (
+	"cmp"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -44,6 +47,46 @@ const (
 	SymbolKindTypeParam   = 26 // TypeParameter
 )
 
+// addDeclarationFields adds a symbol's modifier, decorators, superclass,
+// and interfaces to its find_symbol match. Functions carry modifier and
+// decorators, and classes also superclass and interfaces, even when empty,
+// so consumers can rely on them. Other symbols get only the fields set.
+// Memoized functions also report the memoizing decorator.
+func addDeclarationFields(match map[string]any, node *graph.Node, symbolKind int) {
+	isFunction, isClass := false, false
+	switch symbolKind {
+	case SymbolKindFunction, SymbolKindMethod, SymbolKindConstructor, SymbolKindProperty, SymbolKindOperator:
+		isFunction = true
+	case SymbolKindClass, SymbolKindInterface, SymbolKindEnum, SymbolKindStruct:
+		isClass = true
+	}
+
+	if isFunction || isClass || node.Modifier != "" {
+		match["modifier"] = node.Modifier
+	}
+	if isFunction || isClass || len(node.Annotation) > 0 {
+		match["decorators"] = nonNilStrings(node.Annotation)
+	}
+	if isClass || node.SuperClass != "" {
+		match["superclass"] = node.SuperClass
+	}
+	if isClass || len(node.Interface) > 0 {
+		match["interfaces"] = nonNilStrings(node.Interface)
+	}
+	if memoization, ok := node.Metadata[graph.MetadataMemoization].(string); ok {
+		match["memoization"] = memoization
+	}
+}
+
+// nonNilStrings returns values, or an empty slice when values is nil, so
+// it encodes as [] rather than null.
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
 // getSymbolKind maps Python symbol types to LSP SymbolKind integers and names.
 // Returns (kind int, kindName string) for the given symbol type.
 func getSymbolKind(symbolType string) (int, string) {
@@ -150,7 +193,7 @@ Symbol Types Available:
 - Go Variables: package_variable, constant, variable_assignment
 
 Returns: For ALL symbols: fqn, file, line, type, symbol_kind (LSP integer), symbol_kind_name (human-readable).
-For functions/methods: return_type, parameters, decorators. For classes: superclass, interfaces. For fields: inferred_type, confidence, assigned_in.
+For functions/methods: return_type, parameters, modifier (public/protected/private), decorators, purity (pure/impure/unknown, including callees; unknown when a call cannot be classified), memoization (lru_cache/cache/cached_property, when memoized). For classes: modifier, decorators, superclass, interfaces. Modifier, decorators and bases are always present for functions and classes, possibly empty. For fields: inferred_type, confidence, assigned_in.
 For parameters: inferred_type (type annotation), parent_fqn (containing function).
 
 LSP Symbol Kinds: Function(12), Method(6), Constructor(9), Property(7), Operator(25), Class(5), Interface(11), Enum(10), Struct(23), Variable(13), Constant(14), Field(8).
@@ -311,7 +354,7 @@ Examples:
 			Name: "get_call_details",
 			Description: `Get detailed information about a SPECIFIC call from one function to another. Most detailed view of a single call site.
 
-Returns: Full call site info including caller FQN, target, exact location (file, line, column), arguments passed, and resolution details (resolved status, failure reason if unresolved, type inference info).
+Returns: Full call site info including caller FQN, target, exact location (file, line, column), arguments passed (with the parameter each binds and its kind: positional_only, normal, var_positional, keyword_only, var_keyword, when the callee is in the project), and resolution details (resolved status, failure reason if unresolved, type inference info, and for @singledispatch calls the generic function and the argument type the implementation was chosen for).
 
 Use when: Investigating a specific function call, understanding how arguments are passed, debugging why a call wasn't resolved, or analyzing type inference.
 
@@ -327,6 +370,210 @@ Examples:
 				Required: []string{"caller", "callee"},
 			},
 		},
+		{
+			Name: "get_call_path",
+			Description: `Find the shortest call chain from one function to another. Answer: "How does A reach B?"
+
+Returns: found, from_fqns and to_fqns (the functions each name matched), and when a path exists: path (array of fqn, name, file, line, and call_site (file, line, column) of the call to the next function; the last function has no call_site), length (number of calls), and alternate_paths (how many other paths of the same length exist, counted up to 999). When no path exists, found is false with a message; this is not an error.
+
+When a name matches several functions, the search runs from all "from" matches to any "to" match, and notes lists the matches. "to" may be a library function the project calls, such as subprocess.call; its hop has no file or line.
+
+Use when: Checking whether an entry point can reach a dangerous function, explaining how a helper ends up being called, or scoping the impact of a change.
+
+Examples:
+- get_call_path(from="login", to="execute_query") - how login reaches the database
+- get_call_path(from="myapp.views.upload", to="subprocess.call") - does upload reach a shell?`,
+			InputSchema: InputSchema{
+				Type: "object",
+				Properties: map[string]Property{
+					"from": {Type: "string", Description: "Function the chain starts at. Use short name ('login') or FQN ('myapp.auth.login')"},
+					"to":   {Type: "string", Description: "Function the chain ends at. Use short name ('execute_query') or FQN ('myapp.db.execute_query'), including library functions the project calls ('subprocess.call')"},
+				},
+				Required: []string{"from", "to"},
+			},
+		},
+		{
+			Name: "get_cfg",
+			Description: `Get the control flow graph (CFG) of a function: its basic blocks and the edges between them.
+
+Returns: function info (fqn, file, line), entry and exit block ids, block_count, edge_count, complexity (cyclomatic: 1 + decision points, counting each comparison of a chained comparison), blocks (id, type, statement_count, start_line/end_line when known, condition for branches) and edges (from, to).
+
+Block types: entry, exit, normal, conditional, loop, try, catch, finally.
+
+Use when: Understanding branching and loops in a function, checking whether a call happens on every path, or reasoning about where sanitization occurs relative to a sink.
+
+Examples:
+- get_cfg("process_payment") - blocks and edges of process_payment
+- get_cfg("myapp.views.login") - CFG using the full FQN`,
+			InputSchema: InputSchema{
+				Type: "object",
+				Properties: map[string]Property{
+					"function": {Type: "string", Description: "Function to get the CFG for. Use short name ('login') or FQN ('myapp.views.login')"},
+				},
+				Required: []string{"function"},
+			},
+		},
+		{
+			Name: "get_def_use",
+			Description: `Get where a variable is defined and where it is used inside a function (its def-use chain).
+
+Returns: function info (fqn, file, line), variable, definitions and uses (line, statement type, call target for calls), and the sorted def_lines and use_lines.
+
+Use when: Debugging a taint result (which assignment a sink's argument came from), checking whether a variable is reassigned before use, or following data through a function.
+
+Examples:
+- get_def_use("login", "password") - where password is assigned and read in login
+- get_def_use("myapp.views.search", "query") - def-use chain using the full FQN`,
+			InputSchema: InputSchema{
+				Type: "object",
+				Properties: map[string]Property{
+					"function": {Type: "string", Description: "Function containing the variable. Use short name ('login') or FQN ('myapp.views.login')"},
+					"variable": {Type: "string", Description: "Variable name as written in the function (e.g., 'query')"},
+				},
+				Required: []string{"function", "variable"},
+			},
+		},
+		{
+			Name: "get_hotspots",
+			Description: `List the most connected functions in the call graph: highest fan-in (most distinct callers) and highest fan-out (most distinct callees).
+
+Returns: total_functions, by_fan_in and by_fan_out, each an array of functions (fqn, name, file, line, fan_in, fan_out) ordered from most to least connected.
+
+High fan-out marks "God functions" that do too much and are candidates for splitting. High fan-in marks functions many others depend on, where a change or bug has the widest impact.
+
+Use when: Prioritizing refactoring or code review, finding risky functions to change, or getting a feel for a codebase's central pieces.
+
+Examples:
+- get_hotspots() - top 10 functions by fan-in and by fan-out
+- get_hotspots(limit=25) - top 25 of each`,
+			InputSchema: InputSchema{
+				Type: "object",
+				Properties: map[string]Property{
+					"limit": {Type: "integer", Description: "Functions to return per list (default: 10, max: 100)"},
+				},
+			},
+		},
+		{
+			Name: "get_unresolved_report",
+			Description: `Explain why calls failed to resolve: counts of unresolved call sites per failure category, with the call sites in each.
+
+Returns: total, by_category (category -> count) and call_sites (category -> array of caller, target, file, line ordered by caller, then line). Categories: external_framework, stdlib_unresolved, orm_pattern, attribute_chain, variable_method, super_call, not_in_imports, unresolved_go_call, unknown.
+
+Use when: Judging how complete the call graph is, finding which kind of gap hides the most calls, or listing the calls a missing import or type annotation leaves unresolved.
+
+Examples:
+- get_unresolved_report() - counts and up to 20 call sites per category
+- get_unresolved_report(category="not_in_imports", limit=100) - call sites of one category`,
+			InputSchema: InputSchema{
+				Type: "object",
+				Properties: map[string]Property{
+					"category": {Type: "string", Description: "Only list call sites of this category (e.g., 'attribute_chain'); counts always cover all categories"},
+					"limit":    {Type: "integer", Description: "Call sites to return per category (default: 20, max: 500)"},
+				},
+			},
+		},
+		{
+			Name: "get_graph_metrics",
+			Description: `Measure the shape of the call graph: its weakly connected components and its orphan functions.
+
+Returns: total_functions, call_edges, total_components, components (array of size and functions, largest first), total_orphans and orphans (array of fqn, name, file, line, sorted by FQN). Components group functions linked by calls in either direction; calls to external functions (stdlib, third-party) do not join them.
+
+Orphans have neither callers nor callees: often dead code, or functions only reached through calls that failed to resolve. Many small components suggest loosely coupled modules; one giant component, a tangled codebase.
+
+Use when: Reporting on codebase health, looking for dead code, or judging module cohesion before a refactor.
+
+Examples:
+- get_graph_metrics() - components and orphans, up to 20 of each
+- get_graph_metrics(limit=100) - up to 100 of each`,
+			InputSchema: InputSchema{
+				Type: "object",
+				Properties: map[string]Property{
+					"limit": {Type: "integer", Description: "Components and orphans to return (default: 20, max: 500)"},
+				},
+			},
+		},
+		{
+			Name: "get_circular_imports",
+			Description: `List the circular imports between project modules: groups of modules that import each other, directly or through other modules.
+
+Returns: total and cycles, an array of cycles each listing its module FQNs (sorted) and the files that define them. Only imports of project modules count; stdlib and third-party imports cannot form a cycle with project code.
+
+Circular imports cause ImportError or partially initialized modules depending on which module is imported first, and make code hard to split.
+
+Use when: Debugging "cannot import name" or "partially initialized module" errors, or reviewing module structure before a refactor.
+
+Examples:
+- get_circular_imports() - all import cycles in the project`,
+			InputSchema: InputSchema{
+				Type:       "object",
+				Properties: map[string]Property{},
+			},
+		},
+		{
+			Name: "list_routes",
+			Description: `List the HTTP routes of the project: each handler's method and path template, from Flask/FastAPI route decorators (@app.route, @router.get, ...) and Django URLconf path()/re_path() entries.
+
+Returns: total and routes, an array of (method, path, handler FQN, file, line) ordered by path, then method. Django URLconf routes accept any method and are listed with method "ANY".
+
+When the project uses a web framework, also returns framework and entry_points: the handlers registered on a Flask application or blueprint variable, under any name and even when imported from another module, with the registrar's FQN, the decorator, and the handler's routes.
+
+Use when: Building an API inventory, finding the handler behind a URL, or reviewing which endpoints accept writes.
+
+Examples:
+- list_routes() - every route
+- list_routes(method="POST") - routes that accept POST`,
+			InputSchema: InputSchema{
+				Type: "object",
+				Properties: map[string]Property{
+					"method": {Type: "string", Description: "Only list routes for this HTTP method (e.g., 'POST'); ANY routes always match"},
+				},
+			},
+		},
+		{
+			Name: "find_by_signature",
+			Description: `Find functions by signature: those taking a parameter of a given type, returning a given type, or both. Types come from annotations (Python), declarations (Go, Java); unannotated parameters never match.
+
+Patterns may use * as a wildcard and match a type as written or its last dotted segment, so "HttpRequest" matches django.http.HttpRequest.
+
+Returns: total and functions (fqn, name, file, line, parameters, return_type), ordered by FQN.
+
+Use when: Finding all request handlers, every function returning a sensitive type, or the functions accepting a model or client.
+
+Examples:
+- find_by_signature(param_type="HttpRequest") - Django views taking a request
+- find_by_signature(return_type="*Response") - functions returning any response type
+- find_by_signature(param_type="*Request", return_type="HttpResponse")`,
+			InputSchema: InputSchema{
+				Type: "object",
+				Properties: map[string]Property{
+					"param_type":  {Type: "string", Description: "Type one of the parameters must have, * as wildcard (e.g., 'HttpRequest')"},
+					"return_type": {Type: "string", Description: "Return type, * as wildcard (e.g., '*Response')"},
+					"limit":       {Type: "integer", Description: "Max results to return (default: 50, max: 500)"},
+					"cursor":      {Type: "string", Description: "Pagination cursor from previous response"},
+				},
+			},
+		},
+		{
+			Name: "find_variables_of_type",
+			Description: `Find every variable inferred to hold an instance of a type, across all functions and modules. Reverse of type inference: "who assigns this type?"
+
+Returns: type, total, and variables (name, scope FQN, file, line, confidence, inference source), ordered by file and line. Each reassignment is listed separately.
+
+Use when: Assessing the impact of changing a class, finding where instances of a model or client are created, or tracing which functions hold a given object.
+
+Examples:
+- find_variables_of_type("myapp.models.User") - all variables bound to User instances
+- find_variables_of_type("requests.Session", limit=20) - first 20 Session variables`,
+			InputSchema: InputSchema{
+				Type: "object",
+				Properties: map[string]Property{
+					"type":   {Type: "string", Description: "Fully qualified type name (e.g., 'myapp.models.User')"},
+					"limit":  {Type: "integer", Description: "Max results to return (default: 50, max: 500)"},
+					"cursor": {Type: "string", Description: "Pagination cursor from previous response"},
+				},
+				Required: []string{"type"},
+			},
+		},
 		{
 			Name: "resolve_import",
 			Description: `Resolve a Python import path to its actual file location in the project.
@@ -429,6 +676,47 @@ Examples:
 				Required: []string{"file_path"},
 			},
 		},
+		{
+			Name: "list_rules",
+			Description: `List the available security rules with their metadata, without running analysis.
+
+Returns: rules (id, name, description, type, severity, cwe, owasp, enabled), ordered by id, with total and enabled counts. Disabled rules are listed with enabled=false.
+
+Use when: Building a rule-selection screen, checking in CI that expected rules are present, or looking up the CWE of a rule ID from a finding.
+
+Examples:
+- list_rules() - every registered rule`,
+			InputSchema: InputSchema{
+				Type:       "object",
+				Properties: map[string]Property{},
+			},
+		},
+		{
+			Name: "explain_finding",
+			Description: `Explain a security finding step by step: where the input is read, each call it passes through, and the sink it reaches, with the line of code at each step.
+
+Returns: finding_id, fingerprint, rule (id, name, description, severity, cwe, owasp), status (confirmed/potential), score, context, remediation, and steps (array of step, kind (source/call/sink), description, function, file, line, snippet) in flow order. Findings without a data flow (e.g., a dangerous function call) have a single sink step. Without finding_id, returns findings (finding_id, fingerprint, rule_id, name, severity, status, score, file, line), total, and summary (total, suppressed, by_file, by_module, by_severity, by_rule, top_files); pass status to list only confirmed or potential findings, and sort="score" to list the riskiest first.
+
+Scores range from 0.0 to 1.0 and weight the rule's severity, how confidently the flow's calls were resolved, and whether an HTTP route reaches the source; findings in internal scripts score half.
+
+Finding IDs are stable across runs: they hash the rule and the functions of the flow, not line numbers. Fingerprints hash the rule, the sink's function, and its line of code, matching the fingerprints scan writes to JSON and SARIF output, for comparing against a baseline.
+
+Use when: Triaging a finding, deciding whether it is a true positive, or writing up a fix.
+
+Examples:
+- explain_finding() - list findings and their IDs
+- explain_finding(status="confirmed") - list only findings whose every call resolved with high confidence
+- explain_finding(sort="score") - list findings, riskiest first
+- explain_finding("CODE-INJECTION-001:5d41402a") - full flow of one finding`,
+			InputSchema: InputSchema{
+				Type: "object",
+				Properties: map[string]Property{
+					"finding_id": {Type: "string", Description: "ID of the finding to explain (e.g., 'CODE-INJECTION-001:5d41402a'); omit to list findings"},
+					"status":     {Type: "string", Description: "When listing, only findings with this status: 'confirmed' or 'potential'"},
+					"sort":       {Type: "string", Description: "When listing, 'score' orders findings by score, highest first"},
+				},
+			},
+		},
 		{
 			Name: "status",
 			Description: `Returns the current server state: indexing phase, progress, and readiness.
@@ -522,6 +810,26 @@ func (s *Server) executeTool(name string, args map[string]any) (string, bool) {
 		caller, _ := args["caller"].(string)
 		callee, _ := args["callee"].(string)
 		return s.toolGetCallDetails(caller, callee)
+	case "get_call_path":
+		return s.toolGetCallPath(args)
+	case "get_cfg":
+		return s.toolGetCFG(args)
+	case "get_def_use":
+		return s.toolGetDefUse(args)
+	case "get_hotspots":
+		return s.toolGetHotspots(args)
+	case "get_unresolved_report":
+		return s.toolGetUnresolvedReport(args)
+	case "get_graph_metrics":
+		return s.toolGetGraphMetrics(args)
+	case "get_circular_imports":
+		return s.toolGetCircularImports()
+	case "list_routes":
+		return s.toolListRoutes(args)
+	case "find_by_signature":
+		return s.toolFindBySignature(args)
+	case "find_variables_of_type":
+		return s.toolFindVariablesOfType(args)
 	case "resolve_import":
 		importPath, _ := args["import"].(string)
 		return s.toolResolveImport(importPath)
@@ -533,6 +841,10 @@ func (s *Server) executeTool(name string, args map[string]any) (string, bool) {
 		return s.toolGetDockerfileDetails(args)
 	case "get_docker_dependencies":
 		return s.toolGetDockerDependencies(args)
+	case "list_rules":
+		return s.toolListRules()
+	case "explain_finding":
+		return s.toolExplainFinding(args)
 	case "status":
 		return s.toolStatus()
 	default:
@@ -640,6 +952,7 @@ func (s *Server) toolGetIndexInfo() (string, bool) {
 			"functions_count": functionsCount,
 		})
 	}
+	sortEntries(moduleStats, "module_fqn")
 
 	// Build comprehensive result.
 	result := map[string]any{
@@ -682,38 +995,14 @@ func (s *Server) toolGetIndexInfo() (string, bool) {
 	return string(bytes), false
 }
 
-// getTopModules returns the top N modules by function count.
+// getTopModules returns the top N modules by function count. Modules with
+// the same count keep their order in moduleStats.
 func getTopModules(moduleStats []map[string]any, limit int) []map[string]any {
-	// Sort by functions_count descending.
-	type moduleStat struct {
-		data           map[string]any
-		functionsCount int
-	}
-
-	stats := make([]moduleStat, len(moduleStats))
-	for i, m := range moduleStats {
-		stats[i] = moduleStat{
-			data:           m,
-			functionsCount: m["functions_count"].(int),
-		}
-	}
-
-	// Simple bubble sort for top N (good enough for small N).
-	for i := 0; i < len(stats) && i < limit; i++ {
-		for j := i + 1; j < len(stats); j++ {
-			if stats[j].functionsCount > stats[i].functionsCount {
-				stats[i], stats[j] = stats[j], stats[i]
-			}
-		}
-	}
-
-	// Return top N.
-	result := make([]map[string]any, 0, limit)
-	for i := 0; i < len(stats) && i < limit; i++ {
-		result = append(result, stats[i].data)
-	}
-
-	return result
+	sorted := slices.Clone(moduleStats)
+	slices.SortStableFunc(sorted, func(a, b map[string]any) int {
+		return cmp.Compare(b["functions_count"].(int), a["functions_count"].(int))
+	})
+	return sorted[:min(len(sorted), limit)]
 }
 
 // returnIndexingStatus returns a consistent "indexing" response for all tools.
@@ -863,17 +1152,9 @@ func (s *Server) toolFindSymbol(args map[string]any) (string, bool) {
 			if len(node.MethodArgumentsType) > 0 {
 				match["parameters"] = node.MethodArgumentsType
 			}
-			if node.Modifier != "" {
-				match["modifier"] = node.Modifier
-			}
-			if len(node.Annotation) > 0 {
-				match["decorators"] = node.Annotation
-			}
-			if node.SuperClass != "" {
-				match["superclass"] = node.SuperClass
-			}
-			if len(node.Interface) > 0 {
-				match["interfaces"] = node.Interface
+			addDeclarationFields(match, node, symbolKind)
+			if len(node.Routes) > 0 {
+				match["routes"] = routeList(node.Routes)
 			}
 
 			allMatches = append(allMatches, match)
@@ -1062,18 +1343,7 @@ func (s *Server) toolFindSymbol(args map[string]any) (string, bool) {
 				}
 
 				// Add optional fields if available.
-				if len(node.Annotation) > 0 {
-					match["decorators"] = node.Annotation
-				}
-				if node.SuperClass != "" {
-					match["superclass"] = node.SuperClass
-				}
-				if len(node.Interface) > 0 {
-					match["interfaces"] = node.Interface
-				}
-				if node.Modifier != "" {
-					match["modifier"] = node.Modifier
-				}
+				addDeclarationFields(match, node, symbolKind)
 
 				// Look up inferred type for module variables and constants.
 				if (node.Type == "module_variable" || node.Type == "constant") && s.callGraph.TypeEngine != nil {
@@ -1107,8 +1377,21 @@ func (s *Server) toolFindSymbol(args map[string]any) (string, bool) {
 	}
 
 	// Apply pagination.
+	sortEntries(allMatches, "fqn", "file", "line", "type")
 	matches, pageInfo := PaginateSlice(allMatches, pageParams)
 
+	// Classify the functions of the returned page only.
+	for _, match := range matches {
+		fqn, _ := match["fqn"].(string)
+		if s.callGraph.Functions[fqn] == nil {
+			continue
+		}
+		switch match["symbol_kind"] {
+		case SymbolKindFunction, SymbolKindMethod, SymbolKindConstructor, SymbolKindProperty, SymbolKindOperator:
+			match["purity"] = string(s.callGraph.Purity(fqn))
+		}
+	}
+
 	// Build filters_applied info for response.
 	filtersApplied := map[string]any{}
 	if name != "" {
@@ -1189,6 +1472,7 @@ func (s *Server) toolFindModule(name string) (string, bool) {
 	if len(matches) == 0 {
 		return fmt.Sprintf(`{"error": "Module not found: %s", "suggestion": "Check module name or try a partial match"}`, name), true
 	}
+	sortEntries(matches, "module_fqn")
 
 	if len(matches) == 1 {
 		// Single match.
@@ -1231,6 +1515,8 @@ func (s *Server) toolListModules() (string, bool) {
 		})
 	}
 
+	sortEntries(modules, "module_fqn")
+
 	result := map[string]any{
 		"modules":       modules,
 		"total_modules": len(modules),
@@ -1343,6 +1629,7 @@ func (s *Server) toolGetCallers(args map[string]any) (string, bool) {
 	}
 
 	// Apply pagination.
+	sortEntries(allCallers, "fqn")
 	callers, pageInfo := PaginateSlice(allCallers, pageParams)
 
 	targetInfo := map[string]any{
@@ -1397,8 +1684,14 @@ func (s *Server) toolGetCallees(args map[string]any) (string, bool) {
 	sourceFQN := fqns[0]
 	sourceNode := s.callGraph.Functions[sourceFQN]
 
-	// Get call sites for this function.
-	callSites := s.callGraph.CallSites[sourceFQN]
+	// Get call sites for this function, in source order.
+	callSites := slices.Clone(s.callGraph.CallSites[sourceFQN])
+	slices.SortStableFunc(callSites, func(a, b core.CallSite) int {
+		return cmp.Or(
+			cmp.Compare(a.Location.Line, b.Location.Line),
+			cmp.Compare(a.Location.Column, b.Location.Column),
+		)
+	})
 
 	allCallees := make([]map[string]any, 0, len(callSites))
 	resolvedCount := 0
@@ -1507,7 +1800,7 @@ func (s *Server) toolGetCallDetails(callerName, calleeName string) (string, bool
 				"resolved": cs.Resolved,
 			}
 
-			// Add arguments if available.
+			// Add arguments if available, with the parameters they bind.
 			if len(cs.Arguments) > 0 {
 				args := make([]map[string]any, len(cs.Arguments))
 				for i, arg := range cs.Arguments {
@@ -1516,6 +1809,14 @@ func (s *Server) toolGetCallDetails(callerName, calleeName string) (string, bool
 						"value":    arg.Value,
 					}
 				}
+				if target, ok := s.callGraph.Functions[cs.TargetFQN]; ok && target != nil {
+					for i, binding := range core.BindArguments(target, cs.Arguments) {
+						if binding.Parameter != "" {
+							args[i]["parameter"] = binding.Parameter
+							args[i]["parameter_kind"] = string(binding.ParameterKind)
+						}
+					}
+				}
 				callSite["arguments"] = args
 			}
 
@@ -1533,6 +1834,12 @@ func (s *Server) toolGetCallDetails(callerName, calleeName string) (string, bool
 				resolution["type_confidence"] = cs.TypeConfidence
 				resolution["type_source"] = cs.TypeSource
 			}
+			if cs.DispatchFunction != "" {
+				resolution["single_dispatch"] = map[string]any{
+					"function":      cs.DispatchFunction,
+					"argument_type": cs.DispatchType,
+				}
+			}
 			if cs.IsStdlib {
 				if info := s.stdlibInfoForFQN(cs.TargetFQN); info != nil {
 					resolution["stdlib_info"] = info
@@ -1626,6 +1933,9 @@ func (s *Server) toolResolveImport(importPath string) (string, bool) {
 	}
 
 	if len(partialMatches) > 0 {
+		slices.SortFunc(partialMatches, func(a, b map[string]string) int {
+			return cmp.Compare(a["fqn"], b["fqn"])
+		})
 		result := map[string]any{
 			"import":       importPath,
 			"resolved":     false,
@@ -2322,9 +2632,84 @@ func (s *Server) findMatchingFQNs(name string) []string {
 			matches = append(matches, fqn)
 		}
 	}
+	sort.Strings(matches)
 	return matches
 }
 
+// sortEntries sorts tool result entries by the given fields, so that output
+// does not depend on map iteration order. Fields hold strings or integers.
+func sortEntries(entries []map[string]any, fields ...string) {
+	slices.SortStableFunc(entries, func(a, b map[string]any) int {
+		for _, field := range fields {
+			if c := compareEntryValues(a[field], b[field]); c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+}
+
+// compareEntryValues compares two entry field values. Missing values sort
+// first, then numbers, then strings; values of any other type sort last and
+// compare equal.
+func compareEntryValues(a, b any) int {
+	kind := entryKind(a)
+	if c := cmp.Compare(kind, entryKind(b)); c != 0 {
+		return c
+	}
+	switch kind {
+	case entryKindNumber:
+		x, _ := entryNumber(a)
+		y, _ := entryNumber(b)
+		return cmp.Compare(x, y)
+	case entryKindString:
+		return cmp.Compare(a.(string), b.(string))
+	}
+	return 0
+}
+
+// Kinds of entry field values, in the order compareEntryValues sorts them.
+const (
+	entryKindMissing = iota
+	entryKindNumber
+	entryKindString
+	entryKindOther
+)
+
+// entryKind returns the kind of an entry field value.
+func entryKind(value any) int {
+	if value == nil {
+		return entryKindMissing
+	}
+	if _, ok := entryNumber(value); ok {
+		return entryKindNumber
+	}
+	if _, ok := value.(string); ok {
+		return entryKindString
+	}
+	return entryKindOther
+}
+
+// entryNumber returns a numeric entry field value as a float64, and whether
+// the value is a number.
+func entryNumber(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
 // getShortName extracts the last part of a FQN.
 func getShortName(fqn string) string {
 	parts := strings.Split(fqn, ".")
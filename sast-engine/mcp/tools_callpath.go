@@ -0,0 +1,196 @@
+package mcp
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// maxCountedCallPaths caps the number of shortest call paths counted, so
+// that densely connected graphs do not overflow the count.
+const maxCountedCallPaths = 1000
+
+// toolGetCallPath returns the shortest call chain from one function to
+// another: a breadth-first search over the call graph's edges, from every
+// function matching "from" to any function matching "to". "to" may also be
+// a callee outside the project, such as subprocess.call (see
+// findCalleeFQNs). Each hop carries the location of the call to the next
+// function. No path is a result, not an error.
+func (s *Server) toolGetCallPath(args map[string]any) (string, bool) {
+	// Check if ready.
+	if !s.statusTracker.IsReady() {
+		return s.returnIndexingStatus(), false
+	}
+
+	from, _ := args["from"].(string)
+	to, _ := args["to"].(string)
+	if from == "" || to == "" {
+		return `{"error": "from and to parameters are required"}`, true
+	}
+
+	fromFQNs := s.findMatchingFQNs(from)
+	if len(fromFQNs) == 0 {
+		return fmt.Sprintf(`{"error": "Function not found: %s"}`, from), true
+	}
+	toFQNs := s.findCalleeFQNs(to)
+	if len(toFQNs) == 0 {
+		return fmt.Sprintf(`{"error": "Function not found: %s"}`, to), true
+	}
+
+	path, count := shortestCallPath(s.callGraph, fromFQNs, toFQNs)
+
+	result := map[string]any{
+		"from_fqns": fromFQNs,
+		"to_fqns":   toFQNs,
+		"found":     path != nil,
+	}
+	if path == nil {
+		result["message"] = fmt.Sprintf("No call path from %s to %s", from, to)
+		result["path"] = []map[string]any{}
+	} else {
+		result["path"] = s.callPathHops(path)
+		result["length"] = len(path) - 1
+		result["alternate_paths"] = count - 1
+	}
+
+	var notes []string
+	if len(fromFQNs) > 1 {
+		notes = append(notes, fmt.Sprintf("Multiple matches found for %s (%d); searched from all of them: %v", from, len(fromFQNs), fromFQNs))
+	}
+	if len(toFQNs) > 1 {
+		notes = append(notes, fmt.Sprintf("Multiple matches found for %s (%d); searched to all of them: %v", to, len(toFQNs), toFQNs))
+	}
+	if len(notes) > 0 {
+		result["notes"] = notes
+	}
+
+	bytes, _ := json.MarshalIndent(result, "", "  ")
+	return string(bytes), false
+}
+
+// findCalleeFQNs returns the functions matching name, as findMatchingFQNs
+// does, and the callees outside the project matching it, e.g. library
+// functions like subprocess.call, which are only edge targets in the call
+// graph.
+func (s *Server) findCalleeFQNs(name string) []string {
+	matches := s.findMatchingFQNs(name)
+	for _, callees := range s.callGraph.Edges {
+		for _, callee := range callees {
+			if _, defined := s.callGraph.Functions[callee]; defined {
+				continue
+			}
+			if getShortName(callee) == name || strings.HasSuffix(callee, "."+name) || callee == name {
+				matches = append(matches, callee)
+			}
+		}
+	}
+	slices.Sort(matches)
+	return slices.Compact(matches)
+}
+
+// shortestCallPath returns the shortest path of FQNs from any of sources to
+// any of targets over callGraph.Edges, and the number of shortest paths
+// (capped at maxCountedCallPaths). Callees are visited in sorted order, so
+// the path returned is the same on every run. Returns nil, 0 if no target is
+// reachable.
+func shortestCallPath(callGraph *core.CallGraph, sources, targets []string) ([]string, int) {
+	parent := make(map[string]string)
+	counts := make(map[string]int)
+	distance := make(map[string]int)
+
+	level := slices.Clone(sources)
+	for _, source := range sources {
+		distance[source] = 0
+		counts[source] = 1
+	}
+
+	for depth := 0; len(level) > 0; depth++ {
+		var reached []string
+		for _, fqn := range level {
+			if slices.Contains(targets, fqn) {
+				reached = append(reached, fqn)
+			}
+		}
+		if len(reached) > 0 {
+			total := 0
+			for _, target := range reached {
+				total = min(total+counts[target], maxCountedCallPaths)
+			}
+			path := []string{reached[0]}
+			for fqn := reached[0]; distance[fqn] > 0; {
+				fqn = parent[fqn]
+				path = append(path, fqn)
+			}
+			slices.Reverse(path)
+			return path, total
+		}
+
+		var next []string
+		for _, fqn := range level {
+			for _, callee := range slices.Compact(slices.Sorted(slices.Values(callGraph.Edges[fqn]))) {
+				seen, ok := distance[callee]
+				switch {
+				case !ok:
+					distance[callee] = depth + 1
+					parent[callee] = fqn
+					counts[callee] = counts[fqn]
+					next = append(next, callee)
+				case seen == depth+1:
+					counts[callee] = min(counts[callee]+counts[fqn], maxCountedCallPaths)
+				}
+			}
+		}
+		level = next
+	}
+	return nil, 0
+}
+
+// callPathHops describes each function of a call path, with the location of
+// its call to the next function.
+func (s *Server) callPathHops(path []string) []map[string]any {
+	hops := make([]map[string]any, 0, len(path))
+	for i, fqn := range path {
+		hop := map[string]any{
+			"fqn":  fqn,
+			"name": getShortName(fqn),
+		}
+		if node := s.callGraph.Functions[fqn]; node != nil {
+			hop["file"] = node.File
+			hop["line"] = node.LineNumber
+		}
+		if i+1 < len(path) {
+			if callSite := s.firstCallSite(fqn, path[i+1]); callSite != nil {
+				hop["call_site"] = map[string]any{
+					"file":   callSite.Location.File,
+					"line":   callSite.Location.Line,
+					"column": callSite.Location.Column,
+				}
+			}
+		}
+		hops = append(hops, hop)
+	}
+	return hops
+}
+
+// firstCallSite returns the earliest call from caller to callee, matched by
+// resolved FQN or, for unresolved calls, by short name. Returns nil if none
+// is recorded.
+func (s *Server) firstCallSite(caller, callee string) *core.CallSite {
+	var first *core.CallSite
+	for i, callSite := range s.callGraph.CallSites[caller] {
+		if callSite.TargetFQN != callee && callSite.Target != getShortName(callee) {
+			continue
+		}
+		if first == nil || cmp.Or(
+			cmp.Compare(callSite.Location.Line, first.Location.Line),
+			cmp.Compare(callSite.Location.Column, first.Location.Column),
+		) < 0 {
+			first = &s.callGraph.CallSites[caller][i]
+		}
+	}
+	return first
+}
@@ -0,0 +1,158 @@
+package mcp
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createCallPathTestServer returns a server over a diamond: handle calls
+// parse and validate, both of which call save, which calls subprocess.call.
+// audit is defined in two modules and calls nothing.
+func createCallPathTestServer() *Server {
+	callGraph := core.NewCallGraph()
+	for i, fqn := range []string{
+		"app.views.handle", "app.forms.parse", "app.forms.validate",
+		"app.db.save", "app.db.audit", "app.logs.audit",
+	} {
+		callGraph.AddFunction(fqn, &graph.Node{
+			Type:       "function_definition",
+			Name:       getShortName(fqn),
+			File:       "/app/module.py",
+			LineNumber: uint32(10 * (i + 1)),
+		})
+	}
+	callGraph.AddEdge("app.views.handle", "app.forms.validate")
+	callGraph.AddEdge("app.views.handle", "app.forms.parse")
+	callGraph.AddEdge("app.forms.parse", "app.db.save")
+	callGraph.AddEdge("app.forms.validate", "app.db.save")
+	callGraph.AddEdge("app.db.save", "subprocess.call")
+	callGraph.AddCallSite("app.views.handle", core.CallSite{
+		Target: "parse", TargetFQN: "app.forms.parse", Resolved: true,
+		Location: core.Location{File: "/app/view.py", Line: 14, Column: 9},
+	})
+	callGraph.AddCallSite("app.views.handle", core.CallSite{
+		Target: "parse", TargetFQN: "app.forms.parse", Resolved: true,
+		Location: core.Location{File: "/app/view.py", Line: 12, Column: 5},
+	})
+	callGraph.AddCallSite("app.forms.parse", core.CallSite{
+		Target: "save", TargetFQN: "app.db.save", Resolved: true,
+		Location: core.Location{File: "/app/form.py", Line: 22, Column: 12},
+	})
+	return NewServer("/app", "3.11", callGraph, core.NewModuleRegistry(), nil, time.Second, false)
+}
+
+type callPathResult struct {
+	FromFQNs       []string         `json:"from_fqns"`
+	ToFQNs         []string         `json:"to_fqns"`
+	Found          bool             `json:"found"`
+	Message        string           `json:"message"`
+	Path           []map[string]any `json:"path"`
+	Length         int              `json:"length"`
+	AlternatePaths int              `json:"alternate_paths"`
+	Notes          []string         `json:"notes"`
+}
+
+func getCallPath(t *testing.T, server *Server, from, to string) callPathResult {
+	t.Helper()
+	result, isError := server.executeTool("get_call_path", map[string]any{"from": from, "to": to})
+	require.False(t, isError, result)
+	var parsed callPathResult
+	require.NoError(t, json.Unmarshal([]byte(result), &parsed))
+	return parsed
+}
+
+func TestToolGetCallPath(t *testing.T) {
+	server := createCallPathTestServer()
+
+	parsed := getCallPath(t, server, "handle", "save")
+	require.True(t, parsed.Found)
+	assert.Equal(t, 2, parsed.Length)
+	assert.Equal(t, 1, parsed.AlternatePaths, "via validate")
+	require.Len(t, parsed.Path, 3)
+	assert.Equal(t, "app.views.handle", parsed.Path[0]["fqn"])
+	assert.Equal(t, "app.forms.parse", parsed.Path[1]["fqn"], "callees are visited in sorted order")
+	assert.Equal(t, "app.db.save", parsed.Path[2]["fqn"])
+	assert.Equal(t, map[string]any{"file": "/app/view.py", "line": 12.0, "column": 5.0}, parsed.Path[0]["call_site"], "earliest call site")
+	assert.Equal(t, map[string]any{"file": "/app/form.py", "line": 22.0, "column": 12.0}, parsed.Path[1]["call_site"])
+	assert.NotContains(t, parsed.Path[2], "call_site")
+	assert.Empty(t, parsed.Notes)
+}
+
+func TestToolGetCallPath_ExternalTarget(t *testing.T) {
+	parsed := getCallPath(t, createCallPathTestServer(), "app.views.handle", "subprocess.call")
+	require.True(t, parsed.Found)
+	assert.Equal(t, []string{"subprocess.call"}, parsed.ToFQNs)
+	assert.Equal(t, 3, parsed.Length)
+	require.Len(t, parsed.Path, 4)
+	assert.Equal(t, "subprocess.call", parsed.Path[3]["fqn"])
+	assert.NotContains(t, parsed.Path[3], "file", "not defined in the project")
+
+	assert.Equal(t, []string{"subprocess.call"}, getCallPath(t, createCallPathTestServer(), "handle", "call").ToFQNs)
+}
+
+func TestToolGetCallPath_SameFunction(t *testing.T) {
+	parsed := getCallPath(t, createCallPathTestServer(), "save", "app.db.save")
+	require.True(t, parsed.Found)
+	assert.Equal(t, 0, parsed.Length)
+	require.Len(t, parsed.Path, 1)
+}
+
+func TestToolGetCallPath_NoPath(t *testing.T) {
+	parsed := getCallPath(t, createCallPathTestServer(), "save", "handle")
+	assert.False(t, parsed.Found)
+	assert.Equal(t, "No call path from save to handle", parsed.Message)
+	assert.Empty(t, parsed.Path)
+}
+
+func TestToolGetCallPath_Ambiguous(t *testing.T) {
+	parsed := getCallPath(t, createCallPathTestServer(), "handle", "audit")
+	assert.False(t, parsed.Found)
+	assert.Equal(t, []string{"app.db.audit", "app.logs.audit"}, parsed.ToFQNs)
+	require.Len(t, parsed.Notes, 1)
+	assert.Contains(t, parsed.Notes[0], "Multiple matches found for audit (2)")
+}
+
+func TestToolGetCallPath_Errors(t *testing.T) {
+	server := createCallPathTestServer()
+
+	result, isError := server.executeTool("get_call_path", map[string]any{"from": "handle"})
+	assert.True(t, isError)
+	assert.Contains(t, result, "from and to parameters are required")
+
+	result, isError = server.executeTool("get_call_path", map[string]any{"from": "handle", "to": "missing"})
+	assert.True(t, isError)
+	assert.Contains(t, result, "Function not found: missing")
+}
+
+func TestShortestCallPath_CountsPaths(t *testing.T) {
+	// Two diamonds in a row: four shortest paths from a to e
+	callGraph := core.NewCallGraph()
+	callGraph.AddEdge("a", "b1")
+	callGraph.AddEdge("a", "b2")
+	callGraph.AddEdge("b1", "c")
+	callGraph.AddEdge("b2", "c")
+	callGraph.AddEdge("c", "d1")
+	callGraph.AddEdge("c", "d2")
+	callGraph.Edges["c"] = append(callGraph.Edges["c"], "d2")
+	callGraph.AddEdge("d1", "e")
+	callGraph.AddEdge("d2", "e")
+	callGraph.AddEdge("a", "x")
+	callGraph.AddEdge("x", "y")
+	callGraph.AddEdge("y", "z")
+	callGraph.AddEdge("z", "w")
+	callGraph.AddEdge("w", "e")
+
+	path, count := shortestCallPath(callGraph, []string{"a"}, []string{"e"})
+	assert.Equal(t, []string{"a", "b1", "c", "d1", "e"}, path)
+	assert.Equal(t, 4, count, "duplicate edges and longer paths are not counted")
+
+	path, count = shortestCallPath(callGraph, []string{"e"}, []string{"a"})
+	assert.Nil(t, path)
+	assert.Zero(t, count)
+}
@@ -0,0 +1,170 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/builder"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/cfg"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/extraction"
+)
+
+// toolGetCFG returns the control flow graph of a function: its basic blocks
+// and successor edges. The CFG built during indexing is used when available;
+// otherwise it is built on demand from the function's source.
+func (s *Server) toolGetCFG(args map[string]any) (string, bool) {
+	// Check if ready.
+	if !s.statusTracker.IsReady() {
+		return s.returnIndexingStatus(), false
+	}
+
+	function, _ := args["function"].(string)
+	if function == "" {
+		return `{"error": "function parameter is required"}`, true
+	}
+
+	fqns := s.findMatchingFQNs(function)
+	if len(fqns) == 0 {
+		return fmt.Sprintf(`{"error": "Function not found: %s"}`, function), true
+	}
+	sort.Strings(fqns)
+
+	targetFQN := fqns[0]
+	targetNode := s.callGraph.Functions[targetFQN]
+
+	cfGraph, blockStmts, err := s.functionCFG(targetFQN)
+	if err != nil {
+		return fmt.Sprintf(`{"error": "Cannot build CFG for %s: %s"}`, targetFQN, err.Error()), true
+	}
+
+	order := cfgBlockOrder(cfGraph)
+	blocks := make([]map[string]any, 0, len(order))
+	edges := make([]map[string]string, 0)
+	for _, blockID := range order {
+		block := cfGraph.Blocks[blockID]
+		stmts := blockStmts[blockID]
+
+		blockInfo := map[string]any{
+			"id":              block.ID,
+			"type":            string(block.Type),
+			"statement_count": len(stmts),
+		}
+		if startLine, endLine := statementLineRange(block, stmts); startLine > 0 {
+			blockInfo["start_line"] = startLine
+			blockInfo["end_line"] = endLine
+		}
+		if block.Condition != "" {
+			blockInfo["condition"] = block.Condition
+		}
+		blocks = append(blocks, blockInfo)
+
+		for _, succID := range block.Successors {
+			edges = append(edges, map[string]string{"from": block.ID, "to": succID})
+		}
+	}
+
+	result := map[string]any{
+		"function": map[string]any{
+			"fqn":  targetFQN,
+			"name": getShortName(targetFQN),
+			"file": targetNode.File,
+			"line": targetNode.LineNumber,
+		},
+		"entry":       cfGraph.EntryBlockID,
+		"exit":        cfGraph.ExitBlockID,
+		"block_count": len(blocks),
+		"edge_count":  len(edges),
+		"complexity":  cfGraph.CyclomaticComplexity(),
+		"blocks":      blocks,
+		"edges":       edges,
+	}
+
+	if len(fqns) > 1 {
+		result["note"] = fmt.Sprintf("Multiple matches found (%d). Showing CFG for first match. Other matches: %v", len(fqns), fqns[1:])
+	}
+
+	bytes, _ := json.MarshalIndent(result, "", "  ")
+	return string(bytes), false
+}
+
+// functionCFG returns the CFG for a function, preferring the one built during
+// indexing. Python functions without a stored CFG are parsed on demand.
+func (s *Server) functionCFG(fqn string) (*cfg.ControlFlowGraph, cfg.BlockStatements, error) {
+	if cfGraph, ok := s.callGraph.CFGs[fqn].(*cfg.ControlFlowGraph); ok && cfGraph != nil {
+		blockStmts, _ := s.callGraph.CFGBlockStatements[fqn].(cfg.BlockStatements)
+		return cfGraph, blockStmts, nil
+	}
+
+	node := s.callGraph.Functions[fqn]
+	if !strings.HasSuffix(node.File, ".py") {
+		return nil, nil, fmt.Errorf("no CFG was built for this function during indexing")
+	}
+
+	sourceCode, err := os.ReadFile(node.File)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read %s: %w", node.File, err)
+	}
+
+	tree, err := extraction.ParsePythonFile(sourceCode)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tree.Close()
+
+	functionNode := builder.FindFunctionAtLine(tree.RootNode(), node.LineNumber)
+	if functionNode == nil {
+		return nil, nil, fmt.Errorf("function definition not found at %s:%d", node.File, node.LineNumber)
+	}
+
+	return cfg.BuildCFGFromAST(fqn, functionNode, sourceCode)
+}
+
+// cfgBlockOrder returns block IDs in BFS order from the entry block, followed
+// by any unreachable blocks (e.g., code after return) sorted by ID.
+func cfgBlockOrder(cfGraph *cfg.ControlFlowGraph) []string {
+	order := []string{cfGraph.EntryBlockID}
+	visited := map[string]bool{cfGraph.EntryBlockID: true}
+	for i := 0; i < len(order); i++ {
+		for _, succID := range cfGraph.Blocks[order[i]].Successors {
+			if _, ok := cfGraph.Blocks[succID]; ok && !visited[succID] {
+				visited[succID] = true
+				order = append(order, succID)
+			}
+		}
+	}
+
+	var unreachable []string
+	for blockID := range cfGraph.Blocks {
+		if !visited[blockID] {
+			unreachable = append(unreachable, blockID)
+		}
+	}
+	sort.Strings(unreachable)
+
+	return append(order, unreachable...)
+}
+
+// statementLineRange returns the block's line range, from its recorded
+// StartLine/EndLine or else from its statements. Returns (0, 0) if unknown.
+func statementLineRange(block *cfg.BasicBlock, stmts []*core.Statement) (int, int) {
+	if block.StartLine > 0 {
+		return block.StartLine, max(block.EndLine, block.StartLine)
+	}
+
+	startLine, endLine := 0, 0
+	for _, stmt := range stmts {
+		line := int(stmt.LineNumber)
+		if line == 0 {
+			continue
+		}
+		if startLine == 0 || line < startLine {
+			startLine = line
+		}
+		endLine = max(endLine, line)
+	}
+	return startLine, endLine
+}
@@ -0,0 +1,151 @@
+package mcp
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/cfg"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const branchyPython = `def handle(request, items):
+    data = request.GET.get("q")
+    if data:
+        data = data.strip()
+    else:
+        data = "default"
+    for item in items:
+        process(item)
+    return data
+`
+
+// createCFGTestServer indexes a single branchy function without stored CFGs.
+func createCFGTestServer(t *testing.T) *Server {
+	t.Helper()
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "views.py")
+	require.NoError(t, os.WriteFile(filePath, []byte(branchyPython), 0644))
+
+	callGraph := core.NewCallGraph()
+	callGraph.Functions["app.views.handle"] = &graph.Node{
+		ID:         "1",
+		Type:       "function_definition",
+		Name:       "handle",
+		File:       filePath,
+		LineNumber: 1,
+	}
+	callGraph.Functions["app.views.remote"] = &graph.Node{
+		ID:         "2",
+		Type:       "function_declaration",
+		Name:       "remote",
+		File:       filepath.Join(tmpDir, "remote.go"),
+		LineNumber: 3,
+	}
+
+	moduleRegistry := &core.ModuleRegistry{
+		Modules:      map[string]string{"app.views": filePath},
+		FileToModule: map[string]string{filePath: "app.views"},
+		ShortNames:   map[string][]string{"views": {filePath}},
+	}
+
+	return NewServer(tmpDir, "3.11", callGraph, moduleRegistry, nil, time.Second, true)
+}
+
+func TestToolGetCFG_BranchyFunction(t *testing.T) {
+	server := createCFGTestServer(t)
+
+	result, isError := server.toolGetCFG(map[string]any{"function": "handle"})
+	require.False(t, isError, result)
+
+	var parsed struct {
+		Function   map[string]any   `json:"function"`
+		Entry      string           `json:"entry"`
+		Exit       string           `json:"exit"`
+		BlockCount int              `json:"block_count"`
+		EdgeCount  int              `json:"edge_count"`
+		Blocks     []map[string]any `json:"blocks"`
+		Edges      []map[string]any `json:"edges"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result), &parsed))
+
+	assert.Equal(t, "app.views.handle", parsed.Function["fqn"])
+	assert.Equal(t, "app.views.handle:entry", parsed.Entry)
+	assert.Equal(t, "app.views.handle:exit", parsed.Exit)
+	// entry, body, if_cond, if_true, if_false, if_merge, for_header,
+	// for_body, for_after, exit, and the unreachable after_return block.
+	assert.Equal(t, 11, parsed.BlockCount)
+	assert.Equal(t, len(parsed.Blocks), parsed.BlockCount)
+	assert.Equal(t, len(parsed.Edges), parsed.EdgeCount)
+	assert.Equal(t, parsed.Entry, parsed.Blocks[0]["id"], "blocks start at entry")
+
+	blockTypes := make(map[string]int)
+	for _, block := range parsed.Blocks {
+		blockTypes[block["type"].(string)]++
+	}
+	assert.Equal(t, 1, blockTypes["conditional"])
+	assert.Equal(t, 1, blockTypes["loop"])
+
+	// The loop back edge is reported.
+	assert.Contains(t, parsed.Edges, map[string]any{
+		"from": "app.views.handle:block_for_body_7",
+		"to":   "app.views.handle:block_for_header_6",
+	})
+}
+
+func TestToolGetCFG_UsesIndexedCFG(t *testing.T) {
+	server := createCFGTestServer(t)
+
+	stored := cfg.NewControlFlowGraph("app.views.remote")
+	stored.AddBlock(&cfg.BasicBlock{ID: "body", Type: cfg.BlockTypeNormal})
+	stored.AddEdge(stored.EntryBlockID, "body")
+	stored.AddEdge("body", stored.ExitBlockID)
+	server.callGraph.CFGs["app.views.remote"] = stored
+	server.callGraph.CFGBlockStatements["app.views.remote"] = cfg.BlockStatements{
+		"body": {{LineNumber: 4}, {LineNumber: 5}},
+	}
+
+	result, isError := server.executeTool("get_cfg", map[string]any{"function": "app.views.remote"})
+	require.False(t, isError, result)
+
+	var parsed map[string]any
+	require.NoError(t, json.Unmarshal([]byte(result), &parsed))
+	assert.InDelta(t, 3, parsed["block_count"], 0)
+	blocks := parsed["blocks"].([]any)
+	body := blocks[1].(map[string]any)
+	assert.Equal(t, "body", body["id"])
+	assert.InDelta(t, 2, body["statement_count"], 0)
+	assert.InDelta(t, 4, body["start_line"], 0)
+	assert.InDelta(t, 5, body["end_line"], 0)
+}
+
+func TestToolGetCFG_Errors(t *testing.T) {
+	server := createCFGTestServer(t)
+
+	result, isError := server.toolGetCFG(map[string]any{})
+	assert.True(t, isError)
+	assert.Contains(t, result, "function parameter is required")
+
+	result, isError = server.toolGetCFG(map[string]any{"function": "missing"})
+	assert.True(t, isError)
+	assert.Contains(t, result, "Function not found: missing")
+
+	// Non-Python function without an indexed CFG.
+	result, isError = server.toolGetCFG(map[string]any{"function": "remote"})
+	assert.True(t, isError)
+	assert.Contains(t, result, "no CFG was built")
+
+	// Stale index: the function is no longer at the recorded line.
+	server.callGraph.Functions["app.views.handle"].LineNumber = 5
+	result, isError = server.toolGetCFG(map[string]any{"function": "handle"})
+	assert.True(t, isError)
+	assert.Contains(t, result, "function definition not found")
+
+	var parsed map[string]any
+	assert.NoError(t, json.Unmarshal([]byte(result), &parsed), "errors are valid JSON")
+}
@@ -0,0 +1,90 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/analysis/taint"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// toolGetDefUse returns where a variable is defined and used inside a
+// function, from the def-use chain taint analysis runs on. Statements are
+// taken from the function's CFG, so branches and loop bodies are included.
+func (s *Server) toolGetDefUse(args map[string]any) (string, bool) {
+	// Check if ready.
+	if !s.statusTracker.IsReady() {
+		return s.returnIndexingStatus(), false
+	}
+
+	function, _ := args["function"].(string)
+	if function == "" {
+		return `{"error": "function parameter is required"}`, true
+	}
+	variable, _ := args["variable"].(string)
+	if variable == "" {
+		return `{"error": "variable parameter is required"}`, true
+	}
+
+	fqns := s.findMatchingFQNs(function)
+	if len(fqns) == 0 {
+		return fmt.Sprintf(`{"error": "Function not found: %s"}`, function), true
+	}
+	sort.Strings(fqns)
+
+	targetFQN := fqns[0]
+	targetNode := s.callGraph.Functions[targetFQN]
+
+	cfGraph, blockStmts, err := s.functionCFG(targetFQN)
+	if err != nil {
+		return fmt.Sprintf(`{"error": "Cannot build def-use chain for %s: %s"}`, targetFQN, err.Error()), true
+	}
+	chain := core.BuildDefUseChains(taint.FlattenBlockStatements(cfGraph, blockStmts))
+
+	result := map[string]any{
+		"function": map[string]any{
+			"fqn":  targetFQN,
+			"name": getShortName(targetFQN),
+			"file": targetNode.File,
+			"line": targetNode.LineNumber,
+		},
+		"variable":    variable,
+		"definitions": defUseStatements(chain.GetDefs(variable)),
+		"uses":        defUseStatements(chain.GetUses(variable)),
+		"def_lines":   chain.DefLines(variable),
+		"use_lines":   chain.UseLines(variable),
+	}
+	if !chain.IsDefined(variable) && chain.IsUsed(variable) {
+		result["note"] = "Variable is used but never assigned in the function body; it may be a parameter, global, or closure variable."
+	}
+	if len(fqns) > 1 {
+		result["note"] = fmt.Sprintf("Multiple matches found (%d). Showing def-use chain for first match. Other matches: %v", len(fqns), fqns[1:])
+	}
+
+	bytes, _ := json.MarshalIndent(result, "", "  ")
+	return string(bytes), false
+}
+
+// defUseStatements describes statements by line and type, with the call
+// target of calls, ordered by line.
+func defUseStatements(statements []*core.Statement) []map[string]any {
+	sorted := make([]*core.Statement, len(statements))
+	copy(sorted, statements)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].LineNumber < sorted[j].LineNumber
+	})
+
+	result := make([]map[string]any, 0, len(sorted))
+	for _, stmt := range sorted {
+		entry := map[string]any{
+			"line": stmt.LineNumber,
+			"type": string(stmt.Type),
+		}
+		if stmt.CallTarget != "" {
+			entry["call"] = stmt.CallTarget
+		}
+		result = append(result, entry)
+	}
+	return result
+}
@@ -0,0 +1,197 @@
+package mcp
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/patterns"
+)
+
+// findings runs the enabled patterns over the call graph, deduplicated as
+// Analyze does (see callgraph.DedupeMatches). Without a registry set via
+// SetPatternRegistry, the default patterns are run for the framework the
+// project uses, as a scan does.
+func (s *Server) findings() []callgraph.SecurityMatch {
+	registry := s.patternRegistry
+	if registry == nil {
+		registry = patterns.NewPatternRegistry()
+		registry.Framework = patterns.DetectRulesetFramework(s.callGraph)
+		registry.LoadDefaultPatterns()
+	}
+	return callgraph.DedupeMatches(callgraph.AnalyzePatterns(s.callGraph, registry))
+}
+
+// toolExplainFinding walks a finding from source to sink, with the line of
+// code at each step, the rule it matched, and how to fix it. Without a
+// finding_id, it lists the findings and their IDs, only those with the
+// given status when one is set, ordered by score when sort is "score".
+func (s *Server) toolExplainFinding(args map[string]any) (string, bool) {
+	// Check if ready.
+	if !s.statusTracker.IsReady() {
+		return s.returnIndexingStatus(), false
+	}
+
+	findingID, _ := args["finding_id"].(string)
+	statusArg, _ := args["status"].(string)
+	status, err := patterns.ParseMatchStatus(statusArg)
+	if err != nil {
+		return NewToolError(err.Error(), ErrCodeInvalidParams, nil), true
+	}
+	sortBy, _ := args["sort"].(string)
+	if sortBy != "" && sortBy != "score" {
+		return NewToolError(`invalid sort "`+sortBy+`", must be "score"`, ErrCodeInvalidParams, nil), true
+	}
+
+	findings := s.findings()
+	if findingID == "" {
+		if status != "" {
+			findings = slices.DeleteFunc(findings, func(match callgraph.SecurityMatch) bool {
+				return match.Status != status
+			})
+		}
+		if sortBy == "score" {
+			callgraph.SortMatchesByScore(findings)
+		}
+		return listFindings(findings, s.moduleRegistry), false
+	}
+
+	var finding *callgraph.SecurityMatch
+	for i := range findings {
+		if findings[i].ID == findingID {
+			finding = &findings[i]
+			break
+		}
+	}
+	if finding == nil {
+		ids := make([]string, 0, len(findings))
+		for _, match := range findings {
+			ids = append(ids, match.ID)
+		}
+		return NewToolError("Finding not found: "+findingID, ErrCodeInvalidParams, map[string]any{
+			"available_ids": ids,
+		}), true
+	}
+
+	steps := s.findingSteps(finding)
+	result := map[string]any{
+		"finding_id":  finding.ID,
+		"fingerprint": finding.Fingerprint,
+		"rule": map[string]any{
+			"id":          finding.PatternID,
+			"name":        finding.PatternName,
+			"description": finding.Description,
+			"severity":    finding.Severity,
+			"cwe":         finding.CWE,
+			"owasp":       finding.OWASP,
+		},
+		"status":      finding.Status,
+		"score":       finding.Score,
+		"context":     finding.Context,
+		"remediation": finding.Remediation,
+		"steps":       steps,
+		"total_steps": len(steps),
+	}
+	if finding.Suppression != "" {
+		result["suppression"] = finding.Suppression
+	}
+
+	bytes, _ := json.MarshalIndent(result, "", "  ")
+	return string(bytes), false
+}
+
+// listFindings returns the findings with the IDs explain_finding takes, and
+// their summary (see callgraph.Summarize).
+func listFindings(findings []callgraph.SecurityMatch, moduleRegistry *core.ModuleRegistry) string {
+	list := make([]map[string]any, 0, len(findings))
+	for _, match := range findings {
+		list = append(list, map[string]any{
+			"finding_id":  match.ID,
+			"fingerprint": match.Fingerprint,
+			"rule_id":     match.PatternID,
+			"name":        match.PatternName,
+			"severity":    match.Severity,
+			"status":      match.Status,
+			"score":       match.Score,
+			"file":        match.SinkFile,
+			"line":        match.SinkLine,
+		})
+	}
+	result := map[string]any{
+		"findings": list,
+		"total":    len(findings),
+		"summary":  callgraph.Summarize(findings, moduleRegistry),
+	}
+
+	bytes, _ := json.MarshalIndent(result, "", "  ")
+	return string(bytes)
+}
+
+// findingSteps returns the steps of a finding in flow order, each with the
+// line of code it happens at: its explanation when it has a data flow,
+// otherwise its source (if any) and sink.
+func (s *Server) findingSteps(finding *callgraph.SecurityMatch) []map[string]any {
+	type step struct {
+		kind, description, function string
+		file                        string
+		line                        int
+		code                        string
+	}
+	var flow []step
+	for i, explained := range finding.Explanation {
+		kind := "call"
+		switch i {
+		case 0:
+			kind = "source"
+		case len(finding.Explanation) - 1:
+			kind = "sink"
+		}
+		flow = append(flow, step{kind, explained.Description, explained.FQN, explained.Location.File, explained.Location.Line, ""})
+	}
+	if len(flow) == 0 {
+		if finding.SourceFile != "" {
+			flow = append(flow, step{"source", "user input read at " + finding.SourceCall, finding.SourceFQN, finding.SourceFile, int(finding.SourceLine), finding.SourceCode})
+		}
+		description := finding.Context
+		if finding.SinkCall != "" {
+			description = "reaches " + finding.SinkCall
+		}
+		flow = append(flow, step{"sink", description, finding.SinkFQN, finding.SinkFile, int(finding.SinkLine), finding.SinkCode})
+	}
+
+	steps := make([]map[string]any, 0, len(flow))
+	for i, st := range flow {
+		if st.code == "" {
+			st.code = s.codeAt(core.Location{File: st.file, Line: st.line})
+		}
+		steps = append(steps, map[string]any{
+			"step":        i + 1,
+			"kind":        st.kind,
+			"description": st.description,
+			"function":    st.function,
+			"file":        st.file,
+			"line":        st.line,
+			"snippet":     st.code,
+		})
+	}
+	return steps
+}
+
+// codeAt returns the trimmed line of code at a location, or "" if the file
+// cannot be read or has no such line.
+func (s *Server) codeAt(location core.Location) string {
+	if location.File == "" || location.Line < 1 {
+		return ""
+	}
+	content, err := s.callGraph.ReadSource(location.File)
+	if err != nil {
+		return ""
+	}
+	lines := strings.Split(string(content), "\n")
+	if location.Line > len(lines) {
+		return ""
+	}
+	return strings.TrimSpace(lines[location.Line-1])
+}
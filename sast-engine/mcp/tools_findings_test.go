@@ -0,0 +1,168 @@
+package mcp
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/builder"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/registry"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newExplainTestServer returns a server for the explain fixture project.
+func newExplainTestServer(t *testing.T) (*Server, string) {
+	t.Helper()
+	projectPath, err := filepath.Abs("../test-fixtures/python/explain")
+	require.NoError(t, err)
+	codeGraph := graph.Initialize(projectPath, nil)
+	moduleRegistry, err := registry.BuildModuleRegistry(projectPath, false)
+	require.NoError(t, err)
+	callGraph, err := builder.BuildCallGraph(codeGraph, moduleRegistry, projectPath, output.NewLogger(output.VerbosityDefault))
+	require.NoError(t, err)
+	return NewServer(projectPath, "3.11", callGraph, moduleRegistry, codeGraph, time.Second, false), projectPath
+}
+
+func TestToolExplainFinding(t *testing.T) {
+	server, projectPath := newExplainTestServer(t)
+
+	result, isError := server.executeTool("explain_finding", map[string]any{})
+	require.False(t, isError, result)
+	var listed struct {
+		Findings []struct {
+			FindingID   string `json:"finding_id"`
+			Fingerprint string `json:"fingerprint"`
+			RuleID      string `json:"rule_id"`
+		} `json:"findings"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result), &listed))
+	var findingID, fingerprint string
+	for _, finding := range listed.Findings {
+		if finding.RuleID == "CODE-INJECTION-001" {
+			findingID = finding.FindingID
+			fingerprint = finding.Fingerprint
+		}
+	}
+	assert.Len(t, fingerprint, 64)
+	require.NotEmpty(t, findingID, result)
+	assert.True(t, strings.HasPrefix(findingID, "CODE-INJECTION-001:"))
+
+	result, isError = server.executeTool("explain_finding", map[string]any{"finding_id": findingID})
+	require.False(t, isError, result)
+	var explained struct {
+		FindingID   string `json:"finding_id"`
+		Fingerprint string `json:"fingerprint"`
+		Rule        struct {
+			ID  string `json:"id"`
+			CWE string `json:"cwe"`
+		} `json:"rule"`
+		Steps []struct {
+			Step     int    `json:"step"`
+			Kind     string `json:"kind"`
+			Function string `json:"function"`
+			File     string `json:"file"`
+			Line     int    `json:"line"`
+			Snippet  string `json:"snippet"`
+		} `json:"steps"`
+		TotalSteps int `json:"total_steps"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result), &explained))
+	assert.Equal(t, findingID, explained.FindingID)
+	assert.Equal(t, fingerprint, explained.Fingerprint)
+	assert.Equal(t, "CODE-INJECTION-001", explained.Rule.ID)
+	assert.NotEmpty(t, explained.Rule.CWE)
+
+	require.Len(t, explained.Steps, 4)
+	assert.Equal(t, 4, explained.TotalSteps)
+	var kinds, snippets []string
+	for i, step := range explained.Steps {
+		assert.Equal(t, i+1, step.Step)
+		assert.Equal(t, filepath.Join(projectPath, "app.py"), step.File)
+		assert.NotEmpty(t, step.Snippet)
+		kinds = append(kinds, step.Kind)
+		snippets = append(snippets, step.Snippet)
+	}
+	assert.Equal(t, []string{"source", "call", "call", "sink"}, kinds)
+	assert.Equal(t, []string{
+		`expression = input("expression: ")`,
+		"return helper(expression)",
+		"return run(expression)",
+		"return eval(code)",
+	}, snippets)
+}
+
+func TestToolExplainFinding_NotFound(t *testing.T) {
+	server := createTestServer()
+
+	result, isError := server.executeTool("explain_finding", map[string]any{"finding_id": "CODE-INJECTION-001:00000000"})
+	assert.True(t, isError)
+	assert.Contains(t, result, "Finding not found")
+}
+
+func TestToolExplainFinding_StatusFilter(t *testing.T) {
+	server, _ := newExplainTestServer(t)
+
+	type listing struct {
+		Findings []struct {
+			Status string `json:"status"`
+		} `json:"findings"`
+		Total   int `json:"total"`
+		Summary struct {
+			Total    int            `json:"total"`
+			ByModule map[string]int `json:"by_module"`
+		} `json:"summary"`
+	}
+	list := func(args map[string]any) listing {
+		result, isError := server.executeTool("explain_finding", args)
+		require.False(t, isError, result)
+		var listed listing
+		require.NoError(t, json.Unmarshal([]byte(result), &listed))
+		return listed
+	}
+
+	all := list(map[string]any{})
+	require.NotZero(t, all.Total)
+	assert.Equal(t, all.Total, all.Summary.Total)
+	assert.Equal(t, all.Total, all.Summary.ByModule["app"], "every finding is in app.py")
+	confirmed := list(map[string]any{"status": "confirmed"})
+	potential := list(map[string]any{"status": "potential"})
+	assert.Equal(t, all.Total, confirmed.Total+potential.Total)
+	assert.NotZero(t, confirmed.Total, "the fixture's flow resolves directly")
+	for _, finding := range confirmed.Findings {
+		assert.Equal(t, "confirmed", finding.Status)
+	}
+	for _, finding := range potential.Findings {
+		assert.Equal(t, "potential", finding.Status)
+	}
+
+	result, isError := server.executeTool("explain_finding", map[string]any{"status": "likely"})
+	assert.True(t, isError)
+	assert.Contains(t, result, `invalid status \"likely\"`)
+}
+
+func TestToolExplainFinding_SortByScore(t *testing.T) {
+	server, _ := newExplainTestServer(t)
+
+	result, isError := server.executeTool("explain_finding", map[string]any{"sort": "score"})
+	require.False(t, isError, result)
+	var listed struct {
+		Findings []struct {
+			Score float64 `json:"score"`
+		} `json:"findings"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result), &listed))
+	require.NotEmpty(t, listed.Findings)
+	assert.Positive(t, listed.Findings[0].Score)
+	for i := 1; i < len(listed.Findings); i++ {
+		assert.GreaterOrEqual(t, listed.Findings[i-1].Score, listed.Findings[i].Score)
+	}
+
+	result, isError = server.executeTool("explain_finding", map[string]any{"sort": "severity"})
+	assert.True(t, isError)
+	assert.Contains(t, result, `invalid sort \"severity\"`)
+}
@@ -0,0 +1,66 @@
+package mcp
+
+import (
+	"encoding/json"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// Default and max number of functions per get_hotspots list.
+const (
+	defaultHotspotLimit = 10
+	maxHotspotLimit     = 100
+)
+
+// toolGetHotspots returns the functions with the highest fan-in and fan-out.
+func (s *Server) toolGetHotspots(args map[string]any) (string, bool) {
+	// Check if ready.
+	if !s.statusTracker.IsReady() {
+		return s.returnIndexingStatus(), false
+	}
+
+	limit := defaultHotspotLimit
+	if limitVal, ok := args["limit"]; ok {
+		switch v := limitVal.(type) {
+		case float64:
+			limit = int(v)
+		case int:
+			limit = v
+		default:
+			return NewToolError("limit must be a number", ErrCodeInvalidParams, nil), true
+		}
+	}
+	if limit <= 0 {
+		limit = defaultHotspotLimit
+	}
+	limit = min(limit, maxHotspotLimit)
+
+	metrics := s.callGraph.FanMetrics()
+	result := map[string]any{
+		"total_functions": len(metrics),
+		"by_fan_in":       s.hotspotList(core.TopFanIn(metrics, limit)),
+		"by_fan_out":      s.hotspotList(core.TopFanOut(metrics, limit)),
+	}
+
+	bytes, _ := json.MarshalIndent(result, "", "  ")
+	return string(bytes), false
+}
+
+// hotspotList converts fan metrics into tool output entries.
+func (s *Server) hotspotList(infos []core.FanInfo) []map[string]any {
+	hotspots := make([]map[string]any, 0, len(infos))
+	for _, info := range infos {
+		hotspot := map[string]any{
+			"fqn":     info.FQN,
+			"name":    getShortName(info.FQN),
+			"fan_in":  info.FanIn,
+			"fan_out": info.FanOut,
+		}
+		if node := s.callGraph.Functions[info.FQN]; node != nil {
+			hotspot["file"] = node.File
+			hotspot["line"] = node.LineNumber
+		}
+		hotspots = append(hotspots, hotspot)
+	}
+	return hotspots
+}
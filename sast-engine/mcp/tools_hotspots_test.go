@@ -0,0 +1,48 @@
+package mcp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolGetHotspots(t *testing.T) {
+	server := createTestServer()
+	server.callGraph.AddEdge("myapp.views.logout", "myapp.auth.validate_user")
+
+	result, isError := server.executeTool("get_hotspots", map[string]any{"limit": float64(2)})
+	require.False(t, isError, result)
+
+	var parsed struct {
+		TotalFunctions int              `json:"total_functions"`
+		ByFanIn        []map[string]any `json:"by_fan_in"`
+		ByFanOut       []map[string]any `json:"by_fan_out"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result), &parsed))
+	assert.Equal(t, 3, parsed.TotalFunctions)
+
+	require.Len(t, parsed.ByFanIn, 2)
+	assert.Equal(t, "myapp.auth.validate_user", parsed.ByFanIn[0]["fqn"])
+	assert.Equal(t, "validate_user", parsed.ByFanIn[0]["name"])
+	assert.InDelta(t, 2, parsed.ByFanIn[0]["fan_in"], 0)
+	assert.Equal(t, "/path/to/myapp/auth.py", parsed.ByFanIn[0]["file"])
+
+	require.Len(t, parsed.ByFanOut, 2)
+	assert.Equal(t, "myapp.views.login", parsed.ByFanOut[0]["fqn"])
+	assert.InDelta(t, 1, parsed.ByFanOut[0]["fan_out"], 0)
+	assert.Equal(t, "myapp.views.logout", parsed.ByFanOut[1]["fqn"])
+}
+
+func TestToolGetHotspots_InvalidLimit(t *testing.T) {
+	server := createTestServer()
+
+	result, isError := server.executeTool("get_hotspots", map[string]any{"limit": "ten"})
+	assert.True(t, isError)
+	assert.Contains(t, result, "limit must be a number")
+
+	result, isError = server.executeTool("get_hotspots", map[string]any{})
+	require.False(t, isError, result)
+	assert.Contains(t, result, `"total_functions": 3`)
+}
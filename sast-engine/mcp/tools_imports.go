@@ -0,0 +1,35 @@
+package mcp
+
+import (
+	"encoding/json"
+)
+
+// toolGetCircularImports returns the import cycles between project modules.
+func (s *Server) toolGetCircularImports() (string, bool) {
+	// Check if ready.
+	if !s.statusTracker.IsReady() {
+		return s.returnIndexingStatus(), false
+	}
+
+	cycles := s.callGraph.CircularImports()
+	entries := make([]map[string]any, 0, len(cycles))
+	for _, modules := range cycles {
+		files := make([]string, 0, len(modules))
+		for _, module := range modules {
+			if file, ok := s.moduleRegistry.Modules[module]; ok {
+				files = append(files, file)
+			}
+		}
+		entries = append(entries, map[string]any{
+			"modules": modules,
+			"files":   files,
+		})
+	}
+
+	result := map[string]any{
+		"total":  len(entries),
+		"cycles": entries,
+	}
+	bytes, _ := json.MarshalIndent(result, "", "  ")
+	return string(bytes), false
+}
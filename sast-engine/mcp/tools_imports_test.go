@@ -0,0 +1,36 @@
+package mcp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolGetCircularImports(t *testing.T) {
+	server := createTestServer()
+
+	result, isError := server.executeTool("get_circular_imports", map[string]any{})
+	require.False(t, isError, result)
+	assert.Contains(t, result, `"total": 0`)
+
+	server.callGraph.AddModuleImport("myapp.views", "myapp.auth")
+	server.callGraph.AddModuleImport("myapp.auth", "myapp.views")
+
+	result, isError = server.executeTool("get_circular_imports", map[string]any{})
+	require.False(t, isError, result)
+
+	var parsed struct {
+		Total  int `json:"total"`
+		Cycles []struct {
+			Modules []string `json:"modules"`
+			Files   []string `json:"files"`
+		} `json:"cycles"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result), &parsed))
+	assert.Equal(t, 1, parsed.Total)
+	require.Len(t, parsed.Cycles, 1)
+	assert.Equal(t, []string{"myapp.auth", "myapp.views"}, parsed.Cycles[0].Modules)
+	assert.Equal(t, []string{"/path/to/myapp/auth.py", "/path/to/myapp/views.py"}, parsed.Cycles[0].Files)
+}
@@ -0,0 +1,72 @@
+package mcp
+
+import (
+	"encoding/json"
+)
+
+// Default and max number of components and orphans listed by
+// get_graph_metrics.
+const (
+	defaultGraphMetricsLimit = 20
+	maxGraphMetricsLimit     = 500
+)
+
+// toolGetGraphMetrics returns the weakly connected components and orphan
+// functions of the call graph.
+func (s *Server) toolGetGraphMetrics(args map[string]any) (string, bool) {
+	// Check if ready.
+	if !s.statusTracker.IsReady() {
+		return s.returnIndexingStatus(), false
+	}
+
+	limit := defaultGraphMetricsLimit
+	if limitVal, ok := args["limit"]; ok {
+		switch v := limitVal.(type) {
+		case float64:
+			limit = int(v)
+		case int:
+			limit = v
+		default:
+			return NewToolError("limit must be a number", ErrCodeInvalidParams, nil), true
+		}
+	}
+	if limit <= 0 {
+		limit = defaultGraphMetricsLimit
+	}
+	limit = min(limit, maxGraphMetricsLimit)
+
+	components := s.callGraph.WeaklyConnectedComponents()
+	componentList := make([]map[string]any, 0, min(len(components), limit))
+	for _, component := range components[:min(len(components), limit)] {
+		componentList = append(componentList, map[string]any{
+			"size":      len(component),
+			"functions": component,
+		})
+	}
+
+	orphans := s.callGraph.OrphanFunctions()
+	orphanList := make([]map[string]any, 0, min(len(orphans), limit))
+	for _, fqn := range orphans[:min(len(orphans), limit)] {
+		orphan := map[string]any{
+			"fqn":  fqn,
+			"name": getShortName(fqn),
+		}
+		if node := s.callGraph.Functions[fqn]; node != nil {
+			orphan["file"] = node.File
+			orphan["line"] = node.LineNumber
+		}
+		orphanList = append(orphanList, orphan)
+	}
+
+	result := map[string]any{
+		"total_functions":  len(s.callGraph.Functions),
+		"call_edges":       len(s.callGraph.Edges),
+		"total_components": len(components),
+		"components":       componentList,
+		"total_orphans":    len(orphans),
+		"orphans":          orphanList,
+	}
+
+	bytes, _ := json.MarshalIndent(result, "", "  ")
+	return string(bytes), false
+}
@@ -0,0 +1,60 @@
+package mcp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolGetGraphMetrics(t *testing.T) {
+	server := createTestServer()
+	server.callGraph.Functions["myapp.jobs.run"] = &graph.Node{Name: "run", File: "/path/to/myapp/jobs.py", LineNumber: 3}
+	server.callGraph.Functions["myapp.jobs.retry"] = &graph.Node{Name: "retry", File: "/path/to/myapp/jobs.py", LineNumber: 9}
+	server.callGraph.AddEdge("myapp.jobs.run", "myapp.jobs.retry")
+
+	result, isError := server.executeTool("get_graph_metrics", map[string]any{})
+	require.False(t, isError, result)
+
+	var parsed struct {
+		TotalFunctions  int `json:"total_functions"`
+		TotalComponents int `json:"total_components"`
+		Components      []struct {
+			Size      int      `json:"size"`
+			Functions []string `json:"functions"`
+		} `json:"components"`
+		TotalOrphans int              `json:"total_orphans"`
+		Orphans      []map[string]any `json:"orphans"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result), &parsed))
+	assert.Equal(t, 5, parsed.TotalFunctions)
+
+	assert.Equal(t, 3, parsed.TotalComponents)
+	require.Len(t, parsed.Components, 3)
+	assert.Equal(t, []string{"myapp.auth.validate_user", "myapp.views.login"}, parsed.Components[0].Functions)
+	assert.Equal(t, []string{"myapp.jobs.retry", "myapp.jobs.run"}, parsed.Components[1].Functions)
+	assert.Equal(t, []string{"myapp.views.logout"}, parsed.Components[2].Functions)
+	assert.Equal(t, 1, parsed.Components[2].Size)
+
+	assert.Equal(t, 1, parsed.TotalOrphans)
+	require.Len(t, parsed.Orphans, 1)
+	assert.Equal(t, "myapp.views.logout", parsed.Orphans[0]["fqn"])
+	assert.Equal(t, "logout", parsed.Orphans[0]["name"])
+	assert.Equal(t, "/path/to/myapp/views.py", parsed.Orphans[0]["file"])
+}
+
+func TestToolGetGraphMetrics_Limit(t *testing.T) {
+	server := createTestServer()
+
+	result, isError := server.executeTool("get_graph_metrics", map[string]any{"limit": float64(1)})
+	require.False(t, isError, result)
+	assert.Contains(t, result, `"total_components": 2`)
+	assert.Contains(t, result, `"size": 2`)
+	assert.NotContains(t, result, `"size": 1`)
+
+	result, isError = server.executeTool("get_graph_metrics", map[string]any{"limit": "ten"})
+	assert.True(t, isError)
+	assert.Contains(t, result, "limit must be a number")
+}
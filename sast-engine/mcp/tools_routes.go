@@ -0,0 +1,87 @@
+package mcp
+
+import (
+	"cmp"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/patterns"
+)
+
+// toolListRoutes returns the HTTP routes registered for handler functions,
+// and the entry points of the project's framework, if it uses one.
+func (s *Server) toolListRoutes(args map[string]any) (string, bool) {
+	// Check if ready.
+	if !s.statusTracker.IsReady() {
+		return s.returnIndexingStatus(), false
+	}
+
+	method, _ := args["method"].(string)
+	method = strings.ToUpper(method)
+
+	type routeEntry struct {
+		route graph.Route
+		fqn   string
+		node  *graph.Node
+	}
+	var entries []routeEntry
+	for fqn, node := range s.callGraph.Functions {
+		for _, route := range node.Routes {
+			if method != "" && route.Method != method && route.Method != "ANY" {
+				continue
+			}
+			entries = append(entries, routeEntry{route, fqn, node})
+		}
+	}
+	slices.SortFunc(entries, func(a, b routeEntry) int {
+		return cmp.Or(
+			cmp.Compare(a.route.Path, b.route.Path),
+			cmp.Compare(a.route.Method, b.route.Method),
+			cmp.Compare(a.fqn, b.fqn),
+		)
+	})
+
+	routes := make([]map[string]any, 0, len(entries))
+	for _, entry := range entries {
+		routes = append(routes, map[string]any{
+			"method":  entry.route.Method,
+			"path":    entry.route.Path,
+			"handler": entry.fqn,
+			"file":    entry.node.File,
+			"line":    entry.node.LineNumber,
+		})
+	}
+
+	result := map[string]any{
+		"total":  len(routes),
+		"routes": routes,
+	}
+	if framework := patterns.DetectProjectFramework(s.callGraph); framework != nil {
+		entryPoints := make([]map[string]any, 0, len(framework.EntryPoints))
+		for _, entryPoint := range framework.EntryPoints {
+			entryPoints = append(entryPoints, map[string]any{
+				"handler":   entryPoint.FQN,
+				"registrar": entryPoint.Registrar,
+				"decorator": entryPoint.Decorator,
+				"file":      entryPoint.File,
+				"line":      entryPoint.Line,
+				"routes":    routeList(entryPoint.Routes),
+			})
+		}
+		result["framework"] = framework.Name
+		result["entry_points"] = entryPoints
+	}
+	bytes, _ := json.MarshalIndent(result, "", "  ")
+	return string(bytes), false
+}
+
+// routeList converts a handler's routes into tool output entries.
+func routeList(routes []graph.Route) []map[string]string {
+	list := make([]map[string]string, 0, len(routes))
+	for _, route := range routes {
+		list = append(list, map[string]string{"method": route.Method, "path": route.Path})
+	}
+	return list
+}
@@ -0,0 +1,82 @@
+package mcp
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/builder"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/registry"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolListRoutes(t *testing.T) {
+	server := createTestServer()
+	server.callGraph.Functions["myapp.views.login"].Routes = []graph.Route{{Method: "POST", Path: "/login"}}
+	server.callGraph.Functions["myapp.views.logout"].Routes = []graph.Route{
+		{Method: "GET", Path: "/logout"},
+		{Method: "ANY", Path: "/bye"},
+	}
+
+	result, isError := server.executeTool("list_routes", map[string]any{})
+	require.False(t, isError, result)
+
+	var parsed struct {
+		Total  int              `json:"total"`
+		Routes []map[string]any `json:"routes"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result), &parsed))
+	assert.Equal(t, 3, parsed.Total)
+	require.Len(t, parsed.Routes, 3)
+	assert.Equal(t, "/bye", parsed.Routes[0]["path"])
+	assert.Equal(t, "ANY", parsed.Routes[0]["method"])
+	assert.Equal(t, "/login", parsed.Routes[1]["path"])
+	assert.Equal(t, "myapp.views.login", parsed.Routes[1]["handler"])
+	assert.Equal(t, "/path/to/myapp/views.py", parsed.Routes[1]["file"])
+	assert.InDelta(t, 10, parsed.Routes[1]["line"], 0)
+
+	result, isError = server.executeTool("list_routes", map[string]any{"method": "post"})
+	require.False(t, isError, result)
+	require.NoError(t, json.Unmarshal([]byte(result), &parsed))
+	assert.Equal(t, 2, parsed.Total, "POST and ANY routes")
+}
+
+func TestToolListRoutes_EntryPoints(t *testing.T) {
+	projectPath, err := filepath.Abs("../test-fixtures/python/blueprints")
+	require.NoError(t, err)
+	codeGraph := graph.Initialize(projectPath, nil)
+	moduleRegistry, err := registry.BuildModuleRegistry(projectPath, false)
+	require.NoError(t, err)
+	callGraph, err := builder.BuildCallGraph(codeGraph, moduleRegistry, projectPath, output.NewLogger(output.VerbosityDefault))
+	require.NoError(t, err)
+	server := NewServer(projectPath, "3.11", callGraph, moduleRegistry, codeGraph, time.Second, false)
+
+	result, isError := server.executeTool("list_routes", map[string]any{})
+	require.False(t, isError, result)
+
+	var parsed struct {
+		Framework   string           `json:"framework"`
+		EntryPoints []map[string]any `json:"entry_points"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result), &parsed))
+	assert.Equal(t, "Flask", parsed.Framework)
+	require.Len(t, parsed.EntryPoints, 2)
+	assert.Equal(t, "portal.views.delete_user", parsed.EntryPoints[0]["handler"])
+	assert.Equal(t, "portal.admin.admin_bp", parsed.EntryPoints[0]["registrar"])
+	assert.Equal(t, "admin_bp.route", parsed.EntryPoints[0]["decorator"])
+	assert.Equal(t, "portal.views.health", parsed.EntryPoints[1]["handler"])
+}
+
+func TestToolFindSymbol_Routes(t *testing.T) {
+	server := createTestServer()
+	server.callGraph.Functions["myapp.views.login"].Routes = []graph.Route{{Method: "POST", Path: "/login"}}
+
+	result, isError := server.executeTool("find_symbol", map[string]any{"name": "login"})
+	require.False(t, isError, result)
+	assert.Contains(t, result, `"routes": [`)
+	assert.Contains(t, result, `"path": "/login"`)
+}
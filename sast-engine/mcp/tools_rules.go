@@ -0,0 +1,39 @@
+package mcp
+
+import (
+	"encoding/json"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/patterns"
+)
+
+// SetPatternRegistry sets the patterns list_rules reports, with their
+// enabled state. When unset, the default patterns are reported.
+func (s *Server) SetPatternRegistry(registry *patterns.PatternRegistry) {
+	s.patternRegistry = registry
+}
+
+// toolListRules returns the rule catalog. It needs no index, so it answers
+// while indexing is still in progress.
+func (s *Server) toolListRules() (string, bool) {
+	registry := s.patternRegistry
+	if registry == nil {
+		registry = patterns.NewPatternRegistry()
+		registry.LoadDefaultPatterns()
+	}
+
+	rules := registry.Catalog()
+	enabled := 0
+	for _, rule := range rules {
+		if rule.Enabled {
+			enabled++
+		}
+	}
+	result := map[string]any{
+		"rules":   rules,
+		"total":   len(rules),
+		"enabled": enabled,
+	}
+
+	bytes, _ := json.MarshalIndent(result, "", "  ")
+	return string(bytes), false
+}
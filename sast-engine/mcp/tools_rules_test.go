@@ -0,0 +1,45 @@
+package mcp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/patterns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolListRules(t *testing.T) {
+	server := createTestServer()
+	registry := patterns.NewPatternRegistry()
+	registry.LoadDefaultPatterns()
+	registry.DisabledPatterns = []string{"WEAK-CRYPTO-001"}
+	server.SetPatternRegistry(registry)
+
+	result, isError := server.executeTool("list_rules", map[string]any{})
+	require.False(t, isError)
+
+	var parsed struct {
+		Rules   []patterns.RuleMetadata `json:"rules"`
+		Total   int                     `json:"total"`
+		Enabled int                     `json:"enabled"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result), &parsed))
+	assert.Len(t, parsed.Rules, len(registry.Patterns))
+	assert.Equal(t, len(registry.Patterns), parsed.Total)
+	assert.Equal(t, len(registry.Patterns)-1, parsed.Enabled)
+
+	for _, rule := range parsed.Rules {
+		assert.Equal(t, rule.ID != "WEAK-CRYPTO-001", rule.Enabled, rule.ID)
+	}
+}
+
+func TestToolListRules_DefaultPatterns(t *testing.T) {
+	// No index and no registry set: the default rules are still listed
+	server := NewServerWithBackgroundIndexing("/tmp", "3.11", true)
+
+	result, isError := server.toolListRules()
+	require.False(t, isError)
+	assert.Contains(t, result, `"id": "SQL-INJECTION-001"`)
+	assert.Contains(t, result, `"enabled": true`)
+}
@@ -0,0 +1,65 @@
+package mcp
+
+import (
+	"encoding/json"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+)
+
+// toolFindBySignature lists the functions whose parameter and return types
+// match the given patterns, with pagination support.
+func (s *Server) toolFindBySignature(args map[string]any) (string, bool) {
+	// Check if ready.
+	if !s.statusTracker.IsReady() {
+		return s.returnIndexingStatus(), false
+	}
+
+	paramType, _ := args["param_type"].(string)
+	returnType, _ := args["return_type"].(string)
+	if paramType == "" && returnType == "" {
+		return `{"error": "param_type or return_type is required"}`, true
+	}
+
+	pageParams, err := ExtractPaginationParams(args)
+	if err != nil {
+		return NewToolError(err.Message, err.Code, err.Data), true
+	}
+
+	nodes := s.callGraph.FindBySignature(paramType, returnType)
+	fqns := make(map[*graph.Node]string, len(nodes))
+	for fqn, node := range s.callGraph.Functions {
+		fqns[node] = fqn
+	}
+
+	allFunctions := make([]map[string]any, 0, len(nodes))
+	for _, node := range nodes {
+		function := map[string]any{
+			"fqn":        fqns[node],
+			"name":       node.Name,
+			"file":       node.File,
+			"line":       node.LineNumber,
+			"parameters": nonNilStrings(node.MethodArgumentsType),
+		}
+		if node.ReturnType != "" {
+			function["return_type"] = node.ReturnType
+		}
+		allFunctions = append(allFunctions, function)
+	}
+
+	functions, pageInfo := PaginateSlice(allFunctions, pageParams)
+
+	result := map[string]any{
+		"total":      len(allFunctions),
+		"functions":  functions,
+		"pagination": pageInfo,
+	}
+	if paramType != "" {
+		result["param_type"] = paramType
+	}
+	if returnType != "" {
+		result["return_type"] = returnType
+	}
+
+	bytes, _ := json.MarshalIndent(result, "", "  ")
+	return string(bytes), false
+}
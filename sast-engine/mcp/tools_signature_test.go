@@ -0,0 +1,48 @@
+package mcp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolFindBySignature(t *testing.T) {
+	server := createTestServer()
+	server.callGraph.Functions["myapp.views.login"].MethodArgumentsType = []string{"request: HttpRequest"}
+	server.callGraph.Functions["myapp.views.login"].ReturnType = "HttpResponse"
+	server.callGraph.Functions["myapp.views.logout"].MethodArgumentsType = []string{"request: django.http.HttpRequest"}
+
+	var parsed struct {
+		Total     int              `json:"total"`
+		Functions []map[string]any `json:"functions"`
+	}
+
+	result, isError := server.executeTool("find_by_signature", map[string]any{"param_type": "HttpRequest"})
+	require.False(t, isError, result)
+	require.NoError(t, json.Unmarshal([]byte(result), &parsed))
+	assert.Equal(t, 2, parsed.Total)
+	require.Len(t, parsed.Functions, 2)
+	assert.Equal(t, "myapp.views.login", parsed.Functions[0]["fqn"])
+	assert.Equal(t, "HttpResponse", parsed.Functions[0]["return_type"])
+	assert.Equal(t, []any{"request: HttpRequest"}, parsed.Functions[0]["parameters"])
+	assert.Equal(t, "myapp.views.logout", parsed.Functions[1]["fqn"])
+	assert.NotContains(t, parsed.Functions[1], "return_type")
+
+	result, isError = server.executeTool("find_by_signature", map[string]any{"return_type": "*Response"})
+	require.False(t, isError, result)
+	require.NoError(t, json.Unmarshal([]byte(result), &parsed))
+	require.Equal(t, 1, parsed.Total)
+	assert.Equal(t, "myapp.views.login", parsed.Functions[0]["fqn"])
+
+	result, isError = server.executeTool("find_by_signature", map[string]any{"param_type": "HttpRequest", "limit": float64(1)})
+	require.False(t, isError, result)
+	require.NoError(t, json.Unmarshal([]byte(result), &parsed))
+	assert.Equal(t, 2, parsed.Total)
+	assert.Len(t, parsed.Functions, 1)
+
+	result, isError = server.executeTool("find_by_signature", map[string]any{})
+	assert.True(t, isError)
+	assert.Contains(t, result, "param_type or return_type is required")
+}
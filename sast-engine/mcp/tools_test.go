@@ -3,12 +3,19 @@ package mcp
 import (
 	"encoding/json"
 	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/builder"
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/registry"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestToolGetIndexInfo(t *testing.T) {
@@ -67,6 +74,51 @@ func TestToolFindSymbol_Found(t *testing.T) {
 	assert.Contains(t, result, "myapp.auth.validate_user")
 }
 
+func TestToolFindSymbol_Purity(t *testing.T) {
+	server := createTestServer()
+	server.callGraph.Functions["myapp.models.User"] = &graph.Node{
+		ID:   "4",
+		Type: "class_definition",
+		Name: "User",
+		File: "/path/to/myapp/models.py",
+	}
+	server.callGraph.CallSites["myapp.auth.validate_user"] = []core.CallSite{
+		{Target: "open", TargetFQN: "builtins.open", Resolved: true},
+	}
+	server.callGraph.Functions["myapp.views.render"] = &graph.Node{
+		ID:   "5",
+		Type: "function_definition",
+		Name: "render",
+		File: "/path/to/myapp/views.py",
+	}
+	server.callGraph.CallSites["myapp.views.render"] = []core.CallSite{
+		{Target: "template.render", Resolved: false},
+	}
+
+	tests := []struct {
+		name string
+		want any
+	}{
+		{"validate_user", "impure"},
+		{"login", "impure"}, // calls validate_user
+		{"logout", "pure"},
+		{"render", "unknown"}, // unresolved call
+		{"User", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, isError := server.toolFindSymbol(map[string]any{"name": tt.name})
+			require.False(t, isError)
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(result), &response))
+			matches := response["matches"].([]any)
+			require.Len(t, matches, 1)
+			assert.Equal(t, tt.want, matches[0].(map[string]any)["purity"])
+		})
+	}
+}
+
 func TestToolFindSymbol_PartialMatch(t *testing.T) {
 	server := createTestServer()
 
@@ -509,7 +561,7 @@ func TestGetToolDefinitions(t *testing.T) {
 
 	tools := server.getToolDefinitions()
 
-	assert.Len(t, tools, 13) // Updated for PR-03: added status tool
+	assert.Len(t, tools, 25)
 
 	// Verify each tool has required fields.
 	for _, tool := range tools {
@@ -530,10 +582,21 @@ func TestGetToolDefinitions(t *testing.T) {
 	assert.True(t, toolNames["get_callers"])
 	assert.True(t, toolNames["get_callees"])
 	assert.True(t, toolNames["get_call_details"])
+	assert.True(t, toolNames["get_call_path"])
+	assert.True(t, toolNames["get_cfg"])
+	assert.True(t, toolNames["get_def_use"])
+	assert.True(t, toolNames["get_hotspots"])
+	assert.True(t, toolNames["get_graph_metrics"])
+	assert.True(t, toolNames["get_circular_imports"])
+	assert.True(t, toolNames["list_routes"])
+	assert.True(t, toolNames["find_by_signature"])
+	assert.True(t, toolNames["find_variables_of_type"])
 	assert.True(t, toolNames["resolve_import"])
 	assert.True(t, toolNames["find_dockerfile_instructions"])
 	assert.True(t, toolNames["find_compose_services"])
 	assert.True(t, toolNames["get_dockerfile_details"])
+	assert.True(t, toolNames["list_rules"])
+	assert.True(t, toolNames["explain_finding"])
 	assert.True(t, toolNames["status"])
 }
 
@@ -601,6 +664,34 @@ func TestToolGetCallDetails_TypeInference(t *testing.T) {
 	assert.Contains(t, result, "assignment")
 }
 
+func TestToolGetCallDetails_SingleDispatch(t *testing.T) {
+	server := createExtendedTestServer()
+	server.callGraph.CallSites["myapp.views.login"] = append(server.callGraph.CallSites["myapp.views.login"], core.CallSite{
+		Target:           "render",
+		TargetFQN:        "myapp.utils._render_int",
+		Location:         core.Location{File: "/path/to/myapp/views.py", Line: 30, Column: 4},
+		Arguments:        []core.Argument{{Value: "total", IsVariable: true, Position: 0}},
+		Resolved:         true,
+		DispatchFunction: "myapp.utils.render",
+		DispatchType:     "builtins.int",
+	})
+
+	result, isError := server.toolGetCallDetails("login", "render")
+
+	assert.False(t, isError)
+	var parsed struct {
+		CallSite struct {
+			TargetFQN  string `json:"target_fqn"`
+			Resolution struct {
+				SingleDispatch map[string]string `json:"single_dispatch"`
+			} `json:"resolution"`
+		} `json:"call_site"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result), &parsed))
+	assert.Equal(t, "myapp.utils._render_int", parsed.CallSite.TargetFQN)
+	assert.Equal(t, map[string]string{"function": "myapp.utils.render", "argument_type": "builtins.int"}, parsed.CallSite.Resolution.SingleDispatch)
+}
+
 func TestToolGetCallees_WithUnresolvedCalls(t *testing.T) {
 	server := createExtendedTestServer()
 
@@ -2103,6 +2194,70 @@ func TestToolFindSymbol_CodeGraphNodesWithOptionalFields(t *testing.T) {
 	assert.Contains(t, result, "public")
 }
 
+// TestToolFindSymbol_DeclarationFieldsAlwaysPresent tests that functions,
+// methods, and classes report modifier and decorators, and classes their
+// bases, even when the node leaves them unset.
+func TestToolFindSymbol_DeclarationFieldsAlwaysPresent(t *testing.T) {
+	callGraph := core.NewCallGraph()
+	callGraph.Functions["myapp.jobs.run"] = &graph.Node{
+		ID: "f", Type: "function_definition", Name: "run", File: "/test/jobs.py", LineNumber: 1,
+	}
+	callGraph.Functions["myapp.jobs.Worker._poll"] = &graph.Node{
+		ID: "m", Type: "method", Name: "_poll", File: "/test/jobs.py", LineNumber: 5, Modifier: "protected",
+	}
+	callGraph.Functions["myapp.jobs.Worker.config"] = &graph.Node{
+		ID: "p", Type: "property", Name: "config", File: "/test/jobs.py", LineNumber: 9, Modifier: "public",
+		Annotation: []string{"cached_property"}, Metadata: map[string]any{graph.MetadataMemoization: "cached_property"},
+	}
+	codeGraph := graph.NewCodeGraph()
+	codeGraph.AddNode(&graph.Node{
+		ID: "c", Type: "class_definition", Name: "Worker", File: "/test/jobs.py", LineNumber: 4,
+	})
+	codeGraph.AddNode(&graph.Node{
+		ID: "v", Type: "module_variable", Name: "QUEUE_SIZE", File: "/test/jobs.py", LineNumber: 2,
+	})
+
+	moduleRegistry := core.NewModuleRegistry()
+	moduleRegistry.Modules["myapp.jobs"] = "/test/jobs.py"
+	moduleRegistry.FileToModule["/test/jobs.py"] = "myapp.jobs"
+
+	server := NewServer("/test/project", "3.11", callGraph, moduleRegistry, codeGraph, time.Second, false)
+
+	findOne := func(name string) map[string]any {
+		result, isError := server.toolFindSymbol(map[string]any{"name": name})
+		require.False(t, isError, result)
+		var parsed map[string]any
+		require.NoError(t, json.Unmarshal([]byte(result), &parsed))
+		for _, match := range parsed["matches"].([]any) {
+			if fqn := match.(map[string]any)["fqn"].(string); strings.HasSuffix(fqn, "."+name) {
+				return match.(map[string]any)
+			}
+		}
+		require.FailNow(t, "symbol not found", name)
+		return nil
+	}
+
+	for _, name := range []string{"run", "_poll"} {
+		match := findOne(name)
+		assert.Contains(t, match, "modifier", name)
+		assert.Equal(t, []any{}, match["decorators"], name)
+		assert.NotContains(t, match, "superclass", name)
+		assert.NotContains(t, match, "memoization", name)
+	}
+	assert.Equal(t, "protected", findOne("_poll")["modifier"])
+	assert.Equal(t, "cached_property", findOne("config")["memoization"])
+
+	class := findOne("Worker")
+	assert.Equal(t, "", class["modifier"])
+	assert.Equal(t, []any{}, class["decorators"])
+	assert.Equal(t, "", class["superclass"])
+	assert.Equal(t, []any{}, class["interfaces"])
+
+	variable := findOne("QUEUE_SIZE")
+	assert.NotContains(t, variable, "modifier")
+	assert.NotContains(t, variable, "decorators")
+}
+
 // TestToolFindSymbol_CombineCallGraphAndCodeGraph tests that search includes both sources.
 func TestToolFindSymbol_CombineCallGraphAndCodeGraph(t *testing.T) {
 	callGraph := core.NewCallGraph()
@@ -3539,3 +3694,183 @@ func TestToolGetIndexInfo_GoSymbols(t *testing.T) {
 	symbolsByLSPKind := parsed["symbols_by_lsp_kind"].(map[string]any)
 	assert.Contains(t, symbolsByLSPKind, "Function")
 }
+
+func TestToolGetCallDetails_ParameterKinds(t *testing.T) {
+	projectPath, err := filepath.Abs("../test-fixtures/python/parameter_kinds")
+	require.NoError(t, err)
+	codeGraph := graph.Initialize(projectPath, nil)
+	moduleRegistry, err := registry.BuildModuleRegistry(projectPath, false)
+	require.NoError(t, err)
+	callGraph, err := builder.BuildCallGraph(codeGraph, moduleRegistry, projectPath, output.NewLogger(output.VerbosityDefault))
+	require.NoError(t, err)
+	server := NewServer(projectPath, "3.11", callGraph, moduleRegistry, codeGraph, time.Second, false)
+
+	boundArguments := func(caller string) []map[string]any {
+		result, isError := server.toolGetCallDetails(caller, "send")
+		require.False(t, isError, result)
+		var parsed struct {
+			CallSite struct {
+				Arguments []map[string]any `json:"arguments"`
+			} `json:"call_site"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(result), &parsed))
+		return parsed.CallSite.Arguments
+	}
+
+	// send(user, "Welcome", urgent=True)
+	args := boundArguments("notify_all")
+	require.Len(t, args, 3)
+	assert.Equal(t, "recipient", args[0]["parameter"])
+	assert.Equal(t, "positional_only", args[0]["parameter_kind"])
+	assert.Equal(t, "subject", args[1]["parameter"])
+	assert.Equal(t, "normal", args[1]["parameter_kind"])
+	assert.Equal(t, "urgent", args[2]["parameter"])
+	assert.Equal(t, "keyword_only", args[2]["parameter_kind"])
+
+	// send(admin, subject="Alert", urgent=True)
+	args = boundArguments("notify_admin")
+	require.Len(t, args, 3)
+	assert.Equal(t, "recipient", args[0]["parameter"])
+	assert.Equal(t, "subject", args[1]["parameter"])
+	assert.Equal(t, "normal", args[1]["parameter_kind"])
+	assert.Equal(t, "urgent", args[2]["parameter"])
+}
+
+// createManyModulesTestServer creates a Server with enough modules,
+// functions, and callers that map iteration order varies between calls.
+func createManyModulesTestServer() *Server {
+	callGraph := core.NewCallGraph()
+	moduleRegistry := core.NewModuleRegistry()
+
+	for m := range 12 {
+		module := fmt.Sprintf("app.mod%02d", m)
+		file := fmt.Sprintf("/project/app/mod%02d.py", m)
+		moduleRegistry.AddModule(module, file)
+		for f := range m%3 + 1 {
+			fqn := fmt.Sprintf("%s.handler%d", module, f)
+			callGraph.Functions[fqn] = &graph.Node{
+				ID:         fqn,
+				Type:       "function_definition",
+				Name:       fmt.Sprintf("handler%d", f),
+				File:       file,
+				LineNumber: uint32(10 * (f + 1)),
+			}
+			callGraph.AddCallSite(fqn, core.CallSite{
+				Target:    "target",
+				TargetFQN: "app.mod00.target",
+				Location:  core.Location{File: file, Line: 10*(f+1) + 1, Column: 4},
+				Resolved:  true,
+			})
+			callGraph.AddEdge(fqn, "app.mod00.target")
+		}
+	}
+	callGraph.Functions["app.mod00.target"] = &graph.Node{
+		ID: "target", Type: "function_definition", Name: "target", File: "/project/app/mod00.py", LineNumber: 1,
+	}
+	// Append callers out of order, as parallel call graph workers do
+	slices.Reverse(callGraph.ReverseEdges["app.mod00.target"])
+
+	return NewServer("/project", "3.11", callGraph, moduleRegistry, nil, time.Second, false)
+}
+
+func TestTools_DeterministicOutput(t *testing.T) {
+	server := createManyModulesTestServer()
+
+	tools := []struct {
+		name string
+		call func() (string, bool)
+	}{
+		{"get_index_info", server.toolGetIndexInfo},
+		{"list_modules", server.toolListModules},
+		{"find_module", func() (string, bool) { return server.toolFindModule("mod") }},
+		{"find_symbol", func() (string, bool) { return server.toolFindSymbol(map[string]any{"name": "handler"}) }},
+		{"get_callers", func() (string, bool) { return server.toolGetCallers(map[string]any{"function": "target"}) }},
+		{"get_callees", func() (string, bool) { return server.toolGetCallees(map[string]any{"function": "handler0"}) }},
+	}
+	for _, tool := range tools {
+		t.Run(tool.name, func(t *testing.T) {
+			first, isError := tool.call()
+			require.False(t, isError, first)
+			for range 10 {
+				again, _ := tool.call()
+				require.Equal(t, first, again)
+			}
+		})
+	}
+}
+
+func TestTools_SortedOutput(t *testing.T) {
+	server := createManyModulesTestServer()
+
+	fqnsOf := func(entries []any, field string) []string {
+		fqns := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			fqns = append(fqns, entry.(map[string]any)[field].(string))
+		}
+		return fqns
+	}
+
+	var modules map[string]any
+	result, _ := server.toolListModules()
+	require.NoError(t, json.Unmarshal([]byte(result), &modules))
+	moduleFQNs := fqnsOf(modules["modules"].([]any), "module_fqn")
+	assert.Len(t, moduleFQNs, 12)
+	assert.True(t, slices.IsSorted(moduleFQNs))
+
+	var callers map[string]any
+	result, _ = server.toolGetCallers(map[string]any{"function": "target", "limit": float64(100)})
+	require.NoError(t, json.Unmarshal([]byte(result), &callers))
+	callerFQNs := fqnsOf(callers["callers"].([]any), "fqn")
+	assert.Len(t, callerFQNs, 24)
+	assert.True(t, slices.IsSorted(callerFQNs))
+
+	var symbols map[string]any
+	result, _ = server.toolFindSymbol(map[string]any{"name": "handler", "limit": float64(100)})
+	require.NoError(t, json.Unmarshal([]byte(result), &symbols))
+	assert.True(t, slices.IsSorted(fqnsOf(symbols["matches"].([]any), "fqn")))
+
+	// get_callers and get_callees use the first match in FQN order
+	assert.Equal(t, []string{"app.mod00.handler0", "app.mod01.handler0"}, server.findMatchingFQNs("handler0")[:2])
+
+	// Modules with equal function counts are listed by name
+	var info map[string]any
+	result, _ = server.toolGetIndexInfo()
+	require.NoError(t, json.Unmarshal([]byte(result), &info))
+	assert.Equal(t, []string{
+		"app.mod02", "app.mod05", "app.mod08", "app.mod11",
+		"app.mod00", "app.mod01", "app.mod04", "app.mod07", "app.mod10",
+		"app.mod03",
+	}, fqnsOf(info["top_modules"].([]any), "module_fqn"))
+}
+
+func TestCompareEntryValues(t *testing.T) {
+	// Missing values sort first, whichever side they are on
+	assert.Equal(t, -1, compareEntryValues(nil, "x"))
+	assert.Equal(t, 1, compareEntryValues("x", nil))
+	assert.Equal(t, -1, compareEntryValues(nil, 3))
+	assert.Equal(t, 1, compareEntryValues(0.5, nil))
+	assert.Equal(t, 0, compareEntryValues(nil, nil))
+
+	assert.Equal(t, -1, compareEntryValues(1.5, 2.5))
+	assert.Equal(t, 1, compareEntryValues(0.9, 0.25))
+	assert.Equal(t, -1, compareEntryValues(2, 2.5))
+	assert.Equal(t, 0, compareEntryValues(int64(3), 3.0))
+	assert.Equal(t, -1, compareEntryValues("a", "b"))
+	assert.Equal(t, -1, compareEntryValues(10, "a"))
+}
+
+func TestSortEntries_MissingAndFloatValues(t *testing.T) {
+	entries := []map[string]any{
+		{"name": "c", "score": 0.75},
+		{"name": "a"},
+		{"name": "b", "score": 0.25},
+		{"name": "d", "score": 0.5},
+	}
+	sortEntries(entries, "score", "name")
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry["name"].(string))
+	}
+	assert.Equal(t, []string{"a", "b", "d", "c"}, names)
+}
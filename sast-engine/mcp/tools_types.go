@@ -0,0 +1,61 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// toolFindVariablesOfType lists every variable binding inferred to hold an
+// instance of the given type, with pagination support.
+func (s *Server) toolFindVariablesOfType(args map[string]any) (string, bool) {
+	// Check if ready.
+	if !s.statusTracker.IsReady() {
+		return s.returnIndexingStatus(), false
+	}
+
+	typeFQN, _ := args["type"].(string)
+	if typeFQN == "" {
+		return `{"error": "type parameter is required"}`, true
+	}
+
+	pageParams, err := ExtractPaginationParams(args)
+	if err != nil {
+		return NewToolError(err.Message, err.Code, err.Data), true
+	}
+
+	provider, ok := s.callGraph.TypeEngine.(core.TypedVariableProvider)
+	if !ok {
+		return `{"error": "Type inference is not available for this project"}`, true
+	}
+
+	typedVariables := provider.VariablesOfType(typeFQN)
+	allVariables := make([]map[string]any, 0, len(typedVariables))
+	for _, variable := range typedVariables {
+		allVariables = append(allVariables, map[string]any{
+			"name":       variable.Name,
+			"scope":      variable.Scope,
+			"file":       variable.File,
+			"line":       variable.Line,
+			"confidence": variable.Confidence,
+			"source":     variable.Source,
+		})
+	}
+
+	variables, pageInfo := PaginateSlice(allVariables, pageParams)
+
+	result := map[string]any{
+		"type":       typeFQN,
+		"total":      len(allVariables),
+		"variables":  variables,
+		"pagination": pageInfo,
+	}
+
+	if len(allVariables) == 0 {
+		result["note"] = fmt.Sprintf("No variables inferred as %s. Use the fully qualified type name (e.g., 'myapp.models.User').", typeFQN)
+	}
+
+	bytes, _ := json.MarshalIndent(result, "", "  ")
+	return string(bytes), false
+}
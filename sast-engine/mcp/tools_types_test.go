@@ -0,0 +1,70 @@
+package mcp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/resolution"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolFindVariablesOfType(t *testing.T) {
+	server := createTestServer()
+
+	engine := resolution.NewTypeInferenceEngine(core.NewModuleRegistry())
+	addBinding := func(scopeFQN, name, typeFQN string, line uint32) {
+		scope := engine.GetScope(scopeFQN)
+		if scope == nil {
+			scope = resolution.NewFunctionScope(scopeFQN)
+			engine.AddScope(scope)
+		}
+		scope.AddVariable(&resolution.VariableBinding{
+			VarName:  name,
+			Type:     &core.TypeInfo{TypeFQN: typeFQN, Confidence: 0.95, Source: "class_instantiation"},
+			Location: resolution.Location{File: "/test/service.py", Line: line},
+		})
+	}
+	addBinding("service", "system_user", "myapp.models.User", 3)
+	addBinding("service.create", "user", "myapp.models.User", 10)
+	addBinding("service.create", "session", "requests.Session", 11)
+	server.callGraph.TypeEngine = engine
+
+	result, isError := server.executeTool("find_variables_of_type", map[string]any{"type": "myapp.models.User"})
+	require.False(t, isError, result)
+
+	var parsed struct {
+		Type      string           `json:"type"`
+		Total     int              `json:"total"`
+		Variables []map[string]any `json:"variables"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result), &parsed))
+	assert.Equal(t, "myapp.models.User", parsed.Type)
+	assert.Equal(t, 2, parsed.Total)
+	require.Len(t, parsed.Variables, 2)
+	assert.Equal(t, "system_user", parsed.Variables[0]["name"])
+	assert.Equal(t, "service", parsed.Variables[0]["scope"])
+	assert.Equal(t, "user", parsed.Variables[1]["name"])
+	assert.Equal(t, "service.create", parsed.Variables[1]["scope"])
+	assert.InDelta(t, 10, parsed.Variables[1]["line"], 0)
+}
+
+func TestToolFindVariablesOfType_Errors(t *testing.T) {
+	server := createTestServer()
+
+	result, isError := server.executeTool("find_variables_of_type", map[string]any{})
+	assert.True(t, isError)
+	assert.Contains(t, result, "type parameter is required")
+
+	// Providers without reverse lookup are reported as unavailable.
+	server.callGraph.TypeEngine = &mockModuleVariableProvider{}
+	result, isError = server.executeTool("find_variables_of_type", map[string]any{"type": "myapp.models.User"})
+	assert.True(t, isError)
+	assert.Contains(t, result, "Type inference is not available")
+
+	server.callGraph.TypeEngine = resolution.NewTypeInferenceEngine(core.NewModuleRegistry())
+	result, isError = server.executeTool("find_variables_of_type", map[string]any{"type": "User"})
+	assert.False(t, isError)
+	assert.Contains(t, result, "No variables inferred as User")
+}
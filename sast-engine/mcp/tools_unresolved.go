@@ -0,0 +1,71 @@
+package mcp
+
+import (
+	"encoding/json"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// Default and max number of call sites listed per get_unresolved_report
+// category.
+const (
+	defaultUnresolvedLimit = 20
+	maxUnresolvedLimit     = 500
+)
+
+// toolGetUnresolvedReport returns the unresolved call sites grouped by why
+// they failed to resolve.
+func (s *Server) toolGetUnresolvedReport(args map[string]any) (string, bool) {
+	// Check if ready.
+	if !s.statusTracker.IsReady() {
+		return s.returnIndexingStatus(), false
+	}
+
+	limit := defaultUnresolvedLimit
+	if limitVal, ok := args["limit"]; ok {
+		switch v := limitVal.(type) {
+		case float64:
+			limit = int(v)
+		case int:
+			limit = v
+		default:
+			return NewToolError("limit must be a number", ErrCodeInvalidParams, nil), true
+		}
+	}
+	if limit <= 0 {
+		limit = defaultUnresolvedLimit
+	}
+	limit = min(limit, maxUnresolvedLimit)
+	category, _ := args["category"].(string)
+
+	report := s.callGraph.UnresolvedReport()
+	total := 0
+	for _, count := range report {
+		total += count
+	}
+
+	callSites := make(map[core.ResolutionFailure][]map[string]any)
+	for failure, calls := range s.callGraph.UnresolvedCalls() {
+		if category != "" && string(failure) != category {
+			continue
+		}
+		entries := make([]map[string]any, 0, min(len(calls), limit))
+		for _, call := range calls[:min(len(calls), limit)] {
+			entries = append(entries, map[string]any{
+				"caller": call.Caller,
+				"target": call.CallSite.Target,
+				"file":   call.CallSite.Location.File,
+				"line":   call.CallSite.Location.Line,
+			})
+		}
+		callSites[failure] = entries
+	}
+
+	result := map[string]any{
+		"total":       total,
+		"by_category": report,
+		"call_sites":  callSites,
+	}
+	bytes, _ := json.MarshalIndent(result, "", "  ")
+	return string(bytes), false
+}
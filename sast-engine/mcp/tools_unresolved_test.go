@@ -0,0 +1,73 @@
+package mcp
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/builder"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/registry"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type unresolvedReport struct {
+	Total      int                         `json:"total"`
+	ByCategory map[string]int              `json:"by_category"`
+	CallSites  map[string][]map[string]any `json:"call_sites"`
+}
+
+func TestToolGetUnresolvedReport(t *testing.T) {
+	projectPath, err := filepath.Abs("../test-fixtures/python/unresolved_calls")
+	require.NoError(t, err)
+	codeGraph := graph.Initialize(projectPath, nil)
+	moduleRegistry, err := registry.BuildModuleRegistry(projectPath, false)
+	require.NoError(t, err)
+	callGraph, err := builder.BuildCallGraph(codeGraph, moduleRegistry, projectPath, output.NewLogger(output.VerbosityDefault))
+	require.NoError(t, err)
+	server := NewServer(projectPath, "3.11", callGraph, moduleRegistry, codeGraph, time.Second, false)
+
+	report := func(args map[string]any) unresolvedReport {
+		t.Helper()
+		result, isError := server.executeTool("get_unresolved_report", args)
+		require.False(t, isError, result)
+		var parsed unresolvedReport
+		require.NoError(t, json.Unmarshal([]byte(result), &parsed))
+		return parsed
+	}
+
+	t.Run("counts every category", func(t *testing.T) {
+		parsed := report(map[string]any{})
+		assert.Equal(t, 7, parsed.Total)
+		assert.Equal(t, map[string]int{
+			"attribute_chain": 3, // session.pool.conn.flush, conn.pool.cursor(), .fetchall()
+			"not_in_imports":  2, // paginate, render from a module outside the project
+			"variable_method": 2, // value.text.strip, value.text.encode
+		}, parsed.ByCategory)
+
+		notInImports := parsed.CallSites["not_in_imports"]
+		require.Len(t, notInImports, 2)
+		assert.Equal(t, "app.load", notInImports[0]["caller"])
+		assert.Equal(t, "paginate", notInImports[0]["target"])
+		assert.InDelta(t, 7, notInImports[0]["line"], 0)
+		assert.Equal(t, "render", notInImports[1]["target"])
+	})
+
+	t.Run("category and limit narrow the call sites", func(t *testing.T) {
+		parsed := report(map[string]any{"category": "attribute_chain", "limit": float64(1)})
+		assert.Equal(t, 7, parsed.Total)
+		assert.Len(t, parsed.ByCategory, 3)
+		require.Len(t, parsed.CallSites, 1)
+		require.Len(t, parsed.CallSites["attribute_chain"], 1)
+		assert.Equal(t, "app.export", parsed.CallSites["attribute_chain"][0]["caller"])
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		result, isError := server.executeTool("get_unresolved_report", map[string]any{"limit": "ten"})
+		assert.True(t, isError)
+		assert.Contains(t, result, "limit must be a number")
+	})
+}
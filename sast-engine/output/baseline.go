@@ -0,0 +1,126 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/dsl"
+)
+
+// baselineVersion is the baseline file format written by SaveBaseline.
+const baselineVersion = 1
+
+// Baseline is a snapshot of the findings of a scan, so later scans can
+// suppress them and report only findings introduced since. Each entry keeps
+// the rule, function, and location for people reviewing the file; only the
+// fingerprint is compared (see Fingerprint).
+type Baseline struct {
+	Version  int             `json:"version"`
+	Findings []BaselineEntry `json:"findings"`
+}
+
+// BaselineEntry is one baselined finding.
+type BaselineEntry struct {
+	Fingerprint string `json:"fingerprint"`
+	RuleID      string `json:"rule_id"` //nolint:tagliatelle
+	Function    string `json:"function,omitempty"`
+	File        string `json:"file,omitempty"`
+	Line        int    `json:"line,omitempty"`
+}
+
+// SaveBaseline writes findings to path as a baseline.
+func SaveBaseline(path string, findings []BaselineEntry) error {
+	baseline := Baseline{Version: baselineVersion, Findings: findings}
+	if baseline.Findings == nil {
+		baseline.Findings = []BaselineEntry{}
+	}
+
+	data, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode baseline: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write baseline %s: %w", path, err)
+	}
+	return nil
+}
+
+// LoadBaseline reads a baseline written by SaveBaseline. A file that is not
+// valid JSON, has an unsupported version, or has an entry without a
+// fingerprint is reported as malformed.
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline %s: %w", path, err)
+	}
+	var baseline Baseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("malformed baseline %s: %w", path, err)
+	}
+	if baseline.Version != baselineVersion {
+		return nil, fmt.Errorf("malformed baseline %s: unsupported version %d", path, baseline.Version)
+	}
+	for i, entry := range baseline.Findings {
+		if entry.Fingerprint == "" {
+			return nil, fmt.Errorf("malformed baseline %s: finding %d has no fingerprint", path, i+1)
+		}
+	}
+	return &baseline, nil
+}
+
+// Baselined reports, for each fingerprint in order, whether the baseline
+// holds it. A fingerprint baselined n times matches its first n occurrences,
+// so a second copy of a baselined finding is still new.
+func (b *Baseline) Baselined(fingerprints []string) []bool {
+	counts := make(map[string]int, len(b.Findings))
+	for _, entry := range b.Findings {
+		counts[entry.Fingerprint]++
+	}
+
+	baselined := make([]bool, len(fingerprints))
+	for i, fingerprint := range fingerprints {
+		if counts[fingerprint] > 0 {
+			counts[fingerprint]--
+			baselined[i] = true
+		}
+	}
+	return baselined
+}
+
+// DetectionBaseline returns the baseline entries of detections, for
+// SaveBaseline.
+func DetectionBaseline(detections []*dsl.EnrichedDetection) []BaselineEntry {
+	entries := make([]BaselineEntry, 0, len(detections))
+	for _, det := range detections {
+		file := det.Location.RelPath
+		if file == "" {
+			file = det.Location.FilePath
+		}
+		entries = append(entries, BaselineEntry{
+			Fingerprint: det.Fingerprint,
+			RuleID:      det.Rule.ID,
+			Function:    det.Detection.FunctionFQN,
+			File:        file,
+			Line:        det.Location.Line,
+		})
+	}
+	return entries
+}
+
+// FilterBaselined returns the detections not in the baseline, in order, and
+// the number of baselined detections dropped.
+func FilterBaselined(detections []*dsl.EnrichedDetection, baseline *Baseline) ([]*dsl.EnrichedDetection, int) {
+	fingerprints := make([]string, len(detections))
+	for i, det := range detections {
+		fingerprints[i] = det.Fingerprint
+	}
+
+	var kept []*dsl.EnrichedDetection
+	for i, baselined := range baseline.Baselined(fingerprints) {
+		if !baselined {
+			kept = append(kept, detections[i])
+		}
+	}
+	return kept, len(detections) - len(kept)
+}
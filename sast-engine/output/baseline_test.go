@@ -0,0 +1,73 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/dsl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaseline_SaveFilter(t *testing.T) {
+	detection := func(function, code string) *dsl.EnrichedDetection {
+		det := &dsl.EnrichedDetection{
+			Detection: dsl.DataflowDetection{FunctionFQN: function, SinkCall: code},
+			Location:  dsl.LocationInfo{RelPath: "views.py", Line: 3},
+			Rule:      dsl.RuleMetadata{ID: "code-injection"},
+		}
+		det.Fingerprint = DetectionFingerprint(det)
+		return det
+	}
+	calculate := detection("views.calculate", "eval")
+
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	require.NoError(t, SaveBaseline(path, DetectionBaseline([]*dsl.EnrichedDetection{calculate})))
+	baseline, err := LoadBaseline(path)
+	require.NoError(t, err)
+	assert.Equal(t, []BaselineEntry{{
+		Fingerprint: calculate.Fingerprint,
+		RuleID:      "code-injection",
+		Function:    "views.calculate",
+		File:        "views.py",
+		Line:        3,
+	}}, baseline.Findings)
+
+	// Re-running unchanged reports nothing new
+	kept, suppressed := FilterBaselined([]*dsl.EnrichedDetection{detection("views.calculate", "eval")}, baseline)
+	assert.Empty(t, kept)
+	assert.Equal(t, 1, suppressed)
+
+	// A new flow, and a second copy of the baselined one, are reported
+	ping := detection("tasks.ping", "os.system")
+	again := detection("views.calculate", "eval")
+	kept, suppressed = FilterBaselined([]*dsl.EnrichedDetection{calculate, ping, again}, baseline)
+	assert.Equal(t, []*dsl.EnrichedDetection{ping, again}, kept)
+	assert.Equal(t, 1, suppressed)
+}
+
+func TestLoadBaseline_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := LoadBaseline(filepath.Join(dir, "missing.json"))
+	assert.ErrorContains(t, err, "failed to read baseline")
+
+	for name, content := range map[string]string{
+		"truncated.json":      `{"version": 1, "findings": [`,
+		"version.json":        `{"version": 7, "findings": []}`,
+		"no_fingerprint.json": `{"version": 1, "findings": [{"rule_id": "code-injection"}]}`,
+	} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		_, err := LoadBaseline(path)
+		assert.ErrorContains(t, err, "malformed baseline", name)
+	}
+
+	// An empty baseline is valid
+	path := filepath.Join(dir, "empty.json")
+	require.NoError(t, SaveBaseline(path, nil))
+	baseline, err := LoadBaseline(path)
+	require.NoError(t, err)
+	assert.Empty(t, baseline.Findings)
+}
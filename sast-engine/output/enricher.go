@@ -2,6 +2,7 @@ package output
 
 import (
 	"bufio"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -78,6 +79,7 @@ func (e *Enricher) EnrichDetection(detection dsl.DataflowDetection, rule dsl.Rul
 
 	// Extract rule metadata
 	enriched.Rule = e.extractRuleMetadata(rule)
+	enriched.Fingerprint = DetectionFingerprint(enriched)
 
 	// Build taint path for inter-procedural flows
 	if enriched.DetectionType == dsl.DetectionTypeTaintGlobal {
@@ -313,16 +315,19 @@ func (e *Enricher) buildReferenceURLs(cwes []string) []string {
 	return refs
 }
 
-// buildTaintPath constructs inter-procedural taint path (stub for v1).
+// buildTaintPath constructs the inter-procedural taint path: the source, one
+// node per call on the detection's CallPath, then the sink.
 func (e *Enricher) buildTaintPath(detection dsl.DataflowDetection) []dsl.TaintPathNode {
-	// For v1, return source and sink nodes only
-	// Full path reconstruction is a future enhancement
-	path := make([]dsl.TaintPathNode, 0, 2)
+	path := make([]dsl.TaintPathNode, 0, len(detection.CallPath)+2)
 
 	// Source node
+	sourceFQN := detection.SourceFunctionFQN
+	if sourceFQN == "" {
+		sourceFQN = detection.FunctionFQN
+	}
 	sourceLoc := dsl.LocationInfo{
 		Line:     detection.SourceLine,
-		Function: extractFunctionFromFQN(detection.FunctionFQN),
+		Function: extractFunctionFromFQN(sourceFQN),
 	}
 	path = append(path, dsl.TaintPathNode{
 		Location:    sourceLoc,
@@ -331,6 +336,20 @@ func (e *Enricher) buildTaintPath(detection dsl.DataflowDetection) []dsl.TaintPa
 		IsSource:    true,
 	})
 
+	// One node per call the taint is passed through
+	for i := 0; i+1 < len(detection.CallPath); i++ {
+		callee := detection.CallPath[i+1]
+		loc := e.callSiteLocation(detection.CallPath[i], callee)
+		description := "Passed to " + extractFunctionFromFQN(callee)
+		if loc.Line > 0 {
+			description += fmt.Sprintf(" at line %d", loc.Line)
+		}
+		path = append(path, dsl.TaintPathNode{
+			Location:    loc,
+			Description: description,
+		})
+	}
+
 	// Sink node
 	sinkLoc := dsl.LocationInfo{
 		Line:     detection.SinkLine,
@@ -346,6 +365,30 @@ func (e *Enricher) buildTaintPath(detection dsl.DataflowDetection) []dsl.TaintPa
 	return path
 }
 
+// callSiteLocation locates the call from caller to callee, falling back to
+// the caller's function name when the callgraph does not record the call.
+func (e *Enricher) callSiteLocation(caller, callee string) dsl.LocationInfo {
+	loc := dsl.LocationInfo{Function: extractFunctionFromFQN(caller)}
+	if e.callgraph == nil {
+		return loc
+	}
+	for _, site := range e.callgraph.CallSites[caller] {
+		if site.TargetFQN != callee {
+			continue
+		}
+		loc.FilePath = site.Location.File
+		loc.Line = site.Location.Line
+		loc.Column = site.Location.Column
+		if e.options.ProjectRoot != "" && loc.FilePath != "" {
+			if relPath, err := filepath.Rel(e.options.ProjectRoot, loc.FilePath); err == nil {
+				loc.RelPath = relPath
+			}
+		}
+		break
+	}
+	return loc
+}
+
 // EnrichAll enriches multiple detections.
 func (e *Enricher) EnrichAll(detections []dsl.DataflowDetection, rule dsl.RuleIR) ([]*dsl.EnrichedDetection, error) {
 	enriched := make([]*dsl.EnrichedDetection, 0, len(detections))
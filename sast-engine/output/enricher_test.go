@@ -569,6 +569,40 @@ func TestBuildTaintPath(t *testing.T) {
 	}
 }
 
+func TestBuildTaintPath_CallPath(t *testing.T) {
+	cg := core.NewCallGraph()
+	cg.AddCallSite("app.calculate", core.CallSite{
+		Target:    "helper",
+		TargetFQN: "app.helper",
+		Location:  core.Location{File: "/project/app.py", Line: 11, Column: 12},
+	})
+	e := NewEnricher(cg, &OutputOptions{ProjectRoot: "/project"})
+
+	detection := dsl.DataflowDetection{
+		FunctionFQN:       "app.run",
+		SourceFunctionFQN: "app.calculate",
+		SourceLine:        10,
+		SinkLine:          2,
+		CallPath:          []string{"app.calculate", "app.helper", "app.run"},
+	}
+
+	path := e.buildTaintPath(detection)
+	require.Len(t, path, 4)
+	assert.True(t, path[0].IsSource)
+	assert.Equal(t, "calculate", path[0].Location.Function)
+
+	assert.Equal(t, "Passed to helper at line 11", path[1].Description)
+	assert.Equal(t, "app.py", path[1].Location.RelPath)
+	assert.Equal(t, "calculate", path[1].Location.Function)
+
+	// app.helper -> app.run is not in the callgraph, so its line is unknown
+	assert.Equal(t, "Passed to run", path[2].Description)
+	assert.Equal(t, "helper", path[2].Location.Function)
+
+	assert.True(t, path[3].IsSink)
+	assert.Equal(t, "run", path[3].Location.Function)
+}
+
 func TestEnrichAll(t *testing.T) {
 	e := NewEnricher(nil, nil)
 
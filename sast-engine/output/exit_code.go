@@ -2,6 +2,7 @@ package output
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/shivasurya/code-pathfinder/sast-engine/dsl"
@@ -40,37 +41,131 @@ var validSeverities = map[string]bool{
 	"info":     true,
 }
 
-// DetermineExitCode calculates the appropriate exit code based on detections,
-// fail-on severities, and whether errors occurred during execution.
+// GateFinding is what the CI gate needs to know about a finding: its
+// severity and whether it is suppressed (e.g. baselined or allowlisted).
+type GateFinding struct {
+	Severity   string
+	Suppressed bool
+}
+
+// GateExitCode is the CI gate decision shared by the CLI and embedders
+// (see callgraph.AnalysisResult.ExitCode): whether findings should fail a
+// run with the given --fail-on severities. Suppressed findings never fail.
+// Use ExitSummary to explain the decision.
 //
 // Exit code precedence:
 // 1. ExitCodeError (2) - if hadErrors is true.
-// 2. ExitCodeFindings (1) - if any detections match fail-on severities.
+// 2. ExitCodeFindings (1) - if any unsuppressed finding matches fail-on severities.
 // 3. ExitCodeSuccess (0) - otherwise (no findings or no --fail-on match).
-func DetermineExitCode(detections []*dsl.EnrichedDetection, failOn []string, hadErrors bool) ExitCode {
+func GateExitCode(findings []GateFinding, failOn []string, hadErrors bool) ExitCode {
 	// Errors take precedence over findings
 	if hadErrors {
 		return ExitCodeError
 	}
+	if len(failingFindings(findings, failOn)) > 0 {
+		return ExitCodeFindings
+	}
+	return ExitCodeSuccess
+}
+
+// DetermineExitCode is GateExitCode for the detections of a scan, none of
+// which is suppressed: baselined detections are dropped before it is called
+// (see FilterBaselined).
+func DetermineExitCode(detections []*dsl.EnrichedDetection, failOn []string, hadErrors bool) ExitCode {
+	return GateExitCode(DetectionGateFindings(detections), failOn, hadErrors)
+}
+
+// DetectionGateFindings returns the gate findings of detections.
+func DetectionGateFindings(detections []*dsl.EnrichedDetection) []GateFinding {
+	findings := make([]GateFinding, 0, len(detections))
+	for _, det := range detections {
+		findings = append(findings, GateFinding{Severity: det.Rule.Severity})
+	}
+	return findings
+}
+
+// ExitSummary explains GateExitCode's decision, counting the failing
+// findings by severity and noting how many suppressed findings were
+// ignored.
+//
+// Example:
+//
+//	"failing: 3 findings match critical,high (1 critical, 2 high); 4 suppressed"
+//	"passing: no findings match critical,high"
+func ExitSummary(findings []GateFinding, failOn []string) string {
+	failing := failingFindings(findings, failOn)
+	threshold := strings.ToLower(strings.Join(failOn, ","))
+
+	var summary string
+	switch {
+	case len(failOn) == 0:
+		summary = "passing: no --fail-on severities"
+	case len(failing) == 0:
+		summary = fmt.Sprintf("passing: no findings match %s", threshold)
+	default:
+		counts := make(map[string]int)
+		for _, finding := range failing {
+			counts[strings.ToLower(finding.Severity)]++
+		}
+		var parts []string
+		for _, severity := range severityOrder {
+			if counts[severity] > 0 {
+				parts = append(parts, fmt.Sprintf("%d %s", counts[severity], severity))
+			}
+		}
+		summary = fmt.Sprintf("failing: %s match %s (%s)", pluralFindings(len(failing)), threshold, strings.Join(parts, ", "))
+	}
 
-	// If no --fail-on specified, always return success
-	if len(failOn) == 0 {
-		return ExitCodeSuccess
+	suppressed := 0
+	for _, finding := range findings {
+		if finding.Suppressed {
+			suppressed++
+		}
+	}
+	if suppressed > 0 {
+		summary += fmt.Sprintf("; %d suppressed", suppressed)
 	}
+	return summary
+}
 
-	// Check if any detections match fail-on severities
+// SeveritiesAtOrAbove returns the severities at or above severity, most
+// severe first, for gating on a threshold: SeveritiesAtOrAbove("high") is
+// critical and high. An unknown severity returns none, which never fails.
+func SeveritiesAtOrAbove(severity string) []string {
+	for i, s := range severityOrder {
+		if s == strings.ToLower(severity) {
+			return slices.Clone(severityOrder[:i+1])
+		}
+	}
+	return nil
+}
+
+// severityOrder lists the valid severities from most to least severe.
+var severityOrder = []string{"critical", "high", "medium", "low", "info"}
+
+// failingFindings returns the unsuppressed findings matching a fail-on
+// severity, in order.
+func failingFindings(findings []GateFinding, failOn []string) []GateFinding {
 	failOnMap := make(map[string]bool)
 	for _, severity := range failOn {
 		failOnMap[strings.ToLower(severity)] = true
 	}
 
-	for _, det := range detections {
-		if failOnMap[strings.ToLower(det.Rule.Severity)] {
-			return ExitCodeFindings
+	var failing []GateFinding
+	for _, finding := range findings {
+		if !finding.Suppressed && failOnMap[strings.ToLower(finding.Severity)] {
+			failing = append(failing, finding)
 		}
 	}
+	return failing
+}
 
-	return ExitCodeSuccess
+// pluralFindings formats a count of findings.
+func pluralFindings(n int) string {
+	if n == 1 {
+		return "1 finding"
+	}
+	return fmt.Sprintf("%d findings", n)
 }
 
 // ParseFailOn parses the comma-separated --fail-on flag value into a slice of severities.
@@ -96,14 +191,12 @@ func ParseFailOn(value string) []string {
 // Valid severities are: critical, high, medium, low, info (case-insensitive).
 // Returns InvalidSeverityError for the first invalid severity encountered.
 func ValidateSeverities(severities []string) error {
-	validList := []string{"critical", "high", "medium", "low", "info"}
-
 	for _, severity := range severities {
 		normalized := strings.ToLower(severity)
 		if !validSeverities[normalized] {
 			return &InvalidSeverityError{
 				Severity: severity,
-				Valid:    validList,
+				Valid:    severityOrder,
 			}
 		}
 	}
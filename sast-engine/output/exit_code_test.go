@@ -173,6 +173,40 @@ func TestDetermineExitCode(t *testing.T) {
 	}
 }
 
+func TestGateExitCode_Threshold(t *testing.T) {
+	findings := []GateFinding{{Severity: "medium"}}
+
+	// The code flips exactly at the finding's severity
+	assert.Equal(t, ExitCodeSuccess, GateExitCode(findings, SeveritiesAtOrAbove("critical"), false))
+	assert.Equal(t, ExitCodeSuccess, GateExitCode(findings, SeveritiesAtOrAbove("high"), false))
+	assert.Equal(t, ExitCodeFindings, GateExitCode(findings, SeveritiesAtOrAbove("medium"), false))
+	assert.Equal(t, ExitCodeFindings, GateExitCode(findings, SeveritiesAtOrAbove("low"), false))
+
+	assert.Equal(t, ExitCodeSuccess, GateExitCode(nil, SeveritiesAtOrAbove("low"), false))
+	assert.Empty(t, SeveritiesAtOrAbove("urgent"), "an unknown threshold never fails")
+	assert.Equal(t, []string{"critical", "high"}, SeveritiesAtOrAbove("HIGH"))
+	assert.Equal(t, ExitCodeError, GateExitCode(findings, nil, true))
+}
+
+func TestGateExitCode_SuppressedAndSummary(t *testing.T) {
+	findings := []GateFinding{
+		{Severity: "critical"},
+		{Severity: "high"},
+		{Severity: "medium"},
+	}
+	failOn := []string{"critical", "high"}
+	assert.Equal(t, ExitCodeFindings, GateExitCode(findings, []string{"critical"}, false))
+	assert.Equal(t, "failing: 2 findings match critical,high (1 critical, 1 high)", ExitSummary(findings, failOn))
+
+	// Suppressing the critical finding takes it out of the gate
+	findings[0].Suppressed = true
+	assert.Equal(t, ExitCodeSuccess, GateExitCode(findings, []string{"critical"}, false))
+	assert.Equal(t, "passing: no findings match critical; 1 suppressed", ExitSummary(findings, []string{"critical"}))
+	assert.Equal(t, ExitCodeFindings, GateExitCode(findings, failOn, false))
+	assert.Equal(t, "failing: 1 finding match critical,high (1 high); 1 suppressed", ExitSummary(findings, failOn))
+	assert.Equal(t, "passing: no --fail-on severities; 1 suppressed", ExitSummary(findings, nil))
+}
+
 func TestParseFailOn(t *testing.T) {
 	tests := []struct {
 		name     string
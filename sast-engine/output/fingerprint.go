@@ -0,0 +1,44 @@
+package output
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/dsl"
+)
+
+// SARIFFingerprintKey names the fingerprint in a SARIF result's
+// partialFingerprints.
+const SARIFFingerprintKey = "codePathfinder/v1"
+
+// Fingerprint returns a stable identifier for a finding, for comparing
+// scans against a baseline. It hashes the rule ID, the FQN of the function
+// containing the finding, and its line of code with whitespace normalized,
+// so lines added elsewhere in the file or reindentation do not change it.
+func Fingerprint(ruleID, function, code string) string {
+	code = strings.Join(strings.Fields(code), " ")
+	sum := sha256.Sum256([]byte(ruleID + "\x00" + function + "\x00" + code))
+	return hex.EncodeToString(sum[:])
+}
+
+// DetectionFingerprint returns the Fingerprint of a detection: its rule,
+// its function, and its highlighted snippet line. Without a snippet, the
+// sink call is used. File-scoped detections (container rules) have no
+// function, so their relative path stands in, keeping the fingerprint
+// independent of where the project is checked out.
+func DetectionFingerprint(det *dsl.EnrichedDetection) string {
+	function := det.Detection.FunctionFQN
+	if det.Detection.Scope == "file" {
+		function = det.Location.RelPath
+	}
+
+	code := det.Detection.SinkCall
+	for _, line := range det.Snippet.Lines {
+		if line.IsHighlight {
+			code = line.Content
+			break
+		}
+	}
+	return Fingerprint(det.Rule.ID, function, code)
+}
@@ -0,0 +1,85 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/dsl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fingerprintSource = `def login(request):
+    next_url = request.GET.get("next")
+    return redirect(next_url)
+`
+
+// enrichRedirect enriches the redirect finding in source, written to a
+// project file, the way a scan reports it.
+func enrichRedirect(t *testing.T, source string) *dsl.EnrichedDetection {
+	t.Helper()
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "views.py"), []byte(source), 0o644))
+
+	line := 0
+	for i, text := range strings.Split(source, "\n") {
+		if strings.Contains(text, "return redirect(") {
+			line = i + 1
+		}
+	}
+	require.NotZero(t, line, "no redirect call in source")
+
+	rule := dsl.RuleIR{}
+	rule.Rule.ID = "open-redirect"
+	enriched, err := NewEnricher(nil, &OutputOptions{ProjectRoot: tmpDir}).EnrichDetection(dsl.DataflowDetection{
+		FunctionFQN: "views.login",
+		SinkLine:    line,
+		SinkCall:    "redirect",
+		Scope:       "local",
+	}, rule)
+	require.NoError(t, err)
+	return enriched
+}
+
+func TestDetectionFingerprint_StableAcrossUnrelatedEdits(t *testing.T) {
+	original := enrichRedirect(t, fingerprintSource)
+	require.Len(t, original.Fingerprint, 64)
+
+	// Unrelated lines above the finding shift its location
+	shifted := enrichRedirect(t, "LOGIN_URL = \"/login\"\n\n\ndef home(request):\n    return None\n\n\n"+fingerprintSource)
+	require.NotEqual(t, original.Location.Line, shifted.Location.Line)
+	assert.Equal(t, original.Fingerprint, shifted.Fingerprint)
+
+	// Reindenting the sink line does not change it either
+	reindented := enrichRedirect(t, strings.ReplaceAll(fingerprintSource, "    ", "\t"))
+	assert.Equal(t, original.Fingerprint, reindented.Fingerprint)
+
+	changed := enrichRedirect(t, strings.Replace(fingerprintSource, "redirect(next_url)", "redirect(next_url, permanent=True)", 1))
+	assert.NotEqual(t, original.Fingerprint, changed.Fingerprint)
+}
+
+func TestFingerprint(t *testing.T) {
+	fingerprint := Fingerprint("open-redirect", "views.login", "return redirect(next_url)")
+	assert.Equal(t, fingerprint, Fingerprint("open-redirect", "views.login", "  return   redirect(next_url)\n"))
+	assert.NotEqual(t, fingerprint, Fingerprint("open-redirect", "views.logout", "return redirect(next_url)"))
+	assert.NotEqual(t, fingerprint, Fingerprint("xss", "views.login", "return redirect(next_url)"))
+
+	// Without a snippet, the sink call identifies the detection
+	det := &dsl.EnrichedDetection{
+		Detection: dsl.DataflowDetection{FunctionFQN: "views.login", SinkCall: "redirect"},
+		Rule:      dsl.RuleMetadata{ID: "open-redirect"},
+	}
+	assert.Equal(t, Fingerprint("open-redirect", "views.login", "redirect"), DetectionFingerprint(det))
+
+	// Container findings are identified by relative path, not checkout location
+	container := func(checkout string) *dsl.EnrichedDetection {
+		return &dsl.EnrichedDetection{
+			Detection: dsl.DataflowDetection{FunctionFQN: checkout + "/Dockerfile", Scope: "file"},
+			Location:  dsl.LocationInfo{FilePath: checkout + "/Dockerfile", RelPath: "Dockerfile"},
+			Rule:      dsl.RuleMetadata{ID: "DOCKER-SEC-001"},
+		}
+	}
+	assert.Equal(t, DetectionFingerprint(container("/home/ci/a")), DetectionFingerprint(container("/tmp/b")))
+}
@@ -59,14 +59,16 @@ type JSONScan struct {
 
 // JSONResult represents a single finding.
 type JSONResult struct {
-	RuleID     string         `json:"rule_id"`   //nolint:tagliatelle
-	RuleName   string         `json:"rule_name"` //nolint:tagliatelle
-	Message    string         `json:"message"`
-	Severity   string         `json:"severity"`
-	Confidence string         `json:"confidence"`
-	Location   JSONLocation   `json:"location"`
-	Detection  JSONDetection  `json:"detection"`
-	Metadata   JSONMetadata   `json:"metadata"`
+	RuleID      string        `json:"rule_id"`   //nolint:tagliatelle
+	RuleName    string        `json:"rule_name"` //nolint:tagliatelle
+	Message     string        `json:"message"`
+	Severity    string        `json:"severity"`
+	Confidence  string        `json:"confidence"`
+	Location    JSONLocation  `json:"location"`
+	Detection   JSONDetection `json:"detection"`
+	Metadata    JSONMetadata  `json:"metadata"`
+	Fingerprint string        `json:"fingerprint,omitempty"`
+	Score       float64       `json:"score"`
 }
 
 // JSONLocation contains finding location.
@@ -169,14 +171,16 @@ func (f *JSONFormatter) buildResults(detections []*dsl.EnrichedDetection) []JSON
 
 	for _, det := range detections {
 		result := JSONResult{
-			RuleID:     det.Rule.ID,
-			RuleName:   det.Rule.Name,
-			Message:    det.Rule.Description,
-			Severity:   det.Rule.Severity,
-			Confidence: det.ConfidenceLevel(),
-			Location:   f.buildLocation(det),
-			Detection:  f.buildDetection(det),
-			Metadata:   f.buildMetadata(det),
+			RuleID:      det.Rule.ID,
+			RuleName:    det.Rule.Name,
+			Message:     det.Rule.Description,
+			Severity:    det.Rule.Severity,
+			Confidence:  det.ConfidenceLevel(),
+			Location:    f.buildLocation(det),
+			Detection:   f.buildDetection(det),
+			Metadata:    f.buildMetadata(det),
+			Fingerprint: det.Fingerprint,
+			Score:       det.Score,
 		}
 		results = append(results, result)
 	}
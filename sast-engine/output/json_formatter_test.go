@@ -47,6 +47,8 @@ func TestJSONFormatterStructure(t *testing.T) {
 				CWE:         []string{"CWE-78"},
 				OWASP:       []string{"A1:2017"},
 			},
+			Fingerprint: "3f2a",
+			Score:       0.9,
 		},
 	}
 
@@ -109,6 +111,12 @@ func TestJSONFormatterStructure(t *testing.T) {
 	if result.Severity != "critical" {
 		t.Errorf("severity: got %q", result.Severity)
 	}
+	if result.Fingerprint != "3f2a" {
+		t.Errorf("fingerprint: got %q, want %q", result.Fingerprint, "3f2a")
+	}
+	if result.Score != 0.9 {
+		t.Errorf("score: got %v, want 0.9", result.Score)
+	}
 	if result.Confidence != "high" {
 		t.Errorf("confidence: got %q", result.Confidence)
 	}
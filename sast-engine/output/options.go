@@ -19,6 +19,7 @@ type OutputOptions struct {
 	FailOn       []string // Severities to fail on (empty = never fail)
 	ProjectRoot  string   // Project root for relative paths
 	ContextLines int      // Lines of context around findings (default 3)
+	Explain      bool     // Show each step of taint flows (text output)
 }
 
 // OutputFormat specifies the output format.
@@ -178,6 +178,14 @@ func (f *SARIFFormatter) buildResult(det *dsl.EnrichedDetection, run *sarif.Run)
 
 	result := run.CreateResultForRule(det.Rule.ID).
 		WithMessage(sarif.NewTextMessage(message))
+	if det.Fingerprint != "" {
+		result.WithPartialFingerPrints(map[string]any{SARIFFingerprintKey: det.Fingerprint})
+	}
+
+	// Triage score (see RiskScore)
+	props := sarif.NewPropertyBag()
+	props.Add("score", det.Score)
+	result.AttachPropertyBag(props)
 
 	// Primary location
 	f.addLocation(det, result)
@@ -270,11 +278,31 @@ func (f *SARIFFormatter) addCodeFlow(det *dsl.EnrichedDetection, result *sarif.R
 		).
 		WithMessage(sarif.NewTextMessage(sinkMsg))
 
+	// Calls the taint passes through sit between source and sink
+	threadFlowLocations := []*sarif.ThreadFlowLocation{
+		sarif.NewThreadFlowLocation().WithLocation(sourceLocation),
+	}
+	for _, step := range det.TaintPath {
+		stepFilePath := step.Location.RelPath
+		if stepFilePath == "" {
+			stepFilePath = step.Location.FilePath
+		}
+		if step.IsSource || step.IsSink || stepFilePath == "" || step.Location.Line == 0 {
+			continue
+		}
+		stepLocation := sarif.NewLocation().
+			WithPhysicalLocation(
+				sarif.NewPhysicalLocation().
+					WithArtifactLocation(sarif.NewArtifactLocation().WithUri(stepFilePath)).
+					WithRegion(sarif.NewRegion().WithStartLine(step.Location.Line)),
+			).
+			WithMessage(sarif.NewTextMessage(step.Description))
+		threadFlowLocations = append(threadFlowLocations, sarif.NewThreadFlowLocation().WithLocation(stepLocation))
+	}
+	threadFlowLocations = append(threadFlowLocations, sarif.NewThreadFlowLocation().WithLocation(sinkLocation))
+
 	threadFlow := sarif.NewThreadFlow().
-		WithLocations([]*sarif.ThreadFlowLocation{
-			sarif.NewThreadFlowLocation().WithLocation(sourceLocation),
-			sarif.NewThreadFlowLocation().WithLocation(sinkLocation),
-		})
+		WithLocations(threadFlowLocations)
 
 	flowMsg := fmt.Sprintf("Taint flow from line %d to line %d", det.Detection.SourceLine, det.Detection.SinkLine)
 	codeFlow := sarif.NewCodeFlow().
@@ -172,6 +172,8 @@ func TestSARIFFormatterResults(t *testing.T) {
 				Severity:    "critical",
 				Description: "Command injection vulnerability",
 			},
+			Fingerprint: "3f2a",
+			Score:       0.9,
 		},
 	}
 
@@ -205,6 +207,9 @@ func TestSARIFFormatterResults(t *testing.T) {
 	region := physLoc["region"].(map[string]any)
 	assert.Equal(t, float64(20), region["startLine"])
 	assert.Equal(t, float64(8), region["startColumn"])
+
+	assert.Equal(t, map[string]any{SARIFFingerprintKey: "3f2a"}, result["partialFingerprints"])
+	assert.Equal(t, map[string]any{"score": 0.9}, result["properties"])
 }
 
 func TestSARIFFormatterCodeFlows(t *testing.T) {
@@ -475,6 +480,58 @@ func TestSARIFFormatterTaintGlobalCodeFlow(t *testing.T) {
 	assert.NotNil(t, codeFlow["message"])
 }
 
+func TestSARIFFormatterTaintPathSteps(t *testing.T) {
+	var buf bytes.Buffer
+	sf := NewSARIFFormatterWithWriter(&buf, nil)
+
+	detections := []*dsl.EnrichedDetection{
+		{
+			Detection: dsl.DataflowDetection{
+				SourceLine: 10,
+				SinkLine:   2,
+				TaintedVar: "expression",
+				SinkCall:   "eval",
+			},
+			DetectionType: dsl.DetectionTypeTaintGlobal,
+			Location:      dsl.LocationInfo{RelPath: "app.py", Line: 2},
+			Rule:          dsl.RuleMetadata{ID: "code-inj", Name: "Code Injection", Severity: "critical", Description: "Code injection"},
+			TaintPath: []dsl.TaintPathNode{
+				{Location: dsl.LocationInfo{Line: 10}, Description: "Taint originates here", IsSource: true},
+				{Location: dsl.LocationInfo{RelPath: "app.py", Line: 11}, Description: "Passed to helper at line 11"},
+				{Location: dsl.LocationInfo{RelPath: "app.py", Line: 6}, Description: "Passed to run at line 6"},
+				{Location: dsl.LocationInfo{Line: 2}, Description: "Taint reaches dangerous sink", IsSink: true},
+			},
+		},
+	}
+
+	err := sf.Format(detections, ScanInfo{})
+	require.NoError(t, err)
+
+	var report map[string]any
+	err = json.Unmarshal(buf.Bytes(), &report)
+	require.NoError(t, err)
+
+	result := report["runs"].([]any)[0].(map[string]any)["results"].([]any)[0].(map[string]any)
+	codeFlow := result["codeFlows"].([]any)[0].(map[string]any)
+	threadFlow := codeFlow["threadFlows"].([]any)[0].(map[string]any)
+
+	var messages []string
+	var lines []float64
+	for _, step := range threadFlow["locations"].([]any) {
+		location := step.(map[string]any)["location"].(map[string]any)
+		messages = append(messages, location["message"].(map[string]any)["text"].(string))
+		region := location["physicalLocation"].(map[string]any)["region"].(map[string]any)
+		lines = append(lines, region["startLine"].(float64))
+	}
+	assert.Equal(t, []string{
+		"Taint source: expression",
+		"Passed to helper at line 11",
+		"Passed to run at line 6",
+		"Taint sink: eval",
+	}, messages)
+	assert.Equal(t, []float64{10, 11, 6, 2}, lines)
+}
+
 func TestSARIFFormatterFallbackToFilePath(t *testing.T) {
 	var buf bytes.Buffer
 	sf := NewSARIFFormatterWithWriter(&buf, nil)
@@ -0,0 +1,52 @@
+package output
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/dsl"
+)
+
+// InternalReachWeight scales the score of a finding whose source no HTTP
+// entrypoint reaches, e.g. one in an internal script.
+const InternalReachWeight = 0.5
+
+// RiskScore ranks a finding for triage, from 0.0 to 1.0. It multiplies
+// three weights:
+//   - severity: 1.0 for critical down to 0.25 for low (0 for info or unknown)
+//   - confidence: how certain the analysis is of the flow, 0.0-1.0
+//   - reachability: 1.0 when an HTTP entrypoint reaches the source,
+//     InternalReachWeight otherwise
+//
+// A critical flow from a route handler thus outranks a low-severity one in
+// an internal script.
+//
+// Example:
+//
+//	score := output.RiskScore("critical", 0.9, true)  // 0.9
+func RiskScore(severity string, confidence float64, reachable bool) float64 {
+	reach := InternalReachWeight
+	if reachable {
+		reach = 1.0
+	}
+	return severityWeight(severity) * confidence * reach
+}
+
+// severityWeight spreads the severities evenly from 1.0 for critical to 0
+// for info. Unknown severities weigh 0.
+func severityWeight(severity string) float64 {
+	i := slices.Index(severityOrder, strings.ToLower(severity))
+	if i < 0 {
+		return 0
+	}
+	return float64(len(severityOrder)-1-i) / float64(len(severityOrder)-1)
+}
+
+// SortByScore orders detections by Score, highest first. Ties keep their
+// order, so sorting stays deterministic.
+func SortByScore(detections []*dsl.EnrichedDetection) {
+	slices.SortStableFunc(detections, func(a, b *dsl.EnrichedDetection) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+}
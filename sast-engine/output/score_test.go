@@ -0,0 +1,36 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/dsl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRiskScore(t *testing.T) {
+	assert.InDelta(t, 0.9, RiskScore("critical", 0.9, true), 1e-9)
+	assert.InDelta(t, 0.75, RiskScore("HIGH", 1.0, true), 1e-9)
+	assert.InDelta(t, 0.25, RiskScore("medium", 1.0, false), 1e-9)
+	assert.InDelta(t, 0.125, RiskScore("low", 1.0, false), 1e-9)
+	assert.Zero(t, RiskScore("info", 1.0, true))
+	assert.Zero(t, RiskScore("urgent", 1.0, true))
+}
+
+func TestSortByScore(t *testing.T) {
+	detection := func(id string, score float64) *dsl.EnrichedDetection {
+		return &dsl.EnrichedDetection{Rule: dsl.RuleMetadata{ID: id}, Score: score}
+	}
+	detections := []*dsl.EnrichedDetection{
+		detection("internal-low", RiskScore("low", 1.0, false)),
+		detection("route-critical", RiskScore("critical", 0.8, true)),
+		detection("internal-critical", RiskScore("critical", 0.8, false)),
+		detection("internal-low-2", RiskScore("low", 1.0, false)),
+	}
+	SortByScore(detections)
+
+	var order []string
+	for _, det := range detections {
+		order = append(order, det.Rule.ID)
+	}
+	assert.Equal(t, []string{"route-critical", "internal-critical", "internal-low", "internal-low-2"}, order)
+}
@@ -153,6 +153,9 @@ func (f *TextFormatter) writeDetailedFinding(det *dsl.EnrichedDetection) {
 	// Taint flow (for taint detections with named variable)
 	if det.DetectionType == dsl.DetectionTypeTaintLocal || det.DetectionType == dsl.DetectionTypeTaintGlobal {
 		f.writeTaintFlow(det)
+		if f.options.Explain {
+			f.writeExplanation(det)
+		}
 	}
 
 	// Confidence and detection method
@@ -232,6 +235,24 @@ func (f *TextFormatter) writeTaintFlow(det *dsl.EnrichedDetection) {
 		det.Detection.TaintedVar)
 }
 
+// writeExplanation lists the steps of the taint path, one per line.
+func (f *TextFormatter) writeExplanation(det *dsl.EnrichedDetection) {
+	if len(det.TaintPath) == 0 {
+		return
+	}
+
+	fmt.Fprintln(f.writer, "    Explanation:")
+	for i, step := range det.TaintPath {
+		location := f.formatLocation(step.Location)
+		if step.IsSource && det.Detection.SourceFile != "" {
+			location = fmt.Sprintf("%s:%d", det.Detection.SourceFile, step.Location.Line)
+		} else if step.IsSink && det.Detection.SinkFile != "" {
+			location = fmt.Sprintf("%s:%d", det.Detection.SinkFile, step.Location.Line)
+		}
+		fmt.Fprintf(f.writer, "      %d. %s (%s)\n", i+1, step.Description, location)
+	}
+}
+
 func (f *TextFormatter) formatDetectionMethod(dt dsl.DetectionType) string {
 	switch dt {
 	case dsl.DetectionTypePattern:
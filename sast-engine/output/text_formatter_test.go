@@ -268,6 +268,50 @@ func TestTextFormatterTaintFlow(t *testing.T) {
 	}
 }
 
+func TestTextFormatterExplain(t *testing.T) {
+	detections := []*dsl.EnrichedDetection{
+		{
+			Detection: dsl.DataflowDetection{
+				SourceLine: 10,
+				SinkLine:   2,
+				SourceFile: "app.py",
+				SinkFile:   "app.py",
+				TaintedVar: "expression",
+				SinkCall:   "eval",
+				Confidence: 0.9,
+			},
+			DetectionType: dsl.DetectionTypeTaintGlobal,
+			Rule:          dsl.RuleMetadata{ID: "code-inj", Severity: "critical"},
+			Location:      dsl.LocationInfo{RelPath: "app.py", Line: 2},
+			TaintPath: []dsl.TaintPathNode{
+				{Location: dsl.LocationInfo{Line: 10, Function: "calculate"}, Description: "Taint originates here", IsSource: true},
+				{Location: dsl.LocationInfo{RelPath: "app.py", Line: 11}, Description: "Passed to helper at line 11"},
+				{Location: dsl.LocationInfo{Line: 2, Function: "run"}, Description: "Taint reaches dangerous sink", IsSink: true},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	tf := NewTextFormatterWithWriter(&buf, &OutputOptions{Explain: true}, nil)
+	tf.Format(detections, BuildSummary(detections, 1))
+
+	expected := "    Explanation:\n" +
+		"      1. Taint originates here (app.py:10)\n" +
+		"      2. Passed to helper at line 11 (app.py:11)\n" +
+		"      3. Taint reaches dangerous sink (app.py:2)\n"
+	if !strings.Contains(buf.String(), expected) {
+		t.Errorf("missing explanation steps, got:\n%s", buf.String())
+	}
+
+	// Steps are only listed with --explain
+	buf.Reset()
+	tf = NewTextFormatterWithWriter(&buf, nil, nil)
+	tf.Format(detections, BuildSummary(detections, 1))
+	if strings.Contains(buf.String(), "Explanation:") {
+		t.Error("explanation shown without Explain option")
+	}
+}
+
 func TestFormatLocation(t *testing.T) {
 	tf := NewTextFormatter(nil, nil)
 
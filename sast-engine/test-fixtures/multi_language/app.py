@@ -0,0 +1,9 @@
+import subprocess
+
+
+def run_worker():
+    return subprocess.run(["go", "run", "./cmd/worker"], check=True)
+
+
+def cleanup():
+    subprocess.run("rm -rf build", shell=True)
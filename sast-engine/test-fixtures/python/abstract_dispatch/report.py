@@ -0,0 +1,5 @@
+from shapes import Shape
+
+
+def total(shape: Shape):
+    return shape.area()
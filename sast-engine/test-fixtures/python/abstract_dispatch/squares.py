@@ -0,0 +1,9 @@
+from shapes import Shape
+
+
+class Square(Shape):
+    def __init__(self, side):
+        self.side = side
+
+    def area(self):
+        return self.side * self.side
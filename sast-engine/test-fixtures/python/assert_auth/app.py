@@ -0,0 +1,23 @@
+from django.http import HttpResponse, HttpResponseForbidden
+
+
+def dashboard(request):
+    assert request.user.is_authenticated
+    return HttpResponse("dashboard")
+
+
+def delete_post(request, post):
+    assert request.user.has_perm("blog.delete_post"), "not allowed"
+    post.delete()
+    return HttpResponse("deleted")
+
+
+def average(values):
+    assert len(values) > 0, "values must not be empty"
+    return sum(values) / len(values)
+
+
+def settings_page(request):
+    if not request.user.is_staff:
+        return HttpResponseForbidden()
+    return HttpResponse("settings")
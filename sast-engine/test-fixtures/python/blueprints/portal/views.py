@@ -0,0 +1,28 @@
+import subprocess
+
+from flask import Flask, request
+
+from portal.admin import admin_bp
+
+app = Flask(__name__)
+
+
+@admin_bp.route("/delete", methods=["POST"])
+def delete_user():
+    name = request.form["name"]
+    subprocess.call("userdel " + name, shell=True)
+    return "deleted"
+
+
+@app.get("/health")
+def health():
+    return "ok"
+
+
+@cache.get("key")
+def cached():
+    return "value"
+
+
+def helper():
+    return request.args.get("q")
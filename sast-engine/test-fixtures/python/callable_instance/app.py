@@ -0,0 +1,28 @@
+class Handler:
+    def __call__(self, event):
+        return self.process(event)
+
+    def process(self, event):
+        return event
+
+
+class Plain:
+    def run(self):
+        return None
+
+
+handler = Handler()
+
+
+def dispatch(event):
+    local = Handler()
+    return local(event)
+
+
+def dispatch_global(event):
+    return handler(event)
+
+
+def dispatch_plain():
+    plain = Plain()
+    return plain()
@@ -0,0 +1,24 @@
+def lookup(items, index, default):
+    if 0 <= index < len(items):
+        return items[index]
+    return default
+
+
+def clamp(value, low, high):
+    if low <= value <= high:
+        return value
+    return low
+
+
+def drain(queue, limit):
+    count = 0
+    while 0 < count < limit:
+        queue.pop()
+        count += 1
+    return count
+
+
+def positive(value):
+    if value > 0:
+        return value
+    return 0
@@ -0,0 +1,9 @@
+from pkg import b
+
+
+def start():
+    return b.finish()
+
+
+def helper():
+    return "a"
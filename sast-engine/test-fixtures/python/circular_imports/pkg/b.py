@@ -0,0 +1,7 @@
+import os
+
+from pkg.a import helper
+
+
+def finish():
+    return helper() + os.sep
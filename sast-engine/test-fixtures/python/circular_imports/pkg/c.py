@@ -0,0 +1,5 @@
+from pkg.a import start
+
+
+def run():
+    return start()
@@ -0,0 +1,18 @@
+from models import User
+
+
+def register(data):
+    user = User.from_dict(data)
+    user.save()
+    return user
+
+
+def welcome():
+    visitor = User.guest()
+    return visitor.display_name()
+
+
+def create():
+    user = User("alice", "alice@example.com")
+    user.save()
+    return user
@@ -0,0 +1,18 @@
+class User:
+    def __init__(self, name, email):
+        self.name = name
+        self.email = email
+
+    @classmethod
+    def from_dict(cls, data):
+        return cls(data["name"], data["email"])
+
+    @classmethod
+    def guest(cls):
+        return cls("guest", "guest@example.com")
+
+    def save(self):
+        return True
+
+    def display_name(self):
+        return self.name.title()
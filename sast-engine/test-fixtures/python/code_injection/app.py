@@ -0,0 +1,42 @@
+"""Flask handlers running or importing request-controlled code."""
+
+import importlib
+import re
+
+from flask import request
+
+
+def eval_expression():
+    user_input = request.args.get("expr")
+    return eval(user_input)
+
+
+def exec_code():
+    code = request.form["code"]
+    exec(code, {"__builtins__": {}})
+
+
+def exec_constant():
+    namespace = dict(request.args)
+    exec("result = 1", namespace)
+    return namespace
+
+
+def compile_source():
+    source = request.form["source"]
+    return compile(source, "<input>", "exec")
+
+
+def load_plugin():
+    name = request.args.get("plugin")
+    return importlib.import_module(name)
+
+
+def load_builtin_import():
+    name = request.args.get("module")
+    return __import__(name)
+
+
+def search():
+    pattern = request.args.get("q")
+    return re.compile(pattern)
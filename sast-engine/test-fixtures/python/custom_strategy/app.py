@@ -0,0 +1,7 @@
+def get_user(request):
+    # rpc_call is injected by the RPC framework at runtime
+    return rpc_call("users.get", request.args["id"])
+
+
+def helper():
+    return eval("1 + 1")
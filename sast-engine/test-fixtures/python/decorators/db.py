@@ -0,0 +1,28 @@
+from contextlib import contextmanager
+
+
+class Session:
+    def query(self, sql):
+        return []
+
+    def close(self):
+        pass
+
+
+@contextmanager
+def open_session():
+    session = Session()
+    try:
+        yield session
+    finally:
+        session.close()
+
+
+def list_users():
+    with open_session() as session:
+        return session.query("SELECT * FROM users")
+
+
+def session_factory():
+    manager = open_session()
+    return manager
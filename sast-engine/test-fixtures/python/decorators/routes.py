@@ -0,0 +1,17 @@
+from flask import Flask, redirect
+
+app = Flask(__name__)
+
+
+@app.route("/go/<path:target>")
+def go(target):
+    return redirect(target)
+
+
+@app.get("/home")
+def home():
+    return redirect("/")
+
+
+def follow(target):
+    return redirect(target)
@@ -0,0 +1,48 @@
+"""Calls evaluated when functions are defined, at import time."""
+
+import functools
+
+
+def make_default():
+    return []
+
+
+def compute_retries():
+    return 3
+
+
+def retry(times):
+    def decorate(func):
+        @functools.wraps(func)
+        def wrapper(*args, **kwargs):
+            return func(*args, **kwargs)
+        return wrapper
+    return decorate
+
+
+def handle(items=make_default()):
+    return items
+
+
+@retry(times=compute_retries())
+def fetch(url):
+    return url
+
+
+class Client:
+    def send(self, payload, timeout=compute_retries()):
+        return payload
+
+    @retry(times=compute_retries())
+    def close(self):
+        return None
+
+
+def configure():
+    def on_error(handler=make_default()):
+        return handler
+    return on_error
+
+
+def make_fallback():
+    return lambda items=make_default(): items
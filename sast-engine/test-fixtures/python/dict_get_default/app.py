@@ -0,0 +1,23 @@
+class LocalBackend:
+    def store(self, key, value):
+        return key, value
+
+
+class MemoryCache:
+    def lookup(self, key):
+        return key
+
+
+def save(config, key, value):
+    backend = config.get("backend", LocalBackend())
+    return backend.store(key, value)
+
+
+def cached(settings, key):
+    cache = getattr(settings, "cache", MemoryCache())
+    return cache.lookup(key)
+
+
+def optional(config, key):
+    backend = config.get("backend", None)
+    return backend.store(key, None)
@@ -0,0 +1,40 @@
+"""Commands dispatched through tables of handler functions."""
+
+from handlers import handle_delete
+
+
+def handle_create(payload):
+    return payload
+
+
+def handle_update(payload):
+    return payload
+
+
+HANDLERS = {
+    "create": handle_create,
+    "update": handle_update,
+    "delete": handle_delete,
+}
+
+
+def dispatch(action, payload):
+    return HANDLERS[action](payload)
+
+
+def dispatch_local(action, payload):
+    handlers = {"create": handle_create, "update": handle_update}
+    return handlers[action](payload)
+
+
+def run_pipeline(payload, step):
+    steps = [handle_create, handle_update]
+    return steps[step](payload)
+
+
+
+CONVERTERS = [int, float]
+
+
+def convert(value, kind):
+    return CONVERTERS[kind](value)
@@ -0,0 +1,5 @@
+"""Handlers defined in another module."""
+
+
+def handle_delete(payload):
+    return None
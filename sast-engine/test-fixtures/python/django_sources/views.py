@@ -0,0 +1,31 @@
+"""Django views reading each untrusted request attribute into a code sink."""
+
+
+def files_view(request):
+    upload = request.FILES["document"]
+    eval(upload)
+
+
+def cookies_view(request):
+    token = request.COOKIES["token"]
+    eval(token)
+
+
+def meta_view(request):
+    host = request.META.get("HTTP_HOST")
+    eval(host)
+
+
+def body_view(request):
+    payload = request.body
+    eval(payload)
+
+
+def session_view(request):
+    expr = request.session["expr"]
+    eval(expr)
+
+
+def safe_view(request):
+    value = "1 + 1"
+    eval(value)
@@ -0,0 +1,34 @@
+from enum import Enum, auto
+
+
+class Color(Enum):
+    RED = 1
+    GREEN = 2
+
+    def describe(self):
+        return self.name.lower()
+
+
+class Status(Enum):
+    ACTIVE = "active"
+    RETIRED = auto()
+
+
+class Settings:
+    MODE = "fast"
+
+
+def paint():
+    color = Color.RED
+    code = Color.RED.value
+    label = Color.GREEN.name
+    status = Status.ACTIVE.value
+    mode = Settings.MODE
+    return color.describe()
+
+
+def lookup():
+    color = Color(1)
+    code = color.value
+    label = color.name
+    return code
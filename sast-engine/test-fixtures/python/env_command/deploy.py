@@ -0,0 +1,23 @@
+import os
+import shlex
+import subprocess
+
+
+def checkout():
+    branch = os.environ["DEPLOY_BRANCH"]
+    subprocess.run("git checkout " + branch, shell=True)
+
+
+def notify():
+    channel = os.getenv("SLACK_CHANNEL")
+    os.system(f"notify --channel {channel}")
+
+
+def checkout_quoted():
+    branch = os.environ.get("DEPLOY_BRANCH")
+    safe_branch = shlex.quote(branch)
+    subprocess.run("git checkout " + safe_branch, shell=True)
+
+
+def status():
+    subprocess.run(["git", "status"])
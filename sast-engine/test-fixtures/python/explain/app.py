@@ -0,0 +1,11 @@
+def run(code):
+    return eval(code)
+
+
+def helper(expression):
+    return run(expression)
+
+
+def calculate():
+    expression = input("expression: ")
+    return helper(expression)
@@ -0,0 +1,16 @@
+class Client:
+    def fetch(self, url):
+        return url
+
+
+class Service:
+    def run(self):
+        return None
+
+    def helper(self):
+        return None
+
+
+def handler(url):
+    client = Client()
+    return client
@@ -0,0 +1,17 @@
+"""Flask views reading a Flask and a Django request attribute into eval."""
+
+from flask import Flask, request
+
+app = Flask(__name__)
+
+
+@app.route("/flask")
+def flask_view():
+    expression = request.args.get("expression")
+    return eval(expression)
+
+
+@app.route("/django")
+def django_view():
+    expression = request.GET["expression"]
+    return eval(expression)
@@ -0,0 +1,36 @@
+class User:
+    def __init__(self, name):
+        self.name = name
+
+    def greet(self):
+        return "hello " + self.name
+
+
+def active_users(names):
+    for name in names:
+        yield User(name)
+
+
+def admins():
+    admin = User("root")
+    yield admin
+
+
+def user_names():
+    return ["alice", "bob"]
+
+
+def greet_active(names):
+    for user in active_users(names):
+        user.greet()
+
+
+def greet_admins():
+    pending = admins()
+    for admin in pending:
+        admin.greet()
+
+
+def shout_names():
+    for name in user_names():
+        name.upper()
@@ -0,0 +1,42 @@
+import os
+
+command = "ls"
+client = None
+
+
+class Client:
+    def send(self, message):
+        return message
+
+
+def configure():
+    global command
+    command = input("command: ")
+
+
+def run():
+    os.system(command)
+
+
+def run_local():
+    command = "whoami"
+    eval(command)
+
+
+def connect():
+    global client
+    client = Client()
+
+
+def notify():
+    client.send("ready")
+
+
+def session():
+    conn = Client()
+
+    def close():
+        nonlocal conn
+        conn.send("bye")
+
+    return close
@@ -0,0 +1,31 @@
+import uuid
+
+from django.http import Http404
+
+
+def paginate(request, cursor):
+    raw = request.GET.get("limit")
+    try:
+        limit = int(raw)
+    except ValueError:
+        return []
+    cursor.execute("SELECT * FROM items LIMIT %d" % limit)
+    cursor.execute("SELECT * FROM items WHERE name = '%s'" % raw)
+
+
+def lookup(request, cursor):
+    raw = request.GET.get("id")
+    try:
+        key = uuid.UUID(raw)
+    except ValueError:
+        raise Http404()
+    cursor.execute("SELECT * FROM items WHERE id = '%s'" % key)
+
+
+def lenient(request, cursor):
+    raw = request.GET.get("limit")
+    try:
+        limit = int(raw)
+    except ValueError:
+        limit = raw
+    cursor.execute("SELECT * FROM items LIMIT %s" % limit)
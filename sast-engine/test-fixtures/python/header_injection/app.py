@@ -0,0 +1,35 @@
+import re
+
+from flask import Flask, make_response, request
+
+app = Flask(__name__)
+
+
+@app.route("/download")
+def download():
+    name = request.args.get("name")
+    response = make_response("data")
+    response.headers["Content-Disposition"] = f"attachment; filename={name}"
+    return response
+
+
+@app.route("/download-stripped")
+def download_stripped():
+    name = re.sub(r"[\r\n]", "", request.args.get("name"))
+    response = make_response("data")
+    response.headers["Content-Disposition"] = f"attachment; filename={name}"
+    return response
+
+
+@app.route("/trace")
+def trace():
+    response = make_response("ok")
+    response.headers.set("X-Trace", request.headers.get("X-Trace"))
+    return response
+
+
+@app.route("/cache")
+def cache():
+    settings = {}
+    settings["mode"] = request.args.get("mode")
+    return settings
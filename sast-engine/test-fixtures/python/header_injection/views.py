@@ -0,0 +1,28 @@
+from django.http import HttpResponse
+
+
+def set_location(request):
+    response = HttpResponse(status=302)
+    response["Location"] = request.GET["next"]
+    return response
+
+
+def set_language(request):
+    lang = request.GET.get("lang", "en")
+    response = HttpResponse("ok")
+    response["Content-Language"] = lang
+    return response
+
+
+def set_language_stripped(request):
+    lang = request.GET.get("lang", "en")
+    lang = lang.replace("\r", "").replace("\n", "")
+    response = HttpResponse("ok")
+    response["Content-Language"] = lang
+    return response
+
+
+def set_static_header(request):
+    response = HttpResponse("ok")
+    response["X-Frame-Options"] = "DENY"
+    return response
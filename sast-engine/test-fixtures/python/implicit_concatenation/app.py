@@ -0,0 +1,33 @@
+import sqlite3
+
+from flask import Flask, request
+
+app = Flask(__name__)
+
+
+@app.route("/accounts")
+def accounts():
+    email = request.args.get("email")
+    cursor = sqlite3.connect("app.db").cursor()
+    cursor.execute("SELECT * FROM accounts " f"WHERE email = '{email}'")
+    return cursor.fetchall()
+
+
+@app.route("/roles")
+def roles():
+    role = request.args.get("role")
+    query = (
+        f"SELECT * FROM users WHERE role = '{role}'"
+        " ORDER BY name"
+    )
+    cursor = sqlite3.connect("app.db").cursor()
+    cursor.execute(query)
+    return cursor.fetchall()
+
+
+@app.route("/teams")
+def teams():
+    team = request.args.get("team")
+    cursor = sqlite3.connect("app.db").cursor()
+    cursor.execute("SELECT * FROM teams " "WHERE name = ?", (team,))
+    return cursor.fetchall()
@@ -0,0 +1,85 @@
+"""Indexing lists and tuples of known length."""
+
+
+def past_end():
+    a = [1, 2]
+    return a[2]
+
+
+def length_index():
+    items = ["x", "y", "z"]
+    print(items[len(items)])
+
+
+def negative_index():
+    pair = (1, 2)
+    return pair[-3]
+
+
+def folded_constants():
+    size = 2
+    grid = [0] * size
+    last = size * 2 - 1
+    return grid[last]
+
+
+def last_element():
+    a = [1, 2]
+    return a[len(a) - 1], a[-2]
+
+
+def dynamic_index(i):
+    a = [1, 2]
+    return a[i]
+
+
+def appended():
+    a = [1, 2]
+    a.append(3)
+    return a[2]
+
+
+def extended(extra):
+    a = [1, 2]
+    a += extra
+    return a[2]
+
+
+def passed(fill):
+    a = [1, 2]
+    fill(a)
+    return a[2]
+
+
+def aliased():
+    a = [1, 2]
+    b = a
+    b.append(3)
+    return a[2]
+
+
+def branch(flag):
+    a = [1, 2]
+    if flag:
+        a = [1, 2, 3]
+    return a[2]
+
+
+def stored():
+    a = [1, 2]
+    a[1:] = [2, 3]
+    return a[2]
+
+
+def closure():
+    def grow():
+        a.append(3)
+
+    a = [1, 2]
+    grow()
+    return a[2]
+
+
+def text_only():
+    a = [1, 2]
+    return "a[2]"
@@ -0,0 +1,33 @@
+from django.http import HttpResponse
+from flask import Flask, make_response
+
+app = Flask(__name__)
+app.config.update(SESSION_COOKIE_SECURE=False, SESSION_COOKIE_HTTPONLY=True)
+
+
+def login(request):
+    response = HttpResponse("ok")
+    response.set_cookie("session", "abc")
+    return response
+
+
+def remember(request):
+    response = HttpResponse("ok")
+    response.set_cookie("remember", "1", secure=False, httponly=True, samesite="Lax")
+    return response
+
+
+def secure_login(request):
+    response = HttpResponse("ok")
+    response.set_cookie("session", "abc", secure=True, httponly=True, samesite="Strict")
+    return response
+
+
+def flask_login():
+    resp = make_response("ok")
+    resp.set_cookie("token", "t", secure=True, httponly=True, samesite=None)
+    return resp
+
+
+def browser_helper(browser):
+    browser.set_cookie("debug", "1")
@@ -0,0 +1,44 @@
+import ssl
+import urllib.request
+
+import httpx
+import requests
+
+VERIFY_CERTS = False
+
+
+def fetch_unverified(url):
+    return requests.get(url, verify=False)
+
+
+def fetch_with_flag(url, payload):
+    verify_ssl = False
+    return requests.post(url, json=payload, verify=verify_ssl)
+
+
+def fetch_with_module_flag(url):
+    return httpx.get(url, verify=VERIFY_CERTS)
+
+
+def fetch_with_session(url):
+    session = requests.Session()
+    return session.get(url, verify=False)
+
+
+def fetch_verified(url):
+    verify_ssl = True
+    return requests.get(url, verify=verify_ssl, timeout=10)
+
+
+def fetch_with_bundle(url):
+    return requests.get(url, verify="/etc/ssl/certs/ca.pem")
+
+
+def open_unverified(url):
+    context = ssl._create_unverified_context()
+    return urllib.request.urlopen(url, context=context)
+
+
+def open_verified(url):
+    context = ssl.create_default_context()
+    return urllib.request.urlopen(url, context=context)
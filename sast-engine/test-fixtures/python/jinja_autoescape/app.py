@@ -0,0 +1,49 @@
+from flask import Flask, request
+from jinja2 import Environment, FileSystemLoader, Template, select_autoescape
+from markupsafe import escape
+
+app = Flask(__name__)
+
+unsafe_env = Environment(loader=FileSystemLoader("templates"), autoescape=False)
+safe_env = Environment(loader=FileSystemLoader("templates"), autoescape=select_autoescape())
+
+
+@app.route("/greet")
+def greet():
+    name = request.args.get("name")
+    template = unsafe_env.get_template("greet.html")
+    return template.render(name=name)
+
+
+@app.route("/inline")
+def inline():
+    bio = request.args.get("bio")
+    env = Environment(autoescape=False)
+    return env.from_string("<p>{{ bio }}</p>").render(bio=bio)
+
+
+@app.route("/safe")
+def safe_greet():
+    name = request.args.get("name")
+    template = safe_env.get_template("greet.html")
+    return template.render(name=name)
+
+
+@app.route("/static")
+def static_page():
+    template = unsafe_env.get_template("about.html")
+    return template.render(title="About")
+
+
+@app.route("/escaped")
+def escaped_greet():
+    name = escape(request.args.get("name"))
+    template = unsafe_env.get_template("greet.html")
+    return template.render(name=name)
+
+
+@app.route("/direct")
+def direct_template():
+    comment = request.form.get("comment")
+    template = Template("<div>{{ comment }}</div>", autoescape=False)
+    return template.render(comment=comment)
@@ -0,0 +1,50 @@
+"""Flask handlers searching an LDAP directory."""
+
+import ldap
+from flask import request
+from ldap.filter import escape_filter_chars
+from ldap3 import Connection, Server
+
+BASE_DN = "ou=people,dc=example,dc=com"
+
+
+def find_user():
+    username = request.args.get("username")
+    conn = ldap.initialize("ldap://directory")
+    return conn.search_s(BASE_DN, ldap.SCOPE_SUBTREE, f"(uid={username})")
+
+
+def find_group():
+    group = request.form["group"]
+    conn = Connection(Server("ldap://directory"))
+    conn.search(BASE_DN, "(cn=" + group + ")")
+    return conn.entries
+
+
+def find_escaped():
+    username = request.args.get("username")
+    conn = ldap.initialize("ldap://directory")
+    return conn.search_s(BASE_DN, ldap.SCOPE_SUBTREE, f"(uid={escape_filter_chars(username)})")
+
+
+def find_escaped_variable():
+    username = request.args.get("username")
+    safe = escape_filter_chars(username)
+    conn = ldap.initialize("ldap://directory")
+    return conn.search_s(BASE_DN, ldap.SCOPE_SUBTREE, "(uid=%s)" % safe)
+
+
+def list_people():
+    conn = ldap.initialize("ldap://directory")
+    return conn.search_s(BASE_DN, ldap.SCOPE_SUBTREE, "(objectClass=person)")
+
+
+def search_index():
+    term = request.args.get("q")
+    index = SearchIndex()
+    return index.search(BASE_DN, 0, term)
+
+
+class SearchIndex:
+    def search(self, base, scope, term):
+        return []
@@ -0,0 +1,54 @@
+import logging
+import re
+
+from flask import current_app, request
+
+logger = logging.getLogger(__name__)
+
+
+def login():
+    username = request.form["username"]
+    logger.info(username)
+    return "ok"
+
+
+def search():
+    query = request.args.get("q")
+    logger.info("search: %s", query)
+    return "ok"
+
+
+def audit():
+    action = request.args["action"]
+    logging.warning("audit " + action)
+    return "ok"
+
+
+def track():
+    page = request.args.get("page")
+    current_app.logger.error(f"missing page {page}")
+    return "ok"
+
+
+def level():
+    message = request.args.get("m")
+    logger.log(logging.INFO, message)
+    return "ok"
+
+
+def stripped():
+    comment = request.form["comment"]
+    clean = comment.replace("\n", "").replace("\r", "")
+    logger.info(clean)
+    return "ok"
+
+
+def substituted():
+    note = request.form["note"]
+    logger.debug(re.sub(r"[\r\n]", "", note))
+    return "ok"
+
+
+def constant():
+    logger.info("health check")
+    return "ok"
@@ -0,0 +1,7 @@
+from django.db import models
+
+
+class Profile(models.Model):
+    name = models.CharField(max_length=100)
+    bio = models.TextField()
+    is_admin = models.BooleanField(default=False)
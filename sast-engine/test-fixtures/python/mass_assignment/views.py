@@ -0,0 +1,45 @@
+from django.http import JsonResponse
+
+from forms import ProfileForm
+from models import Profile
+
+PROFILE_FIELDS = ("name", "bio")
+
+
+def create_profile(request):
+    profile = Profile.objects.create(**request.POST)
+    return JsonResponse({"id": profile.pk})
+
+
+def update_profile(request, pk):
+    data = request.POST.dict()
+    Profile.objects.filter(pk=pk).update(**data)
+    return JsonResponse({"ok": True})
+
+
+def build_profile(request):
+    profile = Profile(**request.POST.dict())
+    profile.save()
+    return JsonResponse({"id": profile.pk})
+
+
+def create_from_allowlist(request):
+    data = {field: request.POST[field] for field in PROFILE_FIELDS}
+    profile = Profile.objects.create(**data)
+    return JsonResponse({"id": profile.pk})
+
+
+def create_from_form(request):
+    form = ProfileForm(request.POST)
+    if form.is_valid():
+        Profile.objects.create(**form.cleaned_data)
+    return JsonResponse({"ok": True})
+
+
+def create_explicit(request):
+    profile = Profile.objects.create(name=request.POST["name"])
+    return JsonResponse({"id": profile.pk})
+
+
+def log_request(request):
+    print(**request.POST)
@@ -0,0 +1,46 @@
+"""Reports with memoized computations."""
+
+import functools
+from functools import cached_property, lru_cache
+
+
+class Owner:
+    def notify(self):
+        pass
+
+
+class Report:
+    def __init__(self, rows):
+        self.rows = rows
+
+    @cached_property
+    def title(self) -> str:
+        return "Report of %d rows" % len(self.rows)
+
+    @functools.cached_property
+    def owner(self):
+        return Owner()
+
+    def render(self):
+        heading = self.title
+        return heading.upper()
+
+
+@lru_cache(maxsize=128)
+def load_report(name):
+    return Report([])
+
+
+@functools.cache
+def default_report():
+    return Report([])
+
+
+def publish():
+    report = load_report("weekly")
+    title = report.title
+    shout = title.upper()
+    owner = report.owner
+    owner.notify()
+    fallback = default_report()
+    return fallback.render()
@@ -0,0 +1,31 @@
+"""WSGI middleware and an application, chained by wrapping."""
+
+
+class Application:
+    def __call__(self, environ, start_response):
+        start_response("200 OK", [])
+        return [b"hello"]
+
+
+class AuthMiddleware:
+    def __init__(self, app):
+        self.app = app
+
+    def __call__(self, environ, start_response):
+        if "HTTP_AUTHORIZATION" not in environ:
+            start_response("401 Unauthorized", [])
+            return [b""]
+        return self.app(environ, start_response)
+
+
+class LoggingMiddleware:
+    def __init__(self, wrapped, prefix="request"):
+        self.wrapped = wrapped
+        self.prefix = prefix
+
+    def __call__(self, environ, start_response):
+        print(self.prefix, environ.get("PATH_INFO"))
+        return self.wrapped(environ, start_response)
+
+
+application = LoggingMiddleware(AuthMiddleware(Application()))
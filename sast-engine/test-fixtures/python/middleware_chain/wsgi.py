@@ -0,0 +1,17 @@
+"""Pipelines built by rebinding a variable, and around a function app."""
+
+from middleware import Application, AuthMiddleware, LoggingMiddleware
+
+
+def health(environ, start_response):
+    start_response("200 OK", [])
+    return [b"ok"]
+
+
+def make_app():
+    handler = Application()
+    handler = LoggingMiddleware(wrapped=handler)
+    return handler
+
+
+health_app = AuthMiddleware(health)
@@ -0,0 +1,2 @@
+def create_invoice(order_id, amount):
+    return {"order": order_id, "amount": amount}
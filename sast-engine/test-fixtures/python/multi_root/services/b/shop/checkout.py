@@ -0,0 +1,5 @@
+from billing.invoice import create_invoice
+
+
+def checkout(order_id, total):
+    return create_invoice(order_id, total)
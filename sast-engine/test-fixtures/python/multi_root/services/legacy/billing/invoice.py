@@ -0,0 +1,2 @@
+def create_invoice(order_id):
+    return order_id
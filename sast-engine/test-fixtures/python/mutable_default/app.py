@@ -0,0 +1,24 @@
+def append_item(item, items=[]):
+    items.append(item)
+    return items
+
+
+def append_item_safely(item, items=None):
+    if items is None:
+        items = []
+    items.append(item)
+    return items
+
+
+def merge(base, overrides: dict = {}, seen=set()):
+    seen.add(id(base))
+    return {**base, **overrides}
+
+
+def label(name, sep="[]", size=(1, 2)):
+    return sep.join([name] * size[0])
+
+
+class Cache:
+    def __init__(self, entries={}):
+        self.entries = entries
@@ -0,0 +1,45 @@
+from flask import Flask, request
+from pymongo import MongoClient
+
+app = Flask(__name__)
+client = MongoClient("mongodb://localhost:27017")
+db = client.shop
+
+
+@app.route("/users/search", methods=["POST"])
+def search_users():
+    users = db.users
+    return list(users.find(request.json))
+
+
+@app.route("/users/login", methods=["POST"])
+def login():
+    collection = MongoClient().shop.users
+    credentials = request.get_json()
+    return collection.find_one(credentials)
+
+
+@app.route("/users/where")
+def where():
+    name = request.args.get("name")
+    return list(db.users.find({"$where": "this.name == '" + name + "'"}))
+
+
+@app.route("/users/<user_id>", methods=["DELETE"])
+def delete_user(user_id):
+    client.shop.users.delete_one({"_id": user_id})
+
+
+@app.route("/orders/shipped")
+def shipped_orders():
+    return list(db.orders.find({"status": "shipped"}))
+
+
+class Cache:
+    def find(self, key):
+        return None
+
+
+def lookup():
+    cache = Cache()
+    return cache.find(request.json)
@@ -0,0 +1,12 @@
+"""Flask handlers redirecting to request-controlled URLs."""
+
+from flask import redirect, request
+
+
+def flask_next():
+    target = request.args.get("next")
+    return redirect(target)
+
+
+def flask_keyword():
+    return redirect(location=request.args["next"])
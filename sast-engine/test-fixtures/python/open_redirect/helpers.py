@@ -0,0 +1,9 @@
+"""Project-local redirect helper that is not a framework redirect."""
+
+
+def redirect(url):
+    return url
+
+
+def log_next(request):
+    return redirect(request.GET["next"])
@@ -0,0 +1,26 @@
+"""Django views redirecting to request-controlled URLs."""
+
+from django.http import HttpResponseRedirect
+from django.shortcuts import redirect
+from django.utils.http import url_has_allowed_host_and_scheme
+
+
+def login_redirect(request):
+    return redirect(request.GET["next"])
+
+
+def logout_redirect(request):
+    next_url = request.GET.get("next")
+    return HttpResponseRedirect(next_url)
+
+
+def safe_login_redirect(request):
+    next_url = request.GET["next"]
+    if url_has_allowed_host_and_scheme(next_url, allowed_hosts={request.get_host()}):
+        return redirect(next_url)
+    return redirect("/")
+
+
+def fixed_redirect(request):
+    permanent = request.GET.get("permanent")
+    return redirect("/home", permanent=permanent)
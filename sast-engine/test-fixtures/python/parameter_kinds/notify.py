@@ -0,0 +1,11 @@
+def send(recipient, /, subject, *, urgent=False):
+    return recipient, subject, urgent
+
+
+def notify_all(users):
+    for user in users:
+        send(user, "Welcome", urgent=True)
+
+
+def notify_admin(admin):
+    send(admin, subject="Alert", urgent=True)
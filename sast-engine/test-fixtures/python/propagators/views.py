@@ -0,0 +1,21 @@
+"""Django views redirecting through helper calls, for propagator configs."""
+
+import copy
+
+from django.shortcuts import redirect
+
+
+def normalize(url):
+    return "/" + url.rsplit("/", 1)[-1]
+
+
+def copied_redirect(request):
+    next_url = request.GET.get("next")
+    target = copy.deepcopy(next_url)
+    return redirect(target)
+
+
+def normalized_redirect(request):
+    next_url = request.GET.get("next")
+    target = normalize(next_url)
+    return redirect(target)
@@ -0,0 +1,30 @@
+from typing import Protocol
+
+
+class Drawable(Protocol):
+    def draw(self) -> None:
+        ...
+
+    def area(self) -> float:
+        ...
+
+
+class Circle:
+    def draw(self) -> None:
+        print("circle")
+
+    def area(self) -> float:
+        return 3.14
+
+
+class Square:
+    def draw(self) -> None:
+        print("square")
+
+
+def render(shape: Drawable) -> None:
+    shape.draw()
+
+
+def measure(shape: "Drawable") -> float:
+    return shape.area()
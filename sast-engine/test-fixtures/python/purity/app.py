@@ -0,0 +1,57 @@
+import os
+
+counter = 0
+
+
+def add(a, b):
+    return a + b
+
+
+def area(width, height):
+    return add(width, height) * 2
+
+
+def read_config(path):
+    with open(path) as f:
+        return f.read()
+
+
+def load_settings():
+    return read_config("settings.ini")
+
+
+def remove_file(path):
+    os.remove(path)
+
+
+def increment():
+    global counter
+    counter += 1
+    return counter
+
+
+def reset(flag):
+    global counter
+    if flag:
+        counter = 0
+
+
+def uses_global_read_only():
+    global counter
+    return counter + 1
+
+
+def factorial(n):
+    if n <= 1:
+        return 1
+    return n * factorial(n - 1)
+
+
+def ping(n):
+    if n > 0:
+        return pong(n - 1)
+    return read_config("done.txt")
+
+
+def pong(n):
+    return ping(n)
@@ -0,0 +1,13 @@
+from fastapi import FastAPI
+
+api = FastAPI()
+
+
+@api.get("/y")
+async def read_items():
+    return []
+
+
+@api.api_route("/z", methods=["GET", "PUT"])
+async def items():
+    return []
@@ -0,0 +1,17 @@
+from flask import Flask, request
+
+app = Flask(__name__)
+
+
+@app.route("/x", methods=["POST"])
+def create_item():
+    return request.get_json()
+
+
+@app.route("/health")
+def health():
+    return "ok"
+
+
+def helper():
+    return None
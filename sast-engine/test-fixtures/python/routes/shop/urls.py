@@ -0,0 +1,8 @@
+from django.urls import path, re_path
+
+from shop import views
+
+urlpatterns = [
+    path("orders/<int:order_id>/", views.order_detail, name="order-detail"),
+    re_path(r"^$", views.index),
+]
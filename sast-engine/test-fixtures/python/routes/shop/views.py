@@ -0,0 +1,9 @@
+from django.http import HttpResponse
+
+
+def order_detail(request, order_id):
+    return HttpResponse(str(order_id))
+
+
+def index(request):
+    return HttpResponse("shop")
@@ -0,0 +1,11 @@
+from formatting import render
+
+
+class Money:
+    def __init__(self, cents):
+        self.cents = cents
+
+
+@render.register
+def _render_money(value: Money):
+    return f"${value.cents / 100:.2f}"
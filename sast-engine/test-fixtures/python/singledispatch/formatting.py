@@ -0,0 +1,16 @@
+from functools import singledispatch
+
+
+@singledispatch
+def render(value):
+    return str(value)
+
+
+@render.register
+def _render_int(value: int):
+    return f"{value:,}"
+
+
+@render.register(list)
+def _render_list(value):
+    return ", ".join(render(item) for item in value)
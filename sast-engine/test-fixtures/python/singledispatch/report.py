@@ -0,0 +1,8 @@
+from currency import Money
+from formatting import render
+
+
+def summary(name):
+    total = 1200
+    price = Money(499)
+    return [render(total), render([1, 2]), render(price), render(name)]
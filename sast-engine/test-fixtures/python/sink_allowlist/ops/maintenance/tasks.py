@@ -0,0 +1,10 @@
+"""Operator maintenance task, run on purpose with a raw shell command."""
+
+import os
+
+from flask import request
+
+
+def rotate_logs():
+    path = request.args.get("path")
+    os.system("logrotate " + path)
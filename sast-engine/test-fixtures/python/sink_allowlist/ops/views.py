@@ -0,0 +1,10 @@
+"""Flask handler restarting a service named in the request."""
+
+import os
+
+from flask import request
+
+
+def restart():
+    service = request.args.get("service")
+    os.system("systemctl restart " + service)
@@ -0,0 +1,59 @@
+import sqlite3
+
+from flask import Flask, request
+
+app = Flask(__name__)
+
+
+@app.route("/users")
+def search():
+    name = request.args.get("name")
+    cursor = sqlite3.connect("app.db").cursor()
+    cursor.execute(f"SELECT * FROM users WHERE name = '{name}'")
+    return cursor.fetchall()
+
+
+@app.route("/orders")
+def orders():
+    status = request.args.get("status")
+    limit = request.args.get("limit")
+    conn = sqlite3.connect("app.db")
+    conn.execute(f"SELECT * FROM orders WHERE status = \"{status}\" LIMIT {limit!s}")
+    return "ok"
+
+
+@app.route("/report")
+def report():
+    year = request.args.get("year")
+    query = "SELECT * FROM sales WHERE year = " + year
+    cursor = sqlite3.connect("app.db").cursor()
+    cursor.execute(query)
+    return cursor.fetchall()
+
+
+@app.route("/safe")
+def safe():
+    name = request.args.get("name")
+    cursor = sqlite3.connect("app.db").cursor()
+    cursor.execute("SELECT * FROM users WHERE name = ?", (name,))
+    return cursor.fetchall()
+
+
+def constant():
+    table = "users"
+    cursor = sqlite3.connect("app.db").cursor()
+    cursor.execute(f"SELECT count(*) FROM {table}")
+
+
+@app.route("/tags")
+def tags():
+    term = request.args.get("term")
+    cursor = sqlite3.connect("app.db").cursor()
+    cursor.execute(params=None, sql="SELECT * FROM tags WHERE term = '" + term + "'")
+    return cursor.fetchall()
+
+
+@app.route("/people")
+def people():
+    city = request.args.get("city")
+    return Person.objects.raw(raw_query=f"SELECT * FROM people WHERE city = '{city}'")
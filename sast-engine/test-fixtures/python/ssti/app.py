@@ -0,0 +1,26 @@
+from flask import request, render_template_string
+from jinja2 import Environment, Template
+
+
+def preview():
+    user_input = request.args.get("template")
+    return render_template_string(user_input)
+
+
+def greet():
+    name = request.args.get("name")
+    return render_template_string("<p>Hello {{ name }}</p>", name=name)
+
+
+def banner():
+    title = request.form["title"]
+    return Template("<h1>" + title + "</h1>").render()
+
+
+def compile_keyword():
+    env = Environment()
+    return env.from_string(source=request.args["t"]).render()
+
+
+def static_page():
+    return Template("<p>{{ message }}</p>").render(message=request.args["m"])
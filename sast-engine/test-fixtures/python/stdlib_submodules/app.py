@@ -0,0 +1,20 @@
+import os
+import os.path
+import urllib
+from os import path
+
+
+def build_path(base, name):
+    return os.path.join(base, name)
+
+
+def exists(name):
+    return path.exists(name)
+
+
+def quote(value):
+    return urllib.parse.quote(value)
+
+
+def unknown(value):
+    return os.missing.join(value)
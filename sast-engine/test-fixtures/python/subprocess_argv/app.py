@@ -0,0 +1,37 @@
+"""Flask handlers passing request data to subprocesses."""
+
+import subprocess
+
+from flask import request
+
+
+def run_tool():
+    tool = request.args.get("tool")
+    subprocess.run([tool, "--version"])
+
+
+def launch():
+    program = request.args.get("program")
+    subprocess.Popen(program)
+
+
+def convert():
+    name = request.args.get("name")
+    subprocess.run(["convert", name, "out.png"])
+
+
+def archive():
+    target = request.args.get("target")
+    argv = ["tar", "-czf", "backup.tgz", target]
+    subprocess.check_call(argv)
+
+
+def ping():
+    host = request.args.get("host")
+    subprocess.run("ping -c 1 " + host, shell=True)
+
+
+def version():
+    flags = request.args.getlist("flags")
+    subprocess.run(["git", "--version"])
+    return flags
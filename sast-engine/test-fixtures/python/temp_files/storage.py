@@ -0,0 +1,54 @@
+import os
+import tempfile
+
+from flask import request
+
+REPORT_PATH = "/tmp/report.csv"
+
+
+def mktemp_report(rows):
+    path = tempfile.mktemp(suffix=".csv")
+    with open(path, "w") as handle:
+        handle.writelines(rows)
+
+
+def concat_upload(name, data):
+    with open("/tmp/" + name, "wb") as handle:
+        handle.write(data)
+
+
+def fstring_cache(key, value):
+    with open(f"/var/tmp/cache-{key}", mode="w") as handle:
+        handle.write(value)
+
+
+def module_path_report(rows):
+    with open(REPORT_PATH, "a") as handle:
+        handle.writelines(rows)
+
+
+def joined_upload():
+    name = request.args.get("name")
+    fd = os.open(os.path.join("/tmp", name), os.O_WRONLY | os.O_CREAT)
+    os.close(fd)
+
+
+def mkstemp_report(rows):
+    fd, path = tempfile.mkstemp(suffix=".csv")
+    with os.fdopen(fd, "w") as handle:
+        handle.writelines(rows)
+
+
+def named_temp_report(rows):
+    with tempfile.NamedTemporaryFile("w", delete=False) as handle:
+        handle.writelines(rows)
+
+
+def read_tmp_config():
+    with open("/tmp/app.cfg") as handle:
+        return handle.read()
+
+
+def exclusive_lock():
+    fd = os.open("/tmp/app.lock", os.O_WRONLY | os.O_CREAT | os.O_EXCL)
+    os.close(fd)
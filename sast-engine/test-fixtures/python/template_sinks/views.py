@@ -0,0 +1,35 @@
+from flask import request
+from mako.template import Template
+
+GREETING = Template("<p>Hello ${name}</p>")
+
+
+def greet():
+    name = request.args.get("name")
+    page = Template("<p>Hello ${name}</p>")
+    return page.render(name=name)
+
+
+def greet_unicode():
+    name = request.args.get("name")
+    page = Template("<p>Hello ${name}</p>")
+    return page.render_unicode(name=name)
+
+
+def compile_user_template():
+    source = request.form["template"]
+    return Template(source)
+
+
+def static_greeting():
+    page = Template("<p>Hello ${name}</p>")
+    return page.render(name="world")
+
+
+def render(name):
+    return "<p>" + name + "</p>"
+
+
+def local_render():
+    name = request.args.get("name")
+    return render(name)
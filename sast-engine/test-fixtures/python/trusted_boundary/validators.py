@@ -0,0 +1,10 @@
+"""The project's validation layer: values returned here are clean."""
+
+
+def clean_hostname(value):
+    return value.strip().lower()
+
+
+def clean_path(value):
+    stripped = value.strip()
+    return stripped.replace("..", "")
@@ -0,0 +1,25 @@
+import os
+
+from validators import clean_hostname, clean_path
+
+
+def ping():
+    host = input()
+    checked = clean_hostname(host)
+    os.system("ping -c 1 " + checked)
+
+
+def show():
+    name = input()
+    path = clean_path(name)
+    os.system("cat " + path)
+
+
+def relay(value):
+    return value
+
+
+def unchecked():
+    host = input()
+    forwarded = relay(host)
+    eval(forwarded)
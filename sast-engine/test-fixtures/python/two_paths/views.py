@@ -0,0 +1,16 @@
+"""A request value reaching eval() both directly and through a helper."""
+
+
+def handle(request):
+    expression = request.GET.get("expr")
+    if expression.startswith("="):
+        return helper(expression)
+    return run(expression)
+
+
+def helper(expression):
+    return run(expression)
+
+
+def run(code):
+    return eval(code)
@@ -0,0 +1,21 @@
+from models import Account, Ledger
+
+system_account = Account("system")
+
+
+def make_account(owner):
+    return Account(owner)
+
+
+def open_account(owner):
+    account = Account(owner)
+    ledger = Ledger()
+    ledger.record(account)
+    return account
+
+
+def transfer(owner):
+    target = make_account(owner)
+    current = Account(owner)
+    current = Ledger()
+    return target, current
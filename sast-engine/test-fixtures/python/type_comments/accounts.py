@@ -0,0 +1,44 @@
+class User:
+    def __init__(self, name):
+        self.name = name
+
+    def greet(self):
+        return "hello " + self.name
+
+
+class Repository:
+    def __init__(self, rows):
+        self.rows = rows
+
+    def find(self, name):
+        # type: (str) -> User
+        return self.rows.get(name)
+
+
+def load(row):
+    return row.build()
+
+
+def show(row):
+    user = load(row)  # type: User
+    user.greet()
+
+
+def welcome(repo, name):
+    # type: (Repository, str) -> None
+    user = repo.find(name)
+    user.greet()
+
+
+def first(users):  # type: (list) -> User
+    return users[0]
+
+
+def banner(users):
+    user = first(users)
+    user.greet()
+
+
+def skipped(row):
+    user = load(row)  # type: ignore
+    user.greet()
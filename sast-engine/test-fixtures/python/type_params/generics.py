@@ -0,0 +1,40 @@
+from collections.abc import Callable
+
+
+class User:
+    def name(self):
+        return "user"
+
+
+type UserList = list[User]
+type Pair[K, V] = tuple[K, V]
+
+
+def first[T](items: list[T], default: T | None = None) -> T:
+    value = items[0]
+    return value
+
+
+def apply[**P, R](func: Callable[P, R], *args: P.args, **kwargs: P.kwargs) -> R:
+    result = func(*args, **kwargs)
+    return result
+
+
+def load_user[T: User](user: T) -> T:
+    label = user.name()
+    return user
+
+
+class Box[T]:
+    def __init__(self, item: T):
+        self.item = item
+
+    def get[U](self, fallback: U) -> T | U:
+        return self.item
+
+
+def pairs[K, V](keys: list[K], values: list[V], limit: int = 10):
+    type Pair = tuple[K, V]
+    type Table[R] = dict[R, list[Pair]]
+    zipped = zip(keys, values)
+    return zipped
@@ -0,0 +1,24 @@
+"""Django views and helpers with annotated signatures."""
+
+from django.http import HttpRequest, HttpResponse, JsonResponse
+
+
+def profile(request: HttpRequest, user_id: int) -> HttpResponse:
+    return HttpResponse(str(user_id))
+
+
+def search(request: "HttpRequest") -> JsonResponse:
+    return JsonResponse({"q": request.GET.get("q")})
+
+
+def health(request) -> HttpResponse:
+    return HttpResponse("ok")
+
+
+def parse_limit(raw: str, default: int = 10) -> int:
+    return int(raw) if raw else default
+
+
+class ReportView:
+    def get(self, request: HttpRequest) -> HttpResponse:
+        return HttpResponse("report")
@@ -0,0 +1,13 @@
+from vendor_lib import render, paginate
+
+
+def load(value, conn):
+    title = value.text.strip()
+    rows = conn.pool.cursor().fetchall()
+    pages = paginate(rows)
+    return render(pages, title)
+
+
+def export(value, session):
+    session.pool.conn.flush()
+    return value.text.encode()
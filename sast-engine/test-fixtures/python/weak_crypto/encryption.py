@@ -0,0 +1,21 @@
+from Crypto.Cipher import AES, DES
+from cryptography.hazmat.primitives.ciphers import Cipher, algorithms, modes
+
+
+def encrypt_des(key, data):
+    cipher = DES.new(key, DES.MODE_CBC)
+    return cipher.encrypt(data)
+
+
+def encrypt_ecb(key, data):
+    cipher = AES.new(key, AES.MODE_ECB)
+    return cipher.encrypt(data)
+
+
+def encrypt_gcm(key, data):
+    cipher = AES.new(key, AES.MODE_GCM)
+    return cipher.encrypt(data)
+
+
+def encrypt_hazmat_ecb(key):
+    return Cipher(algorithms.AES(key), modes.ECB())
@@ -0,0 +1,32 @@
+import hashlib
+from hashlib import sha1
+
+
+def hash_password(password):
+    return hashlib.md5(password.encode()).hexdigest()
+
+
+def sign_token(payload):
+    signature = sha1(payload).hexdigest()
+    return signature
+
+
+def named_hash(data):
+    digest = hashlib.new("md5", data)
+    return digest.hexdigest()
+
+
+def strong_hash(password):
+    return hashlib.sha256(password.encode()).hexdigest()
+
+
+def file_checksum(data):
+    return hashlib.md5(data, usedforsecurity=False).hexdigest()
+
+
+def etag(data):
+    return hashlib.md5(data).hexdigest()  # nosec: HTTP cache validator
+
+
+def cache_digest(data):
+    return hashlib.sha1(data).hexdigest()
@@ -0,0 +1,22 @@
+import random
+import secrets
+import string
+
+
+def generate_reset_token():
+    token = "".join(random.choice(string.ascii_letters) for _ in range(32))
+    return token
+
+
+def make_session_id():
+    return random.getrandbits(128)
+
+
+def shuffle_deck(cards):
+    index = random.randint(0, len(cards) - 1)
+    return cards[index]
+
+
+def secure_token():
+    token = secrets.token_hex(32)
+    return token
@@ -0,0 +1,26 @@
+"""Flask handlers querying an XML catalog with lxml."""
+
+from flask import request
+from lxml import etree
+
+CATALOG = etree.parse("catalog.xml")
+
+
+def find_book():
+    title = request.args.get("title")
+    return CATALOG.xpath("//book[title='" + title + "']")
+
+
+def compile_query():
+    author = request.args["author"]
+    query = etree.XPath(f"//book[author='{author}']")
+    return query(CATALOG)
+
+
+def find_book_safely():
+    title = request.args.get("title")
+    return CATALOG.xpath("//book[title=$title]", title=title)
+
+
+def all_books():
+    return CATALOG.xpath("//book")